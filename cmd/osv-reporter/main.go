@@ -88,6 +88,10 @@ func run(args []string, stdout, stderr io.Writer) int {
 				Usage:       "whether to return 1 when vulnerabilities are found",
 				DefaultText: "true",
 			},
+			&cli.BoolFlag{
+				Name:  "fail-on-changed-packages",
+				Usage: "report all vulnerabilities in the new results, but only fail on those in packages that have been added or changed compared to the old results",
+			},
 			&cli.BoolFlag{
 				Name:  "all-vulns",
 				Usage: "show all vulnerabilities including unimportant and uncalled ones",
@@ -143,6 +147,15 @@ func run(args []string, stdout, stderr io.Writer) int {
 				diffVulns = ci.DiffVulnerabilityResults(oldVulns, newVulns)
 			}
 
+			// failVulns are the vulnerabilities that cause the action to fail
+			failVulns := diffVulns
+
+			if cmd.Bool("fail-on-changed-packages") {
+				// report the full picture, but only fail on packages this change is responsible for
+				failVulns = ci.FilterChangedPackages(oldVulns, newVulns)
+				diffVulns = newVulns
+			}
+
 			showAllVulns := cmd.Bool("all-vulns")
 
 			stdoutTaken := false
@@ -176,20 +189,20 @@ func run(args []string, stdout, stderr io.Writer) int {
 					}
 					termWidth = 0
 
-					if errPrint := reporter.PrintResult(&diffVulns, format, writer, termWidth, showAllVulns); errPrint != nil {
+					if errPrint := reporter.PrintResult(&diffVulns, format, writer, reporter.Options{TerminalWidth: termWidth, ShowAllVulns: showAllVulns}); errPrint != nil {
 						return fmt.Errorf("failed to write output: %w", errPrint)
 					}
 				}
 			}
 
 			if !stdoutTaken {
-				if errPrint := reporter.PrintResult(&diffVulns, "table", stdout, termWidth, showAllVulns); errPrint != nil {
+				if errPrint := reporter.PrintResult(&diffVulns, "table", stdout, reporter.Options{TerminalWidth: termWidth, ShowAllVulns: showAllVulns}); errPrint != nil {
 					return fmt.Errorf("failed to write output: %w", errPrint)
 				}
 			}
 
 			if cmd.Bool("gh-annotations") {
-				if errPrint := reporter.PrintResult(&diffVulns, "gh-annotations", stderr, termWidth, showAllVulns); errPrint != nil {
+				if errPrint := reporter.PrintResult(&diffVulns, "gh-annotations", stderr, reporter.Options{TerminalWidth: termWidth, ShowAllVulns: showAllVulns}); errPrint != nil {
 					return fmt.Errorf("failed to write output: %w", errPrint)
 				}
 			}
@@ -199,7 +212,7 @@ func run(args []string, stdout, stderr io.Writer) int {
 
 			// Check if any is *not* called
 			anyIsCalled := false
-			for _, vuln := range diffVulns.Flatten() {
+			for _, vuln := range failVulns.Flatten() {
 				if vuln.GroupInfo.IsCalled() {
 					anyIsCalled = true
 					break
@@ -207,7 +220,7 @@ func run(args []string, stdout, stderr io.Writer) int {
 			}
 
 			// if vulnerability exists it should return error
-			if len(diffVulns.Results) > 0 && failOnVuln && anyIsCalled {
+			if len(failVulns.Results) > 0 && failOnVuln && anyIsCalled {
 				return osvscanner.ErrVulnerabilitiesFound
 			}
 
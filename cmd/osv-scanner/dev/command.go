@@ -0,0 +1,19 @@
+package dev
+
+import (
+	"io"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/dev/genfixture"
+	"github.com/urfave/cli/v3"
+)
+
+func Command(stdout, stderr io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "dev",
+		Usage:       "tools for developing osv-scanner, such as generating test fixtures",
+		Description: "tools for developing osv-scanner, such as generating test fixtures",
+		Commands: []*cli.Command{
+			genfixture.Command(stdout, stderr),
+		},
+	}
+}
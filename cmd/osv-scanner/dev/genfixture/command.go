@@ -0,0 +1,141 @@
+package genfixture
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/builders"
+	"github.com/google/osv-scanner/v2/internal/fixturegen"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract"
+	"github.com/urfave/cli/v3"
+)
+
+func Command(stdout, _ io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "gen-fixture",
+		Usage:       "generates an anonymized, minimized test fixture from a lockfile",
+		Description: "anonymizes a lockfile and removes the lines that do not change what is extracted from it, then writes it alongside golden JSON of the packages that are expected to be extracted from it",
+		ArgsUsage:   "[<parse-as>:]<lockfile>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:      "output-dir",
+				Aliases:   []string{"o"},
+				Usage:     "directory to write the fixture and its golden JSON to",
+				Value:     ".",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "file name of the fixture; defaults to the name of the lockfile",
+			},
+			&cli.StringSliceFlag{
+				Name:  "package",
+				Usage: "name of a package that the fixture must keep, such as one that is extracted incorrectly; can be repeated, and defaults to every package",
+			},
+			&cli.StringSliceFlag{
+				Name:  "replace",
+				Usage: "additional string to anonymize, in the format `old=new`, such as the name of a private package or registry; can be repeated",
+			},
+			&cli.BoolFlag{
+				Name:  "no-minimize",
+				Usage: "only anonymize the lockfile, without removing any lines from it",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return action(ctx, cmd, stdout)
+		},
+	}
+}
+
+func parseReplacements(values []string) (map[string]string, error) {
+	replacements := make(map[string]string, len(values))
+
+	for _, value := range values {
+		old, replacement, ok := strings.Cut(value, "=")
+		if !ok || old == "" {
+			return nil, fmt.Errorf("invalid --replace %q - must be in the format old=new", value)
+		}
+
+		replacements[old] = replacement
+	}
+
+	return replacements, nil
+}
+
+func action(ctx context.Context, cmd *cli.Command, stdout io.Writer) error {
+	if cmd.Args().Len() != 1 {
+		return errors.New("expected exactly one lockfile to generate a fixture from")
+	}
+
+	replacements, err := parseReplacements(cmd.StringSlice("replace"))
+	if err != nil {
+		return err
+	}
+
+	// like --lockfile, lockfiles can be prefixed with the name of the file to parse them as
+	parseAs, lockfile, ok := strings.Cut(cmd.Args().First(), ":")
+	if !ok {
+		parseAs, lockfile = "", parseAs
+	}
+
+	fixture, err := fixturegen.Generate(ctx, lockfile, fixturegen.Options{
+		Extractors:   builders.BuildExtractors(scalibrextract.ExtractorsLockfiles),
+		ParseAs:      parseAs,
+		Packages:     cmd.StringSlice("package"),
+		Replacements: replacements,
+		Minimize:     !cmd.Bool("no-minimize"),
+	})
+	if err != nil {
+		return err
+	}
+
+	golden, err := json.MarshalIndent(fixture.Golden, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := cmd.String("name")
+	if name == "" {
+		name = filepath.Base(lockfile)
+	}
+
+	fixturePath := filepath.Join(cmd.String("output-dir"), name)
+	goldenPath := fixturePath + ".golden.json"
+
+	if err := os.MkdirAll(cmd.String("output-dir"), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(fixturePath, fixture.Content, 0o644); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(goldenPath, append(golden, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(
+		stdout,
+		"Wrote %s with %d of the %d lines of %s, and %s with %d expected %s\n",
+		fixturePath,
+		fixture.Lines,
+		fixture.OriginalLines,
+		lockfile,
+		goldenPath,
+		len(fixture.Golden.Packages),
+		output.Form(len(fixture.Golden.Packages), "package", "packages"),
+	)
+
+	if fixture.Golden.Error != "" {
+		fmt.Fprintf(stdout, "The fixture is expected to fail to be extracted with: %s\n", fixture.Golden.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package diff
+
+import (
+	"io"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/diff/image"
+	"github.com/urfave/cli/v3"
+)
+
+func Command(stdout, stderr io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "diff",
+		Usage:       "compares the dependencies and vulnerabilities of two versions of a target.",
+		Description: "compares the dependencies and vulnerabilities of two versions of a target.",
+		Commands: []*cli.Command{
+			image.Command(stdout, stderr),
+		},
+	}
+}
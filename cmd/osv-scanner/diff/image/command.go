@@ -0,0 +1,206 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/helper"
+	"github.com/google/osv-scanner/v2/internal/imagediff"
+	"github.com/google/osv-scanner/v2/internal/layercache"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+	"github.com/urfave/cli/v3"
+)
+
+// maxCommandLength is the longest a layer command can be before it is truncated in the table output
+const maxCommandLength = 80
+
+func Command(stdout, _ io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "image",
+		Usage:       "compares the packages and vulnerabilities of two container images",
+		Description: "scans two container images and reports the packages and vulnerabilities that have been added, removed, or updated, along with the layers they are in",
+		ArgsUsage:   "<old image> <new image>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "archive",
+				Usage: "input local archive images (e.g. tar files)",
+			},
+			&cli.StringFlag{
+				Name:  "layer-cache-dir",
+				Usage: "directory to cache the packages extracted from each layer of the images in, so that layers shared with previously scanned images are not extracted again",
+			},
+			&cli.StringSliceFlag{
+				Name:      "config",
+				Usage:     "set/override config file; can be repeated to merge multiple config files, with later files taking precedence",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:  "profile",
+				Usage: "name of the profile to use from the config files, which is merged on top of the rest of each config file that defines it",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "sets the output format; value can be: table, json",
+				Value: "table",
+				Action: func(_ context.Context, _ *cli.Command, s string) error {
+					if s != "table" && s != "json" {
+						return fmt.Errorf("unsupported output format \"%s\" - must be one of: table, json", s)
+					}
+
+					return nil
+				},
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return action(ctx, cmd, stdout)
+		},
+	}
+}
+
+func action(ctx context.Context, cmd *cli.Command, stdout io.Writer) error {
+	if cmd.Args().Len() != 2 {
+		return errors.New("expected exactly two images to compare")
+	}
+
+	oldImage, newImage := cmd.Args().Get(0), cmd.Args().Get(1)
+
+	if !cmd.Bool("archive") {
+		for _, img := range []string{oldImage, newImage} {
+			if !strings.Contains(img, ":") {
+				return fmt.Errorf("%q is not a tagged image name", img)
+			}
+		}
+	}
+
+	// the layers that the images share are only extracted once
+	layerCache := osvscanner.NewLayerCache(cmd.String("layer-cache-dir"))
+
+	oldResults, err := scanImage(ctx, cmd, oldImage, layerCache)
+	if err != nil {
+		return err
+	}
+
+	newResults, err := scanImage(ctx, cmd, newImage, layerCache)
+	if err != nil {
+		return err
+	}
+
+	result := imagediff.Diff(oldImage, newImage, oldResults, newResults)
+
+	if cmd.String("format") == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		printTable(stdout, result)
+	}
+
+	if result.AddedVulns() > 0 {
+		return imagediff.ErrVulnerabilitiesAdded
+	}
+
+	return nil
+}
+
+// scanImage scans all the packages of the given image, regardless of whether
+// they have any vulnerabilities, so that they can be compared
+func scanImage(ctx context.Context, cmd *cli.Command, img string, layerCache *layercache.Cache) (models.VulnerabilityResults, error) {
+	results, err := osvscanner.DoContainerScanContext(ctx, osvscanner.ScannerActions{
+		Image:               img,
+		IsImageArchive:      cmd.Bool("archive"),
+		LayerCache:          layerCache,
+		ConfigOverridePaths: cmd.StringSlice("config"),
+		ConfigProfile:       cmd.String("profile"),
+		ShowAllPackages:     true,
+		ExperimentalScannerActions: osvscanner.ExperimentalScannerActions{
+			Extractors: helper.ResolveEnabledExtractors([]string{"artifact"}, nil),
+		},
+	})
+
+	// an image without any packages can still be compared to
+	if err != nil && !osvscanner.IsFindingErr(err) && !errors.Is(err, osvscanner.ErrNoPackagesFound) {
+		return models.VulnerabilityResults{}, fmt.Errorf("failed to scan %s: %w", img, err)
+	}
+
+	return results, nil
+}
+
+func printTable(w io.Writer, result imagediff.Result) {
+	fmt.Fprintf(
+		w,
+		"Compared %s to %s: %d %s shared, %d removed, %d added\n",
+		result.OldImage,
+		result.NewImage,
+		result.SharedLayers,
+		output.Form(result.SharedLayers, "layer", "layers"),
+		len(result.RemovedLayers),
+		len(result.AddedLayers),
+	)
+
+	printLayers(w, "Removed layers", result.RemovedLayers)
+	printLayers(w, "Added layers", result.AddedLayers)
+
+	fmt.Fprintln(w)
+
+	if len(result.Packages) == 0 {
+		fmt.Fprintln(w, "No package changes found")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHANGE\tECOSYSTEM\tPACKAGE\tOLD VERSION\tNEW VERSION\tLAYER\tADDED VULNERABILITIES\tREMOVED VULNERABILITIES")
+	for _, pkg := range result.Packages {
+		layer := ""
+		if pkg.Layer != nil {
+			layer = fmt.Sprintf("#%d", pkg.Layer.Index)
+		}
+
+		fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			pkg.Kind,
+			pkg.Ecosystem,
+			pkg.Name,
+			pkg.OldVersion,
+			pkg.NewVersion,
+			layer,
+			strings.Join(pkg.AddedVulns, "; "),
+			strings.Join(pkg.RemovedVulns, "; "),
+		)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(
+		w,
+		"\n%d %s added, %d removed\n",
+		result.AddedVulns(),
+		output.Form(result.AddedVulns(), "vulnerability", "vulnerabilities"),
+		result.RemovedVulns(),
+	)
+}
+
+func printLayers(w io.Writer, title string, layers []imagediff.Layer) {
+	if len(layers) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, layer := range layers {
+		command := layer.Command
+		if len(command) > maxCommandLength {
+			command = command[:maxCommandLength-3] + "..."
+		}
+
+		fmt.Fprintf(w, "  #%d  %s\n", layer.Index, command)
+	}
+}
@@ -0,0 +1,42 @@
+package image_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/testcmd"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []testcmd.Case{
+		{
+			Name: "no_images",
+			Args: []string{"", "diff", "image"},
+			Exit: 127,
+		},
+		{
+			Name: "one_image",
+			Args: []string{"", "diff", "image", "alpine:3.19"},
+			Exit: 127,
+		},
+		{
+			Name: "untagged_image",
+			Args: []string{"", "diff", "image", "alpine:3.19", "alpine"},
+			Exit: 127,
+		},
+		{
+			Name: "unsupported_format",
+			Args: []string{"", "diff", "image", "--format", "sarif", "alpine:3.19", "alpine:3.20"},
+			Exit: 127,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			testcmd.RunAndMatchSnapshots(t, tt)
+		})
+	}
+}
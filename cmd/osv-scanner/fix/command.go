@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,6 +16,7 @@ import (
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 	"github.com/google/osv-scanner/v2/internal/depsdev"
 	"github.com/google/osv-scanner/v2/internal/imodels/ecosystem"
+	"github.com/google/osv-scanner/v2/internal/pullrequest"
 	"github.com/google/osv-scanner/v2/internal/remediation"
 	"github.com/google/osv-scanner/v2/internal/remediation/upgrade"
 	"github.com/google/osv-scanner/v2/internal/resolution"
@@ -27,7 +27,6 @@ import (
 	"github.com/google/osv-scanner/v2/internal/version"
 	"github.com/urfave/cli/v3"
 	"golang.org/x/term"
-	"osv.dev/bindings/go/osvdev"
 )
 
 type strategy string
@@ -44,6 +43,7 @@ const (
 	vulnCategory     = "Vulnerability Selection Options:"
 	upgradeCategory  = "Dependency Upgrade Options:"
 	autoModeCategory = "non-interactive options:" // intentionally lowercase to force it to sort after the other categories
+	prCategory       = "pull request options:"
 )
 
 type osvFixOptions struct {
@@ -56,6 +56,11 @@ type osvFixOptions struct {
 	LockfileRW  lockfile.ReadWriter
 	NoIntroduce bool
 	OutputJSON  bool
+	// PlanPath and SummaryPath are where to write the patch plan and the Markdown summary of it, if set
+	PlanPath    string
+	SummaryPath string
+	// PullRequest configures the pull request to open with the changes, if one should be opened
+	PullRequest *pullrequest.Options
 	Stdout      io.Writer
 	Stderr      io.Writer
 }
@@ -94,6 +99,14 @@ func Command(stdout, stderr io.Writer) *cli.Command {
 				Name:  "maven-registry",
 				Usage: "URL of the default Maven registry to fetch metadata",
 			},
+			&cli.StringFlag{
+				Name:  "npm-registry",
+				Usage: "URL of the default npm registry to fetch metadata, instead of the one configured in .npmrc",
+			},
+			&cli.StringFlag{
+				Name:  "pypi-registry",
+				Usage: "URL of the JSON API of the Python package index to fetch metadata, instead of PyPI",
+			},
 			&cli.BoolFlag{
 				Name:   "non-interactive",
 				Usage:  "[DEPRECATED] run in the non-interactive mode",
@@ -166,6 +179,48 @@ func Command(stdout, stderr io.Writer) *cli.Command {
 				Name:     "no-introduce",
 				Usage:    "exclude patches that would introduce new vulnerabilities",
 			},
+			&cli.StringFlag{
+				Category:  autoModeCategory,
+				Name:      "output-plan",
+				Usage:     "write a json plan of the upgrades that are applied, the vulnerabilities they fix, and whether they are major version bumps to the given file",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Category:  autoModeCategory,
+				Name:      "output-summary",
+				Usage:     "write a Markdown summary of the upgrades that are applied, suitable for the body of a pull request, to the given file",
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Category: prCategory,
+				Name:     "create-pr",
+				Usage:    "commit the changes onto a new branch, push it to the origin remote, and open a GitHub pull request for it",
+			},
+			&cli.StringFlag{
+				Category: prCategory,
+				Name:     "github-token",
+				Usage:    "GitHub token to push the branch and open the pull request with",
+				Sources:  cli.EnvVars("GITHUB_TOKEN"),
+			},
+			&cli.StringFlag{
+				Category:    prCategory,
+				Name:        "pr-branch",
+				Usage:       "name of the branch to commit the changes onto",
+				DefaultText: "osv-scanner/fix-<timestamp>",
+			},
+			&cli.StringFlag{
+				Category:    prCategory,
+				Name:        "pr-base",
+				Usage:       "branch to open the pull request against",
+				DefaultText: "the current branch",
+			},
+			&cli.StringFlag{
+				Name:    "github-api-url",
+				Usage:   "URL of the GitHub REST API, for GitHub Enterprise Server",
+				Value:   pullrequest.DefaultAPIURL,
+				Sources: cli.EnvVars("GITHUB_API_URL"),
+				Hidden:  true,
+			},
 
 			&cli.StringSliceFlag{
 				Category:    upgradeCategory,
@@ -221,6 +276,11 @@ func Command(stdout, stderr io.Writer) *cli.Command {
 				Usage:  "sets the path that local databases should be stored",
 				Hidden: true,
 			},
+			&cli.StringSliceFlag{
+				Name:      "local-db-extra",
+				Usage:     "loads additional OSV advisories from the given directory into the local databases; can be repeated",
+				TakesFile: true,
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return action(ctx, cmd, stdout, stderr)
@@ -233,6 +293,15 @@ func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer) err
 		return errors.New("manifest or lockfile is required")
 	}
 
+	if cmd.Bool("create-pr") {
+		if cmd.Bool("interactive") {
+			return errors.New("--create-pr cannot be used in the interactive mode")
+		}
+		if cmd.String("github-token") == "" {
+			return errors.New("--create-pr requires a GitHub token, either with --github-token or the GITHUB_TOKEN environment variable")
+		}
+	}
+
 	opts := osvFixOptions{
 		Options: remediation.Options{
 			ResolveOpts: resolution.ResolveOpts{
@@ -249,13 +318,30 @@ func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer) err
 		Lockfile:    cmd.String("lockfile"),
 		NoIntroduce: cmd.Bool("no-introduce"),
 		OutputJSON:  cmd.String("format") == "json",
+		PlanPath:    cmd.String("output-plan"),
+		SummaryPath: cmd.String("output-summary"),
 		Stdout:      stdout,
 		Stderr:      stderr,
 	}
 
+	if cmd.Bool("create-pr") {
+		branch := cmd.String("pr-branch")
+		if branch == "" {
+			branch = defaultPullRequestBranch(time.Now())
+		}
+
+		opts.PullRequest = &pullrequest.Options{
+			Token:     cmd.String("github-token"),
+			APIURL:    cmd.String("github-api-url"),
+			Base:      cmd.String("pr-base"),
+			Branch:    branch,
+			UserAgent: "osv-scanner_fix/" + version.OSVVersion,
+		}
+	}
+
 	system := resolve.UnknownSystem
 	if opts.Lockfile != "" {
-		rw, err := lockfile.GetReadWriter(opts.Lockfile)
+		rw, err := lockfile.GetReadWriter(opts.Lockfile, cmd.String("npm-registry"))
 		if err != nil {
 			return err
 		}
@@ -274,7 +360,14 @@ func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer) err
 		system = rw.System()
 	}
 
-	switch cmd.String("data-source") {
+	dataSource := cmd.String("data-source")
+	if system == resolve.PyPI && dataSource == "deps.dev" {
+		// deps.dev does not have the requirements of PyPI packages to resolve with
+		cmdlogger.Infof("deps.dev data-source is unsupported for the PyPI ecosystem, using native data-source instead")
+		dataSource = "native"
+	}
+
+	switch dataSource {
 	case "deps.dev":
 		cl, err := client.NewDepsDevClient(depsdev.DepsdevAPI, "osv-scanner_fix/"+version.OSVVersion)
 		if err != nil {
@@ -291,7 +384,7 @@ func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer) err
 			} else {
 				workDir = filepath.Dir(opts.Lockfile)
 			}
-			cl, err := client.NewNpmRegistryClient(workDir)
+			cl, err := client.NewNpmRegistryClient(workDir, cmd.String("npm-registry"))
 			if err != nil {
 				return err
 			}
@@ -302,6 +395,8 @@ func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer) err
 				return err
 			}
 			opts.Client.DependencyClient = cl
+		case resolve.PyPI:
+			opts.Client.DependencyClient = client.NewPyPIRegistryClient(cmd.String("pypi-registry"))
 		case resolve.UnknownSystem:
 			fallthrough
 		default:
@@ -313,6 +408,7 @@ func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer) err
 	if cmd.Bool("offline-vulnerabilities") {
 		matcher, err := localmatcher.NewLocalMatcher(
 			cmd.String("local-db-path"),
+			cmd.StringSlice("local-db-extra"),
 			userAgent,
 			cmd.Bool("download-offline-databases"),
 		)
@@ -331,14 +427,9 @@ func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer) err
 
 		opts.Client.VulnerabilityMatcher = matcher
 	} else {
-		config := osvdev.DefaultConfig()
-		config.UserAgent = userAgent
+		transport := &osvmatcher.RetryTransport{Config: osvmatcher.DefaultRetryConfig()}
 		opts.Client.VulnerabilityMatcher = &osvmatcher.CachedOSVMatcher{
-			Client: osvdev.OSVClient{
-				HTTPClient:  http.DefaultClient,
-				Config:      config,
-				BaseHostURL: osvdev.DefaultBaseURL,
-			},
+			Client:              *osvmatcher.NewRetryingOSVClient(transport, userAgent),
 			InitialQueryTimeout: 5 * time.Minute,
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"path/filepath"
 	"slices"
 
 	"deps.dev/util/resolve"
@@ -57,8 +58,11 @@ func autoInPlace(ctx context.Context, opts osvFixOptions, maxUpgrades int) error
 	}
 
 	cmdlogger.Infof("Rewriting %s...", opts.Lockfile)
+	if err := lf.Overwrite(opts.LockfileRW, opts.Lockfile, patches); err != nil {
+		return err
+	}
 
-	return lf.Overwrite(opts.LockfileRW, opts.Lockfile, patches)
+	return createPullRequest(ctx, opts, outputResult)
 }
 
 // returns the top {maxUpgrades} compatible patches, and populates outputResult.
@@ -184,36 +188,13 @@ func autoRelax(ctx context.Context, opts osvFixOptions, maxUpgrades int) error {
 		return err
 	}
 
-	if opts.Lockfile != "" {
-		// We only recreate the lockfile if we know a lockfile already exists
-		// or we've been given a command to run.
-		cmdlogger.Infof("Shelling out to regenerate lockfile...")
-		cmd, err := regenerateLockfileCmd(opts)
-		if err != nil {
-			return err
-		}
-
-		cmd.Stdout = opts.Stdout
-		cmd.Stderr = opts.Stderr
-		cmdlogger.Infof("Executing `%s`...", cmd)
-		err = cmd.Run()
-		if err == nil {
-			return nil
-		}
-
-		cmdlogger.Warnf("Install failed. Trying again with `--legacy-peer-deps`...")
-		cmd, err = regenerateLockfileCmd(opts)
-		if err != nil {
+	if shouldRegenerateLockfile(opts) {
+		if err := regenerateLockfile(opts); err != nil {
 			return err
 		}
-		cmd.Args = append(cmd.Args, "--legacy-peer-deps")
-		cmd.Stdout = opts.Stdout
-		cmd.Stderr = opts.Stderr
-
-		return cmd.Run()
 	}
 
-	return nil
+	return createPullRequest(ctx, opts, outputResult)
 }
 
 // returns the top {maxUpgrades} compatible patches, and populates outputResult
@@ -340,7 +321,22 @@ func autoOverride(ctx context.Context, opts osvFixOptions, maxUpgrades int) erro
 		return err
 	}
 
-	return nil
+	if opts.ManifestRW.System() != resolve.NPM {
+		return createPullRequest(ctx, opts, outputResult)
+	}
+
+	// package.json cannot have comments, so the reasons for the overrides are written alongside it
+	if err := writeOverrideReasons(filepath.Join(filepath.Dir(opts.Manifest), overrideReasonsFile), outputResult.Patches); err != nil {
+		return err
+	}
+
+	if opts.Lockfile != "" {
+		if err := regenerateLockfile(opts); err != nil {
+			return err
+		}
+	}
+
+	return createPullRequest(ctx, opts, outputResult)
 }
 
 func autoChooseOverridePatches(diffs []resolution.Difference, maxUpgrades int, outputResult *fixOutput) []manifest.DependencyPatch {
@@ -62,6 +62,10 @@ type errorOutput struct {
 }
 
 func printResult(outputResult fixOutput, opts osvFixOptions) error {
+	if err := writePatchPlan(outputResult, opts.PlanPath, opts.SummaryPath); err != nil {
+		return err
+	}
+
 	if opts.OutputJSON {
 		return outputJSON(opts.Stdout, outputResult)
 	}
@@ -0,0 +1,70 @@
+package fix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+)
+
+// overrideReasonsFile is the name of the file next to the package.json that records
+// why each override was added, since the package.json cannot contain comments
+const overrideReasonsFile = "osv-scanner-overrides.json"
+
+type overrideReasons struct {
+	Overrides map[string]overrideReason `json:"overrides"`
+}
+
+type overrideReason struct {
+	Version         string   `json:"version"`
+	PreviousVersion string   `json:"previousVersion"`
+	Fixes           []string `json:"fixes"`
+	Reason          string   `json:"reason"`
+}
+
+// writeOverrideReasons records the reasons for the overrides added by the patches in the
+// file at path, keeping the reasons of existing overrides that were not changed
+func writeOverrideReasons(path string, patches []patchOutput) error {
+	reasons := overrideReasons{Overrides: make(map[string]overrideReason)}
+
+	b, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(b, &reasons); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if reasons.Overrides == nil {
+			reasons.Overrides = make(map[string]overrideReason)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	for _, p := range patches {
+		fixes := make([]string, len(p.Fixed))
+		for i, v := range p.Fixed {
+			fixes[i] = v.ID
+		}
+
+		for _, pkg := range p.PackageUpdates {
+			reasons.Overrides[pkg.Name] = overrideReason{
+				Version:         pkg.VersionTo,
+				PreviousVersion: pkg.VersionFrom,
+				Fixes:           fixes,
+				Reason:          fmt.Sprintf("Overrides %s@%s to fix %s", pkg.Name, pkg.VersionFrom, strings.Join(fixes, ", ")),
+			}
+		}
+	}
+
+	b, err = json.MarshalIndent(reasons, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	cmdlogger.Infof("Writing reasons for overrides to %s...", path)
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
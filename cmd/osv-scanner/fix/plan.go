@@ -0,0 +1,201 @@
+package fix
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"deps.dev/util/semver"
+	"github.com/google/osv-scanner/v2/internal/resolution/util"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// patchPlan is a machine-readable description of the upgrades that guided remediation
+// applies, for tooling that automates opening pull requests with them.
+type patchPlan struct {
+	Path      string              `json:"path"`
+	Ecosystem osvschema.Ecosystem `json:"ecosystem"`
+	Strategy  strategy            `json:"strategy"`
+	Upgrades  []plannedUpgrade    `json:"upgrades"`
+	// RemainingVulns are the IDs of the vulns that are not fixed by the upgrades.
+	RemainingVulns []string `json:"remainingVulnerabilities"`
+	// Summary is a Markdown description of the upgrades, suitable for the body of a pull request.
+	Summary string `json:"summary"`
+}
+
+// plannedUpgrade is a single package that is upgraded by the plan.
+type plannedUpgrade struct {
+	Name        string `json:"name"`
+	VersionFrom string `json:"versionFrom"`
+	VersionTo   string `json:"versionTo"`
+	Transitive  bool   `json:"transitive"`
+	// MajorBump is true if the upgrade crosses a major version, and so may contain breaking changes.
+	MajorBump  bool     `json:"majorBump"`
+	Fixes      []string `json:"fixes"`
+	Introduces []string `json:"introduces,omitempty"`
+}
+
+func vulnIDs(vulns []vulnOutput) []string {
+	ids := make([]string, len(vulns))
+	for i, v := range vulns {
+		ids[i] = v.ID
+	}
+
+	return ids
+}
+
+func makePatchPlan(out fixOutput) patchPlan {
+	plan := patchPlan{
+		Path:           out.Path,
+		Ecosystem:      out.Ecosystem,
+		Strategy:       out.Strategy,
+		Upgrades:       []plannedUpgrade{},
+		RemainingVulns: []string{},
+	}
+
+	var sys *semver.System
+	for s, eco := range util.OSVEcosystem {
+		if eco == out.Ecosystem {
+			sem := s.Semver()
+			sys = &sem
+		}
+	}
+
+	fixed := make(map[string]bool)
+	for _, patch := range out.Patches {
+		fixes := vulnIDs(patch.Fixed)
+		for _, id := range fixes {
+			fixed[id] = true
+		}
+
+		for _, pkg := range patch.PackageUpdates {
+			plan.Upgrades = append(plan.Upgrades, plannedUpgrade{
+				Name:        pkg.Name,
+				VersionFrom: pkg.VersionFrom,
+				VersionTo:   pkg.VersionTo,
+				Transitive:  pkg.Transitive,
+				MajorBump:   isMajorBump(sys, pkg.VersionFrom, pkg.VersionTo),
+				Fixes:       fixes,
+				Introduces:  vulnIDs(patch.Introduced),
+			})
+		}
+	}
+
+	for _, v := range out.Vulnerabilities {
+		if !fixed[v.ID] && !slices.Contains(plan.RemainingVulns, v.ID) {
+			plan.RemainingVulns = append(plan.RemainingVulns, v.ID)
+		}
+	}
+	slices.Sort(plan.RemainingVulns)
+
+	plan.Summary = plan.markdown()
+
+	return plan
+}
+
+// isMajorBump returns whether upgrading from one version to the other changes the major
+// version, treating requirements such as "^1.2.3" as the version that they are based on
+func isMajorBump(sys *semver.System, from, to string) bool {
+	if sys == nil {
+		// the ecosystem is not known, so there is no way to compare the versions
+		return false
+	}
+
+	trim := func(s string) string {
+		return strings.TrimLeft(s, "^~=<>v ")
+	}
+
+	_, diff, err := sys.Difference(from, to)
+	if err != nil {
+		_, diff, err = sys.Difference(trim(from), trim(to))
+		if err != nil {
+			return false
+		}
+	}
+
+	return diff == semver.DiffMajor
+}
+
+// markdown renders the plan as a summary for the body of a pull request
+func (p patchPlan) markdown() string {
+	var sb strings.Builder
+
+	nFixed := 0
+	seen := make(map[string]bool)
+	for _, u := range p.Upgrades {
+		for _, id := range u.Fixes {
+			if !seen[id] {
+				seen[id] = true
+				nFixed++
+			}
+		}
+	}
+
+	fmt.Fprintf(&sb, "This pull request was created by `osv-scanner fix`, which used the %s strategy on `%s` ", p.Strategy, filepath.ToSlash(p.Path))
+	fmt.Fprintf(&sb, "to fix %d of the %d vulnerabilities that were found.\n\n", nFixed, nFixed+len(p.RemainingVulns))
+
+	sb.WriteString("| Package | From | To | Fixes |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+	majorBumps := 0
+	for _, u := range p.Upgrades {
+		to := u.VersionTo
+		if u.MajorBump {
+			to += " :warning:"
+			majorBumps++
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", u.Name, u.VersionFrom, to, strings.Join(u.Fixes, ", "))
+	}
+
+	if majorBumps > 0 {
+		fmt.Fprintf(&sb, "\n:warning: %d of the upgrades change the major version of the package, and may contain breaking changes.\n", majorBumps)
+	}
+
+	var introduced []string
+	for _, u := range p.Upgrades {
+		introduced = append(introduced, u.Introduces...)
+	}
+	slices.Sort(introduced)
+	introduced = slices.Compact(introduced)
+
+	if len(introduced) > 0 {
+		fmt.Fprintf(&sb, "\nThe upgrades introduce %d new vulnerabilities: %s\n", len(introduced), strings.Join(introduced, ", "))
+	}
+
+	if len(p.RemainingVulns) > 0 {
+		fmt.Fprintf(&sb, "\n%d vulnerabilities could not be fixed by these changes: %s\n", len(p.RemainingVulns), strings.Join(p.RemainingVulns, ", "))
+	}
+
+	return sb.String()
+}
+
+// writePatchPlan writes the plan of the changes as json to planPath and
+// the summary of it to summaryPath, if either of them are set
+func writePatchPlan(out fixOutput, planPath, summaryPath string) error {
+	if planPath == "" && summaryPath == "" {
+		return nil
+	}
+
+	plan := makePatchPlan(out)
+
+	if planPath != "" {
+		b, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(planPath, append(b, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write patch plan: %w", err)
+		}
+	}
+
+	if summaryPath != "" {
+		if err := os.WriteFile(summaryPath, []byte(plan.Summary), 0o644); err != nil {
+			return fmt.Errorf("failed to write patch summary: %w", err)
+		}
+	}
+
+	return nil
+}
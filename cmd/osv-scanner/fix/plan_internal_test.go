@@ -0,0 +1,104 @@
+package fix
+
+import (
+	"testing"
+
+	"deps.dev/util/semver"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func Test_isMajorBump(t *testing.T) {
+	t.Parallel()
+
+	npm := semver.NPM
+	maven := semver.Maven
+
+	tests := []struct {
+		name string
+		sys  *semver.System
+		from string
+		to   string
+		want bool
+	}{
+		{name: "npm major", sys: &npm, from: "1.2.3", to: "2.0.0", want: true},
+		{name: "npm minor", sys: &npm, from: "1.2.3", to: "1.3.0", want: false},
+		{name: "npm requirements", sys: &npm, from: "^1.2.3", to: "^2.0.1", want: true},
+		{name: "npm same major requirements", sys: &npm, from: "~1.2.3", to: "^1.4.0", want: false},
+		{name: "maven major", sys: &maven, from: "2.9.10", to: "3.0.0", want: true},
+		{name: "maven patch", sys: &maven, from: "2.9.10", to: "2.9.10.8", want: false},
+		{name: "unparsable", sys: &npm, from: "latest", to: "next", want: false},
+		{name: "unknown ecosystem", sys: nil, from: "1.0.0", to: "2.0.0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isMajorBump(tt.sys, tt.from, tt.to); got != tt.want {
+				t.Errorf("isMajorBump(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_makePatchPlan(t *testing.T) {
+	t.Parallel()
+
+	out := fixOutput{
+		Path:      "path/to/package.json",
+		Ecosystem: osvschema.EcosystemNPM,
+		Strategy:  strategyRelax,
+		Vulnerabilities: []vulnOutput{
+			{ID: "GHSA-1111"},
+			{ID: "GHSA-2222"},
+			{ID: "GHSA-3333"},
+			{ID: "GHSA-4444", Unactionable: true},
+		},
+		Patches: []patchOutput{
+			{
+				PackageUpdates: []updatePackageOutput{{Name: "express", VersionFrom: "^4.17.0", VersionTo: "^5.0.0"}},
+				Fixed:          []vulnOutput{{ID: "GHSA-1111"}, {ID: "GHSA-2222"}},
+				Introduced:     []vulnOutput{{ID: "GHSA-5555"}},
+			},
+			{
+				PackageUpdates: []updatePackageOutput{{Name: "lodash", VersionFrom: "^4.17.15", VersionTo: "^4.17.21"}},
+				Fixed:          []vulnOutput{{ID: "GHSA-3333"}},
+			},
+		},
+	}
+
+	got := makePatchPlan(out)
+
+	want := patchPlan{
+		Path:      "path/to/package.json",
+		Ecosystem: osvschema.EcosystemNPM,
+		Strategy:  strategyRelax,
+		Upgrades: []plannedUpgrade{
+			{
+				Name:        "express",
+				VersionFrom: "^4.17.0",
+				VersionTo:   "^5.0.0",
+				MajorBump:   true,
+				Fixes:       []string{"GHSA-1111", "GHSA-2222"},
+				Introduces:  []string{"GHSA-5555"},
+			},
+			{
+				Name:        "lodash",
+				VersionFrom: "^4.17.15",
+				VersionTo:   "^4.17.21",
+				Fixes:       []string{"GHSA-3333"},
+				Introduces:  []string{},
+			},
+		},
+		RemainingVulns: []string{"GHSA-4444"},
+	}
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(patchPlan{}, "Summary")); diff != "" {
+		t.Errorf("makePatchPlan() diff (-want +got):\n%s", diff)
+	}
+
+	testutility.NewSnapshot().MatchText(t, got.Summary)
+}
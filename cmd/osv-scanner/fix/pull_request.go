@@ -0,0 +1,76 @@
+package fix
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/pullrequest"
+)
+
+// defaultPullRequestBranch is the name of the branch that changes are committed onto
+// if one is not given, which is unique so that runs do not clash with each other
+func defaultPullRequestBranch(now time.Time) string {
+	return "osv-scanner/fix-" + now.UTC().Format("20060102150405")
+}
+
+// createPullRequest opens a pull request with the changes made to the manifest and/or
+// lockfile, if a pull request was requested and any patches were applied
+func createPullRequest(ctx context.Context, opts osvFixOptions, out fixOutput) error {
+	if opts.PullRequest == nil || len(out.Patches) == 0 {
+		return nil
+	}
+
+	var files []string
+	for _, f := range []string{opts.Manifest, opts.Lockfile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+
+	if out.Strategy == strategyOverride && opts.Manifest != "" {
+		reasons := filepath.Join(filepath.Dir(opts.Manifest), overrideReasonsFile)
+		if _, err := os.Stat(reasons); err == nil {
+			files = append(files, reasons)
+		}
+	}
+
+	prOpts := *opts.PullRequest
+	prOpts.Title = pullRequestTitle(out)
+	prOpts.Body = makePatchPlan(out).Summary
+
+	cmdlogger.Infof("Opening pull request from branch %s...", prOpts.Branch)
+	url, err := pullrequest.Create(ctx, filepath.Dir(files[0]), files, prOpts)
+	if err != nil {
+		return fmt.Errorf("failed to open pull request: %w", err)
+	}
+
+	cmdlogger.Infof("Opened pull request: %s", url)
+
+	return nil
+}
+
+func fixedVulnIDs(out fixOutput) []string {
+	var ids []string
+	for _, patch := range out.Patches {
+		for _, v := range patch.Fixed {
+			ids = append(ids, v.ID)
+		}
+	}
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+func pullRequestTitle(out fixOutput) string {
+	n := len(fixedVulnIDs(out))
+	if n == 1 {
+		return fmt.Sprintf("Fix 1 vulnerability in %s", filepath.Base(out.Path))
+	}
+
+	return fmt.Sprintf("Fix %d vulnerabilities in %s", n, filepath.Base(out.Path))
+}
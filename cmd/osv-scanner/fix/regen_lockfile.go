@@ -1,12 +1,67 @@
 package fix
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+
+	"deps.dev/util/resolve"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 )
 
+// pythonLockfileCmds are the commands that relock the pyproject.toml of a project
+// without upgrading unrelated dependencies, keyed by the lockfile of the tool that manages the project
+var pythonLockfileCmds = []struct {
+	lockfile string
+	args     []string
+}{
+	{lockfile: "poetry.lock", args: []string{"poetry", "lock"}},
+	{lockfile: "uv.lock", args: []string{"uv", "lock"}},
+}
+
+// shouldRegenerateLockfile returns if the lockfile should be regenerated after the manifest is rewritten.
+// We only recreate the lockfile if we know a lockfile already exists.
+func shouldRegenerateLockfile(opts osvFixOptions) bool {
+	if opts.Lockfile != "" {
+		return true
+	}
+
+	// Python lockfiles cannot be remediated directly, so they are found next to the manifest instead
+	if opts.ManifestRW != nil && opts.ManifestRW.System() == resolve.PyPI {
+		_, ok := pythonLockfileCmd(opts.Manifest)
+		return ok
+	}
+
+	return false
+}
+
+// pythonLockfileCmd returns the command that relocks the pyproject.toml,
+// based on the lockfile that exists next to it
+func pythonLockfileCmd(manifestPath string) (*exec.Cmd, bool) {
+	dir := filepath.Dir(manifestPath)
+	for _, c := range pythonLockfileCmds {
+		if _, err := os.Stat(filepath.Join(dir, c.lockfile)); err == nil {
+			cmd := exec.Command(c.args[0], c.args[1:]...)
+			cmd.Dir = dir
+
+			return cmd, true
+		}
+	}
+
+	return nil, false
+}
+
 func regenerateLockfileCmd(opts osvFixOptions) (*exec.Cmd, error) {
+	if opts.ManifestRW != nil && opts.ManifestRW.System() == resolve.PyPI {
+		c, ok := pythonLockfileCmd(opts.Manifest)
+		if !ok {
+			return nil, errors.New("no poetry.lock or uv.lock found next to the manifest to regenerate")
+		}
+
+		return c, nil
+	}
+
 	// TODO: this is npm-specific and hacky
 	// delete existing package-lock & node_modules directory to force npm to do a clean install
 	dir := filepath.Dir(opts.Manifest)
@@ -23,3 +78,38 @@ func regenerateLockfileCmd(opts osvFixOptions) (*exec.Cmd, error) {
 
 	return c, nil
 }
+
+// canRetryWithLegacyPeerDeps returns if regenerating the lockfile can be retried with `--legacy-peer-deps`,
+// which is only the case for npm
+func canRetryWithLegacyPeerDeps(opts osvFixOptions) bool {
+	return opts.ManifestRW == nil || opts.ManifestRW.System() == resolve.NPM
+}
+
+// regenerateLockfile regenerates the lockfile of the manifest, trying again
+// with `--legacy-peer-deps` if the first npm install fails
+func regenerateLockfile(opts osvFixOptions) error {
+	cmdlogger.Infof("Shelling out to regenerate lockfile...")
+	cmd, err := regenerateLockfileCmd(opts)
+	if err != nil {
+		return err
+	}
+
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+	cmdlogger.Infof("Executing `%s`...", cmd)
+	err = cmd.Run()
+	if err == nil || !canRetryWithLegacyPeerDeps(opts) {
+		return err
+	}
+
+	cmdlogger.Warnf("Install failed. Trying again with `--legacy-peer-deps`...")
+	cmd, err = regenerateLockfileCmd(opts)
+	if err != nil {
+		return err
+	}
+	cmd.Args = append(cmd.Args, "--legacy-peer-deps")
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+
+	return cmd.Run()
+}
@@ -0,0 +1,64 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/resolution/manifest"
+)
+
+func Test_regenerateLockfileCmd_python(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		lockfiles []string
+		want      []string
+	}{
+		{name: "poetry", lockfiles: []string{"poetry.lock"}, want: []string{"poetry", "lock"}},
+		{name: "uv", lockfiles: []string{"uv.lock"}, want: []string{"uv", "lock"}},
+		{name: "none", lockfiles: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for _, f := range append(tt.lockfiles, "pyproject.toml") {
+				if err := os.WriteFile(filepath.Join(dir, f), nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			opts := osvFixOptions{
+				Manifest:   filepath.Join(dir, "pyproject.toml"),
+				ManifestRW: manifest.PythonReadWriter{},
+			}
+
+			if got := shouldRegenerateLockfile(opts); got != (tt.want != nil) {
+				t.Errorf("shouldRegenerateLockfile() = %v, want %v", got, tt.want != nil)
+			}
+
+			cmd, err := regenerateLockfileCmd(opts)
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("regenerateLockfileCmd() did not error without a lockfile")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("regenerateLockfileCmd() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, cmd.Args); diff != "" {
+				t.Errorf("regenerateLockfileCmd() args mismatch (-want +got):\n%s", diff)
+			}
+			if cmd.Dir != dir {
+				t.Errorf("regenerateLockfileCmd() dir = %s, want %s", cmd.Dir, dir)
+			}
+		})
+	}
+}
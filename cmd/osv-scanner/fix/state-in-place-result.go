@@ -139,7 +139,7 @@ func (st *stateInPlaceResult) currentInfoView() (view tui.ViewModel, canFocus bo
 	case stateInPlaceChoice: // choose changes
 		return infoStringView("Choose which changes to apply"), false
 	case stateInPlaceWrite: // write
-		return infoStringView("Write changes to lockfile"), false
+		return infoStringView("Preview the selected changes before writing them to the lockfile"), false
 	case stateInPlaceRelock: // relock
 		return st.relockFixVulns, st.canRelock
 	case stateInPlaceQuit: // quit
@@ -158,9 +158,11 @@ func (st *stateInPlaceResult) parseInput(m model) (tea.Model, tea.Cmd) {
 	case stateInPlaceChoice: // choose specific patches
 		m.st = &stateChooseInPlacePatches{stateInPlace: st}
 		cmd = m.st.Init(m)
-	case stateInPlaceWrite: // write
-		m.writing = true
-		cmd = func() tea.Msg { return st.write(m) }
+	case stateInPlaceWrite: // preview the changes before writing
+		if slices.Contains(st.selectedChanges, true) {
+			m.st = st.previewChanges(m)
+			cmd = m.st.Init(m)
+		}
 	case stateInPlaceRelock: // relock
 		if st.canRelock {
 			m.st = &stateRelockResult{}
@@ -215,7 +217,7 @@ func (st *stateInPlaceResult) View(m model) string {
 		st.cursorPos == stateInPlaceWrite,
 		" > ",
 		fmt.Sprintf("%%s %d changes to lockfile\n", nSelected),
-		"Write",
+		"Preview & write",
 	))
 	if st.canRelock {
 		s.WriteString(tui.RenderSelectorOption(
@@ -259,6 +261,43 @@ func (st *stateInPlaceResult) IsInfoFocused() bool {
 	return st.focusedInfo != nil
 }
 
+// previewChanges creates the state for previewing the selected changes to the lockfile,
+// where each upgrade can be individually rejected before the rest are written
+func (st *stateInPlaceResult) previewChanges(m model) *statePreviewChanges {
+	var patchIdx []int // for each previewed upgrade, its index into the in-place patches
+	var upgrades []string
+	for i, p := range m.inPlaceResult.Patches {
+		if st.selectedChanges[i] {
+			patchIdx = append(patchIdx, i)
+			upgrades = append(upgrades, fmt.Sprintf("%s: %s → %s", p.Pkg.Name, p.OrigVersion, p.NewVersion))
+		}
+	}
+
+	return &statePreviewChanges{
+		parent:   st,
+		filename: m.options.Lockfile,
+		upgrades: upgrades,
+		patched: func(accepted []bool) ([]byte, error) {
+			var changes []lockf.DependencyPatch
+			for i, idx := range patchIdx {
+				if accepted[i] {
+					changes = append(changes, m.inPlaceResult.Patches[idx].DependencyPatch)
+				}
+			}
+
+			return lockf.Patched(m.options.LockfileRW, m.options.Lockfile, changes)
+		},
+		write: func(m model, accepted []bool) tea.Cmd {
+			// deselect the rejected upgrades, so they remain available to be applied later
+			for i, idx := range patchIdx {
+				st.selectedChanges[idx] = accepted[i]
+			}
+
+			return func() tea.Msg { return st.write(m) }
+		},
+	}
+}
+
 // TODO: Work out a better way to output npm commands
 func (st *stateInPlaceResult) write(m model) tea.Msg {
 	var changes []lockf.DependencyPatch
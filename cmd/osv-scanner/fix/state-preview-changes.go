@@ -0,0 +1,222 @@
+package fix
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/osv-scanner/v2/internal/tui"
+)
+
+// statePreviewChanges shows a diff of the changes that will be written to a file,
+// allowing each individual upgrade to be accepted or rejected before anything is written
+type statePreviewChanges struct {
+	parent modelState // the state to return to after writing or going back
+
+	filename string   // the file being changed
+	upgrades []string // description of each upgrade that can be accepted or rejected
+	accepted []bool   // whether each upgrade will be written
+
+	// patched computes the contents of the file with only the accepted upgrades applied
+	patched func(accepted []bool) ([]byte, error)
+	// write writes the accepted upgrades, with the result being handled by the parent state
+	write func(m model, accepted []bool) tea.Cmd
+
+	cursorPos int
+	spinner   spinner.Model
+	computing bool // whether the diff is currently being computed
+	diffGen   int  // incremented with every change to the accepted upgrades, to ignore outdated diffs
+	diffView  tui.ViewModel
+
+	focusedInfo tui.ViewModel // the infoview that is currently focused, nil if not focused
+
+	infoWidth  int
+	infoHeight int
+}
+
+type previewDiffMsg struct {
+	gen      int
+	original []byte
+	patched  []byte
+	err      error
+}
+
+func (st *statePreviewChanges) Init(m model) tea.Cmd {
+	st.accepted = make([]bool, len(st.upgrades))
+	for i := range st.accepted {
+		st.accepted[i] = true
+	}
+	st.cursorPos = 0
+	st.spinner = tui.NewSpinner()
+	st.diffView = emptyInfoView
+	st.ResizeInfo(m.infoViewWidth, m.infoViewHeight)
+
+	return tea.Batch(st.computeDiff(), st.spinner.Tick)
+}
+
+// computeDiff starts computing the diff of the file with the currently accepted upgrades
+func (st *statePreviewChanges) computeDiff() tea.Cmd {
+	st.diffGen++
+	st.computing = true
+	gen := st.diffGen
+	accepted := slices.Clone(st.accepted)
+
+	return func() tea.Msg {
+		original, err := os.ReadFile(st.filename)
+		if err != nil {
+			return previewDiffMsg{gen: gen, err: err}
+		}
+		patched, err := st.patched(accepted)
+
+		return previewDiffMsg{gen: gen, original: original, patched: patched, err: err}
+	}
+}
+
+func (st *statePreviewChanges) Update(m model, msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case previewDiffMsg:
+		if msg.gen != st.diffGen {
+			// the accepted upgrades have changed since this diff was started
+			break
+		}
+		if msg.err != nil {
+			return errorAndExit(m, msg.err)
+		}
+		st.computing = false
+		st.diffView = tui.NewDiffView(st.filename, msg.original, msg.patched)
+		st.diffView.Resize(st.infoWidth, st.infoHeight)
+	case tui.ViewModelCloseMsg:
+		// info view wants to quit, just unfocus it
+		st.focusedInfo = nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, tui.Keys.SwitchView):
+			if st.IsInfoFocused() {
+				st.focusedInfo = nil
+			} else if !st.computing {
+				st.focusedInfo = st.diffView
+			}
+		case st.IsInfoFocused():
+			st.focusedInfo, cmd = st.focusedInfo.Update(msg)
+		case key.Matches(msg, tui.Keys.Quit):
+			// go back without writing anything
+			m.st = st.parent
+			return m, nil
+		case key.Matches(msg, tui.Keys.Select):
+			return st.parseInput(m)
+		case key.Matches(msg, tui.Keys.Up):
+			if st.cursorPos > 0 {
+				st.cursorPos--
+			}
+		case key.Matches(msg, tui.Keys.Down):
+			if st.cursorPos < len(st.upgrades)+1 {
+				st.cursorPos++
+			}
+		}
+	}
+	var c tea.Cmd
+	st.spinner, c = st.spinner.Update(msg)
+
+	return m, tea.Batch(cmd, c)
+}
+
+func (st *statePreviewChanges) parseInput(m model) (tea.Model, tea.Cmd) {
+	switch {
+	case st.cursorPos < len(st.upgrades): // accept/reject an upgrade
+		st.accepted[st.cursorPos] = !st.accepted[st.cursorPos]
+		return m, tea.Batch(st.computeDiff(), st.spinner.Tick)
+	case st.cursorPos == len(st.upgrades): // write
+		if st.computing || !slices.Contains(st.accepted, true) {
+			return m, nil
+		}
+		m.st = st.parent
+		m.writing = true
+
+		return m, st.write(m, slices.Clone(st.accepted))
+	default: // back
+		m.st = st.parent
+		return m, nil
+	}
+}
+
+func (st *statePreviewChanges) View(_ model) string {
+	nAccepted := 0
+	for _, a := range st.accepted {
+		if a {
+			nAccepted++
+		}
+	}
+
+	s := strings.Builder{}
+	s.WriteString("PREVIEW CHANGES\n")
+	fmt.Fprintf(&s, "Changes to %s:\n", st.filename)
+	for i, upgrade := range st.upgrades {
+		checkBox := "[ ]"
+		if st.accepted[i] {
+			checkBox = "[x]"
+		}
+		s.WriteString(tui.RenderSelectorOption(
+			st.cursorPos == i,
+			" > ",
+			"%s %s\n",
+			checkBox,
+			upgrade,
+		))
+	}
+	s.WriteString("\n")
+
+	switch {
+	case st.computing:
+		s.WriteString(tui.RenderSelectorOption(
+			st.cursorPos == len(st.upgrades),
+			"> ",
+			tui.DisabledTextStyle.Render("Computing changes ")+st.spinner.View()+"\n",
+		))
+	case nAccepted == 0:
+		s.WriteString(tui.RenderSelectorOption(
+			st.cursorPos == len(st.upgrades),
+			"> ",
+			tui.DisabledTextStyle.Render("No accepted changes")+"\n",
+		))
+	default:
+		s.WriteString(tui.RenderSelectorOption(
+			st.cursorPos == len(st.upgrades),
+			"> ",
+			fmt.Sprintf("%%s %d accepted changes\n", nAccepted),
+			"Write",
+		))
+	}
+	s.WriteString(tui.RenderSelectorOption(
+		st.cursorPos == len(st.upgrades)+1,
+		"> ",
+		"%s without writing\n",
+		"Go back",
+	))
+
+	return s.String()
+}
+
+func (st *statePreviewChanges) InfoView() string {
+	if st.computing {
+		return "Computing changes " + st.spinner.View()
+	}
+
+	return st.diffView.View()
+}
+
+func (st *statePreviewChanges) Resize(_, _ int) {}
+
+func (st *statePreviewChanges) ResizeInfo(w, h int) {
+	st.infoWidth = w
+	st.infoHeight = h
+	st.diffView.Resize(w, h)
+}
+
+func (st *statePreviewChanges) IsInfoFocused() bool {
+	return st.focusedInfo != nil
+}
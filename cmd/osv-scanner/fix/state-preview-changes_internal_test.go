@@ -0,0 +1,156 @@
+package fix
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestPreviewChanges(t *testing.T, upgrades []string) (*statePreviewChanges, model) {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "package.json")
+	if err := os.WriteFile(filename, []byte("original\n"), 0o600); err != nil {
+		t.Fatalf("could not write file: %v", err)
+	}
+
+	st := &statePreviewChanges{
+		filename: filename,
+		upgrades: upgrades,
+		patched: func(accepted []bool) ([]byte, error) {
+			var sb strings.Builder
+			for i, a := range accepted {
+				if a {
+					sb.WriteString(upgrades[i] + "\n")
+				}
+			}
+
+			return []byte(sb.String()), nil
+		},
+	}
+
+	m := model{st: st}
+	st.Init(m)
+
+	return st, m
+}
+
+func Test_statePreviewChanges_View(t *testing.T) {
+	t.Parallel()
+
+	st, m := newTestPreviewChanges(t, []string{
+		"lodash@4.17.20 -> 4.17.21",
+		// descriptions are not format strings
+		"minimist@%d%s -> 1.2.8",
+	})
+	st.computing = false
+	st.accepted[0] = false
+
+	view := st.View(m)
+
+	for _, want := range []string{
+		"[ ] lodash@4.17.20 -> 4.17.21",
+		"[x] minimist@%d%s -> 1.2.8",
+		"1 accepted changes",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() does not contain %q:\n%s", want, view)
+		}
+	}
+
+	if strings.Contains(view, "%!") {
+		t.Errorf("View() has formatting errors:\n%s", view)
+	}
+}
+
+func Test_statePreviewChanges_View_NoneAccepted(t *testing.T) {
+	t.Parallel()
+
+	st, m := newTestPreviewChanges(t, []string{"lodash@4.17.20 -> 4.17.21"})
+	st.computing = false
+	st.accepted[0] = false
+
+	if view := st.View(m); !strings.Contains(view, "No accepted changes") {
+		t.Errorf("View() does not say there are no accepted changes:\n%s", view)
+	}
+}
+
+func Test_statePreviewChanges_Update(t *testing.T) {
+	t.Parallel()
+
+	st, m := newTestPreviewChanges(t, []string{
+		"lodash@4.17.20 -> 4.17.21",
+		"minimist@1.2.5 -> 1.2.8",
+	})
+
+	// rejecting an upgrade recomputes the diff without it
+	st.cursorPos = 1
+	_, cmd := st.Update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("Update() did not recompute the diff")
+	}
+
+	if diff := cmp.Diff([]bool{true, false}, st.accepted); diff != "" {
+		t.Errorf("accepted upgrades mismatch (-want +got):\n%s", diff)
+	}
+
+	if !st.computing {
+		t.Errorf("expected the diff to be computing")
+	}
+
+	msg := st.computeDiff()()
+	got, ok := msg.(previewDiffMsg)
+	if !ok {
+		t.Fatalf("computeDiff() returned %T, want previewDiffMsg", msg)
+	}
+
+	if diff := cmp.Diff("lodash@4.17.20 -> 4.17.21\n", string(got.patched)); diff != "" {
+		t.Errorf("patched mismatch (-want +got):\n%s", diff)
+	}
+
+	// diffs of earlier accepted upgrades are ignored
+	st.Update(m, previewDiffMsg{gen: got.gen - 1})
+	if !st.computing {
+		t.Errorf("outdated diff was not ignored")
+	}
+
+	st.Update(m, got)
+	if st.computing {
+		t.Errorf("diff was not finished computing")
+	}
+}
+
+func Test_statePreviewChanges_Write(t *testing.T) {
+	t.Parallel()
+
+	st, m := newTestPreviewChanges(t, []string{
+		"lodash@4.17.20 -> 4.17.21",
+		"minimist@1.2.5 -> 1.2.8",
+	})
+
+	var written []bool
+	st.write = func(_ model, accepted []bool) tea.Cmd {
+		written = accepted
+		return nil
+	}
+
+	st.accepted[0] = false
+	st.cursorPos = len(st.upgrades)
+
+	// nothing is written while the diff is being computed
+	st.parseInput(m)
+	if written != nil {
+		t.Fatalf("upgrades were written while computing the diff")
+	}
+
+	st.computing = false
+	st.parseInput(m)
+
+	if diff := cmp.Diff([]bool{false, true}, written); diff != "" {
+		t.Errorf("written upgrades mismatch (-want +got):\n%s", diff)
+	}
+}
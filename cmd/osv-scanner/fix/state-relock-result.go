@@ -3,6 +3,7 @@ package fix
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -34,6 +35,10 @@ type stateRelockResult struct {
 	resolveErrors tui.ViewModel
 
 	focusedInfo tui.ViewModel // the infoview that is currently focused, nil if not focused
+
+	// whether some upgrades were rejected when writing, so the manifest on disk
+	// no longer matches currRes and has to be resolved again
+	resolveAfterWrite bool
 }
 
 const (
@@ -118,6 +123,12 @@ func (st *stateRelockResult) Update(m model, msg tea.Msg) (tea.Model, tea.Cmd) {
 			return errorAndExit(m, msg.err)
 		}
 		st.currRes = msg.res
+		if st.resolveAfterWrite {
+			// this is the result of resolving the written manifest
+			st.resolveAfterWrite = false
+			m.relockBaseRes = st.currRes
+			m.relockBaseResErrs = m.relockBaseRes.Errors()
+		}
 		// recreate the vuln list info view
 		var vulns []*resolution.Vulnerability
 		for i := range st.currRes.Vulns {
@@ -152,9 +163,20 @@ func (st *stateRelockResult) Update(m model, msg tea.Msg) (tea.Model, tea.Cmd) {
 			return errorAndExit(m, msg.err)
 		}
 		m.writing = false
+		clear(st.selectedPatches)
+		if st.resolveAfterWrite {
+			// relockBaseRes must match what is in the package.json, which needs to be resolved again
+			st.currRes = nil
+			st.patchesDone = false
+			cmd = tea.Batch(
+				func() tea.Msg { return doInitialRelock(m.ctx, m.options) },
+				st.spinner.Tick,
+			)
+
+			break
+		}
 		m.relockBaseRes = st.currRes // relockBaseRes must match what is in the package.json
 		m.relockBaseResErrs = m.relockBaseRes.Errors()
-		clear(st.selectedPatches)
 
 	case tui.ViewModelCloseMsg:
 		// info view wants to quit, just unfocus it
@@ -217,7 +239,7 @@ func (st *stateRelockResult) currentInfoView() (view tui.ViewModel, canFocus boo
 	case stateRelockApply:
 		return infoStringView("Apply the selected patches and recompute vulnerabilities"), false
 	case stateRelockWrite:
-		return infoStringView("Shell out to write manifest & lockfile"), false
+		return infoStringView("Preview the changes to the manifest, then shell out to write manifest & lockfile"), false
 	case stateRelockQuit:
 		return infoStringView("Exit Guided Remediation"), false
 	default:
@@ -280,9 +302,17 @@ func (st *stateRelockResult) parseInput(m model) (tea.Model, tea.Cmd) {
 		if len(st.selectedPatches) > 0 {
 			m, cmd = st.relaxChoice(m)
 		}
-	case stateRelockWrite: // write
-		m.writing = true
-		cmd = func() tea.Msg { return st.write(m) }
+	case stateRelockWrite: // preview the changes before writing
+		changes := m.relockBaseRes.CalculateDiff(st.currRes)
+		if len(changes.Deps) == 0 {
+			// nothing to preview, just regenerate the lockfile
+			m.writing = true
+			cmd = func() tea.Msg { return st.write(m, changes.Patch) }
+
+			break
+		}
+		m.st = st.previewChanges(m, changes.Patch)
+		cmd = m.st.Init(m)
 	case stateRelockQuit: // quit
 		cmd = tea.Quit
 	}
@@ -434,7 +464,7 @@ func (st *stateRelockResult) View(m model) string {
 		st.getEffectiveCursor() == stateRelockWrite,
 		"> ",
 		"%s changes to manifest\n",
-		"Write",
+		"Preview & write",
 	))
 	s.WriteString("\n")
 	s.WriteString(tui.RenderSelectorOption(
@@ -506,14 +536,47 @@ func (st *stateRelockResult) IsInfoFocused() bool {
 	return st.focusedInfo != nil
 }
 
+// previewChanges creates the state for previewing the changes to the manifest,
+// where each upgrade can be individually rejected before the rest are written
+func (st *stateRelockResult) previewChanges(m model, patch manif.Patch) *statePreviewChanges {
+	upgrades := make([]string, len(patch.Deps))
+	for i, dep := range patch.Deps {
+		upgrades[i] = fmt.Sprintf("%s: %s → %s", dep.Pkg.Name, dep.OrigRequire, dep.NewRequire)
+	}
+
+	acceptedPatch := func(accepted []bool) manif.Patch {
+		p := patch
+		p.Deps = nil
+		for i, dep := range patch.Deps {
+			if accepted[i] {
+				p.Deps = append(p.Deps, dep)
+			}
+		}
+
+		return p
+	}
+
+	return &statePreviewChanges{
+		parent:   st,
+		filename: m.options.Manifest,
+		upgrades: upgrades,
+		patched: func(accepted []bool) ([]byte, error) {
+			return manif.Patched(m.options.ManifestRW, m.options.Manifest, acceptedPatch(accepted))
+		},
+		write: func(m model, accepted []bool) tea.Cmd {
+			st.resolveAfterWrite = slices.Contains(accepted, false)
+			return func() tea.Msg { return st.write(m, acceptedPatch(accepted)) }
+		},
+	}
+}
+
 // TODO: Work out a better way to output npm commands
-func (st *stateRelockResult) write(m model) tea.Msg {
-	changes := m.relockBaseRes.CalculateDiff(st.currRes)
-	if err := manif.Overwrite(m.options.ManifestRW, m.options.Manifest, changes.Patch); err != nil {
+func (st *stateRelockResult) write(m model, patch manif.Patch) tea.Msg {
+	if err := manif.Overwrite(m.options.ManifestRW, m.options.Manifest, patch); err != nil {
 		return writeMsg{err}
 	}
 
-	if m.options.Lockfile == "" {
+	if !shouldRegenerateLockfile(m.options) {
 		// TODO: there's no user feedback to show this was successful
 		return writeMsg{nil}
 	}
@@ -524,7 +587,7 @@ func (st *stateRelockResult) write(m model) tea.Msg {
 	}
 
 	return tea.ExecProcess(c, func(err error) tea.Msg {
-		if err != nil {
+		if err != nil && canRetryWithLegacyPeerDeps(m.options) {
 			// try again with "--legacy-peer-deps"
 			c, err := regenerateLockfileCmd(m.options)
 			if err != nil {
@@ -0,0 +1,115 @@
+package gomod
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/localmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientinterfaces"
+	"github.com/google/osv-scanner/v2/internal/gomod"
+	"github.com/google/osv-scanner/v2/internal/version"
+	"github.com/urfave/cli/v3"
+)
+
+func Command(stdout, _ io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "gomod",
+		Usage:       "checks the modules in the build list of a Go module for vulnerabilities",
+		Description: "checks the modules that are actually built, as reported by \"go list -m all\", for vulnerabilities and reports them in the same formats as govulncheck; if package patterns are given, only modules providing packages to those patterns are checked",
+		ArgsUsage:   "[package patterns]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:      "dir",
+				Usage:     "directory of the Go module to check",
+				Value:     ".",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "sets the output format; value can be: text, json",
+				Value: "text",
+				Action: func(_ context.Context, _ *cli.Command, s string) error {
+					if s != "text" && s != "json" {
+						return fmt.Errorf("unsupported output format \"%s\" - must be one of: text, json", s)
+					}
+
+					return nil
+				},
+			},
+			&cli.BoolFlag{
+				Name:  "offline-vulnerabilities",
+				Usage: "checks for vulnerabilities using local databases that are already cached",
+			},
+			&cli.BoolFlag{
+				Name:  "download-offline-databases",
+				Usage: "downloads vulnerability databases for offline comparison",
+			},
+			&cli.StringFlag{
+				Name:   "local-db-path",
+				Usage:  "sets the path that local databases should be stored",
+				Hidden: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return action(ctx, cmd, stdout)
+		},
+	}
+}
+
+func action(ctx context.Context, cmd *cli.Command, stdout io.Writer) error {
+	modules, err := gomod.BuildList(ctx, cmd.String("dir"), cmd.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	matcher, db, err := newMatcher(cmd)
+	if err != nil {
+		return err
+	}
+
+	result, err := gomod.Check(ctx, matcher, modules)
+	if err != nil {
+		return err
+	}
+
+	if cmd.String("format") == "json" {
+		if err := gomod.WriteJSON(stdout, result, db); err != nil {
+			return err
+		}
+	} else {
+		gomod.WriteText(stdout, result)
+	}
+
+	if len(result.Findings) > 0 {
+		return gomod.ErrVulnerabilitiesFound
+	}
+
+	return nil
+}
+
+// newMatcher returns the matcher to check modules with, along with a description
+// of the database it uses for the config of the json output
+func newMatcher(cmd *cli.Command) (clientinterfaces.VulnerabilityMatcher, string, error) {
+	if cmd.Bool("offline-vulnerabilities") {
+		matcher, err := localmatcher.NewLocalMatcher(
+			cmd.String("local-db-path"),
+			nil,
+			"osv-scanner_gomod/"+version.OSVVersion,
+			cmd.Bool("download-offline-databases"),
+		)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return matcher, "offline", nil
+	}
+
+	return &osvmatcher.OSVMatcher{
+		Client:              *osvmatcher.NewRetryingOSVClient(&osvmatcher.RetryTransport{Config: osvmatcher.DefaultRetryConfig()}, ""),
+		InitialQueryTimeout: 5 * time.Minute,
+		Pipeline:            osvmatcher.DefaultPipelineConfig(),
+	}, "https://api.osv.dev", nil
+}
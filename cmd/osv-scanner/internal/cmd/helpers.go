@@ -34,6 +34,18 @@ EXAMPLES:
 	# Fix vulnerabilities in a manifest file and lockfile (non-interactive mode)
 	$ {{.Name}} fix -M <manifest_file> -L <lockfile>
 
+	# Check that the components of an SBOM can be scanned
+	$ {{.Name}} sbom validate <sbom_file>
+
+	# Compare the packages and vulnerabilities of two versions of an image
+	$ {{.Name}} diff image <old_image_name> <new_image_name>
+
+	# Audit the licenses of a source directory's dependencies without scanning for vulnerabilities
+	$ {{.Name}} licenses --allowlist="MIT,Apache-2.0" -r <source_directory>
+
+	# Print a CycloneDX SBOM of the modules that osv-scanner was built from
+	$ {{.Name}} version --sbom
+
 	For full usage details, please refer to the help command of each subcommand (e.g. {{.Name}} scan --help).
 
 VERSION:
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/exitcode"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/telemetry"
 	"github.com/google/osv-scanner/v2/internal/testlogger"
 	"github.com/google/osv-scanner/v2/internal/version"
 	"github.com/google/osv-scanner/v2/pkg/osvscanner"
@@ -20,6 +24,9 @@ var (
 	date   = "n/a"
 )
 
+// telemetryShutdownTimeout is how long to wait for telemetry to be exported before exiting
+const telemetryShutdownTimeout = 5 * time.Second
+
 type CommandBuilder = func(stdout, stderr io.Writer) *cli.Command
 
 func Run(args []string, stdout, stderr io.Writer, commands []CommandBuilder) int {
@@ -89,24 +96,52 @@ func Run(args []string, stdout, stderr io.Writer, commands []CommandBuilder) int
 
 	args = insertDefaultCommand(args, app.Commands, app.DefaultCommand, stderr)
 
-	err := app.Run(context.Background(), args)
+	if os.Getenv(selfScanEnvVar) == "true" {
+		selfScan(context.Background(), stderr)
+	}
+
+	shutdownTelemetry, err := telemetry.Setup(context.Background())
+	if err != nil {
+		cmdlogger.Warnf("Failed to set up telemetry: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), telemetryShutdownTimeout)
+		defer cancel()
+
+		if err := shutdownTelemetry(ctx); err != nil {
+			cmdlogger.Warnf("Failed to export telemetry: %v", err)
+		}
+	}()
+
+	// the codes that the command was run with are returned even if it succeeded
+	exitCodes, err := exitcode.SplitCodes(app.Run(context.Background(), args))
 
 	// if the config is invalid, it's possible that is why any other errors
 	// happened so that exit code takes priority
 	if logHandler.HasErroredBecauseInvalidConfig() {
-		return 130
+		return exitCodes.Code(exitcode.ConfigError)
 	}
 
 	if err != nil {
-		switch {
-		case errors.Is(err, osvscanner.ErrVulnerabilitiesFound):
-			return 1
-		case errors.Is(err, osvscanner.ErrNoPackagesFound):
-			cmdlogger.Errorf("No package sources found, --help for usage information.")
-			return 128
-		case errors.Is(err, osvscanner.ErrAPIFailed):
+		// the results are incomplete if some packages could not be looked up,
+		// even if vulnerabilities were found in the rest of them
+		if errors.Is(err, osvscanner.ErrAPIFailed) {
+			if errors.Is(err, osvscanner.ErrPartialAPIFailure) {
+				// the error is joined with the findings of the rest of the packages
+				err = osvscanner.ErrPartialAPIFailure
+			}
 			cmdlogger.Errorf("%v", err)
-			return 129
+
+			return exitCodes.Code(exitcode.NetworkFailure)
+		}
+
+		if code, ok := exitcode.FindingsCode(err, exitCodes); ok {
+			return code
+		}
+
+		if errors.Is(err, osvscanner.ErrNoPackagesFound) {
+			cmdlogger.Errorf("No package sources found, --help for usage information.")
+			return exitCodes.Code(exitcode.NoPackagesFound)
 		}
 		cmdlogger.Errorf("%v", err)
 	}
@@ -114,7 +149,7 @@ func Run(args []string, stdout, stderr io.Writer, commands []CommandBuilder) int
 	// if we've been told to print an error, and not already exited with
 	// a specific error code, then exit with a generic non-zero code
 	if logHandler.HasErrored() {
-		return 127
+		return exitCodes.Code(exitcode.Error)
 	}
 
 	return 0
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/google/osv-scanner/v2/internal/selfsbom"
+	"github.com/google/osv-scanner/v2/internal/version"
+)
+
+// selfScanEnvVar enables checking the modules of the running binary for known
+// vulnerabilities at startup, when set to "true"
+const selfScanEnvVar = "OSV_SCANNER_SELF_SCAN"
+
+// selfScan warns about the modules of the running binary that have known vulnerabilities;
+// failing to check them is only warned about, as it should not prevent the scan itself.
+//
+// Warnings are written to stderr, as this happens before the flags of the command have been
+// parsed, so it is not yet known if stdout is being used for structured output.
+func selfScan(ctx context.Context, stderr io.Writer) {
+	info, err := selfsbom.Read()
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to check osv-scanner for vulnerabilities: %s\n", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	matcher := &osvmatcher.OSVMatcher{
+		Client:              *osvmatcher.NewRetryingOSVClient(&osvmatcher.RetryTransport{Config: osvmatcher.DefaultRetryConfig()}, "osv-scanner_self-scan/"+version.OSVVersion),
+		InitialQueryTimeout: time.Minute,
+		Pipeline:            osvmatcher.DefaultPipelineConfig(),
+	}
+
+	vulnerable, err := selfsbom.Check(ctx, matcher, info)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to check osv-scanner for vulnerabilities: %s\n", err)
+		return
+	}
+
+	for _, v := range vulnerable {
+		fmt.Fprintf(
+			stderr,
+			"osv-scanner was built with %s@%s, which has known vulnerabilities: %s\n",
+			v.Module.Path,
+			v.Module.Version,
+			strings.Join(v.IDs, ", "),
+		)
+	}
+
+	if len(vulnerable) > 0 {
+		fmt.Fprintln(stderr, "Update osv-scanner to the latest version, or build it with patched modules")
+	}
+}
@@ -0,0 +1,365 @@
+// Package exitcode maps the outcome of a command to the code that osv-scanner exits with,
+// according to the exit code policy that the command was run with.
+package exitcode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/gomod"
+	"github.com/google/osv-scanner/v2/internal/imagediff"
+	"github.com/google/osv-scanner/v2/internal/licenseaudit"
+	"github.com/google/osv-scanner/v2/internal/sbomvalidate"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+)
+
+// Policy determines the codes that findings exit with
+type Policy string
+
+const (
+	// PolicyDefault exits with 1 for any finding, other than only ignored
+	// vulnerabilities being found, which are not reported
+	PolicyDefault Policy = "default"
+	// PolicyDistinct exits with a distinct code for each finding, using the
+	// lowest code if there is more than one
+	PolicyDistinct Policy = "distinct"
+	// PolicyBitmask exits with a distinct bit for each finding, combining
+	// the bits of every finding if there is more than one
+	PolicyBitmask Policy = "bitmask"
+)
+
+// Policies returns the names of every policy
+func Policies() []string {
+	return []string{string(PolicyDefault), string(PolicyDistinct), string(PolicyBitmask)}
+}
+
+// Condition is an outcome of a command that it exits with a code for
+type Condition string
+
+const (
+	Success                Condition = "success"
+	VulnerabilitiesFound   Condition = "vulnerabilities-found"
+	LicenseViolationsFound Condition = "license-violations-found"
+	PartialScan            Condition = "partial-scan"
+	IgnoredVulnsFound      Condition = "only-ignored-vulnerabilities-found"
+	UncalledVulnsFound     Condition = "only-uncalled-vulnerabilities-found"
+	Error                  Condition = "error"
+	NoPackagesFound        Condition = "no-packages-found"
+	NetworkFailure         Condition = "network-failure"
+	ConfigError            Condition = "config-error"
+)
+
+// The codes of the conditions that stop a command, which are the same for every policy
+const (
+	ErrorCode          = 127
+	NoPackagesCode     = 128
+	NetworkFailureCode = 129
+	ConfigErrorCode    = 130
+)
+
+// findings are the conditions that are determined by the policy, in order of priority
+var findings = []Condition{VulnerabilitiesFound, LicenseViolationsFound, PartialScan, IgnoredVulnsFound, UncalledVulnsFound}
+
+// stops are the conditions that stop a command, along with the codes they exit with by default
+var stops = map[Condition]int{
+	Error:           ErrorCode,
+	NoPackagesFound: NoPackagesCode,
+	NetworkFailure:  NetworkFailureCode,
+	ConfigError:     ConfigErrorCode,
+}
+
+var descriptions = map[Condition]string{
+	Success:                "no vulnerabilities or other issues that fail the scan were found",
+	VulnerabilitiesFound:   "vulnerabilities were found that fail the scan",
+	LicenseViolationsFound: "packages were found with licenses that are not allowed",
+	PartialScan:            "packages were found that could not be checked for vulnerabilities",
+	IgnoredVulnsFound:      "vulnerabilities were found, but were all ignored by config",
+	UncalledVulnsFound:     "vulnerabilities were found, but none of them are called by the code that was scanned",
+	Error:                  "an error occurred, so the results may be missing or incomplete",
+	NoPackagesFound:        "no packages were found to scan",
+	NetworkFailure:         "the vulnerabilities of packages could not be looked up",
+	ConfigError:            "a config file could not be loaded",
+}
+
+// findingCode returns the code that the finding exits with under the policy
+func findingCode(policy Policy, finding Condition) int {
+	i := slices.Index(findings, finding)
+
+	switch policy {
+	case PolicyDistinct:
+		return i + 1
+	case PolicyBitmask:
+		return 1 << i
+	default:
+		if finding == IgnoredVulnsFound || finding == UncalledVulnsFound {
+			return 0
+		}
+
+		return 1
+	}
+}
+
+// policyError annotates an error with the policy that the command which returned it was run with
+type policyError struct {
+	policy Policy
+	err    error
+}
+
+func (e *policyError) Error() string { return e.err.Error() }
+func (e *policyError) Unwrap() error { return e.err }
+
+// WithPolicy annotates err with the policy that the code it exits with should be determined by
+func WithPolicy(err error, policy Policy) error {
+	if err == nil || policy == PolicyDefault {
+		return err
+	}
+
+	return &policyError{policy: policy, err: err}
+}
+
+// Codes are the codes that conditions exit with in place of the codes of the policy
+type Codes map[Condition]int
+
+// ParseCodes parses codes given as condition=code (e.g. "vulnerabilities-found=3") on
+// top of the given codes, such as those set by config, with later codes taking precedence
+func ParseCodes(base map[string]int, values []string) (Codes, error) {
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string]int)
+	}
+
+	for _, value := range values {
+		condition, code, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid exit code %q - must be in the form condition=code", value)
+		}
+
+		n, err := strconv.Atoi(code)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exit code %q - %q is not a number", value, code)
+		}
+
+		merged[condition] = n
+	}
+
+	codes := make(Codes, len(merged))
+	for condition, code := range merged {
+		c := Condition(condition)
+		if _, ok := stops[c]; !ok && !slices.Contains(findings, c) {
+			return nil, fmt.Errorf("unsupported exit code condition \"%s\" - must be one of: %s", condition, strings.Join(conditionNames(), ", "))
+		}
+
+		if code < 0 || code > 255 {
+			return nil, fmt.Errorf("invalid exit code %d for %s - must be between 0 and 255", code, condition)
+		}
+
+		codes[c] = code
+	}
+
+	return codes, nil
+}
+
+// conditionNames returns the names of every condition that can be given a code
+func conditionNames() []string {
+	names := make([]string, 0, len(findings)+len(stops))
+	for _, finding := range findings {
+		names = append(names, string(finding))
+	}
+	for _, stop := range slices.Sorted(maps.Keys(stops)) {
+		names = append(names, string(stop))
+	}
+
+	return names
+}
+
+// Reports returns whether the condition is reported under the policy, as the default
+// policy only reports ignored and uncalled vulnerabilities if they are given a code
+func (c Codes) Reports(policy Policy, condition Condition) bool {
+	_, ok := c[condition]
+
+	return ok || policy != PolicyDefault
+}
+
+// Code returns the code that a condition which stops a command exits with
+func (c Codes) Code(condition Condition) int {
+	if code, ok := c[condition]; ok {
+		return code
+	}
+
+	return stops[condition]
+}
+
+// code returns the code that the finding exits with under the policy
+func (c Codes) code(policy Policy, finding Condition) int {
+	if code, ok := c[finding]; ok {
+		return code
+	}
+
+	return findingCode(policy, finding)
+}
+
+// codesError annotates the outcome of a command with the codes that it was run with,
+// which is done even if the command succeeded so that the code of errors that are
+// only logged can be determined
+type codesError struct {
+	codes Codes
+	err   error
+}
+
+func (e *codesError) Error() string {
+	if e.err == nil {
+		return ""
+	}
+
+	return e.err.Error()
+}
+func (e *codesError) Unwrap() error { return e.err }
+
+// WithCodes annotates the outcome of a command, which may be nil, with the codes that it was run with
+func WithCodes(err error, codes Codes) error {
+	if len(codes) == 0 {
+		return err
+	}
+
+	return &codesError{codes: codes, err: err}
+}
+
+// SplitCodes returns the codes that the outcome of a command was annotated with
+// by WithCodes, along with the outcome itself, which may be nil
+func SplitCodes(err error) (Codes, error) {
+	var ce *codesError
+	if errors.As(err, &ce) {
+		return ce.codes, ce.err
+	}
+
+	return nil, err
+}
+
+// FindingsCode returns the code to exit with for the findings reported by err,
+// returning false if err does not report any findings
+func FindingsCode(err error, codes Codes) (int, bool) {
+	found := Findings(err)
+	if len(found) == 0 {
+		return 0, false
+	}
+
+	policy := PolicyDefault
+	var pe *policyError
+	if errors.As(err, &pe) {
+		policy = pe.policy
+	}
+
+	if policy != PolicyBitmask {
+		return codes.code(policy, found[0]), true
+	}
+
+	code := 0
+	for _, finding := range found {
+		code |= codes.code(policy, finding)
+	}
+
+	return code, true
+}
+
+// Findings returns the findings reported by err in order of priority
+func Findings(err error) []Condition {
+	var found []Condition
+
+	if hasVulnerabilities(err) ||
+		errors.Is(err, imagediff.ErrVulnerabilitiesAdded) ||
+		errors.Is(err, gomod.ErrVulnerabilitiesFound) {
+		found = append(found, VulnerabilitiesFound)
+	}
+
+	if errors.Is(err, osvscanner.ErrLicenseViolationsFound) || errors.Is(err, licenseaudit.ErrViolationsFound) {
+		found = append(found, LicenseViolationsFound)
+	}
+
+	if errors.Is(err, osvscanner.ErrUnscannedPackagesFound) || errors.Is(err, sbomvalidate.ErrIssuesFound) {
+		found = append(found, PartialScan)
+	}
+
+	// vulnerabilities that are uncalled or ignored only matter if nothing else was found
+	if len(found) == 0 {
+		if errors.Is(err, osvscanner.ErrUncalledVulnerabilitiesFound) {
+			found = append(found, UncalledVulnsFound)
+		}
+
+		if errors.Is(err, osvscanner.ErrIgnoredVulnerabilitiesFound) {
+			found = append(found, IgnoredVulnsFound)
+		}
+	}
+
+	return found
+}
+
+// hasVulnerabilities returns whether err reports vulnerabilities being found, as
+// opposed to only license violations, which also wrap ErrVulnerabilitiesFound
+func hasVulnerabilities(err error) bool {
+	//nolint:errorlint // the sentinels themselves are being looked for
+	switch err {
+	case nil, osvscanner.ErrLicenseViolationsFound:
+		return false
+	case osvscanner.ErrVulnerabilitiesFound:
+		return true
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return slices.ContainsFunc(joined.Unwrap(), hasVulnerabilities)
+	}
+
+	return hasVulnerabilities(errors.Unwrap(err))
+}
+
+// Explanation describes the code that each condition exits with under a policy
+type Explanation struct {
+	Policy Policy `json:"policy"`
+	// Combined is whether the codes of multiple findings are combined with a bitwise
+	// OR, rather than the lowest code of the findings being used
+	Combined bool            `json:"combined"`
+	Codes    []ExplainedCode `json:"codes"`
+}
+
+// ExplainedCode is the code that a condition exits with
+type ExplainedCode struct {
+	Code        int       `json:"code"`
+	Condition   Condition `json:"condition"`
+	Description string    `json:"description"`
+}
+
+// Explain returns the code that each condition exits with under the policy and codes
+func Explain(policy Policy, codes Codes) Explanation {
+	explanation := Explanation{
+		Policy:   policy,
+		Combined: policy == PolicyBitmask,
+		Codes:    []ExplainedCode{{Code: 0, Condition: Success}},
+	}
+
+	for _, finding := range findings {
+		explanation.Codes = append(explanation.Codes, ExplainedCode{Code: codes.code(policy, finding), Condition: finding})
+	}
+
+	for _, stop := range []Condition{Error, NoPackagesFound, NetworkFailure, ConfigError} {
+		explanation.Codes = append(explanation.Codes, ExplainedCode{Code: codes.Code(stop), Condition: stop})
+	}
+
+	for i := range explanation.Codes {
+		explanation.Codes[i].Description = descriptions[explanation.Codes[i].Condition]
+	}
+
+	return explanation
+}
+
+// PrintExplanation writes the explanation of the policy and codes to w as JSON
+func PrintExplanation(w io.Writer, policy Policy, codes Codes) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(Explain(policy, codes))
+}
@@ -0,0 +1,305 @@
+package exitcode_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/exitcode"
+	"github.com/google/osv-scanner/v2/internal/licenseaudit"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+)
+
+func TestFindings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want []exitcode.Condition
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "other error",
+			err:  errors.New("oh no"),
+			want: nil,
+		},
+		{
+			name: "vulnerabilities",
+			err:  osvscanner.ErrVulnerabilitiesFound,
+			want: []exitcode.Condition{exitcode.VulnerabilitiesFound},
+		},
+		{
+			name: "only license violations",
+			err:  osvscanner.ErrLicenseViolationsFound,
+			want: []exitcode.Condition{exitcode.LicenseViolationsFound},
+		},
+		{
+			name: "license violations from the licenses command",
+			err:  licenseaudit.ErrViolationsFound,
+			want: []exitcode.Condition{exitcode.LicenseViolationsFound},
+		},
+		{
+			name: "vulnerabilities and license violations",
+			err:  errors.Join(osvscanner.ErrVulnerabilitiesFound, osvscanner.ErrLicenseViolationsFound),
+			want: []exitcode.Condition{exitcode.VulnerabilitiesFound, exitcode.LicenseViolationsFound},
+		},
+		{
+			name: "license violations and unscanned packages",
+			err:  errors.Join(osvscanner.ErrLicenseViolationsFound, osvscanner.ErrUnscannedPackagesFound),
+			want: []exitcode.Condition{exitcode.LicenseViolationsFound, exitcode.PartialScan},
+		},
+		{
+			name: "wrapped vulnerabilities",
+			err:  fmt.Errorf("target: %w", osvscanner.ErrVulnerabilitiesFound),
+			want: []exitcode.Condition{exitcode.VulnerabilitiesFound},
+		},
+		{
+			name: "only ignored vulnerabilities",
+			err:  osvscanner.ErrIgnoredVulnerabilitiesFound,
+			want: []exitcode.Condition{exitcode.IgnoredVulnsFound},
+		},
+		{
+			name: "only uncalled vulnerabilities",
+			err:  osvscanner.ErrUncalledVulnerabilitiesFound,
+			want: []exitcode.Condition{exitcode.UncalledVulnsFound},
+		},
+		{
+			name: "uncalled and ignored vulnerabilities",
+			err:  errors.Join(osvscanner.ErrIgnoredVulnerabilitiesFound, osvscanner.ErrUncalledVulnerabilitiesFound),
+			want: []exitcode.Condition{exitcode.UncalledVulnsFound, exitcode.IgnoredVulnsFound},
+		},
+		{
+			name: "ignored vulnerabilities along with other findings",
+			err:  errors.Join(osvscanner.ErrIgnoredVulnerabilitiesFound, osvscanner.ErrVulnerabilitiesFound),
+			want: []exitcode.Condition{exitcode.VulnerabilitiesFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := exitcode.Findings(tt.err)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Findings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFindingsCode(t *testing.T) {
+	t.Parallel()
+
+	vulnsAndLicenses := errors.Join(osvscanner.ErrVulnerabilitiesFound, osvscanner.ErrLicenseViolationsFound)
+
+	tests := []struct {
+		name   string
+		err    error
+		codes  exitcode.Codes
+		want   int
+		wantOk bool
+	}{
+		{
+			name:   "not a finding",
+			err:    exitcode.WithPolicy(errors.New("oh no"), exitcode.PolicyDistinct),
+			want:   0,
+			wantOk: false,
+		},
+		{
+			name:   "default policy",
+			err:    osvscanner.ErrLicenseViolationsFound,
+			want:   1,
+			wantOk: true,
+		},
+		{
+			name:   "ignored vulnerabilities with the default policy",
+			err:    osvscanner.ErrIgnoredVulnerabilitiesFound,
+			want:   0,
+			wantOk: true,
+		},
+		{
+			name:   "distinct policy",
+			err:    exitcode.WithPolicy(osvscanner.ErrLicenseViolationsFound, exitcode.PolicyDistinct),
+			want:   2,
+			wantOk: true,
+		},
+		{
+			name:   "distinct policy with multiple findings",
+			err:    exitcode.WithPolicy(vulnsAndLicenses, exitcode.PolicyDistinct),
+			want:   1,
+			wantOk: true,
+		},
+		{
+			name:   "distinct policy with ignored vulnerabilities",
+			err:    exitcode.WithPolicy(osvscanner.ErrIgnoredVulnerabilitiesFound, exitcode.PolicyDistinct),
+			want:   4,
+			wantOk: true,
+		},
+		{
+			name:   "bitmask policy with multiple findings",
+			err:    exitcode.WithPolicy(errors.Join(vulnsAndLicenses, osvscanner.ErrUnscannedPackagesFound), exitcode.PolicyBitmask),
+			want:   7,
+			wantOk: true,
+		},
+		{
+			name:   "bitmask policy with ignored vulnerabilities",
+			err:    exitcode.WithPolicy(osvscanner.ErrIgnoredVulnerabilitiesFound, exitcode.PolicyBitmask),
+			want:   8,
+			wantOk: true,
+		},
+		{
+			name:   "uncalled vulnerabilities with the default policy",
+			err:    osvscanner.ErrUncalledVulnerabilitiesFound,
+			want:   0,
+			wantOk: true,
+		},
+		{
+			name:   "distinct policy with uncalled vulnerabilities",
+			err:    exitcode.WithPolicy(osvscanner.ErrUncalledVulnerabilitiesFound, exitcode.PolicyDistinct),
+			want:   5,
+			wantOk: true,
+		},
+		{
+			name:   "codes take precedence over the default policy",
+			err:    osvscanner.ErrUncalledVulnerabilitiesFound,
+			codes:  exitcode.Codes{exitcode.UncalledVulnsFound: 3},
+			want:   3,
+			wantOk: true,
+		},
+		{
+			name:   "codes take precedence over the distinct policy",
+			err:    exitcode.WithPolicy(vulnsAndLicenses, exitcode.PolicyDistinct),
+			codes:  exitcode.Codes{exitcode.VulnerabilitiesFound: 10},
+			want:   10,
+			wantOk: true,
+		},
+		{
+			name:   "codes are combined by the bitmask policy",
+			err:    exitcode.WithPolicy(vulnsAndLicenses, exitcode.PolicyBitmask),
+			codes:  exitcode.Codes{exitcode.LicenseViolationsFound: 32},
+			want:   33,
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := exitcode.FindingsCode(tt.err, tt.codes)
+
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("FindingsCode() = %d, %t, want %d, %t", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestParseCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		base    map[string]int
+		values  []string
+		want    exitcode.Codes
+		wantErr bool
+	}{
+		{
+			name: "no codes",
+			want: exitcode.Codes{},
+		},
+		{
+			name:   "codes of findings and of stopping",
+			values: []string{"vulnerabilities-found=3", "only-uncalled-vulnerabilities-found=4", "error=2"},
+			want: exitcode.Codes{
+				exitcode.VulnerabilitiesFound: 3,
+				exitcode.UncalledVulnsFound:   4,
+				exitcode.Error:                2,
+			},
+		},
+		{
+			name:   "values take precedence over the base codes",
+			base:   map[string]int{"vulnerabilities-found": 3, "partial-scan": 5},
+			values: []string{"vulnerabilities-found=10"},
+			want: exitcode.Codes{
+				exitcode.VulnerabilitiesFound: 10,
+				exitcode.PartialScan:          5,
+			},
+		},
+		{
+			name:    "missing code",
+			values:  []string{"vulnerabilities-found"},
+			wantErr: true,
+		},
+		{
+			name:    "code is not a number",
+			values:  []string{"vulnerabilities-found=three"},
+			wantErr: true,
+		},
+		{
+			name:    "code is out of range",
+			values:  []string{"vulnerabilities-found=256"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown condition",
+			base:    map[string]int{"vulnerabilities-missing": 3},
+			wantErr: true,
+		},
+		{
+			name:    "success cannot be given a code",
+			values:  []string{"success=1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := exitcode.ParseCodes(tt.base, tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseCodes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSplitCodes(t *testing.T) {
+	t.Parallel()
+
+	codes := exitcode.Codes{exitcode.Error: 2}
+
+	gotCodes, gotErr := exitcode.SplitCodes(exitcode.WithCodes(nil, codes))
+	if gotErr != nil {
+		t.Errorf("SplitCodes() error = %v, want nil", gotErr)
+	}
+	if diff := cmp.Diff(codes, gotCodes); diff != "" {
+		t.Errorf("SplitCodes() mismatch (-want +got):\n%s", diff)
+	}
+
+	err := exitcode.WithPolicy(osvscanner.ErrVulnerabilitiesFound, exitcode.PolicyDistinct)
+	gotCodes, gotErr = exitcode.SplitCodes(exitcode.WithCodes(err, codes))
+	if !errors.Is(gotErr, osvscanner.ErrVulnerabilitiesFound) {
+		t.Errorf("SplitCodes() error = %v, want %v", gotErr, osvscanner.ErrVulnerabilitiesFound)
+	}
+	if diff := cmp.Diff(codes, gotCodes); diff != "" {
+		t.Errorf("SplitCodes() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := gotCodes.Code(exitcode.Error); got != 2 {
+		t.Errorf("Code(%s) = %d, want 2", exitcode.Error, got)
+	}
+	if got := gotCodes.Code(exitcode.NoPackagesFound); got != exitcode.NoPackagesCode {
+		t.Errorf("Code(%s) = %d, want %d", exitcode.NoPackagesFound, got, exitcode.NoPackagesCode)
+	}
+}
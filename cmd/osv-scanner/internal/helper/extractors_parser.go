@@ -4,6 +4,7 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scanner/v2/internal/builders"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/external"
 )
 
 var presets = map[string][]string{
@@ -42,3 +43,20 @@ func ResolveEnabledExtractors(enabledExtractors []string, disabledExtractors []s
 
 	return builders.BuildExtractors(asSlice)
 }
+
+// ResolveExternalExtractors creates an extractor for each definition of an
+// external command, in the form of "<pattern>=<command>"
+func ResolveExternalExtractors(definitions []string) ([]filesystem.Extractor, error) {
+	extractors := make([]filesystem.Extractor, 0, len(definitions))
+
+	for _, definition := range definitions {
+		extractor, err := external.Parse(definition)
+		if err != nil {
+			return nil, err
+		}
+
+		extractors = append(extractors, extractor)
+	}
+
+	return extractors, nil
+}
@@ -15,7 +15,13 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda/condameta"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/jsbundle"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/nodemodules"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/php/composerinstalled"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/os/nix"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/runtime/electron"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/detect"
 )
 
 func TestResolveEnabledExtractors(t *testing.T) {
@@ -80,12 +86,17 @@ func TestResolveEnabledExtractors(t *testing.T) {
 			},
 			want: []string{
 				wheelegg.Name,
+				condameta.Name,
 				archive.Name,
 				gobinary.Name,
 				nodemodules.Name,
+				jsbundle.Name,
+				composerinstalled.Name,
 				cargoauditable.Name,
+				electron.Name,
 				apk.Name,
 				dpkg.Name,
+				nix.Name,
 			},
 		},
 		{
@@ -96,12 +107,17 @@ func TestResolveEnabledExtractors(t *testing.T) {
 			},
 			want: []string{
 				wheelegg.Name,
+				condameta.Name,
 				archive.Name,
 				gobinary.Name,
 				nodemodules.Name,
+				jsbundle.Name,
+				composerinstalled.Name,
 				cargoauditable.Name,
+				electron.Name,
 				apk.Name,
 				dpkg.Name,
+				nix.Name,
 			},
 		},
 		{
@@ -119,10 +135,15 @@ func TestResolveEnabledExtractors(t *testing.T) {
 				disabledExtractors: []string{wheelegg.Name, archive.Name, cargoauditable.Name},
 			},
 			want: []string{
+				condameta.Name,
 				gobinary.Name,
 				nodemodules.Name,
+				jsbundle.Name,
+				composerinstalled.Name,
+				electron.Name,
 				apk.Name,
 				dpkg.Name,
+				nix.Name,
 			},
 		},
 		//
@@ -135,13 +156,19 @@ func TestResolveEnabledExtractors(t *testing.T) {
 			want: []string{
 				spdx.Name,
 				cdx.Name,
+				detect.Name,
 				wheelegg.Name,
+				condameta.Name,
 				archive.Name,
 				gobinary.Name,
 				nodemodules.Name,
+				jsbundle.Name,
+				composerinstalled.Name,
 				cargoauditable.Name,
+				electron.Name,
 				apk.Name,
 				dpkg.Name,
+				nix.Name,
 			},
 		},
 		//
@@ -2,11 +2,17 @@ package helper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/exitcode"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/output"
 	"github.com/google/osv-scanner/v2/internal/reporter"
 	"github.com/urfave/cli/v3"
 )
@@ -16,6 +22,8 @@ import (
 var offlineFlags = map[string]string{
 	"offline-vulnerabilities": "true",
 	"no-resolve":              "true",
+	"epss":                    "false",
+	"kev-refresh":             "false",
 }
 
 // a "boolean or list" flag whose presence indicates a summary of licenses should
@@ -52,11 +60,24 @@ func (g *allowedLicencesFlag) String() string {
 // BuildCommonScanFlags returns a slice of flags which are common to all scan (sub)commands
 func BuildCommonScanFlags(defaultExtractors []string) []cli.Flag {
 	return []cli.Flag{
-		&cli.StringFlag{
+		&cli.StringSliceFlag{
 			Name:      "config",
-			Usage:     "set/override config file",
+			Usage:     "set/override config file; can be repeated to merge multiple config files, with later files taking precedence",
 			TakesFile: true,
 		},
+		&cli.StringFlag{
+			Name:  "profile",
+			Usage: "name of the profile to use from the config files, which is merged on top of the rest of each config file that defines it",
+		},
+		&cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "attaches metadata describing what was scanned to the results, as key=value (e.g. env=prod); can be specified multiple times",
+			Action: func(_ context.Context, _ *cli.Command, values []string) error {
+				_, err := parseLabels(values)
+
+				return err
+			},
+		},
 		&cli.StringFlag{
 			Name:    "format",
 			Aliases: []string{"f"},
@@ -64,7 +85,7 @@ func BuildCommonScanFlags(defaultExtractors []string) []cli.Flag {
 			Value:   "table",
 			Action: func(_ context.Context, _ *cli.Command, s string) error {
 				if slices.Contains(reporter.Format(), s) {
-					if s != "vertical" && s != "table" && s != "markdown" {
+					if !reporter.IsHumanReadable(s) {
 						cmdlogger.SendEverythingToStderr()
 					}
 
@@ -91,6 +112,22 @@ func BuildCommonScanFlags(defaultExtractors []string) []cli.Flag {
 			Usage:     "saves the result to the given file path",
 			TakesFile: true,
 		},
+		&cli.BoolFlag{
+			Name:  "tee",
+			Usage: "also print the results to stdout as a table when saving them to a file with --output, so a machine-readable format can be saved in the same run",
+			Action: func(_ context.Context, cmd *cli.Command, _ bool) error {
+				if cmd.String("output") == "" {
+					return errors.New("--tee can only be used with --output")
+				}
+
+				return nil
+			},
+		},
+		&cli.StringFlag{
+			Name:      "output-db",
+			Usage:     "appends the result to the SQLite database at the given file path",
+			TakesFile: true,
+		},
 		&cli.StringFlag{
 			Name:  "verbosity",
 			Usage: "specify the level of information that should be provided during runtime; value can be: " + strings.Join(cmdlogger.Levels(), ", "),
@@ -146,6 +183,15 @@ func BuildCommonScanFlags(defaultExtractors []string) []cli.Flag {
 			Usage:  "sets the path that local databases should be stored",
 			Hidden: true,
 		},
+		&cli.StringSliceFlag{
+			Name:      "local-db-extra",
+			Usage:     "loads additional OSV advisories from the given directory into the local databases; can be repeated",
+			TakesFile: true,
+		},
+		&cli.StringSliceFlag{
+			Name:  "vuln-source",
+			Usage: "checks packages for vulnerabilities against the given source, which is one of osv (the OSV.dev API), local (the local databases), or feed:<dir> (a directory of OSV advisories); can be repeated to combine sources, in order of precedence",
+		},
 		&cli.BoolFlag{
 			Name:  "no-resolve",
 			Usage: "disable transitive dependency resolution of manifest files",
@@ -154,6 +200,122 @@ func BuildCommonScanFlags(defaultExtractors []string) []cli.Flag {
 			Name:  "allow-no-lockfiles",
 			Usage: "has the scanner consider no lockfiles being found as ok",
 		},
+		&cli.BoolFlag{
+			Name:  "fail-on-unscanned-packages",
+			Usage: "exit with a non-zero code if any packages could not be checked for vulnerabilities (e.g. because they have no version)",
+		},
+		&cli.StringFlag{
+			Name:  "exit-code-policy",
+			Usage: "sets the exit codes of findings, where default exits with 1 for any finding, distinct exits with a different code for each finding, and bitmask combines a different bit for each finding; value can be: " + strings.Join(exitcode.Policies(), ", "),
+			Value: string(exitcode.PolicyDefault),
+			Action: func(_ context.Context, _ *cli.Command, s string) error {
+				if !slices.Contains(exitcode.Policies(), s) {
+					return fmt.Errorf("unsupported exit code policy \"%s\" - must be one of: %s", s, strings.Join(exitcode.Policies(), ", "))
+				}
+
+				return nil
+			},
+		},
+		&cli.StringSliceFlag{
+			Name:  "exit-code",
+			Usage: "sets the exit code of a condition in place of the --exit-code-policy, as condition=code (e.g. vulnerabilities-found=3); see --explain-exit-code for the conditions",
+			Action: func(_ context.Context, _ *cli.Command, values []string) error {
+				_, err := exitcode.ParseCodes(nil, values)
+
+				return err
+			},
+		},
+		&cli.BoolFlag{
+			Name:  "explain-exit-code",
+			Usage: "print the exit codes of the --exit-code-policy and --exit-code flags as json, then exit without scanning",
+		},
+		&cli.StringSliceFlag{
+			Name:  "fail-on-severity",
+			Usage: "only exit with a non-zero code for vulnerabilities of at least this severity; use group:SEVERITY (e.g. dev:CRITICAL) to set the severity for a dependency group",
+			Action: func(_ context.Context, _ *cli.Command, values []string) error {
+				_, err := config.ParseSeverityThresholds(values)
+
+				return err
+			},
+		},
+		&cli.IntFlag{
+			Name:  "suppress-unfixed-after",
+			Usage: "report vulnerabilities that have had no fix published for more than this many days without exiting with a non-zero code for them",
+			Action: func(_ context.Context, _ *cli.Command, value int) error {
+				if value < 0 {
+					return fmt.Errorf("--suppress-unfixed-after must not be negative, got %d", value)
+				}
+
+				return nil
+			},
+		},
+		&cli.StringFlag{
+			Name:      "baseline",
+			Usage:     "compare the results against the json output of a previous scan, marking which vulnerabilities are new since then and when each was first seen",
+			TakesFile: true,
+		},
+		&cli.BoolFlag{
+			Name:  "only-new",
+			Usage: "report vulnerabilities that were found by the --baseline scan without exiting with a non-zero code for them",
+			Action: func(_ context.Context, cmd *cli.Command, value bool) error {
+				if value && cmd.String("baseline") == "" {
+					return errors.New("--only-new requires --baseline")
+				}
+
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:  "epss",
+			Usage: "look up the EPSS score of the CVEs of each vulnerability to help with prioritizing them",
+		},
+		&cli.FloatFlag{
+			Name:  "fail-on-epss",
+			Usage: "only exit with a non-zero code for vulnerabilities with an EPSS score of at least this probability (between 0 and 1); implies --epss",
+			Action: func(_ context.Context, _ *cli.Command, value float64) error {
+				if value < 0 || value > 1 {
+					return fmt.Errorf("--fail-on-epss must be between 0 and 1, got %v", value)
+				}
+
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:  "kev",
+			Usage: "flag vulnerabilities that are in the Known Exploited Vulnerabilities catalog of CISA",
+		},
+		&cli.BoolFlag{
+			Name:  "kev-refresh",
+			Usage: "fetch the latest version of the KEV catalog instead of using the snapshot bundled with osv-scanner",
+		},
+		&cli.BoolFlag{
+			Name:  "fix-references",
+			Usage: "include the upstream fix commits, patches, and advisories of each vulnerability in the json output",
+		},
+		&cli.BoolFlag{
+			Name:  "fail-on-kev",
+			Usage: "only exit with a non-zero code for vulnerabilities that are in the KEV catalog; implies --kev",
+		},
+		&cli.BoolFlag{
+			Name:  "risk-score",
+			Usage: "include the aggregate risk score of all vulnerabilities, weighted by their severity and any EPSS scores and KEV entries, in the summary",
+		},
+		&cli.FloatFlag{
+			Name:  "max-risk-score",
+			Usage: "only exit with a non-zero code if the aggregate risk score is higher than this, instead of for any single vulnerability; implies --risk-score",
+			Action: func(_ context.Context, _ *cli.Command, value float64) error {
+				if value < 0 {
+					return fmt.Errorf("--max-risk-score must not be negative, got %v", value)
+				}
+
+				return nil
+			},
+		},
+		&cli.StringFlag{
+			Name:      "policy",
+			Usage:     "evaluate the results against a CEL policy, which decides whether the scan fails and can report custom messages, in place of the vulnerabilities found and the other --fail-on flags",
+			TakesFile: true,
+		},
 		&cli.BoolFlag{
 			Name:  "all-packages",
 			Usage: "when json output is selected, prints all packages",
@@ -162,11 +324,71 @@ func BuildCommonScanFlags(defaultExtractors []string) []cli.Flag {
 			Name:  "all-vulns",
 			Usage: "show all vulnerabilities including unimportant and uncalled ones",
 		},
+		&cli.BoolFlag{
+			Name:  "dedupe-table",
+			Usage: "when table output is selected, merges rows for the same package version found in multiple sources",
+		},
+		&cli.BoolFlag{
+			Name:  "summary",
+			Usage: "include counts of the vulnerabilities by severity, ecosystem, fixability, and call analysis at the top of table, markdown, and html output, and as the summary of json output",
+		},
+		&cli.StringFlag{
+			Name:  "group-by",
+			Usage: "when table, markdown, or html output is selected, sets how findings are grouped (html only supports package and vuln); value can be: " + strings.Join(output.GroupByValues(), ", "),
+			Value: string(output.GroupByPackage),
+			Action: func(_ context.Context, _ *cli.Command, s string) error {
+				if !slices.Contains(output.GroupByValues(), s) {
+					return fmt.Errorf("unsupported grouping \"%s\" - must be one of: %s", s, strings.Join(output.GroupByValues(), ", "))
+				}
+
+				return nil
+			},
+		},
+		&cli.StringFlag{
+			Name:  "include-advisory-details",
+			Usage: "when json output is selected, sets how much of each advisory is included; value can be: " + strings.Join(output.AdvisoryDetailsLevels(), ", "),
+			Value: string(output.AdvisoryDetailsFull),
+			Action: func(_ context.Context, _ *cli.Command, s string) error {
+				if !slices.Contains(output.AdvisoryDetailsLevels(), s) {
+					return fmt.Errorf("unsupported advisory details \"%s\" - must be one of: %s", s, strings.Join(output.AdvisoryDetailsLevels(), ", "))
+				}
+
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:  "stats",
+			Usage: "print per-extractor statistics after scanning, and include them in json output",
+		},
+		&cli.BoolFlag{
+			Name:  "progress",
+			Usage: "report the progress of each phase of the scan to stderr, as a live status line in a terminal or as json events otherwise",
+		},
+		&cli.DurationFlag{
+			Name:  "heartbeat",
+			Usage: "when stderr is not a terminal, report the phase of the scan to stderr as a json event at this interval (e.g. 30s), so CI systems do not kill long scans for being idle",
+			Action: func(_ context.Context, _ *cli.Command, d time.Duration) error {
+				if d < 0 {
+					return fmt.Errorf("--heartbeat must not be negative, got %s", d)
+				}
+
+				return nil
+			},
+		},
+		&cli.IntFlag{
+			Name:  "api-max-attempts",
+			Usage: "number of times to attempt requests to the OSV API that fail due to rate limiting or server errors; request counts are printed with --verbosity=debug",
+			Value: osvmatcher.DefaultRetryConfig().MaxAttempts,
+		},
 		&cli.GenericFlag{
 			Name:  "licenses",
 			Usage: "report on licenses based on an allowlist",
 			Value: &allowedLicencesFlag{},
 		},
+		&cli.BoolFlag{
+			Name:  "experimental-licenses",
+			Usage: "evaluate licenses against the --licenses allowlist using SPDX expression semantics, including WITH exceptions and + operators",
+		},
 		&cli.StringSliceFlag{
 			Name:  "experimental-extractors",
 			Usage: "list of specific extractors and presets of extractors to use",
@@ -176,5 +398,14 @@ func BuildCommonScanFlags(defaultExtractors []string) []cli.Flag {
 			Name:  "experimental-disable-extractors",
 			Usage: "list of specific extractors and presets of extractors to not use",
 		},
+		&cli.StringSliceFlag{
+			Name:  "experimental-extractor-command",
+			Usage: "run a command to extract packages from files matching a pattern, in the form of <pattern>=<command>; the command is given the file on stdin and its path as the last argument, and must print the packages as json",
+			Action: func(_ context.Context, _ *cli.Command, definitions []string) error {
+				_, err := ResolveExternalExtractors(definitions)
+
+				return err
+			},
+		},
 	}
 }
@@ -2,8 +2,13 @@ package helper
 
 import (
 	"fmt"
+	"maps"
 	"strings"
 
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/exitcode"
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/reporter"
 	"github.com/google/osv-scanner/v2/internal/spdx"
 	"github.com/google/osv-scanner/v2/pkg/osvscanner"
 	"github.com/urfave/cli/v3"
@@ -20,37 +25,152 @@ func GetScanLicensesAllowlist(cmd *cli.Command) ([]string, error) {
 		return []string{}, nil
 	}
 
-	if unrecognized := spdx.Unrecognized(allowlist); len(unrecognized) > 0 {
-		return nil, fmt.Errorf("--licenses requires comma-separated spdx licenses. The following license(s) are not recognized as spdx: %s", strings.Join(unrecognized, ","))
+	unrecognized := spdx.Unrecognized(allowlist)
+	if cmd.Bool("experimental-licenses") {
+		unrecognized = spdx.UnrecognizedExpressions(allowlist)
 	}
 
-	if cmd.Bool("offline") {
-		allowlist = []string{}
+	if len(unrecognized) > 0 {
+		return nil, fmt.Errorf("--licenses requires comma-separated spdx licenses. The following license(s) are not recognized as spdx: %s", strings.Join(unrecognized, ","))
 	}
 
 	return allowlist, nil
 }
 
-func GetCommonScannerActions(cmd *cli.Command, scanLicensesAllowlist []string) osvscanner.ScannerActions {
+func GetCommonScannerActions(cmd *cli.Command, scanLicensesAllowlist []string, exitCodes exitcode.Codes) osvscanner.ScannerActions {
 	return osvscanner.ScannerActions{
-		IncludeGitRoot:     cmd.Bool("include-git-root"),
-		ConfigOverridePath: cmd.String("config"),
-		ShowAllPackages:    cmd.Bool("all-packages"),
-		ShowAllVulns:       cmd.Bool("all-vulns"),
+		IncludeGitRoot:           cmd.Bool("include-git-root"),
+		ConfigOverridePaths:      cmd.StringSlice("config"),
+		ConfigProfile:            cmd.String("profile"),
+		ShowAllPackages:          cmd.Bool("all-packages"),
+		ShowAllVulns:             cmd.Bool("all-vulns"),
+		ShowStats:                cmd.Bool("stats"),
+		ShowProgress:             cmd.Bool("progress"),
+		HeartbeatInterval:        cmd.Duration("heartbeat"),
+		FailOnSeverity:           cmd.StringSlice("fail-on-severity"),
+		SuppressUnfixedAfterDays: cmd.Int("suppress-unfixed-after"),
+		BaselinePath:             cmd.String("baseline"),
+		OnlyNew:                  cmd.Bool("only-new"),
+		EnrichEPSS:               cmd.Bool("epss"),
+		FailOnEPSS:               cmd.Float("fail-on-epss"),
+		FlagKEV:                  cmd.Bool("kev") || cmd.Bool("kev-refresh"),
+		RefreshKEV:               cmd.Bool("kev-refresh"),
+		FailOnKEV:                cmd.Bool("fail-on-kev"),
+		ShowRiskScore:            cmd.Bool("risk-score") || cmd.Float("max-risk-score") > 0,
+		MaxRiskScore:             cmd.Float("max-risk-score"),
+		PolicyPath:               cmd.String("policy"),
+		IncludeFixReferences:     cmd.Bool("fix-references"),
+		FailOnIgnoredVulns:       exitCodes.Reports(GetExitCodePolicy(cmd), exitcode.IgnoredVulnsFound),
+		FailOnUncalledVulns:      exitCodes.Reports(GetExitCodePolicy(cmd), exitcode.UncalledVulnsFound),
+		APIMaxAttempts:           cmd.Int("api-max-attempts"),
+		Labels:                   getLabels(cmd),
 
 		CompareOffline:        cmd.Bool("offline-vulnerabilities"),
 		DownloadDatabases:     cmd.Bool("download-offline-databases"),
 		LocalDBPath:           cmd.String("local-db-path"),
+		LocalDBExtraPaths:     cmd.StringSlice("local-db-extra"),
+		VulnerabilitySources:  cmd.StringSlice("vuln-source"),
 		ScanLicensesSummary:   cmd.IsSet("licenses"),
 		ScanLicensesAllowlist: scanLicensesAllowlist,
 	}
 }
 
 func GetExperimentalScannerActions(cmd *cli.Command) osvscanner.ExperimentalScannerActions {
+	return getExperimentalScannerActions(cmd, cmd.StringSlice("experimental-extractors"))
+}
+
+// GetImageExperimentalScannerActions returns the experimental scanner actions for scanning
+// container images from a command that is not the image command, which would otherwise
+// default to the extractors of that command rather than those for images
+func GetImageExperimentalScannerActions(cmd *cli.Command) osvscanner.ExperimentalScannerActions {
+	enabled := cmd.StringSlice("experimental-extractors")
+	if !cmd.IsSet("experimental-extractors") {
+		enabled = []string{"artifact"}
+	}
+
+	return getExperimentalScannerActions(cmd, enabled)
+}
+
+func getExperimentalScannerActions(cmd *cli.Command, enabledExtractors []string) osvscanner.ExperimentalScannerActions {
+	extractors := ResolveEnabledExtractors(
+		enabledExtractors,
+		cmd.StringSlice("experimental-disable-extractors"),
+	)
+
+	// the definitions have already been validated when parsing the flag
+	externalExtractors, _ := ResolveExternalExtractors(cmd.StringSlice("experimental-extractor-command"))
+
 	return osvscanner.ExperimentalScannerActions{
-		Extractors: ResolveEnabledExtractors(
-			cmd.StringSlice("experimental-extractors"),
-			cmd.StringSlice("experimental-disable-extractors"),
-		),
+		Extractors:         append(extractors, externalExtractors...),
+		LicenseExpressions: cmd.Bool("experimental-licenses"),
+	}
+}
+
+func GetReporterOptions(cmd *cli.Command) reporter.Options {
+	// the thresholds are reported as an error by the scan if they are invalid
+	failOnSeverity, _ := config.ParseSeverityThresholds(cmd.StringSlice("fail-on-severity"))
+
+	return reporter.Options{
+		ShowAllVulns:    cmd.Bool("all-vulns"),
+		DedupeTable:     cmd.Bool("dedupe-table"),
+		GroupBy:         output.GroupBy(cmd.String("group-by")),
+		Summary:         cmd.Bool("summary"),
+		AdvisoryDetails: output.AdvisoryDetails(cmd.String("include-advisory-details")),
+		FailOnSeverity:  failOnSeverity,
+	}
+}
+
+func GetExitCodePolicy(cmd *cli.Command) exitcode.Policy {
+	return exitcode.Policy(cmd.String("exit-code-policy"))
+}
+
+// GetExitCodes returns the codes of conditions that are set by the ExitCodes of the
+// config files given with --config and of their --profile, with those set by
+// --exit-code taking precedence
+func GetExitCodes(cmd *cli.Command) (exitcode.Codes, error) {
+	fromConfig, err := config.LoadExitCodes(cmd.String("profile"), cmd.StringSlice("config")...)
+	if err != nil {
+		// config files that cannot be read are reported by the scan when it loads them
+		fromConfig = nil
+	}
+
+	return exitcode.ParseCodes(fromConfig, cmd.StringSlice("exit-code"))
+}
+
+// getLabels returns the labels that are set by the Labels of the config files given
+// with --config and of their --profile, with those set by --label taking precedence
+func getLabels(cmd *cli.Command) map[string]string {
+	labels, err := config.LoadLabels(cmd.String("profile"), cmd.StringSlice("config")...)
+	if err != nil {
+		// config files that cannot be read are reported by the scan when it loads them
+		labels = make(map[string]string)
+	}
+
+	// the labels have already been validated when parsing the flag
+	fromFlags, _ := parseLabels(cmd.StringSlice("label"))
+	maps.Copy(labels, fromFlags)
+
+	if len(labels) == 0 {
+		return nil
 	}
+
+	return labels
+}
+
+// parseLabels parses labels given as key=value, with later labels taking precedence
+func parseLabels(values []string) (map[string]string, error) {
+	labels := make(map[string]string, len(values))
+
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || key == "" {
+			return nil, fmt.Errorf("--label must be given as key=value, got %q", value)
+		}
+
+		labels[key] = strings.TrimSpace(val)
+	}
+
+	return labels, nil
 }
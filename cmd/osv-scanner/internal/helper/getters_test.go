@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "no labels",
+			values: nil,
+			want:   map[string]string{},
+		},
+		{
+			name:   "multiple labels",
+			values: []string{"env=prod", "service = payments"},
+			want:   map[string]string{"env": "prod", "service": "payments"},
+		},
+		{
+			name:   "later labels take precedence",
+			values: []string{"env=dev", "env=prod"},
+			want:   map[string]string{"env": "prod"},
+		},
+		{
+			name:   "values can contain equals signs and be empty",
+			values: []string{"query=a=b", "owner="},
+			want:   map[string]string{"query": "a=b", "owner": ""},
+		},
+		{
+			name:    "labels without a value",
+			values:  []string{"env"},
+			wantErr: true,
+		},
+		{
+			name:    "labels without a key",
+			values:  []string{"=prod"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseLabels(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseLabels() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,15 +1,22 @@
 package helper
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 	"github.com/google/osv-scanner/v2/internal/reporter"
+	"github.com/google/osv-scanner/v2/internal/resultsdb"
+	"github.com/google/osv-scanner/v2/internal/version"
 	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/urfave/cli/v3"
 	"golang.org/x/term"
 )
 
@@ -38,28 +45,153 @@ func ServeHTML(outputPath string) {
 	}
 }
 
-func PrintResult(stdout, stderr io.Writer, outputPath, format string, diffVulns *models.VulnerabilityResults, showAllVulns bool) error {
-	termWidth := 0
-	var err error
+func PrintResult(stdout, stderr io.Writer, outputPath, format string, diffVulns *models.VulnerabilityResults, opts reporter.Options) error {
 	if outputPath != "" { // Output is definitely a file
-		stdout, err = os.Create(outputPath)
-		if err != nil {
-			return fmt.Errorf("failed to create output file: %w", err)
-		}
-	} else { // Output might be a terminal
-		if stdoutAsFile, ok := stdout.(*os.File); ok {
-			termWidth, _, err = term.GetSize(int(stdoutAsFile.Fd()))
-			if err != nil { // If output is not a terminal,
-				termWidth = 0
-			}
+		return WriteFileAtomically(outputPath, func(w io.Writer) error {
+			return printResult(w, stderr, format, diffVulns, opts)
+		})
+	}
+
+	// Output might be a terminal
+	if stdoutAsFile, ok := stdout.(*os.File); ok {
+		var err error
+		opts.TerminalWidth, _, err = term.GetSize(int(stdoutAsFile.Fd()))
+		if err != nil { // If output is not a terminal,
+			opts.TerminalWidth = 0
 		}
 	}
 
+	return printResult(stdout, stderr, format, diffVulns, opts)
+}
+
+// TeeResult prints the results to stdout as a table if --tee was set, which is in addition
+// to the results being saved to the file given by --output in the format given by --format
+func TeeResult(cmd *cli.Command, stdout, stderr io.Writer, diffVulns *models.VulnerabilityResults) error {
+	if !cmd.Bool("tee") {
+		return nil
+	}
+
+	return PrintResult(stdout, stderr, "", "table", diffVulns, GetReporterOptions(cmd))
+}
+
+func printResult(stdout, stderr io.Writer, format string, diffVulns *models.VulnerabilityResults, opts reporter.Options) error {
 	writer := stdout
 
 	if format == "gh-annotations" {
 		writer = stderr
 	}
 
-	return reporter.PrintResult(diffVulns, format, writer, termWidth, showAllVulns)
+	return reporter.PrintResult(diffVulns, format, writer, opts)
+}
+
+// WriteFileAtomically writes to a temporary file next to path which then replaces it,
+// so that path is never left partially written if osv-scanner crashes or is killed.
+//
+// Only missing and regular files are replaced: anything else, such as a symlink,
+// a device like /dev/stdout, or a named pipe, is opened and written to directly
+func WriteFileAtomically(path string, write func(w io.Writer) error) error {
+	info, err := os.Lstat(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err == nil && !info.Mode().IsRegular() {
+		return writeFileDirectly(path, write)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	err = write(f)
+	if err == nil {
+		// temporary files are only readable by their owner
+		err = f.Chmod(0o644)
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
+
+func writeFileDirectly(path string, write func(w io.Writer) error) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	err = write(f)
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+
+	return err
+}
+
+// OutputResults writes the results of a scan over the given targets to the output of the command,
+// along with the copies, statistics, and results database that have been requested by its flags
+func OutputResults(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer, outputPath, format string, targets []string, vulnResult *models.VulnerabilityResults) error {
+	if err := PrintResult(stdout, stderr, outputPath, format, vulnResult, GetReporterOptions(cmd)); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if err := TeeResult(cmd, stdout, stderr, vulnResult); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if cmd.Bool("stats") {
+		PrintStats(vulnResult.ExperimentalScanStats)
+	}
+
+	if outputDB := cmd.String("output-db"); outputDB != "" {
+		if err := WriteResultsDB(ctx, outputDB, targets, vulnResult); err != nil {
+			return fmt.Errorf("failed to write results database: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// PrintStats logs the per-extractor statistics collected during a scan
+func PrintStats(scanStats *models.ScanStats) {
+	if scanStats == nil {
+		return
+	}
+
+	cmdlogger.Infof("Extraction statistics (%d files considered):", scanStats.FilesConsidered)
+
+	if len(scanStats.Extractors) == 0 {
+		cmdlogger.Infof("  no files were matched by any extractor")
+		return
+	}
+
+	for _, es := range scanStats.Extractors {
+		cmdlogger.Infof(
+			"  %s: %d matched, %d parsed, %d errored, %d packages found in %s",
+			es.Name,
+			es.FilesMatched,
+			es.FilesParsed,
+			es.FilesErrored,
+			es.PackagesFound,
+			time.Duration(es.DurationMs)*time.Millisecond,
+		)
+	}
+}
+
+// WriteResultsDB appends the result of a scan over the given targets to the
+// SQLite database at dbPath
+func WriteResultsDB(ctx context.Context, dbPath string, targets []string, vulnResult *models.VulnerabilityResults) error {
+	return resultsdb.Append(ctx, dbPath, resultsdb.ScanMetadata{
+		ScannedAt:      time.Now(),
+		ScannerVersion: version.OSVVersion,
+		Targets:        targets,
+	}, vulnResult)
 }
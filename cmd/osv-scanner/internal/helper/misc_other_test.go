@@ -0,0 +1,53 @@
+//go:build !windows
+
+package helper
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/testutility"
+)
+
+func TestWriteFileAtomically_NamedPipe(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "results.json")
+
+	if err := syscall.Mkfifo(path, 0o600); err != nil {
+		testutility.Skip(t, "could not create named pipe:", err)
+	}
+
+	read := make(chan string)
+	go func() {
+		// opening the pipe for reading blocks until it is opened for writing
+		f, err := os.Open(path)
+		if err != nil {
+			read <- err.Error()
+			return
+		}
+		defer f.Close()
+
+		b, _ := io.ReadAll(f)
+		read <- string(b)
+	}()
+
+	if err := WriteFileAtomically(path, writeString("results")); err != nil {
+		t.Fatalf("WriteFileAtomically() error = %v", err)
+	}
+
+	if got := <-read; got != "results" {
+		t.Errorf("WriteFileAtomically() wrote %q to the pipe, want %q", got, "results")
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		t.Fatalf("could not stat %s: %v", path, err)
+	}
+	if info.Mode()&os.ModeNamedPipe == 0 {
+		t.Errorf("WriteFileAtomically() replaced the named pipe with a %s", info.Mode().Type())
+	}
+}
@@ -0,0 +1,100 @@
+package helper
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/testutility"
+)
+
+func writeString(s string) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		_, err := io.WriteString(w, s)
+		return err
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read %s: %v", path, err)
+	}
+
+	return string(b)
+}
+
+func TestWriteFileAtomically_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "results.json")
+
+	if err := WriteFileAtomically(path, writeString("results")); err != nil {
+		t.Fatalf("WriteFileAtomically() error = %v", err)
+	}
+
+	if got := readFile(t, path); got != "results" {
+		t.Errorf("WriteFileAtomically() wrote %q, want %q", got, "results")
+	}
+}
+
+func TestWriteFileAtomically_RegularFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.json")
+
+	if err := os.WriteFile(path, []byte("previous results that are longer"), 0o600); err != nil {
+		t.Fatalf("could not write %s: %v", path, err)
+	}
+
+	if err := WriteFileAtomically(path, writeString("results")); err != nil {
+		t.Fatalf("WriteFileAtomically() error = %v", err)
+	}
+
+	if got := readFile(t, path); got != "results" {
+		t.Errorf("WriteFileAtomically() wrote %q, want %q", got, "results")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("could not read %s: %v", dir, err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("WriteFileAtomically() left %d files behind, want 1", len(entries))
+	}
+}
+
+func TestWriteFileAtomically_Symlink(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.json")
+	link := filepath.Join(dir, "results.json")
+
+	if err := os.WriteFile(target, []byte("previous results"), 0o600); err != nil {
+		t.Fatalf("could not write %s: %v", target, err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		testutility.Skip(t, "could not create symlink:", err)
+	}
+
+	if err := WriteFileAtomically(link, writeString("results")); err != nil {
+		t.Fatalf("WriteFileAtomically() error = %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatalf("could not stat %s: %v", link, err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("WriteFileAtomically() replaced the symlink with a %s", info.Mode().Type())
+	}
+
+	if got := readFile(t, target); got != "results" {
+		t.Errorf("WriteFileAtomically() wrote %q to the symlink target, want %q", got, "results")
+	}
+}
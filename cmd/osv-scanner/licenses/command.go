@@ -0,0 +1,210 @@
+package licenses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/helper"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/licenseaudit"
+	"github.com/google/osv-scanner/v2/internal/spdx"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+)
+
+func Command(stdout, _ io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "licenses",
+		Usage:       "audits the licenses of a source project's dependencies without checking for vulnerabilities",
+		Description: "extracts the dependencies of a source project and reports their licenses, along with any that are not in the allowlist; no vulnerability databases are queried",
+		ArgsUsage:   "[directory1 directory2...]",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:      "lockfile",
+				Aliases:   []string{"L"},
+				Usage:     "scan package lockfile on this path",
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Name:    "recursive",
+				Aliases: []string{"r"},
+				Usage:   "check subdirectories",
+			},
+			&cli.BoolFlag{
+				Name:  "no-ignore",
+				Usage: "also scan files that would be ignored by .gitignore or .osv-scanner-ignore files",
+			},
+			&cli.StringSliceFlag{
+				Name:      "config",
+				Usage:     "set/override config file; can be repeated to merge multiple config files, with later files taking precedence",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:  "profile",
+				Usage: "name of the profile to use from the config files, which is merged on top of the rest of each config file that defines it",
+			},
+			&cli.StringSliceFlag{
+				Name:  "allowlist",
+				Usage: "comma-separated list of spdx licenses that are allowed; packages with any other license are reported as violations",
+			},
+			&cli.BoolFlag{
+				Name:  "spdx-expressions",
+				Usage: "evaluate licenses against the --allowlist using SPDX expression semantics, including WITH exceptions and + operators",
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "sets the output format; value can be: " + strings.Join(licenseaudit.Formats(), ", "),
+				Value:   "table",
+				Action: func(_ context.Context, _ *cli.Command, s string) error {
+					if !slices.Contains(licenseaudit.Formats(), s) {
+						return fmt.Errorf("unsupported output format \"%s\" - must be one of: %s", s, strings.Join(licenseaudit.Formats(), ", "))
+					}
+
+					if s != "table" {
+						cmdlogger.SendEverythingToStderr()
+					}
+
+					return nil
+				},
+			},
+			&cli.StringFlag{
+				Name:      "output",
+				Usage:     "saves the result to the given file path",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:  "verbosity",
+				Usage: "specify the level of information that should be provided during runtime; value can be: " + strings.Join(cmdlogger.Levels(), ", "),
+				Value: "info",
+				Action: func(_ context.Context, _ *cli.Command, s string) error {
+					lvl, err := cmdlogger.ParseLevel(s)
+					if err != nil {
+						return err
+					}
+
+					cmdlogger.SetLevel(lvl)
+
+					return nil
+				},
+			},
+			&cli.BoolFlag{
+				Name:  "offline",
+				Usage: "resolve licenses using the license datasets in the local database directory, disabling any features requiring network access",
+			},
+			&cli.StringFlag{
+				Name:   "local-db-path",
+				Usage:  "sets the path that local databases should be stored",
+				Hidden: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "experimental-extractors",
+				Usage: "list of specific extractors and presets of extractors to use",
+				Value: []string{"lockfile", "sbom", "directory"},
+			},
+			&cli.StringSliceFlag{
+				Name:  "experimental-disable-extractors",
+				Usage: "list of specific extractors and presets of extractors to not use",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return action(cmd, stdout)
+		},
+	}
+}
+
+func action(cmd *cli.Command, stdout io.Writer) error {
+	allowlist := cmd.StringSlice("allowlist")
+
+	unrecognized := spdx.Unrecognized(allowlist)
+	if cmd.Bool("spdx-expressions") {
+		unrecognized = spdx.UnrecognizedExpressions(allowlist)
+	}
+
+	if len(unrecognized) > 0 {
+		return fmt.Errorf("--allowlist requires comma-separated spdx licenses. The following license(s) are not recognized as spdx: %s", strings.Join(unrecognized, ","))
+	}
+
+	extractors := helper.ResolveEnabledExtractors(
+		cmd.StringSlice("experimental-extractors"),
+		cmd.StringSlice("experimental-disable-extractors"),
+	)
+
+	if len(extractors) == 0 {
+		return errors.New("at least one extractor must be enabled")
+	}
+
+	offline := cmd.Bool("offline")
+
+	scannerAction := osvscanner.ScannerActions{
+		LockfilePaths:       cmd.StringSlice("lockfile"),
+		DirectoryPaths:      cmd.Args().Slice(),
+		Recursive:           cmd.Bool("recursive"),
+		NoIgnore:            cmd.Bool("no-ignore"),
+		ConfigOverridePaths: cmd.StringSlice("config"),
+		ConfigProfile:       cmd.String("profile"),
+		// every package is needed to report on their licenses, not just those with violations
+		ShowAllPackages: true,
+
+		CompareOffline: offline,
+		LocalDBPath:    cmd.String("local-db-path"),
+
+		ScanLicensesSummary:   true,
+		ScanLicensesAllowlist: allowlist,
+		SkipVulnerabilities:   true,
+
+		ExperimentalScannerActions: osvscanner.ExperimentalScannerActions{
+			Extractors:         extractors,
+			LicenseExpressions: cmd.Bool("spdx-expressions"),
+			TransitiveScanningActions: osvscanner.TransitiveScanningActions{
+				Disabled: offline,
+			},
+		},
+	}
+
+	vulnResult, err := osvscanner.DoScan(scannerAction)
+
+	// license violations are reported by the scanner as vulnerabilities being found,
+	// but they are determined from the report below so that is not an error here
+	if err != nil && !errors.Is(err, osvscanner.ErrVulnerabilitiesFound) {
+		return err
+	}
+
+	report := licenseaudit.FromResults(&vulnResult)
+
+	if err := writeReport(stdout, cmd.String("output"), cmd.String("format"), report); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if len(report.Violations()) > 0 {
+		return licenseaudit.ErrViolationsFound
+	}
+
+	return nil
+}
+
+func writeReport(stdout io.Writer, outputPath, format string, report licenseaudit.Report) error {
+	terminalWidth := 0
+
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+
+		stdout = f
+	} else if stdoutAsFile, ok := stdout.(*os.File); ok {
+		if width, _, err := term.GetSize(int(stdoutAsFile.Fd())); err == nil {
+			terminalWidth = width
+		}
+	}
+
+	return licenseaudit.Write(stdout, report, format, terminalWidth)
+}
@@ -3,10 +3,16 @@ package main
 import (
 	"os"
 
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/dev"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/diff"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/fix"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/gomod"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/cmd"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/licenses"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/sbom"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/scan"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/update"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/version"
 )
 
 func main() {
@@ -15,6 +21,12 @@ func main() {
 			scan.Command,
 			fix.Command,
 			update.Command,
+			sbom.Command,
+			diff.Command,
+			gomod.Command,
+			licenses.Command,
+			dev.Command,
+			version.Command,
 		}),
 	)
 }
@@ -0,0 +1,19 @@
+package sbom
+
+import (
+	"io"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/sbom/validate"
+	"github.com/urfave/cli/v3"
+)
+
+func Command(stdout, stderr io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "sbom",
+		Usage:       "works with SBOMs before they are scanned.",
+		Description: "works with SBOMs before they are scanned.",
+		Commands: []*cli.Command{
+			validate.Command(stdout, stderr),
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/google/osv-scanner/v2/internal/sbomvalidate"
+	"github.com/urfave/cli/v3"
+)
+
+func Command(stdout, _ io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "validate",
+		Usage:       "checks that the components of an SBOM can be scanned",
+		Description: "checks an SBOM for components that will not be scanned, such as those missing a purl or version, and for duplicate bom-refs",
+		ArgsUsage:   "<sbom file>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "sets the output format; value can be: table, json",
+				Value: "table",
+				Action: func(_ context.Context, _ *cli.Command, s string) error {
+					if s != "table" && s != "json" {
+						return fmt.Errorf("unsupported output format \"%s\" - must be one of: table, json", s)
+					}
+
+					return nil
+				},
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return action(cmd, stdout)
+		},
+	}
+}
+
+func action(cmd *cli.Command, stdout io.Writer) error {
+	if cmd.Args().Len() != 1 {
+		return errors.New("expected exactly one SBOM file to validate")
+	}
+
+	report, err := sbomvalidate.Validate(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	if cmd.String("format") == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printTable(stdout, report)
+	}
+
+	if len(report.Issues) > 0 {
+		return sbomvalidate.ErrIssuesFound
+	}
+
+	return nil
+}
+
+func printTable(w io.Writer, report sbomvalidate.Report) {
+	fmt.Fprintf(
+		w,
+		"Validated %s (%s): %d of %d components can be scanned\n",
+		report.Path,
+		report.Format,
+		report.Scannable,
+		report.Components,
+	)
+
+	if len(report.Issues) == 0 {
+		fmt.Fprintln(w, "No issues found")
+		return
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ISSUE\tREF\tCOMPONENT\tDETAILS")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", issue.Kind, issue.Ref, issue.Component, issue.Message)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,52 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/testcmd"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []testcmd.Case{
+		{
+			Name: "valid_sbom",
+			Args: []string{"", "sbom", "validate", "./fixtures/valid.cdx.json"},
+			Exit: 0,
+		},
+		{
+			Name: "sbom_with_issues",
+			Args: []string{"", "sbom", "validate", "./fixtures/issues.cdx.json"},
+			Exit: 1,
+		},
+		{
+			Name: "sbom_with_issues_as_json",
+			Args: []string{"", "sbom", "validate", "--format", "json", "./fixtures/issues.cdx.json"},
+			Exit: 1,
+		},
+		{
+			Name: "unsupported_format",
+			Args: []string{"", "sbom", "validate", "./fixtures/not-an-sbom.txt"},
+			Exit: 127,
+		},
+		{
+			Name: "nonexistent_file",
+			Args: []string{"", "sbom", "validate", "./fixtures/does-not-exist.txt"},
+			Exit: 127,
+		},
+		{
+			Name: "missing_file",
+			Args: []string{"", "sbom", "validate"},
+			Exit: 127,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			testcmd.RunAndMatchSnapshots(t, tt)
+		})
+	}
+}
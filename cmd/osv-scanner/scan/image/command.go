@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/exitcode"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/helper"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 	"github.com/google/osv-scanner/v2/pkg/models"
@@ -26,15 +27,47 @@ func Command(stdout, stderr io.Writer) *cli.Command {
 				Name:  "archive",
 				Usage: "input a local archive image (e.g. a tar file)",
 			},
+			&cli.StringFlag{
+				Name:  "base-image",
+				Usage: "the image (or path to a local archive image) that the scanned image was built from, used to attribute vulnerabilities to the base image",
+			},
+			&cli.StringFlag{
+				Name:  "layer-cache-dir",
+				Usage: "directory to cache the packages extracted from each layer of the image in, so that layers shared with previously scanned images are not extracted again",
+			},
+			&cli.StringFlag{
+				Name:    "registry-username",
+				Usage:   "username to pull the image from its registry with, instead of exporting it with docker",
+				Sources: cli.EnvVars("OSV_SCANNER_REGISTRY_USERNAME"),
+			},
+			&cli.StringFlag{
+				Name:    "registry-password",
+				Usage:   "password to pull the image from its registry with, instead of exporting it with docker",
+				Sources: cli.EnvVars("OSV_SCANNER_REGISTRY_PASSWORD"),
+			},
+			&cli.StringFlag{
+				Name:    "registry-token",
+				Usage:   "identity token (such as an OAuth2 access token) to pull the image from its registry with, instead of exporting it with docker",
+				Sources: cli.EnvVars("OSV_SCANNER_REGISTRY_TOKEN"),
+			},
 		}, helper.BuildCommonScanFlags([]string{"artifact"})...),
 		ArgsUsage: "[image imageNameWithTag]",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			return action(ctx, cmd, stdout, stderr)
+			exitCodes, err := helper.GetExitCodes(cmd)
+			if err != nil {
+				return err
+			}
+
+			return exitcode.WithCodes(action(ctx, cmd, stdout, stderr, exitCodes), exitCodes)
 		},
 	}
 }
 
-func action(_ context.Context, cmd *cli.Command, stdout, stderr io.Writer) error {
+func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer, exitCodes exitcode.Codes) error {
+	if cmd.Bool("explain-exit-code") {
+		return exitcode.PrintExplanation(stdout, helper.GetExitCodePolicy(cmd), exitCodes)
+	}
+
 	if cmd.Args().Len() == 0 {
 		return errors.New("please provide an image name or see the help document")
 	}
@@ -69,10 +102,18 @@ func action(_ context.Context, cmd *cli.Command, stdout, stderr io.Writer) error
 		return err
 	}
 
-	scannerAction := helper.GetCommonScannerActions(cmd, scanLicensesAllowlist)
+	scannerAction := helper.GetCommonScannerActions(cmd, scanLicensesAllowlist, exitCodes)
+	scannerAction.ProgressWriter = stderr
 
 	scannerAction.Image = cmd.Args().First()
 	scannerAction.IsImageArchive = cmd.Bool("archive")
+	scannerAction.BaseImage = cmd.String("base-image")
+	scannerAction.RegistryUsername = cmd.String("registry-username")
+	scannerAction.RegistryPassword = cmd.String("registry-password")
+	scannerAction.RegistryToken = cmd.String("registry-token")
+	if dir := cmd.String("layer-cache-dir"); dir != "" {
+		scannerAction.LayerCache = osvscanner.NewLayerCache(dir)
+	}
 	scannerAction.ExperimentalScannerActions = helper.GetExperimentalScannerActions(cmd)
 
 	if len(scannerAction.Extractors) == 0 {
@@ -80,20 +121,26 @@ func action(_ context.Context, cmd *cli.Command, stdout, stderr io.Writer) error
 	}
 
 	var vulnResult models.VulnerabilityResults
-	//nolint:contextcheck // passing the context in would be a breaking change
-	vulnResult, err = osvscanner.DoContainerScan(scannerAction)
+	vulnResult, err = osvscanner.DoContainerScanContext(ctx, scannerAction)
 
 	if cmd.Bool("allow-no-lockfiles") && errors.Is(err, osvscanner.ErrNoPackagesFound) {
 		cmdlogger.Warnf("No package sources found")
 		err = nil
 	}
 
-	if err != nil && !errors.Is(err, osvscanner.ErrVulnerabilitiesFound) {
+	// the results of packages that could be checked are still output if others could not be
+	if err != nil && !osvscanner.IsFindingErr(err) {
 		return err
 	}
 
-	if errPrint := helper.PrintResult(stdout, stderr, outputPath, format, &vulnResult, scannerAction.ShowAllVulns); errPrint != nil {
-		return fmt.Errorf("failed to write output: %w", errPrint)
+	// packages that could not be scanned are always reported by the policies other than the default
+	policy := helper.GetExitCodePolicy(cmd)
+	if len(vulnResult.UnscannedPackages) > 0 && (cmd.Bool("fail-on-unscanned-packages") || exitCodes.Reports(policy, exitcode.PartialScan)) {
+		err = errors.Join(err, osvscanner.ErrUnscannedPackagesFound)
+	}
+
+	if errOutput := helper.OutputResults(ctx, cmd, stdout, stderr, outputPath, format, []string{scannerAction.Image}, &vulnResult); errOutput != nil {
+		return errOutput
 	}
 
 	// Auto-open outputted HTML file for users.
@@ -106,5 +153,5 @@ func action(_ context.Context, cmd *cli.Command, stdout, stderr io.Writer) error
 	}
 
 	// This may be nil.
-	return err
+	return exitcode.WithPolicy(err, policy)
 }
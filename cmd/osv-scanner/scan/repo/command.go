@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/exitcode"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/helper"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/remoterepo"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+	"github.com/urfave/cli/v3"
+)
+
+func Command(stdout, stderr io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "repo",
+		Usage:       "fetches a remote Git repository and scans its dependencies for known vulnerabilities using the OSV database.",
+		Description: "fetches a remote Git repository and scans its dependencies for known vulnerabilities using the OSV database.",
+		Flags: append([]cli.Flag{
+			&cli.BoolFlag{
+				Name:  "no-ignore",
+				Usage: "also scan files that would be ignored by .gitignore or .osv-scanner-ignore files",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "verify-integrity",
+				Usage: "compare the hashes recorded by lockfiles against the hashes published by the registries of their packages, and report any that do not match",
+				Value: false,
+			},
+		}, helper.BuildCommonScanFlags([]string{"lockfile", "sbom", "directory"})...),
+		ArgsUsage: "[url@ref]",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			exitCodes, err := helper.GetExitCodes(cmd)
+			if err != nil {
+				return err
+			}
+
+			return exitcode.WithCodes(action(ctx, cmd, stdout, stderr, exitCodes), exitCodes)
+		},
+	}
+}
+
+func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer, exitCodes exitcode.Codes) error {
+	if cmd.Bool("explain-exit-code") {
+		return exitcode.PrintExplanation(stdout, helper.GetExitCodePolicy(cmd), exitCodes)
+	}
+
+	if cmd.Args().Len() != 1 {
+		return errors.New("please provide the URL of a repository to scan, optionally followed by @ and the ref to scan, or see the help document")
+	}
+
+	ref, err := remoterepo.ParseReference(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	format := cmd.String("format")
+	outputPath := cmd.String("output")
+	serve := cmd.Bool("serve")
+	if serve {
+		format = "html"
+		if outputPath == "" {
+			// Create a temporary directory
+			tmpDir, err := os.MkdirTemp("", "osv-scanner-result")
+			if err != nil {
+				return fmt.Errorf("failed creating temporary directory: %w\n"+
+					"Please use `--output result.html` to specify the output path", err)
+			}
+
+			// Remove the created temporary directory after
+			defer os.RemoveAll(tmpDir)
+			outputPath = filepath.Join(tmpDir, "index.html")
+		}
+	}
+
+	scanLicensesAllowlist, err := helper.GetScanLicensesAllowlist(cmd)
+	if err != nil {
+		return err
+	}
+
+	repoDir, err := os.MkdirTemp("", "osv-scanner-repo-*")
+	if err != nil {
+		return fmt.Errorf("failed creating temporary directory to fetch the repository into: %w", err)
+	}
+	defer os.RemoveAll(repoDir)
+
+	cmdlogger.Infof("Fetching %s...", ref)
+	commit, err := remoterepo.Clone(ctx, ref, repoDir)
+	if err != nil {
+		return err
+	}
+
+	scannerAction := helper.GetCommonScannerActions(cmd, scanLicensesAllowlist, exitCodes)
+	scannerAction.ProgressWriter = stderr
+
+	scannerAction.DirectoryPaths = []string{repoDir}
+	scannerAction.Recursive = true
+	scannerAction.NoIgnore = cmd.Bool("no-ignore")
+	scannerAction.VerifyIntegrity = cmd.Bool("verify-integrity")
+	scannerAction.ExperimentalScannerActions = helper.GetExperimentalScannerActions(cmd)
+	scannerAction.TransitiveScanningActions = osvscanner.TransitiveScanningActions{
+		Disabled: cmd.Bool("no-resolve"),
+	}
+
+	if len(scannerAction.Extractors) == 0 {
+		return errors.New("at least one extractor must be enabled")
+	}
+
+	var vulnResult models.VulnerabilityResults
+	vulnResult, err = osvscanner.DoScanContext(ctx, scannerAction)
+
+	if cmd.Bool("allow-no-lockfiles") && errors.Is(err, osvscanner.ErrNoPackagesFound) {
+		cmdlogger.Warnf("No package sources found")
+		err = nil
+	}
+
+	// the results of packages that could be checked are still output if others could not be
+	if err != nil && !osvscanner.IsFindingErr(err) {
+		return err
+	}
+
+	relativizeSources(&vulnResult, repoDir)
+	vulnResult.RepositoryMetadata = &models.RepositoryMetadata{
+		URL:    ref.URL,
+		Ref:    ref.Ref,
+		Commit: commit,
+	}
+
+	// packages that could not be scanned are always reported by the policies other than the default
+	policy := helper.GetExitCodePolicy(cmd)
+	if len(vulnResult.UnscannedPackages) > 0 && (cmd.Bool("fail-on-unscanned-packages") || exitCodes.Reports(policy, exitcode.PartialScan)) {
+		err = errors.Join(err, osvscanner.ErrUnscannedPackagesFound)
+	}
+
+	if errOutput := helper.OutputResults(ctx, cmd, stdout, stderr, outputPath, format, []string{ref.String()}, &vulnResult); errOutput != nil {
+		return errOutput
+	}
+
+	// Auto-open outputted HTML file for users.
+	if outputPath != "" {
+		if serve {
+			helper.ServeHTML(outputPath)
+		} else if format == "html" {
+			cmdlogger.Infof("HTML output available at: %s", outputPath)
+		}
+	}
+
+	// This may be nil.
+	return exitcode.WithPolicy(err, policy)
+}
+
+// relativizeSources makes the paths of the sources in the results relative to
+// the root of the repository, as the directory it was fetched into is temporary
+func relativizeSources(vulnResult *models.VulnerabilityResults, repoDir string) {
+	relativize := func(source *models.SourceInfo) {
+		rel, err := filepath.Rel(repoDir, source.Path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return
+		}
+
+		source.Path = filepath.ToSlash(rel)
+	}
+
+	for i := range vulnResult.Results {
+		relativize(&vulnResult.Results[i].Source)
+	}
+
+	for i := range vulnResult.UnscannedPackages {
+		relativize(&vulnResult.UnscannedPackages[i].Source)
+	}
+
+	for i := range vulnResult.IntegrityMismatches {
+		relativize(&vulnResult.IntegrityMismatches[i].Source)
+	}
+}
@@ -0,0 +1,45 @@
+package repo_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/testcmd"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []testcmd.Case{
+		{
+			Name: "no_repository",
+			Args: []string{"", "repo"},
+			Exit: 127,
+		},
+		{
+			Name: "multiple_repositories",
+			Args: []string{"", "repo", "https://github.com/google/osv-scanner", "https://github.com/google/osv-scalibr"},
+			Exit: 127,
+		},
+		{
+			Name: "local_path",
+			Args: []string{"", "repo", "./fixtures"},
+			Exit: 127,
+		},
+		{
+			Name: "missing_ref",
+			Args: []string{"", "repo", "git@github.com:google/osv-scanner.git@"},
+			Exit: 127,
+		},
+		{
+			Name: "repository_does_not_exist",
+			Args: []string{"", "repo", "file:///osv-scanner/does-not-exist@main"},
+			Exit: 127,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			testcmd.RunAndMatchSnapshots(t, tt)
+		})
+	}
+}
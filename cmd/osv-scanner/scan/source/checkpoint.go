@@ -0,0 +1,159 @@
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/exitcode"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/helper"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+)
+
+// checkpointedErrs are the errors that the results of a target can be returned with, which
+// are saved alongside them by name so that a target that has been checkpointed is restored
+// with the same errors as it was scanned with. The findings have the names of the exit code
+// conditions they are reported as, which is what they were previously saved as
+var checkpointedErrs = map[string]error{
+	string(exitcode.VulnerabilitiesFound):   osvscanner.ErrVulnerabilitiesFound,
+	string(exitcode.LicenseViolationsFound): osvscanner.ErrLicenseViolationsFound,
+	string(exitcode.IgnoredVulnsFound):      osvscanner.ErrIgnoredVulnerabilitiesFound,
+	string(exitcode.UncalledVulnsFound):     osvscanner.ErrUncalledVulnerabilitiesFound,
+	"policy-violated":                       osvscanner.ErrPolicyViolated,
+	"integrity-mismatches-found":            osvscanner.ErrIntegrityMismatchesFound,
+	"partial-api-failure":                   osvscanner.ErrPartialAPIFailure,
+}
+
+// checkpointedErrNames returns the names of the errors that err is made up of, returning
+// false if any of them cannot be checkpointed, in which case the target should not be
+func checkpointedErrNames(err error) ([]string, bool) {
+	if err == nil {
+		return nil, true
+	}
+
+	for name, sentinel := range checkpointedErrs {
+		//nolint:errorlint // the sentinels themselves are being looked for, as they wrap each other
+		if err == sentinel {
+			return []string{name}, true
+		}
+	}
+
+	var errs []error
+	switch e := err.(type) { //nolint:errorlint // the error is being unwrapped
+	case interface{ Unwrap() []error }:
+		errs = e.Unwrap()
+	case interface{ Unwrap() error }:
+		errs = []error{e.Unwrap()}
+	default:
+		return nil, false
+	}
+
+	var names []string
+	for _, e := range errs {
+		n, ok := checkpointedErrNames(e)
+		if !ok {
+			return nil, false
+		}
+		names = append(names, n...)
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names), true
+}
+
+// checkpointDir returns the directory that the results of each target are saved
+// to as they are completed, until the results of every target have been output
+func checkpointDir(outputPath string) string {
+	return outputPath + ".partial"
+}
+
+// checkpoint is the results of a target that has been scanned, saved so that
+// the scan of the targets can be resumed if it does not finish
+type checkpoint struct {
+	Target target `json:"target"`
+	// Findings are the names of the errors that the target was scanned with
+	Findings []string                    `json:"findings,omitempty"`
+	Results  models.VulnerabilityResults `json:"results"`
+}
+
+// err returns the findings of the target as an error, in the same way as they
+// were returned when the target was scanned
+func (c checkpoint) err() error {
+	errs := make([]error, 0, len(c.Findings))
+	for _, finding := range c.Findings {
+		errs = append(errs, checkpointedErrs[finding])
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkpointPath returns where the checkpoint of the target is saved within dir,
+// which changes if the target is changed so that it is scanned again when resuming
+func checkpointPath(dir string, t target) (string, error) {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(b)
+
+	return filepath.Join(dir, hex.EncodeToString(hash[:8])+".json"), nil
+}
+
+// saveCheckpoint saves the results of scanning the target to dir, unless the target was
+// scanned with an error that cannot be checkpointed, as it will have to be scanned again
+func saveCheckpoint(dir string, t target, results models.VulnerabilityResults, err error) error {
+	findings, ok := checkpointedErrNames(err)
+	if !ok {
+		return nil
+	}
+
+	path, errPath := checkpointPath(dir, t)
+	if errPath != nil {
+		return errPath
+	}
+
+	if errMkdir := os.MkdirAll(dir, 0o755); errMkdir != nil {
+		return errMkdir
+	}
+
+	return helper.WriteFileAtomically(path, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(checkpoint{
+			Target:   t,
+			Findings: findings,
+			Results:  results,
+		})
+	})
+}
+
+// loadCheckpoint loads the results of the target from dir, returning false if
+// the target has not been checkpointed
+func loadCheckpoint(dir string, t target) (checkpoint, bool, error) {
+	path, err := checkpointPath(dir, t)
+	if err != nil {
+		return checkpoint{}, false, err
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint{}, false, nil
+	}
+	if err != nil {
+		return checkpoint{}, false, err
+	}
+
+	var c checkpoint
+	if err := json.Unmarshal(b, &c); err != nil {
+		return checkpoint{}, false, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+
+	return c, true, nil
+}
@@ -0,0 +1,127 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+)
+
+func Test_checkpoint_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "results.json.partial")
+	recursive := true
+	tgt := target{Name: "backend", Directory: "/path/to/backend", Recursive: &recursive}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "/path/to/backend/go.mod", Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{{
+				Package:           models.PackageInfo{Name: "golang.org/x/net", Version: "0.1.0", Ecosystem: "Go"},
+				LicenseViolations: []models.License{"GPL-3.0"},
+			}},
+		}},
+	}
+
+	if _, ok, err := loadCheckpoint(dir, tgt); ok || err != nil {
+		t.Fatalf("loadCheckpoint() before saving = %t, %v, want false, nil", ok, err)
+	}
+
+	findings := errors.Join(osvscanner.ErrVulnerabilitiesFound, osvscanner.ErrLicenseViolationsFound)
+	if err := saveCheckpoint(dir, tgt, results, findings); err != nil {
+		t.Fatalf("saveCheckpoint() error = %v", err)
+	}
+
+	c, ok, err := loadCheckpoint(dir, tgt)
+	if !ok || err != nil {
+		t.Fatalf("loadCheckpoint() = %t, %v, want true, nil", ok, err)
+	}
+
+	if diff := cmp.Diff(results, c.Results); diff != "" {
+		t.Errorf("loadCheckpoint() results mismatch (-want +got):\n%s", diff)
+	}
+
+	if !errors.Is(c.err(), osvscanner.ErrVulnerabilitiesFound) || !errors.Is(c.err(), osvscanner.ErrLicenseViolationsFound) {
+		t.Errorf("loadCheckpoint() err = %v, want vulnerabilities and license violations to be found", c.err())
+	}
+
+	// changing the target means it has to be scanned again
+	tgt.Recursive = nil
+	if _, ok, err := loadCheckpoint(dir, tgt); ok || err != nil {
+		t.Errorf("loadCheckpoint() of changed target = %t, %v, want false, nil", ok, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the checkpoint to be in the directory, got %d entries", len(entries))
+	}
+}
+
+func Test_checkpoint_NoFindings(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tgt := target{Name: "frontend", Lockfile: "/path/to/package-lock.json"}
+
+	if err := saveCheckpoint(dir, tgt, models.VulnerabilityResults{}, nil); err != nil {
+		t.Fatalf("saveCheckpoint() error = %v", err)
+	}
+
+	c, ok, err := loadCheckpoint(dir, tgt)
+	if !ok || err != nil {
+		t.Fatalf("loadCheckpoint() = %t, %v, want true, nil", ok, err)
+	}
+
+	if c.err() != nil {
+		t.Errorf("loadCheckpoint() err = %v, want nil", c.err())
+	}
+}
+
+func Test_checkpoint_PartialResults(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tgt := target{Name: "frontend", Lockfile: "/path/to/package-lock.json"}
+
+	scanErr := errors.Join(osvscanner.ErrIntegrityMismatchesFound, osvscanner.ErrPartialAPIFailure)
+	if err := saveCheckpoint(dir, tgt, models.VulnerabilityResults{}, scanErr); err != nil {
+		t.Fatalf("saveCheckpoint() error = %v", err)
+	}
+
+	c, ok, err := loadCheckpoint(dir, tgt)
+	if !ok || err != nil {
+		t.Fatalf("loadCheckpoint() = %t, %v, want true, nil", ok, err)
+	}
+
+	for _, want := range []error{osvscanner.ErrIntegrityMismatchesFound, osvscanner.ErrPartialAPIFailure} {
+		if !errors.Is(c.err(), want) {
+			t.Errorf("loadCheckpoint() err = %v, want it to be %v", c.err(), want)
+		}
+	}
+}
+
+func Test_checkpoint_Failed(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tgt := target{Name: "frontend", Lockfile: "/path/to/package-lock.json"}
+
+	// the target failed, so it has to be scanned again even though vulnerabilities were found
+	scanErr := errors.Join(osvscanner.ErrVulnerabilitiesFound, fmt.Errorf("%w: connection refused", osvscanner.ErrAPIFailed))
+	if err := saveCheckpoint(dir, tgt, models.VulnerabilityResults{}, scanErr); err != nil {
+		t.Fatalf("saveCheckpoint() error = %v", err)
+	}
+
+	if _, ok, err := loadCheckpoint(dir, tgt); ok || err != nil {
+		t.Errorf("loadCheckpoint() of failed target = %t, %v, want false, nil", ok, err)
+	}
+}
@@ -7,9 +7,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/exitcode"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/helper"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sandboxed"
 	"github.com/google/osv-scanner/v2/pkg/models"
 	"github.com/google/osv-scanner/v2/pkg/osvscanner"
 	"github.com/urfave/cli/v3"
@@ -38,6 +42,20 @@ func Command(stdout, stderr io.Writer) *cli.Command {
 				},
 				TakesFile: true,
 			},
+			&cli.StringSliceFlag{
+				Name:      "purl-file",
+				Usage:     "scan the packages listed in this newline-delimited file of package urls, or stdin if \"-\"",
+				TakesFile: true,
+			},
+			&cli.StringFlag{
+				Name:      "targets",
+				Usage:     "scan the directories, lockfiles, SBOMs, and images listed in this YAML manifest concurrently, each with their own overrides of the flags, and report on them together",
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Name:  "resume",
+				Usage: "continue a scan of --targets that did not finish, using the results of the targets it completed rather than scanning them again; requires --output",
+			},
 			&cli.BoolFlag{
 				Name:    "recursive",
 				Aliases: []string{"r"},
@@ -46,7 +64,7 @@ func Command(stdout, stderr io.Writer) *cli.Command {
 			},
 			&cli.BoolFlag{
 				Name:  "no-ignore",
-				Usage: "also scan files that would be ignored by .gitignore",
+				Usage: "also scan files that would be ignored by .gitignore or .osv-scanner-ignore files",
 				Value: false,
 			},
 			&cli.StringSliceFlag{
@@ -57,11 +75,40 @@ func Command(stdout, stderr io.Writer) *cli.Command {
 				Name:  "no-call-analysis",
 				Usage: "disables call graph analysis",
 			},
+			&cli.BoolFlag{
+				Name:  "inherit-config",
+				Usage: "merge the osv-scanner.toml configs of the scanned directories with those of their subdirectories, with configs closer to each file taking precedence",
+				Value: false,
+			},
 			&cli.BoolFlag{
 				Name:  "include-git-root",
 				Usage: "include scanning git root (non-submoduled) repositories",
 				Value: false,
 			},
+			&cli.BoolFlag{
+				Name:  "scan-base-images",
+				Usage: "pull the images that Dockerfiles build from and scan their packages",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "kubernetes",
+				Usage: "find the images run by the workloads of Kubernetes manifests and set by the values of Helm charts",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "scan-kubernetes-images",
+				Usage: "pull the images run by Kubernetes workloads and scan their packages, reporting them by workload; implies --kubernetes",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "verify-integrity",
+				Usage: "compare the hashes recorded by lockfiles against the hashes published by the registries of their packages, and report any that do not match",
+				Value: false,
+			},
+			&cli.StringFlag{
+				Name:  "layer-cache-dir",
+				Usage: "directory to cache the packages extracted from the layers of base images in, so that layers are not extracted again by later scans",
+			},
 			&cli.StringFlag{
 				Name:  "data-source",
 				Usage: "source to fetch package information from; value can be: deps.dev, native",
@@ -78,15 +125,77 @@ func Command(stdout, stderr io.Writer) *cli.Command {
 				Name:  "maven-registry",
 				Usage: "URL of the default registry to fetch Maven metadata",
 			},
+			&cli.StringFlag{
+				Name:      "maven-settings",
+				Usage:     "path to a Maven settings.xml file to read mirrors and credentials from",
+				TakesFile: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "experimental-resolve-in-sandbox",
+				Usage: "generate lockfiles for manifests by running the native tool of their ecosystem in a container; value can be: " + strings.Join(sandboxed.Tools(), ", "),
+				Action: func(_ context.Context, _ *cli.Command, names []string) error {
+					for _, name := range names {
+						if _, err := sandboxed.ParseTool(name); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				},
+			},
+			&cli.StringFlag{
+				Name:  "experimental-sandbox-network",
+				Usage: "network access of tools run by --experimental-resolve-in-sandbox; value can be: " + strings.Join(sandboxed.NetworkModes(), ", "),
+				Value: string(sandboxed.NetworkNone),
+				Action: func(_ context.Context, _ *cli.Command, s string) error {
+					if !slices.Contains(sandboxed.NetworkModes(), s) {
+						return fmt.Errorf("unsupported sandbox network %q - must be one of: %s", s, strings.Join(sandboxed.NetworkModes(), ", "))
+					}
+
+					return nil
+				},
+			},
+			&cli.StringSliceFlag{
+				Name:  "experimental-sandbox-registry",
+				Usage: "registry that a tool run with --experimental-sandbox-network=registry resolves dependencies from, in the form of <tool>=<url>",
+				Action: func(_ context.Context, _ *cli.Command, definitions []string) error {
+					_, err := sandboxed.ParseToolValues(definitions)
+
+					return err
+				},
+			},
+			&cli.StringSliceFlag{
+				Name:  "experimental-sandbox-image",
+				Usage: "container image to run a tool in for --experimental-resolve-in-sandbox, in the form of <tool>=<image>",
+				Action: func(_ context.Context, _ *cli.Command, definitions []string) error {
+					_, err := sandboxed.ParseToolValues(definitions)
+
+					return err
+				},
+			},
+			&cli.StringFlag{
+				Name:  "experimental-sandbox-runtime",
+				Usage: "container runtime to run tools with for --experimental-resolve-in-sandbox, such as docker or podman",
+				Value: "docker",
+			},
 		}, helper.BuildCommonScanFlags([]string{"lockfile", "sbom", "directory"})...),
 		ArgsUsage: "[directory1 directory2...]",
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			return action(ctx, cmd, stdout, stderr)
+			exitCodes, err := helper.GetExitCodes(cmd)
+			if err != nil {
+				return err
+			}
+
+			return exitcode.WithCodes(action(ctx, cmd, stdout, stderr, exitCodes), exitCodes)
 		},
 	}
 }
 
-func action(_ context.Context, cmd *cli.Command, stdout, stderr io.Writer) error {
+func action(ctx context.Context, cmd *cli.Command, stdout, stderr io.Writer, exitCodes exitcode.Codes) error {
+	if cmd.Bool("explain-exit-code") {
+		return exitcode.PrintExplanation(stdout, helper.GetExitCodePolicy(cmd), exitCodes)
+	}
+
 	format := cmd.String("format")
 
 	outputPath := cmd.String("output")
@@ -115,20 +224,37 @@ func action(_ context.Context, cmd *cli.Command, stdout, stderr io.Writer) error
 	callAnalysisStates := helper.CreateCallAnalysisStates(cmd.StringSlice("call-analysis"), cmd.StringSlice("no-call-analysis"))
 
 	experimentalScannerActions := helper.GetExperimentalScannerActions(cmd)
+	experimentalScannerActions.Extractors, err = withSandboxedExtractors(cmd, experimentalScannerActions.Extractors)
+	if err != nil {
+		return err
+	}
+
+	experimentalScannerActions.Extractors = withKubernetesExtractor(cmd, experimentalScannerActions.Extractors)
+
 	// Add `source` specific experimental configs
 	experimentalScannerActions.TransitiveScanningActions = osvscanner.TransitiveScanningActions{
 		Disabled:         cmd.Bool("no-resolve"),
 		NativeDataSource: cmd.String("data-source") == "native",
 		MavenRegistry:    cmd.String("maven-registry"),
+		MavenSettings:    cmd.String("maven-settings"),
 	}
 
-	scannerAction := helper.GetCommonScannerActions(cmd, scanLicensesAllowlist)
+	scannerAction := helper.GetCommonScannerActions(cmd, scanLicensesAllowlist, exitCodes)
+	scannerAction.ProgressWriter = stderr
 
 	scannerAction.LockfilePaths = cmd.StringSlice("lockfile")
 	//nolint:staticcheck // ignore our own deprecated field
 	scannerAction.SBOMPaths = cmd.StringSlice("sbom")
+	scannerAction.PURLListPaths = cmd.StringSlice("purl-file")
 	scannerAction.Recursive = cmd.Bool("recursive")
 	scannerAction.NoIgnore = cmd.Bool("no-ignore")
+	scannerAction.InheritConfig = cmd.Bool("inherit-config")
+	scannerAction.ScanBaseImages = cmd.Bool("scan-base-images")
+	scannerAction.ScanKubernetesImages = cmd.Bool("scan-kubernetes-images")
+	scannerAction.VerifyIntegrity = cmd.Bool("verify-integrity")
+	if dir := cmd.String("layer-cache-dir"); dir != "" {
+		scannerAction.LayerCache = osvscanner.NewLayerCache(dir)
+	}
 	scannerAction.DirectoryPaths = cmd.Args().Slice()
 	scannerAction.CallAnalysisStates = callAnalysisStates
 	scannerAction.ExperimentalScannerActions = experimentalScannerActions
@@ -137,21 +263,94 @@ func action(_ context.Context, cmd *cli.Command, stdout, stderr io.Writer) error
 		return errors.New("at least one extractor must be enabled")
 	}
 
+	resume := cmd.Bool("resume")
+	if resume && (cmd.String("targets") == "" || cmd.String("output") == "") {
+		return errors.New("--resume can only be used with --targets and --output")
+	}
+
+	if cmd.Bool("watch") {
+		if len(scannerAction.DirectoryPaths) == 0 || len(scannerAction.LockfilePaths) > 0 || cmd.String("targets") != "" || outputPath != "" {
+			return errors.New("--watch can only be used to scan directories, and cannot be used with --lockfile, --targets, --output, or --serve")
+		}
+
+		return newWatcher(scannerAction, stdout).watch(ctx, func(vulnResult *models.VulnerabilityResults) error {
+			return helper.PrintResult(stdout, stderr, "", format, vulnResult, helper.GetReporterOptions(cmd))
+		})
+	}
+
 	var vulnResult models.VulnerabilityResults
-	//nolint:contextcheck // passing the context in would be a breaking change
-	vulnResult, err = osvscanner.DoScan(scannerAction)
+	var checkpoints string
+	scanned := slices.Concat(scannerAction.LockfilePaths, scannerAction.DirectoryPaths)
+	if targetsPath := cmd.String("targets"); targetsPath != "" {
+		if len(scannerAction.LockfilePaths) > 0 || len(scannerAction.SBOMPaths) > 0 || len(scannerAction.PURLListPaths) > 0 || len(scannerAction.DirectoryPaths) > 0 { //nolint:staticcheck // ignore our own deprecated field
+			return errors.New("--targets cannot be used with other paths to scan")
+		}
+
+		manifest, errManifest := loadTargetsManifest(targetsPath)
+		if errManifest != nil {
+			return errManifest
+		}
+
+		scanned = make([]string, 0, len(manifest.Targets))
+		for _, t := range manifest.Targets {
+			scanned = append(scanned, t.Name)
+		}
+
+		// the results of each target are saved alongside the output until it has been written
+		if cmd.String("output") != "" {
+			checkpoints = checkpointDir(outputPath)
+
+			if !resume {
+				if errRemove := os.RemoveAll(checkpoints); errRemove != nil {
+					return fmt.Errorf("failed to remove results of previous scan: %w", errRemove)
+				}
+			}
+		}
+
+		vulnResult, err = scanTargets(ctx, targetsScan{
+			manifest:        manifest,
+			actions:         scannerAction,
+			imageActions:    helper.GetImageExperimentalScannerActions(cmd),
+			allowNoPackages: cmd.Bool("allow-no-lockfiles"),
+			checkpointDir:   checkpoints,
+			resume:          resume,
+			stdout:          stdout,
+			stderr:          stderr,
+			format:          format,
+			reporterOpts:    helper.GetReporterOptions(cmd),
+		})
+
+		if checkpoints != "" && err != nil && len(exitcode.Findings(err)) == 0 {
+			cmdlogger.Infof("Use --resume to continue from the targets that were completed")
+		}
+	} else {
+		vulnResult, err = osvscanner.DoScanContext(ctx, scannerAction)
+	}
 
 	if cmd.Bool("allow-no-lockfiles") && errors.Is(err, osvscanner.ErrNoPackagesFound) {
 		cmdlogger.Warnf("No package sources found")
 		err = nil
 	}
 
-	if err != nil && !errors.Is(err, osvscanner.ErrVulnerabilitiesFound) {
+	// the results of packages that could be checked are still output if others could not be
+	if err != nil && !osvscanner.IsFindingErr(err) {
 		return err
 	}
 
-	if errPrint := helper.PrintResult(stdout, stderr, outputPath, format, &vulnResult, scannerAction.ShowAllVulns); errPrint != nil {
-		return fmt.Errorf("failed to write output: %w", errPrint)
+	// packages that could not be scanned are always reported by the policies other than the default
+	policy := helper.GetExitCodePolicy(cmd)
+	if len(vulnResult.UnscannedPackages) > 0 && (cmd.Bool("fail-on-unscanned-packages") || exitCodes.Reports(policy, exitcode.PartialScan)) {
+		err = errors.Join(err, osvscanner.ErrUnscannedPackagesFound)
+	}
+
+	if errOutput := helper.OutputResults(ctx, cmd, stdout, stderr, outputPath, format, scanned, &vulnResult); errOutput != nil {
+		return errOutput
+	}
+
+	if checkpoints != "" {
+		if errRemove := os.RemoveAll(checkpoints); errRemove != nil {
+			cmdlogger.Warnf("Failed to remove the results of each target from %s: %v", checkpoints, errRemove)
+		}
 	}
 
 	// Auto-open outputted HTML file for users.
@@ -164,5 +363,5 @@ func action(_ context.Context, cmd *cli.Command, stdout, stderr io.Writer) error
 	}
 
 	// This may be nil.
-	return err
+	return exitcode.WithPolicy(err, policy)
 }
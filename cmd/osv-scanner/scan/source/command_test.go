@@ -1,12 +1,15 @@
 package source_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/testcmd"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/scan/source"
 	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
 )
 
 func TestCommand(t *testing.T) {
@@ -735,6 +738,53 @@ func TestCommand_LocalDatabases_AlwaysOffline(t *testing.T) {
 	}
 }
 
+func TestCommand_Resume(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		testcmd.Case
+
+		// scanErrs are the errors that each target was completed with by the scan being resumed
+		scanErrs []error
+	}{
+		{
+			Case: testcmd.Case{
+				Name: "vulnerabilities were found by the previous scan",
+				Exit: 1,
+			},
+			scanErrs: []error{osvscanner.ErrVulnerabilitiesFound, nil},
+		},
+		{
+			Case: testcmd.Case{
+				Name: "some packages could not be checked by the previous scan",
+				Exit: 129,
+			},
+			scanErrs: []error{
+				osvscanner.ErrVulnerabilitiesFound,
+				errors.Join(osvscanner.ErrVulnerabilitiesFound, osvscanner.ErrPartialAPIFailure),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			manifestPath := "./fixtures/targets-resume/targets.yaml"
+			outputPath := filepath.Join(t.TempDir(), "results.json")
+
+			// every target was completed by the previous scan, so none of them are scanned again
+			if err := source.SaveTargetCheckpoints(manifestPath, outputPath, tt.scanErrs); err != nil {
+				t.Fatalf("could not save checkpoints: %v", err)
+			}
+
+			tt.Args = []string{"", "source", "--targets", manifestPath, "--output", outputPath, "--resume"}
+
+			testcmd.RunAndMatchSnapshots(t, tt.Case)
+		})
+	}
+}
+
 func TestCommand_Licenses(t *testing.T) {
 	t.Parallel()
 
@@ -810,14 +860,19 @@ func TestCommand_Licenses(t *testing.T) {
 			Exit: 1,
 		},
 		{
-			Name: "When offline licenses summary cannot be printed",
-			Args: []string{"", "source", "--offline", "--licenses", "--config=./fixtures/osv-scanner-empty-config.toml", "./fixtures/locks-many/package-lock.json"},
-			Exit: 127,
+			Name: "When offline licenses summary is read from the local dataset",
+			Args: []string{"", "source", "--offline", "--local-db-path=./fixtures/local-license-db", "--licenses", "./fixtures/locks-licenses/package-lock.json"},
+			Exit: 0,
 		},
 		{
-			Name: "When offline licenses cannot be checked",
-			Args: []string{"", "source", "--offline", "--licenses=MIT", "--config=./fixtures/osv-scanner-empty-config.toml", "./fixtures/locks-many/package-lock.json"},
-			Exit: 127,
+			Name: "When offline licenses are checked against the local dataset",
+			Args: []string{"", "source", "--offline", "--local-db-path=./fixtures/local-license-db", "--licenses=MIT", "./fixtures/locks-licenses/package-lock.json"},
+			Exit: 1,
+		},
+		{
+			Name: "When offline licenses are unknown for an ecosystem missing from the local dataset",
+			Args: []string{"", "source", "--offline", "--local-db-path=./fixtures/local-license-db", "--licenses", "./fixtures/locks-many/composer.lock"},
+			Exit: 0,
 		},
 		{
 			Name: "When offline licenses are still validated",
@@ -0,0 +1,21 @@
+package source
+
+import "github.com/google/osv-scanner/v2/pkg/models"
+
+// SaveTargetCheckpoints saves empty results for each target of the manifest at
+// manifestPath to the checkpoint directory of outputPath, as if they were completed
+// by a scan that returned the corresponding error of errs
+func SaveTargetCheckpoints(manifestPath, outputPath string, errs []error) error {
+	manifest, err := loadTargetsManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range manifest.Targets {
+		if err := saveCheckpoint(checkpointDir(outputPath), t, models.VulnerabilityResults{}, errs[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package source
+
+import (
+	"slices"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/kubernetes"
+	"github.com/urfave/cli/v3"
+)
+
+// withKubernetesExtractor adds the extractor for Kubernetes manifests and Helm charts
+// if their images are to be found, unless it has already been enabled by name
+func withKubernetesExtractor(cmd *cli.Command, extractors []filesystem.Extractor) []filesystem.Extractor {
+	if !cmd.Bool("kubernetes") && !cmd.Bool("scan-kubernetes-images") {
+		return extractors
+	}
+
+	if slices.ContainsFunc(extractors, func(e filesystem.Extractor) bool { return e.Name() == kubernetes.Name }) {
+		return extractors
+	}
+
+	return append(extractors, kubernetes.Extractor{})
+}
@@ -0,0 +1,54 @@
+package source
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sandboxed"
+	"github.com/urfave/cli/v3"
+)
+
+// withSandboxedExtractors adds an extractor for each tool that is enabled to be run in
+// a sandbox to the extractors, replacing the extractors that they supersede
+func withSandboxedExtractors(cmd *cli.Command, extractors []filesystem.Extractor) ([]filesystem.Extractor, error) {
+	names := cmd.StringSlice("experimental-resolve-in-sandbox")
+	if len(names) == 0 {
+		return extractors, nil
+	}
+
+	runtime := cmd.String("experimental-sandbox-runtime")
+	if _, err := exec.LookPath(runtime); err != nil {
+		return nil, fmt.Errorf("container runtime %q is needed to resolve manifests in a sandbox: %w", runtime, err)
+	}
+
+	// the definitions have already been validated when parsing the flags
+	registries, _ := sandboxed.ParseToolValues(cmd.StringSlice("experimental-sandbox-registry"))
+	images, _ := sandboxed.ParseToolValues(cmd.StringSlice("experimental-sandbox-image"))
+
+	config := sandboxed.Config{
+		Runtime:    []string{runtime},
+		Network:    sandboxed.NetworkMode(cmd.String("experimental-sandbox-network")),
+		Registries: registries,
+		Images:     images,
+	}
+
+	var superseded []string
+	sandboxedExtractors := make([]filesystem.Extractor, 0, len(names))
+	for _, name := range names {
+		extractor, err := sandboxed.New(sandboxed.Tool(name), config)
+		if err != nil {
+			return nil, err
+		}
+
+		superseded = append(superseded, extractor.Supersedes()...)
+		sandboxedExtractors = append(sandboxedExtractors, extractor)
+	}
+
+	extractors = slices.DeleteFunc(slices.Clone(extractors), func(extractor filesystem.Extractor) bool {
+		return slices.Contains(superseded, extractor.Name())
+	})
+
+	return append(extractors, sandboxedExtractors...), nil
+}
@@ -0,0 +1,420 @@
+package source
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/helper"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/progress"
+	"github.com/google/osv-scanner/v2/internal/reporter"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultTargetsConcurrency is how many targets are scanned at the same time
+// if the manifest does not say otherwise
+const defaultTargetsConcurrency = 4
+
+// targetsManifest lists the targets to scan with --targets
+type targetsManifest struct {
+	// Concurrency is how many targets are scanned at the same time
+	Concurrency int      `yaml:"concurrency"`
+	Targets     []target `yaml:"targets"`
+}
+
+// target is something to scan, along with any overrides of the flags it is scanned with
+type target struct {
+	// Name identifies the target in logs, defaulting to what is being scanned
+	Name string `yaml:"name"`
+
+	// exactly one of these must be set
+	Directory string `yaml:"directory"`
+	Lockfile  string `yaml:"lockfile"`
+	SBOM      string `yaml:"sbom"`
+	PURLFile  string `yaml:"purl-file"`
+	Image     string `yaml:"image"`
+
+	// Archive is whether the image is a local archive rather than the name of an image
+	Archive bool `yaml:"archive"`
+
+	Recursive      *bool    `yaml:"recursive"`
+	NoIgnore       *bool    `yaml:"no-ignore"`
+	Config         []string `yaml:"config"`
+	FailOnSeverity []string `yaml:"fail-on-severity"`
+
+	// Output is where to write the results of just this target, if anywhere
+	Output string `yaml:"output"`
+}
+
+// kind returns what sort of target this is, returning an error unless exactly one thing is being scanned
+func (t target) kind() (string, error) {
+	var kinds []string
+	for kind, value := range map[string]string{
+		"directory": t.Directory,
+		"lockfile":  t.Lockfile,
+		"sbom":      t.SBOM,
+		"purl-file": t.PURLFile,
+		"image":     t.Image,
+	} {
+		if value != "" {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	if len(kinds) != 1 {
+		slices.Sort(kinds)
+
+		return "", fmt.Errorf("must have exactly one of directory, lockfile, sbom, purl-file, or image, but has %d (%s)", len(kinds), strings.Join(kinds, ", "))
+	}
+
+	return kinds[0], nil
+}
+
+// loadTargetsManifest reads the manifest at path, resolving the paths within it relative to the manifest
+func loadTargetsManifest(path string) (targetsManifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return targetsManifest{}, fmt.Errorf("failed to read targets manifest: %w", err)
+	}
+	defer f.Close()
+
+	return parseTargetsManifest(f, filepath.Dir(path))
+}
+
+func parseTargetsManifest(r io.Reader, dir string) (targetsManifest, error) {
+	var manifest targetsManifest
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&manifest); err != nil {
+		return targetsManifest{}, fmt.Errorf("failed to parse targets manifest: %w", err)
+	}
+
+	if len(manifest.Targets) == 0 {
+		return targetsManifest{}, errors.New("targets manifest does not have any targets")
+	}
+
+	if manifest.Concurrency < 0 {
+		return targetsManifest{}, fmt.Errorf("targets manifest concurrency must not be negative, got %d", manifest.Concurrency)
+	}
+
+	if manifest.Concurrency == 0 {
+		manifest.Concurrency = defaultTargetsConcurrency
+	}
+
+	resolve := func(p string) string {
+		if p == "" || p == "-" || filepath.IsAbs(p) {
+			return p
+		}
+
+		return filepath.Join(dir, p)
+	}
+
+	for i := range manifest.Targets {
+		t := &manifest.Targets[i]
+
+		kind, err := t.kind()
+		if err != nil {
+			return targetsManifest{}, fmt.Errorf("target %d %w", i+1, err)
+		}
+
+		if t.Archive && kind != "image" {
+			return targetsManifest{}, fmt.Errorf("target %d can only be an archive if it is an image", i+1)
+		}
+
+		if kind == "image" && !t.Archive && !strings.Contains(t.Image, ":") {
+			return targetsManifest{}, fmt.Errorf("target %d: %q is not a tagged image name", i+1, t.Image)
+		}
+
+		if t.Name == "" {
+			t.Name = cmp.Or(t.Directory, t.Lockfile, t.SBOM, t.PURLFile, t.Image)
+		}
+
+		t.Directory = resolve(t.Directory)
+		t.SBOM = resolve(t.SBOM)
+		t.PURLFile = resolve(t.PURLFile)
+		t.Output = resolve(t.Output)
+		if t.Archive {
+			t.Image = resolve(t.Image)
+		}
+
+		// lockfiles can be prefixed with the format to parse them as
+		if format, lockfile, ok := strings.Cut(t.Lockfile, ":"); ok && !filepath.IsAbs(t.Lockfile) {
+			t.Lockfile = format + ":" + resolve(lockfile)
+		} else {
+			t.Lockfile = resolve(t.Lockfile)
+		}
+
+		for j := range t.Config {
+			t.Config[j] = resolve(t.Config[j])
+		}
+	}
+
+	return manifest, nil
+}
+
+// actions returns the scanner actions for the target, overriding those given by flags
+func (t target) actions(base osvscanner.ScannerActions, imageActions osvscanner.ExperimentalScannerActions) osvscanner.ScannerActions {
+	actions := base
+
+	actions.DirectoryPaths = nil
+	actions.LockfilePaths = nil
+	//nolint:staticcheck // ignore our own deprecated field
+	actions.SBOMPaths = nil
+	actions.PURLListPaths = nil
+
+	switch {
+	case t.Directory != "":
+		actions.DirectoryPaths = []string{t.Directory}
+	case t.Lockfile != "":
+		actions.LockfilePaths = []string{t.Lockfile}
+	case t.SBOM != "":
+		actions.LockfilePaths = []string{t.SBOM}
+	case t.PURLFile != "":
+		actions.PURLListPaths = []string{t.PURLFile}
+	case t.Image != "":
+		actions.Image = t.Image
+		actions.IsImageArchive = t.Archive
+		actions.ExperimentalScannerActions = imageActions
+	}
+
+	if t.Recursive != nil {
+		actions.Recursive = *t.Recursive
+	}
+
+	if t.NoIgnore != nil {
+		actions.NoIgnore = *t.NoIgnore
+	}
+
+	if len(t.Config) > 0 {
+		actions.ConfigOverridePaths = t.Config
+	}
+
+	if len(t.FailOnSeverity) > 0 {
+		actions.FailOnSeverity = t.FailOnSeverity
+	}
+
+	return actions
+}
+
+// targetsScan is how the targets of a manifest are scanned and reported on
+type targetsScan struct {
+	manifest targetsManifest
+
+	// actions are the scanner actions from the flags, which each target overrides
+	actions osvscanner.ScannerActions
+	// imageActions are the experimental scanner actions to scan images with,
+	// as images are scanned with different extractors to source projects
+	imageActions osvscanner.ExperimentalScannerActions
+
+	allowNoPackages bool
+
+	// checkpointDir is where the results of each target are saved as they are
+	// completed, if anywhere
+	checkpointDir string
+	// resume loads the results of targets that were completed by a previous scan
+	// from the checkpoint directory, rather than scanning them again
+	resume bool
+
+	stdout, stderr io.Writer
+	format         string
+	reporterOpts   reporter.Options
+}
+
+// scanTargets scans every target of the manifest concurrently, writing the results of
+// each target to its output if it has one, and returning the results of all the targets
+// merged together. The findings (such as ErrVulnerabilitiesFound) of every target are returned joined together.
+func scanTargets(ctx context.Context, s targetsScan) (models.VulnerabilityResults, error) {
+	concurrency := s.manifest.Concurrency
+
+	// the offline databases are downloaded to the same place for every target
+	if s.actions.CompareOffline && s.actions.DownloadDatabases {
+		concurrency = 1
+	}
+
+	// heartbeats of each target report how many of the targets have been completed
+	s.actions.ProgressTargets = progress.NewTargets(len(s.manifest.Targets))
+
+	results := make([]models.VulnerabilityResults, len(s.manifest.Targets))
+	var findings []error
+	var mu sync.Mutex
+
+	g := errgroup.Group{}
+	g.SetLimit(concurrency)
+
+	for i, t := range s.manifest.Targets {
+		g.Go(func() error {
+			var err error
+			results[i], err = s.scanTarget(ctx, t)
+			s.actions.ProgressTargets.Done()
+
+			if s.allowNoPackages && errors.Is(err, osvscanner.ErrNoPackagesFound) {
+				cmdlogger.Warnf("No package sources found for target %s", t.Name)
+				results[i].Diagnostics = append(results[i].Diagnostics, models.Diagnostic{
+					Kind:    models.DiagnosticPartialResult,
+					Source:  t.Name,
+					Message: "no package sources found for target",
+				})
+				err = nil
+			}
+
+			if osvscanner.IsFindingErr(err) {
+				mu.Lock()
+				findings = append(findings, err)
+				mu.Unlock()
+			} else if err != nil {
+				return fmt.Errorf("failed to scan target %s: %w", t.Name, err)
+			}
+
+			if t.Output != "" {
+				if err := helper.PrintResult(s.stdout, s.stderr, t.Output, s.format, &results[i], s.reporterOpts); err != nil {
+					return fmt.Errorf("failed to write output of target %s: %w", t.Name, err)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	return mergeResults(results), errors.Join(findings...)
+}
+
+// scanTarget scans the target, unless it was completed by a previous scan that is being resumed,
+// saving its results to the checkpoint directory if the scan did not fail
+func (s targetsScan) scanTarget(ctx context.Context, t target) (models.VulnerabilityResults, error) {
+	if s.resume {
+		c, ok, err := loadCheckpoint(s.checkpointDir, t)
+		if err != nil {
+			cmdlogger.Warnf("Failed to load the results of target %s from the previous scan: %v", t.Name, err)
+		} else if ok {
+			cmdlogger.Infof("Using the results of target %s from the previous scan", t.Name)
+
+			return c.Results, c.err()
+		}
+	}
+
+	actions := t.actions(s.actions, s.imageActions)
+
+	cmdlogger.Infof("Scanning target %s", t.Name)
+
+	var results models.VulnerabilityResults
+	var err error
+	if actions.Image != "" {
+		results, err = osvscanner.DoContainerScanContext(ctx, actions)
+	} else {
+		results, err = osvscanner.DoScanContext(ctx, actions)
+	}
+
+	if s.checkpointDir != "" {
+		if errSave := saveCheckpoint(s.checkpointDir, t, results, err); errSave != nil {
+			cmdlogger.Warnf("Failed to save the results of target %s: %v", t.Name, errSave)
+		}
+	}
+
+	return results, err
+}
+
+// mergeResults combines the results of scanning multiple targets into a single set of results
+func mergeResults(results []models.VulnerabilityResults) models.VulnerabilityResults {
+	merged := models.VulnerabilityResults{Results: []models.PackageSource{}}
+
+	licenseCounts := make(map[models.License]int)
+	extractorStats := make(map[string]models.ExtractorStats)
+	var images []*models.ImageMetadata
+
+	for _, result := range results {
+		merged.Results = append(merged.Results, result.Results...)
+		merged.UnscannedPackages = append(merged.UnscannedPackages, result.UnscannedPackages...)
+		merged.IntegrityMismatches = append(merged.IntegrityMismatches, result.IntegrityMismatches...)
+		merged.Diagnostics = append(merged.Diagnostics, result.Diagnostics...)
+
+		// every target is scanned with the same analysis enabled and labels
+		merged.ExperimentalAnalysisConfig = result.ExperimentalAnalysisConfig
+		merged.Labels = result.Labels
+
+		if result.ImageMetadata != nil {
+			images = append(images, result.ImageMetadata)
+		}
+
+		if result.LicenseSummary != nil {
+			merged.LicenseSummary = []models.LicenseCount{}
+		}
+		for _, lc := range result.LicenseSummary {
+			licenseCounts[lc.Name] += lc.Count
+		}
+
+		if result.ExperimentalScanStats != nil {
+			if merged.ExperimentalScanStats == nil {
+				merged.ExperimentalScanStats = &models.ScanStats{}
+			}
+			merged.ExperimentalScanStats.FilesConsidered += result.ExperimentalScanStats.FilesConsidered
+
+			for _, es := range result.ExperimentalScanStats.Extractors {
+				total := extractorStats[es.Name]
+				total.Name = es.Name
+				total.FilesMatched += es.FilesMatched
+				total.FilesParsed += es.FilesParsed
+				total.FilesErrored += es.FilesErrored
+				total.PackagesFound += es.PackagesFound
+				total.DurationMs += es.DurationMs
+				extractorStats[es.Name] = total
+			}
+		}
+
+		// the maximum applies to each target rather than to all of them together
+		if result.RiskScore != nil {
+			if merged.RiskScore == nil {
+				merged.RiskScore = &models.RiskScore{}
+			}
+			merged.RiskScore.Score += result.RiskScore.Score
+		}
+	}
+
+	// the layers of an image can only be shown if it is the only image
+	if len(images) == 1 {
+		merged.ImageMetadata = images[0]
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(licenseCounts)) {
+		merged.LicenseSummary = append(merged.LicenseSummary, models.LicenseCount{Name: name, Count: licenseCounts[name]})
+	}
+
+	// sort the licenses in descending count order with the UNKNOWN license last,
+	// in the same way as the summary of a single scan
+	slices.SortStableFunc(merged.LicenseSummary, func(a, b models.LicenseCount) int {
+		if (a.Name == "UNKNOWN") != (b.Name == "UNKNOWN") {
+			if a.Name == "UNKNOWN" {
+				return 1
+			}
+
+			return -1
+		}
+
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	if merged.ExperimentalScanStats != nil {
+		for _, name := range slices.Sorted(maps.Keys(extractorStats)) {
+			merged.ExperimentalScanStats.Extractors = append(merged.ExperimentalScanStats.Extractors, extractorStats[name])
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,212 @@
+package source
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+)
+
+func Test_parseTargetsManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.FromSlash("/path/to")
+	recursive := true
+
+	manifest, err := parseTargetsManifest(strings.NewReader(`
+targets:
+  - name: backend
+    directory: ./backend
+    recursive: true
+    config: [./backend/osv-scanner.toml]
+    output: reports/backend.json
+  - lockfile: requirements.txt:frontend/deps.txt
+  - sbom: /sboms/app.cdx.json
+  - image: alpine:3.19
+    fail-on-severity: [HIGH]
+  - image: images/app.tar
+    archive: true
+`), dir)
+	if err != nil {
+		t.Fatalf("parseTargetsManifest() error = %v", err)
+	}
+
+	want := targetsManifest{
+		Concurrency: defaultTargetsConcurrency,
+		Targets: []target{
+			{
+				Name:      "backend",
+				Directory: filepath.Join(dir, "backend"),
+				Recursive: &recursive,
+				Config:    []string{filepath.Join(dir, "backend", "osv-scanner.toml")},
+				Output:    filepath.Join(dir, "reports", "backend.json"),
+			},
+			{
+				Name:     "requirements.txt:frontend/deps.txt",
+				Lockfile: "requirements.txt:" + filepath.Join(dir, "frontend", "deps.txt"),
+			},
+			{
+				Name: "/sboms/app.cdx.json",
+				SBOM: "/sboms/app.cdx.json",
+			},
+			{
+				Name:           "alpine:3.19",
+				Image:          "alpine:3.19",
+				FailOnSeverity: []string{"HIGH"},
+			},
+			{
+				Name:    "images/app.tar",
+				Image:   filepath.Join(dir, "images", "app.tar"),
+				Archive: true,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, manifest); diff != "" {
+		t.Errorf("parseTargetsManifest() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_parseTargetsManifest_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name:     "no targets",
+			manifest: "concurrency: 2\n",
+			wantErr:  "does not have any targets",
+		},
+		{
+			name:     "unknown field",
+			manifest: "targets:\n  - directory: .\n    recursve: true\n",
+			wantErr:  "field recursve not found",
+		},
+		{
+			name:     "nothing to scan",
+			manifest: "targets:\n  - name: empty\n",
+			wantErr:  "target 1 must have exactly one of",
+		},
+		{
+			name:     "multiple things to scan",
+			manifest: "targets:\n  - directory: .\n    lockfile: go.mod\n",
+			wantErr:  "but has 2 (directory, lockfile)",
+		},
+		{
+			name:     "archive that is not an image",
+			manifest: "targets:\n  - directory: .\n    archive: true\n",
+			wantErr:  "can only be an archive if it is an image",
+		},
+		{
+			name:     "untagged image",
+			manifest: "targets:\n  - image: alpine\n",
+			wantErr:  "is not a tagged image name",
+		},
+		{
+			name:     "negative concurrency",
+			manifest: "concurrency: -1\ntargets:\n  - directory: .\n",
+			wantErr:  "must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseTargetsManifest(strings.NewReader(tt.manifest), ".")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseTargetsManifest() error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_target_actions(t *testing.T) {
+	t.Parallel()
+
+	noIgnore := true
+	base := osvscanner.ScannerActions{
+		DirectoryPaths:      []string{"ignored"},
+		Recursive:           true,
+		ConfigOverridePaths: []string{"base.toml"},
+		FailOnSeverity:      []string{"CRITICAL"},
+	}
+
+	got := target{
+		Lockfile:       "go.mod",
+		NoIgnore:       &noIgnore,
+		Config:         []string{"target.toml"},
+		FailOnSeverity: []string{"HIGH"},
+	}.actions(base, osvscanner.ExperimentalScannerActions{})
+
+	want := osvscanner.ScannerActions{
+		LockfilePaths:       []string{"go.mod"},
+		Recursive:           true,
+		NoIgnore:            true,
+		ConfigOverridePaths: []string{"target.toml"},
+		FailOnSeverity:      []string{"HIGH"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("actions() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_mergeResults(t *testing.T) {
+	t.Parallel()
+
+	source := func(path string) models.PackageSource {
+		return models.PackageSource{Source: models.SourceInfo{Path: path, Type: models.SourceTypeProjectPackage}}
+	}
+
+	got := mergeResults([]models.VulnerabilityResults{
+		{
+			Results:        []models.PackageSource{source("backend/go.mod")},
+			LicenseSummary: []models.LicenseCount{{Name: "MIT", Count: 2}, {Name: "UNKNOWN", Count: 1}},
+			ExperimentalScanStats: &models.ScanStats{
+				FilesConsidered: 10,
+				Extractors:      []models.ExtractorStats{{Name: "go/gomod", FilesMatched: 1, FilesParsed: 1, PackagesFound: 5}},
+			},
+			RiskScore: &models.RiskScore{Score: 4, MaxScore: 10},
+		},
+		{
+			Results:           []models.PackageSource{source("frontend/package-lock.json")},
+			UnscannedPackages: []models.UnscannedPackage{{Reason: models.UnscannedReasonMissingVersion}},
+			LicenseSummary:    []models.LicenseCount{{Name: "Apache-2.0", Count: 4}, {Name: "MIT", Count: 1}},
+			ExperimentalScanStats: &models.ScanStats{
+				FilesConsidered: 5,
+				Extractors:      []models.ExtractorStats{{Name: "javascript/packagelockjson", FilesMatched: 1, FilesParsed: 1, PackagesFound: 20}},
+			},
+			RiskScore:   &models.RiskScore{Score: 7.5, MaxScore: 10},
+			Diagnostics: []models.Diagnostic{{Kind: models.DiagnosticExtractionError, Path: "frontend/yarn.lock"}},
+		},
+	})
+
+	want := models.VulnerabilityResults{
+		Results:           []models.PackageSource{source("backend/go.mod"), source("frontend/package-lock.json")},
+		UnscannedPackages: []models.UnscannedPackage{{Reason: models.UnscannedReasonMissingVersion}},
+		LicenseSummary: []models.LicenseCount{
+			{Name: "Apache-2.0", Count: 4},
+			{Name: "MIT", Count: 3},
+			{Name: "UNKNOWN", Count: 1},
+		},
+		ExperimentalScanStats: &models.ScanStats{
+			FilesConsidered: 15,
+			Extractors: []models.ExtractorStats{
+				{Name: "go/gomod", FilesMatched: 1, FilesParsed: 1, PackagesFound: 5},
+				{Name: "javascript/packagelockjson", FilesMatched: 1, FilesParsed: 1, PackagesFound: 20},
+			},
+		},
+		RiskScore:   &models.RiskScore{Score: 11.5},
+		Diagnostics: []models.Diagnostic{{Kind: models.DiagnosticExtractionError, Path: "frontend/yarn.lock"}},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeResults() mismatch (-want +got):\n%s", diff)
+	}
+}
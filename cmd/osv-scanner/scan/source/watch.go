@@ -0,0 +1,322 @@
+package source
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+)
+
+// watchInterval is how often the watched directories are checked for changes
+const watchInterval = time.Second
+
+// fileState is what is compared to determine if a watched file has changed
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// watchedFinding is a vulnerability of a package from a watched file
+type watchedFinding struct {
+	source    string
+	ecosystem string
+	name      string
+	version   string
+	// ids are the ids of the group of aliases that the vulnerability is in
+	ids string
+}
+
+// watcher re-scans the manifests and lockfiles of directories whenever they change,
+// reporting the vulnerabilities that have been added or removed by each change
+type watcher struct {
+	actions osvscanner.ScannerActions
+	stdout  io.Writer
+	// scan is osvscanner.DoScanContext, unless it is replaced by tests
+	scan func(context.Context, osvscanner.ScannerActions) (models.VulnerabilityResults, error)
+
+	files    map[string]fileState
+	findings map[string][]watchedFinding
+}
+
+func newWatcher(actions osvscanner.ScannerActions, stdout io.Writer) *watcher {
+	return &watcher{
+		actions:  actions,
+		stdout:   stdout,
+		scan:     osvscanner.DoScanContext,
+		findings: make(map[string][]watchedFinding),
+	}
+}
+
+// watch scans the directories of the actions and then re-scans the files that change until
+// the context is cancelled, with the results of the first scan being printed by printResult
+// and only the changes in vulnerabilities being printed after that
+func (w *watcher) watch(ctx context.Context, printResult func(*models.VulnerabilityResults) error) error {
+	w.files = w.snapshot()
+
+	vulnResult, err := w.scan(ctx, w.actions)
+	if err != nil && !osvscanner.IsFindingErr(err) && !errors.Is(err, osvscanner.ErrNoPackagesFound) {
+		return err
+	}
+	w.findings = collectWatchedFindings(vulnResult)
+
+	if err := printResult(&vulnResult); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	cmdlogger.Infof("Watching %d %s for changes...", len(w.files), output.Form(len(w.files), "file", "files"))
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var pending []string
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		files := w.snapshot()
+		changed := changedFiles(w.files, files)
+		w.files = files
+
+		// files are only re-scanned once they have stopped changing, so that
+		// tools that write them in multiple steps do not trigger multiple scans
+		if len(changed) > 0 {
+			pending = slices.Compact(slices.Sorted(slices.Values(append(pending, changed...))))
+			continue
+		}
+
+		if len(pending) > 0 {
+			w.rescan(ctx, pending)
+			pending = nil
+		}
+	}
+}
+
+// rescan scans the files that have changed, printing the vulnerabilities that have been added
+// or removed since they were last scanned; files that have been removed no longer have any
+func (w *watcher) rescan(ctx context.Context, changed []string) {
+	var existing []string
+	for _, path := range changed {
+		if _, ok := w.files[path]; ok {
+			existing = append(existing, path)
+		}
+	}
+
+	fmt.Fprintf(w.stdout, "\nRescanning %s...\n", strings.Join(relativePaths(changed), ", "))
+
+	current := make(map[string][]watchedFinding)
+	if len(existing) > 0 {
+		actions := w.actions
+		actions.DirectoryPaths = nil
+		actions.LockfilePaths = existing
+		actions.PURLListPaths = nil
+		actions.Recursive = false
+
+		vulnResult, err := w.scan(ctx, actions)
+		if err != nil && !osvscanner.IsFindingErr(err) && !errors.Is(err, osvscanner.ErrNoPackagesFound) {
+			cmdlogger.Errorf("Failed to rescan: %s", err)
+			return
+		}
+		current = collectWatchedFindings(vulnResult)
+	}
+
+	var previous []watchedFinding
+	var latest []watchedFinding
+	for _, path := range changed {
+		previous = append(previous, w.findings[path]...)
+		latest = append(latest, current[path]...)
+
+		if len(current[path]) > 0 {
+			w.findings[path] = current[path]
+		} else {
+			delete(w.findings, path)
+		}
+	}
+
+	printWatchDelta(w.stdout, previous, latest)
+}
+
+// snapshot returns the state of the files in the watched directories that would be
+// scanned, which are those that are required by at least one of the extractors
+func (w *watcher) snapshot() map[string]fileState {
+	files := make(map[string]fileState)
+
+	for _, dir := range w.actions.DirectoryPaths {
+		root, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil //nolint:nilerr // files that cannot be read are not watched
+			}
+
+			if d.IsDir() {
+				// dependencies are not installed by lockfiles changing, so they are not watched
+				if path != root && (!w.actions.Recursive || d.Name() == ".git" || d.Name() == "node_modules") {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil || !info.Mode().IsRegular() {
+				return nil //nolint:nilerr // files that cannot be read are not watched
+			}
+
+			api := simplefileapi.New(path, info)
+			if slices.ContainsFunc(w.actions.Extractors, func(e filesystem.Extractor) bool { return e.FileRequired(api) }) {
+				files[path] = fileState{size: info.Size(), modTime: info.ModTime()}
+			}
+
+			return nil
+		})
+	}
+
+	return files
+}
+
+// changedFiles returns the files that have been added, removed, or modified between snapshots
+func changedFiles(previous, current map[string]fileState) []string {
+	var changed []string
+
+	for path, state := range current {
+		if prev, ok := previous[path]; !ok || prev != state {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	slices.Sort(changed)
+
+	return changed
+}
+
+// collectWatchedFindings returns the vulnerabilities of the results, keyed by the file they are from
+func collectWatchedFindings(vulnResult models.VulnerabilityResults) map[string][]watchedFinding {
+	findings := make(map[string][]watchedFinding)
+
+	for _, source := range vulnResult.Results {
+		path := filepath.Clean(filepath.FromSlash(source.Source.Path))
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+
+		for _, pkg := range source.Packages {
+			for _, group := range pkg.Groups {
+				// groups without ids are for license violations
+				if len(group.IDs) == 0 {
+					continue
+				}
+
+				findings[path] = append(findings[path], watchedFinding{
+					source:    path,
+					ecosystem: pkg.Package.Ecosystem,
+					name:      pkg.Package.Name,
+					version:   cmp.Or(pkg.Package.Version, pkg.Package.Commit),
+					ids:       strings.Join(group.IDs, ", "),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// printWatchDelta prints the vulnerabilities that are in only one of the previous and latest findings
+func printWatchDelta(w io.Writer, previous, latest []watchedFinding) {
+	added := watchedFindingsDifference(latest, previous)
+	removed := watchedFindingsDifference(previous, latest)
+
+	for _, f := range added {
+		fmt.Fprintf(w, "+ %s\n", f)
+	}
+	for _, f := range removed {
+		fmt.Fprintf(w, "- %s\n", f)
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Fprintln(w, "No vulnerabilities were added or removed")
+		return
+	}
+
+	fmt.Fprintf(
+		w,
+		"%d %s added, %d %s removed\n",
+		len(added),
+		output.Form(len(added), "vulnerability", "vulnerabilities"),
+		len(removed),
+		output.Form(len(removed), "vulnerability", "vulnerabilities"),
+	)
+}
+
+func (f watchedFinding) String() string {
+	return fmt.Sprintf("%s in %s@%s (%s) from %s", f.ids, f.name, f.version, f.ecosystem, relativePaths([]string{f.source})[0])
+}
+
+// watchedFindingsDifference returns the findings of a that are not in b, in a stable order
+func watchedFindingsDifference(a, b []watchedFinding) []watchedFinding {
+	inB := make(map[watchedFinding]bool, len(b))
+	for _, f := range b {
+		inB[f] = true
+	}
+
+	diff := make(map[watchedFinding]bool)
+	for _, f := range a {
+		if !inB[f] {
+			diff[f] = true
+		}
+	}
+
+	return slices.SortedFunc(maps.Keys(diff), func(x, y watchedFinding) int {
+		return cmp.Or(
+			cmp.Compare(x.source, y.source),
+			cmp.Compare(x.ecosystem, y.ecosystem),
+			cmp.Compare(x.name, y.name),
+			cmp.Compare(x.version, y.version),
+			cmp.Compare(x.ids, y.ids),
+		)
+	})
+}
+
+// relativePaths returns the paths relative to the working directory where possible
+func relativePaths(paths []string) []string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return paths
+	}
+
+	relative := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if rel, err := filepath.Rel(wd, path); err == nil {
+			path = rel
+		}
+		relative = append(relative, path)
+	}
+
+	return relative
+}
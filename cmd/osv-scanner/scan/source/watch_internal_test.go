@@ -0,0 +1,143 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/osvscanner"
+)
+
+func Test_changedFiles(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	previous := map[string]fileState{
+		"unchanged": {size: 1, modTime: now},
+		"modified":  {size: 1, modTime: now},
+		"removed":   {size: 1, modTime: now},
+	}
+	current := map[string]fileState{
+		"unchanged": {size: 1, modTime: now},
+		"modified":  {size: 2, modTime: now.Add(time.Second)},
+		"added":     {size: 1, modTime: now},
+	}
+
+	if diff := cmp.Diff([]string{"added", "modified", "removed"}, changedFiles(previous, current)); diff != "" {
+		t.Errorf("changedFiles() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_watcher_snapshot(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"package-lock.json", "README.md", "nested/package-lock.json", "node_modules/dep/package-lock.json"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, recursive := range []bool{false, true} {
+		w := newWatcher(osvscanner.ScannerActions{
+			DirectoryPaths: []string{dir},
+			Recursive:      recursive,
+			ExperimentalScannerActions: osvscanner.ExperimentalScannerActions{
+				Extractors: []filesystem.Extractor{packagelockjson.Extractor{}},
+			},
+		}, &bytes.Buffer{})
+
+		want := []string{filepath.Join(dir, "package-lock.json")}
+		if recursive {
+			want = append(want, filepath.Join(dir, "nested", "package-lock.json"))
+		}
+
+		var got []string
+		for path := range w.snapshot() {
+			got = append(got, path)
+		}
+
+		if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("snapshot() with recursive = %v mismatch (-want +got):\n%s", recursive, diff)
+		}
+	}
+}
+
+func Test_watcher_rescan(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	lockfile := filepath.Join(dir, "package-lock.json")
+	removed := filepath.Join(dir, "nested", "package-lock.json")
+
+	vulnerable := func(path, name, version, id string) models.PackageSource {
+		return models.PackageSource{
+			Source: models.SourceInfo{Path: filepath.ToSlash(path), Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{{
+				Package: models.PackageInfo{Name: name, Version: version, Ecosystem: "npm"},
+				Groups:  []models.GroupInfo{{IDs: []string{id}}},
+			}},
+		}
+	}
+
+	var scanned []string
+	stdout := &bytes.Buffer{}
+	w := newWatcher(osvscanner.ScannerActions{DirectoryPaths: []string{dir}}, stdout)
+	w.files = map[string]fileState{lockfile: {}}
+	w.scan = func(_ context.Context, actions osvscanner.ScannerActions) (models.VulnerabilityResults, error) {
+		scanned = actions.LockfilePaths
+
+		return models.VulnerabilityResults{
+			Results: []models.PackageSource{
+				vulnerable(lockfile, "lodash", "4.17.20", "GHSA-unchanged"),
+				vulnerable(lockfile, "minimist", "1.2.5", "GHSA-added"),
+			},
+		}, osvscanner.ErrVulnerabilitiesFound
+	}
+	w.findings = collectWatchedFindings(models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			vulnerable(lockfile, "lodash", "4.17.20", "GHSA-unchanged"),
+			vulnerable(lockfile, "lodash", "4.17.19", "GHSA-fixed"),
+			vulnerable(removed, "qs", "6.5.0", "GHSA-removed"),
+		},
+	})
+
+	w.rescan(t.Context(), []string{lockfile, removed})
+
+	if diff := cmp.Diff([]string{lockfile}, scanned); diff != "" {
+		t.Errorf("rescan() scanned files mismatch (-want +got):\n%s", diff)
+	}
+
+	got := stdout.String()
+	for _, want := range []string{
+		"+ GHSA-added in minimist@1.2.5 (npm)",
+		"- GHSA-fixed in lodash@4.17.19 (npm)",
+		"- GHSA-removed in qs@6.5.0 (npm)",
+		"1 vulnerability added, 2 vulnerabilities removed",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rescan() output does not contain %q:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "GHSA-unchanged") {
+		t.Errorf("rescan() output contains a vulnerability that did not change:\n%s", got)
+	}
+
+	if _, ok := w.findings[removed]; ok {
+		t.Errorf("rescan() kept the findings of a file that was removed")
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/diff"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/fix"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/cmd"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/internal/testcmd"
+	"github.com/google/osv-scanner/v2/cmd/osv-scanner/sbom"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/scan"
 	"github.com/google/osv-scanner/v2/cmd/osv-scanner/update"
 	"github.com/google/osv-scanner/v2/internal/testlogger"
@@ -31,6 +33,8 @@ func TestMain(m *testing.M) {
 		scan.Command,
 		fix.Command,
 		update.Command,
+		sbom.Command,
+		diff.Command,
 	}
 	m.Run()
 
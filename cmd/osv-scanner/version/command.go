@@ -0,0 +1,62 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scanner/v2/internal/selfsbom"
+	"github.com/urfave/cli/v3"
+)
+
+func Command(stdout, _ io.Writer) *cli.Command {
+	return &cli.Command{
+		Name:        "version",
+		Usage:       "prints the version of osv-scanner, or the modules that it was built from",
+		Description: "prints the version of osv-scanner; with --sbom, prints a CycloneDX SBOM of the Go modules embedded in the running binary",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "sbom",
+				Usage: "print a CycloneDX SBOM of the modules that osv-scanner was built from",
+			},
+			&cli.StringFlag{
+				Name:      "output",
+				Usage:     "saves the SBOM to the given file path",
+				TakesFile: true,
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return action(cmd, stdout)
+		},
+	}
+}
+
+func action(cmd *cli.Command, stdout io.Writer) error {
+	if !cmd.Bool("sbom") {
+		cli.VersionPrinter(cmd.Root())
+
+		return nil
+	}
+
+	info, err := selfsbom.Read()
+	if err != nil {
+		return err
+	}
+
+	if outputPath := cmd.String("output"); outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+
+		stdout = f
+	}
+
+	encoder := cyclonedx.NewBOMEncoder(stdout, cyclonedx.BOMFileFormatJSON)
+	encoder.SetPretty(true)
+
+	return encoder.Encode(selfsbom.BOM(info))
+}
@@ -18,15 +18,11 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/bunlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/pnpmlock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/yarnlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/php/composerlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pdmlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/poetrylock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/python/uvlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/r/renvlock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/ruby/gemfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargoauditable"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargolock"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/apk"
@@ -34,10 +30,35 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/devcontainer"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/dockerfile"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/githubactions"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/kubernetes"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/filesystem/vendored"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda/condameta"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda/environmentyml"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/cmakelists"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/unrealproject"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/vcpkg"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/csharp/unitypackages"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/golang/modulestxt"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/java/pomxmlenhanceable"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/java/wrapperproperties"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/jsbundle"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/nodemodules"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/yarnlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/nix/flakelock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/php/composerinstalled"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/ipynb"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/pylock"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/requirementsenhancable"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/uvlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/r/renvlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/ruby/gemfilelock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/terraform/terraformlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/os/nix"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/runtime/electron"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/detect"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/vcs/gitrepo"
 )
 
@@ -50,11 +71,45 @@ func build(name string) filesystem.Extractor {
 	// C
 	case conanlock.Name:
 		return conanlock.New()
+	case cmakelists.Name:
+		return cmakelists.Extractor{}
+	case vcpkg.Name:
+		return vcpkg.Extractor{}
+
+	// Conda
+	case environmentyml.Name:
+		return environmentyml.Extractor{}
+	case condameta.Name:
+		return condameta.Extractor{}
 
 	// Debian
 	case dpkg.Name:
 		return dpkg.NewDefault()
 
+	// NixOS
+	case nix.Name:
+		return nix.New()
+	case flakelock.Name:
+		return flakelock.Extractor{}
+
+	// Dev containers
+	case devcontainer.Name:
+		return devcontainer.Extractor{}
+
+	// Dockerfiles
+	case dockerfile.Name:
+		return dockerfile.Extractor{}
+
+	// Kubernetes
+	case kubernetes.Name:
+		return kubernetes.Extractor{}
+
+	// Game engines
+	case unitypackages.Name:
+		return unitypackages.Extractor{}
+	case unrealproject.Name:
+		return unrealproject.Extractor{}
+
 	// Erlang
 	case mixlock.Name:
 		return mixlock.New()
@@ -63,9 +118,15 @@ func build(name string) filesystem.Extractor {
 	case pubspec.Name:
 		return pubspec.New()
 
+	// GitHub Actions
+	case githubactions.Name:
+		return githubactions.Extractor{}
+
 	// Go
 	case gomod.Name:
 		return gomod.New()
+	case modulestxt.Name:
+		return modulestxt.Extractor{}
 	case gobinary.Name:
 		return gobinary.NewDefault()
 
@@ -82,6 +143,8 @@ func build(name string) filesystem.Extractor {
 		return gradleverificationmetadataxml.New()
 	case pomxmlenhanceable.Name:
 		return pomxmlenhanceable.New()
+	case wrapperproperties.Name:
+		return wrapperproperties.Extractor{}
 	case archive.Name:
 		return archive.NewDefault()
 
@@ -96,6 +159,8 @@ func build(name string) filesystem.Extractor {
 		return bunlock.New()
 	case nodemodules.Name:
 		return nodemodules.Extractor{}
+	case jsbundle.Name:
+		return jsbundle.Extractor{}
 
 	// NuGet
 	case depsjson.Name:
@@ -108,6 +173,8 @@ func build(name string) filesystem.Extractor {
 	// PHP
 	case composerlock.Name:
 		return composerlock.New()
+	case composerinstalled.Name:
+		return composerinstalled.Extractor{}
 
 	// Python
 	case pipfilelock.Name:
@@ -120,6 +187,10 @@ func build(name string) filesystem.Extractor {
 		return requirementsenhancable.New()
 	case uvlock.Name:
 		return uvlock.New()
+	case pylock.Name:
+		return pylock.New()
+	case ipynb.Name:
+		return ipynb.New()
 	case wheelegg.Name:
 		return wheelegg.NewDefault()
 
@@ -137,11 +208,21 @@ func build(name string) filesystem.Extractor {
 	case cargoauditable.Name:
 		return cargoauditable.NewDefault()
 
+	// Desktop applications
+	case electron.Name:
+		return electron.Extractor{}
+
+	// Terraform
+	case terraformlock.Name:
+		return terraformlock.Extractor{}
+
 	// SBOM
 	case spdx.Name:
 		return spdx.New()
 	case cdx.Name:
 		return cdx.New()
+	case detect.Name:
+		return detect.Extractor{}
 
 	// Directories
 	case vendored.Name:
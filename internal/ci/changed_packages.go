@@ -0,0 +1,58 @@
+package ci
+
+import (
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// changedPackageKey identifies a package version regardless of the source it is
+// in, so that packages in moved lockfiles are not considered to have changed
+type changedPackageKey struct {
+	ecosystem string
+	name      string
+	version   string
+	commit    string
+}
+
+func newChangedPackageKey(pkg models.PackageInfo) changedPackageKey {
+	return changedPackageKey{
+		ecosystem: pkg.Ecosystem,
+		name:      pkg.Name,
+		version:   pkg.Version,
+		commit:    pkg.Commit,
+	}
+}
+
+// FilterChangedPackages will return the packages in `newRes` whose version is not
+// present in `oldRes`, which are the packages that have been added or had their
+// version changed, along with all of their vulnerabilities.
+//
+// Unlike DiffVulnerabilityResults, this does not include vulnerabilities that are
+// new to packages which have not changed, which can happen when new advisories are
+// published between scans, or `oldRes` does not include packages without vulnerabilities.
+func FilterChangedPackages(oldRes, newRes models.VulnerabilityResults) models.VulnerabilityResults {
+	oldPackages := make(map[changedPackageKey]bool)
+	for _, ps := range oldRes.Results {
+		for _, pv := range ps.Packages {
+			oldPackages[newChangedPackageKey(pv.Package)] = true
+		}
+	}
+
+	result := models.VulnerabilityResults{}
+	for _, ps := range newRes.Results {
+		var packages []models.PackageVulns
+		for _, pv := range ps.Packages {
+			if !oldPackages[newChangedPackageKey(pv.Package)] {
+				packages = append(packages, pv)
+			}
+		}
+
+		if len(packages) > 0 {
+			result.Results = append(result.Results, models.PackageSource{
+				Source:   ps.Source,
+				Packages: packages,
+			})
+		}
+	}
+
+	return result
+}
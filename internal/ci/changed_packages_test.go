@@ -0,0 +1,68 @@
+package ci_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/ci"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func TestFilterChangedPackages(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		oldRes models.VulnerabilityResults
+		newRes models.VulnerabilityResults
+	}
+	tests := []struct {
+		name string
+		args args
+	}{
+		{
+			name: "same results",
+			args: args{
+				oldRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-a.json"),
+				newRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-a.json"),
+			},
+		},
+		{
+			// `newRes` is the same as `oldRes`, but with the source path moved, so the result should be empty
+			name: "moved source",
+			args: args{
+				oldRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-a.json"),
+				newRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-a-1.json"),
+			},
+		},
+		{
+			// `newRes` has one new GO vuln in a package that has not changed, so the result should be empty
+			name: "new vuln in unchanged package",
+			args: args{
+				oldRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-a.json"),
+				newRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-b.json"),
+			},
+		},
+		{
+			// `newRes` has a new `cargo.toml` source, so the result should contain just its package
+			name: "new source",
+			args: args{
+				oldRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-c.json"),
+				newRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-b.json"),
+			},
+		},
+		{
+			// `newRes` has updated the version of `regex`, so the result should contain just that package
+			name: "updated package",
+			args: args{
+				oldRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-a.json"),
+				newRes: testutility.LoadJSONFixture[models.VulnerabilityResults](t, "fixtures/vulns/test-vuln-results-d.json"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ci.FilterChangedPackages(tt.args.oldRes, tt.args.newRes)
+			testutility.NewSnapshot().MatchJSON(t, got)
+		})
+	}
+}
@@ -35,7 +35,8 @@ func DiffVulnerabilityResults(oldRes, newRes models.VulnerabilityResults) models
 			// Otherwise the old package used to exist, so we need to find the difference in the vulnerabilities
 			// Only copy over packages as vulns and groups might change
 			resultPS.Packages = append(resultPS.Packages, models.PackageVulns{
-				Package: pv.Package,
+				Package:     pv.Package,
+				Enrichments: pv.Enrichments,
 			})
 			resultPV := &resultPS.Packages[len(resultPS.Packages)-1]
 			for _, v := range pv.Vulnerabilities {
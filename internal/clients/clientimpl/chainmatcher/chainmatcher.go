@@ -0,0 +1,132 @@
+// Package chainmatcher combines the vulnerabilities found by several vulnerability matchers.
+package chainmatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientinterfaces"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// Source is a matcher of vulnerabilities, named so that it can be identified in logs
+type Source struct {
+	Name    string
+	Matcher clientinterfaces.VulnerabilityMatcher
+}
+
+// ChainMatcher implements the VulnerabilityMatcher interface by matching packages against
+// each of its sources in turn, and combining the vulnerabilities that they find.
+//
+// A vulnerability is skipped if it is the same as one that was found by an earlier source,
+// because they share an id or alias, so sources should be given in order of precedence.
+type ChainMatcher struct {
+	Sources []Source
+}
+
+// MatchVulnerabilities matches vulnerabilities for a list of packages against every source.
+//
+// A package is only reported as unmatched by an osvmatcher.UnmatchedPackagesError if none
+// of the sources were able to match it, as its vulnerabilities from the other sources are
+// still worth reporting; an error is returned without any results if any source fails entirely.
+func (matcher *ChainMatcher) MatchVulnerabilities(ctx context.Context, invs []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	vulnerabilities := make([][]*osvschema.Vulnerability, len(invs))
+	// seen are the ids and aliases of the vulnerabilities found for each package
+	seen := make([]map[string]struct{}, len(invs))
+
+	// failures are the packages that each source was unable to match
+	var failures []osvmatcher.EcosystemFailure
+	failedSources := make(map[int]int)
+
+	for _, source := range matcher.Sources {
+		res, err := source.Matcher.MatchVulnerabilities(ctx, invs)
+
+		var unmatchedErr *osvmatcher.UnmatchedPackagesError
+		if errors.As(err, &unmatchedErr) {
+			if len(matcher.Sources) > 1 {
+				cmdlogger.Warnf("Failed to check %d packages against %s: %v", len(unmatchedErr.Indexes()), source.Name, err)
+			}
+
+			for _, failure := range unmatchedErr.Failures {
+				failures = append(failures, failure)
+				for _, idx := range failure.Indexes {
+					failedSources[idx]++
+				}
+			}
+		} else if err != nil {
+			if res == nil {
+				return nil, fmt.Errorf("%s: %w", source.Name, err)
+			}
+			cmdlogger.Errorf("error when retrieving vulns from %s: %v", source.Name, err)
+		}
+
+		for i, vulns := range res {
+			if i >= len(invs) {
+				break
+			}
+
+			if seen[i] == nil {
+				seen[i] = make(map[string]struct{})
+			}
+
+			for _, vuln := range vulns {
+				if isSeen(seen[i], vuln) {
+					continue
+				}
+
+				seen[i][vuln.ID] = struct{}{}
+				for _, alias := range vuln.Aliases {
+					seen[i][alias] = struct{}{}
+				}
+
+				vulnerabilities[i] = append(vulnerabilities[i], vuln)
+			}
+		}
+	}
+
+	unmatchedErr := &osvmatcher.UnmatchedPackagesError{}
+	for _, failure := range failures {
+		// packages that were matched by any of the sources are not unmatched
+		failure.Indexes = slices.DeleteFunc(slices.Clone(failure.Indexes), func(idx int) bool {
+			return failedSources[idx] < len(matcher.Sources)
+		})
+
+		if len(failure.Indexes) > 0 {
+			unmatchedErr.Failures = append(unmatchedErr.Failures, failure)
+		}
+
+		// each package only needs to be reported as unmatched once
+		for _, idx := range failure.Indexes {
+			failedSources[idx] = 0
+		}
+	}
+
+	if len(unmatchedErr.Failures) > 0 {
+		return vulnerabilities, unmatchedErr
+	}
+
+	return vulnerabilities, nil
+}
+
+// isSeen returns whether the vulnerability is the same as one that has already been
+// found, because its id or one of its aliases is the id or alias of that vulnerability
+func isSeen(seen map[string]struct{}, vuln *osvschema.Vulnerability) bool {
+	if _, ok := seen[vuln.ID]; ok {
+		return true
+	}
+
+	for _, alias := range vuln.Aliases {
+		if _, ok := seen[alias]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+var _ clientinterfaces.VulnerabilityMatcher = &ChainMatcher{}
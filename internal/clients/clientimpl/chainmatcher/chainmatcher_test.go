@@ -0,0 +1,165 @@
+package chainmatcher_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/chainmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// fakeMatcher returns the given vulnerabilities for the package at each index
+type fakeMatcher struct {
+	vulns [][]osvschema.Vulnerability
+	err   error
+}
+
+func (m fakeMatcher) MatchVulnerabilities(_ context.Context, _ []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	if m.vulns == nil {
+		return nil, m.err
+	}
+
+	res := make([][]*osvschema.Vulnerability, len(m.vulns))
+	for i, vulns := range m.vulns {
+		for _, vuln := range vulns {
+			res[i] = append(res[i], &vuln)
+		}
+	}
+
+	return res, m.err
+}
+
+func vulnIDs(res [][]*osvschema.Vulnerability) [][]string {
+	ids := make([][]string, 0, len(res))
+	for _, vulns := range res {
+		pkgIDs := []string{}
+		for _, vuln := range vulns {
+			pkgIDs = append(pkgIDs, vuln.ID)
+		}
+		ids = append(ids, pkgIDs)
+	}
+
+	return ids
+}
+
+var invs = []*extractor.Package{
+	{Name: "lodash", Version: "4.17.20", PURLType: "npm"},
+	{Name: "requests", Version: "2.0.0", PURLType: "pypi"},
+}
+
+func TestChainMatcher_MatchVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	matcher := &chainmatcher.ChainMatcher{
+		Sources: []chainmatcher.Source{
+			{
+				Name: "osv",
+				Matcher: fakeMatcher{vulns: [][]osvschema.Vulnerability{
+					{{ID: "GHSA-1", Aliases: []string{"CVE-2021-1"}}},
+					{},
+				}},
+			},
+			{
+				Name: "feed",
+				Matcher: fakeMatcher{vulns: [][]osvschema.Vulnerability{
+					{
+						// the same as the vulnerability from the first source
+						{ID: "GHSA-1"},
+						// an alias of the vulnerability from the first source
+						{ID: "CVE-2021-1"},
+						// has an alias in common with the vulnerability from the first source
+						{ID: "INTERNAL-1", Aliases: []string{"CVE-2021-1"}},
+						{ID: "INTERNAL-2"},
+					},
+					{{ID: "INTERNAL-3"}},
+				}},
+			},
+		},
+	}
+
+	got, err := matcher.MatchVulnerabilities(t.Context(), invs)
+	if err != nil {
+		t.Fatalf("MatchVulnerabilities() error = %v", err)
+	}
+
+	want := [][]string{
+		{"GHSA-1", "INTERNAL-2"},
+		{"INTERNAL-3"},
+	}
+
+	if diff := cmp.Diff(want, vulnIDs(got)); diff != "" {
+		t.Errorf("MatchVulnerabilities() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestChainMatcher_MatchVulnerabilities_Unmatched(t *testing.T) {
+	t.Parallel()
+
+	errQuery := errors.New("query failed")
+
+	matcher := &chainmatcher.ChainMatcher{
+		Sources: []chainmatcher.Source{
+			{
+				Name: "osv",
+				Matcher: fakeMatcher{
+					vulns: [][]osvschema.Vulnerability{{}, {}},
+					err: &osvmatcher.UnmatchedPackagesError{Failures: []osvmatcher.EcosystemFailure{
+						{Ecosystem: osvschema.EcosystemNPM, Indexes: []int{0}, Err: errQuery},
+						{Ecosystem: osvschema.EcosystemPyPI, Indexes: []int{1}, Err: errQuery},
+					}},
+				},
+			},
+			{
+				Name: "feed",
+				Matcher: fakeMatcher{
+					vulns: [][]osvschema.Vulnerability{{{ID: "INTERNAL-1"}}, {}},
+					err: &osvmatcher.UnmatchedPackagesError{Failures: []osvmatcher.EcosystemFailure{
+						{Ecosystem: osvschema.EcosystemPyPI, Indexes: []int{1}, Err: errQuery},
+					}},
+				},
+			},
+		},
+	}
+
+	got, err := matcher.MatchVulnerabilities(t.Context(), invs)
+
+	var unmatchedErr *osvmatcher.UnmatchedPackagesError
+	if !errors.As(err, &unmatchedErr) {
+		t.Fatalf("MatchVulnerabilities() error = %v, want an UnmatchedPackagesError", err)
+	}
+
+	// only the package that neither source could match is unmatched
+	if diff := cmp.Diff([]int{1}, unmatchedErr.Indexes()); diff != "" {
+		t.Errorf("MatchVulnerabilities() unmatched indexes mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([][]string{{"INTERNAL-1"}, {}}, vulnIDs(got)); diff != "" {
+		t.Errorf("MatchVulnerabilities() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestChainMatcher_MatchVulnerabilities_Failed(t *testing.T) {
+	t.Parallel()
+
+	errAPI := errors.New("api is down")
+
+	matcher := &chainmatcher.ChainMatcher{
+		Sources: []chainmatcher.Source{
+			{Name: "feed", Matcher: fakeMatcher{vulns: [][]osvschema.Vulnerability{{}, {}}}},
+			{Name: "osv", Matcher: fakeMatcher{err: errAPI}},
+		},
+	}
+
+	got, err := matcher.MatchVulnerabilities(t.Context(), invs)
+	if !errors.Is(err, errAPI) {
+		t.Errorf("MatchVulnerabilities() error = %v, want %v", err, errAPI)
+	}
+
+	if got != nil {
+		t.Errorf("MatchVulnerabilities() = %v, want nil", got)
+	}
+}
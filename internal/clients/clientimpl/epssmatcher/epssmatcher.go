@@ -0,0 +1,146 @@
+// Package epssmatcher implements clientinterfaces.EPSSMatcher using the EPSS API of FIRST.
+package epssmatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// APIEndpoint is the EPSS API of FIRST, see https://www.first.org/epss/api
+	APIEndpoint = "https://api.first.org/data/v1/epss"
+
+	// maxCVEsPerRequest is the most CVEs that are looked up in a single request,
+	// which is the default number of results returned by the API per page
+	maxCVEsPerRequest = 100
+	// maxConcurrentRequests is kept low as the API is rate limited per client
+	maxConcurrentRequests = 4
+)
+
+// FirstEPSSMatcher is an implementation of clientinterfaces.EPSSMatcher that
+// looks up the EPSS scores of CVEs using the EPSS API of FIRST
+type FirstEPSSMatcher struct {
+	HTTPClient *http.Client
+	// APIEndpoint defaults to the APIEndpoint constant if empty
+	APIEndpoint string
+	UserAgent   string
+}
+
+type epssResponse struct {
+	Data []struct {
+		CVE        string `json:"cve"`
+		EPSS       string `json:"epss"`
+		Percentile string `json:"percentile"`
+		Date       string `json:"date"`
+	} `json:"data"`
+}
+
+func (matcher *FirstEPSSMatcher) MatchEPSS(ctx context.Context, cves []string) (map[string]models.EPSSScore, error) {
+	cves = slices.Compact(slices.Sorted(slices.Values(cves)))
+
+	var mu sync.Mutex
+	scores := make(map[string]models.EPSSScore, len(cves))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentRequests)
+
+	for chunk := range slices.Chunk(cves, maxCVEsPerRequest) {
+		g.Go(func() error {
+			chunkScores, err := matcher.query(ctx, chunk)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, score := range chunkScores {
+				scores[score.CVE] = score
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return scores, nil
+}
+
+// query looks up the scores of a single chunk of CVEs, omitting any that do not have a score
+func (matcher *FirstEPSSMatcher) query(ctx context.Context, cves []string) ([]models.EPSSScore, error) {
+	endpoint := matcher.APIEndpoint
+	if endpoint == "" {
+		endpoint = APIEndpoint
+	}
+
+	query := url.Values{}
+	query.Set("cve", strings.Join(cves, ","))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if matcher.UserAgent != "" {
+		req.Header.Set("User-Agent", matcher.UserAgent)
+	}
+
+	client := matcher.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("EPSS API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+
+		return nil, fmt.Errorf("EPSS API request failed: status=%q body=%s", resp.Status, body)
+	}
+
+	var result epssResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("unexpected response from EPSS API: %w", err)
+	}
+
+	scores := make([]models.EPSSScore, 0, len(result.Data))
+	for _, d := range result.Data {
+		// the API returns the scores as strings to preserve their precision
+		score, err := strconv.ParseFloat(d.EPSS, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected EPSS score %q for %s: %w", d.EPSS, d.CVE, err)
+		}
+
+		percentile, err := strconv.ParseFloat(d.Percentile, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected EPSS percentile %q for %s: %w", d.Percentile, d.CVE, err)
+		}
+
+		scores = append(scores, models.EPSSScore{
+			CVE:        d.CVE,
+			Score:      score,
+			Percentile: percentile,
+			Date:       d.Date,
+		})
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,62 @@
+package epssmatcher_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/epssmatcher"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func TestFirstEPSSMatcher_MatchEPSS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data []string
+		for cve := range strings.SplitSeq(r.URL.Query().Get("cve"), ",") {
+			// CVEs that are not known to the API are omitted from the response
+			if cve == "CVE-2024-9999" {
+				continue
+			}
+
+			data = append(data, fmt.Sprintf(`{"cve":%q,"epss":"0.123000000","percentile":"0.456000000","date":"2024-06-01"}`, cve))
+		}
+
+		fmt.Fprintf(w, `{"status":"OK","data":[%s]}`, strings.Join(data, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	matcher := &epssmatcher.FirstEPSSMatcher{APIEndpoint: server.URL}
+
+	got, err := matcher.MatchEPSS(t.Context(), []string{"CVE-2024-1", "CVE-2024-9999", "CVE-2024-1"})
+	if err != nil {
+		t.Fatalf("MatchEPSS() error = %v", err)
+	}
+
+	want := map[string]models.EPSSScore{
+		"CVE-2024-1": {CVE: "CVE-2024-1", Score: 0.123, Percentile: 0.456, Date: "2024-06-01"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MatchEPSS() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFirstEPSSMatcher_MatchEPSS_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	matcher := &epssmatcher.FirstEPSSMatcher{APIEndpoint: server.URL}
+
+	if _, err := matcher.MatchEPSS(t.Context(), []string{"CVE-2024-1"}); err == nil {
+		t.Errorf("MatchEPSS() error = nil, want an error")
+	}
+}
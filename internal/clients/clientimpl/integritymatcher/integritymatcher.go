@@ -0,0 +1,235 @@
+// Package integritymatcher implements clientinterfaces.IntegrityMatcher using the
+// registries of npm and PyPI and the Go checksum database.
+package integritymatcher
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/integrity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+	"golang.org/x/mod/module"
+)
+
+const (
+	// NPMRegistry is the public registry of npm, see https://github.com/npm/registry
+	NPMRegistry = "https://registry.npmjs.org"
+	// PyPIRegistry is the public registry of PyPI, see https://docs.pypi.org/api/json/
+	PyPIRegistry = "https://pypi.org"
+	// GoSumDB is the public checksum database of Go modules, see https://sum.golang.org
+	GoSumDB = "https://sum.golang.org"
+)
+
+// errNotFound is for when the registry does not know of the package, such as for
+// private packages, which cannot be verified rather than having mismatched hashes
+var errNotFound = errors.New("package not found")
+
+// RegistryIntegrityMatcher is an implementation of clientinterfaces.IntegrityMatcher that
+// looks up the hashes published by the public registries of npm and PyPI, and by the Go
+// checksum database; the hashes of packages from other ecosystems are not looked up
+type RegistryIntegrityMatcher struct {
+	HTTPClient *http.Client
+	// NPMRegistry, PyPIRegistry, and GoSumDB default to the constants of the same name if empty
+	NPMRegistry  string
+	PyPIRegistry string
+	GoSumDB      string
+	UserAgent    string
+}
+
+func (matcher *RegistryIntegrityMatcher) MatchIntegrity(ctx context.Context, pkg models.PackageInfo) ([]integrity.Hash, error) {
+	var hashes []integrity.Hash
+	var err error
+
+	switch osvschema.Ecosystem(pkg.Ecosystem) {
+	case osvschema.EcosystemNPM:
+		hashes, err = matcher.matchNPM(ctx, pkg)
+	case osvschema.EcosystemPyPI:
+		hashes, err = matcher.matchPyPI(ctx, pkg)
+	case osvschema.EcosystemGo:
+		hashes, err = matcher.matchGo(ctx, pkg)
+	default:
+		return nil, nil
+	}
+
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up hashes of %s@%s: %w", pkg.Name, pkg.Version, err)
+	}
+
+	return hashes, nil
+}
+
+type npmVersionResponse struct {
+	Dist struct {
+		Integrity string `json:"integrity"`
+		// Shasum is the hex encoded sha1 of the tarball, which older lockfiles record
+		Shasum string `json:"shasum"`
+	} `json:"dist"`
+}
+
+func (matcher *RegistryIntegrityMatcher) matchNPM(ctx context.Context, pkg models.PackageInfo) ([]integrity.Hash, error) {
+	registry := matcher.NPMRegistry
+	if registry == "" {
+		registry = NPMRegistry
+	}
+
+	// the slash of scoped packages must be escaped, but not their @
+	name := strings.ReplaceAll(url.PathEscape(pkg.Name), "%40", "@")
+
+	body, err := matcher.get(ctx, registry+"/"+name+"/"+url.PathEscape(pkg.Version))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result npmVersionResponse
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("unexpected response from npm registry: %w", err)
+	}
+
+	var hashes []integrity.Hash
+	for field := range strings.FieldsSeq(result.Dist.Integrity) {
+		hashes = append(hashes, integrity.Hash{Value: field})
+	}
+
+	if shasum, err := hex.DecodeString(result.Dist.Shasum); err == nil && len(shasum) > 0 {
+		hashes = append(hashes, integrity.Hash{Value: "sha1-" + base64.StdEncoding.EncodeToString(shasum)})
+	}
+
+	return hashes, nil
+}
+
+type pypiVersionResponse struct {
+	URLs []struct {
+		Filename string `json:"filename"`
+		Digests  struct {
+			SHA256 string `json:"sha256"`
+		} `json:"digests"`
+	} `json:"urls"`
+}
+
+func (matcher *RegistryIntegrityMatcher) matchPyPI(ctx context.Context, pkg models.PackageInfo) ([]integrity.Hash, error) {
+	registry := matcher.PyPIRegistry
+	if registry == "" {
+		registry = PyPIRegistry
+	}
+
+	body, err := matcher.get(ctx, registry+"/pypi/"+url.PathEscape(pkg.Name)+"/"+url.PathEscape(pkg.Version)+"/json")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result pypiVersionResponse
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("unexpected response from PyPI: %w", err)
+	}
+
+	hashes := make([]integrity.Hash, 0, len(result.URLs))
+	for _, u := range result.URLs {
+		if u.Digests.SHA256 != "" {
+			hashes = append(hashes, integrity.Hash{File: u.Filename, Value: "sha256:" + u.Digests.SHA256})
+		}
+	}
+
+	return hashes, nil
+}
+
+func (matcher *RegistryIntegrityMatcher) matchGo(ctx context.Context, pkg models.PackageInfo) ([]integrity.Hash, error) {
+	sumDB := matcher.GoSumDB
+	if sumDB == "" {
+		sumDB = GoSumDB
+	}
+
+	version := "v" + strings.TrimPrefix(pkg.Version, "v")
+
+	escapedPath, err := module.EscapePath(pkg.Name)
+	if err != nil {
+		return nil, err
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := matcher.get(ctx, sumDB+"/lookup/"+escapedPath+"@"+escapedVersion)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	// the lookup is the id of the record, followed by the lines of go.sum for the module,
+	// and then the signed tree head of the database, which is not verified
+	var hashes []integrity.Hash
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != pkg.Name {
+			continue
+		}
+
+		switch fields[1] {
+		case version:
+			hashes = append(hashes, integrity.Hash{Value: fields[2]})
+		case version + "/" + integrity.GoModFile:
+			hashes = append(hashes, integrity.Hash{File: integrity.GoModFile, Value: fields[2]})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unexpected response from Go checksum database: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// get requests the url, returning errNotFound if the registry does not know of it
+func (matcher *RegistryIntegrityMatcher) get(ctx context.Context, reqURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if matcher.UserAgent != "" {
+		req.Header.Set("User-Agent", matcher.UserAgent)
+	}
+
+	client := matcher.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the Go checksum database responds with 410 Gone for modules it cannot fetch
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		resp.Body.Close()
+		return nil, errNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		return nil, fmt.Errorf("request failed: status=%q body=%s", resp.Status, body)
+	}
+
+	return resp.Body, nil
+}
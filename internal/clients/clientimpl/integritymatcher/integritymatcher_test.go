@@ -0,0 +1,126 @@
+package integritymatcher_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/integritymatcher"
+	"github.com/google/osv-scanner/v2/internal/integrity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func newRegistryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/npm/@babel%2Fcode-frame/7.24.7", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"dist":{"integrity":"sha512-abc==","shasum":"b5243d8f3ec1aa35f1364605bc0d1036e30ab69f"}}`)
+	})
+	mux.HandleFunc("/pypi/pypi/six/1.16.0/json", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"urls":[
+			{"filename":"six-1.16.0-py2.py3-none-any.whl","digests":{"sha256":"8abb"}},
+			{"filename":"six-1.16.0.tar.gz","digests":{"sha256":"1e61"}}
+		]}`)
+	})
+	mux.HandleFunc("/sumdb/lookup/github.com/!burnt!sushi/toml@v1.4.0", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "12345\n"+
+			"github.com/BurntSushi/toml v1.4.0 h1:abc=\n"+
+			"github.com/BurntSushi/toml v1.4.0/go.mod h1:def=\n"+
+			"\n"+
+			"go.sum database tree\n"+
+			"67890\n")
+	})
+	mux.HandleFunc("/sumdb/lookup/example.com/private@v1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "not found", http.StatusGone)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRegistryIntegrityMatcher_MatchIntegrity(t *testing.T) {
+	t.Parallel()
+
+	server := newRegistryServer(t)
+	matcher := &integritymatcher.RegistryIntegrityMatcher{
+		NPMRegistry:  server.URL + "/npm",
+		PyPIRegistry: server.URL + "/pypi",
+		GoSumDB:      server.URL + "/sumdb",
+	}
+
+	tests := []struct {
+		name string
+		pkg  models.PackageInfo
+		want []integrity.Hash
+	}{
+		{
+			name: "npm",
+			pkg:  models.PackageInfo{Name: "@babel/code-frame", Version: "7.24.7", Ecosystem: "npm"},
+			want: []integrity.Hash{
+				{Value: "sha512-abc=="},
+				{Value: "sha1-tSQ9jz7BqjXxNkYFvA0QNuMKtp8="},
+			},
+		},
+		{
+			name: "PyPI",
+			pkg:  models.PackageInfo{Name: "six", Version: "1.16.0", Ecosystem: "PyPI"},
+			want: []integrity.Hash{
+				{File: "six-1.16.0-py2.py3-none-any.whl", Value: "sha256:8abb"},
+				{File: "six-1.16.0.tar.gz", Value: "sha256:1e61"},
+			},
+		},
+		{
+			name: "Go",
+			pkg:  models.PackageInfo{Name: "github.com/BurntSushi/toml", Version: "1.4.0", Ecosystem: "Go"},
+			want: []integrity.Hash{
+				{Value: "h1:abc="},
+				{File: integrity.GoModFile, Value: "h1:def="},
+			},
+		},
+		{
+			name: "not known to the registry",
+			pkg:  models.PackageInfo{Name: "example.com/private", Version: "1.0.0", Ecosystem: "Go"},
+			want: nil,
+		},
+		{
+			name: "unsupported ecosystem",
+			pkg:  models.PackageInfo{Name: "serde", Version: "1.0.0", Ecosystem: "crates.io"},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := matcher.MatchIntegrity(t.Context(), tt.pkg)
+			if err != nil {
+				t.Fatalf("MatchIntegrity() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MatchIntegrity() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegistryIntegrityMatcher_MatchIntegrity_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	matcher := &integritymatcher.RegistryIntegrityMatcher{NPMRegistry: server.URL}
+
+	if _, err := matcher.MatchIntegrity(t.Context(), models.PackageInfo{Name: "wrappy", Version: "1.0.2", Ecosystem: "npm"}); err == nil {
+		t.Errorf("MatchIntegrity() error = nil, want an error")
+	}
+}
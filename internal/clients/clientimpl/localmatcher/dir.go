@@ -0,0 +1,151 @@
+package localmatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// DirDB is a database of vulnerabilities loaded from a local directory of
+// OSV json files, such as advisories that are only distributed internally
+type DirDB struct {
+	// the path to the directory on disk
+	Path string
+	// the vulnerabilities that are loaded into this database
+	vulnerabilities []osvschema.Vulnerability
+}
+
+// NewDirDB loads all the json files within the given directory and its subdirectories,
+// which are assumed to be vulnerabilities following the OSV spec
+func NewDirDB(dir string) (*DirDB, error) {
+	db := &DirDB{Path: dir, vulnerabilities: []osvschema.Vulnerability{}}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			cmdlogger.Warnf("Could not read %s: %v", path, err)
+
+			return nil
+		}
+
+		var vulnerability osvschema.Vulnerability
+		if err := json.Unmarshal(content, &vulnerability); err != nil {
+			cmdlogger.Warnf("%s is not a valid JSON file: %v", path, err)
+
+			return nil
+		}
+
+		if vulnerability.ID == "" {
+			cmdlogger.Warnf("%s is not an OSV vulnerability as it has no id", path)
+
+			return nil
+		}
+
+		db.vulnerabilities = append(db.vulnerabilities, vulnerability)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to load local database %s: %w", dir, err)
+	}
+
+	return db, nil
+}
+
+func (db *DirDB) Vulnerabilities(includeWithdrawn bool) []osvschema.Vulnerability {
+	if includeWithdrawn {
+		return db.vulnerabilities
+	}
+
+	var vulnerabilities []osvschema.Vulnerability
+
+	for _, vulnerability := range db.vulnerabilities {
+		if vulnerability.Withdrawn.IsZero() {
+			vulnerabilities = append(vulnerabilities, vulnerability)
+		}
+	}
+
+	return vulnerabilities
+}
+
+// loadDirVulns loads the vulnerabilities in each of the given directories for each of
+// the ecosystems that they affect, with vulnerabilities in later directories taking
+// precedence over those with the same id in earlier ones
+func loadDirVulns(dirs []string) (map[osvschema.Ecosystem][]osvschema.Vulnerability, error) {
+	vulns := make(map[osvschema.Ecosystem][]osvschema.Vulnerability)
+
+	for _, dir := range dirs {
+		db, err := NewDirDB(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		cmdlogger.Infof("Loaded %d vulnerabilities from %s", len(db.vulnerabilities), db.Path)
+
+		for _, vuln := range db.Vulnerabilities(false) {
+			for _, eco := range vulnEcosystems(vuln) {
+				vulns[eco] = overrideVulns(vulns[eco], []osvschema.Vulnerability{vuln})
+			}
+		}
+	}
+
+	return vulns, nil
+}
+
+// DirMatcher implements the VulnerabilityMatcher interface by matching against only the
+// vulnerabilities in local directories of OSV json files, such as a feed of advisories
+// that is distributed internally or a clone of the GitHub Advisory Database
+type DirMatcher struct {
+	vulns map[osvschema.Ecosystem][]osvschema.Vulnerability
+}
+
+// NewDirMatcher creates a matcher using the vulnerabilities in each of the given directories,
+// with vulnerabilities in later directories taking precedence over those with the same id
+func NewDirMatcher(dirs []string) (*DirMatcher, error) {
+	vulns, err := loadDirVulns(dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DirMatcher{vulns: vulns}, nil
+}
+
+func (matcher *DirMatcher) MatchVulnerabilities(ctx context.Context, invs []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	results := make([][]*osvschema.Vulnerability, 0, len(invs))
+
+	for _, inv := range invs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		pkg := imodels.FromInventory(inv)
+
+		// commits cannot be matched against, as the directories are not indexed by commit
+		if pkg.Ecosystem().IsEmpty() {
+			results = append(results, []*osvschema.Vulnerability{})
+			continue
+		}
+
+		results = append(results, VulnerabilitiesAffectingPackage(matcher.vulns[pkg.Ecosystem().Ecosystem], pkg))
+	}
+
+	return results, nil
+}
@@ -0,0 +1,176 @@
+package localmatcher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+var ErrOfflineLicenseDatasetNotFound = errors.New("no offline license dataset is available")
+
+// licenseDatasetDir is the directory within the local db directory where
+// license datasets are stored, one file per ecosystem
+const licenseDatasetDir = "licenses"
+
+// LicenseRecord is a single line of a license dataset, which are stored as
+// newline-delimited JSON at {local_db_dir}/osv-scanner/licenses/{ecosystem}.jsonl
+type LicenseRecord struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	Licenses []string `json:"licenses"`
+}
+
+// LocalLicenseMatcher implements the LicenseMatcher interface by looking up
+// licenses in datasets that have been placed in the local db directory
+// (e.g. from a deps.dev export), so that licenses can be checked offline.
+type LocalLicenseMatcher struct {
+	dbBasePath string
+	datasets   map[osvschema.Ecosystem]map[string][]models.License
+	// failedDatasets keeps track of the errors when loading datasets for each ecosystem
+	failedDatasets map[osvschema.Ecosystem]error
+}
+
+func NewLocalLicenseMatcher(localDBPath string) (*LocalLicenseMatcher, error) {
+	dbBasePath, err := setupLocalDBDirectory(localDBPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s: %w", dbBasePath, err)
+	}
+
+	return &LocalLicenseMatcher{
+		dbBasePath:     dbBasePath,
+		datasets:       make(map[osvschema.Ecosystem]map[string][]models.License),
+		failedDatasets: make(map[osvschema.Ecosystem]error),
+	}, nil
+}
+
+func (matcher *LocalLicenseMatcher) MatchLicenses(ctx context.Context, packages []imodels.PackageScanResult) error {
+	for i, psr := range packages {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		packages[i].Licenses = []models.License{"UNKNOWN"}
+
+		pkg := psr.PackageInfo
+		if pkg.Ecosystem().IsEmpty() || pkg.Name() == "" || pkg.Version() == "" {
+			continue
+		}
+
+		dataset, err := matcher.loadDataset(pkg.Ecosystem().Ecosystem)
+		if err != nil {
+			continue
+		}
+
+		if ls, ok := lookupLicenses(dataset, pkg); ok {
+			packages[i].Licenses = ls
+		}
+	}
+
+	return nil
+}
+
+// lookupLicenses finds the licenses of the given package within the dataset,
+// accounting for Go versions being stored with a "v" prefix by deps.dev
+func lookupLicenses(dataset map[string][]models.License, pkg imodels.PackageInfo) ([]models.License, bool) {
+	if ls, ok := dataset[licenseKey(pkg.Name(), pkg.Version())]; ok {
+		return ls, true
+	}
+
+	if pkg.Ecosystem().Ecosystem == osvschema.EcosystemGo {
+		ls, ok := dataset[licenseKey(pkg.Name(), "v"+pkg.Version())]
+		return ls, ok
+	}
+
+	return nil, false
+}
+
+func licenseKey(name, version string) string {
+	return name + "@" + version
+}
+
+func (matcher *LocalLicenseMatcher) loadDataset(eco osvschema.Ecosystem) (map[string][]models.License, error) {
+	if dataset, ok := matcher.datasets[eco]; ok {
+		return dataset, nil
+	}
+
+	if matcher.failedDatasets[eco] != nil {
+		return nil, matcher.failedDatasets[eco]
+	}
+
+	storedAt := path.Join(matcher.dbBasePath, licenseDatasetDir, string(eco)+".jsonl")
+	dataset, err := loadLicenseDataset(storedAt)
+	if err != nil {
+		matcher.failedDatasets[eco] = err
+
+		// the licenses of packages in ecosystems without a dataset are reported as unknown
+		if errors.Is(err, ErrOfflineLicenseDatasetNotFound) {
+			cmdlogger.Warnf("could not load license dataset for %s ecosystem: %v", eco, err)
+		} else {
+			cmdlogger.Errorf("could not load license dataset for %s ecosystem: %v", eco, err)
+		}
+
+		return nil, err
+	}
+
+	cmdlogger.Infof("Loaded %s local license dataset from %s", eco, storedAt)
+
+	matcher.datasets[eco] = dataset
+
+	return dataset, nil
+}
+
+func loadLicenseDataset(storedAt string) (map[string][]models.License, error) {
+	f, err := os.Open(storedAt)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrOfflineLicenseDatasetNotFound
+		}
+
+		return nil, err
+	}
+	defer f.Close()
+
+	dataset := make(map[string][]models.License)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var record LicenseRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			cmdlogger.Warnf("%s:%d is not a valid license record: %v", storedAt, lineNum, err)
+			continue
+		}
+
+		ls := make([]models.License, 0, len(record.Licenses))
+		for _, l := range record.Licenses {
+			ls = append(ls, models.License(l))
+		}
+
+		if len(ls) == 0 {
+			ls = append(ls, "UNKNOWN")
+		}
+
+		dataset[licenseKey(record.Name, record.Version)] = ls
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read license dataset: %w", err)
+	}
+
+	return dataset, nil
+}
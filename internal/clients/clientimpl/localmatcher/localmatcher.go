@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
@@ -27,20 +28,35 @@ type LocalMatcher struct {
 	failedDBs map[osvschema.Ecosystem]error
 	// userAgent sets the user agent requests for db zips are made with
 	userAgent string
+	// extraVulns are the vulnerabilities from local directories for each ecosystem,
+	// which are layered on top of the downloaded databases
+	extraVulns map[osvschema.Ecosystem][]osvschema.Vulnerability
+	// vulns caches the merged vulnerabilities of each ecosystem
+	vulns map[osvschema.Ecosystem][]osvschema.Vulnerability
 }
 
-func NewLocalMatcher(localDBPath string, userAgent string, downloadDB bool) (*LocalMatcher, error) {
+// NewLocalMatcher creates a matcher using the databases stored in localDBPath, along with
+// the extra vulnerabilities in each of extraDBPaths, which take precedence over any
+// vulnerabilities in the databases with the same id.
+func NewLocalMatcher(localDBPath string, extraDBPaths []string, userAgent string, downloadDB bool) (*LocalMatcher, error) {
 	dbBasePath, err := setupLocalDBDirectory(localDBPath)
 	if err != nil {
 		return nil, fmt.Errorf("could not create %s: %w", dbBasePath, err)
 	}
 
+	extraVulns, err := loadDirVulns(extraDBPaths)
+	if err != nil {
+		return nil, err
+	}
+
 	return &LocalMatcher{
 		dbBasePath: dbBasePath,
 		dbs:        make(map[osvschema.Ecosystem]*ZipDB),
 		downloadDB: downloadDB,
 		userAgent:  userAgent,
 		failedDBs:  make(map[osvschema.Ecosystem]error),
+		extraVulns: extraVulns,
+		vulns:      make(map[osvschema.Ecosystem][]osvschema.Vulnerability),
 	}, nil
 }
 
@@ -67,13 +83,13 @@ func (matcher *LocalMatcher) MatchVulnerabilities(ctx context.Context, invs []*e
 			continue
 		}
 
-		db, err := matcher.loadDBFromCache(ctx, pkg.Ecosystem())
+		vulns, err := matcher.loadVulns(ctx, pkg.Ecosystem())
 
 		if err != nil {
 			continue
 		}
 
-		results = append(results, VulnerabilitiesAffectingPackage(db.Vulnerabilities(false), pkg))
+		results = append(results, VulnerabilitiesAffectingPackage(vulns, pkg))
 	}
 
 	return results, nil
@@ -82,11 +98,41 @@ func (matcher *LocalMatcher) MatchVulnerabilities(ctx context.Context, invs []*e
 // LoadEcosystem tries to preload the ecosystem into the cache, and returns an error if the ecosystem
 // cannot be loaded.
 func (matcher *LocalMatcher) LoadEcosystem(ctx context.Context, ecosystem ecosystem.Parsed) error {
-	_, err := matcher.loadDBFromCache(ctx, ecosystem)
+	_, err := matcher.loadVulns(ctx, ecosystem)
 
 	return err
 }
 
+// loadVulns returns the vulnerabilities of the ecosystem from its database, layered with
+// any extra vulnerabilities for the ecosystem. If the database cannot be loaded, only the
+// extra vulnerabilities are used, with an error only being returned if there are none.
+func (matcher *LocalMatcher) loadVulns(ctx context.Context, ecosystem ecosystem.Parsed) ([]osvschema.Vulnerability, error) {
+	if vulns, ok := matcher.vulns[ecosystem.Ecosystem]; ok {
+		return vulns, nil
+	}
+
+	extra := matcher.extraVulns[ecosystem.Ecosystem]
+
+	db, err := matcher.loadDBFromCache(ctx, ecosystem)
+	if err != nil {
+		if len(extra) == 0 {
+			return nil, err
+		}
+
+		matcher.vulns[ecosystem.Ecosystem] = extra
+
+		return extra, nil
+	}
+
+	vulns := db.Vulnerabilities(false)
+	if len(extra) > 0 {
+		vulns = overrideVulns(vulns, extra)
+	}
+	matcher.vulns[ecosystem.Ecosystem] = vulns
+
+	return vulns, nil
+}
+
 func (matcher *LocalMatcher) loadDBFromCache(ctx context.Context, ecosystem ecosystem.Parsed) (*ZipDB, error) {
 	if db, ok := matcher.dbs[ecosystem.Ecosystem]; ok {
 		return db, nil
@@ -113,6 +159,39 @@ func (matcher *LocalMatcher) loadDBFromCache(ctx context.Context, ecosystem ecos
 	return db, nil
 }
 
+// vulnEcosystems returns the ecosystems of the packages that the vulnerability affects
+func vulnEcosystems(vuln osvschema.Vulnerability) []osvschema.Ecosystem {
+	var ecosystems []osvschema.Ecosystem
+	for _, affected := range vuln.Affected {
+		// the ecosystem is still parsed if it has an unexpected suffix
+		parsed, _ := ecosystem.Parse(affected.Package.Ecosystem)
+		eco := parsed.Ecosystem
+		if eco != "" && !slices.Contains(ecosystems, eco) {
+			ecosystems = append(ecosystems, eco)
+		}
+	}
+
+	return ecosystems
+}
+
+// overrideVulns returns the vulnerabilities in base, with those that have the same id
+// as one of the overrides being replaced by the override
+func overrideVulns(base, overrides []osvschema.Vulnerability) []osvschema.Vulnerability {
+	ids := make(map[string]struct{}, len(overrides))
+	for _, vuln := range overrides {
+		ids[vuln.ID] = struct{}{}
+	}
+
+	merged := make([]osvschema.Vulnerability, 0, len(base)+len(overrides))
+	for _, vuln := range base {
+		if _, ok := ids[vuln.ID]; !ok {
+			merged = append(merged, vuln)
+		}
+	}
+
+	return append(merged, overrides...)
+}
+
 // setupLocalDBDirectory attempts to set up the directory the scanner should
 // use to store local databases.
 //
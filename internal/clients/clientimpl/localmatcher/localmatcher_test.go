@@ -0,0 +1,156 @@
+package localmatcher_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/localmatcher"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func affecting(ecosystem osvschema.Ecosystem, name string, versions ...string) []osvschema.Affected {
+	return []osvschema.Affected{{
+		Package:  osvschema.Package{Ecosystem: string(ecosystem), Name: name},
+		Versions: versions,
+	}}
+}
+
+func writeOSVsDir(t *testing.T, dir string, osvs map[string]osvschema.Vulnerability) {
+	t.Helper()
+
+	for fp, osv := range osvs {
+		data, err := json.Marshal(osv)
+		if err != nil {
+			t.Fatalf("could not marshal %v: %v", osv, err)
+		}
+
+		fp = filepath.Join(dir, fp)
+		if err := os.MkdirAll(filepath.Dir(fp), 0750); err != nil {
+			t.Fatalf("could not create %s: %v", filepath.Dir(fp), err)
+		}
+
+		//nolint:gosec // being world readable is fine
+		if err := os.WriteFile(fp, data, 0644); err != nil {
+			t.Fatalf("could not write %s: %v", fp, err)
+		}
+	}
+}
+
+func vulnIDs(vulns []*osvschema.Vulnerability) []string {
+	ids := make([]string, 0, len(vulns))
+	for _, vuln := range vulns {
+		ids = append(ids, vuln.ID)
+	}
+
+	return ids
+}
+
+func TestNewDirDB(t *testing.T) {
+	t.Parallel()
+
+	testDir := testutility.CreateTestDir(t)
+	withdrawn := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	writeOSVsDir(t, testDir, map[string]osvschema.Vulnerability{
+		"INTERNAL-1.json":        {ID: "INTERNAL-1"},
+		"nested/INTERNAL-2.json": {ID: "INTERNAL-2"},
+		"INTERNAL-3.json":        {ID: "INTERNAL-3", Withdrawn: withdrawn},
+	})
+
+	//nolint:gosec // being world readable is fine
+	if err := os.WriteFile(filepath.Join(testDir, "README.md"), []byte("# Internal advisories"), 0644); err != nil {
+		t.Fatalf("could not write README.md: %v", err)
+	}
+	//nolint:gosec // being world readable is fine
+	if err := os.WriteFile(filepath.Join(testDir, "bad.json"), []byte("{"), 0644); err != nil {
+		t.Fatalf("could not write bad.json: %v", err)
+	}
+
+	db, err := localmatcher.NewDirDB(testDir)
+	if err != nil {
+		t.Fatalf("unexpected error \"%v\"", err)
+	}
+
+	expectDBToHaveOSVs(t, db, []osvschema.Vulnerability{
+		{ID: "INTERNAL-1"},
+		{ID: "INTERNAL-2"},
+		{ID: "INTERNAL-3", Withdrawn: withdrawn},
+	})
+
+	if got := len(db.Vulnerabilities(false)); got != 2 {
+		t.Errorf("expected 2 vulnerabilities that are not withdrawn, got %d", got)
+	}
+}
+
+func TestNewDirDB_DoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	_, err := localmatcher.NewDirDB(filepath.Join(testutility.CreateTestDir(t), "does-not-exist"))
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected \"%v\" error but got \"%v\"", fs.ErrNotExist, err)
+	}
+}
+
+func TestLocalMatcher_MatchVulnerabilities_WithExtraDBs(t *testing.T) {
+	t.Parallel()
+
+	testDir := testutility.CreateTestDir(t)
+
+	// the downloaded npm database, which is the only database available offline
+	cacheWrite(t, determineStoredAtPath(filepath.Join(testDir, "osv-scanner"), "npm"), zipOSVs(t, map[string]osvschema.Vulnerability{
+		"GHSA-1.json": {ID: "GHSA-1", Affected: affecting(osvschema.EcosystemNPM, "lodash", "4.17.20")},
+		"GHSA-2.json": {ID: "GHSA-2", Affected: affecting(osvschema.EcosystemNPM, "lodash", "4.17.20")},
+	}))
+
+	extraDir1 := testutility.CreateTestDir(t)
+	writeOSVsDir(t, extraDir1, map[string]osvschema.Vulnerability{
+		// overrides the downloaded advisory, which the organization does not consider to affect this version
+		"GHSA-2.json":     {ID: "GHSA-2", Affected: affecting(osvschema.EcosystemNPM, "lodash", "4.17.19")},
+		"INTERNAL-1.json": {ID: "INTERNAL-1", Affected: affecting(osvschema.EcosystemNPM, "lodash", "4.17.20")},
+		"INTERNAL-2.json": {ID: "INTERNAL-2", Affected: affecting(osvschema.EcosystemPyPI, "requests", "2.0.0")},
+	})
+
+	extraDir2 := testutility.CreateTestDir(t)
+	writeOSVsDir(t, extraDir2, map[string]osvschema.Vulnerability{
+		// later directories take precedence
+		"INTERNAL-2.json": {ID: "INTERNAL-2", Affected: affecting(osvschema.EcosystemPyPI, "requests", "2.0.0", "2.0.1")},
+	})
+
+	matcher, err := localmatcher.NewLocalMatcher(testDir, []string{extraDir1, extraDir2}, userAgent, false)
+	if err != nil {
+		t.Fatalf("unexpected error \"%v\"", err)
+	}
+
+	got, err := matcher.MatchVulnerabilities(t.Context(), []*extractor.Package{
+		{Name: "lodash", Version: "4.17.20", PURLType: "npm"},
+		{Name: "requests", Version: "2.0.1", PURLType: "pypi"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error \"%v\"", err)
+	}
+
+	want := [][]string{
+		{"GHSA-1", "INTERNAL-1"},
+		// the PyPI database is not available, but the extra advisories are still used
+		{"INTERNAL-2"},
+	}
+
+	gotIDs := make([][]string, 0, len(got))
+	for _, vulns := range got {
+		gotIDs = append(gotIDs, vulnIDs(vulns))
+	}
+
+	if diff := cmp.Diff(want, gotIDs, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("MatchVulnerabilities() mismatch (-want +got):\n%s", diff)
+	}
+}
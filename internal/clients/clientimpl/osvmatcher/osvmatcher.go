@@ -2,16 +2,19 @@ package osvmatcher
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/log"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/output"
 	"github.com/ossf/osv-schema/bindings/go/osvschema"
-	"golang.org/x/sync/errgroup"
 	"osv.dev/bindings/go/osvdev"
-	"osv.dev/bindings/go/osvdevexperimental"
 )
 
 const (
@@ -25,71 +28,158 @@ type OSVMatcher struct {
 	// InitialQueryTimeout allows you to set a timeout specifically for the initial paging query
 	// If timeout runs out, whatever pages that has been successfully queried within the timeout will
 	// still return fully hydrated.
+	//
+	// The timeout applies to the pipeline of each ecosystem separately, so that an
+	// ecosystem that is slow to query does not use up the time of the others.
 	InitialQueryTimeout time.Duration
+	// Pipeline configures the pipeline that the packages of each ecosystem are queried in
+	Pipeline PipelineConfig
+	// EcosystemPipelines overrides the pipeline config of specific ecosystems
+	EcosystemPipelines map[osvschema.Ecosystem]PipelineConfig
+	// Progress is called with the number of packages in each chunk once it is done
+	// being queried, whether or not it succeeded. It can be called concurrently.
+	Progress func(packages int)
+
+	// chunkSize is the number of queries in each chunk, defaulting to the
+	// most queries that the API accepts in a single batch request
+	chunkSize int
+}
+
+// EcosystemFailure is the packages of an ecosystem that could not be matched
+type EcosystemFailure struct {
+	// Ecosystem is empty for packages that are queried by commit
+	Ecosystem osvschema.Ecosystem
+	// Indexes are the indexes of the packages that could not be matched
+	Indexes []int
+	Err     error
+}
+
+// UnmatchedPackagesError is returned when some of the packages could not be matched
+// because the chunk of the batch query they were in failed even after being retried.
+// The results for all the other packages are still returned alongside it.
+type UnmatchedPackagesError struct {
+	Failures []EcosystemFailure
+}
+
+// Indexes returns the sorted indexes of all the packages that could not be matched
+func (e *UnmatchedPackagesError) Indexes() []int {
+	var indexes []int
+	for _, f := range e.Failures {
+		indexes = append(indexes, f.Indexes...)
+	}
+	slices.Sort(indexes)
+
+	return indexes
+}
+
+func (e *UnmatchedPackagesError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", EcosystemName(f.Ecosystem), f.Err))
+	}
+
+	return fmt.Sprintf("failed to match %d packages: %s", len(e.Indexes()), strings.Join(msgs, "; "))
+}
+
+func (e *UnmatchedPackagesError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+
+	return errs
+}
+
+// EcosystemName returns the name of the ecosystem to use in messages,
+// which is "commits" for the pipeline of packages that are queried by commit
+func EcosystemName(eco osvschema.Ecosystem) string {
+	if eco == "" {
+		return "commits"
+	}
+
+	return string(eco)
 }
 
 // MatchVulnerabilities matches vulnerabilities for a list of packages.
+//
+// The packages of each ecosystem are queried in a separate pipeline, each with their
+// own concurrency, retries, and timeout, so that an ecosystem which is slow or failing
+// does not hold up or fail the others. Within a pipeline the packages are queried in
+// chunks, and a chunk which fails only causes its own packages to go unmatched, as
+// reported by an UnmatchedPackagesError; the error is only returned without any
+// results if every package could not be matched.
 func (matcher *OSVMatcher) MatchVulnerabilities(ctx context.Context, pkgs []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
-	var batchResp *osvdev.BatchedResponse
-	deadlineExceeded := false
-
-	{
-		var err error
-
-		// convert Package to Query for each pkgs element
-		queries := invsToQueries(pkgs)
-		// If there is a timeout for the initial query, set an additional context deadline here.
-		if matcher.InitialQueryTimeout > 0 {
-			batchQueryCtx, cancelFunc := context.WithDeadline(ctx, time.Now().Add(matcher.InitialQueryTimeout))
-			batchResp, err = osvdevexperimental.BatchQueryPaging(batchQueryCtx, &matcher.Client, queries)
-			cancelFunc()
-		} else {
-			batchResp, err = osvdevexperimental.BatchQueryPaging(ctx, &matcher.Client, queries)
+	// convert Package to Query for each pkgs element
+	queries := invsToQueries(pkgs)
+
+	var ecosystems []osvschema.Ecosystem
+	indexesByEcosystem := make(map[osvschema.Ecosystem][]int)
+	for i, pkg := range pkgs {
+		pkgInfo := imodels.FromInventory(pkg)
+		eco := pkgInfo.Ecosystem().Ecosystem
+		if _, ok := indexesByEcosystem[eco]; !ok {
+			ecosystems = append(ecosystems, eco)
 		}
+		indexesByEcosystem[eco] = append(indexesByEcosystem[eco], i)
+	}
 
-		if err != nil {
-			// Deadline being exceeded is likely caused by a long paging time
-			// if that's the case, we should return what we already got, and
-			// then let the caller know it is not all the results.
-			if errors.Is(err, context.DeadlineExceeded) {
-				deadlineExceeded = true
-			} else {
-				return nil, err
+	vulnerabilities := make([][]*osvschema.Vulnerability, len(pkgs))
+	results := make([]pipelineResult, len(ecosystems))
+
+	// hydration requests are limited across all the pipelines
+	hydrateSem := make(chan struct{}, maxConcurrentRequests)
+
+	var wg sync.WaitGroup
+	for i, eco := range ecosystems {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			p := pipeline{
+				matcher:         matcher,
+				ecosystem:       eco,
+				config:          matcher.pipelineConfig(eco),
+				queries:         queries,
+				vulnerabilities: vulnerabilities,
+				hydrateSem:      hydrateSem,
 			}
-		}
+			results[i] = p.run(ctx, indexesByEcosystem[eco])
+		}()
+	}
+	wg.Wait()
 
-		// No results found - this could be due to a timeout
-		if batchResp == nil {
-			return nil, err
+	deadlineExceeded := false
+	unmatchedErr := &UnmatchedPackagesError{}
+	for i, res := range results {
+		deadlineExceeded = deadlineExceeded || res.deadlineExceeded
+
+		// pipelines run on their own goroutines, so their retries are logged once they are done
+		if res.retries > 0 {
+			cmdlogger.Warnf(
+				"Retried %d failed batch %s of %s packages, most recently because: %v",
+				res.retries,
+				output.Form(res.retries, "query", "queries"),
+				EcosystemName(ecosystems[i]),
+				res.retryErr,
+			)
 		}
-	}
 
-	vulnerabilities := make([][]*osvschema.Vulnerability, len(batchResp.Results))
-	g, ctx := errgroup.WithContext(ctx)
-	g.SetLimit(maxConcurrentRequests)
-
-	for batchIdx, resp := range batchResp.Results {
-		vulnerabilities[batchIdx] = make([]*osvschema.Vulnerability, len(resp.Vulns))
-		for resultIdx, vuln := range resp.Vulns {
-			g.Go(func() error {
-				// exit early if another hydration request has already failed
-				// results are thrown away later, so avoid needless work
-				if ctx.Err() != nil {
-					return nil //nolint:nilerr // this value doesn't matter to errgroup.Wait()
-				}
-				vuln, err := matcher.Client.GetVulnByID(ctx, vuln.ID)
-				if err != nil {
-					return err
-				}
-				vulnerabilities[batchIdx][resultIdx] = vuln
-
-				return nil
+		if len(res.failed) > 0 {
+			unmatchedErr.Failures = append(unmatchedErr.Failures, EcosystemFailure{
+				Ecosystem: ecosystems[i],
+				Indexes:   res.failed,
+				Err:       res.err,
 			})
 		}
 	}
 
-	if err := g.Wait(); err != nil {
-		return nil, err
+	if len(unmatchedErr.Failures) > 0 {
+		// No results found - this could be due to a timeout
+		if len(unmatchedErr.Indexes()) == len(pkgs) {
+			return nil, unmatchedErr.Failures[0].Err
+		}
+
+		return vulnerabilities, unmatchedErr
 	}
 
 	if deadlineExceeded {
@@ -99,6 +189,15 @@ func (matcher *OSVMatcher) MatchVulnerabilities(ctx context.Context, pkgs []*ext
 	return vulnerabilities, nil
 }
 
+// pipelineConfig returns the config of the pipeline for the given ecosystem
+func (matcher *OSVMatcher) pipelineConfig(eco osvschema.Ecosystem) PipelineConfig {
+	if config, ok := matcher.EcosystemPipelines[eco]; ok {
+		return config
+	}
+
+	return matcher.Pipeline
+}
+
 func pkgToQuery(pkg imodels.PackageInfo) *osvdev.Query {
 	if pkg.Name() != "" && !pkg.Ecosystem().IsEmpty() && pkg.Version() != "" {
 		return &osvdev.Query{
@@ -2,8 +2,14 @@ package osvmatcher
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -93,3 +99,262 @@ func TestOSVMatcher_MatchVulnerabilities(t *testing.T) {
 		})
 	}
 }
+
+// newFlakyOSVServer creates a fake OSV API that fails batch queries for any of the given
+// packages the given number of times, and otherwise returns a vulnerability named after each package
+func newFlakyOSVServer(t *testing.T, failing []string, failures int) (*httptest.Server, map[string]int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, ok := strings.CutPrefix(r.URL.Path, osvdev.GetEndpoint+"/"); ok {
+			_ = json.NewEncoder(w).Encode(osvschema.Vulnerability{ID: id})
+			return
+		}
+
+		var batch osvdev.BatchedQuery
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Errorf("failed to decode batch query: %v", err)
+		}
+
+		resp := osvdev.BatchedResponse{}
+		for _, query := range batch.Queries {
+			name := query.Package.Name
+
+			if slices.Contains(failing, name) {
+				mu.Lock()
+				attempts[name]++
+				fail := attempts[name] <= failures
+				mu.Unlock()
+
+				if fail {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+			}
+
+			resp.Results = append(resp.Results, osvdev.MinimalResponse{
+				Vulns: []osvdev.MinimalVulnerability{{ID: "OSV-" + name}},
+			})
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, attempts
+}
+
+func TestOSVMatcher_MatchVulnerabilities_ChunkFailures(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"a", "b", "c", "d", "e"}
+	pkgs := make([]*extractor.Package, 0, len(names))
+	for _, name := range names {
+		pkgs = append(pkgs, &extractor.Package{Name: name, Version: "1.0.0", PURLType: purl.TypeNPM})
+	}
+
+	tests := []struct {
+		name         string
+		failures     int
+		wantVulns    []string
+		wantAttempts int
+		wantFailed   []int
+	}{
+		{
+			name:         "transient failures are retried",
+			failures:     1,
+			wantVulns:    []string{"OSV-a", "OSV-b", "OSV-c", "OSV-d", "OSV-e"},
+			wantAttempts: 2,
+		},
+		{
+			name:         "only the packages in the failing chunk are unmatched",
+			failures:     10,
+			wantVulns:    []string{"OSV-a", "OSV-b", "", "", "OSV-e"},
+			wantAttempts: 3,
+			wantFailed:   []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, attempts := newFlakyOSVServer(t, []string{"c"}, tt.failures)
+
+			client := osvdev.DefaultClient()
+			client.BaseHostURL = server.URL
+			client.Config.MaxRetryAttempts = 1
+
+			matcher := &OSVMatcher{
+				Client: *client,
+				Pipeline: PipelineConfig{
+					Retry: RetryConfig{
+						MaxAttempts:    3,
+						InitialBackoff: time.Millisecond,
+						MaxBackoff:     time.Millisecond,
+					},
+				},
+				chunkSize: 2,
+			}
+
+			got, err := matcher.MatchVulnerabilities(t.Context(), pkgs)
+
+			var unmatchedErr *UnmatchedPackagesError
+			if errors.As(err, &unmatchedErr) {
+				if !reflect.DeepEqual(unmatchedErr.Indexes(), tt.wantFailed) {
+					t.Errorf("UnmatchedPackagesError.Indexes() = %v, want %v", unmatchedErr.Indexes(), tt.wantFailed)
+				}
+			} else if err != nil || tt.wantFailed != nil {
+				t.Fatalf("OSVMatcher.MatchVulnerabilities() error = %v, want unmatched packages %v", err, tt.wantFailed)
+			}
+
+			gotVulns := make([]string, len(got))
+			for i, vulns := range got {
+				if len(vulns) > 0 {
+					gotVulns[i] = vulns[0].ID
+				}
+			}
+
+			if !reflect.DeepEqual(gotVulns, tt.wantVulns) {
+				t.Errorf("OSVMatcher.MatchVulnerabilities() = %v, want %v", gotVulns, tt.wantVulns)
+			}
+
+			if attempts["c"] != tt.wantAttempts {
+				t.Errorf("failing chunk was attempted %d times, want %d", attempts["c"], tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestOSVMatcher_MatchVulnerabilities_EcosystemPipelines(t *testing.T) {
+	t.Parallel()
+
+	pkgs := []*extractor.Package{
+		{Name: "a", Version: "1.0.0", PURLType: purl.TypeNPM},
+		{Name: "x", Version: "1.0.0", PURLType: purl.TypePyPi},
+		{Name: "b", Version: "1.0.0", PURLType: purl.TypeNPM},
+		{Name: "c", Version: "1.0.0", PURLType: purl.TypeNPM},
+		{Name: "y", Version: "1.0.0", PURLType: purl.TypePyPi},
+		{Name: "d", Version: "1.0.0", PURLType: purl.TypeNPM},
+	}
+
+	server, attempts := newFlakyOSVServer(t, []string{"a", "b", "c", "d"}, 10)
+
+	client := osvdev.DefaultClient()
+	client.BaseHostURL = server.URL
+	client.Config.MaxRetryAttempts = 1
+
+	matcher := &OSVMatcher{
+		Client: *client,
+		Pipeline: PipelineConfig{
+			Retry: RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		},
+		EcosystemPipelines: map[osvschema.Ecosystem]PipelineConfig{
+			osvschema.EcosystemNPM: {MaxFailedChunks: 2},
+		},
+		chunkSize: 1,
+	}
+
+	got, err := matcher.MatchVulnerabilities(t.Context(), pkgs)
+
+	var unmatchedErr *UnmatchedPackagesError
+	if !errors.As(err, &unmatchedErr) {
+		t.Fatalf("OSVMatcher.MatchVulnerabilities() error = %v, want an UnmatchedPackagesError", err)
+	}
+
+	if len(unmatchedErr.Failures) != 1 {
+		t.Fatalf("UnmatchedPackagesError.Failures = %v, want only npm to have failed", unmatchedErr.Failures)
+	}
+
+	failure := unmatchedErr.Failures[0]
+	if failure.Ecosystem != osvschema.EcosystemNPM {
+		t.Errorf("EcosystemFailure.Ecosystem = %q, want %q", failure.Ecosystem, osvschema.EcosystemNPM)
+	}
+	if want := []int{0, 2, 3, 5}; !reflect.DeepEqual(failure.Indexes, want) {
+		t.Errorf("EcosystemFailure.Indexes = %v, want %v", failure.Indexes, want)
+	}
+	if !strings.Contains(failure.Err.Error(), "gave up after 2 chunks failed") {
+		t.Errorf("EcosystemFailure.Err = %v, want the remaining chunks to have been given up on", failure.Err)
+	}
+
+	gotVulns := make([]string, len(got))
+	for i, vulns := range got {
+		if len(vulns) > 0 {
+			gotVulns[i] = vulns[0].ID
+		}
+	}
+
+	// the packages of other ecosystems are still matched
+	if want := []string{"", "OSV-x", "", "", "OSV-y", ""}; !reflect.DeepEqual(gotVulns, want) {
+		t.Errorf("OSVMatcher.MatchVulnerabilities() = %v, want %v", gotVulns, want)
+	}
+
+	// the npm pipeline does not retry its chunks, and gives up after the first two have failed
+	if want := map[string]int{"a": 1, "b": 1}; !reflect.DeepEqual(attempts, want) {
+		t.Errorf("attempts = %v, want %v", attempts, want)
+	}
+}
+
+func TestUnmatchedPackagesError(t *testing.T) {
+	t.Parallel()
+
+	errTimeout := context.DeadlineExceeded
+	err := &UnmatchedPackagesError{
+		Failures: []EcosystemFailure{
+			{Ecosystem: osvschema.EcosystemDebian, Indexes: []int{4, 1}, Err: errTimeout},
+			{Indexes: []int{2}, Err: errors.New("502 Bad Gateway")},
+		},
+	}
+
+	if want := []int{1, 2, 4}; !reflect.DeepEqual(err.Indexes(), want) {
+		t.Errorf("UnmatchedPackagesError.Indexes() = %v, want %v", err.Indexes(), want)
+	}
+
+	if want := "failed to match 3 packages: Debian: context deadline exceeded; commits: 502 Bad Gateway"; err.Error() != want {
+		t.Errorf("UnmatchedPackagesError.Error() = %q, want %q", err.Error(), want)
+	}
+
+	if !errors.Is(err, errTimeout) {
+		t.Errorf("expected UnmatchedPackagesError to wrap the error of each ecosystem")
+	}
+}
+
+func Test_pipeline_run_Retries(t *testing.T) {
+	t.Parallel()
+
+	pkgs := []*extractor.Package{
+		{Name: "a", Version: "1.0.0", PURLType: purl.TypeNPM},
+		{Name: "b", Version: "1.0.0", PURLType: purl.TypeNPM},
+	}
+
+	server, _ := newFlakyOSVServer(t, []string{"a"}, 2)
+
+	client := osvdev.DefaultClient()
+	client.BaseHostURL = server.URL
+	client.Config.MaxRetryAttempts = 1
+
+	p := pipeline{
+		matcher:         &OSVMatcher{Client: *client, chunkSize: 1},
+		ecosystem:       osvschema.EcosystemNPM,
+		config:          PipelineConfig{Retry: RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}},
+		queries:         invsToQueries(pkgs),
+		vulnerabilities: make([][]*osvschema.Vulnerability, len(pkgs)),
+		hydrateSem:      make(chan struct{}, 1),
+	}
+
+	// the retries are returned rather than being logged by the goroutines of the pipeline
+	res := p.run(t.Context(), []int{0, 1})
+
+	if len(res.failed) != 0 {
+		t.Errorf("pipeline.run() failed = %v, want no failures", res.failed)
+	}
+	if res.retries != 2 {
+		t.Errorf("pipeline.run() retries = %d, want 2", res.retries)
+	}
+	if res.retryErr == nil {
+		t.Errorf("pipeline.run() retryErr = nil, want the error that was retried")
+	}
+}
@@ -0,0 +1,237 @@
+package osvmatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/google/osv-scanner/v2/internal/telemetry"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+	"osv.dev/bindings/go/osvdev"
+	"osv.dev/bindings/go/osvdevexperimental"
+)
+
+// PipelineConfig configures the pipeline that the packages of an ecosystem are queried in
+type PipelineConfig struct {
+	// Concurrency is the most chunks of the ecosystem that are queried at once,
+	// with chunks being queried one at a time if it is zero
+	Concurrency int
+	// Retry configures how each chunk is retried if it fails, on top of any retrying
+	// of the individual requests done by the client.
+	// Chunks are only attempted once if MaxAttempts is zero.
+	Retry RetryConfig
+	// MaxFailedChunks is how many chunks can fail before the remaining chunks of the
+	// ecosystem are given up on without being queried, so that an ecosystem which is
+	// consistently failing (such as when it is being throttled) does not keep retrying.
+	// Chunks are never given up on if it is zero.
+	MaxFailedChunks int
+}
+
+// DefaultPipelineConfig returns the PipelineConfig used for each ecosystem when querying the OSV API
+func DefaultPipelineConfig() PipelineConfig {
+	return PipelineConfig{
+		Concurrency:     4,
+		Retry:           DefaultChunkRetryConfig(),
+		MaxFailedChunks: 3,
+	}
+}
+
+// pipeline queries the packages of a single ecosystem
+type pipeline struct {
+	matcher   *OSVMatcher
+	ecosystem osvschema.Ecosystem
+	config    PipelineConfig
+
+	// queries and vulnerabilities are shared by all the pipelines, with each
+	// pipeline only using the indexes of the packages in its ecosystem
+	queries         []*osvdev.Query
+	vulnerabilities [][]*osvschema.Vulnerability
+
+	// hydrateSem limits the number of vulnerabilities being hydrated across all the pipelines
+	hydrateSem chan struct{}
+}
+
+// pipelineResult accounts for the packages of the pipeline that could not be matched
+type pipelineResult struct {
+	// failed are the sorted indexes of the packages that could not be matched
+	failed []int
+	// err is the error of the most recent chunk that failed
+	err              error
+	deadlineExceeded bool
+	// retries is the number of times that the batch query of a chunk was retried,
+	// which are not logged by the pipeline itself as it runs on its own goroutines
+	retries int
+	// retryErr is the error of the most recent batch query that was retried
+	retryErr error
+}
+
+// run matches the packages at the given indexes in chunks, storing the
+// vulnerabilities of each chunk as soon as it has been hydrated
+func (p *pipeline) run(ctx context.Context, indexes []int) pipelineResult {
+	queryCtx := ctx
+	// If there is a timeout for the initial query, set an additional context deadline here.
+	if p.matcher.InitialQueryTimeout > 0 {
+		var cancelFunc context.CancelFunc
+		queryCtx, cancelFunc = context.WithTimeout(ctx, p.matcher.InitialQueryTimeout)
+		defer cancelFunc()
+	}
+
+	chunkSize := p.matcher.chunkSize
+	if chunkSize <= 0 {
+		chunkSize = osvdev.MaxQueriesPerQueryBatchRequest
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var res pipelineResult
+	failedChunks := 0
+	chunkSem := make(chan struct{}, max(p.config.Concurrency, 1))
+
+	for start := 0; start < len(indexes); start += chunkSize {
+		chunk := indexes[start:min(start+chunkSize, len(indexes))]
+
+		chunkSem <- struct{}{}
+
+		mu.Lock()
+		if p.config.MaxFailedChunks > 0 && failedChunks >= p.config.MaxFailedChunks {
+			res.failed = append(res.failed, indexes[start:]...)
+			res.err = fmt.Errorf("gave up after %d chunks failed: %w", failedChunks, res.err)
+			mu.Unlock()
+			p.reportProgress(len(indexes) - start)
+
+			break
+		}
+		mu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-chunkSem }()
+
+			chunkCtx, endBatch := telemetry.StartQueryBatch(ctx, string(p.ecosystem), len(chunk))
+			chunkRes, err := p.matchChunk(chunkCtx, trace.ContextWithSpan(queryCtx, trace.SpanFromContext(chunkCtx)), chunk)
+			endBatch(err)
+			p.reportProgress(len(chunk))
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			res.retries += chunkRes.retries
+			if chunkRes.retryErr != nil {
+				res.retryErr = chunkRes.retryErr
+			}
+
+			if err != nil {
+				failedChunks++
+				res.failed = append(res.failed, chunk...)
+				res.err = err
+
+				return
+			}
+
+			res.deadlineExceeded = res.deadlineExceeded || chunkRes.deadlineExceeded
+		}()
+	}
+	wg.Wait()
+
+	slices.Sort(res.failed)
+
+	return res
+}
+
+func (p *pipeline) reportProgress(packages int) {
+	if p.matcher.Progress != nil {
+		p.matcher.Progress(packages)
+	}
+}
+
+// matchChunk queries and hydrates the vulnerabilities of the packages at the given
+// indexes, with the result only having deadlineExceeded set if the query only partially
+// completed due to the deadline of queryCtx being exceeded
+func (p *pipeline) matchChunk(ctx, queryCtx context.Context, chunk []int) (pipelineResult, error) {
+	queries := make([]*osvdev.Query, len(chunk))
+	for i, idx := range chunk {
+		queries[i] = p.queries[idx]
+	}
+
+	batchResp, res, err := p.queryChunk(queryCtx, queries)
+
+	// Deadline being exceeded is likely caused by a long paging time
+	// if that's the case, we should return what we already got, and
+	// then let the caller know it is not all the results.
+	res.deadlineExceeded = err != nil && errors.Is(err, context.DeadlineExceeded) && batchResp != nil
+	if err != nil && !res.deadlineExceeded {
+		return res, err
+	}
+
+	vulnerabilities := make([][]*osvschema.Vulnerability, len(chunk))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentRequests)
+
+	for resultIdx, resp := range batchResp.Results {
+		if resultIdx >= len(chunk) {
+			break
+		}
+
+		vulnerabilities[resultIdx] = make([]*osvschema.Vulnerability, len(resp.Vulns))
+		for vulnIdx, vuln := range resp.Vulns {
+			g.Go(func() error {
+				select {
+				case p.hydrateSem <- struct{}{}:
+				case <-ctx.Done():
+					return nil
+				}
+				defer func() { <-p.hydrateSem }()
+
+				// exit early if another hydration request has already failed
+				// results are thrown away later, so avoid needless work
+				if ctx.Err() != nil {
+					return nil //nolint:nilerr // this value doesn't matter to errgroup.Wait()
+				}
+				vuln, err := p.matcher.Client.GetVulnByID(ctx, vuln.ID)
+				if err != nil {
+					return err
+				}
+				vulnerabilities[resultIdx][vulnIdx] = vuln
+
+				return nil
+			})
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		res.deadlineExceeded = false
+		return res, err
+	}
+
+	for i, idx := range chunk {
+		p.vulnerabilities[idx] = vulnerabilities[i]
+	}
+
+	return res, nil
+}
+
+// queryChunk performs a batch query for a single chunk of queries, retrying it
+// with an exponential backoff if it fails for any reason other than the context ending,
+// with the returned result accounting for the retries
+func (p *pipeline) queryChunk(ctx context.Context, queries []*osvdev.Query) (*osvdev.BatchedResponse, pipelineResult, error) {
+	var res pipelineResult
+	for attempt := 0; ; attempt++ {
+		batchResp, err := osvdevexperimental.BatchQueryPaging(ctx, &p.matcher.Client, queries)
+
+		if err == nil || ctx.Err() != nil || attempt+1 >= p.config.Retry.MaxAttempts {
+			return batchResp, res, err
+		}
+
+		res.retries++
+		res.retryErr = err
+
+		if err := sleepWithContext(ctx, p.config.Retry.backoff(attempt)); err != nil {
+			return nil, res, err
+		}
+	}
+}
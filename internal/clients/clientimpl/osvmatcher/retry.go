@@ -0,0 +1,207 @@
+package osvmatcher
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"osv.dev/bindings/go/osvdev"
+)
+
+// RetryConfig configures how requests to the OSV API are retried
+type RetryConfig struct {
+	// MaxAttempts is the total number of times a request is attempted, including the first attempt
+	MaxAttempts int
+	// InitialBackoff is how long to wait before the first retry, doubling for each retry after
+	InitialBackoff time.Duration
+	// MaxBackoff is the longest to wait between attempts, including when honoring Retry-After
+	MaxBackoff time.Duration
+	// JitterMultiplier is the largest fraction of the backoff that is randomly added to it,
+	// to spread out the retries of requests that failed at the same time
+	JitterMultiplier float64
+}
+
+// DefaultRetryConfig returns the RetryConfig used when querying the OSV API
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:      5,
+		InitialBackoff:   time.Second,
+		MaxBackoff:       time.Minute,
+		JitterMultiplier: 0.5,
+	}
+}
+
+// DefaultChunkRetryConfig returns the RetryConfig used when retrying chunks of a batch
+// query, which is only done once the retries of the individual requests have been exhausted
+func DefaultChunkRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:      3,
+		InitialBackoff:   5 * time.Second,
+		MaxBackoff:       time.Minute,
+		JitterMultiplier: 0.5,
+	}
+}
+
+// RetryStats counts the requests that have been made by a RetryTransport
+type RetryStats struct {
+	// Requests is the number of requests sent, including retries
+	Requests int64
+	// Retried is the number of requests that were retried
+	Retried int64
+	// Failed is the number of requests that still failed after being retried
+	Failed int64
+}
+
+// RetryTransport is a http.RoundTripper that retries requests that fail due to
+// network errors, rate limiting (429), or server errors (5xx), with an exponential
+// backoff and jitter between attempts, honoring the Retry-After header if present.
+type RetryTransport struct {
+	// Base is the transport used to send requests, defaulting to http.DefaultTransport
+	Base   http.RoundTripper
+	Config RetryConfig
+
+	requests atomic.Int64
+	retried  atomic.Int64
+	failed   atomic.Int64
+}
+
+// NewRetryingOSVClient creates a osv.dev client that retries requests using the given transport.
+//
+// The retries of the client itself are disabled, as they do not honor Retry-After
+// and would otherwise multiply the number of attempts made for each request.
+func NewRetryingOSVClient(transport *RetryTransport, userAgent string) *osvdev.OSVClient {
+	client := osvdev.DefaultClient()
+	client.HTTPClient = &http.Client{Transport: transport}
+	client.Config.MaxRetryAttempts = 1
+
+	if userAgent != "" {
+		client.Config.UserAgent = userAgent
+	}
+
+	return client
+}
+
+// Stats returns the number of requests that have been made so far
+func (t *RetryTransport) Stats() RetryStats {
+	return RetryStats{
+		Requests: t.requests.Load(),
+		Retried:  t.retried.Load(),
+		Failed:   t.failed.Load(),
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	maxAttempts := t.Config.MaxAttempts
+	if req.Body != nil && req.GetBody == nil {
+		// the body cannot be replayed, so the request can only be sent once
+		maxAttempts = 1
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			// the body of the previous attempt has already been consumed
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		t.requests.Add(1)
+		resp, err := base.RoundTrip(attemptReq)
+
+		if !shouldRetry(resp, err) || attempt+1 >= maxAttempts || req.Context().Err() != nil {
+			if err != nil || resp.StatusCode >= 400 {
+				t.failed.Add(1)
+			}
+
+			return resp, err
+		}
+
+		delay := t.Config.backoff(attempt)
+		if retryAfter, ok := parseRetryAfter(resp); ok {
+			delay = min(retryAfter, t.Config.MaxBackoff)
+		}
+
+		if resp != nil {
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		t.retried.Add(1)
+
+		if err := sleepWithContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns how long to wait before retrying after the given attempt
+func (c RetryConfig) backoff(attempt int) time.Duration {
+	backoff := float64(c.InitialBackoff) * math.Pow(2, float64(attempt))
+
+	// rand is initialized with a random number (since go1.20), and is also safe to use concurrently
+	// we do not need to use a cryptographically secure random jitter, this is just to spread out the retry requests
+	// #nosec G404
+	backoff += backoff * rand.Float64() * c.JitterMultiplier
+
+	return min(time.Duration(backoff), c.MaxBackoff)
+}
+
+// shouldRetry checks if the request failed in a way that might succeed if tried again
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// parseRetryAfter returns how long the server has asked to wait for before
+// retrying the request, which can be given either in seconds or as a date
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
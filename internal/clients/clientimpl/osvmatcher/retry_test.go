@@ -0,0 +1,133 @@
+package osvmatcher
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		statuses   []int
+		retryAfter string
+		wantStatus int
+		wantStats  RetryStats
+	}{
+		{
+			name:       "success is not retried",
+			statuses:   []int{http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantStats:  RetryStats{Requests: 1},
+		},
+		{
+			name:       "client errors are not retried",
+			statuses:   []int{http.StatusBadRequest},
+			wantStatus: http.StatusBadRequest,
+			wantStats:  RetryStats{Requests: 1, Failed: 1},
+		},
+		{
+			name:       "rate limiting and server errors are retried",
+			statuses:   []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantStats:  RetryStats{Requests: 3, Retried: 2},
+		},
+		{
+			name:       "Retry-After is honored",
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "0",
+			wantStatus: http.StatusOK,
+			wantStats:  RetryStats{Requests: 2, Retried: 1},
+		},
+		{
+			name:       "gives up after the max attempts",
+			statuses:   []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			wantStatus: http.StatusInternalServerError,
+			wantStats:  RetryStats{Requests: 3, Retried: 2, Failed: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var count atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := count.Add(1) - 1
+
+				// the body should be sent with every attempt
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "query" {
+					t.Errorf("attempt %d: got body %q, want %q", i+1, body, "query")
+				}
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[i])
+			}))
+			defer server.Close()
+
+			transport := &RetryTransport{
+				Config: RetryConfig{
+					MaxAttempts:    3,
+					InitialBackoff: time.Millisecond,
+					MaxBackoff:     time.Millisecond,
+				},
+			}
+			if tt.retryAfter != "" {
+				// long enough for the test to time out if Retry-After is not honored
+				transport.Config.InitialBackoff = time.Hour
+				transport.Config.MaxBackoff = time.Hour
+			}
+
+			client := &http.Client{Transport: transport}
+			resp, err := client.Post(server.URL, "text/plain", strings.NewReader("query"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("got status %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := transport.Stats(); got != tt.wantStats {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.wantStats)
+			}
+		})
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header string
+		want   time.Duration
+		wantOk bool
+	}{
+		{header: "", want: 0, wantOk: false},
+		{header: "120", want: 2 * time.Minute, wantOk: true},
+		{header: "-1", want: 0, wantOk: false},
+		{header: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, wantOk: true},
+		{header: "soon", want: 0, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{Header: http.Header{}}
+			resp.Header.Set("Retry-After", tt.header)
+
+			got, ok := parseRetryAfter(resp)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("parseRetryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package clientinterfaces
+
+import (
+	"context"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+type EPSSMatcher interface {
+	// MatchEPSS returns the EPSS score of each of the given CVEs that has one, keyed by CVE
+	MatchEPSS(ctx context.Context, cves []string) (map[string]models.EPSSScore, error)
+}
@@ -0,0 +1,14 @@
+package clientinterfaces
+
+import (
+	"context"
+
+	"github.com/google/osv-scanner/v2/internal/integrity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+type IntegrityMatcher interface {
+	// MatchIntegrity returns the hashes of the files of the package that are published by
+	// its registry, or no hashes if the registry does not know of the package
+	MatchIntegrity(ctx context.Context, pkg models.PackageInfo) ([]integrity.Hash, error)
+}
@@ -13,6 +13,7 @@ type Handler struct {
 	stderr             io.Writer
 	hasErrored         bool
 	everythingToStderr bool
+	clearLine          bool
 	Level              slog.Leveler
 
 	hasErroredBecauseInvalidConfig bool
@@ -27,6 +28,15 @@ func (c *Handler) SendEverythingToStderr() {
 	c.everythingToStderr = true
 }
 
+// ClearLineBeforeLogs tells the logger whether to clear the current line of the
+// terminal before writing each log.
+//
+// This is useful if a status line is being redrawn in place, which logs would
+// otherwise be written on the end of.
+func (c *Handler) ClearLineBeforeLogs(enabled bool) {
+	c.clearLine = enabled
+}
+
 func (c *Handler) SetLevel(level slog.Leveler) {
 	c.Level = level
 }
@@ -56,7 +66,12 @@ func (c *Handler) Handle(_ context.Context, record slog.Record) error {
 		}
 	}
 
-	_, err := fmt.Fprint(c.writer(record.Level), record.Message+"\n")
+	msg := record.Message + "\n"
+	if c.clearLine {
+		msg = "\r\x1b[K" + msg
+	}
+
+	_, err := fmt.Fprint(c.writer(record.Level), msg)
 
 	return err
 }
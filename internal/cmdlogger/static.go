@@ -23,3 +23,14 @@ func SetLevel(level slog.Leveler) {
 		l.SetLevel(level)
 	}
 }
+
+// ClearLineBeforeLogs tells the logger (if its in use) whether to clear the
+// current line of the terminal before writing each log, assuming the logger
+// is a [Handler].
+func ClearLineBeforeLogs(enabled bool) {
+	l, ok := slog.Default().Handler().(*Handler)
+
+	if ok {
+		l.ClearLineBeforeLogs(enabled)
+	}
+}
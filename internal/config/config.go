@@ -3,14 +3,15 @@ package config
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 	"time"
 
-	"github.com/BurntSushi/toml"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/cpe"
 	"github.com/google/osv-scanner/v2/internal/imodels"
 )
 
@@ -23,15 +24,37 @@ type Manager struct {
 	DefaultConfig Config
 	// Cache to store loaded configs
 	ConfigMap map[string]Config
+	// Profile is the name of the profile to merge on top of each config that is
+	// loaded, for configs that define it (see Config.mergeWithProfile)
+	Profile string
+	// InheritRoots are the directories whose configs are inherited by the directories
+	// within them, with the configs of nested directories being merged on top of the
+	// configs of their parent directories (see Config.merge); configs are only loaded
+	// from the directory of each target if there are none
+	InheritRoots []string
 }
 
 type Config struct {
 	IgnoredVulns      []IgnoreEntry          `toml:"IgnoredVulns"`
 	PackageOverrides  []PackageOverrideEntry `toml:"PackageOverrides"`
 	GoVersionOverride string                 `toml:"GoVersionOverride"`
+	FailOnSeverity    SeverityThresholds     `toml:"FailOnSeverity"`
+	// SuppressUnfixedAfterDays soft-suppresses vulnerabilities that have had no fix
+	// published for more than this many days, so that they are reported but do not
+	// fail the scan; vulnerabilities are never suppressed if it is zero
+	SuppressUnfixedAfterDays int `toml:"SuppressUnfixedAfterDays"`
+	// CPEMappings map the CPEs of SBOM components without package urls to packages,
+	// taking precedence over the mappings that are bundled with the scanner
+	CPEMappings []cpe.Mapping `toml:"CPEMappings"`
+	// SeverityLabels map the CVSS scores of vulnerabilities to custom labels
+	// that are reported alongside the scores
+	SeverityLabels SeverityLabels `toml:"SeverityLabels"`
 	// The path to config file that this config was loaded from,
 	// set by the scanner after having successfully parsed the file
 	LoadPath string `toml:"-"`
+	// The paths to the config files of parent directories that this config inherits
+	// from, outermost first, which are merged beneath the config at LoadPath
+	InheritedFrom []string `toml:"-"`
 }
 
 type IgnoreEntry struct {
@@ -137,13 +160,34 @@ func shouldIgnoreTimestamp(ignoreUntil time.Time) bool {
 	return ignoreUntil.After(time.Now())
 }
 
-// UseOverride updates the Manager to use the config at the given path in place
-// of any other config files that would be loaded when calling Get
-func (c *Manager) UseOverride(configPath string) error {
-	config, configErr := tryLoadConfig(configPath)
-	if configErr != nil {
-		return configErr
+// UseOverride updates the Manager to use the configs at the given paths in place
+// of any other config files that would be loaded when calling Get.
+//
+// If multiple paths are given, the configs are merged in order, with each config
+// taking precedence over the configs before it (see Config.merge).
+//
+// If the Manager has a profile, it must be defined by at least one of the configs,
+// so that a misspelt profile does not go unnoticed.
+func (c *Manager) UseOverride(configPaths ...string) error {
+	config := Config{}
+	hasProfile := c.Profile == ""
+
+	for _, configPath := range configPaths {
+		file, configErr := tryLoadConfigFile(configPath, true)
+		if configErr != nil {
+			return configErr
+		}
+		config = config.mergeWithProfile(file, c.Profile)
+
+		if _, ok := file.Profiles[c.Profile]; ok {
+			hasProfile = true
+		}
+	}
+
+	if !hasProfile {
+		return fmt.Errorf("profile %q is not defined by any of the config files: %s", c.Profile, strings.Join(configPaths, ", "))
 	}
+
 	c.OverrideConfig = &config
 
 	return nil
@@ -163,30 +207,79 @@ func (c *Manager) Get(targetPath string) Config {
 		return Config{}
 	}
 
-	config, alreadyExists := c.ConfigMap[configPath]
-	if alreadyExists {
+	return c.load(configPath)
+}
+
+// load returns the config to use for the config file at the given path, which is
+// merged on top of the config of its parent directory if that is inherited
+func (c *Manager) load(configPath string) Config {
+	if config, alreadyExists := c.ConfigMap[configPath]; alreadyExists {
 		return config
 	}
 
-	config, configErr := tryLoadConfig(configPath)
-	if configErr == nil {
+	parent, inherits := c.loadParent(configPath)
+
+	var config Config
+	file, configErr := tryLoadConfigFile(configPath, false)
+	switch {
+	case configErr == nil && inherits && parent.LoadPath != "":
+		config = parent.mergeWithProfile(file, c.Profile)
+		config.InheritedFrom = append(slices.Clone(parent.InheritedFrom), parent.LoadPath)
+		cmdlogger.Infof("Loaded filter from: %s (inheriting from %s)", config.LoadPath, strings.Join(config.InheritedFrom, ", "))
+	case configErr == nil:
+		config = Config{}.mergeWithProfile(file, c.Profile)
 		cmdlogger.Infof("Loaded filter from: %s", config.LoadPath)
-	} else {
+	default:
 		// anything other than the config file not existing is most likely due to an invalid config file
 		if !errors.Is(configErr, os.ErrNotExist) {
 			cmdlogger.Errorf("Ignored invalid config file at %s because: %v", configPath, configErr)
 		}
-		// If config doesn't exist, use the default config
+		// If config doesn't exist, use the config of the parent directory or the default config
 		config = c.DefaultConfig
+		if inherits {
+			config = parent
+		}
 	}
 	c.ConfigMap[configPath] = config
 
 	return config
 }
 
+// loadParent returns the config of the parent directory of the given config file,
+// if the directory is within one of the roots that configs are inherited from
+func (c *Manager) loadParent(configPath string) (Config, bool) {
+	dir := filepath.Dir(configPath)
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return Config{}, false
+	}
+
+	for _, root := range c.InheritRoots {
+		absRoot, err := filepath.Abs(root)
+		if err != nil || absRoot == absDir {
+			continue
+		}
+
+		if rel, err := filepath.Rel(absRoot, absDir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return c.load(filepath.Join(filepath.Dir(dir), osvScannerConfigName)), true
+		}
+	}
+
+	return Config{}, false
+}
+
 // Finds the containing folder of `target`, then appends osvScannerConfigName
 func normalizeConfigLoadPath(target string) (string, error) {
 	stat, err := os.Stat(target)
+
+	// the target can be a location within a file, such as a cell of a notebook
+	if i := strings.LastIndex(target, "#"); err != nil && i > 0 {
+		if fileStat, fileErr := os.Stat(target[:i]); fileErr == nil && !fileStat.IsDir() {
+			target, stat, err = target[:i], fileStat, nil
+		}
+	}
+
 	if err != nil {
 		return "", fmt.Errorf("failed to stat target: %w", err)
 	}
@@ -202,11 +295,51 @@ func normalizeConfigLoadPath(target string) (string, error) {
 	return configPath, nil
 }
 
+// configFile represents the contents of a config file, which in addition to the
+// config itself can replace the lists of any configs that it is merged on top of
+type configFile struct {
+	Config
+
+	ReplaceIgnoredVulns     []IgnoreEntry          `toml:"ReplaceIgnoredVulns"`
+	ReplacePackageOverrides []PackageOverrideEntry `toml:"ReplacePackageOverrides"`
+
+	// ExitCodes are only read from the config files given to the scan commands by LoadExitCodes,
+	// as they apply to the scan as a whole rather than to the directories that are scanned
+	ExitCodes map[string]int `toml:"ExitCodes"`
+
+	// Labels are only read from the config files given to the scan commands by LoadLabels,
+	// as they describe the scan as a whole rather than the directories that are scanned
+	Labels map[string]string `toml:"Labels"`
+
+	// Profiles are named configs that are merged on top of the rest of the file when
+	// they are selected, so that the same file can be used in different situations
+	Profiles map[string]configFile `toml:"profile"`
+
+	// whether the replacement lists were present in the file, as they can be empty
+	replacesIgnoredVulns     bool
+	replacesPackageOverrides bool
+}
+
 // tryLoadConfig attempts to parse the config file at the given path as TOML,
-// returning the Config object if successful or otherwise the error
-func tryLoadConfig(configPath string) (Config, error) {
-	config := Config{}
-	m, err := toml.DecodeFile(configPath, &config)
+// returning the Config object with the given profile merged on top of it if
+// successful or otherwise the error
+func tryLoadConfig(configPath string, profile string) (Config, error) {
+	file, err := tryLoadConfigFile(configPath, false)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{}.mergeWithProfile(file, profile), nil
+}
+
+// tryLoadConfigFile attempts to parse the config file at the given path as TOML,
+// returning the configFile object if successful or otherwise the error
+//
+// Environment variables are only interpolated if withEnv is true, as config files
+// found within the directories being scanned are not necessarily trusted
+func tryLoadConfigFile(configPath string, withEnv bool) (configFile, error) {
+	file := configFile{}
+	m, err := decodeConfigFile(configPath, &file, withEnv)
 	if err == nil {
 		unknownKeys := m.Undecoded()
 
@@ -217,20 +350,216 @@ func tryLoadConfig(configPath string) (Config, error) {
 				keys = append(keys, key.String())
 			}
 
-			return Config{}, fmt.Errorf("unknown keys in config file: %s", strings.Join(keys, ", "))
+			return configFile{}, fmt.Errorf("unknown keys in config file: %s", strings.Join(keys, ", "))
+		}
+
+		if err := file.validate(); err != nil {
+			return configFile{}, err
+		}
+
+		file.replacesIgnoredVulns = m.IsDefined("ReplaceIgnoredVulns")
+		file.replacesPackageOverrides = m.IsDefined("ReplacePackageOverrides")
+		file.LoadPath = configPath
+		file.warnAboutDuplicates()
+
+		for name, profile := range file.Profiles {
+			if len(profile.Profiles) > 0 {
+				return configFile{}, fmt.Errorf("invalid profile %q: profiles cannot be nested", name)
+			}
+
+			if err := profile.validate(); err != nil {
+				return configFile{}, fmt.Errorf("invalid profile %q: %w", name, err)
+			}
+
+			profile.replacesIgnoredVulns = m.IsDefined("profile", name, "ReplaceIgnoredVulns")
+			profile.replacesPackageOverrides = m.IsDefined("profile", name, "ReplacePackageOverrides")
+			profile.LoadPath = configPath
+			profile.warnAboutDuplicates()
+			file.Profiles[name] = profile
+		}
+	}
+
+	return file, err
+}
+
+// validate checks that the values of the config file are usable
+func (c *configFile) validate() error {
+	if err := c.FailOnSeverity.validate(); err != nil {
+		return fmt.Errorf("invalid FailOnSeverity: %w", err)
+	}
+
+	if c.SuppressUnfixedAfterDays < 0 {
+		return fmt.Errorf("invalid SuppressUnfixedAfterDays: must not be negative, got %d", c.SuppressUnfixedAfterDays)
+	}
+
+	if err := c.SeverityLabels.validate(); err != nil {
+		return fmt.Errorf("invalid SeverityLabels: %w", err)
+	}
+
+	for _, mapping := range c.CPEMappings {
+		if err := mapping.Validate(); err != nil {
+			return fmt.Errorf("invalid CPEMappings: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LoadExitCodes returns the ExitCodes of the config files at the given paths, with the
+// codes of later files taking precedence over those of earlier files, and the codes of
+// the given profile taking precedence over the rest of the file that it is defined in
+func LoadExitCodes(profile string, configPaths ...string) (map[string]int, error) {
+	codes := make(map[string]int)
+
+	for _, configPath := range configPaths {
+		var file struct {
+			ExitCodes map[string]int `toml:"ExitCodes"`
+			Profiles  map[string]struct {
+				ExitCodes map[string]int `toml:"ExitCodes"`
+			} `toml:"profile"`
+		}
+
+		if _, err := decodeConfigFile(configPath, &file, true); err != nil {
+			return nil, fmt.Errorf("failed to read exit codes from %s: %w", configPath, err)
+		}
+
+		maps.Copy(codes, file.ExitCodes)
+		maps.Copy(codes, file.Profiles[profile].ExitCodes)
+	}
+
+	return codes, nil
+}
+
+// LoadLabels returns the Labels of the config files at the given paths, with the
+// labels of later files and of the given profile taking precedence
+func LoadLabels(profile string, configPaths ...string) (map[string]string, error) {
+	labels := make(map[string]string)
+
+	for _, configPath := range configPaths {
+		var file struct {
+			Labels   map[string]string `toml:"Labels"`
+			Profiles map[string]struct {
+				Labels map[string]string `toml:"Labels"`
+			} `toml:"profile"`
 		}
 
-		config.LoadPath = configPath
-		config.warnAboutDuplicates()
+		if _, err := decodeConfigFile(configPath, &file, true); err != nil {
+			return nil, fmt.Errorf("failed to read labels from %s: %w", configPath, err)
+		}
+
+		maps.Copy(labels, file.Labels)
+		maps.Copy(labels, file.Profiles[profile].Labels)
+	}
+
+	return labels, nil
+}
+
+// merge returns a copy of the config with the given config file merged on top of it:
+//
+//   - GoVersionOverride and SuppressUnfixedAfterDays are replaced if they are set by the file
+//   - SeverityLabels are replaced entirely if any are set by the file, as the labels
+//     of different files are unlikely to make sense together
+//   - FailOnSeverity thresholds from the file replace the existing default
+//     threshold and the thresholds of the same dependency groups
+//   - IgnoredVulns from the file are appended, replacing any existing entries
+//     with the same id
+//   - PackageOverrides from the file are appended, replacing any existing entries
+//     which match the same name, version, ecosystem, and group
+//   - CPEMappings from the file are appended, replacing any existing entries
+//     for the same vendor and product
+//   - ReplaceIgnoredVulns and ReplacePackageOverrides from the file replace the
+//     existing entries entirely, before the entries above are merged
+func (c Config) merge(file configFile) Config {
+	merged := Config{
+		IgnoredVulns: mergeEntries(
+			c.IgnoredVulns,
+			file.replacesIgnoredVulns,
+			file.ReplaceIgnoredVulns,
+			file.IgnoredVulns,
+			func(a, b IgnoreEntry) bool { return a.ID == b.ID },
+		),
+		PackageOverrides: mergeEntries(
+			c.PackageOverrides,
+			file.replacesPackageOverrides,
+			file.ReplacePackageOverrides,
+			file.PackageOverrides,
+			func(a, b PackageOverrideEntry) bool {
+				return a.Name == b.Name && a.Version == b.Version && a.Ecosystem == b.Ecosystem && a.Group == b.Group
+			},
+		),
+		CPEMappings: mergeEntries(
+			c.CPEMappings,
+			false,
+			nil,
+			file.CPEMappings,
+			func(a, b cpe.Mapping) bool {
+				return strings.EqualFold(a.Vendor, b.Vendor) && strings.EqualFold(a.Product, b.Product)
+			},
+		),
+		GoVersionOverride:        c.GoVersionOverride,
+		FailOnSeverity:           c.FailOnSeverity.Merge(file.FailOnSeverity),
+		SuppressUnfixedAfterDays: c.SuppressUnfixedAfterDays,
+		SeverityLabels:           c.SeverityLabels,
+		LoadPath:                 file.LoadPath,
+	}
+
+	if !file.SeverityLabels.IsEmpty() {
+		merged.SeverityLabels = file.SeverityLabels
+	}
+
+	if file.GoVersionOverride != "" {
+		merged.GoVersionOverride = file.GoVersionOverride
+	}
+
+	if file.SuppressUnfixedAfterDays != 0 {
+		merged.SuppressUnfixedAfterDays = file.SuppressUnfixedAfterDays
+	}
+
+	return merged
+}
+
+// mergeWithProfile returns a copy of the config with the given config file merged on top
+// of it, followed by the named profile of the file if it defines one (see Config.merge)
+func (c Config) mergeWithProfile(file configFile, profile string) Config {
+	merged := c.merge(file)
+
+	if p, ok := file.Profiles[profile]; ok && profile != "" {
+		merged = merged.merge(p)
+	}
+
+	return merged
+}
+
+// mergeEntries appends the given entries to the existing ones (or to the replacement
+// entries if replace is true), with entries that are the same as an existing entry
+// taking its place rather than being appended
+func mergeEntries[T any](existing []T, replace bool, replacement []T, entries []T, same func(a, b T) bool) []T {
+	// only entries from earlier configs are replaced, so that duplicates
+	// within the same config keep behaving the same as before merging
+	merged := slices.Clone(existing)
+	n := len(merged)
+
+	if replace {
+		merged = slices.Clone(replacement)
+		n = 0
+	}
+
+	for _, entry := range entries {
+		i := slices.IndexFunc(merged[:n], func(e T) bool { return same(e, entry) })
+		if i == -1 {
+			merged = append(merged, entry)
+			continue
+		}
+		merged[i] = entry
 	}
 
-	return config, err
+	return merged
 }
 
-func (c *Config) warnAboutDuplicates() {
+func (c *configFile) warnAboutDuplicates() {
 	seen := make(map[string]struct{})
 
-	for _, vuln := range c.IgnoredVulns {
+	for _, vuln := range slices.Concat(c.ReplaceIgnoredVulns, c.IgnoredVulns) {
 		if _, ok := seen[vuln.ID]; ok {
 			cmdlogger.Warnf("warning: %s has multiple ignores for %s - only the first will be used!", c.LoadPath, vuln.ID)
 		}
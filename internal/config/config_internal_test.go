@@ -12,7 +12,9 @@ import (
 	apkmetadata "github.com/google/osv-scalibr/extractor/filesystem/os/apk/metadata"
 	"github.com/google/osv-scalibr/extractor/filesystem/osv"
 	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cpe"
 	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/utility/severity"
 )
 
 // Attempts to normalize any file paths in the given `output` so that they can
@@ -85,6 +87,22 @@ func Test_normalizeConfigLoadPath(t *testing.T) {
 			want:    "fixtures/testdatainner/osv-scanner.toml",
 			wantErr: false,
 		},
+		{
+			name: "target is location within file in directory",
+			args: args{
+				target: "./fixtures/testdatainner/innerFolder/test.yaml#cell-2",
+			},
+			want:    "fixtures/testdatainner/innerFolder/osv-scanner.toml",
+			wantErr: false,
+		},
+		{
+			name: "target is location within file that does not exist",
+			args: args{
+				target: "./fixtures/testdatainner/does-not-exist#cell-2",
+			},
+			want:    "",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -176,7 +194,7 @@ func Test_tryLoadConfig(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := tryLoadConfig(tt.args.configPath)
+			got, err := tryLoadConfig(tt.args.configPath, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("tryLoadConfig() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -226,7 +244,7 @@ func TestTryLoadConfig_UnknownKeys(t *testing.T) {
 	}
 
 	for _, testData := range tests {
-		c, err := tryLoadConfig(testData.configPath)
+		c, err := tryLoadConfig(testData.configPath, "")
 
 		// we should always be returning an empty config on error
 		if diff := cmp.Diff(Config{}, c); diff != "" {
@@ -244,6 +262,513 @@ func TestTryLoadConfig_UnknownKeys(t *testing.T) {
 	}
 }
 
+func TestManager_UseOverride(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		configPaths []string
+		want        Config
+		wantErr     bool
+	}{
+		{
+			name:        "single config",
+			configPaths: []string{"./fixtures/merge/base.toml"},
+			want: Config{
+				LoadPath:          "./fixtures/merge/base.toml",
+				GoVersionOverride: "1.21.0",
+				IgnoredVulns: []IgnoreEntry{
+					{ID: "GO-2022-0968", Reason: "No ssh servers are connected to or hosted in Go lang"},
+					{ID: "GO-2022-1059", Reason: "No external http servers are written in Go lang."},
+				},
+				PackageOverrides: []PackageOverrideEntry{
+					{Name: "lib", Ecosystem: "Go", Ignore: true, Reason: "abc"},
+				},
+				FailOnSeverity: SeverityThresholds{
+					Default:   severity.MediumRating,
+					DepGroups: map[string]severity.Rating{"dev": severity.HighRating},
+				},
+				CPEMappings: []cpe.Mapping{
+					{Vendor: "acme", Product: "router_firmware", Ecosystem: "ConanCenter", Name: "openssl"},
+				},
+				SeverityLabels: SeverityLabels{
+					Unknown: "P4",
+					Ranges: []SeverityLabelRange{
+						{Label: "P0", MinScore: 9},
+						{Label: "P1", MinScore: 7},
+						{Label: "P2", MinScore: 4},
+						{Label: "P3", MinScore: 0},
+					},
+				},
+				SuppressUnfixedAfterDays: 90,
+			},
+		},
+		{
+			name:        "later configs are merged on top of earlier ones",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/merge/team-overrides.toml"},
+			want: Config{
+				LoadPath:          "./fixtures/merge/team-overrides.toml",
+				GoVersionOverride: "1.22.0",
+				IgnoredVulns: []IgnoreEntry{
+					{ID: "GO-2022-0968", Reason: "No ssh servers are connected to or hosted in Go lang"},
+					{ID: "GO-2022-1059", Reason: "Our http servers are not exposed to the internet"},
+					{ID: "GHSA-xxxx-xxxx-xxxx"},
+				},
+				PackageOverrides: []PackageOverrideEntry{
+					{Name: "lib", Ecosystem: "Go", Vulnerability: Vulnerability{Ignore: true}, Reason: "def"},
+					{Name: "my-pkg", License: License{Override: []string{"MIT"}}},
+				},
+				FailOnSeverity: SeverityThresholds{
+					Default:   severity.HighRating,
+					DepGroups: map[string]severity.Rating{"dev": severity.HighRating, "test": severity.CriticalRating},
+				},
+				CPEMappings: []cpe.Mapping{
+					{Vendor: "acme", Product: "router_firmware", Ecosystem: "Linux", Name: "Kernel"},
+					{Vendor: "acme", Product: "libacme", Ecosystem: "ConanCenter", Name: "zlib"},
+				},
+				SeverityLabels: SeverityLabels{
+					Ranges: []SeverityLabelRange{
+						{Label: "Blocker", MinScore: 9},
+						{Label: "Major", MinScore: 7},
+						{Label: "Minor", MinScore: 0},
+					},
+				},
+				SuppressUnfixedAfterDays: 90,
+			},
+		},
+		{
+			name:        "lists can be replaced",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/merge/team-overrides.toml", "./fixtures/merge/replace.toml"},
+			want: Config{
+				LoadPath:          "./fixtures/merge/replace.toml",
+				GoVersionOverride: "1.22.0",
+				IgnoredVulns: []IgnoreEntry{
+					{ID: "GO-2022-0969"},
+				},
+				PackageOverrides: []PackageOverrideEntry{
+					{Group: "dev", Ignore: true},
+				},
+				FailOnSeverity: SeverityThresholds{
+					Default:   severity.HighRating,
+					DepGroups: map[string]severity.Rating{"dev": severity.HighRating, "test": severity.CriticalRating},
+				},
+				CPEMappings: []cpe.Mapping{
+					{Vendor: "acme", Product: "router_firmware", Ecosystem: "Linux", Name: "Kernel"},
+					{Vendor: "acme", Product: "libacme", Ecosystem: "ConanCenter", Name: "zlib"},
+				},
+				SeverityLabels: SeverityLabels{
+					Ranges: []SeverityLabelRange{
+						{Label: "Blocker", MinScore: 9},
+						{Label: "Major", MinScore: 7},
+						{Label: "Minor", MinScore: 0},
+					},
+				},
+				SuppressUnfixedAfterDays: 90,
+			},
+		},
+		{
+			name:        "invalid severities are an error",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/invalid-severity.toml"},
+			wantErr:     true,
+		},
+		{
+			name:        "negative days to suppress unfixed vulnerabilities after are an error",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/invalid-suppress-unfixed.toml"},
+			wantErr:     true,
+		},
+		{
+			name:        "severity labels with a minimum score that is not a cvss score are an error",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/invalid-severity-labels.toml"},
+			wantErr:     true,
+		},
+		{
+			name:        "cpe mappings without an ecosystem are an error",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/invalid-cpe-mapping.toml"},
+			wantErr:     true,
+		},
+		{
+			name:        "any config failing to load is an error",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/merge/does-not-exist.toml"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manager := Manager{ConfigMap: make(map[string]Config)}
+
+			err := manager.UseOverride(tt.configPaths...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UseOverride() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, manager.Get("./fixtures/testdatainner")); diff != "" {
+				t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestManager_Profile(t *testing.T) {
+	t.Parallel()
+
+	base := Config{
+		LoadPath:          "fixtures/profiles/osv-scanner.toml",
+		GoVersionOverride: "1.21.0",
+		IgnoredVulns: []IgnoreEntry{
+			{ID: "GO-2022-0968", Reason: "No ssh servers are connected to or hosted in Go lang"},
+		},
+		FailOnSeverity: SeverityThresholds{Default: severity.HighRating},
+	}
+
+	tests := []struct {
+		name    string
+		profile string
+		want    Config
+	}{
+		{
+			name:    "no profile",
+			profile: "",
+			want:    base,
+		},
+		{
+			name:    "profile replacing lists and thresholds",
+			profile: "ci",
+			want: Config{
+				LoadPath:          "fixtures/profiles/osv-scanner.toml",
+				GoVersionOverride: "1.21.0",
+				IgnoredVulns:      []IgnoreEntry{},
+				FailOnSeverity:    SeverityThresholds{Default: severity.LowRating},
+			},
+		},
+		{
+			name:    "profile adding to lists",
+			profile: "nightly",
+			want: Config{
+				LoadPath:          "fixtures/profiles/osv-scanner.toml",
+				GoVersionOverride: "1.21.0",
+				IgnoredVulns: []IgnoreEntry{
+					{ID: "GO-2022-0968", Reason: "No ssh servers are connected to or hosted in Go lang"},
+					{ID: "GO-2022-1059", Reason: "Our http servers are not exposed to the internet"},
+				},
+				FailOnSeverity:           SeverityThresholds{Default: severity.HighRating},
+				SuppressUnfixedAfterDays: 30,
+			},
+		},
+		{
+			name:    "profile that is not defined by the config",
+			profile: "dev",
+			want:    base,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manager := Manager{ConfigMap: make(map[string]Config), Profile: tt.profile}
+
+			got := manager.Get("./fixtures/profiles")
+			got.LoadPath = normalizeFilePaths(t, got.LoadPath)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestManager_UseOverride_Profile(t *testing.T) {
+	t.Parallel()
+
+	manager := Manager{ConfigMap: make(map[string]Config), Profile: "ci"}
+
+	err := manager.UseOverride("./fixtures/merge/base.toml", "./fixtures/profiles/osv-scanner.toml")
+	if err != nil {
+		t.Fatalf("UseOverride() error = %v", err)
+	}
+
+	got := manager.Get("./fixtures/testdatainner")
+
+	if len(got.IgnoredVulns) != 0 {
+		t.Errorf("Get().IgnoredVulns = %v, want none", got.IgnoredVulns)
+	}
+
+	if got.FailOnSeverity.Default != severity.LowRating {
+		t.Errorf("Get().FailOnSeverity.Default = %v, want %v", got.FailOnSeverity.Default, severity.LowRating)
+	}
+
+	// profiles that none of the configs define are most likely misspelt
+	manager = Manager{ConfigMap: make(map[string]Config), Profile: "cl"}
+
+	err = manager.UseOverride("./fixtures/merge/base.toml", "./fixtures/profiles/osv-scanner.toml")
+	wantErr := `profile "cl" is not defined by any of the config files: ./fixtures/merge/base.toml, ./fixtures/profiles/osv-scanner.toml`
+
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("UseOverride() error = %v, want %q", err, wantErr)
+	}
+}
+
+func TestManager_Environment(t *testing.T) {
+	t.Parallel()
+
+	manager := Manager{ConfigMap: make(map[string]Config)}
+
+	// configs given by the user have the environment variables they reference interpolated
+	err := manager.UseOverride("./fixtures/environment/osv-scanner.toml")
+
+	wantErr := "environment variables are not set: OSV_SCANNER_TEST_UNSET_VARIABLE"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("UseOverride() error = %v, want %q", err, wantErr)
+	}
+
+	// whereas configs found alongside what is being scanned are used as they are written
+	got := manager.Get("./fixtures/environment")
+	want := []IgnoreEntry{{ID: "GO-2022-0968", Reason: "${OSV_SCANNER_TEST_UNSET_VARIABLE}"}}
+
+	if diff := cmp.Diff(want, got.IgnoredVulns); diff != "" {
+		t.Errorf("Get().IgnoredVulns mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestManager_InheritRoots(t *testing.T) {
+	t.Parallel()
+
+	root := Config{
+		LoadPath:          "fixtures/inherit/osv-scanner.toml",
+		GoVersionOverride: "1.21.0",
+		IgnoredVulns: []IgnoreEntry{
+			{ID: "GO-2022-0968", Reason: "No ssh servers are connected to or hosted in Go lang"},
+			{ID: "GO-2022-1059", Reason: "No external http servers are written in Go lang."},
+		},
+		FailOnSeverity: SeverityThresholds{Default: severity.MediumRating},
+	}
+
+	payments := Config{
+		LoadPath:          "fixtures/inherit/services/payments/osv-scanner.toml",
+		InheritedFrom:     []string{"fixtures/inherit/osv-scanner.toml"},
+		GoVersionOverride: "1.21.0",
+		IgnoredVulns: []IgnoreEntry{
+			{ID: "GO-2022-0968", Reason: "No ssh servers are connected to or hosted in Go lang"},
+			{ID: "GO-2022-1059", Reason: "Our http servers are not exposed to the internet"},
+		},
+		PackageOverrides: []PackageOverrideEntry{
+			{Name: "lib", Ecosystem: "Go", Ignore: true, Reason: "only used by the payments service in tests"},
+		},
+		FailOnSeverity: SeverityThresholds{Default: severity.HighRating},
+	}
+
+	tests := []struct {
+		name   string
+		roots  []string
+		target string
+		want   Config
+	}{
+		{
+			name:   "config of the root",
+			roots:  []string{"./fixtures/inherit"},
+			target: "./fixtures/inherit/osv-scanner.toml",
+			want:   root,
+		},
+		{
+			name:   "directory without a config inherits from its parent",
+			roots:  []string{"./fixtures/inherit"},
+			target: "./fixtures/inherit/tools/go.mod",
+			want:   root,
+		},
+		{
+			name:   "nested config is merged on top of the root config",
+			roots:  []string{"./fixtures/inherit"},
+			target: "./fixtures/inherit/services/payments/osv-scanner.toml",
+			want:   payments,
+		},
+		{
+			name:   "directories within a nested config inherit the merged config",
+			roots:  []string{"./fixtures/inherit"},
+			target: "./fixtures/inherit/services/payments/api/go.mod",
+			want:   payments,
+		},
+		{
+			name:   "configs above the root are not inherited",
+			roots:  []string{"./fixtures/inherit/services"},
+			target: "./fixtures/inherit/services/payments/api/go.mod",
+			want: Config{
+				LoadPath:         "fixtures/inherit/services/payments/osv-scanner.toml",
+				IgnoredVulns:     payments.IgnoredVulns[1:],
+				PackageOverrides: payments.PackageOverrides,
+				FailOnSeverity:   payments.FailOnSeverity,
+			},
+		},
+		{
+			name:   "configs are not inherited without roots",
+			roots:  nil,
+			target: "./fixtures/inherit/services/go.mod",
+			want:   Config{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			manager := Manager{ConfigMap: make(map[string]Config), InheritRoots: tt.roots}
+
+			got := manager.Get(tt.target)
+			got.LoadPath = normalizeFilePaths(t, got.LoadPath)
+			for i := range got.InheritedFrom {
+				got.InheritedFrom[i] = normalizeFilePaths(t, got.InheritedFrom[i])
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTryLoadConfig_InvalidProfiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		configPath string
+		wantErr    string
+	}{
+		{
+			configPath: "./fixtures/profiles/unknown-key.toml",
+			wantErr:    "unknown keys in config file: profile.ci.FailOnSeverityy",
+		},
+		{
+			configPath: "./fixtures/profiles/invalid-severity.toml",
+			wantErr:    `invalid profile "ci": invalid FailOnSeverity: unknown severity "SEVERE" - must be one of: LOW, MEDIUM, HIGH, CRITICAL`,
+		},
+		{
+			configPath: "./fixtures/profiles/nested.toml",
+			wantErr:    `invalid profile "ci": profiles cannot be nested`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.configPath, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := tryLoadConfig(tt.configPath, "ci")
+
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("tryLoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadExitCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		profile     string
+		configPaths []string
+		want        map[string]int
+		wantErr     bool
+	}{
+		{
+			name:        "no configs",
+			configPaths: nil,
+			want:        map[string]int{},
+		},
+		{
+			name:        "later configs take precedence over earlier ones",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/merge/team-overrides.toml", "./fixtures/merge/replace.toml"},
+			want:        map[string]int{"vulnerabilities-found": 10, "error": 2},
+		},
+		{
+			name:        "profiles take precedence over the rest of the config",
+			profile:     "ci",
+			configPaths: []string{"./fixtures/profiles/osv-scanner.toml"},
+			want:        map[string]int{"vulnerabilities-found": 10, "error": 2},
+		},
+		{
+			name:        "profiles without exit codes use those of the rest of the config",
+			profile:     "nightly",
+			configPaths: []string{"./fixtures/profiles/osv-scanner.toml"},
+			want:        map[string]int{"vulnerabilities-found": 1, "error": 2},
+		},
+		{
+			name:        "any config failing to load is an error",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/merge/does-not-exist.toml"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadExitCodes(tt.profile, tt.configPaths...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadExitCodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadExitCodes() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		profile     string
+		configPaths []string
+		want        map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "no configs",
+			configPaths: nil,
+			want:        map[string]string{},
+		},
+		{
+			name:        "later configs take precedence over earlier ones",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/merge/team-overrides.toml", "./fixtures/merge/replace.toml"},
+			want:        map[string]string{"service": "payments", "team": "payments"},
+		},
+		{
+			name:        "profiles take precedence over the rest of the config",
+			profile:     "ci",
+			configPaths: []string{"./fixtures/profiles/osv-scanner.toml"},
+			want:        map[string]string{"service": "payments", "env": "ci"},
+		},
+		{
+			name:        "profiles without labels use those of the rest of the config",
+			profile:     "nightly",
+			configPaths: []string{"./fixtures/profiles/osv-scanner.toml"},
+			want:        map[string]string{"service": "payments", "env": "dev"},
+		},
+		{
+			name:        "any config failing to load is an error",
+			configPaths: []string{"./fixtures/merge/base.toml", "./fixtures/merge/does-not-exist.toml"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := LoadLabels(tt.profile, tt.configPaths...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadLabels() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestConfig_ShouldIgnore(t *testing.T) {
 	t.Parallel()
 
@@ -1202,3 +1727,204 @@ func TestConfig_ShouldOverridePackageLicense(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSeverityThresholds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  []string
+		want    SeverityThresholds
+		wantErr bool
+	}{
+		{
+			name:   "no values",
+			values: nil,
+			want:   SeverityThresholds{},
+		},
+		{
+			name:   "default threshold",
+			values: []string{"high"},
+			want:   SeverityThresholds{Default: severity.HighRating},
+		},
+		{
+			name:   "dependency group thresholds",
+			values: []string{"HIGH", "dev:CRITICAL", "test:critical"},
+			want: SeverityThresholds{
+				Default: severity.HighRating,
+				DepGroups: map[string]severity.Rating{
+					"dev":  severity.CriticalRating,
+					"test": severity.CriticalRating,
+				},
+			},
+		},
+		{
+			name:   "later values take precedence",
+			values: []string{"dev:LOW", "dev:MEDIUM"},
+			want: SeverityThresholds{
+				DepGroups: map[string]severity.Rating{"dev": severity.MediumRating},
+			},
+		},
+		{
+			name:    "unknown severity",
+			values:  []string{"SEVERE"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown severity is not a threshold",
+			values:  []string{"dev:UNKNOWN"},
+			wantErr: true,
+		},
+		{
+			name:    "missing dependency group",
+			values:  []string{":HIGH"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSeverityThresholds(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSeverityThresholds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseSeverityThresholds() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSeverityThresholds_ShouldFail(t *testing.T) {
+	t.Parallel()
+
+	thresholds := SeverityThresholds{
+		Default: severity.HighRating,
+		DepGroups: map[string]severity.Rating{
+			"dev":  severity.CriticalRating,
+			"test": severity.CriticalRating,
+			"docs": severity.LowRating,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		thresholds SeverityThresholds
+		rating     severity.Rating
+		depGroups  []string
+		want       bool
+	}{
+		{
+			name:       "no thresholds",
+			thresholds: SeverityThresholds{},
+			rating:     severity.LowRating,
+			want:       true,
+		},
+		{
+			name:       "no thresholds with unknown severity",
+			thresholds: SeverityThresholds{},
+			rating:     severity.UnknownRating,
+			want:       true,
+		},
+		{
+			name:       "production dependency meeting the default threshold",
+			thresholds: thresholds,
+			rating:     severity.HighRating,
+			want:       true,
+		},
+		{
+			name:       "production dependency below the default threshold",
+			thresholds: thresholds,
+			rating:     severity.MediumRating,
+			want:       false,
+		},
+		{
+			name:       "unknown severity never meets a threshold",
+			thresholds: thresholds,
+			rating:     severity.UnknownRating,
+			want:       false,
+		},
+		{
+			name:       "dev dependency below the group threshold",
+			thresholds: thresholds,
+			rating:     severity.HighRating,
+			depGroups:  []string{"dev"},
+			want:       false,
+		},
+		{
+			name:       "dev dependency meeting the group threshold",
+			thresholds: thresholds,
+			rating:     severity.CriticalRating,
+			depGroups:  []string{"dev"},
+			want:       true,
+		},
+		{
+			name:       "group without a threshold uses the default",
+			thresholds: thresholds,
+			rating:     severity.HighRating,
+			depGroups:  []string{"optional"},
+			want:       true,
+		},
+		{
+			name:       "strictest threshold of all groups is used",
+			thresholds: thresholds,
+			rating:     severity.MediumRating,
+			depGroups:  []string{"dev", "docs"},
+			want:       true,
+		},
+		{
+			name:       "group without any threshold always fails",
+			thresholds: SeverityThresholds{DepGroups: map[string]severity.Rating{"dev": severity.CriticalRating}},
+			rating:     severity.LowRating,
+			depGroups:  []string{"dev", "optional"},
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.thresholds.ShouldFail(tt.rating, tt.depGroups); got != tt.want {
+				t.Errorf("ShouldFail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverityLabels_Label(t *testing.T) {
+	t.Parallel()
+
+	labels := SeverityLabels{
+		Unknown: "P4",
+		// the ranges do not need to be in order
+		Ranges: []SeverityLabelRange{
+			{Label: "P2", MinScore: 4},
+			{Label: "P0", MinScore: 9},
+			{Label: "P1", MinScore: 7},
+		},
+	}
+
+	tests := []struct {
+		score string
+		want  string
+	}{
+		{score: "10.0", want: "P0"},
+		{score: "9.0", want: "P0"},
+		{score: "8.9", want: "P1"},
+		{score: "7.0", want: "P1"},
+		{score: "4.3", want: "P2"},
+		{score: "3.9", want: ""},
+		{score: "", want: "P4"},
+		{score: "N/A", want: "P4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.score, func(t *testing.T) {
+			t.Parallel()
+
+			if got := labels.Label(tt.score); got != tt.want {
+				t.Errorf("Label(%q) = %q, want %q", tt.score, got, tt.want)
+			}
+		})
+	}
+}
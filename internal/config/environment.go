@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+// interpolator replaces references to environment variables in the form of ${NAME}
+// with their values, recording the names of any variables that are not set.
+//
+// References can be escaped by doubling the dollar sign, so that "$${NAME}" is
+// replaced with "${NAME}" rather than the value of the variable.
+type interpolator struct {
+	lookup  func(string) (string, bool)
+	missing []string
+}
+
+func (in *interpolator) interpolate(s string) string {
+	return cachedregexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`).ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+
+		name := ref[2 : len(ref)-1]
+		value, ok := in.lookup(name)
+		if !ok && !slices.Contains(in.missing, name) {
+			in.missing = append(in.missing, name)
+		}
+
+		return value
+	})
+}
+
+// walk interpolates each of the strings within the given value in place
+func (in *interpolator) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			in.walk(v.Elem())
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Field(i).CanSet() {
+				in.walk(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			in.walk(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// map values are not addressable, so they are interpolated as a copy
+			value := reflect.New(iter.Value().Type()).Elem()
+			value.Set(iter.Value())
+			in.walk(value)
+			v.SetMapIndex(iter.Key(), value)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(in.interpolate(v.String()))
+		}
+	default:
+	}
+}
+
+// interpolateEnv replaces references to environment variables in each of the
+// strings within v, which must be a pointer, returning an error naming any
+// variables that are not set
+func interpolateEnv(v any, lookup func(string) (string, bool)) error {
+	in := interpolator{lookup: lookup}
+	in.walk(reflect.ValueOf(v))
+
+	if len(in.missing) > 0 {
+		// values are not walked in a stable order, as some of them are in maps
+		slices.Sort(in.missing)
+
+		return fmt.Errorf("environment variables are not set: %s", strings.Join(in.missing, ", "))
+	}
+
+	return nil
+}
+
+// decodeConfigFile decodes the config file at the given path as TOML into v,
+// interpolating any environment variables referenced by its string values if
+// withEnv is true, which should only be the case for config files that were
+// given by the user rather than found within what is being scanned
+func decodeConfigFile(configPath string, v any, withEnv bool) (toml.MetaData, error) {
+	m, err := toml.DecodeFile(configPath, v)
+	if err != nil || !withEnv {
+		return m, err
+	}
+
+	return m, interpolateEnv(v, os.LookupEnv)
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func Test_interpolateEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"GO_VERSION": "1.22.0",
+		"EMPTY":      "",
+		"QUOTED":     "\"quoted\"\n[[IgnoredVulns]]",
+	}
+
+	tests := []struct {
+		name    string
+		file    configFile
+		want    configFile
+		wantErr string
+	}{
+		{
+			name: "no references",
+			file: configFile{Config: Config{GoVersionOverride: "1.21.0"}},
+			want: configFile{Config: Config{GoVersionOverride: "1.21.0"}},
+		},
+		{
+			name: "references",
+			file: configFile{
+				Config: Config{
+					GoVersionOverride: "${GO_VERSION}",
+					IgnoredVulns:      []IgnoreEntry{{ID: "GO-2022-0968", Reason: "go ${GO_VERSION}"}},
+				},
+				Labels: map[string]string{"go": "${GO_VERSION}"},
+				Profiles: map[string]configFile{
+					"ci": {Config: Config{GoVersionOverride: "${GO_VERSION}"}},
+				},
+			},
+			want: configFile{
+				Config: Config{
+					GoVersionOverride: "1.22.0",
+					IgnoredVulns:      []IgnoreEntry{{ID: "GO-2022-0968", Reason: "go 1.22.0"}},
+				},
+				Labels: map[string]string{"go": "1.22.0"},
+				Profiles: map[string]configFile{
+					"ci": {Config: Config{GoVersionOverride: "1.22.0"}},
+				},
+			},
+		},
+		{
+			name: "empty variable",
+			file: configFile{Labels: map[string]string{"reason": "${EMPTY}"}},
+			want: configFile{Labels: map[string]string{"reason": ""}},
+		},
+		{
+			name: "values are not parsed",
+			file: configFile{Labels: map[string]string{"reason": "${QUOTED}"}},
+			want: configFile{Labels: map[string]string{"reason": "\"quoted\"\n[[IgnoredVulns]]"}},
+		},
+		{
+			name: "escaped reference",
+			file: configFile{Labels: map[string]string{"reason": "$${GO_VERSION} is ${GO_VERSION}"}},
+			want: configFile{Labels: map[string]string{"reason": "${GO_VERSION} is 1.22.0"}},
+		},
+		{
+			name: "not references",
+			file: configFile{Labels: map[string]string{"reason": "costs $5, see $GO_VERSION or ${not-a-name}"}},
+			want: configFile{Labels: map[string]string{"reason": "costs $5, see $GO_VERSION or ${not-a-name}"}},
+		},
+		{
+			name: "unset variables",
+			file: configFile{
+				Config: Config{GoVersionOverride: "${UNSET_ONE}"},
+				Labels: map[string]string{"reason": "${GO_VERSION} ${UNSET_TWO} ${UNSET_ONE}"},
+			},
+			wantErr: "environment variables are not set: UNSET_ONE, UNSET_TWO",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.file
+			err := interpolateEnv(&got, func(name string) (string, bool) {
+				value, ok := env[name]
+				return value, ok
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("interpolateEnv() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("interpolateEnv() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(configFile{}), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("interpolateEnv() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_decodeConfigFile(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "osv-scanner.toml")
+	content := "# set ${OSV_SCANNER_TEST_COMMENTED_VARIABLE} in CI\n" +
+		"GoVersionOverride = \"$${NOT_INTERPOLATED}\"\n\n" +
+		"[Labels]\n" +
+		"team = \"${OSV_SCANNER_TEST_UNSET_VARIABLE}\"\n"
+
+	if err := os.WriteFile(configPath, []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write %s: %v", configPath, err)
+	}
+
+	var withoutEnv configFile
+	if _, err := decodeConfigFile(configPath, &withoutEnv, false); err != nil {
+		t.Fatalf("decodeConfigFile() error = %v", err)
+	}
+
+	if withoutEnv.Labels["team"] != "${OSV_SCANNER_TEST_UNSET_VARIABLE}" {
+		t.Errorf("decodeConfigFile() Labels[team] = %q, want it to be left as written", withoutEnv.Labels["team"])
+	}
+
+	if withoutEnv.GoVersionOverride != "$${NOT_INTERPOLATED}" {
+		t.Errorf("decodeConfigFile() GoVersionOverride = %q, want it to be left as written", withoutEnv.GoVersionOverride)
+	}
+
+	var withEnv configFile
+	_, err := decodeConfigFile(configPath, &withEnv, true)
+
+	// the reference in the comment is not reported, as only values are interpolated
+	wantErr := "environment variables are not set: OSV_SCANNER_TEST_UNSET_VARIABLE"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("decodeConfigFile() error = %v, want %q", err, wantErr)
+	}
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/utility/severity"
+)
+
+// severityRanks orders the ratings that can be used as a threshold, from least to most severe
+var severityRanks = map[severity.Rating]int{
+	severity.LowRating:      1,
+	severity.MediumRating:   2,
+	severity.HighRating:     3,
+	severity.CriticalRating: 4,
+}
+
+// SeverityThresholds are the minimum severities that vulnerabilities must have to
+// fail the scan, which can differ between dependency groups (e.g. "dev").
+//
+// A vulnerability in a package that has no threshold always fails the scan.
+type SeverityThresholds struct {
+	// Default is the threshold for packages in no dependency group, along with packages
+	// in a group which does not have its own threshold
+	Default severity.Rating `toml:"default"`
+	// DepGroups are the thresholds for packages in each dependency group
+	DepGroups map[string]severity.Rating `toml:"depGroups"`
+}
+
+// ParseSeverityThresholds parses thresholds in the form of "RATING" to set
+// the default threshold, or "group:RATING" to set the threshold of a group
+func ParseSeverityThresholds(values []string) (SeverityThresholds, error) {
+	thresholds := SeverityThresholds{}
+
+	for _, value := range values {
+		group, rating, hasGroup := strings.Cut(value, ":")
+		if !hasGroup {
+			group, rating = "", value
+		}
+
+		parsed, err := parseSeverityRating(rating)
+		if err != nil {
+			return SeverityThresholds{}, err
+		}
+
+		if !hasGroup {
+			thresholds.Default = parsed
+			continue
+		}
+
+		if group == "" {
+			return SeverityThresholds{}, fmt.Errorf("missing dependency group in %q", value)
+		}
+
+		if thresholds.DepGroups == nil {
+			thresholds.DepGroups = make(map[string]severity.Rating)
+		}
+		thresholds.DepGroups[group] = parsed
+	}
+
+	return thresholds, nil
+}
+
+func parseSeverityRating(rating string) (severity.Rating, error) {
+	parsed := severity.Rating(strings.ToUpper(strings.TrimSpace(rating)))
+	if _, ok := severityRanks[parsed]; !ok {
+		return "", fmt.Errorf("unknown severity %q - must be one of: LOW, MEDIUM, HIGH, CRITICAL", rating)
+	}
+
+	return parsed, nil
+}
+
+func (t SeverityThresholds) validate() error {
+	if t.Default != "" {
+		if _, err := parseSeverityRating(string(t.Default)); err != nil {
+			return err
+		}
+	}
+
+	for group, rating := range t.DepGroups {
+		if _, err := parseSeverityRating(string(rating)); err != nil {
+			return fmt.Errorf("dependency group %s: %w", group, err)
+		}
+	}
+
+	return nil
+}
+
+// Merge returns the thresholds with the given thresholds merged on top of them,
+// replacing the default threshold if it is set along with the threshold of each group
+func (t SeverityThresholds) Merge(other SeverityThresholds) SeverityThresholds {
+	merged := SeverityThresholds{Default: t.Default}
+	if other.Default != "" {
+		merged.Default = other.Default
+	}
+
+	if len(t.DepGroups)+len(other.DepGroups) > 0 {
+		merged.DepGroups = make(map[string]severity.Rating, len(t.DepGroups)+len(other.DepGroups))
+		maps.Copy(merged.DepGroups, t.DepGroups)
+		maps.Copy(merged.DepGroups, other.DepGroups)
+	}
+
+	return merged
+}
+
+// threshold returns the threshold for a package in the given dependency groups,
+// which is the strictest of the thresholds of each group, or false if there is none
+func (t SeverityThresholds) threshold(depGroups []string) (severity.Rating, bool) {
+	if len(depGroups) == 0 {
+		return t.Default, t.Default != ""
+	}
+
+	var strictest severity.Rating
+	for _, group := range depGroups {
+		rating, ok := t.DepGroups[group]
+		if !ok {
+			rating = t.Default
+		}
+
+		// a group without any threshold means that the package always fails
+		if rating == "" {
+			return "", false
+		}
+
+		if strictest == "" || severityRanks[rating] < severityRanks[strictest] {
+			strictest = rating
+		}
+	}
+
+	return strictest, true
+}
+
+// ShouldFail returns if a vulnerability with the given rating in a package
+// within the given dependency groups meets the threshold to fail the scan.
+//
+// Vulnerabilities with an unknown severity only fail the scan if there is no threshold.
+func (t SeverityThresholds) ShouldFail(rating severity.Rating, depGroups []string) bool {
+	threshold, ok := t.threshold(depGroups)
+	if !ok {
+		return true
+	}
+
+	rank, known := severityRanks[rating]
+
+	return known && rank >= severityRanks[threshold]
+}
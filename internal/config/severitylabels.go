@@ -0,0 +1,70 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// SeverityLabels map the CVSS scores of vulnerabilities to custom labels, such as the
+// priorities of a ticketing system (e.g. "P0" to "P4"), which are reported alongside
+// the scores in the output
+type SeverityLabels struct {
+	// Ranges are the labels for scores of at least their minimum score, with a score
+	// being labelled by the range with the highest minimum score that it meets
+	Ranges []SeverityLabelRange `toml:"ranges"`
+	// Unknown is the label for vulnerabilities that do not have a score, which are
+	// not labelled if it is empty
+	Unknown string `toml:"unknown"`
+}
+
+// SeverityLabelRange labels the scores that are at least MinScore
+type SeverityLabelRange struct {
+	Label    string  `toml:"label"`
+	MinScore float64 `toml:"minScore"`
+}
+
+func (l SeverityLabels) validate() error {
+	for _, r := range l.Ranges {
+		if r.Label == "" {
+			return errors.New("ranges must have a label")
+		}
+
+		if r.MinScore < 0 || r.MinScore > 10 {
+			return fmt.Errorf("minScore of %s must be between 0 and 10, got %v", r.Label, r.MinScore)
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty returns whether there are no labels
+func (l SeverityLabels) IsEmpty() bool {
+	return len(l.Ranges) == 0 && l.Unknown == ""
+}
+
+// Label returns the label of the given CVSS score, which is in the form that scores
+// are reported in (e.g. "9.8"), or the label for unknown scores if it is empty or not a score.
+// No label is returned if the score does not meet the minimum score of any range.
+func (l SeverityLabels) Label(score string) string {
+	parsed, err := strconv.ParseFloat(score, 64)
+	if err != nil || parsed < 0 {
+		return l.Unknown
+	}
+
+	ranges := slices.Clone(l.Ranges)
+	// highest minimum score first
+	slices.SortStableFunc(ranges, func(a, b SeverityLabelRange) int {
+		return cmp.Compare(b.MinScore, a.MinScore)
+	})
+
+	for _, r := range ranges {
+		if parsed >= r.MinScore {
+			return r.Label
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,126 @@
+// Package cpe maps Common Platform Enumeration (CPE) names, which SBOMs use to
+// identify components such as firmware and appliances that have no package url,
+// to the packages in OSV ecosystems that the components are built from.
+package cpe
+
+import (
+	"fmt"
+	"strings"
+
+	cdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx/metadata"
+	spdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx/metadata"
+)
+
+// CPE is the parts of a CPE name that are used to map it to a package
+type CPE struct {
+	// Part is "a" for applications, "o" for operating systems, and "h" for hardware
+	Part    string
+	Vendor  string
+	Product string
+	// Version is empty if the CPE matches any version ("*") or no version ("-")
+	Version string
+}
+
+// Parse parses a CPE name that is either a CPE 2.3 formatted string (such as
+// "cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*") or a CPE 2.2 URI (such as
+// "cpe:/a:openssl:openssl:3.0.7")
+func Parse(name string) (CPE, error) {
+	var components []string
+
+	switch {
+	case strings.HasPrefix(name, "cpe:2.3:"):
+		components = splitFormattedString(strings.TrimPrefix(name, "cpe:2.3:"))
+	case strings.HasPrefix(name, "cpe:/"):
+		components = strings.Split(strings.TrimPrefix(name, "cpe:/"), ":")
+	default:
+		return CPE{}, fmt.Errorf("%q is not a CPE name", name)
+	}
+
+	for len(components) < 4 {
+		components = append(components, "")
+	}
+
+	cpe := CPE{
+		Part:    components[0],
+		Vendor:  strings.ToLower(unescape(components[1])),
+		Product: strings.ToLower(unescape(components[2])),
+		Version: unescape(components[3]),
+	}
+
+	if cpe.Vendor == "" || cpe.Product == "" || cpe.Vendor == "*" || cpe.Product == "*" {
+		return CPE{}, fmt.Errorf("%q does not name a vendor and product", name)
+	}
+
+	if cpe.Version == "*" || cpe.Version == "-" {
+		cpe.Version = ""
+	}
+
+	return cpe, nil
+}
+
+// splitFormattedString splits the components of a CPE 2.3 formatted string,
+// which are separated by colons that are not escaped with a backslash
+func splitFormattedString(s string) []string {
+	var components []string
+	var component strings.Builder
+
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			component.WriteRune(r)
+			escaped = false
+
+			continue
+		case r == '\\':
+			escaped = true
+		case r == ':':
+			components = append(components, component.String())
+			component.Reset()
+
+			continue
+		}
+
+		component.WriteRune(r)
+	}
+
+	return append(components, component.String())
+}
+
+// unescape removes the backslashes that escape the punctuation in a component
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var sb strings.Builder
+
+	escaped := false
+	for _, r := range s {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// FromSBOM returns the CPEs of a package from an SBOM, if it is only identified by
+// CPEs rather than by a package url, given the metadata of the package
+func FromSBOM(metadata any) []string {
+	switch m := metadata.(type) {
+	case *spdxmeta.Metadata:
+		if m.PURL == nil {
+			return m.CPEs
+		}
+	case *cdxmeta.Metadata:
+		if m.PURL == nil {
+			return m.CPEs
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package cpe_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx/metadata"
+	spdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx/metadata"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cpe"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		want    cpe.CPE
+		wantErr bool
+	}{
+		{
+			name: "cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*",
+			want: cpe.CPE{Part: "a", Vendor: "openssl", Product: "openssl", Version: "3.0.7"},
+		},
+		{
+			name: "cpe:2.3:o:Linux:Linux_Kernel:5.10.120:*:*:*:*:*:*:*",
+			want: cpe.CPE{Part: "o", Vendor: "linux", Product: "linux_kernel", Version: "5.10.120"},
+		},
+		{
+			name: `cpe:2.3:a:acme:router\:firmware:1.0\:2:*:*:*:*:*:*:*`,
+			want: cpe.CPE{Part: "a", Vendor: "acme", Product: "router:firmware", Version: "1.0:2"},
+		},
+		{
+			name: "cpe:2.3:a:haxx:curl:*:*:*:*:*:*:*:*",
+			want: cpe.CPE{Part: "a", Vendor: "haxx", Product: "curl"},
+		},
+		{
+			name: "cpe:2.3:a:haxx:curl:-:*:*:*:*:*:*:*",
+			want: cpe.CPE{Part: "a", Vendor: "haxx", Product: "curl"},
+		},
+		{
+			name: "cpe:/a:zlib:zlib:1.2.11",
+			want: cpe.CPE{Part: "a", Vendor: "zlib", Product: "zlib", Version: "1.2.11"},
+		},
+		{
+			name: "cpe:/a:zlib:zlib",
+			want: cpe.CPE{Part: "a", Vendor: "zlib", Product: "zlib"},
+		},
+		{
+			name:    "cpe:2.3:a:*:*:1.0:*:*:*:*:*:*:*",
+			wantErr: true,
+		},
+		{
+			name:    "cpe:/a:zlib",
+			wantErr: true,
+		},
+		{
+			name:    "pkg:npm/lodash@4.17.21",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := cpe.Parse(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFromSBOM(t *testing.T) {
+	t.Parallel()
+
+	cpes := []string{"cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*"}
+	p := &purl.PackageURL{Type: purl.TypeNPM, Name: "lodash", Version: "4.17.21"}
+
+	tests := []struct {
+		name     string
+		metadata any
+		want     []string
+	}{
+		{
+			name:     "spdx_without_purl",
+			metadata: &spdxmeta.Metadata{CPEs: cpes},
+			want:     cpes,
+		},
+		{
+			name:     "cdx_without_purl",
+			metadata: &cdxmeta.Metadata{CPEs: cpes},
+			want:     cpes,
+		},
+		{
+			name:     "spdx_with_purl",
+			metadata: &spdxmeta.Metadata{PURL: p, CPEs: cpes},
+			want:     nil,
+		},
+		{
+			name:     "cdx_with_purl",
+			metadata: &cdxmeta.Metadata{PURL: p, CPEs: cpes},
+			want:     nil,
+		},
+		{
+			name:     "not_an_sbom",
+			metadata: nil,
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, cpe.FromSBOM(tt.metadata)); diff != "" {
+				t.Errorf("FromSBOM() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBundled(t *testing.T) {
+	t.Parallel()
+
+	mappings, err := cpe.Bundled()
+	if err != nil {
+		t.Fatalf("Bundled() error = %v", err)
+	}
+
+	if len(mappings) == 0 {
+		t.Fatalf("Bundled() has no mappings")
+	}
+
+	for _, mapping := range mappings {
+		if err := mapping.Validate(); err != nil {
+			t.Errorf("Bundled() has an invalid mapping: %v", err)
+		}
+	}
+}
+
+func TestMapping_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mapping cpe.Mapping
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			mapping: cpe.Mapping{Vendor: "acme", Product: "router_firmware", Ecosystem: "ConanCenter", Name: "openssl"},
+		},
+		{
+			name:    "missing_product",
+			mapping: cpe.Mapping{Vendor: "acme", Ecosystem: "ConanCenter", Name: "openssl"},
+			wantErr: true,
+		},
+		{
+			name:    "missing_name",
+			mapping: cpe.Mapping{Vendor: "acme", Product: "router_firmware", Ecosystem: "ConanCenter"},
+			wantErr: true,
+		},
+		{
+			name:    "missing_ecosystem",
+			mapping: cpe.Mapping{Vendor: "acme", Product: "router_firmware", Name: "openssl"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_ecosystem",
+			mapping: cpe.Mapping{Vendor: "acme", Product: "router_firmware", Ecosystem: "npm:1", Name: "openssl"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.mapping.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	bundled := []cpe.Mapping{
+		{Vendor: "openssl", Product: "openssl", Ecosystem: "ConanCenter", Name: "openssl"},
+		{Vendor: "linux", Product: "linux_kernel", Ecosystem: "Linux", Name: "Kernel"},
+	}
+
+	tests := []struct {
+		name     string
+		cpes     []string
+		mappings []cpe.Mapping
+		want     cpe.Package
+		wantOk   bool
+	}{
+		{
+			name: "bundled",
+			cpes: []string{"cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*"},
+			want: cpe.Package{
+				Name:    "openssl",
+				Version: "3.0.7",
+				Metadata: &cpe.Metadata{
+					CPE:       "cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*",
+					Ecosystem: "ConanCenter",
+				},
+			},
+			wantOk: true,
+		},
+		{
+			name: "first_mapped_cpe",
+			cpes: []string{
+				"cpe:2.3:h:acme:router:-:*:*:*:*:*:*:*",
+				"cpe:2.3:o:linux:linux_kernel:5.10.120:*:*:*:*:*:*:*",
+				"cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*",
+			},
+			want: cpe.Package{
+				Name:    "Kernel",
+				Version: "5.10.120",
+				Metadata: &cpe.Metadata{
+					CPE:       "cpe:2.3:o:linux:linux_kernel:5.10.120:*:*:*:*:*:*:*",
+					Ecosystem: "Linux",
+				},
+			},
+			wantOk: true,
+		},
+		{
+			name: "config_takes_precedence",
+			cpes: []string{"cpe:/a:OpenSSL:OpenSSL:1.1.1t"},
+			mappings: []cpe.Mapping{
+				{Vendor: "openssl", Product: "openssl", Ecosystem: "Alpine", Name: "openssl"},
+			},
+			want: cpe.Package{
+				Name:    "openssl",
+				Version: "1.1.1t",
+				Metadata: &cpe.Metadata{
+					CPE:       "cpe:/a:OpenSSL:OpenSSL:1.1.1t",
+					Ecosystem: "Alpine",
+				},
+			},
+			wantOk: true,
+		},
+		{
+			name:   "no_mapping",
+			cpes:   []string{"cpe:2.3:h:acme:router:-:*:*:*:*:*:*:*", "not-a-cpe"},
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := cpe.Map(tt.cpes, tt.mappings, bundled)
+			if ok != tt.wantOk {
+				t.Errorf("Map() ok = %v, want %v", ok, tt.wantOk)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Map() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
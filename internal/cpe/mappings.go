@@ -0,0 +1,104 @@
+package cpe
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scanner/v2/internal/imodels/ecosystem"
+)
+
+// Mapping maps a product of a vendor, as named by CPEs, to the package in an OSV ecosystem that it is
+type Mapping struct {
+	Vendor    string `toml:"vendor"`
+	Product   string `toml:"product"`
+	Ecosystem string `toml:"ecosystem"`
+	Name      string `toml:"name"`
+}
+
+// Validate checks that the mapping is for a vendor and product, and to a package in a valid ecosystem
+func (m Mapping) Validate() error {
+	if m.Vendor == "" || m.Product == "" {
+		return errors.New("vendor and product must be set")
+	}
+
+	if m.Name == "" {
+		return fmt.Errorf("name of the package that %s:%s maps to must be set", m.Vendor, m.Product)
+	}
+
+	if m.Ecosystem == "" {
+		return fmt.Errorf("ecosystem of the package that %s:%s maps to must be set", m.Vendor, m.Product)
+	}
+
+	if _, err := ecosystem.Parse(m.Ecosystem); err != nil {
+		return fmt.Errorf("%s:%s maps to an invalid ecosystem: %w", m.Vendor, m.Product, err)
+	}
+
+	return nil
+}
+
+func (m Mapping) matches(cpe CPE) bool {
+	return strings.EqualFold(m.Vendor, cpe.Vendor) && strings.EqualFold(m.Product, cpe.Product)
+}
+
+// bundledMappings are the curated mappings that are bundled with osv-scanner
+//
+//go:embed mappings.toml
+var bundledMappings []byte
+
+// Bundled returns the curated mappings that are bundled with osv-scanner
+func Bundled() ([]Mapping, error) {
+	var file struct {
+		CPEMappings []Mapping `toml:"CPEMappings"`
+	}
+
+	if _, err := toml.Decode(string(bundledMappings), &file); err != nil {
+		return nil, fmt.Errorf("failed to parse bundled cpe mappings: %w", err)
+	}
+
+	return file.CPEMappings, nil
+}
+
+// Metadata is the metadata of a package from an SBOM that was identified by a CPE,
+// which has been mapped to a package in an OSV ecosystem
+type Metadata struct {
+	CPE       string
+	Ecosystem string
+}
+
+// Package is the package in an OSV ecosystem that a CPE was mapped to
+type Package struct {
+	Name    string
+	Version string
+	// Metadata is the metadata to give the package, which replaces that of the SBOM
+	Metadata *Metadata
+}
+
+// Map returns the package that the first of the CPEs that has a mapping is for, with
+// the given mappings taking precedence over the bundled ones so that they can be overridden
+func Map(cpes []string, mappings []Mapping, bundled []Mapping) (Package, bool) {
+	for _, name := range cpes {
+		cpe, err := Parse(name)
+		if err != nil {
+			continue
+		}
+
+		for _, ms := range [][]Mapping{mappings, bundled} {
+			for _, m := range ms {
+				if !m.matches(cpe) {
+					continue
+				}
+
+				return Package{
+					Name:     m.Name,
+					Version:  cpe.Version,
+					Metadata: &Metadata{CPE: name, Ecosystem: m.Ecosystem},
+				}, true
+			}
+		}
+	}
+
+	return Package{}, false
+}
@@ -36,7 +36,7 @@ func readIgnoreFile(fs billy.Filesystem, path []string, ignoreFile string) (ps [
 		return nil, err
 	}
 
-	return
+	return ps, nil
 }
 
 // ReadPatterns reads the .git/info/exclude and then the gitignore patterns
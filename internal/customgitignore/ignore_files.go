@@ -0,0 +1,99 @@
+package customgitignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// ScannerIgnoreFile is the name of the files that list paths for osv-scanner to skip,
+// using the same syntax as .gitignore files but regardless of whether they are in a git repo
+const ScannerIgnoreFile = ".osv-scanner-ignore"
+
+// IgnoreFiles are the patterns of the ignore files with a particular name within a directory
+type IgnoreFiles struct {
+	// Dir is the directory that the ignore files were read from
+	Dir string
+	// Patterns are the patterns of the ignore files, relative to Dir
+	Patterns []gitignore.Pattern
+	// IgnoredDirs are the absolute paths of the subdirectories that are ignored by the patterns,
+	// which were not searched for further ignore files
+	IgnoredDirs []string
+}
+
+// Match returns whether the given absolute path is ignored, which is never
+// the case for paths outside of the directory that the ignore files were read from
+func (i IgnoreFiles) Match(path string, isDir bool) bool {
+	rel, err := filepath.Rel(i.Dir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+
+	return gitignore.NewMatcher(i.Patterns).Match(toGoGitPath(rel), isDir)
+}
+
+// ParseIgnoreFiles reads the ignore files with the given name in dir, and in its
+// subdirectories if recursive, skipping any subdirectories that are ignored by
+// the ignore files that have been read so far.
+func ParseIgnoreFiles(dir string, ignoreFile string, recursive bool) (IgnoreFiles, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return IgnoreFiles{}, err
+	}
+
+	result := IgnoreFiles{Dir: dir}
+	fs := osfs.New(dir)
+
+	if !recursive {
+		result.Patterns, err = readIgnoreFile(fs, []string{"."}, ignoreFile)
+
+		return result, err
+	}
+
+	err = readIgnoreFilesRecursively(fs, []string{"."}, ignoreFile, &result)
+
+	return result, err
+}
+
+func readIgnoreFilesRecursively(fs billy.Filesystem, path []string, ignoreFile string, result *IgnoreFiles) error {
+	ps, err := readIgnoreFile(fs, path, ignoreFile)
+	if err != nil {
+		return err
+	}
+	result.Patterns = append(result.Patterns, ps...)
+
+	fis, err := fs.ReadDir(fs.Join(path...))
+	if err != nil {
+		if os.IsPermission(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	matcher := gitignore.NewMatcher(result.Patterns)
+
+	for _, fi := range fis {
+		if !fi.IsDir() || fi.Name() == gitDir {
+			continue
+		}
+
+		childPath := append(append([]string{}, path...), fi.Name())
+
+		if matcher.Match(childPath, true) {
+			result.IgnoredDirs = append(result.IgnoredDirs, filepath.Join(append([]string{result.Dir}, childPath...)...))
+
+			continue
+		}
+
+		if err := readIgnoreFilesRecursively(fs, childPath, ignoreFile, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package customgitignore_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/customgitignore"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseIgnoreFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, customgitignore.ScannerIgnoreFile), "# build output\nbuild/\n*.bak\n")
+	writeFile(t, filepath.Join(dir, "app", customgitignore.ScannerIgnoreFile), "fixtures/\n")
+	writeFile(t, filepath.Join(dir, "app", "fixtures", "package-lock.json"), "{}")
+	writeFile(t, filepath.Join(dir, "app", "package-lock.json"), "{}")
+	// ignore files within ignored directories are never read
+	writeFile(t, filepath.Join(dir, "build", customgitignore.ScannerIgnoreFile), "*.json\n")
+	writeFile(t, filepath.Join(dir, "build", "package-lock.json"), "{}")
+
+	tests := []struct {
+		name        string
+		recursive   bool
+		path        string
+		isDir       bool
+		wantIgnored bool
+	}{
+		{name: "ignored_dir", recursive: true, path: "build", isDir: true, wantIgnored: true},
+		{name: "file_in_ignored_dir", recursive: true, path: "build/package-lock.json", wantIgnored: true},
+		{name: "ignored_file", recursive: true, path: "app/package-lock.json.bak", wantIgnored: true},
+		{name: "nested_ignored_dir", recursive: true, path: "app/fixtures", isDir: true, wantIgnored: true},
+		{name: "not_ignored", recursive: true, path: "app/package-lock.json", wantIgnored: false},
+		{name: "nested_not_read", recursive: false, path: "app/fixtures", isDir: true, wantIgnored: false},
+		{name: "root_read", recursive: false, path: "build", isDir: true, wantIgnored: true},
+		{name: "outside_dir", recursive: true, path: "../build", isDir: true, wantIgnored: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ignores, err := customgitignore.ParseIgnoreFiles(dir, customgitignore.ScannerIgnoreFile, tt.recursive)
+			if err != nil {
+				t.Fatalf("ParseIgnoreFiles() error = %v", err)
+			}
+
+			if got := ignores.Match(filepath.Join(dir, filepath.FromSlash(tt.path)), tt.isDir); got != tt.wantIgnored {
+				t.Errorf("Match(%q) = %v, want %v", tt.path, got, tt.wantIgnored)
+			}
+		})
+	}
+
+	ignores, err := customgitignore.ParseIgnoreFiles(dir, customgitignore.ScannerIgnoreFile, true)
+	if err != nil {
+		t.Fatalf("ParseIgnoreFiles() error = %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "app", "fixtures"), filepath.Join(dir, "build")}
+	if diff := cmp.Diff(want, ignores.IgnoredDirs); diff != "" {
+		t.Errorf("ParseIgnoreFiles() ignored dirs mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -27,6 +27,8 @@ type MavenRegistryAPIClient struct {
 	defaultRegistry MavenRegistry                  // The default registry that we are making requests
 	registries      []MavenRegistry                // Additional registries specified to fetch projects
 	registryAuths   map[string]*HTTPAuthentication // Authentication for the registries keyed by registry ID. From settings.xml
+	netrc           NetrcAuths                     // Authentication for registries without any in settings.xml, keyed by host. From .netrc
+	settings        MavenSettingsXML               // Mirrors that requests to registries are redirected to. From settings.xml
 
 	// Cache fields
 	mu             *sync.Mutex
@@ -54,25 +56,43 @@ func NewMavenRegistryAPIClient(registry MavenRegistry) (*MavenRegistryAPIClient,
 		registry.URL = MavenCentral
 		registry.ID = "central"
 	}
+
+	// TODO: allow for manual specification of settings files
+	globalSettings := ParseMavenSettings(globalMavenSettingsFile())
+	userSettings := ParseMavenSettings(userMavenSettingsFile())
+	settings := MergeMavenSettings(globalSettings, userSettings)
+
+	registry, _ = mirrorRegistry(settings, registry)
 	u, err := url.Parse(registry.URL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid Maven registry %s: %w", registry.URL, err)
 	}
 	registry.Parsed = u
 
-	// TODO: allow for manual specification of settings files
-	globalSettings := ParseMavenSettings(globalMavenSettingsFile())
-	userSettings := ParseMavenSettings(userMavenSettingsFile())
-
 	return &MavenRegistryAPIClient{
 		// We assume only downloading releases is allowed on the default registry.
 		defaultRegistry: registry,
 		mu:              &sync.Mutex{},
 		responses:       NewRequestCache[string, response](),
 		registryAuths:   MakeMavenAuth(globalSettings, userSettings),
+		netrc:           LoadNetrc(),
+		settings:        settings,
 	}, nil
 }
 
+// mirrorRegistry redirects the registry to the matching mirror in settings.xml, if any.
+// The mirror ID is used so that the credentials of the mirror are used.
+func mirrorRegistry(settings MavenSettingsXML, registry MavenRegistry) (MavenRegistry, bool) {
+	mirror, ok := settings.FindMirror(registry.ID, registry.URL)
+	if !ok {
+		return registry, false
+	}
+	registry.ID = mirror.ID
+	registry.URL = mirror.URL
+
+	return registry, true
+}
+
 // WithoutRegistries makes MavenRegistryAPIClient including its cache but not registries.
 func (m *MavenRegistryAPIClient) WithoutRegistries() *MavenRegistryAPIClient {
 	return &MavenRegistryAPIClient{
@@ -80,11 +100,23 @@ func (m *MavenRegistryAPIClient) WithoutRegistries() *MavenRegistryAPIClient {
 		mu:              m.mu,
 		cacheTimestamp:  m.cacheTimestamp,
 		responses:       m.responses,
+		registryAuths:   m.registryAuths,
+		netrc:           m.netrc,
+		settings:        m.settings,
 	}
 }
 
 // AddRegistry adds the given registry to the list of registries if it has not been added.
+// Registries matched by a mirror in settings.xml are replaced with that mirror.
 func (m *MavenRegistryAPIClient) AddRegistry(registry MavenRegistry) error {
+	if mirrored, ok := mirrorRegistry(m.settings, registry); ok {
+		if mirrored.ID == m.defaultRegistry.ID {
+			// The default registry is already using this mirror.
+			return nil
+		}
+		registry = mirrored
+	}
+
 	for _, reg := range m.registries {
 		if reg.ID == registry.ID {
 			return nil
@@ -179,7 +211,7 @@ func (m *MavenRegistryAPIClient) getProject(ctx context.Context, registry MavenR
 	u := registry.Parsed.JoinPath(strings.ReplaceAll(groupID, ".", "/"), artifactID, version, fmt.Sprintf("%s-%s.pom", artifactID, snapshot)).String()
 
 	var project maven.Project
-	if err := m.get(ctx, m.registryAuths[registry.ID], u, &project); err != nil {
+	if err := m.get(ctx, m.auth(registry), u, &project); err != nil {
 		return maven.Project{}, err
 	}
 
@@ -191,7 +223,7 @@ func (m *MavenRegistryAPIClient) getVersionMetadata(ctx context.Context, registr
 	u := registry.Parsed.JoinPath(strings.ReplaceAll(groupID, ".", "/"), artifactID, version, "maven-metadata.xml").String()
 
 	var metadata maven.Metadata
-	if err := m.get(ctx, m.registryAuths[registry.ID], u, &metadata); err != nil {
+	if err := m.get(ctx, m.auth(registry), u, &metadata); err != nil {
 		return maven.Metadata{}, err
 	}
 
@@ -203,13 +235,23 @@ func (m *MavenRegistryAPIClient) getArtifactMetadata(ctx context.Context, regist
 	u := registry.Parsed.JoinPath(strings.ReplaceAll(groupID, ".", "/"), artifactID, "maven-metadata.xml").String()
 
 	var metadata maven.Metadata
-	if err := m.get(ctx, m.registryAuths[registry.ID], u, &metadata); err != nil {
+	if err := m.get(ctx, m.auth(registry), u, &metadata); err != nil {
 		return maven.Metadata{}, err
 	}
 
 	return metadata, nil
 }
 
+// auth returns the authentication for the registry from settings.xml,
+// falling back to the credentials for its host in .netrc.
+func (m *MavenRegistryAPIClient) auth(registry MavenRegistry) *HTTPAuthentication {
+	if auth, ok := m.registryAuths[registry.ID]; ok {
+		return auth
+	}
+
+	return m.netrc.GetAuth(registry.URL)
+}
+
 func (m *MavenRegistryAPIClient) get(ctx context.Context, auth *HTTPAuthentication, url string, dst any) error {
 	resp, err := m.responses.Get(url, func() (response, error) {
 		resp, err := auth.Get(ctx, http.DefaultClient, url)
@@ -299,3 +299,25 @@ func TestMultipleRegistry(t *testing.T) {
 		t.Errorf("GetVersions(%s, %s):\ngot %v\nwant %v\n", "org.example", "x.y.z", gotVersions, wantVersions)
 	}
 }
+
+func TestMavenRegistryAPIClient_NetrcAuth(t *testing.T) {
+	t.Parallel()
+
+	srv := testutility.NewMockHTTPServer(t)
+	srv.SetAuthorization(t, "Basic dXNlcjpzZWNyZXQ=")
+	srv.SetResponse(t, "org/example/x.y.z/1.0.0/x.y.z-1.0.0.pom", []byte(`
+	<project>
+	  <groupId>org.example</groupId>
+	  <artifactId>x.y.z</artifactId>
+	  <version>1.0.0</version>
+	</project>
+	`))
+
+	client, _ := NewMavenRegistryAPIClient(MavenRegistry{URL: srv.URL, ReleasesEnabled: true})
+	u, _ := url.Parse(srv.URL)
+	client.netrc = ParseNetrc("machine " + u.Hostname() + " login user password secret")
+
+	if _, err := client.GetProject(t.Context(), "org.example", "x.y.z", "1.0.0"); err != nil {
+		t.Fatalf("failed to get Maven project with .netrc credentials: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package datasource
 
 import (
 	"encoding/xml"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -16,7 +17,10 @@ import (
 // https://maven.apache.org/settings.html
 
 type MavenSettingsXML struct {
-	Servers []MavenSettingsXMLServer `xml:"servers>server"`
+	// LocalRepository is the path to the local repository that artifacts are downloaded to
+	LocalRepository string                   `xml:"localRepository"`
+	Servers         []MavenSettingsXMLServer `xml:"servers>server"`
+	Mirrors         []MavenSettingsXMLMirror `xml:"mirrors>mirror"`
 }
 
 type MavenSettingsXMLServer struct {
@@ -25,16 +29,37 @@ type MavenSettingsXMLServer struct {
 	Password string `xml:"password"`
 }
 
+// MavenSettingsXMLMirror is a mirror that requests for the repositories
+// matched by MirrorOf are redirected to.
+// https://maven.apache.org/guides/mini/guide-mirror-settings.html
+type MavenSettingsXMLMirror struct {
+	ID       string `xml:"id"`
+	URL      string `xml:"url"`
+	MirrorOf string `xml:"mirrorOf"`
+}
+
+// ParseMavenSettings parses the settings.xml at the given path,
+// returning empty settings if the file cannot be read.
 func ParseMavenSettings(path string) MavenSettingsXML {
-	f, err := os.Open(path)
+	settings, err := LoadMavenSettings(path)
 	if err != nil {
 		return MavenSettingsXML{}
 	}
+
+	return settings
+}
+
+// LoadMavenSettings parses the settings.xml at the given path.
+func LoadMavenSettings(path string) (MavenSettingsXML, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return MavenSettingsXML{}, err
+	}
 	defer f.Close()
 
 	var settings MavenSettingsXML
 	if err := xml.NewDecoder(f).Decode(&settings); err != nil {
-		return MavenSettingsXML{}
+		return MavenSettingsXML{}, err
 	}
 
 	// interpolate strings with environment variables only
@@ -57,13 +82,131 @@ func ParseMavenSettings(path string) MavenSettingsXML {
 		// Don't do any replacement if the environment variable isn't set
 		return match
 	}
+	settings.LocalRepository = re.ReplaceAllStringFunc(strings.TrimSpace(settings.LocalRepository), replFn)
 	for i := range settings.Servers {
 		settings.Servers[i].ID = re.ReplaceAllStringFunc(settings.Servers[i].ID, replFn)
 		settings.Servers[i].Username = re.ReplaceAllStringFunc(settings.Servers[i].Username, replFn)
 		settings.Servers[i].Password = re.ReplaceAllStringFunc(settings.Servers[i].Password, replFn)
 	}
+	for i := range settings.Mirrors {
+		settings.Mirrors[i].ID = re.ReplaceAllStringFunc(settings.Mirrors[i].ID, replFn)
+		settings.Mirrors[i].URL = re.ReplaceAllStringFunc(settings.Mirrors[i].URL, replFn)
+	}
 
-	return settings
+	return settings, nil
+}
+
+// DefaultMavenSettings returns the global and user settings.xml merged
+// together, with the user settings taking precedence.
+func DefaultMavenSettings() MavenSettingsXML {
+	return MergeMavenSettings(
+		ParseMavenSettings(globalMavenSettingsFile()),
+		ParseMavenSettings(userMavenSettingsFile()),
+	)
+}
+
+// MergeMavenSettings merges the global and user settings.xml together,
+// with the user settings taking precedence.
+func MergeMavenSettings(globalSettings, userSettings MavenSettingsXML) MavenSettingsXML {
+	localRepository := userSettings.LocalRepository
+	if localRepository == "" {
+		localRepository = globalSettings.LocalRepository
+	}
+
+	return MavenSettingsXML{
+		LocalRepository: localRepository,
+		Servers:         append(globalSettings.Servers, userSettings.Servers...),
+		// Maven uses the first matching mirror, so user mirrors go first
+		Mirrors: append(userSettings.Mirrors, globalSettings.Mirrors...),
+	}
+}
+
+// LocalRepositoryPath returns the path to the local repository, which is
+// ${user.home}/.m2/repository unless it is set by the settings
+func (s MavenSettingsXML) LocalRepositoryPath() string {
+	if s.LocalRepository != "" {
+		return s.LocalRepository
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".m2", "repository")
+}
+
+// FindMirror returns the first mirror whose mirrorOf matches the repository
+// with the given ID and URL.
+func (s MavenSettingsXML) FindMirror(repoID, repoURL string) (MavenSettingsXMLMirror, bool) {
+	for _, m := range s.Mirrors {
+		if mirrorOfMatches(m.MirrorOf, repoID, repoURL) {
+			return m, true
+		}
+	}
+
+	return MavenSettingsXMLMirror{}, false
+}
+
+// FindServer returns the server with the given ID, where the last
+// matching server wins as it does in MakeMavenAuth.
+func (s MavenSettingsXML) FindServer(id string) (MavenSettingsXMLServer, bool) {
+	for i := len(s.Servers) - 1; i >= 0; i-- {
+		if s.Servers[i].ID == id {
+			return s.Servers[i], true
+		}
+	}
+
+	return MavenSettingsXMLServer{}, false
+}
+
+// mirrorOfMatches reports whether a mirrorOf pattern matches a repository.
+// Patterns are a comma-separated list of repository IDs, "*", "external:*",
+// "external:http:*" or exclusions of the form "!id".
+func mirrorOfMatches(mirrorOf, repoID, repoURL string) bool {
+	if mirrorOf == repoID {
+		return true
+	}
+
+	matched := false
+	for _, pattern := range strings.Split(mirrorOf, ",") {
+		pattern = strings.TrimSpace(pattern)
+		switch {
+		case pattern == "":
+			continue
+		case strings.HasPrefix(pattern, "!"):
+			if pattern[1:] == repoID {
+				return false
+			}
+		case pattern == "*" || pattern == repoID:
+			matched = true
+		case pattern == "external:*":
+			if isExternalRepository(repoURL) {
+				matched = true
+			}
+		case pattern == "external:http:*":
+			if isExternalRepository(repoURL) && strings.HasPrefix(repoURL, "http:") {
+				matched = true
+			}
+		}
+	}
+
+	return matched
+}
+
+// isExternalRepository reports whether the repository is not on localhost
+// and is not file based.
+func isExternalRepository(repoURL string) bool {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "file" {
+		return false
+	}
+	host := u.Hostname()
+
+	return host != "localhost" && host != "127.0.0.1"
 }
 
 // TODO: How to use with virtual filesystem + environment variables.
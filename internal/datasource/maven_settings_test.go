@@ -1,6 +1,8 @@
 package datasource_test
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -114,3 +116,106 @@ func TestMakeMavenAuth(t *testing.T) {
 		t.Errorf("MakeMavenAuth() (-want +got):\n%s", diff)
 	}
 }
+
+func TestParseMavenSettings_Mirrors(t *testing.T) {
+	t.Setenv("MAVEN_SETTINGS_TEST_PWD", "P455W0RD")
+	t.Setenv("MAVEN_SETTINGS_TEST_HOST", "maven.example.com")
+	t.Setenv("MAVEN_SETTINGS_TEST_USER", "user")
+	want := datasource.MavenSettingsXML{
+		LocalRepository: "/home/user/.m2/mirrored",
+		Servers: []datasource.MavenSettingsXMLServer{
+			{
+				ID:       "internal-mirror",
+				Username: "mirror-user",
+				Password: "P455W0RD",
+			},
+		},
+		Mirrors: []datasource.MavenSettingsXMLMirror{
+			{
+				ID:       "snapshots-mirror",
+				URL:      "https://snapshots.example.com/maven2",
+				MirrorOf: "snapshots",
+			},
+			{
+				ID:       "internal-mirror",
+				URL:      "https://maven.example.com/maven2",
+				MirrorOf: "external:*,!skipped",
+			},
+		},
+	}
+
+	got := datasource.ParseMavenSettings("./fixtures/maven_settings/mirrors.xml")
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseMavenSettings() (-want +got):\n%s", diff)
+	}
+}
+
+func TestMergeMavenSettings_LocalRepository(t *testing.T) {
+	t.Parallel()
+
+	global := datasource.MavenSettingsXML{LocalRepository: "/opt/maven/repository"}
+	user := datasource.MavenSettingsXML{LocalRepository: "/home/user/.m2/custom"}
+
+	if got := datasource.MergeMavenSettings(global, user).LocalRepositoryPath(); got != "/home/user/.m2/custom" {
+		t.Errorf("LocalRepositoryPath() = %q, want the user local repository", got)
+	}
+
+	if got := datasource.MergeMavenSettings(global, datasource.MavenSettingsXML{}).LocalRepositoryPath(); got != "/opt/maven/repository" {
+		t.Errorf("LocalRepositoryPath() = %q, want the global local repository", got)
+	}
+
+	if got := (datasource.MavenSettingsXML{}).LocalRepositoryPath(); !strings.HasSuffix(got, filepath.Join(".m2", "repository")) {
+		t.Errorf("LocalRepositoryPath() = %q, want the default local repository", got)
+	}
+}
+
+func TestLoadMavenSettings_NotExist(t *testing.T) {
+	t.Parallel()
+
+	_, err := datasource.LoadMavenSettings("./fixtures/maven_settings/does-not-exist.xml")
+	if err == nil {
+		t.Errorf("LoadMavenSettings() did not return an error")
+	}
+}
+
+func TestMavenSettingsXML_FindMirror(t *testing.T) {
+	t.Parallel()
+
+	settings := datasource.MavenSettingsXML{
+		Mirrors: []datasource.MavenSettingsXMLMirror{
+			{ID: "exact", URL: "https://exact.example.com", MirrorOf: "central"},
+			{ID: "list", URL: "https://list.example.com", MirrorOf: "repo1, repo2"},
+			{ID: "external", URL: "https://external.example.com", MirrorOf: "external:*,!excluded"},
+			{ID: "everything", URL: "https://everything.example.com", MirrorOf: "*"},
+		},
+	}
+
+	tests := []struct {
+		repoID  string
+		repoURL string
+		want    string
+	}{
+		{repoID: "central", repoURL: datasource.MavenCentral, want: "exact"},
+		{repoID: "repo2", repoURL: "https://repo2.example.com", want: "list"},
+		{repoID: "other", repoURL: "https://other.example.com", want: "external"},
+		{repoID: "excluded", repoURL: "https://excluded.example.com", want: "everything"},
+		{repoID: "local", repoURL: "http://localhost:8080/maven2", want: "everything"},
+		{repoID: "file", repoURL: "file:///home/user/.m2/repository", want: "everything"},
+	}
+
+	for _, tt := range tests {
+		got, ok := settings.FindMirror(tt.repoID, tt.repoURL)
+		if !ok {
+			t.Errorf("FindMirror(%q, %q) found no mirror, want %q", tt.repoID, tt.repoURL, tt.want)
+			continue
+		}
+		if got.ID != tt.want {
+			t.Errorf("FindMirror(%q, %q) = %q, want %q", tt.repoID, tt.repoURL, got.ID, tt.want)
+		}
+	}
+
+	if _, ok := (datasource.MavenSettingsXML{}).FindMirror("central", datasource.MavenCentral); ok {
+		t.Errorf("FindMirror() found a mirror with no mirrors configured")
+	}
+}
@@ -0,0 +1,118 @@
+package datasource
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// NetrcAuths holds the credentials of the machines in a .netrc file, keyed by their host name.
+// The credentials are only used for registries that do not have any configured
+// in the ecosystem's own configuration (e.g. .npmrc or settings.xml).
+type NetrcAuths map[string]*HTTPAuthentication
+
+var netrcSupportedAuths = []HTTPAuthMethod{AuthBasic}
+
+// GetAuth returns the credentials for the host of uri, or nil if there are none.
+func (auths NetrcAuths) GetAuth(uri string) *HTTPAuthentication {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil
+	}
+
+	return auths[parsed.Hostname()]
+}
+
+// LoadNetrc loads the .netrc file specified by the NETRC environment variable,
+// or the one in the user's home directory.
+// Missing or unreadable files are treated as having no credentials.
+func LoadNetrc() NetrcAuths {
+	path := os.Getenv("NETRC")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return NetrcAuths{}
+		}
+		name := ".netrc"
+		if runtime.GOOS == "windows" {
+			name = "_netrc"
+		}
+		path = filepath.Join(home, name)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return NetrcAuths{}
+	}
+
+	return ParseNetrc(string(b))
+}
+
+// ParseNetrc parses the contents of a .netrc file.
+// https://www.gnu.org/software/inetutils/manual/html_node/The-_002enetrc-file.html
+//
+// Like the go command, the "default" entry is ignored, as is everything after it,
+// so that credentials are never sent to a host that was not explicitly listed.
+func ParseNetrc(data string) NetrcAuths {
+	auths := make(NetrcAuths)
+
+	var machine, login, password string
+	addMachine := func() {
+		if machine != "" && login != "" && password != "" {
+			auths[machine] = &HTTPAuthentication{
+				SupportedMethods: netrcSupportedAuths,
+				AlwaysAuth:       true,
+				Username:         login,
+				Password:         password,
+			}
+		}
+		machine, login, password = "", "", ""
+	}
+
+	inMacro := false
+	for _, line := range strings.Split(data, "\n") {
+		if inMacro {
+			// macro definitions end with an empty line
+			if strings.TrimSpace(line) == "" {
+				inMacro = false
+			}
+
+			continue
+		}
+
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			switch fields[i] {
+			case "machine":
+				addMachine()
+				if i+1 < len(fields) {
+					i++
+					machine = fields[i]
+				}
+			case "login":
+				if i+1 < len(fields) {
+					i++
+					login = fields[i]
+				}
+			case "password":
+				if i+1 < len(fields) {
+					i++
+					password = fields[i]
+				}
+			case "account":
+				i++
+			case "macdef":
+				inMacro = true
+				i = len(fields)
+			case "default":
+				addMachine()
+				return auths
+			}
+		}
+	}
+	addMachine()
+
+	return auths
+}
@@ -0,0 +1,109 @@
+package datasource_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/datasource"
+)
+
+func TestParseNetrc(t *testing.T) {
+	t.Parallel()
+
+	netrc := datasource.ParseNetrc(`
+machine registry.example.com
+  login user
+  password secret
+
+machine maven.example.com login deploy account ci password token
+
+macdef init
+machine ignored.example.com login nobody password nothing
+
+machine incomplete.example.com login someone
+
+default login anonymous password guest
+machine after-default.example.com login user password secret
+`)
+
+	type credentials struct {
+		Username string
+		Password string
+	}
+	got := make(map[string]credentials)
+	for host, auth := range netrc {
+		got[host] = credentials{Username: auth.Username, Password: auth.Password}
+	}
+
+	want := map[string]credentials{
+		"registry.example.com": {Username: "user", Password: "secret"},
+		"maven.example.com":    {Username: "deploy", Password: "token"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseNetrc() (-want +got):\n%s", diff)
+	}
+}
+
+func TestNetrcAuths_GetAuth(t *testing.T) {
+	t.Parallel()
+
+	netrc := datasource.ParseNetrc("machine registry.example.com login user password secret")
+
+	if auth := netrc.GetAuth("https://registry.example.com:8443/some/path"); auth == nil || auth.Username != "user" {
+		t.Errorf("GetAuth() did not return the credentials of the host, got %v", auth)
+	}
+	if auth := netrc.GetAuth("https://other.example.com/some/path"); auth != nil {
+		t.Errorf("GetAuth() returned credentials for another host: %v", auth)
+	}
+}
+
+func TestNpmRegistryConfig_NetrcFallback(t *testing.T) {
+	t.Parallel()
+
+	config := datasource.ParseNpmRegistryInfo(datasource.NpmrcConfig{
+		"registry":                         "https://registry.example.com/",
+		"@scope:registry":                  "https://scoped.example.com/",
+		"//scoped.example.com/:_authToken": "npmrc-token",
+	})
+	config.Netrc = datasource.ParseNetrc(
+		"machine registry.example.com login user password secret\n" +
+			"machine scoped.example.com login user password secret\n",
+	)
+
+	tests := []struct {
+		name     string
+		pkg      string
+		wantAuth string
+	}{
+		{
+			name:     "netrc",
+			pkg:      "foo",
+			wantAuth: "Basic dXNlcjpzZWNyZXQ=",
+		},
+		{
+			name:     "npmrc_preferred",
+			pkg:      "@scope/foo",
+			wantAuth: "Bearer npmrc-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mt := &mockTransport{}
+			httpClient := &http.Client{Transport: mt}
+			resp, err := config.MakeRequest(t.Context(), httpClient, tt.pkg)
+			if err != nil {
+				t.Fatalf("error making request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if len(mt.Requests) != 1 {
+				t.Fatalf("unexpected number of requests made: %v", len(mt.Requests))
+			}
+			if got := mt.Requests[0].Header.Get("Authorization"); got != tt.wantAuth {
+				t.Errorf("authorization header got = \"%s\", want \"%s\"", got, tt.wantAuth)
+			}
+		})
+	}
+}
@@ -32,8 +32,10 @@ type npmRegistryPackageDetails struct {
 	Tags     map[string]string
 }
 
-func NewNpmRegistryAPIClient(workdir string) (*NpmRegistryAPIClient, error) {
-	registries, err := LoadNpmRegistryConfig(workdir)
+// NewNpmRegistryAPIClient makes a client for the registries configured by the npmrc files of workdir,
+// using registry as the default registry instead if it is set.
+func NewNpmRegistryAPIClient(workdir string, registry string) (*NpmRegistryAPIClient, error) {
+	registries, err := LoadNpmRegistryConfig(workdir, registry)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func TestNpmRegistryClient(t *testing.T) {
 		"//"+strings.TrimPrefix(srv2.URL, "http://")+"/:_authToken="+authToken,
 	)
 
-	cl, err := datasource.NewNpmRegistryAPIClient(filepath.Dir(npmrcFile))
+	cl, err := datasource.NewNpmRegistryAPIClient(filepath.Dir(npmrcFile), "")
 	if err != nil {
 		t.Fatalf("failed creating npm api client: %v", err)
 	}
@@ -172,15 +172,24 @@ func urlPathEscapeLower(s string) string {
 type NpmRegistryConfig struct {
 	ScopeURLs map[string]string // map of @scope to registry URL
 	Auths     NpmRegistryAuths  // auth info per npm registry URI
+	Netrc     NetrcAuths        // auth info per host from .netrc, for registries without auth in npmrc
 }
 
-func LoadNpmRegistryConfig(workdir string) (NpmRegistryConfig, error) {
+// LoadNpmRegistryConfig loads the registries configured by the npmrc files of workdir.
+// If registry is set, it is used as the default registry instead of the one in the npmrc files.
+func LoadNpmRegistryConfig(workdir string, registry string) (NpmRegistryConfig, error) {
 	npmrc, err := loadNpmrc(workdir)
 	if err != nil {
 		return NpmRegistryConfig{}, err
 	}
 
-	return ParseNpmRegistryInfo(npmrc), nil
+	config := ParseNpmRegistryInfo(npmrc)
+	if registry != "" {
+		config.ScopeURLs[""] = registry
+	}
+	config.Netrc = LoadNetrc()
+
+	return config, nil
 }
 
 // MakeRequest makes the http request to the corresponding npm registry api (with auth).
@@ -209,7 +218,12 @@ func (r NpmRegistryConfig) MakeRequest(ctx context.Context, httpClient *http.Cli
 		return nil, err
 	}
 
-	return r.Auths.GetAuth(reqURL).Get(ctx, httpClient, reqURL)
+	auth := r.Auths.GetAuth(reqURL)
+	if auth == nil {
+		auth = r.Netrc.GetAuth(reqURL)
+	}
+
+	return auth.Get(ctx, httpClient, reqURL)
 }
 
 var npmSupportedAuths = []HTTPAuthMethod{AuthBearer, AuthBasic}
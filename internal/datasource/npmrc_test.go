@@ -87,7 +87,7 @@ func TestLoadNpmRegistryConfig_WithNoRegistries(t *testing.T) {
 	t.Parallel()
 	npmrcFiles := makeBlankNpmrcFiles(t)
 
-	config, err := datasource.LoadNpmRegistryConfig(filepath.Dir(npmrcFiles.project))
+	config, err := datasource.LoadNpmRegistryConfig(filepath.Dir(npmrcFiles.project), "")
 	if err != nil {
 		t.Fatalf("could not parse npmrc: %v", err)
 	}
@@ -113,7 +113,7 @@ func TestLoadNpmRegistryConfig_WithAuth(t *testing.T) {
 		"//sub.registry2.test.com:_password=d293Cg==",
 	)
 
-	config, err := datasource.LoadNpmRegistryConfig(filepath.Dir(npmrcFiles.project))
+	config, err := datasource.LoadNpmRegistryConfig(filepath.Dir(npmrcFiles.project), "")
 	if err != nil {
 		t.Fatalf("could not parse npmrc: %v", err)
 	}
@@ -124,11 +124,28 @@ func TestLoadNpmRegistryConfig_WithAuth(t *testing.T) {
 	checkNpmRegistryRequest(t, config, []string{"@test2/test"}, "https://sub.registry2.test.com/@test2%2ftest", "Basic dXNlcjp3b3cK")
 }
 
+func TestLoadNpmRegistryConfig_WithRegistry(t *testing.T) {
+	t.Parallel()
+	npmrcFiles := makeBlankNpmrcFiles(t)
+	writeToNpmrc(t, npmrcFiles.project,
+		"registry=https://registry1.test.com",
+		"@test1:registry=https://registry2.test.com",
+	)
+
+	config, err := datasource.LoadNpmRegistryConfig(filepath.Dir(npmrcFiles.project), "https://mirror.test.com/npm/")
+	if err != nil {
+		t.Fatalf("could not parse npmrc: %v", err)
+	}
+
+	checkNpmRegistryRequest(t, config, []string{"foo"}, "https://mirror.test.com/npm/foo", "")
+	checkNpmRegistryRequest(t, config, []string{"@test1/bar"}, "https://registry2.test.com/@test1%2fbar", "")
+}
+
 // Do not make this test parallel because it calls t.Setenv()
 func TestLoadNpmRegistryConfig_WithOverrides(t *testing.T) {
 	check := func(t *testing.T, npmrcFiles testNpmrcFiles, wantURLs [5]string) {
 		t.Helper()
-		config, err := datasource.LoadNpmRegistryConfig(filepath.Dir(npmrcFiles.project))
+		config, err := datasource.LoadNpmRegistryConfig(filepath.Dir(npmrcFiles.project), "")
 		if err != nil {
 			t.Fatalf("could not parse npmrc: %v", err)
 		}
@@ -0,0 +1,107 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+const PyPIRegistry = "https://pypi.org/pypi"
+
+// PyPIRegistryAPIClient is a client for the JSON API of a Python package index,
+// as described in https://docs.pypi.org/api/json/
+type PyPIRegistryAPIClient struct {
+	registry string     // The base URL of the JSON API of the index
+	netrc    NetrcAuths // Authentication for the index, keyed by host. From .netrc
+
+	// cache fields
+	mu             sync.Mutex
+	cacheTimestamp *time.Time // If set, this means we loaded from a cache
+	versions       *RequestCache[string, []string]
+	dependencies   *RequestCache[string, []string]
+}
+
+// NewPyPIRegistryAPIClient makes a client for the JSON API of the index at registry,
+// using PyPI if registry is not set. Requests to the index are authenticated with
+// the credentials for its host in .netrc, if there are any.
+func NewPyPIRegistryAPIClient(registry string) *PyPIRegistryAPIClient {
+	if registry == "" {
+		registry = PyPIRegistry
+	}
+
+	return &PyPIRegistryAPIClient{
+		registry:     strings.TrimSuffix(registry, "/"),
+		netrc:        LoadNetrc(),
+		versions:     NewRequestCache[string, []string](),
+		dependencies: NewRequestCache[string, []string](),
+	}
+}
+
+// Versions returns the versions of the package that can be installed,
+// which excludes the versions that have no files and those where every file has been yanked.
+func (c *PyPIRegistryAPIClient) Versions(ctx context.Context, pkg string) ([]string, error) {
+	return c.versions.Get(pkg, func() ([]string, error) {
+		jsonData, err := c.get(ctx, pkg, "json")
+		if err != nil {
+			return nil, err
+		}
+
+		var versions []string
+		for v, files := range jsonData.Get("releases").Map() {
+			for _, f := range files.Array() {
+				if !f.Get("yanked").Bool() {
+					versions = append(versions, v)
+					break
+				}
+			}
+		}
+
+		return versions, nil
+	})
+}
+
+// Dependencies returns the PEP 508 requirement strings of a version of the package.
+//
+// Packages that were only uploaded as source distributions may not declare their dependencies
+// to the index, in which case no dependencies are returned.
+func (c *PyPIRegistryAPIClient) Dependencies(ctx context.Context, pkg, version string) ([]string, error) {
+	return c.dependencies.Get(pkg+"@"+version, func() ([]string, error) {
+		jsonData, err := c.get(ctx, pkg, version, "json")
+		if err != nil {
+			return nil, err
+		}
+
+		return jsonToStringSlice(jsonData.Get("info.requires_dist")), nil
+	})
+}
+
+func (c *PyPIRegistryAPIClient) get(ctx context.Context, urlComponents ...string) (gjson.Result, error) {
+	reqURL, err := url.JoinPath(c.registry, urlComponents...)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	resp, err := c.netrc.GetAuth(reqURL).Get(ctx, http.DefaultClient, reqURL)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return gjson.Result{}, errors.New(resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	return gjson.ParseBytes(body), nil
+}
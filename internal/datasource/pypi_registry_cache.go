@@ -0,0 +1,57 @@
+package datasource
+
+import (
+	"time"
+)
+
+type pypiRegistryCache struct {
+	Timestamp    *time.Time          // Timestamp of when this cache was made
+	Registry     string              // The index the cache was made from. Used to invalidate cache if the index has changed.
+	Versions     map[string][]string // For a package name, the installable versions
+	Dependencies map[string][]string // For a package name@version, the requirements of that version
+}
+
+func (c *PyPIRegistryAPIClient) GobEncode() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cacheTimestamp == nil {
+		now := time.Now().UTC()
+		c.cacheTimestamp = &now
+	}
+
+	cache := pypiRegistryCache{
+		Timestamp:    c.cacheTimestamp,
+		Registry:     c.registry,
+		Versions:     c.versions.GetMap(),
+		Dependencies: c.dependencies.GetMap(),
+	}
+
+	return gobMarshal(&cache)
+}
+
+func (c *PyPIRegistryAPIClient) GobDecode(b []byte) error {
+	var cache pypiRegistryCache
+	if err := gobUnmarshal(b, &cache); err != nil {
+		return err
+	}
+
+	if cache.Timestamp != nil && time.Since(*cache.Timestamp) >= cacheExpiry {
+		// Cache expired
+		return nil
+	}
+
+	if cache.Registry != c.registry {
+		// Cache is for a different index
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cacheTimestamp = cache.Timestamp
+	c.versions.SetMap(cache.Versions)
+	c.dependencies.SetMap(cache.Dependencies)
+
+	return nil
+}
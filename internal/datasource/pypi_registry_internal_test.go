@@ -0,0 +1,24 @@
+package datasource
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/testutility"
+)
+
+func TestPyPIRegistryAPIClient_NetrcAuth(t *testing.T) {
+	t.Parallel()
+
+	srv := testutility.NewMockHTTPServer(t)
+	srv.SetAuthorization(t, "Basic dXNlcjpzZWNyZXQ=")
+	srv.SetResponseFromFile(t, "/fake-package/1.0.0/json", "./fixtures/pypi_registry/fake-package-1.0.0.json")
+
+	client := NewPyPIRegistryAPIClient(srv.URL)
+	u, _ := url.Parse(srv.URL)
+	client.netrc = ParseNetrc("machine " + u.Hostname() + " login user password secret")
+
+	if _, err := client.Dependencies(t.Context(), "fake-package", "1.0.0"); err != nil {
+		t.Fatalf("failed to get PyPI dependencies with .netrc credentials: %v", err)
+	}
+}
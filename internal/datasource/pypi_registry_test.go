@@ -0,0 +1,63 @@
+package datasource_test
+
+import (
+	compare "cmp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scanner/v2/internal/datasource"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+)
+
+func TestPyPIRegistryClient(t *testing.T) {
+	t.Parallel()
+
+	srv := testutility.NewMockHTTPServer(t)
+	srv.SetResponseFromFile(t, "/fake-package/json", "./fixtures/pypi_registry/fake-package.json")
+	srv.SetResponseFromFile(t, "/fake-package/2.2.2/json", "./fixtures/pypi_registry/fake-package-2.2.2.json")
+	srv.SetResponseFromFile(t, "/fake-package/1.0.0/json", "./fixtures/pypi_registry/fake-package-1.0.0.json")
+
+	cl := datasource.NewPyPIRegistryAPIClient(srv.URL + "/")
+	{
+		const pkg = "fake-package"
+		// 1.1.0 is yanked, and 2.1.0 has no files
+		want := []string{"1.0.0", "2.0.0rc1", "2.2.2"}
+		got, err := cl.Versions(t.Context(), pkg)
+		if err != nil {
+			t.Fatalf("failed getting versions: %v", err)
+		}
+		if diff := cmp.Diff(want, got, cmpopts.SortSlices(compare.Less[string])); diff != "" {
+			t.Errorf("Versions(\"%s\") (-want +got)\n%s", pkg, diff)
+		}
+	}
+	{
+		const pkg = "fake-package"
+		const ver = "2.2.2"
+		want := []string{
+			"idna>=2.5,<4",
+			"PySocks!=1.5.7,>=1.5.6; extra == \"socks\"",
+		}
+		got, err := cl.Dependencies(t.Context(), pkg, ver)
+		if err != nil {
+			t.Fatalf("failed getting dependencies: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Dependencies(\"%s\", \"%s\") (-want +got)\n%s", pkg, ver, diff)
+		}
+	}
+	{
+		const pkg = "fake-package"
+		const ver = "1.0.0"
+		got, err := cl.Dependencies(t.Context(), pkg, ver)
+		if err != nil {
+			t.Fatalf("failed getting dependencies: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("Dependencies(\"%s\", \"%s\") = %v, want none", pkg, ver, got)
+		}
+	}
+	if _, err := cl.Versions(t.Context(), "missing-package"); err == nil {
+		t.Errorf("Versions(\"missing-package\") did not error")
+	}
+}
@@ -0,0 +1,118 @@
+// Package depgraph reads the dependency graphs of the lockfiles that record them, and resolves
+// those of manifests, so that the chain of dependencies that introduces a package can be found.
+package depgraph
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+
+	"deps.dev/util/resolve"
+)
+
+// ErrUnsupported is returned for files that a dependency graph cannot be built for
+var ErrUnsupported = errors.New("dependency graphs are not supported for this file")
+
+// Options are the options for building dependency graphs
+type Options struct {
+	// Offline skips the files that can only have their graph built with network access,
+	// which are go.mod files and pom.xml files
+	Offline bool
+	// ResolveMaven resolves the graphs of pom.xml files, which should only be done if
+	// their transitive dependencies were also resolved when scanning them
+	ResolveMaven bool
+	// MavenRegistry is the registry to fetch the parents of pom.xml files from, and to
+	// resolve their dependencies against if NativeDataSource is set
+	MavenRegistry string
+	// NativeDataSource resolves the dependencies of pom.xml files against MavenRegistry
+	// rather than deps.dev
+	NativeDataSource bool
+}
+
+// Read returns the dependency graph of the lockfile or manifest at path, where the root
+// node (with an id of 0) is the project that the file is for.
+//
+// ErrUnsupported is returned if the graph of the file cannot be built with the options.
+func Read(ctx context.Context, path string, opts Options) (*resolve.Graph, error) {
+	switch filepath.Base(path) {
+	case "package-lock.json":
+		return readNpm(path)
+	case "pnpm-lock.yaml":
+		return readPnpm(path)
+	case "yarn.lock":
+		return readYarn(path)
+	case "go.mod":
+		if opts.Offline {
+			return nil, ErrUnsupported
+		}
+
+		return readGoMod(ctx, path)
+	case "pom.xml":
+		if opts.Offline || !opts.ResolveMaven {
+			return nil, ErrUnsupported
+		}
+
+		return readMaven(ctx, path, opts)
+	}
+
+	return nil, ErrUnsupported
+}
+
+// Key identifies a package in a dependency graph by its name and version
+type Key struct {
+	Name    string
+	Version string
+}
+
+// ShortestChains returns the shortest chain of dependencies from a direct dependency
+// of the root of the graph to each package in the graph, ending with the package itself.
+//
+// If more than one chain is the shortest, the one that is first found is used.
+func ShortestChains(g *resolve.Graph) map[Key][]Key {
+	children := make(map[resolve.NodeID][]resolve.NodeID)
+	for _, e := range g.Edges {
+		children[e.From] = append(children[e.From], e.To)
+	}
+
+	chains := make(map[Key][]Key)
+	if len(g.Nodes) == 0 {
+		return chains
+	}
+
+	// parents records the node that each node was first reached from, doing a breadth first
+	// search from the root so that the first chain to reach each node is a shortest one
+	parents := map[resolve.NodeID]resolve.NodeID{0: 0}
+	queue := []resolve.NodeID{0}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, child := range children[node] {
+			if _, seen := parents[child]; seen {
+				continue
+			}
+
+			parents[child] = node
+			queue = append(queue, child)
+
+			key := keyOf(g, child)
+			if _, ok := chains[key]; ok {
+				continue
+			}
+
+			var chain []Key
+			for n := child; n != 0; n = parents[n] {
+				chain = append(chain, keyOf(g, n))
+			}
+			slices.Reverse(chain)
+			chains[key] = chain
+		}
+	}
+
+	return chains
+}
+
+func keyOf(g *resolve.Graph, n resolve.NodeID) Key {
+	return Key{Name: g.Nodes[n].Version.Name, Version: g.Nodes[n].Version.Version}
+}
@@ -0,0 +1,87 @@
+package depgraph_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/depgraph"
+)
+
+func TestRead_ShortestChains(t *testing.T) {
+	t.Parallel()
+
+	want := map[depgraph.Key][]depgraph.Key{
+		{Name: "express", Version: "4.17.1"}: {
+			{Name: "express", Version: "4.17.1"},
+		},
+		{Name: "lodash", Version: "4.17.21"}: {
+			{Name: "lodash", Version: "4.17.21"},
+		},
+		{Name: "body-parser", Version: "1.19.0"}: {
+			{Name: "express", Version: "4.17.1"},
+			{Name: "body-parser", Version: "1.19.0"},
+		},
+		{Name: "debug", Version: "2.6.9"}: {
+			{Name: "express", Version: "4.17.1"},
+			{Name: "debug", Version: "2.6.9"},
+		},
+		{Name: "qs", Version: "6.7.0"}: {
+			{Name: "express", Version: "4.17.1"},
+			{Name: "body-parser", Version: "1.19.0"},
+			{Name: "qs", Version: "6.7.0"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "npm", path: "fixtures/npm/package-lock.json"},
+		{name: "pnpm_v6", path: "fixtures/pnpm-v6/pnpm-lock.yaml"},
+		{name: "pnpm_v9", path: "fixtures/pnpm-v9/pnpm-lock.yaml"},
+		{name: "yarn_v1", path: "fixtures/yarn-v1/yarn.lock"},
+		{name: "yarn_berry", path: "fixtures/yarn-berry/yarn.lock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := depgraph.Read(t.Context(), tt.path, depgraph.Options{})
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+
+			got := depgraph.ShortestChains(g)
+
+			// npm lockfiles name the root after the project
+			delete(got, depgraph.Key{Name: "my-app", Version: "1.0.0"})
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("ShortestChains() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRead_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		opts depgraph.Options
+	}{
+		{path: "fixtures/Gemfile.lock"},
+		{path: "fixtures/go.mod", opts: depgraph.Options{Offline: true}},
+		{path: "fixtures/pom.xml", opts: depgraph.Options{ResolveMaven: false}},
+		{path: "fixtures/pom.xml", opts: depgraph.Options{Offline: true, ResolveMaven: true}},
+	}
+
+	for _, tt := range tests {
+		_, err := depgraph.Read(t.Context(), tt.path, tt.opts)
+		if !errors.Is(err, depgraph.ErrUnsupported) {
+			t.Errorf("Read(%q) error = %v, want %v", tt.path, err, depgraph.ErrUnsupported)
+		}
+	}
+}
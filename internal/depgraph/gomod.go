@@ -0,0 +1,74 @@
+package depgraph
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"deps.dev/util/resolve"
+	"deps.dev/util/resolve/dep"
+	"github.com/google/osv-scanner/v2/internal/gomod"
+)
+
+// readGoMod builds the graph of a go.mod file from the module graph reported by the go command,
+// which may need to download the go.mod files of the dependencies of the module
+func readGoMod(ctx context.Context, path string) (*resolve.Graph, error) {
+	reqs, err := gomod.Graph(ctx, filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return goModGraph(reqs), nil
+}
+
+// goModGraph converts the requirements of a module graph to a dependency graph,
+// with the versions of modules having no "v" prefix to match the go.mod extractor.
+//
+// The main modules, which are the members of the workspace if the module is in one,
+// are all the root of the graph, as their requirements are all direct dependencies.
+func goModGraph(reqs []gomod.Requirement) *resolve.Graph {
+	g := &resolve.Graph{}
+	nodes := make(map[gomod.Module]resolve.NodeID)
+
+	for _, req := range reqs {
+		if req.From.Version == "" {
+			g.AddNode(resolve.VersionKey{
+				PackageKey:  resolve.PackageKey{System: resolve.UnknownSystem, Name: req.From.Path},
+				VersionType: resolve.Concrete,
+			})
+
+			break
+		}
+	}
+
+	node := func(m gomod.Module) resolve.NodeID {
+		if m.Version == "" {
+			return 0
+		}
+
+		if id, ok := nodes[m]; ok {
+			return id
+		}
+
+		id := g.AddNode(resolve.VersionKey{
+			PackageKey:  resolve.PackageKey{System: resolve.UnknownSystem, Name: m.Path},
+			VersionType: resolve.Concrete,
+			Version:     strings.TrimPrefix(m.Version, "v"),
+		})
+		nodes[m] = id
+
+		return id
+	}
+
+	if len(g.Nodes) == 0 {
+		return g
+	}
+
+	for _, req := range reqs {
+		if from, to := node(req.From), node(req.To); from != to {
+			_ = g.AddEdge(from, to, req.To.Version, dep.NewType())
+		}
+	}
+
+	return g
+}
@@ -0,0 +1,46 @@
+package depgraph
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/gomod"
+)
+
+func Test_goModGraph(t *testing.T) {
+	t.Parallel()
+
+	mod := func(path, version string) gomod.Module {
+		return gomod.Module{Path: path, Version: version}
+	}
+
+	// a workspace, where both of the main modules are the root of the graph
+	g := goModGraph([]gomod.Requirement{
+		{From: mod("example.com/api", ""), To: mod("golang.org/x/net", "v0.20.0")},
+		{From: mod("example.com/api", ""), To: mod("example.com/worker", "")},
+		{From: mod("example.com/worker", ""), To: mod("golang.org/x/crypto", "v0.17.0")},
+		{From: mod("golang.org/x/crypto", "v0.17.0"), To: mod("golang.org/x/net", "v0.17.0")},
+		{From: mod("golang.org/x/net", "v0.20.0"), To: mod("golang.org/x/text", "v0.14.0")},
+	})
+
+	want := map[Key][]Key{
+		{Name: "golang.org/x/net", Version: "0.20.0"}: {
+			{Name: "golang.org/x/net", Version: "0.20.0"},
+		},
+		{Name: "golang.org/x/crypto", Version: "0.17.0"}: {
+			{Name: "golang.org/x/crypto", Version: "0.17.0"},
+		},
+		{Name: "golang.org/x/net", Version: "0.17.0"}: {
+			{Name: "golang.org/x/crypto", Version: "0.17.0"},
+			{Name: "golang.org/x/net", Version: "0.17.0"},
+		},
+		{Name: "golang.org/x/text", Version: "0.14.0"}: {
+			{Name: "golang.org/x/net", Version: "0.20.0"},
+			{Name: "golang.org/x/text", Version: "0.14.0"},
+		},
+	}
+
+	if diff := cmp.Diff(want, ShortestChains(g)); diff != "" {
+		t.Errorf("ShortestChains() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,44 @@
+package depgraph
+
+import (
+	"context"
+
+	"deps.dev/util/resolve"
+	"github.com/google/osv-scanner/v2/internal/depsdev"
+	"github.com/google/osv-scanner/v2/internal/resolution"
+	"github.com/google/osv-scanner/v2/internal/resolution/client"
+	"github.com/google/osv-scanner/v2/internal/resolution/depfile"
+	"github.com/google/osv-scanner/v2/internal/resolution/manifest"
+	"github.com/google/osv-scanner/v2/internal/version"
+)
+
+// readMaven resolves the graph of a pom.xml file, in the same way as guided remediation
+func readMaven(ctx context.Context, path string, opts Options) (*resolve.Graph, error) {
+	rw, err := manifest.NewMavenReadWriter(opts.MavenRegistry)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := depfile.OpenLocalDepFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := rw.Read(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var cl client.ResolutionClient
+	if opts.NativeDataSource {
+		cl.DependencyClient, err = client.NewMavenRegistryClient(opts.MavenRegistry)
+	} else {
+		cl.DependencyClient, err = client.NewDepsDevClient(depsdev.DepsdevAPI, "osv-scanner_scan/"+version.OSVVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resolution.ResolveGraph(ctx, cl, m, resolution.ResolveOpts{})
+}
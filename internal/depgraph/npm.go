@@ -0,0 +1,18 @@
+package depgraph
+
+import (
+	"deps.dev/util/resolve"
+	"github.com/google/osv-scanner/v2/internal/resolution/depfile"
+	"github.com/google/osv-scanner/v2/internal/resolution/lockfile"
+)
+
+// readNpm reads the graph of a package-lock.json file, in the same way as guided remediation
+func readNpm(path string) (*resolve.Graph, error) {
+	f, err := depfile.OpenLocalDepFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return lockfile.NpmReadWriter{}.Read(f)
+}
@@ -0,0 +1,197 @@
+package depgraph
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"deps.dev/util/resolve"
+	"deps.dev/util/resolve/dep"
+	"gopkg.in/yaml.v3"
+)
+
+type pnpmLockfile struct {
+	LockfileVersion string                  `yaml:"lockfileVersion"`
+	Importers       map[string]pnpmImporter `yaml:"importers"`
+	// lockfiles of projects that are not workspaces list the dependencies of the
+	// project at the top level, rather than as the "." importer
+	pnpmImporter `yaml:",inline"`
+	Packages     map[string]pnpmPackage `yaml:"packages"`
+	// Snapshots has the dependencies of each package in v9 lockfiles,
+	// with Packages only having their metadata
+	Snapshots map[string]pnpmPackage `yaml:"snapshots"`
+}
+
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmImporterVersion `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmImporterVersion `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmImporterVersion `yaml:"optionalDependencies"`
+}
+
+// pnpmImporterVersion is the version of a dependency of an importer, which
+// v5 lockfiles list directly rather than along with its specifier
+type pnpmImporterVersion string
+
+func (v *pnpmImporterVersion) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*v = pnpmImporterVersion(node.Value)
+		return nil
+	}
+
+	var specified struct {
+		Version string `yaml:"version"`
+	}
+	if err := node.Decode(&specified); err != nil {
+		return err
+	}
+	*v = pnpmImporterVersion(specified.Version)
+
+	return nil
+}
+
+type pnpmPackage struct {
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+func (i pnpmImporter) dependencies() map[string]string {
+	deps := make(map[string]string)
+	for _, m := range []map[string]pnpmImporterVersion{i.Dependencies, i.DevDependencies, i.OptionalDependencies} {
+		for name, version := range m {
+			deps[name] = string(version)
+		}
+	}
+
+	return deps
+}
+
+func (p pnpmPackage) dependencies() map[string]string {
+	deps := maps.Clone(p.Dependencies)
+	if deps == nil {
+		deps = make(map[string]string)
+	}
+	maps.Copy(deps, p.OptionalDependencies)
+
+	return deps
+}
+
+// readPnpm reads the graph of a pnpm-lock.yaml file, where the root is every importer
+func readPnpm(path string) (*resolve.Graph, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lockfile pnpmLockfile
+	if err := yaml.Unmarshal(b, &lockfile); err != nil {
+		return nil, err
+	}
+
+	major, err := pnpmMajorVersion(lockfile.LockfileVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	importers := lockfile.Importers
+	if len(importers) == 0 {
+		importers = map[string]pnpmImporter{".": lockfile.pnpmImporter}
+	}
+
+	packages := lockfile.Packages
+	if major >= 9 {
+		packages = lockfile.Snapshots
+	}
+
+	g := &resolve.Graph{}
+	g.AddNode(resolve.VersionKey{PackageKey: resolve.PackageKey{System: resolve.NPM}})
+
+	// nodes are added in order of their key so that the graph is the same on every read
+	nodes := make(map[string]resolve.NodeID)
+	for _, key := range slices.Sorted(maps.Keys(packages)) {
+		name, version := pnpmParseKey(key, major)
+		nodes[key] = g.AddNode(resolve.VersionKey{
+			PackageKey:  resolve.PackageKey{System: resolve.NPM, Name: name},
+			VersionType: resolve.Concrete,
+			Version:     version,
+		})
+	}
+
+	addEdges := func(from resolve.NodeID, deps map[string]string) {
+		for _, name := range slices.Sorted(maps.Keys(deps)) {
+			if to, ok := nodes[pnpmDependencyKey(name, deps[name], major)]; ok {
+				_ = g.AddEdge(from, to, deps[name], dep.NewType())
+			}
+		}
+	}
+
+	for _, importer := range slices.Sorted(maps.Keys(importers)) {
+		addEdges(0, importers[importer].dependencies())
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(packages)) {
+		addEdges(nodes[key], packages[key].dependencies())
+	}
+
+	return g, nil
+}
+
+func pnpmMajorVersion(lockfileVersion string) (int, error) {
+	major, _, _ := strings.Cut(lockfileVersion, ".")
+	v, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lockfileVersion %q", lockfileVersion)
+	}
+
+	return v, nil
+}
+
+// pnpmDependencyKey returns the key of the package that a dependency resolves to, which is
+// either the version of the package with the same name, or the key of an aliased package
+func pnpmDependencyKey(name, version string, major int) string {
+	switch {
+	case major >= 9:
+		// aliased packages are referred to by their name and version, like "string-width@4.2.3"
+		if before, _, ok := strings.Cut(strings.TrimPrefix(version, "@"), "@"); ok && !strings.Contains(before, "(") {
+			return version
+		}
+
+		return name + "@" + version
+	case strings.HasPrefix(version, "/"):
+		return version
+	case major >= 6:
+		return "/" + name + "@" + version
+	default:
+		return "/" + name + "/" + version
+	}
+}
+
+// pnpmParseKey returns the name and version of the package with the key, excluding
+// the peer dependencies that the version is suffixed with
+func pnpmParseKey(key string, major int) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+
+	var name, version string
+	if major >= 6 {
+		// the peer dependencies of the version can also have an "@" in them
+		prefix, _, _ := strings.Cut(key, "(")
+		i := strings.LastIndex(prefix, "@")
+		if i <= 0 {
+			return key, ""
+		}
+		name, version = key[:i], key[i+1:]
+		version, _, _ = strings.Cut(version, "(")
+	} else {
+		// the peer dependencies of the version have any "/" replaced with "+"
+		i := strings.LastIndex(key, "/")
+		if i <= 0 {
+			return key, ""
+		}
+		name, version = key[:i], key[i+1:]
+		version, _, _ = strings.Cut(version, "_")
+	}
+
+	return name, version
+}
@@ -0,0 +1,216 @@
+package depgraph
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"deps.dev/util/resolve"
+	"deps.dev/util/resolve/dep"
+	"gopkg.in/yaml.v3"
+)
+
+// yarnEntry is a package in a yarn.lock file, which is keyed by each
+// of the descriptors (such as "lodash@^4.17.0") that resolve to it
+type yarnEntry struct {
+	descriptors  []string
+	name         string
+	version      string
+	dependencies map[string]string
+	// workspace is true for the workspaces of yarn berry lockfiles, which are the root
+	workspace bool
+}
+
+type yarnBerryEntry struct {
+	Version              string            `yaml:"version"`
+	Resolution           string            `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// readYarn reads the graph of a yarn.lock file.
+//
+// The direct dependencies of v1 lockfiles are read from the package.json file next to it,
+// as the lockfile does not record them, while berry lockfiles include the workspaces.
+func readYarn(path string) (*resolve.Graph, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []yarnEntry
+	var direct map[string]string
+	if strings.Contains(string(b), "__metadata:") {
+		entries, err = parseYarnBerry(b)
+	} else {
+		entries = parseYarnV1(string(b))
+		direct, err = readPackageJSONDependencies(filepath.Join(filepath.Dir(path), "package.json"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	g := &resolve.Graph{}
+	g.AddNode(resolve.VersionKey{PackageKey: resolve.PackageKey{System: resolve.NPM}})
+
+	// workspaces are not added to the graph, leaving their id as that of the root
+	// so that their dependencies are the direct dependencies of the project
+	nodes := make(map[string]resolve.NodeID)
+	ids := make([]resolve.NodeID, len(entries))
+	for i, entry := range entries {
+		if entry.workspace {
+			continue
+		}
+
+		ids[i] = g.AddNode(resolve.VersionKey{
+			PackageKey:  resolve.PackageKey{System: resolve.NPM, Name: entry.name},
+			VersionType: resolve.Concrete,
+			Version:     entry.version,
+		})
+		for _, d := range entry.descriptors {
+			nodes[d] = ids[i]
+		}
+	}
+
+	addEdges := func(from resolve.NodeID, deps map[string]string) {
+		for _, name := range slices.Sorted(maps.Keys(deps)) {
+			if to, ok := nodes[name+"@"+deps[name]]; ok {
+				_ = g.AddEdge(from, to, deps[name], dep.NewType())
+			}
+		}
+	}
+
+	addEdges(0, direct)
+	for i, entry := range entries {
+		addEdges(ids[i], entry.dependencies)
+	}
+
+	return g, nil
+}
+
+// parseYarnV1 parses the custom format of v1 yarn.lock files
+func parseYarnV1(content string) []yarnEntry {
+	var entries []yarnEntry
+	var current *yarnEntry
+	var inDependencies bool
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 0:
+			entries = append(entries, yarnEntry{dependencies: make(map[string]string)})
+			current = &entries[len(entries)-1]
+			inDependencies = false
+
+			for _, d := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				d = strings.Trim(strings.TrimSpace(d), `"`)
+				current.descriptors = append(current.descriptors, d)
+				if current.name == "" {
+					current.name = yarnDescriptorName(d)
+				}
+			}
+		case current == nil:
+			continue
+		case indent == 2:
+			inDependencies = trimmed == "dependencies:" || trimmed == "optionalDependencies:"
+			if v, ok := strings.CutPrefix(trimmed, "version "); ok {
+				current.version = strings.Trim(v, `"`)
+			}
+		case inDependencies:
+			name, spec, _ := strings.Cut(trimmed, " ")
+			current.dependencies[strings.Trim(name, `"`)] = strings.Trim(strings.TrimSpace(spec), `"`)
+		}
+	}
+
+	return entries
+}
+
+// parseYarnBerry parses the yaml of yarn berry lockfiles
+func parseYarnBerry(b []byte) ([]yarnEntry, error) {
+	var lockfile map[string]yarnBerryEntry
+	if err := yaml.Unmarshal(b, &lockfile); err != nil {
+		return nil, err
+	}
+
+	entries := make([]yarnEntry, 0, len(lockfile))
+	for _, key := range slices.Sorted(maps.Keys(lockfile)) {
+		if key == "__metadata" {
+			continue
+		}
+
+		e := lockfile[key]
+		entry := yarnEntry{
+			version:      e.Version,
+			dependencies: maps.Clone(e.Dependencies),
+			workspace:    strings.Contains(e.Resolution, "@workspace:"),
+		}
+		if entry.dependencies == nil {
+			entry.dependencies = make(map[string]string)
+		}
+		maps.Copy(entry.dependencies, e.OptionalDependencies)
+
+		for _, d := range strings.Split(key, ",") {
+			entry.descriptors = append(entry.descriptors, strings.TrimSpace(d))
+		}
+		entry.name = yarnDescriptorName(entry.descriptors[0])
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// yarnDescriptorName returns the name of the package of a descriptor, like "@babel/core@^7.0.0"
+func yarnDescriptorName(descriptor string) string {
+	// the range can have an "@" in it too, like "lodash@npm:^4.17.0" or "alias@npm:lodash@^4.17.0"
+	i := strings.Index(strings.TrimPrefix(descriptor, "@"), "@")
+	if i < 0 {
+		return descriptor
+	}
+	if strings.HasPrefix(descriptor, "@") {
+		i++
+	}
+
+	return descriptor[:i]
+}
+
+// readPackageJSONDependencies returns the dependencies of the package.json file at path,
+// or none if it does not exist
+func readPackageJSONDependencies(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string]string)
+	for _, m := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.OptionalDependencies} {
+		maps.Copy(deps, m)
+	}
+
+	return deps, nil
+}
@@ -0,0 +1,324 @@
+// Package fixturegen generates fixtures for testing extractors from real lockfiles,
+// by anonymizing and minimizing them, and recording the packages they are expected to
+// have extracted from them as golden JSON.
+package fixturegen
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract"
+)
+
+// Options control how the fixture is generated
+type Options struct {
+	// Extractors are the extractors to try extracting the lockfile with
+	Extractors []filesystem.Extractor
+	// ParseAs is the name of the file that the lockfile is extracted as,
+	// for lockfiles that are not named as their extractor expects
+	ParseAs string
+	// Packages are the names of the packages that the fixture needs to keep,
+	// with every package being kept if there are none
+	Packages []string
+	// Replacements are additional strings to anonymize, such as the names of
+	// private packages and registries, mapped to what to replace them with
+	Replacements map[string]string
+	// Minimize controls if lines that do not change what is extracted are removed
+	Minimize bool
+}
+
+// ExpectedPackage is a package that is expected to be extracted from a fixture
+type ExpectedPackage struct {
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	Ecosystem string   `json:"ecosystem,omitempty"`
+	Commit    string   `json:"commit,omitempty"`
+	DepGroups []string `json:"depGroups,omitempty"`
+}
+
+// Golden is what is expected to be extracted from a fixture
+type Golden struct {
+	Extractors []string          `json:"extractors,omitempty"`
+	Packages   []ExpectedPackage `json:"packages"`
+	// Error is set if the fixture is expected to fail to be extracted
+	Error string `json:"error,omitempty"`
+}
+
+// Fixture is a generated fixture, along with what is extracted from it
+type Fixture struct {
+	Content []byte
+	Golden  Golden
+	// Lines is the number of lines in the fixture
+	Lines int
+	// OriginalLines is the number of lines in the lockfile that the fixture was generated from
+	OriginalLines int
+}
+
+// extraction is the result of extracting a candidate fixture
+type extraction struct {
+	extractors []string
+	packages   []ExpectedPackage
+	err        error
+}
+
+// Generate generates a fixture from the lockfile at the given path.
+//
+// The fixture is extracted from a copy in an otherwise empty temporary directory, so that
+// it does not depend on any files next to the lockfile, and with extractors that do not
+// fetch anything from the network, so that it can be extracted the same way in tests.
+func Generate(ctx context.Context, path string, opts Options) (Fixture, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	dir, err := os.MkdirTemp("", "osv-scanner-fixture-*")
+	if err != nil {
+		return Fixture{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	g := generator{
+		path: filepath.Join(dir, cmp.Or(opts.ParseAs, filepath.Base(path))),
+		opts: opts,
+	}
+
+	fixture := Fixture{Content: Anonymize(content, opts.Replacements), OriginalLines: countLines(content)}
+
+	want, err := g.extract(ctx, fixture.Content)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	if errors.Is(want.err, scalibrextract.ErrExtractorNotFound) {
+		return Fixture{}, fmt.Errorf("%s: %w", path, want.err)
+	}
+
+	if want.err == nil && len(opts.Packages) > 0 && len(g.kept(want.packages)) == 0 {
+		return Fixture{}, fmt.Errorf("none of the packages %s are extracted from %s", strings.Join(opts.Packages, ", "), path)
+	}
+
+	if opts.Minimize {
+		fixture.Content, err = g.minimize(ctx, fixture.Content, want)
+		if err != nil {
+			return Fixture{}, err
+		}
+	}
+
+	got, err := g.extract(ctx, fixture.Content)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	fixture.Lines = countLines(fixture.Content)
+	fixture.Golden = Golden{Extractors: got.extractors, Packages: got.packages}
+	if got.err != nil {
+		fixture.Golden.Packages = []ExpectedPackage{}
+
+		// the temporary directory is not part of the error that is expected, which can
+		// be in the error relative to the root that the lockfile was extracted from
+		msg := got.err.Error()
+		for _, prefix := range []string{dir, strings.TrimPrefix(dir, getRoot(dir))} {
+			msg = strings.ReplaceAll(msg, prefix+string(filepath.Separator), "")
+		}
+		fixture.Golden.Error = msg
+	}
+
+	return fixture, nil
+}
+
+type generator struct {
+	path string
+	opts Options
+}
+
+// extract writes the candidate fixture to the temporary file and extracts it,
+// returning an error only if the candidate could not be written
+func (g generator) extract(ctx context.Context, content []byte) (extraction, error) {
+	if err := os.WriteFile(g.path, content, 0600); err != nil {
+		return extraction{}, err
+	}
+
+	pkgs, err := scalibrextract.ExtractWithExtractors(ctx, g.path, g.opts.Extractors)
+	if err != nil {
+		return extraction{err: err}, nil
+	}
+
+	return extraction{extractors: extractorNames(pkgs), packages: expectedPackages(pkgs)}, nil
+}
+
+// kept returns the packages that the fixture needs to keep
+func (g generator) kept(pkgs []ExpectedPackage) []ExpectedPackage {
+	if len(g.opts.Packages) == 0 {
+		return pkgs
+	}
+
+	return slices.DeleteFunc(slices.Clone(pkgs), func(pkg ExpectedPackage) bool {
+		return !slices.Contains(g.opts.Packages, pkg.Name)
+	})
+}
+
+// interesting returns if a candidate fixture still has what the fixture was generated for,
+// which is failing to be extracted in the same way if the lockfile failed to be extracted,
+// and otherwise having the same packages extracted from it that are being kept
+func (g generator) interesting(got, want extraction) bool {
+	if want.err != nil {
+		return got.err != nil && errorShape(got.err) == errorShape(want.err)
+	}
+
+	return got.err == nil && reflect.DeepEqual(g.kept(got.packages), g.kept(want.packages))
+}
+
+// minimize removes as many lines from the content as it can while it is still interesting,
+// by trying to remove chunks of lines that are halved in size whenever none can be removed
+func (g generator) minimize(ctx context.Context, content []byte, want extraction) ([]byte, error) {
+	lines := splitLines(content)
+
+	for chunk := max(len(lines)/2, 1); chunk > 0; {
+		removed := false
+
+		for i := 0; i < len(lines); {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			candidate := slices.Concat(lines[:i], lines[min(i+chunk, len(lines)):])
+
+			got, err := g.extract(ctx, []byte(strings.Join(candidate, "")))
+			if err != nil {
+				return nil, err
+			}
+
+			if g.interesting(got, want) {
+				lines = candidate
+				removed = true
+			} else {
+				i += chunk
+			}
+		}
+
+		if !removed {
+			chunk /= 2
+		}
+	}
+
+	return []byte(strings.Join(lines, "")), nil
+}
+
+// getRoot returns the root of the filesystem that the absolute path is in
+func getRoot(path string) string {
+	return filepath.VolumeName(path) + string(filepath.Separator)
+}
+
+// errorShape is the message of the error without any numbers, which are usually
+// positions in the lockfile that change as lines are removed
+func errorShape(err error) string {
+	return cachedregexp.MustCompile(`\d+`).ReplaceAllString(err.Error(), "N")
+}
+
+// splitLines splits the content into lines, keeping their line endings
+func splitLines(content []byte) []string {
+	return strings.SplitAfter(string(content), "\n")
+}
+
+func countLines(content []byte) int {
+	lines := bytes.Count(content, []byte("\n"))
+
+	// the last line does not always end with a new line
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		lines++
+	}
+
+	return lines
+}
+
+func extractorNames(pkgs []*extractor.Package) []string {
+	names := []string{}
+	for _, pkg := range pkgs {
+		for _, name := range pkg.Plugins {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+func expectedPackages(pkgs []*extractor.Package) []ExpectedPackage {
+	expected := make([]ExpectedPackage, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		pkgInfo := imodels.FromInventory(pkg)
+
+		// packages without groups are expected to have none, rather than an empty list
+		var depGroups []string
+		if groups := pkgInfo.DepGroups(); len(groups) > 0 {
+			depGroups = groups
+		}
+
+		expected = append(expected, ExpectedPackage{
+			Name:      pkgInfo.Name(),
+			Version:   pkgInfo.Version(),
+			Ecosystem: pkgInfo.Ecosystem().String(),
+			Commit:    pkgInfo.Commit(),
+			DepGroups: depGroups,
+		})
+	}
+
+	return expected
+}
+
+// Anonymize replaces the given strings in the content, and then removes the credentials
+// in urls, email addresses, and the names of users in paths to their home directories
+func Anonymize(content []byte, replacements map[string]string) []byte {
+	s := string(content)
+
+	olds := make([]string, 0, len(replacements))
+	for old := range replacements {
+		olds = append(olds, old)
+	}
+	// longer strings are replaced first, in case they contain any of the others
+	slices.SortFunc(olds, func(a, b string) int { return len(b) - len(a) })
+
+	for _, old := range olds {
+		s = strings.ReplaceAll(s, old, replacements[old])
+	}
+
+	s = cachedregexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)([^/\s:@"'<>]+)(?::[^/\s@"'<>]*)?@`).ReplaceAllStringFunc(s, func(url string) string {
+		m := cachedregexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*://)([^/\s:@"'<>]+)(:?)`).FindStringSubmatch(url)
+
+		// "git" is the conventional user of repositories cloned over ssh rather than a secret
+		if m[2] == "git" && m[3] == "" {
+			return url
+		}
+
+		return m[1] + "redacted@"
+	})
+
+	s = cachedregexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*\.[a-zA-Z]{2,}\b`).ReplaceAllStringFunc(s, func(email string) string {
+		// addresses that have already been anonymized are kept as they are, along with
+		// the users of urls which look the same as addresses
+		if strings.HasSuffix(email, "@example.com") || strings.HasPrefix(email, "redacted@") || strings.HasPrefix(email, "git@") {
+			return email
+		}
+
+		return "user@example.com"
+	})
+
+	s = cachedregexp.MustCompile(`(/home/|/Users/|[A-Z]:(?:\\\\|\\)Users(?:\\\\|\\))[^/\\\s"']+`).ReplaceAllString(s, "${1}user")
+
+	return []byte(s)
+}
@@ -0,0 +1,238 @@
+// Package gomod checks the modules in the build list of a Go module for
+// vulnerabilities, reporting them in a format compatible with govulncheck.
+package gomod
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// StdlibModulePath is the module path used for the Go standard library,
+// matching both govulncheck and the OSV database
+const StdlibModulePath = "stdlib"
+
+// Module is a module in the build list
+type Module struct {
+	Path string
+	// Version is the version of the module with the "v" prefix,
+	// as reported by the go command
+	Version string
+}
+
+// goModule is a module as output by "go list -m -json"
+type goModule struct {
+	Path    string
+	Version string
+	Main    bool
+	Replace *goModule
+}
+
+// goPackage is a package as output by "go list -json"
+type goPackage struct {
+	ImportPath string
+	Standard   bool
+	Module     *goModule
+}
+
+// BuildList returns the modules in the build list of the main module in dir,
+// along with the standard library of the Go toolchain that builds them.
+//
+// If any package patterns are given, only modules that provide packages to
+// the build of those patterns are returned.
+func BuildList(ctx context.Context, dir string, patterns []string) ([]Module, error) {
+	out, err := runGo(ctx, dir, "list", "-m", "-json", "all")
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := parseModules(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse build list: %w", err)
+	}
+
+	if len(patterns) > 0 {
+		out, err = runGo(ctx, dir, append([]string{"list", "-deps", "-json"}, patterns...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		used, err := parsePackageModules(bytes.NewReader(out))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse packages: %w", err)
+		}
+
+		modules = slices.DeleteFunc(modules, func(m Module) bool {
+			return !used[m.Path]
+		})
+	}
+
+	goVersion, err := runGo(ctx, dir, "env", "GOVERSION")
+	if err != nil {
+		return nil, err
+	}
+
+	if v := toolchainVersion(string(goVersion)); v != "" {
+		modules = append(modules, Module{Path: StdlibModulePath, Version: v})
+	}
+
+	return modules, nil
+}
+
+func runGo(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return nil, fmt.Errorf("go %s failed: %w", strings.Join(args, " "), err)
+	}
+
+	return out, nil
+}
+
+// parseModules parses the stream of modules output by "go list -m -json all",
+// skipping the main modules and applying any replacements
+func parseModules(r io.Reader) ([]Module, error) {
+	var modules []Module
+
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var m goModule
+		if err := dec.Decode(&m); err != nil {
+			return nil, err
+		}
+
+		if m.Main {
+			continue
+		}
+
+		if mod, ok := resolveModule(m); ok {
+			modules = append(modules, mod)
+		}
+	}
+
+	return modules, nil
+}
+
+// resolveModule returns the module that is actually built for m, returning false
+// if it is replaced by a local directory, which cannot have known vulnerabilities
+func resolveModule(m goModule) (Module, bool) {
+	if m.Replace != nil {
+		if m.Replace.Version == "" {
+			return Module{}, false
+		}
+
+		m = *m.Replace
+	}
+
+	if m.Version == "" {
+		return Module{}, false
+	}
+
+	return Module{Path: m.Path, Version: m.Version}, true
+}
+
+// parsePackageModules parses the stream of packages output by "go list -deps -json",
+// returning the path of each module that provides at least one of the packages.
+//
+// Replacements are applied the same way as for the build list so that the paths match.
+func parsePackageModules(r io.Reader) (map[string]bool, error) {
+	used := make(map[string]bool)
+
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var p goPackage
+		if err := dec.Decode(&p); err != nil {
+			return nil, err
+		}
+
+		if p.Standard || p.Module == nil || p.Module.Main {
+			continue
+		}
+
+		if mod, ok := resolveModule(*p.Module); ok {
+			used[mod.Path] = true
+		}
+	}
+
+	return used, nil
+}
+
+// toolchainVersion converts the output of "go env GOVERSION" to a module version,
+// such as "go1.22.1" to "v1.22.1", returning an empty string for development builds
+func toolchainVersion(goVersion string) string {
+	// the version can be followed by experiments, e.g. "go1.22.1 X:rangefunc"
+	fields := strings.Fields(goVersion)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	v, ok := strings.CutPrefix(fields[0], "go")
+	if !ok || v == "" || !('0' <= v[0] && v[0] <= '9') {
+		return ""
+	}
+
+	return "v" + v
+}
+
+// Requirement is an edge of the module graph, where the main module has an empty version
+type Requirement struct {
+	From Module
+	To   Module
+}
+
+// Graph returns the requirements of the module graph of the main module in dir,
+// as reported by "go mod graph", excluding those on the Go toolchain.
+//
+// Unlike the build list, the graph includes every version of each module that
+// is required by any module, not only the versions that are selected.
+func Graph(ctx context.Context, dir string) ([]Requirement, error) {
+	out, err := runGo(ctx, dir, "mod", "graph")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseGraph(string(out)), nil
+}
+
+// parseGraph parses the output of "go mod graph", which has a requirement on each line
+func parseGraph(out string) []Requirement {
+	var reqs []Requirement
+	for line := range strings.Lines(out) {
+		from, to, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+
+		req := Requirement{From: parseModuleVersion(from), To: parseModuleVersion(to)}
+		if req.To.Path == "go" || req.To.Path == "toolchain" {
+			continue
+		}
+
+		reqs = append(reqs, req)
+	}
+
+	return reqs
+}
+
+// parseModuleVersion parses a module in the form "path@version", or just "path" for the main module
+func parseModuleVersion(s string) Module {
+	path, version, _ := strings.Cut(s, "@")
+
+	return Module{Path: path, Version: version}
+}
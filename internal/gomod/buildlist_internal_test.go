@@ -0,0 +1,170 @@
+package gomod
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseModules(t *testing.T) {
+	t.Parallel()
+
+	// a trimmed down version of the output of "go list -m -json all"
+	out := `{
+	"Path": "example.com/project",
+	"Main": true,
+	"Dir": "/src/project",
+	"GoMod": "/src/project/go.mod",
+	"GoVersion": "1.22"
+}
+{
+	"Path": "golang.org/x/net",
+	"Version": "v0.17.0",
+	"Time": "2023-10-05T16:36:47Z",
+	"Indirect": true
+}
+{
+	"Path": "github.com/gorilla/websocket",
+	"Version": "v1.4.0",
+	"Replace": {
+		"Path": "github.com/gorilla/websocket",
+		"Version": "v1.5.1"
+	}
+}
+{
+	"Path": "github.com/example/forked",
+	"Version": "v1.0.0",
+	"Replace": {
+		"Path": "github.com/someone/forked",
+		"Version": "v1.0.1"
+	}
+}
+{
+	"Path": "github.com/example/local",
+	"Version": "v1.0.0",
+	"Replace": {
+		"Path": "../local"
+	}
+}
+`
+
+	got, err := parseModules(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("parseModules() error = %v", err)
+	}
+
+	want := []Module{
+		{Path: "golang.org/x/net", Version: "v0.17.0"},
+		{Path: "github.com/gorilla/websocket", Version: "v1.5.1"},
+		{Path: "github.com/someone/forked", Version: "v1.0.1"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseModules() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_parseModules_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseModules(strings.NewReader(`{"Path": "golang.org/x/net",`))
+	if err == nil {
+		t.Errorf("parseModules() expected an error")
+	}
+}
+
+func Test_parsePackageModules(t *testing.T) {
+	t.Parallel()
+
+	// a trimmed down version of the output of "go list -deps -json ./..."
+	out := `{
+	"ImportPath": "errors",
+	"Standard": true
+}
+{
+	"ImportPath": "golang.org/x/net/html",
+	"Module": {
+		"Path": "golang.org/x/net",
+		"Version": "v0.17.0"
+	}
+}
+{
+	"ImportPath": "github.com/example/forked",
+	"Module": {
+		"Path": "github.com/example/forked",
+		"Version": "v1.0.0",
+		"Replace": {
+			"Path": "github.com/someone/forked",
+			"Version": "v1.0.1"
+		}
+	}
+}
+{
+	"ImportPath": "example.com/project/cmd",
+	"Module": {
+		"Path": "example.com/project",
+		"Main": true
+	}
+}
+`
+
+	got, err := parsePackageModules(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("parsePackageModules() error = %v", err)
+	}
+
+	want := map[string]bool{
+		"golang.org/x/net":          true,
+		"github.com/someone/forked": true,
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parsePackageModules() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_toolchainVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		goVersion string
+		want      string
+	}{
+		{goVersion: "go1.22.1\n", want: "v1.22.1"},
+		{goVersion: "go1.23rc1", want: "v1.23rc1"},
+		{goVersion: "go1.22.1 X:rangefunc\n", want: "v1.22.1"},
+		{goVersion: "devel go1.24-0a1b2c3d4e", want: ""},
+		{goVersion: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := toolchainVersion(tt.goVersion); got != tt.want {
+			t.Errorf("toolchainVersion(%q) = %q, want %q", tt.goVersion, got, tt.want)
+		}
+	}
+}
+
+func Test_parseGraph(t *testing.T) {
+	t.Parallel()
+
+	out := `example.com/project golang.org/x/net@v0.17.0
+example.com/project go@1.22
+golang.org/x/net@v0.17.0 golang.org/x/text@v0.13.0
+golang.org/x/net@v0.17.0 toolchain@go1.22.1
+`
+
+	want := []Requirement{
+		{
+			From: Module{Path: "example.com/project"},
+			To:   Module{Path: "golang.org/x/net", Version: "v0.17.0"},
+		},
+		{
+			From: Module{Path: "golang.org/x/net", Version: "v0.17.0"},
+			To:   Module{Path: "golang.org/x/text", Version: "v0.13.0"},
+		},
+	}
+
+	if diff := cmp.Diff(want, parseGraph(out)); diff != "" {
+		t.Errorf("parseGraph() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,145 @@
+package gomod
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/semantic"
+	"github.com/google/osv-scanner/v2/internal/clients/clientinterfaces"
+	"github.com/google/osv-scanner/v2/internal/sourceanalysis/govulncheck"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// ErrVulnerabilitiesFound is returned when any module in the build list is affected by a vulnerability
+var ErrVulnerabilitiesFound = errors.New("vulnerabilities found")
+
+// Result is the outcome of checking the modules of a build list for vulnerabilities
+type Result struct {
+	Modules []Module
+	// Vulns are the vulnerabilities affecting at least one module, sorted by id
+	Vulns []*osvschema.Vulnerability
+	// Findings are sorted by the id of the vulnerability and then by module
+	Findings []*govulncheck.Finding
+}
+
+// GoVersion returns the version of the standard library in the build list, if any
+func (r Result) GoVersion() string {
+	for _, m := range r.Modules {
+		if m.Path == StdlibModulePath {
+			return m.Version
+		}
+	}
+
+	return ""
+}
+
+// AffectedModules returns the number of modules that have at least one finding
+func (r Result) AffectedModules() int {
+	modules := make(map[string]bool)
+	for _, f := range r.Findings {
+		modules[f.Trace[0].Module] = true
+	}
+
+	return len(modules)
+}
+
+// Check matches the modules against known vulnerabilities, returning a finding
+// for each vulnerability that affects each module
+func Check(ctx context.Context, matcher clientinterfaces.VulnerabilityMatcher, modules []Module) (Result, error) {
+	pkgs := make([]*extractor.Package, 0, len(modules))
+	for _, m := range modules {
+		pkgs = append(pkgs, &extractor.Package{
+			Name:     m.Path,
+			Version:  strings.TrimPrefix(m.Version, "v"),
+			PURLType: purl.TypeGolang,
+		})
+	}
+
+	matched, err := matcher.MatchVulnerabilities(ctx, pkgs)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Modules: modules}
+	seen := make(map[string]bool)
+
+	for i, vulns := range matched {
+		for _, vuln := range vulns {
+			result.Findings = append(result.Findings, &govulncheck.Finding{
+				OSV:          vuln.ID,
+				FixedVersion: fixedVersion(vuln, modules[i]),
+				Trace:        []*govulncheck.Frame{{Module: modules[i].Path, Version: modules[i].Version}},
+			})
+
+			if !seen[vuln.ID] {
+				seen[vuln.ID] = true
+				result.Vulns = append(result.Vulns, vuln)
+			}
+		}
+	}
+
+	slices.SortFunc(result.Vulns, func(a, b *osvschema.Vulnerability) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	slices.SortFunc(result.Findings, func(a, b *govulncheck.Finding) int {
+		return cmp.Or(
+			cmp.Compare(a.OSV, b.OSV),
+			cmp.Compare(a.Trace[0].Module, b.Trace[0].Module),
+		)
+	})
+
+	return result, nil
+}
+
+// fixedVersion returns the lowest version of the module that fixes the vulnerability
+// and is greater than the version that is being used, with the "v" prefix.
+//
+// This is the behavior govulncheck uses for the standard library, which is also
+// used for other modules so that fixes on older release branches are suggested.
+func fixedVersion(vuln *osvschema.Vulnerability, m Module) string {
+	ecosystem := string(osvschema.EcosystemGo)
+
+	current, err := semantic.Parse(strings.TrimPrefix(m.Version, "v"), ecosystem)
+	if err != nil {
+		return ""
+	}
+
+	var lowest string
+	for _, affected := range vuln.Affected {
+		if affected.Package.Name != m.Path || affected.Package.Ecosystem != ecosystem {
+			continue
+		}
+
+		for _, r := range affected.Ranges {
+			for _, event := range r.Events {
+				if event.Fixed == "" {
+					continue
+				}
+
+				if order, err := current.CompareStr(event.Fixed); err != nil || order >= 0 {
+					continue
+				}
+
+				if lowest == "" {
+					lowest = event.Fixed
+					continue
+				}
+
+				if order, err := semantic.MustParse(event.Fixed, ecosystem).CompareStr(lowest); err == nil && order < 0 {
+					lowest = event.Fixed
+				}
+			}
+		}
+	}
+
+	if lowest == "" {
+		return ""
+	}
+
+	return "v" + lowest
+}
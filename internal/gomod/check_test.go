@@ -0,0 +1,239 @@
+package gomod_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/gomod"
+	"github.com/google/osv-scanner/v2/internal/sourceanalysis/govulncheck"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// mockMatcher returns the vulnerabilities listed for each module, regardless of its version
+type mockMatcher map[string][]*osvschema.Vulnerability
+
+func (m mockMatcher) MatchVulnerabilities(_ context.Context, pkgs []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	results := make([][]*osvschema.Vulnerability, len(pkgs))
+	for i, pkg := range pkgs {
+		results[i] = m[pkg.Name]
+	}
+
+	return results, nil
+}
+
+type failingMatcher struct{}
+
+func (failingMatcher) MatchVulnerabilities(_ context.Context, _ []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	return nil, errors.New("api unavailable")
+}
+
+func goVuln(id, module string, fixed ...string) *osvschema.Vulnerability {
+	events := []osvschema.Event{{Introduced: "0"}}
+	for _, f := range fixed {
+		events = append(events, osvschema.Event{Fixed: f})
+	}
+
+	return &osvschema.Vulnerability{
+		ID:      id,
+		Summary: "Summary of " + id,
+		Affected: []osvschema.Affected{
+			{
+				Package: osvschema.Package{Name: module, Ecosystem: string(osvschema.EcosystemGo)},
+				Ranges:  []osvschema.Range{{Type: osvschema.RangeSemVer, Events: events}},
+			},
+		},
+	}
+}
+
+var (
+	netVuln = goVuln("GO-2023-2102", "golang.org/x/net", "0.17.0", "0.19.0")
+	stdVuln = goVuln("GO-2024-2687", "stdlib", "1.21.9", "1.22.2")
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	modules := []gomod.Module{
+		{Path: "golang.org/x/net", Version: "v0.18.0"},
+		{Path: "golang.org/x/text", Version: "v0.14.0"},
+		{Path: "stdlib", Version: "v1.22.1"},
+	}
+
+	// a vulnerability without any fixes that affects multiple modules
+	sharedVuln := goVuln("GO-2024-0001", "golang.org/x/net")
+	sharedVuln.Affected = append(sharedVuln.Affected, goVuln("", "stdlib").Affected...)
+
+	result, err := gomod.Check(context.Background(), mockMatcher{
+		"golang.org/x/net": {sharedVuln, netVuln},
+		"stdlib":           {stdVuln, sharedVuln},
+	}, modules)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	wantFindings := []*govulncheck.Finding{
+		{
+			OSV:          "GO-2023-2102",
+			FixedVersion: "v0.19.0",
+			Trace:        []*govulncheck.Frame{{Module: "golang.org/x/net", Version: "v0.18.0"}},
+		},
+		{
+			OSV:   "GO-2024-0001",
+			Trace: []*govulncheck.Frame{{Module: "golang.org/x/net", Version: "v0.18.0"}},
+		},
+		{
+			OSV:   "GO-2024-0001",
+			Trace: []*govulncheck.Frame{{Module: "stdlib", Version: "v1.22.1"}},
+		},
+		{
+			OSV:          "GO-2024-2687",
+			FixedVersion: "v1.22.2",
+			Trace:        []*govulncheck.Frame{{Module: "stdlib", Version: "v1.22.1"}},
+		},
+	}
+
+	if diff := cmp.Diff(wantFindings, result.Findings); diff != "" {
+		t.Errorf("Check() findings mismatch (-want +got):\n%s", diff)
+	}
+
+	var gotIDs []string
+	for _, v := range result.Vulns {
+		gotIDs = append(gotIDs, v.ID)
+	}
+	if diff := cmp.Diff([]string{"GO-2023-2102", "GO-2024-0001", "GO-2024-2687"}, gotIDs); diff != "" {
+		t.Errorf("Check() vulns mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := result.AffectedModules(); got != 2 {
+		t.Errorf("AffectedModules() = %d, want 2", got)
+	}
+
+	if got := result.GoVersion(); got != "v1.22.1" {
+		t.Errorf("GoVersion() = %q, want %q", got, "v1.22.1")
+	}
+}
+
+func TestCheck_MatcherError(t *testing.T) {
+	t.Parallel()
+
+	_, err := gomod.Check(context.Background(), failingMatcher{}, []gomod.Module{{Path: "golang.org/x/net", Version: "v0.18.0"}})
+	if err == nil {
+		t.Errorf("Check() expected an error")
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	t.Parallel()
+
+	result, err := gomod.Check(context.Background(), mockMatcher{
+		"golang.org/x/net": {netVuln},
+		"stdlib":           {stdVuln},
+	}, []gomod.Module{
+		{Path: "golang.org/x/net", Version: "v0.18.0"},
+		{Path: "stdlib", Version: "v1.22.1"},
+	})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	gomod.WriteText(&buf, result)
+
+	want := `=== Module Results ===
+
+Vulnerability #1: GO-2023-2102
+    Summary of GO-2023-2102
+  More info: https://osv.dev/GO-2023-2102
+  Module: golang.org/x/net
+    Found in: golang.org/x/net@v0.18.0
+    Fixed in: golang.org/x/net@v0.19.0
+
+Vulnerability #2: GO-2024-2687
+    Summary of GO-2024-2687
+  More info: https://osv.dev/GO-2024-2687
+  Standard library
+    Found in: stdlib@go1.22.1
+    Fixed in: stdlib@go1.22.2
+
+Your code is affected by 2 vulnerabilities from 2 modules.
+`
+
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteText() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteText_NoVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	gomod.WriteText(&buf, gomod.Result{Modules: []gomod.Module{{Path: "stdlib", Version: "v1.22.1"}}})
+
+	if !strings.Contains(buf.String(), "No vulnerabilities found.") {
+		t.Errorf("WriteText() = %q, expected no vulnerabilities to be reported", buf.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	result, err := gomod.Check(context.Background(), mockMatcher{
+		"golang.org/x/net": {netVuln},
+	}, []gomod.Module{
+		{Path: "golang.org/x/net", Version: "v0.18.0"},
+		{Path: "stdlib", Version: "v1.22.1"},
+	})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gomod.WriteJSON(&buf, result, "https://api.osv.dev"); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	// the output should be readable as a stream of govulncheck messages
+	var messages []govulncheck.Message
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var msg govulncheck.Message
+		if err := dec.Decode(&msg); err != nil {
+			t.Fatalf("could not decode message: %v", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	if len(messages) != 3 {
+		t.Fatalf("WriteJSON() wrote %d messages, want 3", len(messages))
+	}
+
+	wantConfig := &govulncheck.Config{
+		ProtocolVersion: govulncheck.ProtocolVersion,
+		ScannerName:     "osv-scanner",
+		DB:              "https://api.osv.dev",
+		GoVersion:       "go1.22.1",
+		ScanLevel:       "module",
+		ScanMode:        "source",
+	}
+	if diff := cmp.Diff(wantConfig, messages[0].Config, cmpIgnoreScannerVersion); diff != "" {
+		t.Errorf("WriteJSON() config mismatch (-want +got):\n%s", diff)
+	}
+
+	if messages[1].OSV == nil || messages[1].OSV.ID != "GO-2023-2102" {
+		t.Errorf("WriteJSON() expected the second message to be the osv entry of GO-2023-2102, got %+v", messages[1])
+	}
+
+	if diff := cmp.Diff(result.Findings[0], messages[2].Finding); diff != "" {
+		t.Errorf("WriteJSON() finding mismatch (-want +got):\n%s", diff)
+	}
+}
+
+var cmpIgnoreScannerVersion = cmp.FilterPath(func(p cmp.Path) bool {
+	return p.Last().String() == ".ScannerVersion"
+}, cmp.Ignore())
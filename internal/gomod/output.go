@@ -0,0 +1,119 @@
+package gomod
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/sourceanalysis/govulncheck"
+	"github.com/google/osv-scanner/v2/internal/version"
+)
+
+// WriteJSON writes the result as a stream of govulncheck JSON messages, starting
+// with the config, followed by each vulnerability and then each finding.
+//
+// db describes where the vulnerabilities were loaded from.
+func WriteJSON(w io.Writer, r Result, db string) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	messages := make([]govulncheck.Message, 0, 1+len(r.Vulns)+len(r.Findings))
+	messages = append(messages, govulncheck.Message{
+		Config: &govulncheck.Config{
+			ProtocolVersion: govulncheck.ProtocolVersion,
+			ScannerName:     "osv-scanner",
+			ScannerVersion:  version.OSVVersion,
+			DB:              db,
+			GoVersion:       toGoVersion(r.GoVersion()),
+			ScanLevel:       "module",
+			ScanMode:        "source",
+		},
+	})
+
+	for _, vuln := range r.Vulns {
+		messages = append(messages, govulncheck.Message{OSV: vuln})
+	}
+
+	for _, finding := range r.Findings {
+		messages = append(messages, govulncheck.Message{Finding: finding})
+	}
+
+	for _, msg := range messages {
+		if err := encoder.Encode(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteText writes the result in the same layout as the text output of
+// govulncheck when scanning at the module level
+func WriteText(w io.Writer, r Result) {
+	fmt.Fprintln(w, "=== Module Results ===")
+	fmt.Fprintln(w)
+
+	if len(r.Findings) == 0 {
+		fmt.Fprintln(w, "No vulnerabilities found.")
+		return
+	}
+
+	for i, vuln := range r.Vulns {
+		fmt.Fprintf(w, "Vulnerability #%d: %s\n", i+1, vuln.ID)
+		if vuln.Summary != "" {
+			fmt.Fprintf(w, "    %s\n", vuln.Summary)
+		}
+		fmt.Fprintf(w, "  More info: https://osv.dev/%s\n", vuln.ID)
+
+		for _, f := range r.Findings {
+			if f.OSV != vuln.ID {
+				continue
+			}
+
+			frame := f.Trace[0]
+			if frame.Module == StdlibModulePath {
+				fmt.Fprintln(w, "  Standard library")
+			} else {
+				fmt.Fprintf(w, "  Module: %s\n", frame.Module)
+			}
+			fmt.Fprintf(w, "    Found in: %s\n", moduleAtVersion(frame.Module, frame.Version))
+			if f.FixedVersion == "" {
+				fmt.Fprintln(w, "    Fixed in: N/A")
+			} else {
+				fmt.Fprintf(w, "    Fixed in: %s\n", moduleAtVersion(frame.Module, f.FixedVersion))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(
+		w,
+		"Your code is affected by %d %s from %d %s.\n",
+		len(r.Vulns),
+		output.Form(len(r.Vulns), "vulnerability", "vulnerabilities"),
+		r.AffectedModules(),
+		output.Form(r.AffectedModules(), "module", "modules"),
+	)
+}
+
+// moduleAtVersion formats a module version in the same way as govulncheck,
+// which uses the name of the Go release for the standard library
+func moduleAtVersion(path, v string) string {
+	if path == StdlibModulePath {
+		return "stdlib@" + toGoVersion(v)
+	}
+
+	return path + "@" + v
+}
+
+// toGoVersion converts a module version of the standard library into the name
+// of the Go release, such as "v1.22.1" to "go1.22.1"
+func toGoVersion(v string) string {
+	if v == "" {
+		return ""
+	}
+
+	return "go" + strings.TrimPrefix(v, "v")
+}
@@ -0,0 +1,290 @@
+// Package imagediff compares the results of scanning two versions of a container image.
+package imagediff
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/opencontainers/go-digest"
+)
+
+// ErrVulnerabilitiesAdded is returned when the new image has vulnerabilities
+// that the old image does not have
+var ErrVulnerabilitiesAdded = errors.New("vulnerabilities added")
+
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeUpdated ChangeKind = "updated"
+)
+
+// Layer is a layer of one of the compared images
+type Layer struct {
+	Index   int           `json:"index"`
+	DiffID  digest.Digest `json:"diff_id"`
+	Command string        `json:"command"`
+}
+
+// PackageChange is a package that has been added, removed, or updated between images
+type PackageChange struct {
+	Kind       ChangeKind `json:"kind"`
+	Ecosystem  string     `json:"ecosystem"`
+	Name       string     `json:"name"`
+	Source     string     `json:"source"`
+	OldVersion string     `json:"old_version,omitempty"`
+	NewVersion string     `json:"new_version,omitempty"`
+	// Layer is the layer of the new image that the package was added or updated in,
+	// or the layer of the old image that a removed package was in
+	Layer *Layer `json:"layer,omitempty"`
+	// AddedVulns are the vulnerabilities the package has in the new image but not the
+	// old image, identified by the ids of each group of aliases
+	AddedVulns []string `json:"added_vulnerabilities,omitempty"`
+	// RemovedVulns are the vulnerabilities the package has in the old image but not the new image
+	RemovedVulns []string `json:"removed_vulnerabilities,omitempty"`
+}
+
+// Result is the difference between the results of scanning two images
+type Result struct {
+	OldImage string `json:"old_image"`
+	NewImage string `json:"new_image"`
+	// SharedLayers is the number of layers at the start of both images that are the same,
+	// which cannot contain any differences between the images
+	SharedLayers  int             `json:"shared_layers"`
+	RemovedLayers []Layer         `json:"removed_layers"`
+	AddedLayers   []Layer         `json:"added_layers"`
+	Packages      []PackageChange `json:"packages"`
+}
+
+// AddedVulns returns the total number of vulnerabilities added across all packages
+func (r Result) AddedVulns() int {
+	count := 0
+	for _, pkg := range r.Packages {
+		count += len(pkg.AddedVulns)
+	}
+
+	return count
+}
+
+// RemovedVulns returns the total number of vulnerabilities removed across all packages
+func (r Result) RemovedVulns() int {
+	count := 0
+	for _, pkg := range r.Packages {
+		count += len(pkg.RemovedVulns)
+	}
+
+	return count
+}
+
+// packageKey identifies a package independently of its version, so that
+// different versions of the same package can be paired as an update
+type packageKey struct {
+	source    string
+	ecosystem string
+	name      string
+}
+
+type imagePackage struct {
+	version string
+	layer   *Layer
+	vulns   []string
+}
+
+// Diff compares the results of scanning the old and new images, which must
+// include all packages (rather than just those with vulnerabilities).
+func Diff(oldImage, newImage string, oldResults, newResults models.VulnerabilityResults) Result {
+	oldLayers := layerMetadata(oldResults)
+	newLayers := layerMetadata(newResults)
+
+	shared := 0
+	for shared < len(oldLayers) && shared < len(newLayers) && sameLayer(oldLayers[shared], newLayers[shared]) {
+		shared++
+	}
+
+	result := Result{
+		OldImage:      oldImage,
+		NewImage:      newImage,
+		SharedLayers:  shared,
+		RemovedLayers: toLayers(oldLayers, shared),
+		AddedLayers:   toLayers(newLayers, shared),
+		Packages:      []PackageChange{},
+	}
+
+	oldPackages := collectPackages(oldResults, oldLayers)
+	newPackages := collectPackages(newResults, newLayers)
+
+	keys := make(map[packageKey]struct{})
+	for key := range oldPackages {
+		keys[key] = struct{}{}
+	}
+	for key := range newPackages {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		result.Packages = append(result.Packages, diffPackage(key, oldPackages[key], newPackages[key])...)
+	}
+
+	slices.SortFunc(result.Packages, func(a, b PackageChange) int {
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Ecosystem, b.Ecosystem),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.OldVersion, b.OldVersion),
+			cmp.Compare(a.NewVersion, b.NewVersion),
+		)
+	})
+
+	return result
+}
+
+// diffPackage compares the versions of a package in each image, pairing the versions
+// as an update if there is exactly one version that has been removed and added
+func diffPackage(key packageKey, oldVersions, newVersions map[string]imagePackage) []PackageChange {
+	var removed, added []imagePackage
+	for version, pkg := range oldVersions {
+		if _, ok := newVersions[version]; !ok {
+			removed = append(removed, pkg)
+		}
+	}
+	for version, pkg := range newVersions {
+		if _, ok := oldVersions[version]; !ok {
+			added = append(added, pkg)
+		}
+	}
+
+	if len(removed) == 1 && len(added) == 1 {
+		return []PackageChange{{
+			Kind:         ChangeUpdated,
+			Ecosystem:    key.ecosystem,
+			Name:         key.name,
+			Source:       key.source,
+			OldVersion:   removed[0].version,
+			NewVersion:   added[0].version,
+			Layer:        added[0].layer,
+			AddedVulns:   difference(added[0].vulns, removed[0].vulns),
+			RemovedVulns: difference(removed[0].vulns, added[0].vulns),
+		}}
+	}
+
+	changes := make([]PackageChange, 0, len(removed)+len(added))
+	for _, pkg := range removed {
+		changes = append(changes, PackageChange{
+			Kind:         ChangeRemoved,
+			Ecosystem:    key.ecosystem,
+			Name:         key.name,
+			Source:       key.source,
+			OldVersion:   pkg.version,
+			Layer:        pkg.layer,
+			RemovedVulns: pkg.vulns,
+		})
+	}
+	for _, pkg := range added {
+		changes = append(changes, PackageChange{
+			Kind:       ChangeAdded,
+			Ecosystem:  key.ecosystem,
+			Name:       key.name,
+			Source:     key.source,
+			NewVersion: pkg.version,
+			Layer:      pkg.layer,
+			AddedVulns: pkg.vulns,
+		})
+	}
+
+	return changes
+}
+
+func collectPackages(results models.VulnerabilityResults, layers []models.LayerMetadata) map[packageKey]map[string]imagePackage {
+	packages := make(map[packageKey]map[string]imagePackage)
+
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			key := packageKey{
+				source:    source.Source.Path,
+				ecosystem: pkg.Package.Ecosystem,
+				name:      pkg.Package.Name,
+			}
+
+			if packages[key] == nil {
+				packages[key] = make(map[string]imagePackage)
+			}
+
+			version := pkg.Package.Version
+			if version == "" {
+				version = pkg.Package.Commit
+			}
+
+			var vulns []string
+			for _, group := range pkg.Groups {
+				// groups without ids are for license violations
+				if len(group.IDs) == 0 {
+					continue
+				}
+				vulns = append(vulns, strings.Join(group.IDs, ", "))
+			}
+			slices.Sort(vulns)
+
+			imgPkg := imagePackage{version: version, vulns: vulns}
+			if pkg.Package.ImageOrigin != nil && pkg.Package.ImageOrigin.Index < len(layers) {
+				layer := toLayer(layers, pkg.Package.ImageOrigin.Index)
+				imgPkg.layer = &layer
+			}
+
+			packages[key][version] = imgPkg
+		}
+	}
+
+	return packages
+}
+
+func layerMetadata(results models.VulnerabilityResults) []models.LayerMetadata {
+	if results.ImageMetadata == nil {
+		return nil
+	}
+
+	return results.ImageMetadata.LayerMetadata
+}
+
+// sameLayer checks if the layers are the same, using the command of empty layers
+// since they all have the same (lack of) content
+func sameLayer(a, b models.LayerMetadata) bool {
+	if a.IsEmpty || b.IsEmpty {
+		return a.IsEmpty == b.IsEmpty && a.Command == b.Command
+	}
+
+	return a.DiffID == b.DiffID
+}
+
+func toLayer(layers []models.LayerMetadata, index int) Layer {
+	return Layer{
+		Index:   index,
+		DiffID:  layers[index].DiffID,
+		Command: layers[index].Command,
+	}
+}
+
+// toLayers returns the layers from the given index onwards
+func toLayers(layers []models.LayerMetadata, from int) []Layer {
+	result := make([]Layer, 0, len(layers)-from)
+	for i := from; i < len(layers); i++ {
+		result = append(result, toLayer(layers, i))
+	}
+
+	return result
+}
+
+// difference returns the values of a that are not in b
+func difference(a, b []string) []string {
+	var result []string
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,138 @@
+package imagediff_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/imagediff"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func imagePackage(name, version string, layer int, vulns ...string) models.PackageVulns {
+	groups := make([]models.GroupInfo, 0, len(vulns))
+	for _, id := range vulns {
+		groups = append(groups, models.GroupInfo{IDs: []string{id}})
+	}
+
+	return models.PackageVulns{
+		Package: models.PackageInfo{
+			Name:        name,
+			Version:     version,
+			Ecosystem:   "Alpine:v3.19",
+			ImageOrigin: &models.ImageOriginDetails{Index: layer},
+		},
+		Groups: groups,
+	}
+}
+
+func imageResults(layers []models.LayerMetadata, packages ...models.PackageVulns) models.VulnerabilityResults {
+	return models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source:   models.SourceInfo{Path: "/lib/apk/db/installed", Type: models.SourceTypeOSPackage},
+				Packages: packages,
+			},
+		},
+		ImageMetadata: &models.ImageMetadata{LayerMetadata: layers},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	base := models.LayerMetadata{DiffID: "sha256:base", Command: "ADD rootfs.tar.gz /"}
+	cmd := models.LayerMetadata{Command: `CMD ["/bin/sh"]`, IsEmpty: true}
+	oldApp := models.LayerMetadata{DiffID: "sha256:old", Command: "RUN apk add curl"}
+	newApp := models.LayerMetadata{DiffID: "sha256:new", Command: "RUN apk add curl openssl"}
+
+	oldResults := imageResults(
+		[]models.LayerMetadata{base, cmd, oldApp},
+		imagePackage("musl", "1.2.4-r2", 0),
+		imagePackage("busybox", "1.36.1-r15", 0, "CVE-2023-42363"),
+		imagePackage("curl", "8.5.0-r0", 2, "CVE-2024-0853", "CVE-2024-2398"),
+		imagePackage("nghttp2", "1.58.0-r0", 2),
+	)
+	newResults := imageResults(
+		[]models.LayerMetadata{base, cmd, newApp},
+		imagePackage("musl", "1.2.4-r2", 0),
+		imagePackage("busybox", "1.36.1-r15", 0, "CVE-2023-42363"),
+		imagePackage("curl", "8.9.0-r0", 2, "CVE-2024-6197"),
+		imagePackage("openssl", "3.1.4-r5", 2, "CVE-2024-0727"),
+	)
+
+	got := imagediff.Diff("alpine-app:1", "alpine-app:2", oldResults, newResults)
+
+	newLayer := &imagediff.Layer{Index: 2, DiffID: newApp.DiffID, Command: newApp.Command}
+	oldLayer := &imagediff.Layer{Index: 2, DiffID: oldApp.DiffID, Command: oldApp.Command}
+
+	want := imagediff.Result{
+		OldImage:      "alpine-app:1",
+		NewImage:      "alpine-app:2",
+		SharedLayers:  2,
+		RemovedLayers: []imagediff.Layer{*oldLayer},
+		AddedLayers:   []imagediff.Layer{*newLayer},
+		Packages: []imagediff.PackageChange{
+			{
+				Kind:         imagediff.ChangeUpdated,
+				Ecosystem:    "Alpine:v3.19",
+				Name:         "curl",
+				Source:       "/lib/apk/db/installed",
+				OldVersion:   "8.5.0-r0",
+				NewVersion:   "8.9.0-r0",
+				Layer:        newLayer,
+				AddedVulns:   []string{"CVE-2024-6197"},
+				RemovedVulns: []string{"CVE-2024-0853", "CVE-2024-2398"},
+			},
+			{
+				Kind:       imagediff.ChangeRemoved,
+				Ecosystem:  "Alpine:v3.19",
+				Name:       "nghttp2",
+				Source:     "/lib/apk/db/installed",
+				OldVersion: "1.58.0-r0",
+				Layer:      oldLayer,
+			},
+			{
+				Kind:       imagediff.ChangeAdded,
+				Ecosystem:  "Alpine:v3.19",
+				Name:       "openssl",
+				Source:     "/lib/apk/db/installed",
+				NewVersion: "3.1.4-r5",
+				Layer:      newLayer,
+				AddedVulns: []string{"CVE-2024-0727"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got.AddedVulns() != 2 {
+		t.Errorf("AddedVulns() = %d, want 2", got.AddedVulns())
+	}
+	if got.RemovedVulns() != 2 {
+		t.Errorf("RemovedVulns() = %d, want 2", got.RemovedVulns())
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	t.Parallel()
+
+	layers := []models.LayerMetadata{{DiffID: "sha256:base", Command: "ADD rootfs.tar.gz /"}}
+	results := imageResults(layers, imagePackage("musl", "1.2.4-r2", 0))
+
+	got := imagediff.Diff("alpine:3.19", "alpine:3.19", results, results)
+
+	want := imagediff.Result{
+		OldImage:      "alpine:3.19",
+		NewImage:      "alpine:3.19",
+		SharedLayers:  1,
+		RemovedLayers: []imagediff.Layer{},
+		AddedLayers:   []imagediff.Layer{},
+		Packages:      []imagediff.PackageChange{},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diff() mismatch (-want +got):\n%s", diff)
+	}
+}
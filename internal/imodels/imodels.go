@@ -20,8 +20,19 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
 	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/cpe"
 	"github.com/google/osv-scanner/v2/internal/imodels/ecosystem"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/githubactions"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/toolchain"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/external"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/filesystem/vendored"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/jsbundle"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/nodemodules"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/php/composerinstalled"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/r/renvlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/runtime/electron"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/detect"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/purllist"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/vcs/gitrepo"
 	"github.com/google/osv-scanner/v2/internal/utility/purl"
 	"github.com/google/osv-scanner/v2/internal/utility/semverlike"
@@ -33,12 +44,15 @@ import (
 )
 
 var sbomExtractors = map[string]struct{}{
-	spdx.Name: {},
-	cdx.Name:  {},
+	spdx.Name:     {},
+	cdx.Name:      {},
+	detect.Name:   {},
+	purllist.Name: {},
 }
 
 var gitExtractors = map[string]struct{}{
-	gitrepo.Name: {},
+	gitrepo.Name:  {},
+	vendored.Name: {},
 }
 
 var osExtractors = map[string]struct{}{
@@ -48,10 +62,13 @@ var osExtractors = map[string]struct{}{
 }
 
 var artifactExtractors = map[string]struct{}{
-	nodemodules.Name: {},
-	gobinary.Name:    {},
-	archive.Name:     {},
-	wheelegg.Name:    {},
+	nodemodules.Name:       {},
+	jsbundle.Name:          {},
+	gobinary.Name:          {},
+	archive.Name:           {},
+	wheelegg.Name:          {},
+	composerinstalled.Name: {},
+	electron.Name:          {},
 }
 
 // PackageInfo provides getter functions for commonly used fields of inventory
@@ -113,6 +130,31 @@ func (pkg *PackageInfo) Name() string {
 func (pkg *PackageInfo) Ecosystem() ecosystem.Parsed {
 	ecosystemStr := pkg.Package.Ecosystem()
 
+	// scalibr does not have a purl type for GitHub Actions to derive the ecosystem from
+	if _, ok := pkg.Metadata.(*githubactions.Metadata); ok {
+		ecosystemStr = string(osvschema.EcosystemGitHubActions)
+	}
+
+	// nor for toolchains pinned by CI and development environment configs
+	if metadata, ok := pkg.Metadata.(*toolchain.Metadata); ok {
+		ecosystemStr = string(toolchain.Ecosystem(metadata.Tool))
+	}
+
+	// or for R packages from Bioconductor
+	if metadata, ok := pkg.Metadata.(*renvlock.Metadata); ok && metadata.IsBioconductor() {
+		ecosystemStr = string(osvschema.EcosystemBioconductor)
+	}
+
+	// or for packages returned by external extractors, which give the ecosystem directly
+	if metadata, ok := pkg.Metadata.(*external.Metadata); ok {
+		ecosystemStr = metadata.Ecosystem
+	}
+
+	// or for SBOM components identified by CPEs, which have been mapped to a package
+	if metadata, ok := pkg.Metadata.(*cpe.Metadata); ok {
+		ecosystemStr = metadata.Ecosystem
+	}
+
 	// TODO(v2): SBOM special case, to be removed after PURL to ESI conversion within each extractor is complete
 	if pkg.purlCache != nil {
 		ecosystemStr = pkg.purlCache.Ecosystem
@@ -219,6 +261,18 @@ func (pkg *PackageInfo) OSPackageName() string {
 // FromInventory converts an extractor.Package into a PackageInfo.
 func FromInventory(inventory *extractor.Package) PackageInfo {
 	pi := PackageInfo{Package: inventory}
+
+	// purl lists have already been converted from purls when they were extracted
+	if metadata, ok := pi.Metadata.(*purllist.Metadata); ok {
+		pi.purlCache = &models.PackageInfo{
+			Name:      pi.Package.Name,
+			Version:   pi.Package.Version,
+			Ecosystem: metadata.Ecosystem,
+		}
+
+		return pi
+	}
+
 	if pi.SourceType() == models.SourceTypeSBOM {
 		purlStruct := converter.ToPURL(pi.Package)
 		if purlStruct != nil {
@@ -238,8 +292,9 @@ type PackageScanResult struct {
 	Vulnerabilities []*osvschema.Vulnerability
 	Licenses        []models.License
 	LayerDetails    *extractor.LayerDetails
+	// GoWorkspace is set if the package is a Go module required by a member of a Go workspace
+	GoWorkspace *models.GoWorkspace
 
 	// TODO(v2):
 	// SourceAnalysis *SourceAnalysis
-	// Any additional scan enrichment steps
 }
@@ -11,6 +11,12 @@ import (
 type ScanResults struct {
 	PackageScanResults []imodels.PackageScanResult
 
+	// Packages that were found but could not be scanned, along with the reason why
+	UnscannedPackages []models.UnscannedPackage
+
+	// Problems that were encountered during the scan without stopping it
+	Diagnostics []models.Diagnostic
+
 	// TODO(v2): Temporarily commented out until ScanParameters is moved
 	// to a shared package to avoid cyclic dependencies
 	// The user parameters for the scan
@@ -0,0 +1,289 @@
+// Package integrity reads the hashes that lockfiles record for the files of their packages,
+// so that they can be compared against the hashes published by the registries of the packages.
+package integrity
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// GoModFile is the file of Go modules that the second hash recorded by go.sum is of
+const GoModFile = "go.mod"
+
+// Hash is a hash of a file of a package, in the format that the lockfiles of its ecosystem
+// record it, such as "sha512-<base64>" for npm and "sha256:<hex>" for PyPI
+type Hash struct {
+	// File is the name of the file that the hash is of, which is empty for the only
+	// hash recorded for packages that are distributed as a single file
+	File  string
+	Value string
+}
+
+// Algorithm returns the algorithm of the hash, which prefixes its value
+func (h Hash) Algorithm() string {
+	algorithm, _, _ := strings.Cut(h.Value, ":")
+	if i := strings.Index(algorithm, "-"); i >= 0 {
+		algorithm = algorithm[:i]
+	}
+
+	return strings.ToLower(algorithm)
+}
+
+// Lockfile is the hashes recorded by a lockfile for each of its packages
+type Lockfile struct {
+	Ecosystem osvschema.Ecosystem
+	hashes    map[packageKey][]Hash
+}
+
+type packageKey struct {
+	name    string
+	version string
+}
+
+func newLockfile(ecosystem osvschema.Ecosystem) Lockfile {
+	return Lockfile{Ecosystem: ecosystem, hashes: make(map[packageKey][]Hash)}
+}
+
+func (l Lockfile) key(name, version string) packageKey {
+	switch l.Ecosystem {
+	case osvschema.EcosystemPyPI:
+		// PyPI names are case-insensitive, and treat runs of "-", "_", and "." as the same
+		name = strings.ToLower(cachedregexp.MustCompile(`[-_.]+`).ReplaceAllString(name, "-"))
+	case osvschema.EcosystemGo:
+		version = strings.TrimPrefix(version, "v")
+	}
+
+	return packageKey{name: name, version: version}
+}
+
+func (l Lockfile) add(name, version string, hashes ...Hash) {
+	key := l.key(name, version)
+	for _, hash := range hashes {
+		if hash.Value != "" && !slices.Contains(l.hashes[key], hash) {
+			l.hashes[key] = append(l.hashes[key], hash)
+		}
+	}
+}
+
+// Hashes returns the hashes that the lockfile records for the given version of the package
+func (l Lockfile) Hashes(name, version string) []Hash {
+	return l.hashes[l.key(name, version)]
+}
+
+// HashesPath returns the path of the file that records the hashes of the packages
+// extracted from the file at the given path, and whether it records any
+func HashesPath(lockfilePath string) (string, bool) {
+	switch filepath.Base(lockfilePath) {
+	case "package-lock.json", "npm-shrinkwrap.json", "poetry.lock", "go.sum":
+		return lockfilePath, true
+	case "go.mod":
+		// go.mod files do not record hashes, but the go.sum files next to them do
+		return filepath.Join(filepath.Dir(lockfilePath), "go.sum"), true
+	}
+
+	return "", false
+}
+
+// Read reads the hashes recorded by the lockfile at the given path, which must be a path
+// returned by HashesPath
+func Read(lockfilePath string) (Lockfile, error) {
+	content, err := os.ReadFile(lockfilePath)
+	if err != nil {
+		return Lockfile{}, err
+	}
+
+	switch filepath.Base(lockfilePath) {
+	case "package-lock.json", "npm-shrinkwrap.json":
+		return parseNpmLockfile(content)
+	case "poetry.lock":
+		return parsePoetryLockfile(content)
+	case "go.sum":
+		return parseGoSum(content)
+	}
+
+	return Lockfile{}, fmt.Errorf("%s does not record the hashes of packages", lockfilePath)
+}
+
+type npmLockDependency struct {
+	Version      string                       `json:"version"`
+	Integrity    string                       `json:"integrity"`
+	Dependencies map[string]npmLockDependency `json:"dependencies,omitempty"`
+}
+
+type npmLockPackage struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Integrity string `json:"integrity"`
+	Link      bool   `json:"link"`
+}
+
+type npmLockfile struct {
+	// Dependencies is only present in lockfiles before version 3
+	Dependencies map[string]npmLockDependency `json:"dependencies,omitempty"`
+	// Packages is only present in lockfiles from version 2
+	Packages map[string]npmLockPackage `json:"packages,omitempty"`
+}
+
+func parseNpmLockfile(content []byte) (Lockfile, error) {
+	var parsed npmLockfile
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		return Lockfile{}, fmt.Errorf("could not parse npm lockfile: %w", err)
+	}
+
+	lockfile := newLockfile(osvschema.EcosystemNPM)
+
+	if parsed.Packages != nil {
+		for location, pkg := range parsed.Packages {
+			// the root package and workspaces are not installed from the registry
+			if pkg.Link || !strings.Contains(location, "node_modules/") {
+				continue
+			}
+
+			name := pkg.Name
+			if name == "" {
+				name = location[strings.LastIndex(location, "node_modules/")+len("node_modules/"):]
+			}
+
+			lockfile.add(name, pkg.Version, npmIntegrityHashes(pkg.Integrity)...)
+		}
+
+		return lockfile, nil
+	}
+
+	var addDependencies func(map[string]npmLockDependency)
+	addDependencies = func(deps map[string]npmLockDependency) {
+		for name, dep := range deps {
+			lockfile.add(name, dep.Version, npmIntegrityHashes(dep.Integrity)...)
+			addDependencies(dep.Dependencies)
+		}
+	}
+	addDependencies(parsed.Dependencies)
+
+	return lockfile, nil
+}
+
+// npmIntegrityHashes splits a subresource integrity string, which can have
+// multiple space-separated hashes, into its hashes
+func npmIntegrityHashes(integrity string) []Hash {
+	fields := strings.Fields(integrity)
+	hashes := make([]Hash, 0, len(fields))
+	for _, field := range fields {
+		hashes = append(hashes, Hash{Value: field})
+	}
+
+	return hashes
+}
+
+type poetryFile struct {
+	File string `toml:"file"`
+	Hash string `toml:"hash"`
+}
+
+type poetryLockfile struct {
+	Packages []struct {
+		Name    string       `toml:"name"`
+		Version string       `toml:"version"`
+		Files   []poetryFile `toml:"files"`
+		Source  struct {
+			Type string `toml:"type"`
+		} `toml:"source"`
+	} `toml:"package"`
+	Metadata struct {
+		// Files is only present in lockfiles from before Poetry 1.5
+		Files map[string][]poetryFile `toml:"files"`
+	} `toml:"metadata"`
+}
+
+func parsePoetryLockfile(content []byte) (Lockfile, error) {
+	var parsed poetryLockfile
+	if _, err := toml.Decode(string(content), &parsed); err != nil {
+		return Lockfile{}, fmt.Errorf("could not parse poetry lockfile: %w", err)
+	}
+
+	lockfile := newLockfile(osvschema.EcosystemPyPI)
+
+	for _, pkg := range parsed.Packages {
+		// packages from git, directories, urls, and other indexes are not published to PyPI
+		if pkg.Source.Type != "" {
+			continue
+		}
+
+		files := pkg.Files
+		if len(files) == 0 {
+			files = parsed.Metadata.Files[pkg.Name]
+		}
+
+		for _, file := range files {
+			lockfile.add(pkg.Name, pkg.Version, Hash{File: file.File, Value: file.Hash})
+		}
+	}
+
+	return lockfile, nil
+}
+
+func parseGoSum(content []byte) (Lockfile, error) {
+	lockfile := newLockfile(osvschema.EcosystemGo)
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return Lockfile{}, fmt.Errorf("could not parse go.sum: malformed line %q", scanner.Text())
+		}
+
+		file := ""
+		version, found := strings.CutSuffix(fields[1], "/"+GoModFile)
+		if found {
+			file = GoModFile
+		}
+
+		lockfile.add(fields[0], version, Hash{File: file, Value: fields[2]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Lockfile{}, fmt.Errorf("could not parse go.sum: %w", err)
+	}
+
+	return lockfile, nil
+}
+
+// Mismatches returns the recorded hashes that do not match the published hash of
+// the same file that uses the same algorithm; recorded hashes are not compared if
+// there are no such published hashes, such as for files that are no longer published
+func Mismatches(recorded []Hash, published []Hash) []Hash {
+	var mismatched []Hash
+
+	for _, hash := range recorded {
+		comparable := false
+		matched := false
+
+		for _, p := range published {
+			if p.File != hash.File || p.Algorithm() != hash.Algorithm() {
+				continue
+			}
+
+			comparable = true
+			if p.Value == hash.Value {
+				matched = true
+			}
+		}
+
+		if comparable && !matched {
+			mismatched = append(mismatched, hash)
+		}
+	}
+
+	return mismatched
+}
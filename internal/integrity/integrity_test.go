@@ -0,0 +1,191 @@
+package integrity_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/integrity"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func TestHashesPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOk bool
+	}{
+		{path: filepath.FromSlash("app/package-lock.json"), want: filepath.FromSlash("app/package-lock.json"), wantOk: true},
+		{path: filepath.FromSlash("app/poetry.lock"), want: filepath.FromSlash("app/poetry.lock"), wantOk: true},
+		{path: filepath.FromSlash("app/go.mod"), want: filepath.FromSlash("app/go.sum"), wantOk: true},
+		{path: filepath.FromSlash("app/yarn.lock"), want: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotOk := integrity.HashesPath(tt.path)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("HashesPath(%q) = %q, %v, want %q, %v", tt.path, got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	type pkg struct {
+		name    string
+		version string
+		want    []integrity.Hash
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		wantEcosystem osvschema.Ecosystem
+		packages      []pkg
+	}{
+		{
+			name:          "npm lockfile v1",
+			path:          "testdata/npm-v1/package-lock.json",
+			wantEcosystem: osvschema.EcosystemNPM,
+			packages: []pkg{
+				{name: "wrappy", version: "1.0.2", want: []integrity.Hash{{Value: "sha1-tSQ9jz7BqjXxNkYFvA0QNuMKtp8="}}},
+				{name: "wrappy", version: "1.0.1", want: []integrity.Hash{{Value: "sha1-ZQTIHMkfI4yIvsH3bDdYyJmWvoA="}}},
+				{name: "once", version: "1.4.0", want: []integrity.Hash{{Value: "sha1-WDsap3WWHUsROsF9nFC6753Xa9E="}}},
+			},
+		},
+		{
+			name:          "npm lockfile v3",
+			path:          "testdata/npm-v3/package-lock.json",
+			wantEcosystem: osvschema.EcosystemNPM,
+			packages: []pkg{
+				{
+					name:    "@babel/code-frame",
+					version: "7.24.7",
+					want:    []integrity.Hash{{Value: "sha512-BcYH1CVJBO9tvyIZ2jVeXgSIMvGZ2FDRvDdOIVQyuklNKSsx+eppDEBq/g47Ayw+RqNFE+URvOShmf+f/qwAlA=="}},
+				},
+				{
+					name:    "string-width",
+					version: "4.2.3",
+					want:    []integrity.Hash{{Value: "sha512-wKyQRQpjJ0sIp62ErSZdGsjMJWsap5oRNihHhu6G7JVO/9jIB6UyevL+tXuOqrng8j/cxKTWyWUwvSTriiZz/g=="}},
+				},
+				{
+					name:    "wrappy",
+					version: "1.0.2",
+					want: []integrity.Hash{
+						{Value: "sha512-l4Sp/DRseor9wL6EvV2+TuQn63dMkPjZ/sp9XkghTEbV9KlPS1xUsZ3u7/IQO4wxtcFB4bgpQPRcR3QCvezPcQ=="},
+						{Value: "sha1-tSQ9jz7BqjXxNkYFvA0QNuMKtp8="},
+					},
+				},
+				{name: "lib", version: "0.1.0", want: nil},
+				{name: "my-app", version: "1.0.0", want: nil},
+			},
+		},
+		{
+			name:          "poetry lockfile",
+			path:          "testdata/poetry/poetry.lock",
+			wantEcosystem: osvschema.EcosystemPyPI,
+			packages: []pkg{
+				{
+					name:    "six",
+					version: "1.16.0",
+					want: []integrity.Hash{
+						{File: "six-1.16.0-py2.py3-none-any.whl", Value: "sha256:8abb2f1d86890a2dfb989f9a77cfcfd3e47c2a354b01111771326f8aa26e0254"},
+						{File: "six-1.16.0.tar.gz", Value: "sha256:1e61c37477a1626458e36f7b1d82aa5c9b094fa4802892072e49de9c60c4c926"},
+					},
+				},
+				{name: "private-lib", version: "0.1.0", want: nil},
+			},
+		},
+		{
+			name:          "poetry lockfile with metadata files",
+			path:          "testdata/poetry-legacy/poetry.lock",
+			wantEcosystem: osvschema.EcosystemPyPI,
+			packages: []pkg{
+				{
+					name:    "Six",
+					version: "1.16.0",
+					want: []integrity.Hash{
+						{File: "six-1.16.0-py2.py3-none-any.whl", Value: "sha256:8abb2f1d86890a2dfb989f9a77cfcfd3e47c2a354b01111771326f8aa26e0254"},
+						{File: "six-1.16.0.tar.gz", Value: "sha256:1e61c37477a1626458e36f7b1d82aa5c9b094fa4802892072e49de9c60c4c926"},
+					},
+				},
+			},
+		},
+		{
+			name:          "go.sum",
+			path:          "testdata/go/go.sum",
+			wantEcosystem: osvschema.EcosystemGo,
+			packages: []pkg{
+				{
+					name:    "github.com/BurntSushi/toml",
+					version: "1.4.0",
+					want: []integrity.Hash{
+						{Value: "h1:kuoIxZQy2WRRk1pttg9asf+WVv6tWQuBNVmK4+nh1NE="},
+						{File: integrity.GoModFile, Value: "h1:ukJfTF/6rtPPRCnwkur4qwRxa8vTRFBF0uk2lLoLwho="},
+					},
+				},
+				{
+					name:    "golang.org/x/text",
+					version: "v0.3.0",
+					want:    []integrity.Hash{{File: integrity.GoModFile, Value: "h1:NqM8EUOU14njkJ3fqMW+pc6Ldnwhi/IjpwHt7yyuwOQ="}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lockfile, err := integrity.Read(tt.path)
+			if err != nil {
+				t.Fatalf("Read(%q) error = %v", tt.path, err)
+			}
+
+			if lockfile.Ecosystem != tt.wantEcosystem {
+				t.Errorf("Read(%q).Ecosystem = %q, want %q", tt.path, lockfile.Ecosystem, tt.wantEcosystem)
+			}
+
+			for _, p := range tt.packages {
+				if diff := cmp.Diff(p.want, lockfile.Hashes(p.name, p.version)); diff != "" {
+					t.Errorf("Hashes(%q, %q) mismatch (-want +got):\n%s", p.name, p.version, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMismatches(t *testing.T) {
+	t.Parallel()
+
+	published := []integrity.Hash{
+		{File: "six-1.16.0-py2.py3-none-any.whl", Value: "sha256:8abb2f1d86890a2dfb989f9a77cfcfd3e47c2a354b01111771326f8aa26e0254"},
+		{File: "six-1.16.0.tar.gz", Value: "sha256:1e61c37477a1626458e36f7b1d82aa5c9b094fa4802892072e49de9c60c4c926"},
+	}
+
+	recorded := []integrity.Hash{
+		// matches the published hash
+		{File: "six-1.16.0-py2.py3-none-any.whl", Value: "sha256:8abb2f1d86890a2dfb989f9a77cfcfd3e47c2a354b01111771326f8aa26e0254"},
+		// does not match the published hash
+		{File: "six-1.16.0.tar.gz", Value: "sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+		// is of a file that is not published
+		{File: "six-1.16.0-py3-none-any.whl", Value: "sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+		// uses an algorithm that is not published
+		{File: "six-1.16.0.tar.gz", Value: "md5:00000000000000000000000000000000"},
+	}
+
+	want := []integrity.Hash{
+		{File: "six-1.16.0.tar.gz", Value: "sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+	}
+
+	if diff := cmp.Diff(want, integrity.Mismatches(recorded, published)); diff != "" {
+		t.Errorf("Mismatches() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,110 @@
+// Package kev checks CVEs against the Known Exploited Vulnerabilities (KEV)
+// catalog of CISA, using either the snapshot of the catalog that is bundled
+// with osv-scanner or the latest version of the catalog fetched from CISA.
+package kev
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// CatalogURL is where the latest version of the catalog is published by CISA
+const CatalogURL = "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json"
+
+// bundledCatalog is a snapshot of the catalog, updated with scripts/update_kev_catalog.sh
+//
+//go:embed catalog.json
+var bundledCatalog []byte
+
+// catalogEntry is a vulnerability as listed in the json feed of the catalog
+type catalogEntry struct {
+	CVEID                      string `json:"cveID"`
+	DateAdded                  string `json:"dateAdded"`
+	DueDate                    string `json:"dueDate"`
+	RequiredAction             string `json:"requiredAction"`
+	KnownRansomwareCampaignUse string `json:"knownRansomwareCampaignUse"`
+}
+
+// Catalog is a version of the KEV catalog
+type Catalog struct {
+	// Version is the version of the catalog, in the form of YYYY.MM.DD
+	Version string
+	entries map[string]models.KEVEntry
+}
+
+// Parse parses the json feed of the catalog
+func Parse(r io.Reader) (*Catalog, error) {
+	var feed struct {
+		CatalogVersion  string         `json:"catalogVersion"`
+		Vulnerabilities []catalogEntry `json:"vulnerabilities"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("could not parse KEV catalog: %w", err)
+	}
+
+	catalog := &Catalog{
+		Version: feed.CatalogVersion,
+		entries: make(map[string]models.KEVEntry, len(feed.Vulnerabilities)),
+	}
+
+	for _, v := range feed.Vulnerabilities {
+		catalog.entries[v.CVEID] = models.KEVEntry{
+			CVE:                        v.CVEID,
+			DateAdded:                  v.DateAdded,
+			DueDate:                    v.DueDate,
+			RequiredAction:             v.RequiredAction,
+			KnownRansomwareCampaignUse: v.KnownRansomwareCampaignUse == "Known",
+		}
+	}
+
+	return catalog, nil
+}
+
+// Bundled returns the snapshot of the catalog that is bundled with osv-scanner
+func Bundled() (*Catalog, error) {
+	return Parse(bytes.NewReader(bundledCatalog))
+}
+
+// Fetch downloads the latest version of the catalog from url, which is usually CatalogURL
+func Fetch(ctx context.Context, client *http.Client, url string, userAgent string) (*Catalog, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch KEV catalog: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch KEV catalog: status=%q", resp.Status)
+	}
+
+	return Parse(resp.Body)
+}
+
+// Lookup returns the entry of the CVE in the catalog, if it has been exploited
+func (c *Catalog) Lookup(cve string) (models.KEVEntry, bool) {
+	entry, ok := c.entries[cve]
+
+	return entry, ok
+}
+
+// Len returns the number of CVEs in the catalog
+func (c *Catalog) Len() int {
+	return len(c.entries)
+}
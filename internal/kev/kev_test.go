@@ -0,0 +1,87 @@
+package kev_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/kev"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func TestBundled(t *testing.T) {
+	t.Parallel()
+
+	catalog, err := kev.Bundled()
+	if err != nil {
+		t.Fatalf("Bundled() error = %v", err)
+	}
+
+	if catalog.Version == "" {
+		t.Errorf("Bundled() has no version")
+	}
+
+	want := models.KEVEntry{
+		CVE:                        "CVE-2021-44228",
+		DateAdded:                  "2021-12-10",
+		DueDate:                    "2021-12-24",
+		RequiredAction:             "Apply updates per vendor instructions.",
+		KnownRansomwareCampaignUse: true,
+	}
+
+	got, ok := catalog.Lookup("CVE-2021-44228")
+	if !ok {
+		t.Fatalf("Lookup(%q) did not find the CVE", want.CVE)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Lookup() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, ok := catalog.Lookup("CVE-2024-0000"); ok {
+		t.Errorf("Lookup() found a CVE that is not in the catalog")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{
+			"catalogVersion": "2025.01.02",
+			"vulnerabilities": [
+				{"cveID": "CVE-2024-1", "dateAdded": "2025-01-02", "dueDate": "2025-01-23", "knownRansomwareCampaignUse": "Unknown"}
+			]
+		}`)
+	}))
+	t.Cleanup(server.Close)
+
+	catalog, err := kev.Fetch(t.Context(), server.Client(), server.URL, "")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if catalog.Version != "2025.01.02" || catalog.Len() != 1 {
+		t.Errorf("Fetch() = version %q with %d CVEs, want version 2025.01.02 with 1 CVE", catalog.Version, catalog.Len())
+	}
+
+	want := models.KEVEntry{CVE: "CVE-2024-1", DateAdded: "2025-01-02", DueDate: "2025-01-23"}
+	if got, _ := catalog.Lookup("CVE-2024-1"); got != want {
+		t.Errorf("Lookup() = %v, want %v", got, want)
+	}
+}
+
+func TestFetch_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	if _, err := kev.Fetch(t.Context(), server.Client(), server.URL, ""); err == nil {
+		t.Errorf("Fetch() error = nil, want an error")
+	}
+}
@@ -0,0 +1,86 @@
+package layercache
+
+import (
+	"context"
+	"fmt"
+
+	scalibrimage "github.com/google/osv-scalibr/artifact/image"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/opencontainers/go-digest"
+)
+
+// cachingExtractor is an extractor that caches the packages extracted from
+// each file by the layer that the file is from
+type cachingExtractor struct {
+	filesystem.Extractor
+
+	layers []scalibrimage.ChainLayer
+	cache  *Cache
+}
+
+// Wrap returns the extractors wrapped so that the packages they extract from the files
+// of the image with the given chain layers are cached in the cache.
+//
+// Files are cached by the chain ID of the layer that they are from rather than its diff ID,
+// as the chain ID also covers the layers before it which extractors can read other files
+// from, such as the "/etc/os-release" file that OS package extractors read. Files from later
+// layers that extractors read are not covered, though these are rarely changed by themselves.
+func Wrap(extractors []filesystem.Extractor, layers []scalibrimage.ChainLayer, cache *Cache) []filesystem.Extractor {
+	wrapped := make([]filesystem.Extractor, 0, len(extractors))
+
+	for _, ex := range extractors {
+		wrapped = append(wrapped, cachingExtractor{Extractor: ex, layers: layers, cache: cache})
+	}
+
+	return wrapped
+}
+
+// Extract returns the cached packages of the file if there are any, and otherwise
+// extracts and caches them. Files that are not from a layer of the image, such as
+// those that are only reachable through a symlink from another layer, are not cached.
+func (e cachingExtractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	chainID, ok := e.origin(input)
+	if !ok {
+		return e.Extractor.Extract(ctx, input)
+	}
+
+	key := fmt.Sprintf("%s@%d:%s:%s", e.Name(), e.Version(), chainID, input.Path)
+
+	if pkgs, ok := e.cache.Get(key); ok {
+		return inventory.Inventory{Packages: pkgs}, nil
+	}
+
+	inv, err := e.Extractor.Extract(ctx, input)
+	if err != nil {
+		return inv, err
+	}
+
+	// the cache only holds packages, so anything else that is extracted is not cached
+	if len(inv.PackageVulns) == 0 && len(inv.GenericFindings) == 0 && len(inv.Secrets) == 0 {
+		e.cache.Put(key, inv.Packages)
+	}
+
+	return inv, nil
+}
+
+// origin returns the chain ID of the layer that the file being extracted is from, which is
+// the layer that has the same file as the filesystem being extracted from; this is not
+// always the last layer with the file, as the file system may be of an earlier chain layer
+func (e cachingExtractor) origin(input *filesystem.ScanInput) (digest.Digest, bool) {
+	info, err := input.FS.Stat(input.Path)
+	if err != nil {
+		return "", false
+	}
+
+	for i := len(e.layers) - 1; i >= 0; i-- {
+		layerInfo, err := e.layers[i].Layer().FS().Stat(input.Path)
+		if err == nil && layerInfo == info {
+			return e.layers[i].ChainID(), true
+		}
+	}
+
+	return "", false
+}
+
+var _ filesystem.Extractor = cachingExtractor{}
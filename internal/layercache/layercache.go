@@ -0,0 +1,151 @@
+// Package layercache caches the packages extracted from the files of container image layers,
+// so that layers shared between images, such as those of a common base image, are only
+// extracted once.
+package layercache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+
+	scalibrproto "github.com/google/osv-scalibr/binary/proto"
+	spb "github.com/google/osv-scalibr/binary/proto/scan_result_go_proto"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"google.golang.org/protobuf/proto"
+)
+
+// Cache holds the packages extracted from files of image layers, keyed by the extractor,
+// the chain ID of the layer that the file is from, and the path of the file.
+//
+// Entries are always kept in memory, so a Cache can be shared between the scans of many
+// images by the same process. If the Cache has a directory, entries are also persisted to
+// it so that they can be reused by later runs.
+type Cache struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[string][]*extractor.Package
+}
+
+// New returns a Cache that persists its entries to dir, or that is only held
+// in memory if dir is empty
+func New(dir string) *Cache {
+	return &Cache{
+		dir:     dir,
+		entries: make(map[string][]*extractor.Package),
+	}
+}
+
+// Get returns a copy of the packages cached for the key, if there are any
+func (c *Cache) Get(key string) ([]*extractor.Package, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pkgs, ok := c.entries[key]
+	if !ok && c.dir != "" {
+		pkgs, ok = c.read(key)
+		if ok {
+			c.entries[key] = pkgs
+		}
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	return clonePackages(pkgs), true
+}
+
+// Put caches a copy of the packages for the key
+func (c *Cache) Put(key string, pkgs []*extractor.Package) {
+	pkgs = clonePackages(pkgs)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = pkgs
+
+	if c.dir != "" {
+		if err := c.write(key, pkgs); err != nil {
+			cmdlogger.Warnf("Failed to write layer cache entry: %s", err)
+		}
+	}
+}
+
+// path returns where the entry for the key is persisted, which is named after
+// the hash of the key as keys contain the paths of files
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".binproto")
+}
+
+func (c *Cache) read(key string) ([]*extractor.Package, bool) {
+	content, err := os.ReadFile(c.path(key))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			cmdlogger.Warnf("Failed to read layer cache entry: %s", err)
+		}
+
+		return nil, false
+	}
+
+	var inv spb.Inventory
+	if err := proto.Unmarshal(content, &inv); err != nil {
+		cmdlogger.Warnf("Failed to read layer cache entry: %s", err)
+
+		return nil, false
+	}
+
+	return scalibrproto.InventoryToStruct(&inv).Packages, true
+}
+
+// write persists the packages for the key, unless any of them would not be read back
+// the same, which is the case for packages with metadata that scalibr has no proto for
+func (c *Cache) write(key string, pkgs []*extractor.Package) error {
+	inv, err := scalibrproto.InventoryToProto(&inventory.Inventory{Packages: pkgs})
+	if err != nil {
+		return err
+	}
+
+	for i, pkg := range scalibrproto.InventoryToStruct(inv).Packages {
+		if reflect.TypeOf(pkg.Metadata) != reflect.TypeOf(pkgs[i].Metadata) {
+			return nil
+		}
+	}
+
+	content, err := proto.Marshal(inv)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("could not create %s: %w", c.dir, err)
+	}
+
+	return os.WriteFile(c.path(key), content, 0o600)
+}
+
+// clonePackages copies the packages so that neither the cache nor the scans that use
+// it are affected by the details of the layers of a particular image being filled in
+func clonePackages(pkgs []*extractor.Package) []*extractor.Package {
+	cloned := make([]*extractor.Package, 0, len(pkgs))
+
+	for _, pkg := range pkgs {
+		c := *pkg
+		c.Locations = slices.Clone(pkg.Locations)
+		c.LayerDetails = nil
+
+		cloned = append(cloned, &c)
+	}
+
+	return cloned
+}
@@ -0,0 +1,262 @@
+package layercache_test
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/artifact/image/layerscanning/image"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/layercache"
+)
+
+// fakeExtractor extracts a package for each line of "packages.txt" files,
+// recording the paths of the files that it has extracted
+type fakeExtractor struct {
+	mu        *sync.Mutex
+	extracted *[]string
+}
+
+func newFakeExtractor() fakeExtractor {
+	return fakeExtractor{mu: &sync.Mutex{}, extracted: &[]string{}}
+}
+
+func (e fakeExtractor) Name() string                       { return "test/packages" }
+func (e fakeExtractor) Version() int                       { return 0 }
+func (e fakeExtractor) Requirements() *plugin.Capabilities { return &plugin.Capabilities{} }
+
+func (e fakeExtractor) FileRequired(fapi filesystem.FileAPI) bool {
+	return filepath.Base(fapi.Path()) == "packages.txt"
+}
+
+func (e fakeExtractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	e.mu.Lock()
+	*e.extracted = append(*e.extracted, input.Path)
+	e.mu.Unlock()
+
+	var pkgs []*extractor.Package
+
+	scanner := bufio.NewScanner(input.Reader)
+	for scanner.Scan() {
+		pkgs = append(pkgs, &extractor.Package{
+			Name:      scanner.Text(),
+			Version:   "1.0.0",
+			PURLType:  purl.TypeGeneric,
+			Locations: []string{input.Path},
+		})
+	}
+
+	return inventory.Inventory{Packages: pkgs}, scanner.Err()
+}
+
+// reset returns the paths of the files that have been extracted since it was last called
+func (e fakeExtractor) reset() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	extracted := slices.Compact(slices.Sorted(slices.Values(*e.extracted)))
+	*e.extracted = nil
+
+	return extracted
+}
+
+func newLayer(t *testing.T, files map[string]string) v1.Layer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		content := files[name]
+
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return layer
+}
+
+func newImage(t *testing.T, layers ...v1.Layer) *image.Image {
+	t.Helper()
+
+	v1Image, err := mutate.AppendLayers(empty.Image, layers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := image.FromV1Image(v1Image, image.DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := img.CleanUp(); err != nil {
+			t.Errorf("failed to clean up image: %v", err)
+		}
+	})
+
+	return img
+}
+
+type scannedPackage struct {
+	Name       string
+	LayerIndex int
+}
+
+func scan(t *testing.T, img *image.Image, extractors []filesystem.Extractor) []scannedPackage {
+	t.Helper()
+
+	sr, err := scalibr.New().ScanContainer(context.Background(), img, &scalibr.ScanConfig{
+		FilesystemExtractors: extractors,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs := make([]scannedPackage, 0, len(sr.Inventory.Packages))
+	for _, pkg := range sr.Inventory.Packages {
+		sp := scannedPackage{Name: pkg.Name, LayerIndex: -1}
+		if pkg.LayerDetails != nil {
+			sp.LayerIndex = pkg.LayerDetails.Index
+		}
+
+		pkgs = append(pkgs, sp)
+	}
+
+	slices.SortFunc(pkgs, func(a, b scannedPackage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return pkgs
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	base := newLayer(t, map[string]string{"base/packages.txt": "openssl\nzlib\n"})
+	app1 := newLayer(t, map[string]string{"app/packages.txt": "left-pad\n"})
+	app2 := newLayer(t, map[string]string{"app/packages.txt": "right-pad\n"})
+
+	img1 := newImage(t, base, app1)
+	img2 := newImage(t, base, app2)
+
+	ex := newFakeExtractor()
+	dir := t.TempDir()
+	cache := layercache.New(dir)
+
+	wrap := func(img *image.Image, cache *layercache.Cache) []filesystem.Extractor {
+		t.Helper()
+
+		layers, err := img.ChainLayers()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return layercache.Wrap([]filesystem.Extractor{ex}, layers, cache)
+	}
+
+	want1 := []scannedPackage{{"left-pad", 1}, {"openssl", 0}, {"zlib", 0}}
+	want2 := []scannedPackage{{"openssl", 0}, {"right-pad", 1}, {"zlib", 0}}
+
+	// the first scan extracts every file
+	if diff := cmp.Diff(want1, scan(t, img1, wrap(img1, cache))); diff != "" {
+		t.Errorf("first scan of image 1 mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"app/packages.txt", "base/packages.txt"}, ex.reset()); diff != "" {
+		t.Errorf("first scan of image 1 extracted mismatch (-want +got):\n%s", diff)
+	}
+
+	// rescanning the same image extracts nothing
+	if diff := cmp.Diff(want1, scan(t, img1, wrap(img1, cache))); diff != "" {
+		t.Errorf("second scan of image 1 mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string(nil), ex.reset()); diff != "" {
+		t.Errorf("second scan of image 1 extracted mismatch (-want +got):\n%s", diff)
+	}
+
+	// scanning an image with the same base only extracts the app layer
+	if diff := cmp.Diff(want2, scan(t, img2, wrap(img2, cache))); diff != "" {
+		t.Errorf("scan of image 2 mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"app/packages.txt"}, ex.reset()); diff != "" {
+		t.Errorf("scan of image 2 extracted mismatch (-want +got):\n%s", diff)
+	}
+
+	// a new cache with the same directory is populated from what was persisted
+	if diff := cmp.Diff(want2, scan(t, img2, wrap(img2, layercache.New(dir)))); diff != "" {
+		t.Errorf("scan of image 2 with persisted cache mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string(nil), ex.reset()); diff != "" {
+		t.Errorf("scan of image 2 with persisted cache extracted mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCache_UnpersistableMetadata(t *testing.T) {
+	t.Parallel()
+
+	type metadata struct{ Value string }
+
+	dir := t.TempDir()
+
+	pkgs := []*extractor.Package{{
+		Name:     "example",
+		Version:  "1.0.0",
+		PURLType: purl.TypeGeneric,
+		Metadata: &metadata{Value: "not in the proto"},
+	}}
+
+	cache := layercache.New(dir)
+	cache.Put("key", pkgs)
+
+	got, ok := cache.Get("key")
+	if !ok {
+		t.Fatalf("Get() did not find the package in memory")
+	}
+	if diff := cmp.Diff(pkgs, got); diff != "" {
+		t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, ok := layercache.New(dir).Get("key"); ok {
+		t.Errorf("Get() found a package with metadata that cannot be persisted")
+	}
+}
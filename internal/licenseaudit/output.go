@@ -0,0 +1,149 @@
+package licenseaudit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// Formats returns the formats that a report can be written in
+func Formats() []string {
+	return []string{"table", "json", "csv"}
+}
+
+// Write writes the report in the given format, which must be one of Formats
+func Write(w io.Writer, r Report, format string, terminalWidth int) error {
+	switch format {
+	case "json":
+		return WriteJSON(w, r)
+	case "csv":
+		return WriteCSV(w, r)
+	case "table":
+		WriteTable(w, r, terminalWidth)
+		return nil
+	}
+
+	return fmt.Errorf("unsupported output format \"%s\" - must be one of: %s", format, strings.Join(Formats(), ", "))
+}
+
+// WriteJSON writes the report as a single indented json object
+func WriteJSON(w io.Writer, r Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes a row for each package, with multiple licenses being
+// joined with " AND " so that each package fits in a single row
+func WriteCSV(w io.Writer, r Report) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"Ecosystem", "Package", "Version", "Source", "Licenses", "Violations"}); err != nil {
+		return err
+	}
+
+	for _, pkg := range r.Packages {
+		err := writer.Write([]string{
+			pkg.Ecosystem,
+			pkg.Name,
+			pkg.Version,
+			pkg.Source,
+			joinLicenses(pkg.Licenses, " AND "),
+			joinLicenses(pkg.Violations, " AND "),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// WriteTable writes the license summary followed by the packages with license
+// violations, using fancy characters if terminalWidth is greater than zero
+func WriteTable(w io.Writer, r Report, terminalWidth int) {
+	if len(r.Packages) == 0 {
+		fmt.Fprintln(w, "No packages found.")
+		return
+	}
+
+	summary := newTable(w, terminalWidth)
+	summary.AppendHeader(table.Row{"License", "No. of package versions"})
+	for _, license := range r.Summary {
+		summary.AppendRow(table.Row{license.Name, license.Count})
+	}
+	summary.Render()
+
+	violations := r.Violations()
+	if len(violations) == 0 {
+		fmt.Fprintf(
+			w,
+			"\nNo license violations found in %d %s.\n",
+			len(r.Packages),
+			output.Form(len(r.Packages), "package", "packages"),
+		)
+
+		return
+	}
+
+	fmt.Fprintln(w)
+
+	workingDir, _ := os.Getwd()
+	outputTable := newTable(w, terminalWidth)
+	outputTable.AppendHeader(table.Row{"License Violation", "Ecosystem", "Package", "Version", "Source"})
+	for _, pkg := range violations {
+		path := pkg.Source
+		if simplifiedPath, err := filepath.Rel(workingDir, pkg.Source); err == nil && workingDir != "" {
+			path = simplifiedPath
+		}
+
+		outputTable.AppendRow(table.Row{
+			joinLicenses(pkg.Violations, ", "),
+			pkg.Ecosystem,
+			pkg.Name,
+			pkg.Version,
+			path,
+		})
+	}
+	outputTable.Render()
+
+	fmt.Fprintf(
+		w,
+		"\nFound %d %s with license violations out of %d scanned.\n",
+		len(violations),
+		output.Form(len(violations), "package", "packages"),
+		len(r.Packages),
+	)
+}
+
+func newTable(w io.Writer, terminalWidth int) table.Writer {
+	outputTable := table.NewWriter()
+	outputTable.SetOutputMirror(w)
+
+	if terminalWidth > 0 {
+		outputTable.SetStyle(table.StyleRounded)
+		outputTable.SetAllowedRowLength(terminalWidth)
+	}
+
+	return outputTable
+}
+
+func joinLicenses(licenses []models.License, sep string) string {
+	strs := make([]string, len(licenses))
+	for i, l := range licenses {
+		strs[i] = string(l)
+	}
+
+	return strings.Join(strs, sep)
+}
@@ -0,0 +1,83 @@
+// Package licenseaudit builds reports of the licenses of scanned packages and
+// whether they are allowed, independently of any vulnerabilities affecting them.
+package licenseaudit
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// ErrViolationsFound is returned when any package has a license that is not allowed
+var ErrViolationsFound = errors.New("license violations found")
+
+// Package is a package along with its licenses
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Source    string `json:"source"`
+	// Licenses are the licenses of the package, which is "UNKNOWN" if they could not be determined
+	Licenses []models.License `json:"licenses"`
+	// Violations are the licenses of the package that are not in the allowlist
+	Violations []models.License `json:"violations,omitempty"`
+}
+
+// Report is the outcome of auditing the licenses of the scanned packages
+type Report struct {
+	// Summary is the number of package versions with each license, most common first
+	Summary []models.LicenseCount `json:"summary"`
+	// Packages are sorted by source, ecosystem, name, and version
+	Packages []Package `json:"packages"`
+}
+
+// FromResults builds a report from the results of a scan that included every
+// package, regardless of whether it had any license violations
+func FromResults(results *models.VulnerabilityResults) Report {
+	report := Report{
+		Summary:  results.LicenseSummary,
+		Packages: []Package{},
+	}
+
+	if report.Summary == nil {
+		report.Summary = []models.LicenseCount{}
+	}
+
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			report.Packages = append(report.Packages, Package{
+				Ecosystem:  pkg.Package.Ecosystem,
+				Name:       pkg.Package.Name,
+				Version:    pkg.Package.Version,
+				Source:     source.Source.Path,
+				Licenses:   pkg.Licenses,
+				Violations: pkg.LicenseViolations,
+			})
+		}
+	}
+
+	slices.SortFunc(report.Packages, func(a, b Package) int {
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Ecosystem, b.Ecosystem),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Version, b.Version),
+		)
+	})
+
+	return report
+}
+
+// Violations returns the packages that have at least one license that is not allowed
+func (r Report) Violations() []Package {
+	var violations []Package
+	for _, pkg := range r.Packages {
+		if len(pkg.Violations) > 0 {
+			violations = append(violations, pkg)
+		}
+	}
+
+	return violations
+}
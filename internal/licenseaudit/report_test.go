@@ -0,0 +1,179 @@
+package licenseaudit_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/licenseaudit"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func testResults() *models.VulnerabilityResults {
+	return &models.VulnerabilityResults{
+		LicenseSummary: []models.LicenseCount{
+			{Name: "MIT", Count: 2},
+			{Name: "GPL-3.0-only", Count: 1},
+			{Name: "UNKNOWN", Count: 1},
+		},
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/app/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package:  models.PackageInfo{Name: "left-pad", Version: "1.3.0", Ecosystem: "npm"},
+						Licenses: []models.License{"MIT"},
+					},
+					{
+						Package:           models.PackageInfo{Name: "gpl-thing", Version: "2.0.0", Ecosystem: "npm"},
+						Licenses:          []models.License{"GPL-3.0-only"},
+						LicenseViolations: []models.License{"GPL-3.0-only"},
+					},
+				},
+			},
+			{
+				Source: models.SourceInfo{Path: "/app/composer.lock", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package:           models.PackageInfo{Name: "acme/private", Version: "1.0.0", Ecosystem: "Packagist"},
+						Licenses:          []models.License{"UNKNOWN"},
+						LicenseViolations: []models.License{"UNKNOWN"},
+					},
+					{
+						Package:  models.PackageInfo{Name: "psr/log", Version: "1.1.4", Ecosystem: "Packagist"},
+						Licenses: []models.License{"MIT"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestFromResults(t *testing.T) {
+	t.Parallel()
+
+	got := licenseaudit.FromResults(testResults())
+
+	want := licenseaudit.Report{
+		Summary: []models.LicenseCount{
+			{Name: "MIT", Count: 2},
+			{Name: "GPL-3.0-only", Count: 1},
+			{Name: "UNKNOWN", Count: 1},
+		},
+		Packages: []licenseaudit.Package{
+			{
+				Ecosystem:  "Packagist",
+				Name:       "acme/private",
+				Version:    "1.0.0",
+				Source:     "/app/composer.lock",
+				Licenses:   []models.License{"UNKNOWN"},
+				Violations: []models.License{"UNKNOWN"},
+			},
+			{
+				Ecosystem: "Packagist",
+				Name:      "psr/log",
+				Version:   "1.1.4",
+				Source:    "/app/composer.lock",
+				Licenses:  []models.License{"MIT"},
+			},
+			{
+				Ecosystem:  "npm",
+				Name:       "gpl-thing",
+				Version:    "2.0.0",
+				Source:     "/app/package-lock.json",
+				Licenses:   []models.License{"GPL-3.0-only"},
+				Violations: []models.License{"GPL-3.0-only"},
+			},
+			{
+				Ecosystem: "npm",
+				Name:      "left-pad",
+				Version:   "1.3.0",
+				Source:    "/app/package-lock.json",
+				Licenses:  []models.License{"MIT"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FromResults() diff (-want +got):\n%s", diff)
+	}
+
+	if len(got.Violations()) != 2 {
+		t.Errorf("Violations() returned %d packages, want 2", len(got.Violations()))
+	}
+}
+
+func TestFromResults_Empty(t *testing.T) {
+	t.Parallel()
+
+	got := licenseaudit.FromResults(&models.VulnerabilityResults{})
+
+	want := licenseaudit.Report{
+		Summary:  []models.LicenseCount{},
+		Packages: []licenseaudit.Package{},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FromResults() diff (-want +got):\n%s", diff)
+	}
+
+	if got.Violations() != nil {
+		t.Errorf("Violations() = %v, want nil", got.Violations())
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := licenseaudit.WriteCSV(&buf, licenseaudit.FromResults(testResults())); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	want := `Ecosystem,Package,Version,Source,Licenses,Violations
+Packagist,acme/private,1.0.0,/app/composer.lock,UNKNOWN,UNKNOWN
+Packagist,psr/log,1.1.4,/app/composer.lock,MIT,
+npm,gpl-thing,2.0.0,/app/package-lock.json,GPL-3.0-only,GPL-3.0-only
+npm,left-pad,1.3.0,/app/package-lock.json,MIT,
+`
+
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteCSV() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteTable_NoViolations(t *testing.T) {
+	t.Parallel()
+
+	report := licenseaudit.Report{
+		Summary: []models.LicenseCount{{Name: "MIT", Count: 1}},
+		Packages: []licenseaudit.Package{
+			{Ecosystem: "npm", Name: "left-pad", Version: "1.3.0", Source: "/app/package-lock.json", Licenses: []models.License{"MIT"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	licenseaudit.WriteTable(&buf, report, 0)
+
+	want := `+---------+-------------------------+
+| LICENSE | NO. OF PACKAGE VERSIONS |
++---------+-------------------------+
+| MIT     |                       1 |
++---------+-------------------------+
+
+No license violations found in 1 package.
+`
+
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteTable() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	err := licenseaudit.Write(&bytes.Buffer{}, licenseaudit.Report{}, "sarif", 0)
+	if err == nil {
+		t.Errorf("Write() did not return an error for an unsupported format")
+	}
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"cmp"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// csvHeader is the header row of the CSV output
+var csvHeader = []string{
+	"Ecosystem",
+	"Package",
+	"Version",
+	"Vulnerability ID",
+	"Aliases",
+	"Severity",
+	"Severity Score",
+	"Fixed Version",
+	"Source",
+	"Dependency Groups",
+}
+
+// csvListSeparator separates the values of fields that can have more than one value
+const csvListSeparator = "; "
+
+// PrintCSVResults prints each vulnerability found in each package as a row of
+// comma separated values, with a header row and no summary, so the results can
+// be opened and filtered in spreadsheet tools. If the results have labels, they
+// are included in a trailing column of every row.
+func PrintCSVResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, showAllVulns bool) error {
+	result := BuildResults(vulnResult)
+
+	w := csv.NewWriter(outputWriter)
+
+	header := csvHeader
+	labels := formatLabels(vulnResult.Labels)
+	if labels != "" {
+		header = append(slices.Clone(header), "Labels")
+	}
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, eco := range result.Ecosystems {
+		for _, source := range eco.Sources {
+			for _, pkg := range source.Packages {
+				vulns := pkg.RegularVulns
+				if showAllVulns {
+					vulns = slices.Concat(vulns, pkg.HiddenVulns)
+				}
+
+				for _, vuln := range vulns {
+					fixedVersion := vuln.FixedVersion
+					if fixedVersion == UnfixedDescription || fixedVersion == VersionUnsupported {
+						fixedVersion = ""
+					}
+
+					row := []string{
+						eco.Name,
+						pkg.Name,
+						cmp.Or(pkg.InstalledVersion, pkg.Commit),
+						vuln.ID,
+						strings.Join(vuln.Aliases, csvListSeparator),
+						string(vuln.SeverityRating),
+						vuln.SeverityScore,
+						fixedVersion,
+						strings.TrimPrefix(source.Name, string(source.Type)+":"),
+						strings.Join(pkg.DepGroups, csvListSeparator),
+					}
+
+					if labels != "" {
+						row = append(row, strings.ReplaceAll(labels, ", ", csvListSeparator))
+					}
+
+					for i := range row {
+						row[i] = csvEscapeFormula(row[i])
+					}
+
+					if err := w.Write(row); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+// csvEscapeFormula prefixes values that spreadsheet tools would otherwise
+// evaluate as a formula with a single quote, so that they are shown as text
+func csvEscapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
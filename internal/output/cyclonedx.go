@@ -16,6 +16,7 @@ func PrintCycloneDXResults(vulnResult *models.VulnerabilityResults, cycloneDXVer
 	resultsByPurl, errs := purl.Group(vulnResult.Results)
 
 	bom := bomCreator(resultsByPurl)
+	bom.Metadata = sbom.BuildLabelsMetadata(vulnResult.Labels)
 	encoder := cyclonedx.NewBOMEncoder(outputWriter, cyclonedx.BOMFileFormatJSON)
 	encoder.SetPretty(true)
 
@@ -0,0 +1,221 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/utility/results"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// GroupBy controls how the findings are grouped in table and html output
+type GroupBy string
+
+const (
+	// GroupByPackage lists each vulnerability of each package in each source
+	GroupByPackage GroupBy = "package"
+	// GroupByFix groups the vulnerabilities by the upgrade that fixes them
+	GroupByFix GroupBy = "fix"
+	// GroupByVuln groups the affected packages of each source by the vulnerability
+	GroupByVuln GroupBy = "vuln"
+)
+
+// GroupByValues returns the supported ways of grouping findings
+func GroupByValues() []string {
+	return []string{
+		string(GroupByPackage),
+		string(GroupByFix),
+		string(GroupByVuln),
+	}
+}
+
+// FixAction is a single remediation action, which is upgrading a package to the version that
+// fixes the most of its vulnerabilities, along with the findings across all sources that it resolves
+type FixAction struct {
+	Ecosystem string
+	Package   string
+	// InstalledVersions are the versions of the package that were found in the sources
+	InstalledVersions []string
+	// FixedVersion is the version to upgrade the package to,
+	// which is empty if the vulnerabilities do not have a fix
+	FixedVersion string
+	// VulnIDs are the vulnerabilities that are resolved by the action
+	VulnIDs []string
+	// Sources are the paths of the sources that the package was found in
+	Sources []string
+}
+
+// Summary describes the action and what it resolves in a sentence
+func (a FixAction) Summary() string {
+	vulns := fmt.Sprintf("%d %s", len(a.VulnIDs), Form(len(a.VulnIDs), "vulnerability", "vulnerabilities"))
+	sources := fmt.Sprintf("%d %s", len(a.Sources), Form(len(a.Sources), "source", "sources"))
+
+	if a.FixedVersion == "" {
+		return fmt.Sprintf("no fix available for %s, leaving %s across %s", a.Package, vulns, sources)
+	}
+
+	return fmt.Sprintf("upgrade %s to %s fixes %s across %s", a.Package, a.FixedVersion, vulns, sources)
+}
+
+// BuildFixActions groups the vulnerabilities of the result by the action that resolves them.
+//
+// The version that each vulnerability is fixed in is calculated from its affected ranges,
+// and each package is upgraded to the highest of them so that the one action covers every
+// source the package was found in. Vulnerabilities without a fix are grouped into a separate
+// action for each package, so that every finding is accounted for.
+func BuildFixActions(result Result) []FixAction {
+	workingDir := mustGetWorkingDirectory()
+
+	// the vulnerabilities of every version of each package across all sources,
+	// so that the package is upgraded to the same version in each of them
+	vulnsByPackage := make(map[string][]VulnResult)
+	for _, eco := range result.Ecosystems {
+		for _, source := range eco.Sources {
+			for _, pkg := range source.Packages {
+				key := eco.Name + ":" + fixActionPackageName(eco.Name, pkg)
+				vulnsByPackage[key] = append(vulnsByPackage[key], pkg.RegularVulns...)
+			}
+		}
+	}
+
+	actions := make(map[string]*FixAction)
+	var keys []string
+
+	for _, eco := range result.Ecosystems {
+		for _, source := range eco.Sources {
+			path := displaySourcePath(source, workingDir)
+
+			for _, pkg := range source.Packages {
+				name := fixActionPackageName(eco.Name, pkg)
+				fixedVersion := calculatePackageFixedVersion(eco.Name, vulnsByPackage[eco.Name+":"+name])
+
+				for _, vuln := range pkg.RegularVulns {
+					action := FixAction{Ecosystem: eco.Name, Package: name}
+					if vuln.IsFixable {
+						action.FixedVersion = fixedVersion
+					}
+
+					key := eco.Name + ":" + name + ":" + action.FixedVersion
+					if _, ok := actions[key]; !ok {
+						actions[key] = &action
+						keys = append(keys, key)
+					}
+
+					a := actions[key]
+					a.InstalledVersions = appendUnique(a.InstalledVersions, pkg.InstalledVersion)
+					a.VulnIDs = appendUnique(a.VulnIDs, vuln.ID)
+					a.Sources = appendUnique(a.Sources, path)
+				}
+			}
+		}
+	}
+
+	sorted := make([]FixAction, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, *actions[key])
+	}
+
+	// actions that fix the most vulnerabilities come first, with those that
+	// cannot be taken because there is no fix coming last
+	slices.SortStableFunc(sorted, func(a, b FixAction) int {
+		if (a.FixedVersion == "") != (b.FixedVersion == "") {
+			if a.FixedVersion == "" {
+				return 1
+			}
+
+			return -1
+		}
+
+		return cmp.Or(
+			cmp.Compare(len(b.VulnIDs), len(a.VulnIDs)),
+			cmp.Compare(a.Ecosystem, b.Ecosystem),
+			cmp.Compare(a.Package, b.Package),
+		)
+	})
+
+	return sorted
+}
+
+// fixActionPackageName is the name of the package to act on, which
+// for packages identified by a commit includes the commit
+func fixActionPackageName(ecosystem string, pkg PackageResult) string {
+	if ecosystem == "" && pkg.Commit != "" {
+		return results.PkgToString(models.PackageInfo{Name: pkg.Name, Commit: pkg.Commit, Version: pkg.InstalledVersion})
+	}
+
+	return pkg.Name
+}
+
+func appendUnique(s []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+
+	return s
+}
+
+// PrintFixActionsTableResults prints the vulnerabilities of the osv scan results into a
+// human friendly table, grouped by the action that fixes them
+func PrintFixActionsTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, terminalWidth int, markdown bool) {
+	if terminalWidth <= 0 || markdown {
+		text.DisableColors()
+	}
+
+	printLabels(vulnResult.Labels, outputWriter)
+
+	var outputTable table.Writer
+	if markdown {
+		printMarkdownVulnSummary(vulnResult, outputWriter)
+		outputTable = table.NewWriter()
+		outputTable.SetOutputMirror(outputWriter)
+	} else {
+		printVulnSummary(vulnResult, outputWriter)
+		outputTable = newTable(outputWriter, terminalWidth)
+	}
+
+	outputTable = fixActionsTableBuilder(outputTable, BuildFixActions(BuildResults(vulnResult)))
+
+	if markdown {
+		if outputTable.Length() != 0 {
+			outputTable.RenderMarkdown()
+		}
+		buildMarkdownUnscannedPackagesTable(outputWriter, vulnResult)
+		buildMarkdownIntegrityMismatchesTable(outputWriter, vulnResult)
+
+		return
+	}
+
+	if outputTable.Length() != 0 {
+		outputTable.Render()
+	}
+	buildUnscannedPackagesTable(outputWriter, terminalWidth, vulnResult)
+	buildIntegrityMismatchesTable(outputWriter, terminalWidth, vulnResult)
+}
+
+func fixActionsTableBuilder(outputTable table.Writer, actions []FixAction) table.Writer {
+	outputTable.AppendHeader(table.Row{"Action", "Ecosystem", "Installed", "Vulnerabilities", "Sources"})
+
+	for _, action := range actions {
+		vulns := make([]string, 0, len(action.VulnIDs))
+		for _, id := range action.VulnIDs {
+			vulns = append(vulns, OSVBaseVulnerabilityURL+text.Bold.Sprintf("%s", id))
+		}
+
+		outputTable.AppendRow(table.Row{
+			action.Summary(),
+			action.Ecosystem,
+			strings.Join(action.InstalledVersions, "\n"),
+			strings.Join(vulns, "\n"),
+			strings.Join(action.Sources, "\n"),
+		})
+	}
+
+	return outputTable
+}
@@ -0,0 +1,157 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func fixActionVuln(id, pkg, fixed string) osvschema.Vulnerability {
+	events := []osvschema.Event{{Introduced: "0"}}
+	if fixed != "" {
+		events = append(events, osvschema.Event{Fixed: fixed})
+	}
+
+	return osvschema.Vulnerability{
+		ID: id,
+		Affected: []osvschema.Affected{
+			{
+				Package: osvschema.Package{Ecosystem: "npm", Name: pkg},
+				Ranges:  []osvschema.Range{{Type: osvschema.RangeSemVer, Events: events}},
+			},
+		},
+	}
+}
+
+func fixActionPackage(name, version string, vulns ...osvschema.Vulnerability) models.PackageVulns {
+	pkg := models.PackageVulns{
+		Package:         models.PackageInfo{Name: name, Version: version, Ecosystem: "npm"},
+		Vulnerabilities: vulns,
+	}
+	for _, v := range vulns {
+		pkg.Groups = append(pkg.Groups, models.GroupInfo{IDs: []string{v.ID}})
+	}
+
+	return pkg
+}
+
+func fixActionsResult() *models.VulnerabilityResults {
+	return &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "first/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					fixActionPackage(
+						"lodash", "4.17.15",
+						fixActionVuln("GHSA-0001", "lodash", "4.17.19"),
+						fixActionVuln("GHSA-0002", "lodash", "4.17.21"),
+					),
+					fixActionPackage("minimist", "1.2.0", fixActionVuln("GHSA-0003", "minimist", "1.2.6")),
+				},
+			},
+			{
+				Source: models.SourceInfo{Path: "second/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					fixActionPackage(
+						"lodash", "4.17.20",
+						fixActionVuln("GHSA-0002", "lodash", "4.17.21"),
+						fixActionVuln("GHSA-0004", "lodash", ""),
+					),
+				},
+			},
+		},
+	}
+}
+
+func TestBuildFixActions(t *testing.T) {
+	t.Parallel()
+
+	got := output.BuildFixActions(output.BuildResults(fixActionsResult()))
+
+	want := []output.FixAction{
+		{
+			Ecosystem:         "npm",
+			Package:           "lodash",
+			InstalledVersions: []string{"4.17.15", "4.17.20"},
+			FixedVersion:      "4.17.21",
+			VulnIDs:           []string{"GHSA-0001", "GHSA-0002"},
+			Sources:           []string{"first/package-lock.json", "second/package-lock.json"},
+		},
+		{
+			Ecosystem:         "npm",
+			Package:           "minimist",
+			InstalledVersions: []string{"1.2.0"},
+			FixedVersion:      "1.2.6",
+			VulnIDs:           []string{"GHSA-0003"},
+			Sources:           []string{"first/package-lock.json"},
+		},
+		{
+			Ecosystem:         "npm",
+			Package:           "lodash",
+			InstalledVersions: []string{"4.17.20"},
+			VulnIDs:           []string{"GHSA-0004"},
+			Sources:           []string{"second/package-lock.json"},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("BuildFixActions() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestFixAction_Summary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		action output.FixAction
+		want   string
+	}{
+		{
+			action: output.FixAction{
+				Package:      "lodash",
+				FixedVersion: "4.17.21",
+				VulnIDs:      []string{"GHSA-0001", "GHSA-0002"},
+				Sources:      []string{"first/package-lock.json", "second/package-lock.json"},
+			},
+			want: "upgrade lodash to 4.17.21 fixes 2 vulnerabilities across 2 sources",
+		},
+		{
+			action: output.FixAction{
+				Package: "lodash",
+				VulnIDs: []string{"GHSA-0004"},
+				Sources: []string{"second/package-lock.json"},
+			},
+			want: "no fix available for lodash, leaving 1 vulnerability across 1 source",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.action.Summary(); got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestPrintFixActionsTableResults(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintFixActionsTableResults(fixActionsResult(), outputWriter, 800, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func TestPrintFixActionsTableResults_Markdown(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintFixActionsTableResults(fixActionsResult(), outputWriter, 0, true)
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
@@ -23,6 +23,9 @@ func createSourceRemediationTable(source models.PackageSource, groupFixedVersion
 			for _, id := range group.IDs {
 				vulnIDs = append(vulnIDs, "https://osv.dev/"+id)
 			}
+			if group.KEV != nil {
+				vulnIDs = append(vulnIDs, "(known exploited: "+group.KEV.CVE+")")
+			}
 			remediationTable.AppendRow(table.Row{
 				pv.Package.Name,
 				strings.Join(vulnIDs, "\n"),
@@ -0,0 +1,249 @@
+package output
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/utility/severity"
+	"github.com/google/osv-scanner/v2/internal/version"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/uuid"
+)
+
+// gitlabSchemaVersion is the version of the GitLab security report schema that is written,
+// see https://gitlab.com/gitlab-org/security-products/security-report-schemas
+const gitlabSchemaVersion = "15.2.1"
+
+// gitlabTimeFormat is the format of the times of the scan, which must not have a timezone
+const gitlabTimeFormat = "2006-01-02T15:04:05"
+
+// gitlabNamespace namespaces the ids of the vulnerabilities in the report, which are
+// derived from the finding so that they are the same across scans
+var gitlabNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/google/osv-scanner"))
+
+type gitlabReport struct {
+	Version         string                `json:"version"`
+	Schema          string                `json:"schema"`
+	Scan            gitlabScan            `json:"scan"`
+	Vulnerabilities []gitlabVulnerability `json:"vulnerabilities"`
+}
+
+type gitlabScan struct {
+	Analyzer  gitlabScanner `json:"analyzer"`
+	Scanner   gitlabScanner `json:"scanner"`
+	Type      string        `json:"type"`
+	StartTime string        `json:"start_time"`
+	EndTime   string        `json:"end_time"`
+	Status    string        `json:"status"`
+}
+
+type gitlabScanner struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	URL     string       `json:"url"`
+	Version string       `json:"version"`
+	Vendor  gitlabVendor `json:"vendor"`
+}
+
+type gitlabVendor struct {
+	Name string `json:"name"`
+}
+
+type gitlabVulnerability struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Severity    string                   `json:"severity"`
+	Solution    string                   `json:"solution,omitempty"`
+	Identifiers []gitlabIdentifier       `json:"identifiers"`
+	Links       []gitlabLink             `json:"links,omitempty"`
+	Details     map[string]gitlabDetail  `json:"details,omitempty"`
+	Location    gitlabDependencyLocation `json:"location"`
+}
+
+type gitlabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type gitlabLink struct {
+	URL string `json:"url"`
+}
+
+// gitlabDetail is either a "named-list" of other details, or a "text" detail
+type gitlabDetail struct {
+	Type  string                  `json:"type"`
+	Name  string                  `json:"name"`
+	Value string                  `json:"value,omitempty"`
+	Items map[string]gitlabDetail `json:"items,omitempty"`
+}
+
+type gitlabDependencyLocation struct {
+	File       string           `json:"file"`
+	Dependency gitlabDependency `json:"dependency"`
+}
+
+type gitlabDependency struct {
+	Package gitlabPackage `json:"package"`
+	Version string        `json:"version"`
+}
+
+type gitlabPackage struct {
+	Name string `json:"name"`
+}
+
+// gitlabSeverities maps the severity ratings of vulnerabilities to those of GitLab,
+// with any other rating being "Unknown"
+var gitlabSeverities = map[severity.Rating]string{
+	severity.CriticalRating: "Critical",
+	severity.HighRating:     "High",
+	severity.MediumRating:   "Medium",
+	severity.LowRating:      "Low",
+}
+
+// PrintGitLabDependencyScanningReport writes the results as a GitLab dependency scanning
+// report, so that the vulnerabilities are shown in the security widgets of merge requests
+// and in the vulnerability report of projects
+func PrintGitLabDependencyScanningReport(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, showAllVulns bool) error {
+	now := time.Now().UTC().Format(gitlabTimeFormat)
+	osvScanner := gitlabScanner{
+		ID:      "osv-scanner",
+		Name:    "OSV-Scanner",
+		URL:     "https://github.com/google/osv-scanner",
+		Version: version.OSVVersion,
+		Vendor:  gitlabVendor{Name: "Google"},
+	}
+
+	report := gitlabReport{
+		Version: gitlabSchemaVersion,
+		Schema:  "https://gitlab.com/gitlab-org/security-products/security-report-schemas/-/raw/v" + gitlabSchemaVersion + "/dist/dependency-scanning-report-format.json",
+		Scan: gitlabScan{
+			Analyzer:  osvScanner,
+			Scanner:   osvScanner,
+			Type:      "dependency_scanning",
+			StartTime: now,
+			EndTime:   now,
+			Status:    "success",
+		},
+		Vulnerabilities: []gitlabVulnerability{},
+	}
+
+	details := collectVulnerabilityDetails(vulnResult)
+	labels := gitlabLabelsDetail(vulnResult.Labels)
+	workingDir := mustGetWorkingDirectory()
+
+	result := BuildResults(vulnResult)
+	for _, eco := range result.Ecosystems {
+		for _, source := range eco.Sources {
+			file := strings.TrimPrefix(source.Name, string(source.Type)+":")
+			if rel, err := filepath.Rel(workingDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+			file = filepath.ToSlash(file)
+
+			for _, pkg := range source.Packages {
+				vulns := pkg.RegularVulns
+				if showAllVulns {
+					vulns = slices.Concat(vulns, pkg.HiddenVulns)
+				}
+
+				for _, vuln := range vulns {
+					report.Vulnerabilities = append(report.Vulnerabilities, gitlabVulnerabilityOf(vuln, pkg, file, details[vuln.ID], labels))
+				}
+			}
+		}
+	}
+
+	encoder := json.NewEncoder(outputWriter)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+func gitlabVulnerabilityOf(vuln VulnResult, pkg PackageResult, file string, details string, labels map[string]gitlabDetail) gitlabVulnerability {
+	version := cmp.Or(pkg.InstalledVersion, pkg.Commit)
+
+	identifiers := make([]gitlabIdentifier, 0, len(vuln.Aliases)+1)
+	for _, id := range slices.Concat([]string{vuln.ID}, vuln.Aliases) {
+		if slices.ContainsFunc(identifiers, func(i gitlabIdentifier) bool { return i.Value == id }) {
+			continue
+		}
+
+		prefix, _, _ := strings.Cut(id, "-")
+		identifiers = append(identifiers, gitlabIdentifier{
+			Type:  strings.ToLower(prefix),
+			Name:  id,
+			Value: id,
+			URL:   OSVBaseVulnerabilityURL + id,
+		})
+	}
+
+	v := gitlabVulnerability{
+		// the same vulnerability of the same package in the same file has the same id in every
+		// scan, which GitLab uses to track the vulnerability across pipelines
+		ID:          uuid.NewSHA1(gitlabNamespace, []byte(strings.Join([]string{file, pkg.Name, version, vuln.ID}, "\x00"))).String(),
+		Name:        cmp.Or(vuln.Description, vuln.ID),
+		Description: cmp.Or(details, vuln.Description),
+		Severity:    cmp.Or(gitlabSeverities[vuln.SeverityRating], "Unknown"),
+		Identifiers: identifiers,
+		Links:       []gitlabLink{{URL: OSVBaseVulnerabilityURL + vuln.ID}},
+		Details:     labels,
+		Location: gitlabDependencyLocation{
+			File: file,
+			Dependency: gitlabDependency{
+				Package: gitlabPackage{Name: pkg.Name},
+				Version: version,
+			},
+		},
+	}
+
+	if vuln.IsFixable {
+		v.Solution = fmt.Sprintf("Upgrade %s to version %s or later", pkg.Name, vuln.FixedVersion)
+	}
+
+	return v
+}
+
+// collectVulnerabilityDetails returns the details of each vulnerability in the results,
+// keyed by their id, as the output results only have their summaries
+func collectVulnerabilityDetails(vulnResult *models.VulnerabilityResults) map[string]string {
+	details := make(map[string]string)
+
+	for _, source := range vulnResult.Results {
+		for _, pkg := range source.Packages {
+			for _, vuln := range pkg.Vulnerabilities {
+				if vuln.Details != "" {
+					details[vuln.ID] = vuln.Details
+				}
+			}
+		}
+	}
+
+	return details
+}
+
+// gitlabLabelsDetail returns the labels of the scan as details of each vulnerability,
+// as the report has nowhere else to record them
+func gitlabLabelsDetail(labels map[string]string) map[string]gitlabDetail {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	items := make(map[string]gitlabDetail, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		items[key] = gitlabDetail{Type: "text", Name: key, Value: labels[key]}
+	}
+
+	return map[string]gitlabDetail{
+		"labels": {Type: "named-list", Name: "Labels", Items: items},
+	}
+}
@@ -0,0 +1,106 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+)
+
+func normalizeGitLabOutput(t *testing.T, str string) string {
+	t.Helper()
+
+	return cachedregexp.MustCompile(`"(start|end)_time": "[^"]+"`).ReplaceAllString(str, `"${1}_time": "<timestamp>"`)
+}
+
+func TestPrintGitLabDependencyScanningReport_WithVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithVulnerabilities(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintGitLabDependencyScanningReport(args.vulnResult, outputWriter, false)
+
+		if err != nil {
+			t.Errorf("%v", err)
+		}
+
+		testutility.NewSnapshot().MatchText(t, normalizeGitLabOutput(t, outputWriter.String()))
+	})
+}
+
+func TestPrintGitLabDependencyScanningReport_WithMixedIssues(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithMixedIssues(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintGitLabDependencyScanningReport(args.vulnResult, outputWriter, true)
+
+		if err != nil {
+			t.Errorf("%v", err)
+		}
+
+		testutility.NewSnapshot().MatchText(t, normalizeGitLabOutput(t, outputWriter.String()))
+	})
+}
+
+func TestPrintGitLabDependencyScanningReport_StableIDs(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := labelledVulnResult()
+
+	ids := func() []string {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		if err := output.PrintGitLabDependencyScanningReport(vulnResult, outputWriter, false); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		var report struct {
+			Vulnerabilities []struct {
+				ID      string `json:"id"`
+				Details struct {
+					Labels struct {
+						Items map[string]struct {
+							Value string `json:"value"`
+						} `json:"items"`
+					} `json:"labels"`
+				} `json:"details"`
+			} `json:"vulnerabilities"`
+		}
+		if err := json.Unmarshal(outputWriter.Bytes(), &report); err != nil {
+			t.Fatalf("failed to parse report: %v", err)
+		}
+
+		got := make([]string, 0, len(report.Vulnerabilities))
+		for _, v := range report.Vulnerabilities {
+			if v.Details.Labels.Items["env"].Value != "prod" {
+				t.Errorf("expected the labels of the scan to be in the details of %s", v.ID)
+			}
+			got = append(got, v.ID)
+		}
+
+		return got
+	}
+
+	first := ids()
+	if len(first) != 1 || first[0] == "" {
+		t.Fatalf("expected one vulnerability with an id, got %v", first)
+	}
+
+	if second := ids(); second[0] != first[0] {
+		t.Errorf("expected the id of the vulnerability to be the same across scans, got %s and %s", first[0], second[0])
+	}
+
+	vulnResult.Results[0].Packages[0].Package.Version = "4.17.21"
+	if third := ids(); third[0] == first[0] {
+		t.Errorf("expected the id of the vulnerability to change with the version of the package")
+	}
+}
@@ -57,6 +57,33 @@ func buildVulnTableEntryArgument(element VulnResult, isHidden bool) VulnTableEnt
 	}
 }
 
+// severityBarSegment is a segment of a bar chart of the severities of vulnerabilities
+type severityBarSegment struct {
+	Class   string
+	Count   int
+	Percent float64
+}
+
+// severityBar returns the segments of a bar chart of the given counts, with the
+// width of each segment being its share of the total number of vulnerabilities
+func severityBar(counts models.SeverityCounts, total int) []severityBarSegment {
+	segments := []severityBarSegment{
+		{Class: "critical", Count: counts.Critical},
+		{Class: "high", Count: counts.High},
+		{Class: "medium", Count: counts.Medium},
+		{Class: "low", Count: counts.Low},
+		{Class: "unknown", Count: counts.Unknown},
+	}
+
+	if total > 0 {
+		for i := range segments {
+			segments[i].Percent = float64(segments[i].Count) * 100 / float64(total)
+		}
+	}
+
+	return segments
+}
+
 func hasOSResult(ecosystems []EcosystemResult) bool {
 	for _, ecosystem := range ecosystems {
 		if ecosystem.IsOS {
@@ -67,9 +94,36 @@ func hasOSResult(ecosystems []EcosystemResult) bool {
 	return false
 }
 
+// formatDependencyChain formats a chain of dependencies, such as "express@4.17.1 → qs@6.7.0",
+// noting when the package is a direct dependency
+func formatDependencyChain(chain []models.DependencyLink) string {
+	if len(chain) == 1 {
+		return "direct dependency"
+	}
+
+	links := make([]string, 0, len(chain))
+	for _, link := range chain {
+		links = append(links, link.Name+"@"+link.Version)
+	}
+
+	return strings.Join(links, " → ")
+}
+
 func PrintHTMLResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) error {
 	// htmlResult := BuildHTMLResults(vulnResult)
+	return printHTMLResult(BuildResults(vulnResult), outputWriter)
+}
+
+// PrintVulnGroupsHTMLResults writes results to the provided writer in HTML format,
+// listing each vulnerability along with every package and source that it affects
+func PrintVulnGroupsHTMLResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, showAllVulns bool) error {
 	result := BuildResults(vulnResult)
+	result.VulnGroups = BuildVulnGroups(result, showAllVulns)
+
+	return printHTMLResult(result, outputWriter)
+}
+
+func printHTMLResult(result Result, outputWriter io.Writer) error {
 	vulnIndex := 0
 
 	// Parse embedded templates
@@ -89,6 +143,8 @@ func PrintHTMLResults(vulnResult *models.VulnerabilityResults, outputWriter io.W
 		"hasOSResult":                 hasOSResult,
 		"GetShortCommit":              results.GetShortCommit,
 		"isOSResult":                  isOSResult,
+		"severityBar":                 severityBar,
+		"formatDependencyChain":       formatDependencyChain,
 	}
 
 	tmpl := template.Must(template.New("").Funcs(funcMap).ParseFS(templates, TemplateDir))
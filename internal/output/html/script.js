@@ -219,6 +219,11 @@ function showAllVulns() {
     row.classList.toggle("hide-block", isUncalled);
   }
 
+  const vulnGroups = document.getElementsByClassName("vuln-group-container");
+  for (const group of vulnGroups) {
+    group.classList.remove("hide-block");
+  }
+
   showAndHideParentSections();
 }
 
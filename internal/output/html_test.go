@@ -2,9 +2,11 @@ package output_test
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
 )
 
 func TestPrintHTMLResults_WithVulnerabilities(t *testing.T) {
@@ -51,3 +53,28 @@ func TestPrintHTMLResults_WithMixedIssues(t *testing.T) {
 		}
 	})
 }
+
+func TestPrintHTMLResults_WithIntroducedVia(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := fixActionsResult()
+	vulnResult.Results[0].Packages[1].IntroducedVia = []models.DependencyLink{
+		{Name: "mkdirp", Version: "0.5.1"},
+		{Name: "minimist", Version: "1.2.0"},
+	}
+	vulnResult.Results[0].Packages[0].IntroducedVia = []models.DependencyLink{
+		{Name: "lodash", Version: "4.17.15"},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	if err := output.PrintHTMLResults(vulnResult, outputWriter); err != nil {
+		t.Fatalf("Error writing HTML output: %s", err)
+	}
+
+	got := outputWriter.String()
+	for _, want := range []string{"mkdirp@0.5.1 → minimist@1.2.0", "direct dependency"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected HTML output to contain %q", want)
+		}
+	}
+}
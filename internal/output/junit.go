@@ -0,0 +1,220 @@
+package output
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// add adds the test case to the suite, counting whether it failed or was skipped
+func (s *junitTestSuite) add(tc junitTestCase) {
+	s.TestCases = append(s.TestCases, tc)
+	s.Tests++
+
+	switch {
+	case tc.Failure != nil:
+		s.Failures++
+	case tc.Skipped != nil:
+		s.Skipped++
+	}
+}
+
+// PrintJUnitReport writes the results as a JUnit XML test report, so that CI systems which
+// only understand test reports can show the findings natively.
+//
+// Each scanned source is a test suite, with a test case for each vulnerability of each package
+// that fails if the vulnerability meets the given severity thresholds, and is skipped if it is
+// suppressed or hidden. Packages without any vulnerabilities have a passing test case, and
+// packages that violate the license allowlist have a failing test case for their licenses.
+func PrintJUnitReport(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, thresholds config.SeverityThresholds, showAllVulns bool) error {
+	report := junitTestSuites{Name: "osv-scanner"}
+
+	details := collectVulnerabilityDetails(vulnResult)
+	properties := junitLabelProperties(vulnResult.Labels)
+
+	result := BuildResults(vulnResult)
+	for _, eco := range result.Ecosystems {
+		for _, source := range eco.Sources {
+			suite := junitTestSuite{
+				Name:       strings.TrimPrefix(source.Name, string(source.Type)+":"),
+				Properties: properties,
+				TestCases:  []junitTestCase{},
+			}
+
+			for _, pkg := range source.Packages {
+				className := pkg.Name + "@" + cmp.Or(pkg.InstalledVersion, pkg.Commit)
+
+				for _, vuln := range pkg.RegularVulns {
+					suite.add(junitVulnTestCase(className, vuln, pkg, details[vuln.ID], thresholds))
+				}
+
+				if showAllVulns {
+					for _, vuln := range pkg.HiddenVulns {
+						tc := junitVulnTestCase(className, vuln, pkg, details[vuln.ID], thresholds)
+						tc.Failure = nil
+						tc.Skipped = &junitSkipped{Message: "vulnerability is " + strings.ToLower(vuln.VulnAnalysisType.String())}
+						suite.add(tc)
+					}
+				}
+
+				if len(pkg.LicenseViolations) > 0 {
+					violations := make([]string, 0, len(pkg.LicenseViolations))
+					for _, l := range pkg.LicenseViolations {
+						violations = append(violations, string(l))
+					}
+
+					suite.add(junitTestCase{
+						ClassName: className,
+						Name:      "license",
+						Failure: &junitFailure{
+							Message: "license not in the allowlist: " + strings.Join(violations, ", "),
+							Type:    "license",
+						},
+					})
+				}
+
+				if len(pkg.RegularVulns)+len(pkg.HiddenVulns)+len(pkg.LicenseViolations) == 0 {
+					suite.add(junitTestCase{ClassName: className, Name: "no known vulnerabilities"})
+				}
+			}
+
+			report.Tests += suite.Tests
+			report.Failures += suite.Failures
+			report.Skipped += suite.Skipped
+			report.Suites = append(report.Suites, suite)
+		}
+	}
+
+	if _, err := io.WriteString(outputWriter, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(outputWriter)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(outputWriter, "\n")
+
+	return err
+}
+
+// junitVulnTestCase returns the test case of a vulnerability of a package, which fails
+// unless the vulnerability is suppressed or below the severity threshold of the package
+func junitVulnTestCase(className string, vuln VulnResult, pkg PackageResult, details string, thresholds config.SeverityThresholds) junitTestCase {
+	rating := cmp.Or(string(vuln.SeverityRating), "UNKNOWN")
+
+	tc := junitTestCase{
+		ClassName: className,
+		Name:      fmt.Sprintf("[%s] %s", rating, vuln.ID),
+	}
+
+	switch {
+	case vuln.Suppression != nil:
+		tc.Skipped = &junitSkipped{Message: "vulnerability is suppressed: " + vuln.Suppression.Reason}
+	case !thresholds.ShouldFail(vuln.SeverityRating, pkg.DepGroups):
+		tc.SystemOut = fmt.Sprintf("%s is below the severity threshold of the package", vuln.ID)
+	default:
+		tc.Failure = &junitFailure{
+			Message: cmp.Or(vuln.Description, vuln.ID),
+			Type:    rating,
+			Text:    junitFailureText(vuln, details),
+		}
+	}
+
+	return tc
+}
+
+// junitFailureText returns the body of the failure of a vulnerability, which is shown
+// by CI systems when the failing test case is expanded
+func junitFailureText(vuln VulnResult, details string) string {
+	lines := []string{
+		"Vulnerability: " + vuln.ID,
+	}
+
+	if len(vuln.Aliases) > 0 {
+		lines = append(lines, "Aliases: "+strings.Join(vuln.Aliases, ", "))
+	}
+
+	lines = append(lines, "Severity: "+cmp.Or(string(vuln.SeverityRating), "UNKNOWN")+" ("+vuln.SeverityScore+")")
+
+	if vuln.IsFixable {
+		lines = append(lines, "Fixed version: "+vuln.FixedVersion)
+	}
+
+	lines = append(lines, "Link: "+OSVBaseVulnerabilityURL+vuln.ID)
+
+	if details != "" {
+		lines = append(lines, "", details)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// junitLabelProperties returns the labels of the scan as properties of each test suite
+func junitLabelProperties(labels map[string]string) *junitProperties {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	properties := &junitProperties{Properties: make([]junitProperty, 0, len(labels))}
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		properties.Properties = append(properties.Properties, junitProperty{Name: key, Value: labels[key]})
+	}
+
+	return properties
+}
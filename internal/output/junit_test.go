@@ -0,0 +1,118 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/internal/utility/severity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func TestPrintJUnitReport_WithVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithVulnerabilities(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintJUnitReport(args.vulnResult, outputWriter, config.SeverityThresholds{}, false)
+
+		if err != nil {
+			t.Errorf("%v", err)
+		}
+
+		testutility.NewSnapshot().MatchText(t, outputWriter.String())
+	})
+}
+
+func TestPrintJUnitReport_WithLicenseViolations(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithLicenseViolations(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintJUnitReport(args.vulnResult, outputWriter, config.SeverityThresholds{}, false)
+
+		if err != nil {
+			t.Errorf("%v", err)
+		}
+
+		testutility.NewSnapshot().MatchText(t, outputWriter.String())
+	})
+}
+
+func TestPrintJUnitReport_WithMixedIssues(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithMixedIssues(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintJUnitReport(args.vulnResult, outputWriter, config.SeverityThresholds{}, true)
+
+		if err != nil {
+			t.Errorf("%v", err)
+		}
+
+		testutility.NewSnapshot().MatchText(t, outputWriter.String())
+	})
+}
+
+func TestPrintJUnitReport_WithLabels(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	if err := output.PrintJUnitReport(labelledVulnResult(), outputWriter, config.SeverityThresholds{}, false); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintJUnitReport_ThresholdsAndSuppressions(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+						Vulnerabilities: []osvschema.Vulnerability{
+							{ID: "GHSA-35jh-r3h4-6jhm", Summary: "Command Injection in lodash"},
+							{ID: "GHSA-29mw-wpgm-hmr9", Summary: "Regular Expression Denial of Service (ReDoS) in lodash"},
+							{ID: "GHSA-p6mc-m468-83gw", Summary: "Prototype Pollution in lodash"},
+						},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-35jh-r3h4-6jhm"}, Aliases: []string{"GHSA-35jh-r3h4-6jhm"}, MaxSeverity: "7.2"},
+							{IDs: []string{"GHSA-29mw-wpgm-hmr9"}, Aliases: []string{"GHSA-29mw-wpgm-hmr9"}, MaxSeverity: "5.3"},
+							{
+								IDs:         []string{"GHSA-p6mc-m468-83gw"},
+								Aliases:     []string{"GHSA-p6mc-m468-83gw"},
+								MaxSeverity: "7.4",
+								Suppression: &models.Suppression{Reason: "no fix has been published for over 90 days"},
+							},
+						},
+					},
+					{
+						Package: models.PackageInfo{Name: "jest", Version: "29.7.0", Ecosystem: "npm"},
+					},
+				},
+			},
+		},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	err := output.PrintJUnitReport(vulnResult, outputWriter, config.SeverityThresholds{Default: severity.HighRating}, false)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
@@ -0,0 +1,28 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// formatLabels returns the labels as comma separated key=value pairs, sorted by key
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
+// printLabels prints the labels of the results on their own line, if they have any
+func printLabels(labels map[string]string, outputWriter io.Writer) {
+	if len(labels) == 0 {
+		return
+	}
+
+	fmt.Fprintf(outputWriter, "Labels: %s\n\n", formatLabels(labels))
+}
@@ -0,0 +1,137 @@
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func labelledVulnResult() *models.VulnerabilityResults {
+	return &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+						Vulnerabilities: []osvschema.Vulnerability{{ID: "GHSA-35jh-r3h4-6jhm"}},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-35jh-r3h4-6jhm"}, Aliases: []string{"GHSA-35jh-r3h4-6jhm"}, MaxSeverity: "7.2"},
+						},
+					},
+				},
+			},
+		},
+		Labels: map[string]string{"service": "payments", "env": "prod"},
+	}
+}
+
+func TestPrintTableResults_WithLabels(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(labelledVulnResult(), outputWriter, 120, false, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func TestPrintCSVResults_WithLabels(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	if err := output.PrintCSVResults(labelledVulnResult(), outputWriter, false); err != nil {
+		t.Fatalf("Error writing CSV output: %s", err)
+	}
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintOnelineResults_WithLabels(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintOnelineResults(labelledVulnResult(), outputWriter, false)
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintResults_WithLabels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		print func(*models.VulnerabilityResults, *bytes.Buffer) error
+		want  []string
+	}{
+		{
+			name: "markdown",
+			print: func(vulnResult *models.VulnerabilityResults, w *bytes.Buffer) error {
+				output.PrintMarkdownTableResults(vulnResult, w, false, false)
+				return nil
+			},
+			want: []string{"Labels: env=prod, service=payments"},
+		},
+		{
+			name: "vertical",
+			print: func(vulnResult *models.VulnerabilityResults, w *bytes.Buffer) error {
+				output.PrintVerticalResults(vulnResult, w, false)
+				return nil
+			},
+			want: []string{"Labels: env=prod, service=payments"},
+		},
+		{
+			name: "sarif",
+			print: func(vulnResult *models.VulnerabilityResults, w *bytes.Buffer) error {
+				return output.PrintSARIFReport(vulnResult, w)
+			},
+			want: []string{`"labels": {`, `"env": "prod"`, `"service": "payments"`},
+		},
+		{
+			name: "cyclonedx",
+			print: func(vulnResult *models.VulnerabilityResults, w *bytes.Buffer) error {
+				return output.PrintCycloneDXResults(vulnResult, models.CycloneDXVersion15, w)
+			},
+			want: []string{`"name": "osv-scanner:label:env"`, `"value": "prod"`},
+		},
+		{
+			name: "spdx",
+			print: func(vulnResult *models.VulnerabilityResults, w *bytes.Buffer) error {
+				// converting packages to SPDX requires the inventories that they were extracted as
+				vulnResult.Results = nil
+
+				return output.PrintSPDXResults(vulnResult, w)
+			},
+			want: []string{`"comment": "Labels: env=prod, service=payments"`},
+		},
+		{
+			name: "html",
+			print: func(vulnResult *models.VulnerabilityResults, w *bytes.Buffer) error {
+				return output.PrintHTMLResults(vulnResult, w)
+			},
+			want: []string{`<span class="label">env=prod</span>`, `<span class="label">service=payments</span>`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outputWriter := &bytes.Buffer{}
+			if err := tt.print(labelledVulnResult(), outputWriter); err != nil {
+				t.Fatalf("Error writing output: %s", err)
+			}
+
+			got := text.StripEscape(outputWriter.String())
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
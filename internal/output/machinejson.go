@@ -5,8 +5,31 @@ import (
 	"io"
 
 	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
 )
 
+// AdvisoryDetails controls how much of each OSV advisory is included in the JSON output
+type AdvisoryDetails string
+
+const (
+	// AdvisoryDetailsFull includes the complete OSV record of each advisory
+	AdvisoryDetailsFull AdvisoryDetails = "full"
+	// AdvisoryDetailsSummary includes the identifying and severity fields of each advisory,
+	// without the details, affected ranges, references, or credits
+	AdvisoryDetailsSummary AdvisoryDetails = "summary"
+	// AdvisoryDetailsIDs omits the advisories, leaving only their ids and aliases in the groups
+	AdvisoryDetailsIDs AdvisoryDetails = "ids"
+)
+
+// AdvisoryDetailsLevels returns the supported levels of advisory details
+func AdvisoryDetailsLevels() []string {
+	return []string{
+		string(AdvisoryDetailsFull),
+		string(AdvisoryDetailsSummary),
+		string(AdvisoryDetailsIDs),
+	}
+}
+
 // PrintJSONResults writes results to the provided writer in JSON format
 func PrintJSONResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) error {
 	encoder := json.NewEncoder(outputWriter)
@@ -14,3 +37,52 @@ func PrintJSONResults(vulnResult *models.VulnerabilityResults, outputWriter io.W
 
 	return encoder.Encode(vulnResult)
 }
+
+// TrimAdvisoryDetails returns a copy of the results with only the given level of
+// details of each advisory, leaving the original results unchanged
+func TrimAdvisoryDetails(vulnResult *models.VulnerabilityResults, details AdvisoryDetails) *models.VulnerabilityResults {
+	if details == "" || details == AdvisoryDetailsFull {
+		return vulnResult
+	}
+
+	trimmed := *vulnResult
+	trimmed.Results = make([]models.PackageSource, len(vulnResult.Results))
+
+	for i, source := range vulnResult.Results {
+		trimmed.Results[i] = source
+		trimmed.Results[i].Packages = make([]models.PackageVulns, len(source.Packages))
+
+		for j, pkg := range source.Packages {
+			if details == AdvisoryDetailsIDs {
+				pkg.Vulnerabilities = nil
+			} else {
+				vulns := make([]osvschema.Vulnerability, len(pkg.Vulnerabilities))
+				for k, vuln := range pkg.Vulnerabilities {
+					vulns[k] = summarizeAdvisory(vuln)
+				}
+				pkg.Vulnerabilities = vulns
+			}
+
+			trimmed.Results[i].Packages[j] = pkg
+		}
+	}
+
+	return &trimmed
+}
+
+// summarizeAdvisory returns the fields of the advisory needed to identify and triage it
+func summarizeAdvisory(vuln osvschema.Vulnerability) osvschema.Vulnerability {
+	return osvschema.Vulnerability{
+		SchemaVersion:    vuln.SchemaVersion,
+		ID:               vuln.ID,
+		Modified:         vuln.Modified,
+		Published:        vuln.Published,
+		Withdrawn:        vuln.Withdrawn,
+		Aliases:          vuln.Aliases,
+		Related:          vuln.Related,
+		Upstream:         vuln.Upstream,
+		Summary:          vuln.Summary,
+		Severity:         vuln.Severity,
+		DatabaseSpecific: vuln.DatabaseSpecific,
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/osv-scanner/v2/internal/output"
 	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
 )
 
 func TestPrintJSONResults_WithVulnerabilities(t *testing.T) {
@@ -58,3 +61,80 @@ func TestPrintJSONResults_WithMixedIssues(t *testing.T) {
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
 }
+
+func TestTrimAdvisoryDetails(t *testing.T) {
+	t.Parallel()
+
+	vuln := osvschema.Vulnerability{
+		ID:       "GHSA-1",
+		Aliases:  []string{"CVE-1"},
+		Summary:  "Something bad",
+		Details:  "A long description of something bad",
+		Severity: []osvschema.Severity{{Type: osvschema.SeverityCVSSV3, Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}},
+		Affected: []osvschema.Affected{{Package: osvschema.Package{Ecosystem: "npm", Name: "lodash"}}},
+		References: []osvschema.Reference{
+			{Type: osvschema.ReferenceAdvisory, URL: "https://github.com/advisories/GHSA-1"},
+		},
+		Credits:          []osvschema.Credit{{Name: "Someone"}},
+		DatabaseSpecific: map[string]any{"severity": "HIGH"},
+	}
+
+	newResults := func() *models.VulnerabilityResults {
+		return &models.VulnerabilityResults{
+			Results: []models.PackageSource{{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{{
+					Package:         models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+					Vulnerabilities: []osvschema.Vulnerability{vuln},
+					Groups:          []models.GroupInfo{{IDs: []string{"GHSA-1"}, Aliases: []string{"CVE-1", "GHSA-1"}}},
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		details output.AdvisoryDetails
+		want    []osvschema.Vulnerability
+	}{
+		{
+			details: output.AdvisoryDetailsFull,
+			want:    []osvschema.Vulnerability{vuln},
+		},
+		{
+			details: output.AdvisoryDetailsSummary,
+			want: []osvschema.Vulnerability{{
+				ID:               "GHSA-1",
+				Aliases:          []string{"CVE-1"},
+				Summary:          "Something bad",
+				Severity:         vuln.Severity,
+				DatabaseSpecific: map[string]any{"severity": "HIGH"},
+			}},
+		},
+		{
+			details: output.AdvisoryDetailsIDs,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.details), func(t *testing.T) {
+			t.Parallel()
+
+			results := newResults()
+			got := output.TrimAdvisoryDetails(results, tt.details)
+
+			if diff := cmp.Diff(tt.want, got.Results[0].Packages[0].Vulnerabilities); diff != "" {
+				t.Errorf("TrimAdvisoryDetails() vulnerabilities mismatch (-want +got):\n%s", diff)
+			}
+
+			// the groups are always kept, so that the ids are still present
+			if diff := cmp.Diff(newResults().Results[0].Packages[0].Groups, got.Results[0].Packages[0].Groups); diff != "" {
+				t.Errorf("TrimAdvisoryDetails() groups mismatch (-want +got):\n%s", diff)
+			}
+
+			// the original results should not have been changed
+			if diff := cmp.Diff(newResults(), results); diff != "" {
+				t.Errorf("TrimAdvisoryDetails() modified the original results (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/google/osv-scanner/v2/pkg/models"
@@ -9,14 +10,17 @@ import (
 )
 
 // PrintMarkdownTableResults prints the osv scan results into a human friendly table.
-func PrintMarkdownTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, showAllVulns bool) {
+func PrintMarkdownTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, showAllVulns bool, dedupe bool) {
 	text.DisableColors()
 
 	outputResult := BuildResults(vulnResult)
 
+	printLabels(vulnResult.Labels, outputWriter)
+	printMarkdownVulnSummary(vulnResult, outputWriter)
+
 	outputTable := table.NewWriter()
 	outputTable.SetOutputMirror(outputWriter)
-	outputTable = tableBuilder(outputTable, outputResult, showAllVulns)
+	outputTable = tableBuilder(outputTable, outputResult, showAllVulns, dedupe)
 
 	if outputTable.Length() != 0 {
 		outputTable.RenderMarkdown()
@@ -42,4 +46,37 @@ func PrintMarkdownTableResults(vulnResult *models.VulnerabilityResults, outputWr
 			outputLicenseViolationsTable.RenderMarkdown()
 		}
 	}
+
+	buildMarkdownUnscannedPackagesTable(outputWriter, vulnResult)
+	buildMarkdownIntegrityMismatchesTable(outputWriter, vulnResult)
+}
+
+func buildMarkdownUnscannedPackagesTable(outputWriter io.Writer, vulnResult *models.VulnerabilityResults) {
+	if len(vulnResult.UnscannedPackages) == 0 {
+		return
+	}
+
+	fmt.Fprintln(outputWriter)
+	fmt.Fprintln(outputWriter, unscannedPackagesSummary(vulnResult))
+	fmt.Fprintln(outputWriter)
+
+	outputUnscannedPackagesTable := table.NewWriter()
+	outputUnscannedPackagesTable.SetOutputMirror(outputWriter)
+	outputUnscannedPackagesTable = unscannedPackagesTableBuilder(outputUnscannedPackagesTable, vulnResult)
+	outputUnscannedPackagesTable.RenderMarkdown()
+}
+
+func buildMarkdownIntegrityMismatchesTable(outputWriter io.Writer, vulnResult *models.VulnerabilityResults) {
+	if len(vulnResult.IntegrityMismatches) == 0 {
+		return
+	}
+
+	fmt.Fprintln(outputWriter)
+	fmt.Fprintln(outputWriter, integrityMismatchesSummary(vulnResult))
+	fmt.Fprintln(outputWriter)
+
+	outputIntegrityMismatchesTable := table.NewWriter()
+	outputIntegrityMismatchesTable.SetOutputMirror(outputWriter)
+	outputIntegrityMismatchesTable = integrityMismatchesTableBuilder(outputIntegrityMismatchesTable, vulnResult)
+	outputIntegrityMismatchesTable.RenderMarkdown()
 }
@@ -15,7 +15,7 @@ func TestPrintMarkdownTableResults_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, true)
+		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, true, false)
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -28,7 +28,7 @@ func TestPrintMarkdownTableResults_WithLicenseViolations(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, false)
+		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, false, false)
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -41,8 +41,17 @@ func TestPrintMarkdownTableResults_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, false)
+		output.PrintMarkdownTableResults(args.vulnResult, outputWriter, false, false)
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
 }
+
+func TestPrintMarkdownTableResults_WithUnscannedPackages(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintMarkdownTableResults(unscannedPackagesResult(), outputWriter, false, false)
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
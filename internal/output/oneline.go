@@ -0,0 +1,79 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/utility/purl"
+	"github.com/google/osv-scanner/v2/internal/utility/severity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// onelineEmpty is printed in place of fields that have no value, so that
+// every line always has the same number of fields
+const onelineEmpty = "-"
+
+// PrintOnelineResults prints each vulnerability found on a single line, as
+// tab separated "severity, vulnerability id, purl, location, fixed version" fields
+// with no header or summary, so the output can be easily processed with tools like grep and awk.
+// If the results have labels, they are included as a trailing comma separated "key=value" field.
+func PrintOnelineResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, showAllVulns bool) {
+	result := BuildResults(vulnResult)
+	labels := strings.ReplaceAll(formatLabels(vulnResult.Labels), ", ", ",")
+
+	for _, eco := range result.Ecosystems {
+		for _, source := range eco.Sources {
+			for _, pkg := range source.Packages {
+				vulns := pkg.RegularVulns
+				if showAllVulns {
+					vulns = slices.Concat(vulns, pkg.HiddenVulns)
+				}
+
+				for _, vuln := range vulns {
+					fields := []string{
+						onelineField(string(vuln.SeverityRating), string(severity.UnknownRating)),
+						vuln.ID,
+						onelinePURL(eco.Name, pkg),
+						onelineField(strings.TrimPrefix(source.Name, string(source.Type)+":"), onelineEmpty),
+						onelineField(vuln.FixedVersion, onelineEmpty),
+					}
+					if labels != "" {
+						fields = append(fields, labels)
+					}
+
+					fmt.Fprintln(outputWriter, strings.Join(fields, "\t"))
+				}
+			}
+		}
+	}
+}
+
+func onelineField(value string, fallback string) string {
+	if value == "" || value == UnfixedDescription || value == VersionUnsupported {
+		return fallback
+	}
+
+	return value
+}
+
+// onelinePURL returns the purl of the package, falling back to the name and
+// version (or commit) of the package if it is not in an ecosystem with purls
+func onelinePURL(ecosystem string, pkg PackageResult) string {
+	p, err := purl.FromPackage(models.PackageInfo{
+		Name:      pkg.Name,
+		Version:   pkg.InstalledVersion,
+		Ecosystem: ecosystem,
+	})
+	if err == nil {
+		return p.ToString()
+	}
+
+	version := getInstalledVersionOrCommit(pkg)
+	if version == "" {
+		return pkg.Name
+	}
+
+	return pkg.Name + "@" + version
+}
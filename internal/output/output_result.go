@@ -7,6 +7,7 @@ import (
 	"io"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/google/osv-scalibr/extractor"
@@ -31,6 +32,14 @@ type Result struct {
 	VulnTypeSummary     VulnTypeSummary
 	PackageTypeCount    AnalysisCount
 	VulnCount           VulnCount
+	// RiskScore is nil unless the aggregate risk score of the vulnerabilities was calculated
+	RiskScore *models.RiskScore `json:",omitempty"`
+	// Summary is nil unless the summary of the vulnerabilities was requested
+	Summary *models.ResultsSummary `json:",omitempty"`
+	// Labels are the metadata that was attached to the scan, if any
+	Labels map[string]string `json:",omitempty"`
+	// VulnGroups is nil unless the vulnerabilities are being grouped by their id
+	VulnGroups []VulnGroup `json:",omitempty"`
 }
 
 // EcosystemResult represents the vulnerability scanning results for an ecosystem.
@@ -68,6 +77,10 @@ type PackageResult struct {
 	Licenses          []models.License
 	LicenseViolations []models.License
 	DepGroups         []string `json:"-"`
+	// GoWorkspace is set if the package is a Go module required by a member of a Go workspace
+	GoWorkspace *models.GoWorkspace `json:"-"`
+	// IntroducedVia is the shortest chain of dependencies that introduces the package, if known
+	IntroducedVia []models.DependencyLink `json:"-"`
 }
 
 // VulnResult represents a single vulnerability.
@@ -82,6 +95,17 @@ type VulnResult struct {
 	VulnAnalysisType VulnAnalysisType
 	SeverityRating   severity.Rating
 	SeverityScore    string
+	// SeverityLabel is the custom label of the severity of the vulnerability, if labels are configured
+	SeverityLabel string `json:",omitempty"`
+	// EPSS is the highest EPSS score of the CVEs of the vulnerability, if they were looked up
+	EPSS *models.EPSSScore `json:",omitempty"`
+	// KEV is the entry in the Known Exploited Vulnerabilities catalog of the vulnerability,
+	// if it has been exploited and the catalog was checked
+	KEV *models.KEVEntry `json:",omitempty"`
+	// AffectedSymbols are the vulnerable symbols that call analysis found to be reachable
+	AffectedSymbols []models.AffectedSymbol `json:",omitempty"`
+	// Suppression is set if the vulnerability is soft-suppressed, so does not fail the scan
+	Suppression *models.Suppression `json:",omitempty"`
 }
 
 type ImageInfo struct {
@@ -203,7 +227,12 @@ RowLoop:
 		}
 	}
 
-	return buildResult(ecosystemMap, resultCount, vulnResult.ImageMetadata, vulnResult.ExperimentalAnalysisConfig.Licenses, vulnResult.LicenseSummary)
+	result := buildResult(ecosystemMap, resultCount, vulnResult.ImageMetadata, vulnResult.ExperimentalAnalysisConfig.Licenses, vulnResult.LicenseSummary)
+	result.RiskScore = vulnResult.RiskScore
+	result.Summary = vulnResult.Summary
+	result.Labels = vulnResult.Labels
+
+	return result
 }
 
 // buildResult builds the final Result object from the ecosystem map and total vulnerability count.
@@ -475,6 +504,8 @@ func processPackage(vulnPkg models.PackageVulns) PackageResult {
 		Licenses:          vulnPkg.Licenses,
 		LicenseViolations: vulnPkg.LicenseViolations,
 		DepGroups:         vulnPkg.DepGroups,
+		GoWorkspace:       vulnPkg.GoWorkspace,
+		IntroducedVia:     vulnPkg.IntroducedVia,
 	}
 
 	return packageResult
@@ -501,16 +532,22 @@ func processVulnGroups(vulnPkg models.PackageVulns) (map[string]VulnResult, map[
 		}
 
 		vuln := VulnResult{
-			ID:       representID,
-			GroupIDs: group.IDs,
-			Aliases:  aliases,
+			ID:          representID,
+			GroupIDs:    group.IDs,
+			Aliases:     aliases,
+			EPSS:        group.EPSS,
+			KEV:         group.KEV,
+			Suppression: group.Suppression,
 		}
 
+		vuln.AffectedSymbols = getAffectedSymbols(group)
+
 		vuln.SeverityScore = group.MaxSeverity
 		vuln.SeverityRating, _ = severity.CalculateRating(vuln.SeverityScore)
 		if vuln.SeverityRating == severity.UnknownRating {
 			vuln.SeverityScore = "N/A"
 		}
+		vuln.SeverityLabel = group.SeverityLabel
 
 		if group.IsCalled() && !group.IsGroupUnimportant() {
 			vuln.VulnAnalysisType = VulnTypeRegular
@@ -527,6 +564,21 @@ func processVulnGroups(vulnPkg models.PackageVulns) (map[string]VulnResult, map[
 	return regularVulnMap, hiddenVulnMap
 }
 
+// getAffectedSymbols returns the reachable symbols found by the analyses of the
+// vulnerabilities in the group, which can overlap as they are aliases of each other
+func getAffectedSymbols(group models.GroupInfo) []models.AffectedSymbol {
+	var symbols []models.AffectedSymbol
+	for _, id := range group.IDs {
+		for _, symbol := range group.ExperimentalAnalysis[id].AffectedSymbols {
+			if !slices.ContainsFunc(symbols, func(s models.AffectedSymbol) bool { return s.Symbol == symbol.Symbol }) {
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+
+	return symbols
+}
+
 // updateVuln updates each vulnerability info in vulnMap from the details of vulnPkg.Vulnerabilities.
 func updateVuln(vulnMap map[string]VulnResult, vulnPkg models.PackageVulns) {
 	for _, vuln := range vulnPkg.Vulnerabilities {
@@ -825,9 +877,44 @@ func printSummary(result Result, out io.Writer) {
 		vulnerabilityForm,
 		fixedVulnForm,
 	)
+
+	if result.RiskScore != nil {
+		summary += describeRiskScore(*result.RiskScore)
+	}
+
 	fmt.Fprintln(out, summary)
 }
 
+// describeRiskScore describes the aggregate risk score, highlighting it if it is higher than the maximum
+func describeRiskScore(riskScore models.RiskScore) string {
+	score := strconv.FormatFloat(riskScore.Score, 'f', -1, 64)
+
+	if riskScore.MaxScore <= 0 {
+		return fmt.Sprintf("Aggregate risk score: %s.\n", score)
+	}
+
+	if riskScore.Score > riskScore.MaxScore {
+		score = text.FgRed.Sprint(score)
+	}
+
+	return fmt.Sprintf("Aggregate risk score: %s (maximum %s).\n", score, strconv.FormatFloat(riskScore.MaxScore, 'f', -1, 64))
+}
+
+// formatInstalledVersion returns the installed version of the package, noting if it is
+// a Go module that the workspace it is required in builds a different version of
+func formatInstalledVersion(pkg PackageResult) string {
+	ws := pkg.GoWorkspace
+	if ws == nil || ws.Selected {
+		return pkg.InstalledVersion
+	}
+
+	if ws.SelectedVersion == "" {
+		return pkg.InstalledVersion + " (not used by go.work)"
+	}
+
+	return fmt.Sprintf("%s (go.work selects %s)", pkg.InstalledVersion, ws.SelectedVersion)
+}
+
 func getInstalledVersionOrCommit(pkg PackageResult) string {
 	result := pkg.InstalledVersion
 	if result == "" && pkg.Commit != "" {
@@ -0,0 +1,205 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/resultspb"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PrintProtoResults writes the results as a binary encoded resultspb.Results message
+func PrintProtoResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) error {
+	msg, err := ToProtoResults(vulnResult)
+	if err != nil {
+		return err
+	}
+
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+
+	_, err = outputWriter.Write(b)
+
+	return err
+}
+
+// ToProtoResults converts the results into their protocol buffer representation
+func ToProtoResults(vulnResult *models.VulnerabilityResults) (*resultspb.Results, error) {
+	msg := &resultspb.Results{}
+
+	for _, source := range vulnResult.Results {
+		ps := &resultspb.PackageSource{Source: protoSourceInfo(source.Source)}
+
+		for _, pkg := range source.Packages {
+			pv, err := protoPackageVulns(pkg)
+			if err != nil {
+				return nil, err
+			}
+			ps.Packages = append(ps.Packages, pv)
+		}
+
+		msg.Results = append(msg.Results, ps)
+	}
+
+	for _, pkg := range vulnResult.UnscannedPackages {
+		msg.UnscannedPackages = append(msg.UnscannedPackages, &resultspb.UnscannedPackage{
+			Package: protoPackageInfo(pkg.Package),
+			Source:  protoSourceInfo(pkg.Source),
+			Reason:  string(pkg.Reason),
+			Error:   pkg.Error,
+		})
+	}
+
+	for _, lc := range vulnResult.LicenseSummary {
+		msg.LicenseSummary = append(msg.LicenseSummary, &resultspb.LicenseCount{
+			Name:  string(lc.Name),
+			Count: int64(lc.Count),
+		})
+	}
+
+	if rm := vulnResult.RepositoryMetadata; rm != nil {
+		msg.RepositoryMetadata = &resultspb.RepositoryMetadata{Url: rm.URL, Ref: rm.Ref, Commit: rm.Commit}
+	}
+
+	if rs := vulnResult.RiskScore; rs != nil {
+		msg.RiskScore = &resultspb.RiskScore{Score: rs.Score, MaxScore: rs.MaxScore}
+	}
+
+	if len(vulnResult.Labels) > 0 {
+		msg.Labels = maps.Clone(vulnResult.Labels)
+	}
+
+	return msg, nil
+}
+
+var protoSourceTypes = map[models.SourceType]resultspb.SourceType{
+	models.SourceTypeUnknown:        resultspb.SourceType_SOURCE_TYPE_UNKNOWN,
+	models.SourceTypeOSPackage:      resultspb.SourceType_SOURCE_TYPE_OS,
+	models.SourceTypeProjectPackage: resultspb.SourceType_SOURCE_TYPE_LOCKFILE,
+	models.SourceTypeArtifact:       resultspb.SourceType_SOURCE_TYPE_ARTIFACT,
+	models.SourceTypeSBOM:           resultspb.SourceType_SOURCE_TYPE_SBOM,
+	models.SourceTypeGit:            resultspb.SourceType_SOURCE_TYPE_GIT,
+}
+
+func protoSourceInfo(source models.SourceInfo) *resultspb.SourceInfo {
+	return &resultspb.SourceInfo{
+		Path: source.Path,
+		// unrecognized types become SOURCE_TYPE_UNSPECIFIED
+		Type: protoSourceTypes[source.Type],
+	}
+}
+
+func protoPackageInfo(pkg models.PackageInfo) *resultspb.PackageInfo {
+	return &resultspb.PackageInfo{
+		Name:          pkg.Name,
+		OsPackageName: pkg.OSPackageName,
+		Version:       pkg.Version,
+		Ecosystem:     pkg.Ecosystem,
+		Commit:        pkg.Commit,
+	}
+}
+
+func protoPackageVulns(pkg models.PackageVulns) (*resultspb.PackageVulns, error) {
+	pv := &resultspb.PackageVulns{
+		Package:          protoPackageInfo(pkg.Package),
+		DependencyGroups: pkg.DepGroups,
+	}
+
+	for _, vuln := range pkg.Vulnerabilities {
+		v, err := protoVulnerability(vuln)
+		if err != nil {
+			return nil, err
+		}
+		pv.Vulnerabilities = append(pv.Vulnerabilities, v)
+	}
+
+	for _, group := range pkg.Groups {
+		pv.Groups = append(pv.Groups, protoGroup(group))
+	}
+
+	for _, license := range pkg.Licenses {
+		pv.Licenses = append(pv.Licenses, string(license))
+	}
+
+	for _, license := range pkg.LicenseViolations {
+		pv.LicenseViolations = append(pv.LicenseViolations, string(license))
+	}
+
+	return pv, nil
+}
+
+func protoVulnerability(vuln osvschema.Vulnerability) (*resultspb.Vulnerability, error) {
+	osvJSON, err := json.Marshal(vuln)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", vuln.ID, err)
+	}
+
+	v := &resultspb.Vulnerability{
+		Id:      vuln.ID,
+		Aliases: vuln.Aliases,
+		Related: vuln.Related,
+		Summary: vuln.Summary,
+		Details: vuln.Details,
+		OsvJson: osvJSON,
+	}
+
+	if !vuln.Modified.IsZero() {
+		v.Modified = timestamppb.New(vuln.Modified)
+	}
+
+	if !vuln.Published.IsZero() {
+		v.Published = timestamppb.New(vuln.Published)
+	}
+
+	for _, severity := range vuln.Severity {
+		v.Severity = append(v.Severity, &resultspb.Severity{
+			Type:  string(severity.Type),
+			Score: severity.Score,
+		})
+	}
+
+	return v, nil
+}
+
+func protoGroup(group models.GroupInfo) *resultspb.Group {
+	g := &resultspb.Group{
+		Ids:           group.IDs,
+		Aliases:       group.Aliases,
+		MaxSeverity:   group.MaxSeverity,
+		SeverityLabel: group.SeverityLabel,
+		Called:        group.IsCalled(),
+		Unimportant:   group.IsGroupUnimportant(),
+	}
+
+	if group.EPSS != nil {
+		g.Epss = &resultspb.EPSSScore{
+			Cve:        group.EPSS.CVE,
+			Score:      group.EPSS.Score,
+			Percentile: group.EPSS.Percentile,
+			Date:       group.EPSS.Date,
+		}
+	}
+
+	if group.KEV != nil {
+		g.Kev = &resultspb.KEVEntry{
+			Cve:                        group.KEV.CVE,
+			DateAdded:                  group.KEV.DateAdded,
+			DueDate:                    group.KEV.DueDate,
+			RequiredAction:             group.KEV.RequiredAction,
+			KnownRansomwareCampaignUse: group.KEV.KnownRansomwareCampaignUse,
+		}
+	}
+
+	if group.Suppression != nil {
+		g.SuppressionReason = group.Suppression.Reason
+	}
+
+	return g
+}
@@ -0,0 +1,189 @@
+package output_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/google/osv-scanner/v2/pkg/resultspb"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+	"google.golang.org/protobuf/proto"
+)
+
+// expectProtoToMatchResults checks that the encoded proto can be decoded,
+// and that it has the same packages and vulnerabilities as the results
+func expectProtoToMatchResults(t *testing.T, vulnResult *models.VulnerabilityResults, b []byte) {
+	t.Helper()
+
+	msg := &resultspb.Results{}
+	if err := proto.Unmarshal(b, msg); err != nil {
+		t.Fatalf("Error decoding proto output: %s", err)
+	}
+
+	if len(msg.GetResults()) != len(vulnResult.Results) {
+		t.Fatalf("expected %d sources but got %d", len(vulnResult.Results), len(msg.GetResults()))
+	}
+
+	for i, source := range vulnResult.Results {
+		got := msg.GetResults()[i]
+
+		if got.GetSource().GetPath() != source.Source.Path {
+			t.Errorf("expected source %q but got %q", source.Source.Path, got.GetSource().GetPath())
+		}
+
+		if len(got.GetPackages()) != len(source.Packages) {
+			t.Fatalf("expected %d packages but got %d", len(source.Packages), len(got.GetPackages()))
+		}
+
+		for j, pkg := range source.Packages {
+			gotPkg := got.GetPackages()[j]
+
+			if gotPkg.GetPackage().GetName() != pkg.Package.Name {
+				t.Errorf("expected package %q but got %q", pkg.Package.Name, gotPkg.GetPackage().GetName())
+			}
+
+			if len(gotPkg.GetVulnerabilities()) != len(pkg.Vulnerabilities) {
+				t.Fatalf("expected %d vulnerabilities but got %d", len(pkg.Vulnerabilities), len(gotPkg.GetVulnerabilities()))
+			}
+
+			for k, vuln := range pkg.Vulnerabilities {
+				var gotVuln osvschema.Vulnerability
+				if err := json.Unmarshal(gotPkg.GetVulnerabilities()[k].GetOsvJson(), &gotVuln); err != nil {
+					t.Fatalf("Error decoding OSV record: %s", err)
+				}
+
+				if diff := cmp.Diff(vuln, gotVuln); diff != "" {
+					t.Errorf("OSV record mismatch (-want +got):\n%s", diff)
+				}
+			}
+
+			if len(gotPkg.GetGroups()) != len(pkg.Groups) {
+				t.Errorf("expected %d groups but got %d", len(pkg.Groups), len(gotPkg.GetGroups()))
+			}
+
+			if diff := cmp.Diff(len(pkg.LicenseViolations), len(gotPkg.GetLicenseViolations())); diff != "" {
+				t.Errorf("license violations mismatch (-want +got):\n%s", diff)
+			}
+		}
+	}
+}
+
+func TestPrintProtoResults_WithVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithVulnerabilities(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintProtoResults(args.vulnResult, outputWriter)
+
+		if err != nil {
+			t.Errorf("Error writing proto output: %s", err)
+		}
+
+		expectProtoToMatchResults(t, args.vulnResult, outputWriter.Bytes())
+	})
+}
+
+func TestPrintProtoResults_WithMixedIssues(t *testing.T) {
+	t.Parallel()
+
+	testOutputWithMixedIssues(t, func(t *testing.T, args outputTestCaseArgs) {
+		t.Helper()
+
+		outputWriter := &bytes.Buffer{}
+		err := output.PrintProtoResults(args.vulnResult, outputWriter)
+
+		if err != nil {
+			t.Errorf("Error writing proto output: %s", err)
+		}
+
+		expectProtoToMatchResults(t, args.vulnResult, outputWriter.Bytes())
+	})
+}
+
+func TestToProtoResults(t *testing.T) {
+	t.Parallel()
+
+	got, err := output.ToProtoResults(&models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/dir/go.mod", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "golang.org/x/text", Version: "0.3.0", Ecosystem: "Go"},
+						Vulnerabilities: []osvschema.Vulnerability{
+							{
+								ID:       "GO-2021-0113",
+								Aliases:  []string{"CVE-2021-38561"},
+								Severity: []osvschema.Severity{{Type: osvschema.SeverityCVSSV3, Score: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H"}},
+							},
+						},
+						Groups: []models.GroupInfo{
+							{
+								IDs:           []string{"GO-2021-0113"},
+								Aliases:       []string{"CVE-2021-38561", "GO-2021-0113"},
+								MaxSeverity:   "7.5",
+								SeverityLabel: "P1",
+								KEV:           &models.KEVEntry{CVE: "CVE-2021-38561", DateAdded: "2024-01-01"},
+								Suppression:   &models.Suppression{Reason: "not exposed"},
+							},
+						},
+					},
+				},
+			},
+		},
+		UnscannedPackages: []models.UnscannedPackage{
+			{
+				Package: models.PackageInfo{Name: "mystery"},
+				Source:  models.SourceInfo{Path: "/dir/thing.lock", Type: "something-new"},
+				Reason:  models.UnscannedReasonUnknownEcosystem,
+			},
+		},
+		RiskScore: &models.RiskScore{Score: 12.5},
+		Labels:    map[string]string{"env": "prod"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.GetResults()[0].GetSource().GetType() != resultspb.SourceType_SOURCE_TYPE_LOCKFILE {
+		t.Errorf("expected source type to be lockfile, got %s", got.GetResults()[0].GetSource().GetType())
+	}
+
+	group := got.GetResults()[0].GetPackages()[0].GetGroups()[0]
+
+	if !group.GetCalled() || group.GetSeverityLabel() != "P1" || group.GetSuppressionReason() != "not exposed" {
+		t.Errorf("group was not converted correctly: %v", group)
+	}
+
+	if group.GetKev().GetCve() != "CVE-2021-38561" {
+		t.Errorf("expected KEV entry to be converted, got %v", group.GetKev())
+	}
+
+	vuln := got.GetResults()[0].GetPackages()[0].GetVulnerabilities()[0]
+
+	if vuln.GetSeverity()[0].GetType() != "CVSS_V3" {
+		t.Errorf("expected severity type to be CVSS_V3, got %s", vuln.GetSeverity()[0].GetType())
+	}
+
+	if vuln.GetModified() != nil {
+		t.Errorf("expected unset modified time to be omitted, got %v", vuln.GetModified())
+	}
+
+	if got.GetUnscannedPackages()[0].GetSource().GetType() != resultspb.SourceType_SOURCE_TYPE_UNSPECIFIED {
+		t.Errorf("expected unknown source type to be unspecified, got %s", got.GetUnscannedPackages()[0].GetSource().GetType())
+	}
+
+	if got.GetRiskScore().GetScore() != 12.5 {
+		t.Errorf("expected risk score of 12.5, got %v", got.GetRiskScore().GetScore())
+	}
+
+	if got.GetLabels()["env"] != "prod" {
+		t.Errorf("expected labels to be converted, got %v", got.GetLabels())
+	}
+}
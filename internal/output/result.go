@@ -115,6 +115,12 @@ type groupedSARIFFinding struct {
 	// AliasedIDList contains all aliased IDs, including ones that are not OSV (e.g. CVE IDs)
 	// Sorted by idSortFunc, therefore the first element will be the display ID
 	AliasedIDList []string
+	// EPSS is the highest EPSS score of the groups, if they were looked up
+	EPSS *models.EPSSScore `json:",omitempty"`
+	// KEV is the entry in the KEV catalog of the groups, if any have been exploited
+	KEV *models.KEVEntry `json:",omitempty"`
+	// SeverityLabel is the custom label of the severity of the groups, if labels are configured
+	SeverityLabel string `json:",omitempty"`
 }
 
 // mapIDsToGroupedSARIFFinding creates a map over all vulnerability IDs, with aliased vuln IDs
@@ -146,6 +152,15 @@ func mapIDsToGroupedSARIFFinding(vulns *models.VulnerabilityResults) map[string]
 				for _, id := range gi.IDs {
 					results[id] = data
 				}
+				if gi.EPSS != nil && (data.EPSS == nil || gi.EPSS.Score > data.EPSS.Score) {
+					data.EPSS = gi.EPSS
+				}
+				if gi.KEV != nil && data.KEV == nil {
+					data.KEV = gi.KEV
+				}
+				if data.SeverityLabel == "" {
+					data.SeverityLabel = gi.SeverityLabel
+				}
 			}
 			for _, v := range pkg.Vulnerabilities {
 				newPkgSource := pkgWithSource{
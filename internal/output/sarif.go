@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
 	"github.com/google/osv-scanner/v2/internal/identifiers"
 	"github.com/google/osv-scanner/v2/internal/url"
 	"github.com/google/osv-scanner/v2/internal/utility/results"
@@ -169,6 +170,12 @@ func stripGitHubWorkspace(path string) string {
 	return strings.TrimPrefix(path, "/github/workspace/")
 }
 
+// stripLocationWithinFile removes the location within a file from the path of a source,
+// such as the cell of a notebook, so that the source can be found by SARIF consumers
+func stripLocationWithinFile(path string) string {
+	return cachedregexp.MustCompile(`#cell-\d+$`).ReplaceAllString(path, "")
+}
+
 // createSARIFHelpText returns the text for SARIF rule's help field
 func createSARIFHelpText(gv *groupedSARIFFinding) string {
 	backtickSARIFTemplate := strings.ReplaceAll(strings.TrimSpace(SARIFTemplate), `""`, "`")
@@ -235,6 +242,11 @@ func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.W
 	run := sarif.NewRunWithInformationURI("osv-scanner", "https://github.com/google/osv-scanner")
 	run.Tool.Driver.WithVersion(version.OSVVersion)
 
+	// labels describe everything that was scanned, so apply to every result of the run
+	if len(vulnResult.Labels) > 0 {
+		run.WithProperties(sarif.NewPropertyBag().Add("labels", vulnResult.Labels))
+	}
+
 	vulnIDMap := mapIDsToGroupedSARIFFinding(vulnResult)
 	// Sort the IDs to have deterministic loop of vulnIDMap
 	vulnIDs := []string{}
@@ -271,6 +283,11 @@ func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.W
 			shortDescription = gv.DisplayID
 		}
 
+		// Make vulnerabilities that are known to have been exploited stand out
+		if gv.KEV != nil {
+			shortDescription = "[Known exploited] " + shortDescription
+		}
+
 		rule := run.AddRule(gv.DisplayID).
 			WithName(gv.DisplayID).
 			WithShortDescription(sarif.NewMultiformatMessageString().WithMarkdown(shortDescription)).
@@ -286,16 +303,36 @@ func PrintSARIFReport(vulnResult *models.VulnerabilityResults, outputWriter io.W
 			}
 		}
 
-		if worstScore >= 0 {
+		if worstScore >= 0 || gv.SeverityLabel != "" || gv.EPSS != nil || gv.KEV != nil {
 			var bag = sarif.NewPropertyBag()
-			bag.Add("security-severity", strconv.FormatFloat(worstScore, 'f', -1, 64))
+			if worstScore >= 0 {
+				bag.Add("security-severity", strconv.FormatFloat(worstScore, 'f', -1, 64))
+			}
+			if gv.SeverityLabel != "" {
+				bag.Add("severity-label", gv.SeverityLabel)
+			}
+			if gv.EPSS != nil {
+				bag.Add("epss", strconv.FormatFloat(gv.EPSS.Score, 'f', -1, 64))
+				bag.Add("epss-percentile", strconv.FormatFloat(gv.EPSS.Percentile, 'f', -1, 64))
+			}
+			if gv.KEV != nil {
+				bag.WithTags([]string{"known-exploited"})
+				bag.Add("kev-cve", gv.KEV.CVE)
+				bag.Add("kev-date-added", gv.KEV.DateAdded)
+				if gv.KEV.DueDate != "" {
+					bag.Add("kev-due-date", gv.KEV.DueDate)
+				}
+				if gv.KEV.KnownRansomwareCampaignUse {
+					bag.Add("kev-ransomware", true)
+				}
+			}
 			rule.WithProperties(bag)
 		}
 
 		rule.DeprecatedIds = gv.AliasedIDList
 
 		for _, pws := range gv.PkgSource.StableKeys() {
-			artifactPath := stripGitHubWorkspace(pws.Source.Path)
+			artifactPath := stripGitHubWorkspace(stripLocationWithinFile(pws.Source.Path))
 			if filepath.IsAbs(artifactPath) {
 				// this only errors if the file path is not absolute,
 				// which we've already confirmed is not the case
@@ -1,6 +1,7 @@
 package sbom
 
 import (
+	"maps"
 	"slices"
 	"strings"
 	"time"
@@ -81,10 +82,33 @@ func addVulnerabilities(vulnerabilities map[string]cyclonedx.Vulnerability, pack
 			Ratings:     buildRatings(vulnerability),
 			Advisories:  buildAdvisories(vulnerability),
 			Credits:     buildCredits(vulnerability),
+			Properties:  buildKEVProperties(vulnerability, packageDetail.Groups),
 		}
 	}
 }
 
+// buildKEVProperties records if the vulnerability is in the Known Exploited
+// Vulnerabilities catalog of CISA, as there is no standard field for it
+func buildKEVProperties(vulnerability osvschema.Vulnerability, groups []models.GroupInfo) *[]cyclonedx.Property {
+	for _, group := range groups {
+		if group.KEV == nil || !slices.Contains(group.IDs, vulnerability.ID) {
+			continue
+		}
+
+		properties := []cyclonedx.Property{
+			{Name: "osv-scanner:kev:cve", Value: group.KEV.CVE},
+			{Name: "osv-scanner:kev:date-added", Value: group.KEV.DateAdded},
+		}
+		if group.KEV.DueDate != "" {
+			properties = append(properties, cyclonedx.Property{Name: "osv-scanner:kev:due-date", Value: group.KEV.DueDate})
+		}
+
+		return &properties
+	}
+
+	return nil
+}
+
 func formatDateIfExists(date time.Time) string {
 	if date.IsZero() {
 		return ""
@@ -163,3 +187,18 @@ func buildAdvisories(vulnerability osvschema.Vulnerability) *[]cyclonedx.Advisor
 
 	return &advisories
 }
+
+// BuildLabelsMetadata records the labels of the scan as properties of the metadata
+// of the BOM, as they describe what was scanned rather than any one component
+func BuildLabelsMetadata(labels map[string]string) *cyclonedx.Metadata {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	properties := make([]cyclonedx.Property, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		properties = append(properties, cyclonedx.Property{Name: "osv-scanner:label:" + key, Value: labels[key]})
+	}
+
+	return &cyclonedx.Metadata{Properties: &properties}
+}
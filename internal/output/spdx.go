@@ -22,6 +22,11 @@ func PrintSPDXResults(vulnResult *models.VulnerabilityResults, outputWriter io.W
 	// TODO(#1783): Allow user configuration
 	doc := converter.ToSPDX23(scanResult, converter.SPDXConfig{})
 
+	// SPDX has no field for arbitrary metadata, so the labels are recorded in the comment
+	if len(vulnResult.Labels) > 0 {
+		doc.DocumentComment = "Labels: " + formatLabels(vulnResult.Labels)
+	}
+
 	encoder := json.NewEncoder(outputWriter)
 	encoder.SetIndent("", "  ")
 
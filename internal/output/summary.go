@@ -0,0 +1,234 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// maxTopPackages is the number of packages with the most vulnerabilities in the summary
+const maxTopPackages = 10
+
+// BuildSummary counts the vulnerabilities of the result by severity, by ecosystem,
+// by whether they can be fixed, and by whether they are called, along with finding
+// the packages with the most vulnerabilities
+func BuildSummary(result Result) models.ResultsSummary {
+	summary := models.ResultsSummary{
+		Vulnerabilities:     result.VulnCount.AnalysisCount.Regular,
+		Severity:            severityCounts(result.VulnCount.SeverityCount),
+		Ecosystems:          make(map[string]int),
+		Fixable:             result.VulnCount.FixableCount.Fixed,
+		Unfixable:           result.VulnCount.FixableCount.UnFixed,
+		Called:              result.VulnCount.AnalysisCount.Regular,
+		SeverityByEcosystem: make(map[string]models.SeverityCounts),
+		TopPackages:         []models.PackageSummary{},
+	}
+
+	var fixable, unfixable SeverityCount
+	byEcosystem := make(map[string]SeverityCount)
+	// the same package can be found in many sources, which are counted together
+	topPackageIndexes := make(map[[3]string]int)
+
+	for _, eco := range result.Ecosystems {
+		name := eco.Name
+		if name == "" {
+			// packages without an ecosystem are identified by their git commit
+			name = "GIT"
+		}
+
+		for _, source := range eco.Sources {
+			for _, pkg := range source.Packages {
+				for _, vuln := range pkg.HiddenVulns {
+					if vuln.VulnAnalysisType == VulnTypeUncalled {
+						summary.Uncalled++
+					}
+				}
+
+				if len(pkg.RegularVulns) == 0 {
+					continue
+				}
+
+				summary.Ecosystems[name] += len(pkg.RegularVulns)
+
+				version := cmp.Or(pkg.InstalledVersion, pkg.Commit)
+				i, ok := topPackageIndexes[[3]string{name, pkg.Name, version}]
+				if !ok {
+					i = len(summary.TopPackages)
+					topPackageIndexes[[3]string{name, pkg.Name, version}] = i
+					summary.TopPackages = append(summary.TopPackages, models.PackageSummary{Name: pkg.Name, Version: version, Ecosystem: name})
+				}
+				summary.TopPackages[i].Vulnerabilities += len(pkg.RegularVulns)
+
+				for _, vuln := range pkg.RegularVulns {
+					byEcosystem[name] = increaseSeverityCount(byEcosystem[name], vuln.SeverityRating)
+
+					if vuln.IsFixable {
+						fixable = increaseSeverityCount(fixable, vuln.SeverityRating)
+						summary.TopPackages[i].Fixable++
+					} else {
+						unfixable = increaseSeverityCount(unfixable, vuln.SeverityRating)
+					}
+				}
+			}
+		}
+	}
+
+	for name, counts := range byEcosystem {
+		summary.SeverityByEcosystem[name] = severityCounts(counts)
+	}
+	summary.FixableBySeverity = severityCounts(fixable)
+	summary.UnfixableBySeverity = severityCounts(unfixable)
+
+	slices.SortStableFunc(summary.TopPackages, func(a, b models.PackageSummary) int {
+		return cmp.Or(
+			cmp.Compare(b.Vulnerabilities, a.Vulnerabilities),
+			cmp.Compare(a.Ecosystem, b.Ecosystem),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.Version, b.Version),
+		)
+	})
+	if len(summary.TopPackages) > maxTopPackages {
+		summary.TopPackages = summary.TopPackages[:maxTopPackages]
+	}
+
+	return summary
+}
+
+func severityCounts(count SeverityCount) models.SeverityCounts {
+	return models.SeverityCounts{
+		Critical: count.Critical,
+		High:     count.High,
+		Medium:   count.Medium,
+		Low:      count.Low,
+		Unknown:  count.Unknown,
+	}
+}
+
+// WithSummary returns a copy of the results with the summary of their vulnerabilities,
+// leaving the original results unchanged
+func WithSummary(vulnResult *models.VulnerabilityResults) *models.VulnerabilityResults {
+	summary := BuildSummary(BuildResults(vulnResult))
+
+	withSummary := *vulnResult
+	withSummary.Summary = &summary
+
+	return &withSummary
+}
+
+// summaryLines describes each of the counts of the summary in a line
+func summaryLines(summary models.ResultsSummary) []string {
+	ecosystems := make([]string, 0, len(summary.Ecosystems))
+	for _, name := range slices.Sorted(maps.Keys(summary.Ecosystems)) {
+		ecosystems = append(ecosystems, fmt.Sprintf("%s %d", name, summary.Ecosystems[name]))
+	}
+	if len(ecosystems) == 0 {
+		ecosystems = append(ecosystems, "none")
+	}
+
+	return []string{
+		fmt.Sprintf(
+			"Severity: %d critical, %d high, %d medium, %d low, %d unknown",
+			summary.Severity.Critical,
+			summary.Severity.High,
+			summary.Severity.Medium,
+			summary.Severity.Low,
+			summary.Severity.Unknown,
+		),
+		"Ecosystems: " + strings.Join(ecosystems, ", "),
+		fmt.Sprintf("Fixable: %d fixable, %d unfixable", summary.Fixable, summary.Unfixable),
+		fmt.Sprintf("Call analysis: %d called, %d uncalled", summary.Called, summary.Uncalled),
+	}
+}
+
+func summaryHeading(summary models.ResultsSummary) string {
+	return fmt.Sprintf(
+		"Summary of %d %s:",
+		summary.Vulnerabilities,
+		Form(summary.Vulnerabilities, "vulnerability", "vulnerabilities"),
+	)
+}
+
+// printVulnSummary prints the summary of the vulnerabilities, if the results have one
+func printVulnSummary(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) {
+	if vulnResult.Summary == nil {
+		return
+	}
+
+	fmt.Fprintln(outputWriter, summaryHeading(*vulnResult.Summary))
+	for _, line := range summaryLines(*vulnResult.Summary) {
+		fmt.Fprintln(outputWriter, "  "+line)
+	}
+	fmt.Fprintln(outputWriter)
+}
+
+// printMarkdownVulnSummary prints the summary of the vulnerabilities as a markdown list,
+// if the results have one
+func printMarkdownVulnSummary(vulnResult *models.VulnerabilityResults, outputWriter io.Writer) {
+	if vulnResult.Summary == nil {
+		return
+	}
+
+	fmt.Fprintln(outputWriter, summaryHeading(*vulnResult.Summary))
+	fmt.Fprintln(outputWriter)
+	for _, line := range summaryLines(*vulnResult.Summary) {
+		fmt.Fprintln(outputWriter, "- "+line)
+	}
+	fmt.Fprintln(outputWriter)
+
+	if vulnResult.Summary.Vulnerabilities == 0 {
+		return
+	}
+
+	for _, builder := range []func(table.Writer, models.ResultsSummary) table.Writer{
+		severityMatrixTableBuilder,
+		fixabilityTableBuilder,
+		topPackagesTableBuilder,
+	} {
+		outputTable := table.NewWriter()
+		outputTable.SetOutputMirror(outputWriter)
+		builder(outputTable, *vulnResult.Summary).RenderMarkdown()
+		fmt.Fprintln(outputWriter)
+	}
+}
+
+// severityMatrixTableBuilder builds a table of the number of vulnerabilities of each
+// severity in each ecosystem, along with the totals of each
+func severityMatrixTableBuilder(outputTable table.Writer, summary models.ResultsSummary) table.Writer {
+	outputTable.AppendHeader(table.Row{"Ecosystem", "Critical", "High", "Medium", "Low", "Unknown", "Total"})
+	for _, name := range slices.Sorted(maps.Keys(summary.SeverityByEcosystem)) {
+		outputTable.AppendRow(severityCountsRow(name, summary.SeverityByEcosystem[name]))
+	}
+	outputTable.AppendFooter(severityCountsRow("Total", summary.Severity))
+
+	return outputTable
+}
+
+func severityCountsRow(name string, counts models.SeverityCounts) table.Row {
+	return table.Row{name, counts.Critical, counts.High, counts.Medium, counts.Low, counts.Unknown, counts.Total()}
+}
+
+// fixabilityTableBuilder builds a table of the number of vulnerabilities of each
+// severity that can and cannot be fixed
+func fixabilityTableBuilder(outputTable table.Writer, summary models.ResultsSummary) table.Writer {
+	outputTable.AppendHeader(table.Row{"Fixability", "Critical", "High", "Medium", "Low", "Unknown", "Total"})
+	outputTable.AppendRow(severityCountsRow("Fixable", summary.FixableBySeverity))
+	outputTable.AppendRow(severityCountsRow("Unfixable", summary.UnfixableBySeverity))
+
+	return outputTable
+}
+
+// topPackagesTableBuilder builds a table of the packages with the most vulnerabilities
+func topPackagesTableBuilder(outputTable table.Writer, summary models.ResultsSummary) table.Writer {
+	outputTable.AppendHeader(table.Row{"Most Vulnerable Packages", "Version", "Ecosystem", "Vulnerabilities", "Fixable"})
+	for _, pkg := range summary.TopPackages {
+		outputTable.AppendRow(table.Row{pkg.Name, pkg.Version, pkg.Ecosystem, pkg.Vulnerabilities, pkg.Fixable})
+	}
+
+	return outputTable
+}
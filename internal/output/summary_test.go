@@ -0,0 +1,159 @@
+package output_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func summaryResult() *models.VulnerabilityResults {
+	vulnResult := fixActionsResult()
+	vulnResult.Results[0].Packages[0].Groups[0].MaxSeverity = "9.8"
+	vulnResult.Results[0].Packages[1].Groups[0].MaxSeverity = "5.3"
+
+	vulnResult.Results = append(vulnResult.Results, models.PackageSource{
+		Source: models.SourceInfo{Path: "go.mod", Type: models.SourceTypeProjectPackage},
+		Packages: []models.PackageVulns{
+			{
+				Package: models.PackageInfo{Name: "golang.org/x/net", Version: "0.1.0", Ecosystem: "Go"},
+				Vulnerabilities: []osvschema.Vulnerability{
+					{ID: "GO-0001"},
+					{ID: "GO-0002"},
+				},
+				Groups: []models.GroupInfo{
+					{
+						IDs:                  []string{"GO-0001"},
+						MaxSeverity:          "7.5",
+						ExperimentalAnalysis: map[string]models.AnalysisInfo{"GO-0001": {Called: true}},
+					},
+					{
+						IDs:                  []string{"GO-0002"},
+						ExperimentalAnalysis: map[string]models.AnalysisInfo{"GO-0002": {Called: false}},
+					},
+				},
+			},
+		},
+	})
+
+	return vulnResult
+}
+
+func TestBuildSummary(t *testing.T) {
+	t.Parallel()
+
+	got := output.BuildSummary(output.BuildResults(summaryResult()))
+
+	want := models.ResultsSummary{
+		Vulnerabilities: 6,
+		Severity:        models.SeverityCounts{Critical: 1, High: 1, Medium: 1, Unknown: 3},
+		Ecosystems:      map[string]int{"npm": 5, "Go": 1},
+		Fixable:         4,
+		Unfixable:       2,
+		Called:          6,
+		Uncalled:        1,
+		SeverityByEcosystem: map[string]models.SeverityCounts{
+			"Go":  {High: 1},
+			"npm": {Critical: 1, Medium: 1, Unknown: 3},
+		},
+		FixableBySeverity:   models.SeverityCounts{Critical: 1, Medium: 1, Unknown: 2},
+		UnfixableBySeverity: models.SeverityCounts{High: 1, Unknown: 1},
+		TopPackages: []models.PackageSummary{
+			{Name: "lodash", Version: "4.17.15", Ecosystem: "npm", Vulnerabilities: 2, Fixable: 2},
+			{Name: "lodash", Version: "4.17.20", Ecosystem: "npm", Vulnerabilities: 2, Fixable: 1},
+			{Name: "golang.org/x/net", Version: "0.1.0", Ecosystem: "Go", Vulnerabilities: 1},
+			{Name: "minimist", Version: "1.2.0", Ecosystem: "npm", Vulnerabilities: 1, Fixable: 1},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("BuildSummary() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildSummary_TopPackages(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := &models.VulnerabilityResults{}
+	for i := range 12 {
+		pkg := models.PackageVulns{
+			Package: models.PackageInfo{Name: fmt.Sprintf("pkg-%02d", i), Version: "1.0.0", Ecosystem: "npm"},
+		}
+		// each package has one more vulnerability than the package before it
+		for j := range i + 1 {
+			id := fmt.Sprintf("GHSA-%02d-%02d", i, j)
+			pkg.Vulnerabilities = append(pkg.Vulnerabilities, osvschema.Vulnerability{ID: id})
+			pkg.Groups = append(pkg.Groups, models.GroupInfo{IDs: []string{id}})
+		}
+
+		vulnResult.Results = append(vulnResult.Results, models.PackageSource{
+			Source:   models.SourceInfo{Path: fmt.Sprintf("%02d/package-lock.json", i), Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{pkg},
+		})
+	}
+
+	got := output.BuildSummary(output.BuildResults(vulnResult)).TopPackages
+
+	if len(got) != 10 {
+		t.Fatalf("BuildSummary().TopPackages has %d packages, want 10", len(got))
+	}
+
+	if got[0].Name != "pkg-11" || got[0].Vulnerabilities != 12 || got[9].Name != "pkg-02" {
+		t.Errorf("BuildSummary().TopPackages = %+v, want the packages with the most vulnerabilities first", got)
+	}
+}
+
+func TestWithSummary(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := summaryResult()
+	got := output.WithSummary(vulnResult)
+
+	if vulnResult.Summary != nil {
+		t.Errorf("WithSummary() changed the original results")
+	}
+
+	if got.Summary == nil || got.Summary.Vulnerabilities != 6 {
+		t.Errorf("WithSummary() = %+v, expected a summary of 6 vulnerabilities", got.Summary)
+	}
+}
+
+func TestPrintTableResults_WithSummary(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(output.WithSummary(summaryResult()), outputWriter, 800, false, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func TestPrintMarkdownTableResults_WithSummary(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintMarkdownTableResults(output.WithSummary(summaryResult()), outputWriter, false, false)
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintHTMLResults_WithSummary(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	if err := output.PrintHTMLResults(output.WithSummary(summaryResult()), outputWriter); err != nil {
+		t.Fatalf("PrintHTMLResults() error = %v", err)
+	}
+
+	for _, want := range []string{"Summary of 6 vulnerabilities", "Go (1) npm (5)", "4 fixable, 2 unfixable", "6 called, 1 uncalled", "Most vulnerable packages", `class="summary-bar"`} {
+		if !strings.Contains(outputWriter.String(), want) {
+			t.Errorf("PrintHTMLResults() output does not contain %q", want)
+		}
+	}
+}
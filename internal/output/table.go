@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scanner/v2/internal/imodels/ecosystem"
@@ -22,19 +23,24 @@ import (
 const OSVBaseVulnerabilityURL = "https://osv.dev/"
 
 // PrintTableResults prints the osv scan results into a human friendly table.
-func PrintTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, terminalWidth int, showAllVulns bool) {
+// If dedupe is true, rows for the same vulnerability in the same package version
+// are merged together, listing all the sources that package version was found in.
+func PrintTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, terminalWidth int, showAllVulns bool, dedupe bool) {
 	if terminalWidth <= 0 {
 		text.DisableColors()
 	}
 
 	outputResult := BuildResults(vulnResult)
 
+	printLabels(vulnResult.Labels, outputWriter)
+	printVulnSummary(vulnResult, outputWriter)
+
 	// Render the vulnerabilities.
 	if containsOSResult(outputResult) {
 		printSummaryResult(outputResult, outputWriter, terminalWidth, showAllVulns)
 	} else {
 		outputTable := newTable(outputWriter, terminalWidth)
-		outputTable = tableBuilder(outputTable, outputResult, showAllVulns)
+		outputTable = tableBuilder(outputTable, outputResult, showAllVulns, dedupe)
 		if outputTable.Length() != 0 {
 			outputTable.Render()
 		}
@@ -48,6 +54,9 @@ func PrintTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.
 			buildLicenseViolationsTable(outputWriter, terminalWidth, vulnResult)
 		}
 	}
+
+	buildUnscannedPackagesTable(outputWriter, terminalWidth, vulnResult)
+	buildIntegrityMismatchesTable(outputWriter, terminalWidth, vulnResult)
 }
 
 func newTable(outputWriter io.Writer, terminalWidth int) table.Writer {
@@ -67,14 +76,30 @@ func newTable(outputWriter io.Writer, terminalWidth int) table.Writer {
 	return outputTable
 }
 
-func tableBuilder(outputTable table.Writer, result Result, showAllVulns bool) table.Writer {
-	outputTable.AppendHeader(table.Row{"OSV URL", "CVSS", "Ecosystem", "Package", "Version", "Source"})
-	rows := tableBuilderInner(result, VulnTypeRegular)
+func tableBuilder(outputTable table.Writer, result Result, showAllVulns bool, dedupe bool) table.Writer {
+	columns := optionalColumnsOf(result)
+
+	header := table.Row{"OSV URL", "CVSS"}
+	if columns.epss {
+		header = append(header, "EPSS")
+	}
+	if columns.kev {
+		header = append(header, "KEV")
+	}
+	outputTable.AppendHeader(append(header, "Ecosystem", "Package", "Version", "Source"))
+
+	rows := tableBuilderInner(result, VulnTypeRegular, columns)
+	if dedupe {
+		rows = dedupeRowsBySource(rows)
+	}
 	for _, elem := range rows {
 		outputTable.AppendRow(elem.row, table.RowConfig{AutoMerge: elem.shouldMerge})
 	}
 
-	uncalledRows := tableBuilderInner(result, VulnTypeUncalled)
+	uncalledRows := tableBuilderInner(result, VulnTypeUncalled, columns)
+	if dedupe {
+		uncalledRows = dedupeRowsBySource(uncalledRows)
+	}
 	if showAllVulns && len(uncalledRows) != 0 {
 		outputTable.AppendSeparator()
 		outputTable.AppendRow(table.Row{"Uncalled vulnerabilities"})
@@ -85,7 +110,10 @@ func tableBuilder(outputTable table.Writer, result Result, showAllVulns bool) ta
 		}
 	}
 
-	unimportantRows := tableBuilderInner(result, VulnTypeUnimportant)
+	unimportantRows := tableBuilderInner(result, VulnTypeUnimportant, columns)
+	if dedupe {
+		unimportantRows = dedupeRowsBySource(unimportantRows)
+	}
 	if len(unimportantRows) != 0 {
 		outputTable.AppendSeparator()
 		outputTable.AppendRow(table.Row{"Unimportant vulnerabilities"})
@@ -243,12 +271,79 @@ func printLicenseSummary(licenseSummary LicenseSummary, outputWriter io.Writer,
 	fmt.Fprintln(outputWriter)
 }
 
+// optionalColumns are the columns of the vulnerability table that are only shown
+// if the results have been enriched with the data for them, so that the table
+// stays as narrow as possible otherwise
+type optionalColumns struct {
+	epss bool
+	kev  bool
+}
+
+func optionalColumnsOf(result Result) optionalColumns {
+	var columns optionalColumns
+
+	for _, eco := range result.Ecosystems {
+		for _, source := range eco.Sources {
+			for _, pkg := range source.Packages {
+				for _, vuln := range slices.Concat(pkg.RegularVulns, pkg.HiddenVulns) {
+					columns.epss = columns.epss || vuln.EPSS != nil
+					columns.kev = columns.kev || vuln.KEV != nil
+				}
+			}
+		}
+	}
+
+	return columns
+}
+
+// formatEPSS formats the EPSS score as a percentage, such as "12.34%"
+// formatSeverity formats the severity score of the vulnerability along with
+// its custom label, if it has one
+func formatSeverity(vuln VulnResult) string {
+	// todo: this is just to make the snapshots pass without change
+	score := vuln.SeverityScore
+	if score == "N/A" {
+		score = ""
+	}
+
+	switch {
+	case vuln.SeverityLabel == "":
+		return score
+	case score == "":
+		return vuln.SeverityLabel
+	default:
+		return score + " (" + vuln.SeverityLabel + ")"
+	}
+}
+
+func formatEPSS(epss *models.EPSSScore) string {
+	if epss == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%.2f%%", epss.Score*100)
+}
+
+// formatKEV marks vulnerabilities that are known to have been exploited,
+// noting if they have been used in ransomware campaigns
+func formatKEV(entry *models.KEVEntry) string {
+	if entry == nil {
+		return ""
+	}
+
+	if entry.KnownRansomwareCampaignUse {
+		return text.FgRed.Sprint("Yes (ransomware)")
+	}
+
+	return text.FgRed.Sprint("Yes")
+}
+
 type tbInnerResponse struct {
 	row         table.Row
 	shouldMerge bool
 }
 
-func tableBuilderInner(result Result, vulnAnalysisType VulnAnalysisType) []tbInnerResponse {
+func tableBuilderInner(result Result, vulnAnalysisType VulnAnalysisType, columns optionalColumns) []tbInnerResponse {
 	allOutputRows := []tbInnerResponse{}
 	workingDir := mustGetWorkingDirectory()
 
@@ -281,11 +376,14 @@ func tableBuilderInner(result Result, vulnAnalysisType VulnAnalysisType) []tbInn
 
 					outputRow = append(outputRow, strings.Join(links, "\n"))
 
-					// todo: this is just to make the snapshots pass without change
-					if vuln.SeverityScore == "N/A" {
-						outputRow = append(outputRow, "")
-					} else {
-						outputRow = append(outputRow, vuln.SeverityScore)
+					outputRow = append(outputRow, formatSeverity(vuln))
+
+					if columns.epss {
+						outputRow = append(outputRow, formatEPSS(vuln.EPSS))
+					}
+
+					if columns.kev {
+						outputRow = append(outputRow, formatKEV(vuln.KEV))
 					}
 
 					if eco.Name == "" && pkg.Commit != "" {
@@ -306,17 +404,10 @@ func tableBuilderInner(result Result, vulnAnalysisType VulnAnalysisType) []tbInn
 							name += " (dev)"
 						}
 						outputRow = append(outputRow, name)
-						outputRow = append(outputRow, pkg.InstalledVersion)
+						outputRow = append(outputRow, formatInstalledVersion(pkg))
 					}
 
-					// todo: see if we want to start including any of this information
-					p := strings.TrimPrefix(source.Name, ":")
-					p = strings.TrimPrefix(p, string(source.Type))
-					p = strings.TrimPrefix(p, ":")
-					p = strings.TrimPrefix(p, filepath.ToSlash(workingDir))
-					p = strings.TrimPrefix(p, "/")
-
-					outputRow = append(outputRow, p)
+					outputRow = append(outputRow, displaySourcePath(source, workingDir))
 
 					allOutputRows = append(allOutputRows, tbInnerResponse{
 						row:         outputRow,
@@ -330,6 +421,45 @@ func tableBuilderInner(result Result, vulnAnalysisType VulnAnalysisType) []tbInn
 	return allOutputRows
 }
 
+// displaySourcePath returns the path of the source relative to the working directory
+func displaySourcePath(source SourceResult, workingDir string) string {
+	// todo: see if we want to start including any of this information
+	p := strings.TrimPrefix(source.Name, ":")
+	p = strings.TrimPrefix(p, string(source.Type))
+	p = strings.TrimPrefix(p, ":")
+	p = strings.TrimPrefix(p, filepath.ToSlash(workingDir))
+	p = strings.TrimPrefix(p, "/")
+
+	return p
+}
+
+// dedupeRowsBySource merges rows which only differ by their source (the last column),
+// keeping the position of the first row and listing each source on a new line
+func dedupeRowsBySource(rows []tbInnerResponse) []tbInnerResponse {
+	deduped := make([]tbInnerResponse, 0, len(rows))
+	seen := make(map[string]int)
+
+	for _, elem := range rows {
+		last := len(elem.row) - 1
+		key := fmt.Sprint(elem.row[:last]...)
+
+		idx, ok := seen[key]
+		if !ok {
+			seen[key] = len(deduped)
+			deduped = append(deduped, elem)
+
+			continue
+		}
+
+		existing := deduped[idx].row
+		merged := slices.Clone(existing)
+		merged[last] = fmt.Sprintf("%v\n%v", existing[last], elem.row[last])
+		deduped[idx].row = merged
+	}
+
+	return deduped
+}
+
 func MaxSeverity(group models.GroupInfo, pkg models.PackageVulns) string {
 	var maxSeverity float64 = -1
 	for _, vulnID := range group.IDs {
@@ -407,6 +537,95 @@ func licenseViolationsTableBuilder(outputTable table.Writer, vulnResult *models.
 	return outputTable
 }
 
+// buildUnscannedPackagesTable renders the packages that could not be scanned,
+// so that gaps in the coverage of the scan are visible
+func buildUnscannedPackagesTable(outputWriter io.Writer, terminalWidth int, vulnResult *models.VulnerabilityResults) {
+	if len(vulnResult.UnscannedPackages) == 0 {
+		return
+	}
+
+	fmt.Fprintln(outputWriter)
+	fmt.Fprintln(outputWriter, unscannedPackagesSummary(vulnResult))
+
+	outputTable := newTable(outputWriter, terminalWidth)
+	outputTable = unscannedPackagesTableBuilder(outputTable, vulnResult)
+	outputTable.Render()
+}
+
+func unscannedPackagesSummary(vulnResult *models.VulnerabilityResults) string {
+	count := len(vulnResult.UnscannedPackages)
+
+	return fmt.Sprintf("%d %s could not be scanned:", count, Form(count, "package", "packages"))
+}
+
+func unscannedPackagesTableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults) table.Writer {
+	outputTable.AppendHeader(table.Row{"Ecosystem", "Package", "Version", "Source", "Reason"})
+	workingDir := mustGetWorkingDirectory()
+	for _, unscanned := range vulnResult.UnscannedPackages {
+		path := unscanned.Source.Path
+		if simplifiedPath, err := filepath.Rel(workingDir, unscanned.Source.Path); err == nil {
+			path = simplifiedPath
+		}
+		outputTable.AppendRow(table.Row{
+			unscanned.Package.Ecosystem,
+			unscanned.Package.Name,
+			unscanned.Package.Version,
+			path,
+			unscanned.Reason,
+		})
+	}
+
+	return outputTable
+}
+
+// buildIntegrityMismatchesTable renders the hashes recorded by lockfiles that do not
+// match the hashes published by the registries of their packages
+func buildIntegrityMismatchesTable(outputWriter io.Writer, terminalWidth int, vulnResult *models.VulnerabilityResults) {
+	if len(vulnResult.IntegrityMismatches) == 0 {
+		return
+	}
+
+	fmt.Fprintln(outputWriter)
+	fmt.Fprintln(outputWriter, integrityMismatchesSummary(vulnResult))
+
+	outputTable := newTable(outputWriter, terminalWidth)
+	outputTable = integrityMismatchesTableBuilder(outputTable, vulnResult)
+	outputTable.Render()
+}
+
+func integrityMismatchesSummary(vulnResult *models.VulnerabilityResults) string {
+	count := len(vulnResult.IntegrityMismatches)
+
+	return fmt.Sprintf(
+		"%d %s recorded by lockfiles %s not match the registry, which may indicate tampering:",
+		count,
+		Form(count, "hash", "hashes"),
+		Form(count, "does", "do"),
+	)
+}
+
+func integrityMismatchesTableBuilder(outputTable table.Writer, vulnResult *models.VulnerabilityResults) table.Writer {
+	outputTable.AppendHeader(table.Row{"Ecosystem", "Package", "Version", "File", "Source", "Lockfile hash", "Registry hash"})
+	workingDir := mustGetWorkingDirectory()
+	for _, mismatch := range vulnResult.IntegrityMismatches {
+		path := mismatch.Source.Path
+		if simplifiedPath, err := filepath.Rel(workingDir, mismatch.Source.Path); err == nil {
+			path = simplifiedPath
+		}
+		outputTable.AppendRow(table.Row{
+			mismatch.Package.Ecosystem,
+			mismatch.Package.Name,
+			mismatch.Package.Version,
+			mismatch.File,
+			path,
+			mismatch.LockfileHash,
+			strings.Join(mismatch.RegistryHashes, "\n"),
+		})
+	}
+
+	return outputTable
+}
+
 func formatBinaryPackages(slice []string) string {
 	maxChars := 20
 	result := strings.Join(slice, ", ")
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
 	"github.com/google/osv-scanner/v2/internal/output"
 	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/pkg/models"
 	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
 )
 
 func TestPrintTableResults_StandardTerminalWidth_WithVulnerabilities(t *testing.T) {
@@ -16,7 +19,7 @@ func TestPrintTableResults_StandardTerminalWidth_WithVulnerabilities(t *testing.
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 80, true)
+		output.PrintTableResults(args.vulnResult, outputWriter, 80, true, false)
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -29,7 +32,7 @@ func TestPrintTableResults_StandardTerminalWidth_WithLicenseViolations(t *testin
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 80, false)
+		output.PrintTableResults(args.vulnResult, outputWriter, 80, false, false)
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -42,7 +45,7 @@ func TestPrintTableResults_StandardTerminalWidth_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 80, true)
+		output.PrintTableResults(args.vulnResult, outputWriter, 80, true, false)
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -55,7 +58,7 @@ func TestPrintTableResults_LongTerminalWidth_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 800, true)
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, true, false)
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -68,7 +71,7 @@ func TestPrintTableResults_LongTerminalWidth_WithLicenseViolations(t *testing.T)
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 800, false)
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, false, false)
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -81,7 +84,7 @@ func TestPrintTableResults_LongTerminalWidth_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, 800, true)
+		output.PrintTableResults(args.vulnResult, outputWriter, 800, true, false)
 
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
@@ -94,7 +97,7 @@ func TestPrintTableResults_NoTerminalWidth_WithVulnerabilities(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, -1, true)
+		output.PrintTableResults(args.vulnResult, outputWriter, -1, true, false)
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -107,7 +110,7 @@ func TestPrintTableResults_NoTerminalWidth_WithLicenseViolations(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, -1, false)
+		output.PrintTableResults(args.vulnResult, outputWriter, -1, false, false)
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
@@ -120,8 +123,225 @@ func TestPrintTableResults_NoTerminalWidth_WithMixedIssues(t *testing.T) {
 		t.Helper()
 
 		outputWriter := &bytes.Buffer{}
-		output.PrintTableResults(args.vulnResult, outputWriter, -1, true)
+		output.PrintTableResults(args.vulnResult, outputWriter, -1, true, false)
 
 		testutility.NewSnapshot().MatchText(t, outputWriter.String())
 	})
 }
+
+func TestPrintTableResults_Deduped(t *testing.T) {
+	t.Parallel()
+
+	vulnerablePackage := func(source string) models.PackageSource {
+		return models.PackageSource{
+			Source: models.SourceInfo{Path: source, Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{
+				{
+					Package: newPackageInfo(source, pkginfo{
+						Name:      "mine1",
+						Version:   "1.2.3",
+						Ecosystem: "npm",
+						Extractor: packagelockjson.Extractor{},
+					}),
+					Groups: []models.GroupInfo{{IDs: []string{"OSV-1"}}},
+					Vulnerabilities: []osvschema.Vulnerability{
+						{
+							ID:       "OSV-1",
+							Summary:  "Something scary!",
+							Severity: []osvschema.Severity{{Type: "high", Score: "1"}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			vulnerablePackage("path/to/my/first/lockfile"),
+			vulnerablePackage("path/to/my/second/lockfile"),
+			vulnerablePackage("path/to/my/third/lockfile"),
+		},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(vulnResult, outputWriter, 800, true, true)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func unscannedPackagesResult() *models.VulnerabilityResults {
+	return &models.VulnerabilityResults{
+		Results: []models.PackageSource{},
+		UnscannedPackages: []models.UnscannedPackage{
+			{
+				Package: models.PackageInfo{Name: "my-local-package", Ecosystem: "npm"},
+				Source:  models.SourceInfo{Path: "path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Reason:  models.UnscannedReasonMissingVersion,
+			},
+			{
+				Package: models.PackageInfo{Name: "something", Version: "1.0.0"},
+				Source:  models.SourceInfo{Path: "path/to/bom.json", Type: models.SourceTypeSBOM},
+				Reason:  models.UnscannedReasonInvalidPURL,
+			},
+		},
+	}
+}
+
+func TestPrintTableResults_WithUnscannedPackages(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(unscannedPackagesResult(), outputWriter, 80, false, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func TestPrintTableResults_WithIntegrityMismatches(t *testing.T) {
+	t.Parallel()
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{},
+		IntegrityMismatches: []models.IntegrityMismatch{
+			{
+				Package:        models.PackageInfo{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"},
+				Source:         models.SourceInfo{Path: "path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+				LockfileHash:   "sha1-tampered=",
+				RegistryHashes: []string{"sha1-lodash="},
+			},
+			{
+				Package:        models.PackageInfo{Name: "six", Version: "1.16.0", Ecosystem: "PyPI"},
+				Source:         models.SourceInfo{Path: "path/to/poetry.lock", Type: models.SourceTypeProjectPackage},
+				File:           "six-1.16.0.tar.gz",
+				LockfileHash:   "sha256:0000",
+				RegistryHashes: []string{"sha256:1e61"},
+			},
+		},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(vulnResult, outputWriter, 120, false, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func TestPrintTableResults_WithEPSS(t *testing.T) {
+	t.Parallel()
+
+	vulnerablePackage := func(name string, epss *models.EPSSScore) models.PackageVulns {
+		return models.PackageVulns{
+			Package: newPackageInfo("path/to/package-lock.json", pkginfo{
+				Name:      name,
+				Version:   "1.2.3",
+				Ecosystem: "npm",
+				Extractor: packagelockjson.Extractor{},
+			}),
+			Groups: []models.GroupInfo{{IDs: []string{"GHSA-" + name}, Aliases: []string{"CVE-2024-1"}, EPSS: epss}},
+			Vulnerabilities: []osvschema.Vulnerability{
+				{
+					ID:      "GHSA-" + name,
+					Summary: "Something scary!",
+					Aliases: []string{"CVE-2024-1"},
+				},
+			},
+		}
+	}
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{
+				vulnerablePackage("mine1", &models.EPSSScore{CVE: "CVE-2024-1", Score: 0.12345, Percentile: 0.9}),
+				vulnerablePackage("mine2", nil),
+			},
+		}},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(vulnResult, outputWriter, 800, false, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func TestPrintTableResults_WithSeverityLabels(t *testing.T) {
+	t.Parallel()
+
+	vulnerablePackage := func(name string, maxSeverity string, label string) models.PackageVulns {
+		return models.PackageVulns{
+			Package: newPackageInfo("path/to/package-lock.json", pkginfo{
+				Name:      name,
+				Version:   "1.2.3",
+				Ecosystem: "npm",
+				Extractor: packagelockjson.Extractor{},
+			}),
+			Groups: []models.GroupInfo{{IDs: []string{"GHSA-" + name}, MaxSeverity: maxSeverity, SeverityLabel: label}},
+			Vulnerabilities: []osvschema.Vulnerability{
+				{
+					ID:      "GHSA-" + name,
+					Summary: "Something scary!",
+				},
+			},
+		}
+	}
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{
+				vulnerablePackage("mine1", "9.8", "P0"),
+				vulnerablePackage("mine2", "", "Unrated"),
+				vulnerablePackage("mine3", "2.1", ""),
+			},
+		}},
+	}
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(vulnResult, outputWriter, 800, false, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func kevResult() *models.VulnerabilityResults {
+	vulnerablePackage := func(name string, cve string, entry *models.KEVEntry) models.PackageVulns {
+		return models.PackageVulns{
+			Package: newPackageInfo("path/to/package-lock.json", pkginfo{
+				Name:      name,
+				Version:   "1.2.3",
+				Ecosystem: "npm",
+				Extractor: packagelockjson.Extractor{},
+			}),
+			Groups: []models.GroupInfo{{IDs: []string{"GHSA-" + name}, Aliases: []string{"GHSA-" + name, cve}, KEV: entry}},
+			Vulnerabilities: []osvschema.Vulnerability{
+				{
+					ID:      "GHSA-" + name,
+					Summary: "Something scary!",
+					Aliases: []string{cve},
+				},
+			},
+		}
+	}
+
+	return &models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{
+				vulnerablePackage("mine1", "CVE-2021-44228", &models.KEVEntry{
+					CVE:                        "CVE-2021-44228",
+					DateAdded:                  "2021-12-10",
+					DueDate:                    "2021-12-24",
+					KnownRansomwareCampaignUse: true,
+				}),
+				vulnerablePackage("mine2", "CVE-2024-1", nil),
+			},
+		}},
+	}
+}
+
+func TestPrintTableResults_WithKEV(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintTableResults(kevResult(), outputWriter, 800, false, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
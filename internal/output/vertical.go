@@ -15,6 +15,7 @@ func PrintVerticalResults(vulnResult *models.VulnerabilityResults, outputWriter
 	// Add a newline to separate results from logs.
 	fmt.Fprintln(outputWriter)
 	outputResult := BuildResults(vulnResult)
+	printLabels(vulnResult.Labels, outputWriter)
 	printSummary(outputResult, outputWriter)
 	if outputResult.IsContainerScanning {
 		printBaseImages(outputResult.ImageInfo, outputWriter)
@@ -213,6 +214,10 @@ func printVerticalVulnerabilitiesForPackages(packages []PackageResult, out io.Wr
 				text.FgCyan.Sprintf("%s:", vulnerability.ID),
 				describe(vulnerability),
 			)
+
+			if vulnerability.KEV != nil {
+				fmt.Fprintf(out, "      %s\n", describeKEV(*vulnerability.KEV))
+			}
 		}
 	}
 }
@@ -292,3 +297,19 @@ func describe(vulnerability VulnResult) string {
 
 	return description
 }
+
+// describeKEV describes when the vulnerability was added to the KEV catalog
+// and when it must be remediated by
+func describeKEV(entry models.KEVEntry) string {
+	description := fmt.Sprintf("known to be exploited (%s added to the CISA KEV catalog on %s", entry.CVE, entry.DateAdded)
+	if entry.DueDate != "" {
+		description += ", due " + entry.DueDate
+	}
+	description += ")"
+
+	if entry.KnownRansomwareCampaignUse {
+		description += ", used in ransomware campaigns"
+	}
+
+	return text.FgRed.Sprint(description)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/osv-scanner/v2/internal/output"
 	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/google/osv-scanner/v2/pkg/models"
 	"github.com/jedib0t/go-pretty/v6/text"
 )
 
@@ -47,3 +48,24 @@ func TestPrintVerticalResults_WithMixedIssues(t *testing.T) {
 		testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
 	})
 }
+
+func TestPrintVerticalResults_WithKEV(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintVerticalResults(kevResult(), outputWriter, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func TestPrintVerticalResults_WithRiskScore(t *testing.T) {
+	t.Parallel()
+
+	result := kevResult()
+	result.RiskScore = &models.RiskScore{Score: 12.5, MaxScore: 10}
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintVerticalResults(result, outputWriter, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
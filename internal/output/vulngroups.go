@@ -0,0 +1,180 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// VulnGroup is a vulnerability along with every package in every source that it affects
+type VulnGroup struct {
+	// Vuln is the vulnerability as found in the first package that it affects,
+	// which has the same ids, severity, and description in every package
+	Vuln     VulnResult
+	Affected []AffectedPackage
+}
+
+// AffectedPackage is a package in a source that is affected by a vulnerability
+type AffectedPackage struct {
+	Ecosystem        string
+	Package          string
+	InstalledVersion string
+	// FixedVersion is the version that fixes the vulnerability in the package,
+	// or a description of why there is no such version
+	FixedVersion string
+	Source       string
+}
+
+// Sources returns the number of distinct sources that the vulnerability was found in
+func (g VulnGroup) Sources() int {
+	var sources []string
+	for _, a := range g.Affected {
+		sources = appendUnique(sources, a.Source)
+	}
+
+	return len(sources)
+}
+
+// BuildVulnGroups groups the vulnerabilities of the result by their id, listing each package
+// and source that they affect; uncalled vulnerabilities are only included if showAllVulns is true.
+//
+// Vulnerabilities are ordered by their severity, followed by how many packages they affect.
+func BuildVulnGroups(result Result, showAllVulns bool) []VulnGroup {
+	workingDir := mustGetWorkingDirectory()
+
+	groups := make(map[string]*VulnGroup)
+	var ids []string
+
+	for _, eco := range result.Ecosystems {
+		for _, source := range eco.Sources {
+			path := displaySourcePath(source, workingDir)
+
+			for _, pkg := range source.Packages {
+				vulns := pkg.RegularVulns
+				if showAllVulns {
+					vulns = slices.Concat(vulns, pkg.HiddenVulns)
+				}
+
+				for _, vuln := range vulns {
+					if _, ok := groups[vuln.ID]; !ok {
+						groups[vuln.ID] = &VulnGroup{Vuln: vuln}
+						ids = append(ids, vuln.ID)
+					}
+
+					affected := AffectedPackage{
+						Ecosystem:        eco.Name,
+						Package:          fixActionPackageName(eco.Name, pkg),
+						InstalledVersion: getInstalledVersionOrCommit(pkg),
+						FixedVersion:     vuln.FixedVersion,
+						Source:           path,
+					}
+
+					g := groups[vuln.ID]
+					if !slices.Contains(g.Affected, affected) {
+						g.Affected = append(g.Affected, affected)
+					}
+				}
+			}
+		}
+	}
+
+	sorted := make([]VulnGroup, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, *groups[id])
+	}
+
+	slices.SortStableFunc(sorted, func(a, b VulnGroup) int {
+		return cmp.Or(
+			cmp.Compare(vulnGroupScore(b), vulnGroupScore(a)),
+			cmp.Compare(len(b.Affected), len(a.Affected)),
+			cmp.Compare(a.Vuln.ID, b.Vuln.ID),
+		)
+	})
+
+	return sorted
+}
+
+// vulnGroupScore is the severity score of the vulnerability of the group, which is
+// lower than any score if the vulnerability does not have one
+func vulnGroupScore(g VulnGroup) float64 {
+	score, err := strconv.ParseFloat(g.Vuln.SeverityScore, 64)
+	if err != nil {
+		return -1
+	}
+
+	return score
+}
+
+// PrintVulnGroupsTableResults prints the vulnerabilities of the osv scan results into a
+// human friendly table, with a row for each vulnerability listing every package it affects
+func PrintVulnGroupsTableResults(vulnResult *models.VulnerabilityResults, outputWriter io.Writer, terminalWidth int, markdown bool, showAllVulns bool) {
+	if terminalWidth <= 0 || markdown {
+		text.DisableColors()
+	}
+
+	printLabels(vulnResult.Labels, outputWriter)
+
+	var outputTable table.Writer
+	if markdown {
+		printMarkdownVulnSummary(vulnResult, outputWriter)
+		outputTable = table.NewWriter()
+		outputTable.SetOutputMirror(outputWriter)
+	} else {
+		printVulnSummary(vulnResult, outputWriter)
+		outputTable = newTable(outputWriter, terminalWidth)
+	}
+
+	outputTable = vulnGroupsTableBuilder(outputTable, BuildVulnGroups(BuildResults(vulnResult), showAllVulns))
+
+	if markdown {
+		if outputTable.Length() != 0 {
+			outputTable.RenderMarkdown()
+		}
+		buildMarkdownUnscannedPackagesTable(outputWriter, vulnResult)
+		buildMarkdownIntegrityMismatchesTable(outputWriter, vulnResult)
+
+		return
+	}
+
+	if outputTable.Length() != 0 {
+		outputTable.Render()
+	}
+	buildUnscannedPackagesTable(outputWriter, terminalWidth, vulnResult)
+	buildIntegrityMismatchesTable(outputWriter, terminalWidth, vulnResult)
+}
+
+func vulnGroupsTableBuilder(outputTable table.Writer, groups []VulnGroup) table.Writer {
+	outputTable.AppendHeader(table.Row{"OSV URL", "CVSS", "Ecosystem", "Package", "Version", "Fixed Version", "Source"})
+
+	for _, g := range groups {
+		// each affected package is on its own line of each of the columns that describe it
+		var ecosystems, packages, versions, fixedVersions, sources []string
+		for _, a := range g.Affected {
+			ecosystems = append(ecosystems, a.Ecosystem)
+			packages = append(packages, a.Package)
+			versions = append(versions, a.InstalledVersion)
+			fixedVersions = append(fixedVersions, a.FixedVersion)
+			sources = append(sources, a.Source)
+		}
+
+		n := g.Sources()
+		outputTable.AppendRow(table.Row{
+			OSVBaseVulnerabilityURL + text.Bold.Sprintf("%s", g.Vuln.ID) + "\n" + fmt.Sprintf("found in %d %s", n, Form(n, "source", "sources")),
+			formatSeverity(g.Vuln),
+			strings.Join(ecosystems, "\n"),
+			strings.Join(packages, "\n"),
+			strings.Join(versions, "\n"),
+			strings.Join(fixedVersions, "\n"),
+			strings.Join(sources, "\n"),
+		})
+	}
+
+	return outputTable
+}
@@ -0,0 +1,94 @@
+package output_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func TestBuildVulnGroups(t *testing.T) {
+	t.Parallel()
+
+	got := output.BuildVulnGroups(output.BuildResults(fixActionsResult()), false)
+
+	ids := make([]string, 0, len(got))
+	for _, g := range got {
+		ids = append(ids, g.Vuln.ID)
+	}
+
+	// the vulnerability that affects the most packages comes first
+	if diff := cmp.Diff([]string{"GHSA-0002", "GHSA-0001", "GHSA-0003", "GHSA-0004"}, ids); diff != "" {
+		t.Errorf("BuildVulnGroups() ids diff (-want +got):\n%s", diff)
+	}
+
+	want := []output.AffectedPackage{
+		{
+			Ecosystem:        "npm",
+			Package:          "lodash",
+			InstalledVersion: "4.17.15",
+			FixedVersion:     "4.17.21",
+			Source:           "first/package-lock.json",
+		},
+		{
+			Ecosystem:        "npm",
+			Package:          "lodash",
+			InstalledVersion: "4.17.20",
+			FixedVersion:     "4.17.21",
+			Source:           "second/package-lock.json",
+		},
+	}
+
+	if diff := cmp.Diff(want, got[0].Affected); diff != "" {
+		t.Errorf("BuildVulnGroups() affected diff (-want +got):\n%s", diff)
+	}
+
+	if n := got[0].Sources(); n != 2 {
+		t.Errorf("Sources() = %d, want 2", n)
+	}
+}
+
+func TestPrintVulnGroupsTableResults(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintVulnGroupsTableResults(fixActionsResult(), outputWriter, 800, false, false)
+
+	testutility.NewSnapshot().MatchText(t, text.StripEscape(outputWriter.String()))
+}
+
+func TestPrintVulnGroupsTableResults_Markdown(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	output.PrintVulnGroupsTableResults(fixActionsResult(), outputWriter, 0, true, false)
+
+	testutility.NewSnapshot().MatchText(t, outputWriter.String())
+}
+
+func TestPrintVulnGroupsHTMLResults(t *testing.T) {
+	t.Parallel()
+
+	outputWriter := &bytes.Buffer{}
+	if err := output.PrintVulnGroupsHTMLResults(fixActionsResult(), outputWriter, false); err != nil {
+		t.Fatalf("PrintVulnGroupsHTMLResults() error = %v", err)
+	}
+
+	got := outputWriter.String()
+
+	if n := strings.Count(got, `class="vuln-group-container"`); n != 4 {
+		t.Errorf("expected 4 vulnerability groups, got %d", n)
+	}
+
+	if strings.Contains(got, `class="ecosystem-container`) {
+		t.Errorf("expected the packages to not also be listed by ecosystem")
+	}
+
+	if !strings.Contains(got, "found in 2 sources") {
+		t.Errorf("expected the number of sources of the vulnerability to be listed")
+	}
+}
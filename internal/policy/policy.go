@@ -0,0 +1,155 @@
+// Package policy evaluates the results of a scan against a policy written in the
+// Common Expression Language (CEL), which decides whether the scan passes or fails.
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/google/cel-go/ext"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// Policy is a compiled CEL expression, which is given the results of a scan as the `results`
+// variable in the same structure as the json output, and evaluates to either:
+//
+//   - a bool, which is whether the scan passes
+//   - a list of strings, which are the reasons that the scan fails, passing if it is empty
+//   - a map with a bool "pass" and a list of strings "messages", to report messages
+//     regardless of whether the scan passes
+type Policy struct {
+	path    string
+	program cel.Program
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("results", cel.MapType(cel.StringType, cel.DynType)),
+		ext.Lists(),
+		ext.Strings(),
+	)
+}
+
+// Load reads and compiles the policy at path
+func Load(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".rego") {
+		return nil, fmt.Errorf("failed to load policy %s: only policies written in CEL are supported", path)
+	}
+
+	return Compile(path, string(b))
+}
+
+// Compile compiles the CEL expression of a policy, with the path
+// being where it came from for the purposes of error messages
+func Compile(path, expr string) (*Policy, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("failed to compile policy %s: %w", path, iss.Err())
+	}
+
+	switch ast.OutputType().Kind() {
+	case types.BoolKind, types.ListKind, types.MapKind, types.DynKind:
+	default:
+		return nil, fmt.Errorf("policy %s must evaluate to a bool, a list of messages, or a map, not %s", path, ast.OutputType())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile policy %s: %w", path, err)
+	}
+
+	return &Policy{path: path, program: program}, nil
+}
+
+// Evaluate evaluates the policy against the results of a scan
+func (p *Policy) Evaluate(results models.VulnerabilityResults) (models.PolicyResult, error) {
+	// the policy is given the results in the same structure as the json output,
+	// so that policies can be written against the documented output
+	b, err := json.Marshal(results)
+	if err != nil {
+		return models.PolicyResult{}, err
+	}
+
+	var input map[string]any
+	if err := json.Unmarshal(b, &input); err != nil {
+		return models.PolicyResult{}, err
+	}
+
+	out, _, err := p.program.Eval(map[string]any{"results": input})
+	if err != nil {
+		return models.PolicyResult{}, fmt.Errorf("failed to evaluate policy %s: %w", p.path, err)
+	}
+
+	result, err := toResult(out)
+	if err != nil {
+		return models.PolicyResult{}, fmt.Errorf("failed to evaluate policy %s: %w", p.path, err)
+	}
+	result.Path = p.path
+
+	return result, nil
+}
+
+func toMessages(val ref.Val) ([]string, error) {
+	messages, err := val.ConvertToNative(reflect.TypeFor[[]string]())
+	if err != nil {
+		return nil, errors.New("messages must be a list of strings")
+	}
+
+	return messages.([]string), nil
+}
+
+func toResult(val ref.Val) (models.PolicyResult, error) {
+	switch v := val.(type) {
+	case types.Bool:
+		return models.PolicyResult{Passed: bool(v)}, nil
+	case traits.Lister:
+		messages, err := toMessages(v)
+		if err != nil {
+			return models.PolicyResult{}, err
+		}
+
+		return models.PolicyResult{Passed: len(messages) == 0, Messages: messages}, nil
+	case traits.Mapper:
+		pass, ok := v.Find(types.String("pass"))
+		if !ok {
+			return models.PolicyResult{}, errors.New(`map must have a "pass" key`)
+		}
+
+		passed, ok := pass.(types.Bool)
+		if !ok {
+			return models.PolicyResult{}, errors.New(`"pass" must be a bool`)
+		}
+
+		result := models.PolicyResult{Passed: bool(passed)}
+
+		if messages, ok := v.Find(types.String("messages")); ok {
+			var err error
+			if result.Messages, err = toMessages(messages); err != nil {
+				return models.PolicyResult{}, err
+			}
+		}
+
+		return result, nil
+	}
+
+	return models.PolicyResult{}, fmt.Errorf("result must be a bool, a list of messages, or a map, not %s", val.Type())
+}
@@ -0,0 +1,174 @@
+package policy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/policy"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func testResults() models.VulnerabilityResults {
+	return models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/dir/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package:         models.PackageInfo{Name: "lodash", Version: "4.17.15", Ecosystem: "npm"},
+						Vulnerabilities: []osvschema.Vulnerability{{ID: "GHSA-p6mc-m468-83gw"}},
+						Groups: []models.GroupInfo{
+							{
+								IDs:         []string{"GHSA-p6mc-m468-83gw"},
+								Aliases:     []string{"CVE-2020-8203", "GHSA-p6mc-m468-83gw"},
+								MaxSeverity: "7.4",
+							},
+						},
+					},
+					{
+						Package: models.PackageInfo{Name: "left-pad", Version: "1.3.0", Ecosystem: "npm"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    models.PolicyResult
+		wantErr bool
+	}{
+		{
+			name: "bool that passes",
+			expr: `results.results.size() == 1`,
+			want: models.PolicyResult{Path: "policy.cel", Passed: true},
+		},
+		{
+			name: "bool that fails",
+			expr: `results.results.all(s, s.packages.all(p, !has(p.vulnerabilities)))`,
+			want: models.PolicyResult{Path: "policy.cel", Passed: false},
+		},
+		{
+			name: "list of messages",
+			expr: `results.results.map(s, s.packages.filter(p, has(p.groups) && p.groups.exists(g, double(g.max_severity) >= 7.0)).map(p, "%s@%s has a high severity vulnerability".format([p.package.name, p.package.version]))).flatten()`,
+			want: models.PolicyResult{
+				Path:     "policy.cel",
+				Passed:   false,
+				Messages: []string{"lodash@4.17.15 has a high severity vulnerability"},
+			},
+		},
+		{
+			name: "empty list of messages",
+			expr: `results.results.map(s, s.packages.filter(p, p.package.name == "express").map(p, p.package.name)).flatten()`,
+			want: models.PolicyResult{Path: "policy.cel", Passed: true, Messages: []string{}},
+		},
+		{
+			name: "map",
+			expr: `{"pass": true, "messages": ["only reporting"]}`,
+			want: models.PolicyResult{Path: "policy.cel", Passed: true, Messages: []string{"only reporting"}},
+		},
+		{
+			name:    "map without pass",
+			expr:    `{"messages": ["only reporting"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "list that is not of strings",
+			expr:    `[1, 2]`,
+			wantErr: true,
+		},
+		{
+			name:    "field that does not exist",
+			expr:    `results.does_not_exist.size() == 0`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pol, err := policy.Compile("policy.cel", tt.expr)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+
+			got, err := pol.Evaluate(testResults())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Evaluate() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "syntax error", expr: `results.results.size( == 1`},
+		{name: "undeclared variable", expr: `vulns.size() == 0`},
+		{name: "wrong output type", expr: `"pass"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := policy.Compile("policy.cel", tt.expr); err == nil {
+				t.Errorf("Compile() expected an error, but got none")
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	celPath := filepath.Join(dir, "policy.cel")
+	regoPath := filepath.Join(dir, "policy.rego")
+
+	if err := os.WriteFile(celPath, []byte("results.results.size() > 0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(regoPath, []byte("package osv\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := policy.Load(celPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	got, err := pol.Evaluate(testResults())
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+
+	if !got.Passed || got.Path != celPath {
+		t.Errorf("Evaluate() = %+v, expected the policy at %s to pass", got, celPath)
+	}
+
+	if _, err := policy.Load(regoPath); err == nil {
+		t.Errorf("Load() expected an error for a Rego policy, but got none")
+	}
+
+	if _, err := policy.Load(filepath.Join(dir, "missing.cel")); err == nil {
+		t.Errorf("Load() expected an error for a missing policy, but got none")
+	}
+}
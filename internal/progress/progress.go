@@ -0,0 +1,423 @@
+// Package progress reports the progress of long-running scans, either as a live
+// status line for interactive terminals or as a stream of json events for machines.
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Phase is a stage of a scan that progress is reported for
+type Phase string
+
+const (
+	// PhaseLoading is the loading of the image being scanned, such as exporting
+	// it from the container runtime and unpacking its layers
+	PhaseLoading    Phase = "loading"
+	PhaseWalking    Phase = "walking"
+	PhaseExtracting Phase = "extracting"
+	PhaseQuerying   Phase = "querying"
+	PhaseEnriching  Phase = "enriching"
+)
+
+const (
+	// redrawInterval is how often the status line is redrawn, so that the elapsed
+	// time and ETA keep updating even when there is no progress being made
+	redrawInterval = 250 * time.Millisecond
+	// eventInterval is the least amount of time between progress events of a phase
+	eventInterval = time.Second
+	// barWidth is the number of characters in the progress bar of phases with a total
+	barWidth = 20
+)
+
+// Event is a machine-readable progress update, emitted as a line of json
+type Event struct {
+	// Event is one of "phase_started", "progress", "phase_finished", or "heartbeat"
+	Event string `json:"event"`
+	// Phase is empty for heartbeats that are emitted before any phase has started
+	Phase Phase  `json:"phase,omitempty"`
+	Unit  string `json:"unit,omitempty"`
+	Done  int    `json:"done"`
+	// Total is zero if the total amount of work of the phase is not known
+	Total int `json:"total,omitempty"`
+	// ElapsedMs is the time since the phase started
+	ElapsedMs int64 `json:"elapsed_ms"`
+	// EtaMs is the estimated time until the phase is finished, if it can be estimated
+	EtaMs int64 `json:"eta_ms,omitempty"`
+	// Targets is how many of the targets of a scan of multiple targets have been
+	// completed, which is only included in heartbeats
+	Targets *TargetCounts `json:"targets,omitempty"`
+}
+
+// TargetCounts is how many of the targets of a scan have been completed
+type TargetCounts struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// Targets counts the targets of a scan of multiple targets that have been completed,
+// which is shared by the reporters of each target. It is safe to use concurrently.
+type Targets struct {
+	total int
+	done  atomic.Int64
+}
+
+// NewTargets returns a counter for a scan of total targets
+func NewTargets(total int) *Targets {
+	return &Targets{total: total}
+}
+
+// Done records that a target has been completed
+func (t *Targets) Done() {
+	if t == nil {
+		return
+	}
+
+	t.done.Add(1)
+}
+
+// Counts returns how many of the targets have been completed
+func (t *Targets) Counts() TargetCounts {
+	return TargetCounts{Done: int(t.done.Load()), Total: t.total}
+}
+
+type phaseState struct {
+	phase     Phase
+	unit      string
+	done      int
+	total     int
+	started   time.Time
+	finished  bool
+	lastEvent time.Time
+}
+
+// eta estimates how long until the phase is finished based on how long the work
+// done so far has taken, returning false if there is not enough to go off of
+func (p *phaseState) eta(now time.Time) (time.Duration, bool) {
+	if p.total <= 0 || p.done <= 0 || p.done >= p.total {
+		return 0, false
+	}
+
+	elapsed := now.Sub(p.started)
+
+	return time.Duration(float64(elapsed) / float64(p.done) * float64(p.total-p.done)), true
+}
+
+func (p *phaseState) event(name string, now time.Time) Event {
+	e := Event{
+		Event:     name,
+		Phase:     p.phase,
+		Unit:      p.unit,
+		Done:      p.done,
+		Total:     p.total,
+		ElapsedMs: now.Sub(p.started).Milliseconds(),
+	}
+
+	if eta, ok := p.eta(now); ok {
+		e.EtaMs = eta.Milliseconds()
+	}
+
+	return e
+}
+
+// Reporter reports the progress of the phases of a scan.
+//
+// All methods are safe to call concurrently, and do nothing on a nil Reporter
+// so that progress does not need to be checked for before being reported.
+type Reporter struct {
+	w           io.Writer
+	interactive bool
+	now         func() time.Time
+
+	mu      sync.Mutex
+	started time.Time
+	phases  []*phaseState
+	drawn   bool
+	stop    chan struct{}
+	stopped chan struct{}
+
+	targets          *Targets
+	heartbeatStop    chan struct{}
+	heartbeatStopped chan struct{}
+}
+
+// New returns a Reporter that writes to w, rendering a live status line if interactive
+// and otherwise emitting each event as a line of json
+func New(w io.Writer, interactive bool) *Reporter {
+	r := &Reporter{
+		w:           w,
+		interactive: interactive,
+		now:         time.Now,
+		started:     time.Now(),
+	}
+
+	if interactive {
+		r.stop = make(chan struct{})
+		r.stopped = make(chan struct{})
+
+		go r.redrawLoop()
+	}
+
+	return r
+}
+
+func (r *Reporter) redrawLoop() {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(redrawInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.mu.Lock()
+			r.draw()
+			r.mu.Unlock()
+		}
+	}
+}
+
+// Heartbeat emits a heartbeat event every interval until the reporter is closed, with the
+// phase that is in progress and how far through it is, so that long phases which have no
+// progress to report, such as pulling an image, do not leave the output idle. CI systems
+// commonly kill jobs whose logs have been idle for too long, which slow scans would otherwise
+// be mistaken for.
+//
+// Heartbeats are only emitted by reporters that are not interactive, as the status
+// line of interactive reporters is already redrawn continuously.
+func (r *Reporter) Heartbeat(interval time.Duration, targets *Targets) {
+	if r == nil || r.interactive || interval <= 0 || r.heartbeatStop != nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.targets = targets
+	r.heartbeatStop = make(chan struct{})
+	r.heartbeatStopped = make(chan struct{})
+	r.mu.Unlock()
+
+	go func() {
+		defer close(r.heartbeatStopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.heartbeatStop:
+				return
+			case <-ticker.C:
+				r.mu.Lock()
+				r.heartbeat()
+				r.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Start starts tracking a phase, with the unit of work being counted and the
+// total amount of work if it is known ahead of time, or zero otherwise
+func (r *Reporter) Start(phase Phase, unit string, total int) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.get(phase)
+	if p == nil {
+		p = &phaseState{phase: phase}
+		r.phases = append(r.phases, p)
+	}
+
+	*p = phaseState{phase: phase, unit: unit, total: total, started: r.now()}
+	r.emit("phase_started", p)
+}
+
+// Add records that n more units of work of the phase have been done
+func (r *Reporter) Add(phase Phase, n int) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.get(phase)
+	if p == nil || p.finished {
+		return
+	}
+
+	p.done += n
+
+	if !r.interactive && r.now().Sub(p.lastEvent) >= eventInterval {
+		r.emit("progress", p)
+	}
+}
+
+// Finish records that all the work of the phase has been done
+func (r *Reporter) Finish(phase Phase) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p := r.get(phase)
+	if p == nil || p.finished {
+		return
+	}
+
+	p.finished = true
+	if p.total > 0 {
+		p.done = p.total
+	}
+	r.emit("phase_finished", p)
+}
+
+// Close stops reporting progress, clearing the status line if there is one
+func (r *Reporter) Close() {
+	if r == nil {
+		return
+	}
+
+	if r.stop != nil {
+		close(r.stop)
+		<-r.stopped
+	}
+
+	if r.heartbeatStop != nil {
+		close(r.heartbeatStop)
+		<-r.heartbeatStopped
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.drawn {
+		fmt.Fprint(r.w, "\r\x1b[K")
+		r.drawn = false
+	}
+}
+
+// get returns the state of the phase, or nil if it has not been started.
+// The caller must hold r.mu
+func (r *Reporter) get(phase Phase) *phaseState {
+	for _, p := range r.phases {
+		if p.phase == phase {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// emit writes an event for the phase if the reporter is not interactive.
+// The caller must hold r.mu
+func (r *Reporter) emit(name string, p *phaseState) {
+	if r.interactive {
+		return
+	}
+
+	now := r.now()
+	p.lastEvent = now
+
+	b, err := json.Marshal(p.event(name, now))
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(r.w, "%s\n", b)
+}
+
+// heartbeat writes a heartbeat event for the phase that is in progress, which is the
+// most recently started phase that has not finished, or the last phase if all have.
+// The caller must hold r.mu
+func (r *Reporter) heartbeat() {
+	now := r.now()
+
+	e := Event{Event: "heartbeat", ElapsedMs: now.Sub(r.started).Milliseconds()}
+
+	if len(r.phases) > 0 {
+		p := r.phases[len(r.phases)-1]
+		for i := len(r.phases) - 1; i >= 0; i-- {
+			if !r.phases[i].finished {
+				p = r.phases[i]
+				break
+			}
+		}
+
+		e = p.event("heartbeat", now)
+	}
+
+	if r.targets != nil {
+		counts := r.targets.Counts()
+		e.Targets = &counts
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(r.w, "%s\n", b)
+}
+
+// draw redraws the status line in place.
+// The caller must hold r.mu
+func (r *Reporter) draw() {
+	if len(r.phases) == 0 {
+		return
+	}
+
+	fmt.Fprint(r.w, "\r\x1b[K"+r.render(r.now()))
+	r.drawn = true
+}
+
+// render returns the status line, which has the count of each phase that
+// has been started and a progress bar with an ETA for the phases with a total.
+// The caller must hold r.mu
+func (r *Reporter) render(now time.Time) string {
+	parts := make([]string, 0, len(r.phases))
+
+	for _, p := range r.phases {
+		var sb strings.Builder
+		sb.WriteString(string(p.phase))
+
+		if p.total > 0 {
+			filled := min(barWidth*p.done/p.total, barWidth)
+			fmt.Fprintf(&sb, " [%s%s] %d/%d", strings.Repeat("#", filled), strings.Repeat(".", barWidth-filled), p.done, p.total)
+		} else {
+			fmt.Fprintf(&sb, " %d", p.done)
+		}
+
+		if p.unit != "" {
+			sb.WriteString(" " + p.unit)
+		}
+
+		switch eta, ok := p.eta(now); {
+		case p.finished:
+			sb.WriteString(" (done)")
+		case ok:
+			fmt.Fprintf(&sb, " (ETA %s)", formatDuration(eta))
+		}
+
+		parts = append(parts, sb.String())
+	}
+
+	return fmt.Sprintf("[%s] %s", formatDuration(now.Sub(r.started)), strings.Join(parts, " | "))
+}
+
+// formatDuration formats a duration to the second, such as "1h2m3s"
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,241 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeClock is a clock that only moves when advanced
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestReporter(interactive bool) (*Reporter, *bytes.Buffer, *fakeClock) {
+	var buf bytes.Buffer
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	return &Reporter{w: &buf, interactive: interactive, now: clock.Now, started: clock.now}, &buf, clock
+}
+
+func decodeEvents(t *testing.T, buf *bytes.Buffer) []Event {
+	t.Helper()
+
+	var events []Event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("could not decode event %q: %v", line, err)
+		}
+		events = append(events, e)
+	}
+
+	return events
+}
+
+func TestReporter_Events(t *testing.T) {
+	t.Parallel()
+
+	r, buf, clock := newTestReporter(false)
+
+	r.Start(PhaseQuerying, "packages", 100)
+
+	clock.Advance(2 * time.Second)
+	r.Add(PhaseQuerying, 25)
+
+	// progress events are throttled
+	clock.Advance(100 * time.Millisecond)
+	r.Add(PhaseQuerying, 25)
+
+	clock.Advance(2 * time.Second)
+	r.Finish(PhaseQuerying)
+
+	// work done after the phase has finished is ignored
+	r.Add(PhaseQuerying, 10)
+	r.Close()
+
+	want := []Event{
+		{Event: "phase_started", Phase: PhaseQuerying, Unit: "packages", Total: 100},
+		{Event: "progress", Phase: PhaseQuerying, Unit: "packages", Done: 25, Total: 100, ElapsedMs: 2000, EtaMs: 6000},
+		{Event: "phase_finished", Phase: PhaseQuerying, Unit: "packages", Done: 100, Total: 100, ElapsedMs: 4100},
+	}
+
+	if diff := cmp.Diff(want, decodeEvents(t, buf)); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestReporter_Events_UnknownTotal(t *testing.T) {
+	t.Parallel()
+
+	r, buf, clock := newTestReporter(false)
+
+	r.Start(PhaseWalking, "files", 0)
+	clock.Advance(time.Second)
+	r.Add(PhaseWalking, 10)
+	r.Finish(PhaseWalking)
+
+	want := []Event{
+		{Event: "phase_started", Phase: PhaseWalking, Unit: "files"},
+		{Event: "progress", Phase: PhaseWalking, Unit: "files", Done: 10, ElapsedMs: 1000},
+		{Event: "phase_finished", Phase: PhaseWalking, Unit: "files", Done: 10, ElapsedMs: 1000},
+	}
+
+	if diff := cmp.Diff(want, decodeEvents(t, buf)); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestReporter_Render(t *testing.T) {
+	t.Parallel()
+
+	r, buf, clock := newTestReporter(true)
+
+	r.Start(PhaseWalking, "files", 0)
+	r.Start(PhaseExtracting, "files", 0)
+	r.Add(PhaseWalking, 1200)
+	r.Add(PhaseExtracting, 12)
+	clock.Advance(30 * time.Second)
+	r.Finish(PhaseWalking)
+	r.Finish(PhaseExtracting)
+
+	r.Start(PhaseQuerying, "packages", 400)
+	clock.Advance(10 * time.Second)
+	r.Add(PhaseQuerying, 100)
+
+	// interactive reporters do not emit events
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %q", buf.String())
+	}
+
+	want := "[40s] walking 1200 files (done) | extracting 12 files (done) | querying [#####...............] 100/400 packages (ETA 30s)"
+	if got := r.render(clock.Now()); got != want {
+		t.Errorf("render() = %q, want %q", got, want)
+	}
+
+	r.draw()
+	r.Close()
+
+	if want := "\r\x1b[K" + want + "\r\x1b[K"; buf.String() != want {
+		t.Errorf("expected the status line to be drawn and then cleared, got %q", buf.String())
+	}
+}
+
+func TestReporter_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	r, buf, clock := newTestReporter(false)
+	targets := NewTargets(3)
+	r.targets = targets
+
+	// heartbeats are emitted even before any phase has started
+	clock.Advance(5 * time.Second)
+	r.heartbeat()
+
+	r.Start(PhaseLoading, "", 0)
+	clock.Advance(30 * time.Second)
+	r.heartbeat()
+	r.Finish(PhaseLoading)
+
+	targets.Done()
+	r.Start(PhaseQuerying, "packages", 100)
+	clock.Advance(10 * time.Second)
+	r.Add(PhaseQuerying, 50)
+	r.Finish(PhaseQuerying)
+	r.heartbeat()
+
+	var heartbeats []Event
+	for _, e := range decodeEvents(t, buf) {
+		if e.Event == "heartbeat" {
+			heartbeats = append(heartbeats, e)
+		}
+	}
+
+	want := []Event{
+		{Event: "heartbeat", ElapsedMs: 5000, Targets: &TargetCounts{Done: 0, Total: 3}},
+		{Event: "heartbeat", Phase: PhaseLoading, ElapsedMs: 30000, Targets: &TargetCounts{Done: 0, Total: 3}},
+		{Event: "heartbeat", Phase: PhaseQuerying, Unit: "packages", Done: 100, Total: 100, ElapsedMs: 10000, Targets: &TargetCounts{Done: 1, Total: 3}},
+	}
+
+	if diff := cmp.Diff(want, heartbeats); diff != "" {
+		t.Errorf("unexpected heartbeats (-want +got):\n%s", diff)
+	}
+}
+
+func TestReporter_Heartbeat_Interval(t *testing.T) {
+	t.Parallel()
+
+	var buf syncBuffer
+	r := New(&buf, false)
+	r.Heartbeat(10*time.Millisecond, nil)
+
+	time.Sleep(100 * time.Millisecond)
+	r.Close()
+
+	written := buf.String()
+	if !strings.Contains(written, `"event":"heartbeat"`) {
+		t.Errorf("expected heartbeats to be emitted, got %q", written)
+	}
+
+	// no more heartbeats are emitted once the reporter is closed
+	time.Sleep(50 * time.Millisecond)
+	if buf.String() != written {
+		t.Errorf("expected no heartbeats after closing")
+	}
+}
+
+func TestReporter_Heartbeat_Interactive(t *testing.T) {
+	t.Parallel()
+
+	r, buf, _ := newTestReporter(true)
+	r.Heartbeat(time.Millisecond, nil)
+
+	time.Sleep(20 * time.Millisecond)
+	r.Close()
+
+	if buf.Len() != 0 {
+		t.Errorf("expected interactive reporters to not emit heartbeats, got %q", buf.String())
+	}
+}
+
+// syncBuffer is a buffer that can be written to while it is being read from
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestReporter_Nil(t *testing.T) {
+	t.Parallel()
+
+	var r *Reporter
+
+	// none of these should panic
+	r.Start(PhaseQuerying, "packages", 10)
+	r.Add(PhaseQuerying, 1)
+	r.Finish(PhaseQuerying)
+	r.Heartbeat(time.Second, nil)
+	r.Close()
+}
@@ -0,0 +1,240 @@
+// Package pullrequest commits changes made to a local Git repository onto a new branch,
+// pushes it, and opens a GitHub pull request for it.
+package pullrequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/remoterepo"
+)
+
+// DefaultAPIURL is the URL of the REST API of github.com
+const DefaultAPIURL = "https://api.github.com"
+
+// Options configures the pull request that is opened
+type Options struct {
+	// Token is a GitHub token that can push branches to and open pull requests on the repository
+	Token string
+	// APIURL is the URL of the GitHub REST API, which is usually DefaultAPIURL
+	APIURL string
+	// Base is the branch to open the pull request against, or empty for the current branch
+	Base string
+	// Branch is the name of the branch that the changes are committed onto
+	Branch string
+	Title  string
+	Body   string
+	// UserAgent is sent with the requests made to the GitHub API, if set
+	UserAgent string
+	Client    *http.Client
+}
+
+// Repository is a GitHub repository
+type Repository struct {
+	// Host is the host that the repository is fetched from and pushed to, such as "github.com"
+	Host  string
+	Owner string
+	Name  string
+}
+
+func (r Repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// ParseRepository parses the host, owner, and name of a GitHub repository from the URL of one
+// of its remotes, which can be either a URL such as "https://github.com/org/repo.git"
+// or an scp-like address such as "git@github.com:org/repo.git"
+func ParseRepository(remoteURL string) (Repository, error) {
+	host, path := "", ""
+	if _, rest, ok := strings.Cut(remoteURL, "://"); ok {
+		host, path, _ = strings.Cut(rest, "/")
+		// the host is only preceded by credentials and followed by a port
+		if i := strings.LastIndex(host, "@"); i != -1 {
+			host = host[i+1:]
+		}
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+	} else if before, rest, ok := strings.Cut(remoteURL, ":"); ok {
+		host, path = before, rest
+		if i := strings.LastIndex(host, "@"); i != -1 {
+			host = host[i+1:]
+		}
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	owner, name, ok := strings.Cut(path, "/")
+	if host == "" || !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return Repository{}, fmt.Errorf("%q is not the URL of a GitHub repository", remoteURL)
+	}
+
+	return Repository{Host: strings.ToLower(host), Owner: owner, Name: name}, nil
+}
+
+// checkHost returns an error unless the repository is hosted by the GitHub instance
+// that the REST API at apiURL is for, which is either on the same host or on the
+// "api." subdomain of it, so that the token is not sent to any other host
+func checkHost(repo Repository, apiURL string) error {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return fmt.Errorf("invalid GitHub API URL %q: %w", apiURL, err)
+	}
+
+	apiHost := strings.ToLower(u.Hostname())
+	if repo.Host != apiHost && "api."+repo.Host != apiHost {
+		return fmt.Errorf("refusing to push to %s, as the origin remote is not on the same host as the GitHub API at %s", repo.Host, apiURL)
+	}
+
+	return nil
+}
+
+// Create commits the given files of the repository that dir is in onto a new branch,
+// pushes the branch to the "origin" remote, and opens a pull request for it,
+// returning the URL of the pull request.
+//
+// The repository is left checked out on the new branch.
+func Create(ctx context.Context, dir string, files []string, opts Options) (string, error) {
+	if opts.Token == "" {
+		return "", errors.New("a GitHub token is required to open a pull request")
+	}
+
+	if opts.APIURL == "" {
+		opts.APIURL = DefaultAPIURL
+	}
+
+	remoteURL, err := remoterepo.RunGit(ctx, dir, nil, "remote", "get-url", "origin")
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := ParseRepository(strings.TrimSpace(remoteURL))
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkHost(repo, opts.APIURL); err != nil {
+		return "", err
+	}
+
+	base := opts.Base
+	if base == "" {
+		out, err := remoterepo.RunGit(ctx, dir, nil, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return "", err
+		}
+
+		base = strings.TrimSpace(out)
+		if base == "HEAD" {
+			return "", errors.New("cannot work out the base branch of the pull request as HEAD is detached")
+		}
+	}
+
+	if err := commitChanges(ctx, dir, files, opts); err != nil {
+		return "", err
+	}
+
+	// the token is passed through the environment of git rather than its arguments, so that
+	// it is not written to the git config or visible to other users in the list of processes,
+	// and is only sent to the host of the repository, even if the origin is pushed elsewhere
+	auth := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + opts.Token))
+	env := []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.https://" + repo.Host + "/.extraheader",
+		"GIT_CONFIG_VALUE_0=AUTHORIZATION: basic " + auth,
+	}
+	if _, err := remoterepo.RunGit(ctx, dir, env, "push", "--quiet", "origin", "HEAD:refs/heads/"+opts.Branch); err != nil {
+		return "", err
+	}
+
+	return openPullRequest(ctx, repo, base, opts)
+}
+
+func commitChanges(ctx context.Context, dir string, files []string, opts Options) error {
+	if _, err := remoterepo.RunGit(ctx, dir, nil, "checkout", "--quiet", "-b", opts.Branch); err != nil {
+		return err
+	}
+
+	var paths []string
+	for _, f := range files {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, abs)
+	}
+
+	if _, err := remoterepo.RunGit(ctx, dir, nil, append([]string{"add", "--all", "--"}, paths...)...); err != nil {
+		return err
+	}
+
+	if _, err := remoterepo.RunGit(ctx, dir, nil, "diff", "--cached", "--quiet"); err == nil {
+		return errors.New("there are no changes to open a pull request for")
+	}
+
+	_, err := remoterepo.RunGit(ctx, dir, nil, "commit", "--quiet", "--message", opts.Title+"\n\n"+opts.Body)
+
+	return err
+}
+
+func openPullRequest(ctx context.Context, repo Repository, base string, opts Options) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"title": opts.Title,
+		"body":  opts.Body,
+		"head":  opts.Branch,
+		"base":  base,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	pullsURL := fmt.Sprintf("%s/repos/%s/%s/pulls", strings.TrimSuffix(opts.APIURL, "/"), repo.Owner, repo.Name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pullsURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+opts.Token)
+	req.Header.Set("Content-Type", "application/json")
+	if opts.UserAgent != "" {
+		req.Header.Set("User-Agent", opts.UserAgent)
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not open pull request on %s: %w", repo, err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		HTMLURL string `json:"html_url"`
+		Message string `json:"message"`
+	}
+
+	// the body is only decoded for the details of the pull request or the error
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+
+	if resp.StatusCode != http.StatusCreated {
+		if result.Message != "" {
+			return "", fmt.Errorf("could not open pull request on %s: status=%q: %s", repo, resp.Status, result.Message)
+		}
+
+		return "", fmt.Errorf("could not open pull request on %s: status=%q", repo, resp.Status)
+	}
+
+	return result.HTMLURL, nil
+}
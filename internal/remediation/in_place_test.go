@@ -20,7 +20,7 @@ import (
 func parseInPlaceFixture(t *testing.T, universePath, lockfilePath string) (*resolve.Graph, client.ResolutionClient) {
 	t.Helper()
 
-	rw, err := lockfile.GetReadWriter(lockfilePath)
+	rw, err := lockfile.GetReadWriter(lockfilePath, "")
 	if err != nil {
 		t.Fatalf("Failed to get ReadWriter: %v", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"slices"
 
 	"deps.dev/util/resolve"
@@ -165,6 +166,11 @@ func overridePatchVulns(ctx context.Context, cl client.ResolutionClient, result
 				continue
 			}
 
+			// npm does not allow overriding direct dependencies to a different version
+			if vk.System == resolve.NPM && slices.ContainsFunc(result.Manifest.Requirements, func(r resolve.RequirementVersion) bool { return r.PackageKey == vk.PackageKey }) {
+				continue
+			}
+
 			bestVK := vk
 			bestCount := len(vulnerabilities) // remaining vulns
 			versions, err := getVersionsGreater(ctx, cl, vk)
@@ -174,6 +180,11 @@ func overridePatchVulns(ctx context.Context, cl client.ResolutionClient, result
 
 			// Find the minimal greater version that fixes as many vulnerabilities as possible.
 			for _, ver := range versions {
+				// npm only resolves to prerelease versions if they are explicitly required
+				if vk.System == resolve.NPM && isNpmPrerelease(ver.Version) {
+					continue
+				}
+
 				// Break if we've encountered a disallowed version update.
 				if _, diff, _ := vk.System.Semver().Difference(vk.Version, ver.Version); !opts.UpgradeConfig.Get(vk.Name).Allows(diff) {
 					break
@@ -285,12 +296,45 @@ func getVersionsGreater(ctx context.Context, cl client.DependencyClient, vk reso
 	return versions[offset:], nil
 }
 
+// isNpmPrerelease returns whether the npm version is a prerelease
+func isNpmPrerelease(version string) bool {
+	v, err := semver.NPM.Parse(version)
+
+	return err == nil && v.IsPrerelease()
+}
+
 // patchManifest applies the overridePatches to the manifest in-memory. Returns a copy of the manifest that has been patched.
 func patchManifest(patches []overridePatch, m manifest.Manifest) (manifest.Manifest, error) {
-	if m.System() != resolve.Maven {
+	switch m.System() {
+	case resolve.Maven:
+		return patchMavenManifest(patches, m), nil
+	case resolve.NPM:
+		return patchNpmManifest(patches, m), nil
+	default:
 		return manifest.Manifest{}, errors.New("unsupported ecosystem")
 	}
+}
+
+// patchNpmManifest adds the overridePatches to the overrides of the package.json
+func patchNpmManifest(patches []overridePatch, m manifest.Manifest) manifest.Manifest {
+	patched := m.Clone()
+
+	specific, _ := m.EcosystemSpecific.(manifest.NpmManifestSpecific)
+	overrides := maps.Clone(specific.Overrides)
+	if overrides == nil {
+		overrides = make(map[string]string)
+	}
+
+	for _, p := range patches {
+		overrides[p.Name] = p.NewVersion
+	}
+	patched.EcosystemSpecific = manifest.NpmManifestSpecific{Overrides: overrides}
+
+	return patched
+}
 
+// patchMavenManifest adds the overridePatches to the dependencyManagement of the pom.xml
+func patchMavenManifest(patches []overridePatch, m manifest.Manifest) manifest.Manifest {
 	// TODO: The overridePatch does not have an artifact's type or classifier, which is part of what uniquely identifies them.
 	// This needs to be part of the comparison & added to dependency management for it to override packages that specify them.
 
@@ -328,5 +372,5 @@ func patchManifest(patches []overridePatch, m manifest.Manifest) (manifest.Manif
 		}
 	}
 
-	return patched, nil
+	return patched
 }
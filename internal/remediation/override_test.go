@@ -48,6 +48,12 @@ func TestComputeOverridePatches(t *testing.T) {
 				UpgradeConfig: upgrade.NewConfig(),
 			},
 		},
+		{
+			name:         "npm-santatracker",
+			universePath: "./fixtures/santatracker/universe.yaml",
+			manifestPath: "./fixtures/santatracker/package.json",
+			opts:         basicOpts,
+		},
 		{
 			name:         "workaround-maven-guava-none-to-jre",
 			universePath: "./fixtures/override-workaround/universe.yaml",
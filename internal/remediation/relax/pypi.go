@@ -0,0 +1,162 @@
+package relax
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"deps.dev/util/resolve"
+	"deps.dev/util/semver"
+	"github.com/google/osv-scanner/v2/internal/remediation/upgrade"
+)
+
+type PyPIRelaxer struct{}
+
+func (r PyPIRelaxer) Relax(ctx context.Context, cl resolve.Client, req resolve.RequirementVersion, config upgrade.Config) (resolve.RequirementVersion, bool) {
+	configLevel := config.Get(req.Name)
+	if configLevel == upgrade.None {
+		return req, false
+	}
+
+	c, err := semver.PyPI.ParseConstraint(req.Version)
+	if err != nil {
+		// The specified version is not a valid PEP 440 specifier, cannot relax
+		return req, false
+	}
+
+	// Get all the concrete versions of the package
+	allVKs, err := cl.Versions(ctx, req.PackageKey)
+	if err != nil {
+		return req, false
+	}
+	var vers []string
+	for _, vk := range allVKs {
+		if vk.VersionType == resolve.Concrete {
+			vers = append(vers, vk.Version)
+		}
+	}
+	slices.SortFunc(vers, semver.PyPI.Compare)
+
+	// Find the versions on either side of the upper boundary of the requirement
+	var lastIdx int   // highest version matching constraint
+	nextIdx := -1     // next version outside of range, preferring non-prerelease
+	nextIsPre := true // if the next version is a prerelease version
+	for lastIdx = len(vers) - 1; lastIdx >= 0; lastIdx-- {
+		v, err := semver.PyPI.Parse(vers[lastIdx])
+		if err != nil {
+			continue
+		}
+		if c.MatchVersion(v) { // found the upper bound, stop iterating
+			break
+		}
+
+		// Want to prefer non-prerelease versions, so only select one if we haven't seen any non-prerelease versions
+		if !v.IsPrerelease() || nextIsPre {
+			nextIdx = lastIdx
+			nextIsPre = v.IsPrerelease()
+		}
+	}
+
+	// Didn't find any higher versions of the package
+	if nextIdx == -1 {
+		return req, false
+	}
+
+	// No versions match the existing constraint, something is wrong
+	if lastIdx == -1 {
+		return req, false
+	}
+
+	// Our desired relaxation ordering is
+	// 1.2.3 -> 1.2.* -> 1.*.* -> 2.*.* -> 3.*.* -> ...
+	// But we want to use PEP 440 version specifiers e.g.
+	// ==1.2.3 -> >=1.2.4,<1.3 -> >=1.4.5,<2 -> >=2.6.7,<3 -> >=3.8.9,<4 -> ...
+	// using the latest versions of the ranges
+
+	cmpVer := vers[lastIdx]
+	_, diff, _ := semver.PyPI.Difference(cmpVer, vers[nextIdx])
+	if !configLevel.Allows(diff) {
+		return req, false
+	}
+	if diff == semver.DiffMajor {
+		// Want to step only one major version at a time
+		// Instead of looking for a difference larger than major,
+		// we want to look for a major version bump from the first next version
+		cmpVer = vers[nextIdx]
+		diff = semver.DiffMinor
+	}
+
+	// Find the highest version with the same difference
+	best := vers[nextIdx]
+	for i := nextIdx + 1; i < len(vers); i++ {
+		_, d, err := semver.PyPI.Difference(cmpVer, vers[i])
+		if err != nil {
+			continue
+		}
+
+		// If we've exceeded our allowed upgrade level, stop looking.
+		if !configLevel.Allows(d) {
+			break
+		}
+
+		// DiffMajor < DiffMinor < DiffPatch < DiffPrerelease
+		// So if d is less than the original diff, it represents a larger change
+		if d < diff {
+			break
+		}
+		ver, err := semver.PyPI.Parse(vers[i])
+		if err != nil {
+			continue
+		}
+		if !ver.IsPrerelease() || nextIsPre {
+			best = vers[i]
+		}
+	}
+
+	major, minor, ok := pypiReleaseSegments(best)
+	if !ok {
+		return req, false
+	}
+
+	if diff == semver.DiffPatch {
+		req.Version = ">=" + best + ",<" + strconv.Itoa(major) + "." + strconv.Itoa(minor+1)
+	} else {
+		req.Version = ">=" + best + ",<" + strconv.Itoa(major+1)
+	}
+
+	return req, true
+}
+
+// pypiReleaseSegments returns the major and minor components of the release segment of a PEP 440 version,
+// treating a missing minor component as 0.
+func pypiReleaseSegments(version string) (int, int, bool) {
+	// Ignore the epoch, if any e.g. 1!2.3.4
+	if _, after, found := strings.Cut(version, "!"); found {
+		version = after
+	}
+	version = strings.TrimPrefix(strings.ToLower(version), "v")
+
+	parts := strings.SplitN(version, ".", 3)
+	major, err := strconv.Atoi(leadingDigits(parts[0]))
+	if err != nil {
+		return 0, 0, false
+	}
+	minor := 0
+	if len(parts) > 1 {
+		if minor, err = strconv.Atoi(leadingDigits(parts[1])); err != nil {
+			minor = 0
+		}
+	}
+
+	return major, minor, true
+}
+
+func leadingDigits(s string) string {
+	end := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if end == -1 {
+		return s
+	}
+
+	return s[:end]
+}
@@ -29,6 +29,8 @@ func GetRelaxer(ecosystem resolve.System) (RequirementRelaxer, error) {
 	switch ecosystem {
 	case resolve.NPM:
 		return NpmRelaxer{}, nil
+	case resolve.PyPI:
+		return PyPIRelaxer{}, nil
 	default:
 		return nil, errors.New("unsupported ecosystem")
 	}
@@ -14,7 +14,7 @@ import (
 // TODO: Supported strategies should be part of the manifest/lockfile ReadWriter directly
 func SupportsRelax(m manifest.ReadWriter) bool {
 	switch m.(type) {
-	case manifest.NpmReadWriter:
+	case manifest.NpmReadWriter, manifest.PythonReadWriter:
 		return true
 	default:
 		return false
@@ -23,7 +23,7 @@ func SupportsRelax(m manifest.ReadWriter) bool {
 
 func SupportsOverride(m manifest.ReadWriter) bool {
 	switch m.(type) {
-	case manifest.MavenReadWriter:
+	case manifest.MavenReadWriter, manifest.NpmReadWriter:
 		return true
 	default:
 		return false
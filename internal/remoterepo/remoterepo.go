@@ -0,0 +1,138 @@
+// Package remoterepo fetches remote Git repositories so that they can be scanned.
+package remoterepo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Reference is a remote Git repository, and optionally the branch, tag, or commit of it
+type Reference struct {
+	URL string
+	// Ref is the branch, tag, or commit to fetch, or empty for the default branch
+	Ref string
+}
+
+func (r Reference) String() string {
+	if r.Ref == "" {
+		return r.URL
+	}
+
+	return r.URL + "@" + r.Ref
+}
+
+// ParseReference parses a repository in the form of "<url>[@<ref>]", where the url
+// can either be a URL such as "https://github.com/org/repo" or an scp-like address
+// such as "git@github.com:org/repo.git"
+func ParseReference(s string) (Reference, error) {
+	if s == "" {
+		return Reference{}, errors.New("missing repository URL")
+	}
+
+	ref := Reference{URL: s}
+	if i := strings.LastIndex(s, "@"); i != -1 && hasPath(s[:i]) {
+		ref.URL, ref.Ref = s[:i], s[i+1:]
+		if ref.Ref == "" {
+			return Reference{}, fmt.Errorf("missing ref after @ in %q", s)
+		}
+	}
+
+	if !hasPath(ref.URL) {
+		return Reference{}, fmt.Errorf("%q is not a URL of a remote repository", s)
+	}
+
+	if err := ref.validate(); err != nil {
+		return Reference{}, err
+	}
+
+	return ref, nil
+}
+
+// validate checks that neither the url nor the ref could be mistaken for
+// an option by git, such as "--upload-pack=<command>"
+func (r Reference) validate() error {
+	if strings.HasPrefix(r.URL, "-") {
+		return fmt.Errorf("repository URL %q must not start with \"-\"", r.URL)
+	}
+
+	if strings.HasPrefix(r.Ref, "-") {
+		return fmt.Errorf("ref %q must not start with \"-\"", r.Ref)
+	}
+
+	return nil
+}
+
+// hasPath returns whether the url has a path after its host, which the
+// "@" of a ref must come after to not be part of the user of the url
+func hasPath(url string) bool {
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		return strings.Contains(rest, "/")
+	}
+
+	// scp-like addresses separate the host from the path with a colon
+	_, path, ok := strings.Cut(url, ":")
+
+	return ok && path != ""
+}
+
+// Clone shallowly fetches the ref of the repository into dir, which must be an existing
+// directory that is not already a Git repository, and checks it out, returning the commit it is at
+func Clone(ctx context.Context, ref Reference, dir string) (string, error) {
+	if err := ref.validate(); err != nil {
+		return "", fmt.Errorf("could not fetch %s: %w", ref, err)
+	}
+
+	target := ref.Ref
+	if target == "" {
+		target = "HEAD"
+	}
+
+	// fetching a single ref rather than cloning also allows for fetching commits
+	steps := [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth=1", "--no-tags", "--", ref.URL, target},
+		{"checkout", "--quiet", "--detach", "FETCH_HEAD"},
+	}
+
+	for _, args := range steps {
+		if _, err := RunGit(ctx, dir, nil, args...); err != nil {
+			return "", fmt.Errorf("could not fetch %s: %w", ref, err)
+		}
+	}
+
+	commit, err := RunGit(ctx, dir, nil, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("could not fetch %s: %w", ref, err)
+	}
+
+	return strings.TrimSpace(commit), nil
+}
+
+// RunGit runs git with the given arguments in dir, along with any extra environment variables
+// (such as GIT_CONFIG_COUNT), returning what it writes to stdout
+func RunGit(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// fail rather than waiting for credentials that cannot be entered
+	cmd.Env = append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), env...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+
+	return string(out), nil
+}
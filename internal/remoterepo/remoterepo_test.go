@@ -0,0 +1,176 @@
+package remoterepo_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/remoterepo"
+)
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    remoterepo.Reference
+		wantErr bool
+	}{
+		{
+			input: "https://github.com/google/osv-scanner",
+			want:  remoterepo.Reference{URL: "https://github.com/google/osv-scanner"},
+		},
+		{
+			input: "https://github.com/google/osv-scanner@v2.0.0",
+			want:  remoterepo.Reference{URL: "https://github.com/google/osv-scanner", Ref: "v2.0.0"},
+		},
+		{
+			input: "https://user@example.com/org/repo.git@feature/branch",
+			want:  remoterepo.Reference{URL: "https://user@example.com/org/repo.git", Ref: "feature/branch"},
+		},
+		{
+			input: "https://user@example.com/org/repo.git",
+			want:  remoterepo.Reference{URL: "https://user@example.com/org/repo.git"},
+		},
+		{
+			input: "git@github.com:google/osv-scanner.git",
+			want:  remoterepo.Reference{URL: "git@github.com:google/osv-scanner.git"},
+		},
+		{
+			input: "git@github.com:google/osv-scanner.git@2d5a2ea0a82a4d1b8d0c6c0a0f1ad2e5a3a13e4b",
+			want:  remoterepo.Reference{URL: "git@github.com:google/osv-scanner.git", Ref: "2d5a2ea0a82a4d1b8d0c6c0a0f1ad2e5a3a13e4b"},
+		},
+		{input: "git@github.com:google/osv-scanner.git@", wantErr: true},
+		{input: "--upload-pack=touch /tmp/pwned:repo", wantErr: true},
+		{input: "https://github.com/google/osv-scanner@--upload-pack=touch", wantErr: true},
+		{input: "path/to/repo", wantErr: true},
+		{input: "https://github.com", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := remoterepo.ParseReference(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseReference() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// createRepository creates a repository with a commit on its main branch
+// and a commit on a "release" branch, returning the commits
+func createRepository(t *testing.T) (string, string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=osv-scanner", "GIT_AUTHOR_EMAIL=osv-scanner@example.com",
+			"GIT_COMMITTER_NAME=osv-scanner", "GIT_COMMITTER_EMAIL=osv-scanner@example.com",
+		)
+
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
+		}
+
+		return strings.TrimSpace(string(out))
+	}
+
+	git("init", "--quiet", "--initial-branch=main")
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/main\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "go.mod")
+	git("commit", "--quiet", "--message=main")
+	main := git("rev-parse", "HEAD")
+
+	git("checkout", "--quiet", "-b", "release")
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/release\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	git("commit", "--quiet", "--all", "--message=release")
+	release := git("rev-parse", "HEAD")
+	git("checkout", "--quiet", "main")
+
+	return "file://" + filepath.ToSlash(dir), main, release
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	url, main, release := createRepository(t)
+
+	tests := []struct {
+		name       string
+		ref        string
+		wantCommit string
+		wantModule string
+		wantErr    bool
+	}{
+		{name: "default branch", wantCommit: main, wantModule: "example.com/main"},
+		{name: "branch", ref: "release", wantCommit: release, wantModule: "example.com/release"},
+		{name: "commit", ref: release, wantCommit: release, wantModule: "example.com/release"},
+		{name: "missing ref", ref: "does-not-exist", wantErr: true},
+		{name: "option as ref", ref: "--upload-pack=touch pwned", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			commit, err := remoterepo.Clone(t.Context(), remoterepo.Reference{URL: url, Ref: tt.ref}, dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Clone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if commit != tt.wantCommit {
+				t.Errorf("Clone() = %s, want %s", commit, tt.wantCommit)
+			}
+
+			b, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+			if err != nil {
+				t.Fatalf("could not read checked out file: %v", err)
+			}
+			if got := strings.TrimPrefix(strings.TrimSpace(string(b)), "module "); got != tt.wantModule {
+				t.Errorf("checked out module %s, want %s", got, tt.wantModule)
+			}
+		})
+	}
+}
+
+func TestClone_Options(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	_, err := remoterepo.Clone(t.Context(), remoterepo.Reference{URL: "--upload-pack=touch pwned"}, dir)
+	if err == nil {
+		t.Fatalf("Clone() did not reject a URL that is an option")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "pwned")); err == nil {
+		t.Errorf("Clone() ran the upload pack option")
+	}
+}
@@ -7,28 +7,45 @@ import (
 	"github.com/google/osv-scanner/v2/pkg/models"
 )
 
-var format = []string{"table", "html", "vertical", "json", "markdown", "sarif", "gh-annotations", "cyclonedx-1-4", "cyclonedx-1-5", "spdx-2-3"}
+var format = []string{"table", "html", "vertical", "oneline", "csv", "json", "proto", "markdown", "sarif", "gh-annotations", "gitlab-dependency-scanning", "junit", "cyclonedx-1-4", "cyclonedx-1-5", "spdx-2-3"}
 
 func Format() []string {
 	return format
 }
 
-func newResultPrinter(format string, writer io.Writer, terminalWidth int, showAllVulns bool) (resultPrinter, error) {
+// IsHumanReadable returns true for the formats that are meant to be read in a terminal,
+// which logs can be mixed in with; the output of any other format must not be interleaved
+// with logs or progress, as it is meant to be read by other tools
+func IsHumanReadable(format string) bool {
+	return format == "vertical" || format == "table" || format == "markdown"
+}
+
+func newResultPrinter(format string, writer io.Writer, opts Options) (resultPrinter, error) {
 	switch format {
 	case "html":
-		return &htmlReporter{writer}, nil
+		return &htmlReporter{writer, opts}, nil
 	case "json":
-		return &jsonReporter{writer}, nil
+		return &jsonReporter{writer, opts.AdvisoryDetails}, nil
+	case "proto":
+		return &protoReporter{writer, opts.AdvisoryDetails}, nil
 	case "vertical":
-		return &verticalReporter{writer, terminalWidth, showAllVulns}, nil
+		return &verticalReporter{writer, opts.TerminalWidth, opts.ShowAllVulns}, nil
+	case "oneline":
+		return &onelineReporter{writer, opts.ShowAllVulns}, nil
+	case "csv":
+		return &csvReporter{writer, opts.ShowAllVulns}, nil
 	case "table":
-		return &tableReporter{writer, false, terminalWidth, showAllVulns}, nil
+		return &tableReporter{writer, false, opts}, nil
 	case "markdown":
-		return &tableReporter{writer, true, terminalWidth, showAllVulns}, nil
+		return &tableReporter{writer, true, opts}, nil
 	case "sarif":
 		return &sarifReporter{writer}, nil
 	case "gh-annotations":
 		return &ghAnnotationsReporter{writer}, nil
+	case "gitlab-dependency-scanning":
+		return &gitlabReporter{writer, opts.ShowAllVulns}, nil
+	case "junit":
+		return &junitReporter{writer, opts.FailOnSeverity, opts.ShowAllVulns}, nil
 	case "cyclonedx-1-4":
 		return &cycloneDXReporter{writer, models.CycloneDXVersion14}, nil
 	case "cyclonedx-1-5":
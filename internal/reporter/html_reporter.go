@@ -9,8 +9,13 @@ import (
 
 type htmlReporter struct {
 	writer io.Writer
+	opts   Options
 }
 
 func (r *htmlReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	if r.opts.GroupBy == output.GroupByVuln {
+		return output.PrintVulnGroupsHTMLResults(vulnResult, r.writer, r.opts.ShowAllVulns)
+	}
+
 	return output.PrintHTMLResults(vulnResult, r.writer)
 }
@@ -8,9 +8,10 @@ import (
 )
 
 type jsonReporter struct {
-	writer io.Writer
+	writer          io.Writer
+	advisoryDetails output.AdvisoryDetails
 }
 
 func (r *jsonReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
-	return output.PrintJSONResults(vulnResult, r.writer)
+	return output.PrintJSONResults(output.TrimAdvisoryDetails(vulnResult, r.advisoryDetails), r.writer)
 }
@@ -0,0 +1,19 @@
+package reporter
+
+import (
+	"io"
+
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+type junitReporter struct {
+	writer         io.Writer
+	failOnSeverity config.SeverityThresholds
+	showAllVulns   bool
+}
+
+func (r *junitReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	return output.PrintJUnitReport(vulnResult, r.writer, r.failOnSeverity, r.showAllVulns)
+}
@@ -0,0 +1,19 @@
+package reporter
+
+import (
+	"io"
+
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+type onelineReporter struct {
+	writer       io.Writer
+	showAllVulns bool
+}
+
+func (r *onelineReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	output.PrintOnelineResults(vulnResult, r.writer, r.showAllVulns)
+
+	return nil
+}
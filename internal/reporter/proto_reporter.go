@@ -0,0 +1,17 @@
+package reporter
+
+import (
+	"io"
+
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+type protoReporter struct {
+	writer          io.Writer
+	advisoryDetails output.AdvisoryDetails
+}
+
+func (r *protoReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
+	return output.PrintProtoResults(output.TrimAdvisoryDetails(vulnResult, r.advisoryDetails), r.writer)
+}
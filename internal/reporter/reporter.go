@@ -3,6 +3,8 @@ package reporter
 import (
 	"io"
 
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/output"
 	"github.com/google/osv-scanner/v2/pkg/models"
 )
 
@@ -12,18 +14,41 @@ type resultPrinter interface {
 	PrintResult(vulnResult *models.VulnerabilityResults) error
 }
 
+// Options controls how the human-readable reporters print results
+type Options struct {
+	// 0 indicates not a terminal output
+	TerminalWidth int
+	ShowAllVulns  bool
+	// DedupeTable merges table rows for the same vulnerability in the same package
+	// version that was found in multiple sources
+	DedupeTable bool
+	// GroupBy controls how the findings are grouped in table and html output
+	GroupBy output.GroupBy
+	// Summary adds the counts of the vulnerabilities by severity, ecosystem, fixability,
+	// and call analysis to the top of the human-readable output and to the json output
+	Summary bool
+	// AdvisoryDetails controls how much of each advisory is included in the json output
+	AdvisoryDetails output.AdvisoryDetails
+	// FailOnSeverity are the severity thresholds that vulnerabilities must meet to be
+	// reported as failures in the junit output
+	FailOnSeverity config.SeverityThresholds
+}
+
 func PrintResult(
 	vulnResult *models.VulnerabilityResults,
 	format string,
 	writer io.Writer,
-	terminalWidth int,
-	showAllVulns bool,
+	opts Options,
 ) error {
-	r, err := newResultPrinter(format, writer, terminalWidth, showAllVulns)
+	r, err := newResultPrinter(format, writer, opts)
 
 	if err != nil {
 		return err
 	}
 
+	if opts.Summary {
+		vulnResult = output.WithSummary(vulnResult)
+	}
+
 	return r.PrintResult(vulnResult)
 }
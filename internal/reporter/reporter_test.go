@@ -14,7 +14,7 @@ func TestPrintResult(t *testing.T) {
 	for _, format := range reporter.Format() {
 		stdout := &bytes.Buffer{}
 
-		err := reporter.PrintResult(&models.VulnerabilityResults{}, format, stdout, 0, false)
+		err := reporter.PrintResult(&models.VulnerabilityResults{}, format, stdout, reporter.Options{})
 		if err != nil {
 			t.Errorf("Reporter for '%s' format not implemented", format)
 		}
@@ -26,7 +26,7 @@ func TestPrintResult_UnsupportedFormatter(t *testing.T) {
 
 	stdout := &bytes.Buffer{}
 
-	err := reporter.PrintResult(&models.VulnerabilityResults{}, "unsupported", stdout, 0, true)
+	err := reporter.PrintResult(&models.VulnerabilityResults{}, "unsupported", stdout, reporter.Options{ShowAllVulns: true})
 
 	if err == nil {
 		t.Errorf("Did not get expected error")
@@ -12,21 +12,29 @@ import (
 type tableReporter struct {
 	writer   io.Writer
 	markdown bool
-	// 0 indicates not a terminal output
-	terminalWidth int
-	showAllVulns  bool
+	opts     Options
 }
 
 func (r *tableReporter) PrintResult(vulnResult *models.VulnerabilityResults) error {
-	if len(vulnResult.Results) == 0 && vulnResult.LicenseSummary == nil && !cmdlogger.HasErrored() {
+	if len(vulnResult.Results) == 0 && vulnResult.LicenseSummary == nil && len(vulnResult.UnscannedPackages) == 0 && len(vulnResult.IntegrityMismatches) == 0 && !cmdlogger.HasErrored() {
 		fmt.Fprintf(r.writer, "No issues found\n")
 		return nil
 	}
 
+	if r.opts.GroupBy == output.GroupByFix {
+		output.PrintFixActionsTableResults(vulnResult, r.writer, r.opts.TerminalWidth, r.markdown)
+		return nil
+	}
+
+	if r.opts.GroupBy == output.GroupByVuln {
+		output.PrintVulnGroupsTableResults(vulnResult, r.writer, r.opts.TerminalWidth, r.markdown, r.opts.ShowAllVulns)
+		return nil
+	}
+
 	if r.markdown {
-		output.PrintMarkdownTableResults(vulnResult, r.writer, r.showAllVulns)
+		output.PrintMarkdownTableResults(vulnResult, r.writer, r.opts.ShowAllVulns, r.opts.DedupeTable)
 	} else {
-		output.PrintTableResults(vulnResult, r.writer, r.terminalWidth, r.showAllVulns)
+		output.PrintTableResults(vulnResult, r.writer, r.opts.TerminalWidth, r.opts.ShowAllVulns, r.opts.DedupeTable)
 	}
 
 	return nil
@@ -30,8 +30,8 @@ type NpmRegistryClient struct {
 	fallback *resolve.APIClient
 }
 
-func NewNpmRegistryClient(workdir string) (*NpmRegistryClient, error) {
-	api, err := datasource.NewNpmRegistryAPIClient(workdir)
+func NewNpmRegistryClient(workdir string, registry string) (*NpmRegistryClient, error) {
+	api, err := datasource.NewNpmRegistryAPIClient(workdir, registry)
 	if err != nil {
 		return nil, err
 	}
@@ -7,13 +7,15 @@ import (
 	"deps.dev/util/resolve"
 )
 
-// OverrideClient wraps a DependencyClient, allowing for custom packages & versions to be added
+// OverrideClient wraps a DependencyClient, allowing for custom packages & versions to be added,
+// and for the requirements on a package by the versions of other packages to be overridden
 type OverrideClient struct {
 	DependencyClient
 
 	// Can't quite reuse resolve.LocalClient because it automatically creates dependencies
-	pkgVers map[resolve.PackageKey][]resolve.Version            // versions of a package
-	verDeps map[resolve.VersionKey][]resolve.RequirementVersion // dependencies of a version
+	pkgVers   map[resolve.PackageKey][]resolve.Version            // versions of a package
+	verDeps   map[resolve.VersionKey][]resolve.RequirementVersion // dependencies of a version
+	overrides map[resolve.PackageKey]string                       // requirement that replaces any other on a package
 }
 
 func NewOverrideClient(c DependencyClient) *OverrideClient {
@@ -21,9 +23,16 @@ func NewOverrideClient(c DependencyClient) *OverrideClient {
 		DependencyClient: c,
 		pkgVers:          make(map[resolve.PackageKey][]resolve.Version),
 		verDeps:          make(map[resolve.VersionKey][]resolve.RequirementVersion),
+		overrides:        make(map[resolve.PackageKey]string),
 	}
 }
 
+// AddOverride replaces the requirement on the package by every version that was not added
+// with AddVersion with the given requirement, as npm does for the "overrides" of a package.json
+func (c *OverrideClient) AddOverride(pk resolve.PackageKey, requirement string) {
+	c.overrides[pk] = requirement
+}
+
 func (c *OverrideClient) AddVersion(v resolve.Version, deps []resolve.RequirementVersion) {
 	// TODO: Inserting multiple co-dependent requirements may not work, depending on order
 	versions := c.pkgVers[v.PackageKey]
@@ -62,7 +71,20 @@ func (c *OverrideClient) Requirements(ctx context.Context, vk resolve.VersionKey
 		return deps, nil
 	}
 
-	return c.DependencyClient.Requirements(ctx, vk)
+	deps, err := c.DependencyClient.Requirements(ctx, vk)
+	if err != nil || len(c.overrides) == 0 {
+		return deps, err
+	}
+
+	// the requirements may be cached by the underlying client, so must not be modified
+	deps = slices.Clone(deps)
+	for i, d := range deps {
+		if requirement, ok := c.overrides[d.PackageKey]; ok {
+			deps[i].Version = requirement
+		}
+	}
+
+	return deps, nil
 }
 
 func (c *OverrideClient) MatchingVersions(ctx context.Context, vk resolve.VersionKey) ([]resolve.Version, error) {
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"slices"
+
+	"deps.dev/util/resolve"
+	"deps.dev/util/semver"
+	"github.com/google/osv-scanner/v2/internal/datasource"
+	pypiutil "github.com/google/osv-scanner/v2/internal/utility/pypi"
+)
+
+const pypiRegistryCacheExt = ".resolve.pypi"
+
+// PyPIRegistryClient is a client for resolving Python dependencies using the JSON API of a Python package index.
+// deps.dev does not serve the requirements of PyPI packages, so this is required to resolve them.
+type PyPIRegistryClient struct {
+	api *datasource.PyPIRegistryAPIClient
+}
+
+func NewPyPIRegistryClient(registry string) *PyPIRegistryClient {
+	return &PyPIRegistryClient{api: datasource.NewPyPIRegistryAPIClient(registry)}
+}
+
+func (c *PyPIRegistryClient) Version(_ context.Context, vk resolve.VersionKey) (resolve.Version, error) {
+	return resolve.Version{VersionKey: vk}, nil
+}
+
+func (c *PyPIRegistryClient) Versions(ctx context.Context, pk resolve.PackageKey) ([]resolve.Version, error) {
+	if pk.System != resolve.PyPI {
+		return nil, fmt.Errorf("wrong system: %v", pk.System)
+	}
+
+	versions, err := c.api.Versions(ctx, pk.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	vks := make([]resolve.Version, len(versions))
+	for i, v := range versions {
+		vks[i] = resolve.Version{
+			VersionKey: resolve.VersionKey{
+				PackageKey:  pk,
+				Version:     v,
+				VersionType: resolve.Concrete,
+			}}
+	}
+
+	slices.SortFunc(vks, func(a, b resolve.Version) int { return semver.PyPI.Compare(a.Version, b.Version) })
+
+	return vks, nil
+}
+
+func (c *PyPIRegistryClient) Requirements(ctx context.Context, vk resolve.VersionKey) ([]resolve.RequirementVersion, error) {
+	if vk.System != resolve.PyPI {
+		return nil, fmt.Errorf("wrong system: %v", vk.System)
+	}
+
+	dependencies, err := c.api.Dependencies(ctx, vk.Name, vk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]resolve.RequirementVersion, 0, len(dependencies))
+	for _, d := range dependencies {
+		req, err := pypiutil.MakeRequirement(d)
+		if err != nil {
+			// Skip the requirements that cannot be parsed (e.g. URL requirements)
+			// rather than failing to resolve the whole package.
+			continue
+		}
+		reqs = append(reqs, req)
+	}
+
+	// The order of the requirements is kept as the resolver is sensitive to it.
+	return reqs, nil
+}
+
+func (c *PyPIRegistryClient) MatchingVersions(ctx context.Context, vk resolve.VersionKey) ([]resolve.Version, error) {
+	if vk.System != resolve.PyPI {
+		return nil, fmt.Errorf("wrong system: %v", vk.System)
+	}
+
+	versions, err := c.Versions(ctx, vk.PackageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolve.MatchRequirement(vk, versions), nil
+}
+
+func (c *PyPIRegistryClient) AddRegistries(_ []Registry) error { return nil }
+
+func (c *PyPIRegistryClient) WriteCache(path string) error {
+	f, err := os.Create(path + pypiRegistryCacheExt)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return gob.NewEncoder(f).Encode(c.api)
+}
+
+func (c *PyPIRegistryClient) LoadCache(path string) error {
+	f, err := os.Open(path + pypiRegistryCacheExt)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return gob.NewDecoder(f).Decode(&c.api)
+}
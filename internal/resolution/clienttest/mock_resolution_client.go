@@ -65,6 +65,8 @@ func NewMockResolutionClient(t *testing.T, universeYAML string) client.Resolutio
 		sys = resolve.NPM
 	case "maven":
 		sys = resolve.Maven
+	case "pypi":
+		sys = resolve.PyPI
 	default:
 		t.Fatalf("unknown ecosystem in universe: %s", universe.System)
 	}
@@ -104,6 +104,9 @@ func (ds *DependencySubgraph) IsDevOnly(groups map[manifest.RequirementKey][]str
 				return !slices.Contains(reqGroups, "dev")
 			case resolve.Maven:
 				return !slices.Contains(reqGroups, "test")
+			case resolve.PyPI:
+				// Python requirements are only in groups if they are not dependencies of the project itself
+				return len(reqGroups) == 0
 			case resolve.UnknownSystem:
 				fallthrough
 			default:
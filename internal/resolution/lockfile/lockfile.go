@@ -27,32 +27,43 @@ type ReadWriter interface {
 	Write(original depfile.DepFile, output io.Writer, patches []DependencyPatch) error
 }
 
-func Overwrite(rw ReadWriter, filename string, patches []DependencyPatch) error {
+// Patched returns the contents of the lockfile at filename with the DependencyPatches applied, without modifying the file.
+func Patched(rw ReadWriter, filename string, patches []DependencyPatch) ([]byte, error) {
 	r, err := depfile.OpenLocalDepFile(filename)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer r.Close()
+
 	var buf bytes.Buffer
-	err = rw.Write(r, &buf, patches)
-	r.Close() // Make sure the file is closed before we start writing to it.
+	if err := rw.Write(r, &buf, patches); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func Overwrite(rw ReadWriter, filename string, patches []DependencyPatch) error {
+	// Patched closes the file before we start writing to it.
+	patched, err := Patched(rw, filename, patches)
 	if err != nil {
 		return err
 	}
 
 	//nolint:gosec // Complaining about the 0644 permissions.
 	// The file already exists anyway so the permissions don't matter.
-	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+	if err := os.WriteFile(filename, patched, 0644); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func GetReadWriter(pathToLockfile string) (ReadWriter, error) {
+func GetReadWriter(pathToLockfile string, registry string) (ReadWriter, error) {
 	base := filepath.Base(pathToLockfile)
 	switch base {
 	case "package-lock.json":
-		return NpmReadWriter{}, nil
+		return NpmReadWriter{Registry: registry}, nil
 	default:
 		return nil, fmt.Errorf("unsupported lockfile type: %s", base)
 	}
@@ -15,7 +15,11 @@ import (
 	"github.com/google/osv-scanner/v2/internal/resolution/manifest"
 )
 
-type NpmReadWriter struct{}
+type NpmReadWriter struct {
+	// Registry is the npm registry to fetch package information from when writing the lockfile,
+	// instead of the default registry configured in the npmrc files
+	Registry string
+}
 
 func (NpmReadWriter) System() resolve.System { return resolve.NPM }
 
@@ -193,7 +197,7 @@ func (rw NpmReadWriter) Write(original depfile.DepFile, output io.Writer, patche
 		patchMap[p.Pkg.Name][p.OrigVersion] = p.NewVersion
 	}
 
-	api, err := datasource.NewNpmRegistryAPIClient(filepath.Dir(original.Path()))
+	api, err := datasource.NewNpmRegistryAPIClient(filepath.Dir(original.Path()), rw.Registry)
 	if err != nil {
 		return err
 	}
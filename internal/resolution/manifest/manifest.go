@@ -69,23 +69,34 @@ type ReadWriter interface {
 	Write(original depfile.DepFile, output io.Writer, patches Patch) error
 }
 
-// Overwrite applies the ManifestPatch to the manifest at filename.
-// Used so as to not have the same file open for reading and writing at the same time.
-func Overwrite(rw ReadWriter, filename string, p Patch) error {
+// Patched returns the contents of the manifest at filename with the Patch applied, without modifying the file.
+func Patched(rw ReadWriter, filename string, p Patch) ([]byte, error) {
 	r, err := depfile.OpenLocalDepFile(filename)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer r.Close()
+
 	var buf bytes.Buffer
-	err = rw.Write(r, &buf, p)
-	r.Close() // Make sure the file is closed before we start writing to it.
+	if err := rw.Write(r, &buf, p); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Overwrite applies the ManifestPatch to the manifest at filename.
+// Used so as to not have the same file open for reading and writing at the same time.
+func Overwrite(rw ReadWriter, filename string, p Patch) error {
+	// Patched closes the file before we start writing to it.
+	patched, err := Patched(rw, filename, p)
 	if err != nil {
 		return err
 	}
 
 	//nolint:gosec // Complaining about the 0644 permissions.
 	// The file already exists anyway so the permissions don't matter.
-	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+	if err := os.WriteFile(filename, patched, 0644); err != nil {
 		return err
 	}
 
@@ -99,6 +110,8 @@ func GetReadWriter(pathToManifest string, registry string) (ReadWriter, error) {
 		return NewMavenReadWriter(registry)
 	case "package.json":
 		return NpmReadWriter{}, nil
+	case "pyproject.toml":
+		return PythonReadWriter{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported manifest type: %s", base)
 	}
@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	"deps.dev/util/resolve/dep"
 	"github.com/google/osv-scanner/v2/internal/resolution/depfile"
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/pretty"
 	"github.com/tidwall/sjson"
 )
 
@@ -28,6 +30,14 @@ func npmRequirementKey(requirement resolve.RequirementVersion) RequirementKey {
 
 type NpmReadWriter struct{}
 
+// NpmManifestSpecific is the npm-specific information of a root package.json
+type NpmManifestSpecific struct {
+	// Overrides are the versions that every transitive dependency on a package is forced to,
+	// from the "overrides" (npm) or "resolutions" (yarn) of the package.json, keyed by package name.
+	// Only overrides that apply to the package wherever it is in the dependency tree are included.
+	Overrides map[string]string
+}
+
 func (NpmReadWriter) System() resolve.System { return resolve.NPM }
 
 type PackageJSON struct {
@@ -43,6 +53,35 @@ type PackageJSON struct {
 	// These fields are currently only used when parsing package-lock.json
 	PeerDependencies map[string]string `json:"peerDependencies"`
 	// BundleDependencies   []string          `json:"bundleDependencies"`
+
+	// Overrides can be nested to only apply to the dependencies of a package,
+	// which is why the values are not necessarily strings
+	Overrides   map[string]any    `json:"overrides"`
+	Resolutions map[string]string `json:"resolutions"`
+}
+
+// overrides returns the overrides of the package.json that apply to a package
+// wherever it is in the dependency tree, keyed by package name
+func (p PackageJSON) overrides() map[string]string {
+	overrides := make(map[string]string)
+
+	// yarn resolutions can be for a package anywhere in the tree, or nested under other packages
+	for pattern, version := range p.Resolutions {
+		name := strings.TrimPrefix(pattern, "**/")
+		if strings.Count(name, "/") == 0 || strings.HasPrefix(name, "@") && strings.Count(name, "/") == 1 {
+			overrides[name] = version
+		}
+	}
+
+	// npm overrides that are objects only apply to the dependencies of the package,
+	// and those that start with $ reference the version of a direct dependency
+	for name, version := range p.Overrides {
+		if v, ok := version.(string); ok && !strings.HasPrefix(v, "$") {
+			overrides[name] = v
+		}
+	}
+
+	return overrides
 }
 
 func (rw NpmReadWriter) Read(f depfile.DepFile) (Manifest, error) {
@@ -160,6 +199,10 @@ func (rw NpmReadWriter) Read(f depfile.DepFile) (Manifest, error) {
 
 	resolve.SortDependencies(manif.Requirements)
 
+	if overrides := packagejson.overrides(); len(overrides) > 0 {
+		manif.EcosystemSpecific = NpmManifestSpecific{Overrides: overrides}
+	}
+
 	// resolve workspaces after regular requirements
 	for i, m := range manif.LocalManifests {
 		imp, ok := workspaceReqVers[m.Root.PackageKey]
@@ -244,8 +287,15 @@ func (NpmReadWriter) Write(r depfile.DepFile, w io.Writer, patch Patch) error {
 	}
 	manif := buf.String()
 
+	var overrides []DependencyPatch
 	for _, changedDep := range patch.Deps {
 		name := changedDep.Pkg.Name
+		if changedDep.OrigRequire == "" {
+			// an empty original requirement signals this is an override of a transitive dependency
+			overrides = append(overrides, changedDep)
+			continue
+		}
+
 		origVer := changedDep.OrigRequire
 		newVer := changedDep.NewRequire
 		if knownAs, ok := changedDep.Type.GetAttr(dep.KnownAs); ok {
@@ -300,12 +350,97 @@ func (NpmReadWriter) Write(r depfile.DepFile, w io.Writer, patch Patch) error {
 		}
 	}
 
+	if len(overrides) > 0 {
+		manif, err = writeNpmOverrides(manif, npmOverridesField(r, manif), overrides)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Write out modified package.json
 	_, err = io.WriteString(w, manif)
 
 	return err
 }
 
+// writeNpmOverrides sets the overrides of the package.json to the new requirements of the patches,
+// adding the overrides field if it does not exist
+func writeNpmOverrides(manif string, field string, patches []DependencyPatch) (string, error) {
+	objText := "{}"
+	existing := gjson.Get(manif, field)
+	if existing.IsObject() {
+		objText = existing.Raw
+	}
+
+	var err error
+	for _, p := range patches {
+		key := gjsonEscape(p.Pkg.Name)
+		// yarn resolutions that apply anywhere in the tree can also be written as **/<name>
+		if field == "resolutions" && gjson.Get(objText, gjsonEscape("**/"+p.Pkg.Name)).Exists() {
+			key = gjsonEscape("**/" + p.Pkg.Name)
+		}
+		objText, err = sjson.Set(objText, key, p.NewRequire)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// pretty the json because setting new keys breaks the formatting.
+	// Setting Prefix & Indent to account for the fact that this is not the top-level object.
+	objText = string(pretty.PrettyOptions([]byte(objText), &pretty.Options{Prefix: "  ", Indent: "  "}))
+	objText = strings.TrimSpace(objText) // remove leading spaces & newline pretty creates
+
+	if existing.Exists() {
+		return sjson.SetRaw(manif, field, objText)
+	}
+
+	// sjson appends new fields directly after the last value, so insert the field manually
+	// before the closing brace of the package.json to keep it on its own line
+	end := strings.LastIndex(manif, "}")
+	if end < 0 {
+		return "", errors.New("package.json is not an object")
+	}
+	before := strings.TrimRight(manif[:end], " \t\r\n")
+	sep := ","
+	if strings.HasSuffix(before, "{") {
+		sep = ""
+	}
+
+	return before + sep + "\n  \"" + field + "\": " + objText + "\n" + manif[end:], nil
+}
+
+// npmOverridesField returns the field of the package.json that overrides of transitive
+// dependencies are written to, which is "resolutions" for projects that use yarn
+func npmOverridesField(r depfile.DepFile, manif string) string {
+	if gjson.Get(manif, "overrides").Exists() {
+		return "overrides"
+	}
+
+	if gjson.Get(manif, "resolutions").Exists() || strings.HasPrefix(gjson.Get(manif, "packageManager").Str, "yarn@") {
+		return "resolutions"
+	}
+
+	if f, err := r.Open("yarn.lock"); err == nil {
+		f.Close()
+		return "resolutions"
+	}
+
+	return "overrides"
+}
+
+// gjsonEscape escapes the characters in a key that have special meaning in gjson & sjson paths
+func gjsonEscape(key string) string {
+	var b strings.Builder
+	for _, c := range key {
+		if strings.ContainsRune(`.*?|#@!\`, c) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
+
 // SplitNPMAlias extracts the real package name and version from an alias-specified version.
 //
 // e.g. "npm:pkg@^1.2.3" -> name: "pkg", version: "^1.2.3"
@@ -292,3 +292,126 @@ func TestNpmWrite(t *testing.T) {
 	}
 	testutility.NewSnapshot().WithCRLFReplacement().MatchText(t, buf.String())
 }
+
+func TestNpmReadOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		file string
+		want map[string]string
+	}{
+		{
+			name: "npm overrides",
+			file: "./fixtures/npm-overrides/package.json",
+			want: map[string]string{
+				"semver":          "^7.5.2",
+				"@babel/traverse": "7.23.2",
+			},
+		},
+		{
+			name: "yarn resolutions",
+			file: "./fixtures/yarn-berry/package.json",
+			want: map[string]string{
+				"minimist":    "^1.2.6",
+				"@types/node": "20.11.0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			df, err := depfile.OpenLocalDepFile(tt.file)
+			if err != nil {
+				t.Fatalf("failed to open file: %v", err)
+			}
+			defer df.Close()
+
+			got, err := manifest.NpmReadWriter{}.Read(df)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+
+			want := manifest.NpmManifestSpecific{Overrides: tt.want}
+			if !reflect.DeepEqual(got.EcosystemSpecific, want) {
+				t.Errorf("npm manifest overrides mismatch:\ngot %v\nwant %v\n", got.EcosystemSpecific, want)
+			}
+		})
+	}
+}
+
+func TestNpmWriteOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		file string
+	}{
+		{
+			name: "npm overrides",
+			file: "./fixtures/npm-overrides/package.json",
+		},
+		{
+			name: "yarn lockfile",
+			file: "./fixtures/yarn-resolutions/package.json",
+		},
+		{
+			name: "yarn resolutions",
+			file: "./fixtures/yarn-berry/package.json",
+		},
+		{
+			name: "no existing overrides",
+			file: "./fixtures/package.json",
+		},
+	}
+
+	// an empty original requirement signals that the patch is an override
+	changes := manifest.Patch{
+		Deps: []manifest.DependencyPatch{
+			{
+				Pkg: resolve.PackageKey{
+					System: resolve.NPM,
+					Name:   "semver",
+				},
+				OrigResolved: "7.5.1",
+				NewRequire:   "7.5.4",
+			},
+			{
+				Pkg: resolve.PackageKey{
+					System: resolve.NPM,
+					Name:   "@types/node",
+				},
+				OrigResolved: "20.10.0",
+				NewRequire:   "20.11.5",
+			},
+			{
+				Pkg: resolve.PackageKey{
+					System: resolve.NPM,
+					Name:   "minimist",
+				},
+				OrigResolved: "1.2.5",
+				NewRequire:   "1.2.8",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			df, err := depfile.OpenLocalDepFile(tt.file)
+			if err != nil {
+				t.Fatalf("failed to open file: %v", err)
+			}
+			defer df.Close()
+
+			buf := new(bytes.Buffer)
+			if err := (manifest.NpmReadWriter{}).Write(df, buf, changes); err != nil {
+				t.Fatalf("unable to update npm package.json: %v", err)
+			}
+			testutility.NewSnapshot().WithCRLFReplacement().MatchText(t, buf.String())
+		})
+	}
+}
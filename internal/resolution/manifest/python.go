@@ -0,0 +1,465 @@
+package manifest
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"deps.dev/util/pypi"
+	"deps.dev/util/resolve"
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/resolution/depfile"
+	pypiutil "github.com/google/osv-scanner/v2/internal/utility/pypi"
+)
+
+// PythonReadWriter reads and writes the dependencies of a pyproject.toml, from either the
+// standard [project] and [dependency-groups] tables (as used by uv), or the tables of Poetry.
+type PythonReadWriter struct{}
+
+// PythonManifestSpecific is the Python-specific information of a pyproject.toml
+type PythonManifestSpecific struct {
+	// Poetry is whether the dependencies of the project are managed by Poetry
+	Poetry bool
+}
+
+func (PythonReadWriter) System() resolve.System { return resolve.PyPI }
+
+type pyprojectTOML struct {
+	Project struct {
+		Name         string   `toml:"name"`
+		Version      string   `toml:"version"`
+		Dependencies []string `toml:"dependencies"`
+	} `toml:"project"`
+	// Groups can also include other groups with {include-group = "name"}, which is why the values are not necessarily strings
+	DependencyGroups map[string][]any `toml:"dependency-groups"`
+	Tool             struct {
+		Poetry *struct {
+			Name            string         `toml:"name"`
+			Version         string         `toml:"version"`
+			Dependencies    map[string]any `toml:"dependencies"`
+			DevDependencies map[string]any `toml:"dev-dependencies"`
+			Group           map[string]struct {
+				Dependencies map[string]any `toml:"dependencies"`
+			} `toml:"group"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+func (rw PythonReadWriter) Read(f depfile.DepFile) (Manifest, error) {
+	var pyproject pyprojectTOML
+	if _, err := toml.NewDecoder(f).Decode(&pyproject); err != nil {
+		return Manifest{}, err
+	}
+	poetry := pyproject.Tool.Poetry
+
+	name, version := pyproject.Project.Name, pyproject.Project.Version
+	if poetry != nil {
+		name = cmp.Or(name, poetry.Name)
+		version = cmp.Or(version, poetry.Version)
+	}
+
+	manif := newManifest()
+	manif.FilePath = f.Path()
+	manif.Root = resolve.Version{
+		VersionKey: resolve.VersionKey{
+			PackageKey: resolve.PackageKey{
+				Name:   name,
+				System: resolve.PyPI,
+			},
+			// The version can be dynamic, but the resolver needs one
+			Version:     cmp.Or(version, "0.0.0"),
+			VersionType: resolve.Concrete,
+		}}
+	manif.EcosystemSpecific = PythonManifestSpecific{Poetry: poetry != nil}
+
+	main := make(map[RequirementKey]bool)
+	addRequirement := func(req resolve.RequirementVersion, group string) {
+		key := MakeRequirementKey(req)
+		// TODO: requirements on a package in multiple places with different specifiers are all applied
+		// by the tools, but only the first one found is resolved and relaxed here
+		if !slices.ContainsFunc(manif.Requirements, func(r resolve.RequirementVersion) bool { return MakeRequirementKey(r) == key }) {
+			manif.Requirements = append(manif.Requirements, req)
+		}
+		if group == "" {
+			main[key] = true
+		} else if !slices.Contains(manif.Groups[key], group) {
+			manif.Groups[key] = append(manif.Groups[key], group)
+		}
+	}
+
+	for _, d := range pyproject.Project.Dependencies {
+		req, err := pypiutil.MakeRequirement(d)
+		if err != nil {
+			return Manifest{}, fmt.Errorf("invalid dependency %q: %w", d, err)
+		}
+		addRequirement(req, "")
+	}
+
+	for _, group := range slices.Sorted(maps.Keys(pyproject.DependencyGroups)) {
+		for _, d := range pyproject.DependencyGroups[group] {
+			s, ok := d.(string)
+			if !ok {
+				// included groups are read as groups of their own
+				continue
+			}
+			req, err := pypiutil.MakeRequirement(s)
+			if err != nil {
+				return Manifest{}, fmt.Errorf("invalid dependency %q: %w", s, err)
+			}
+			addRequirement(req, group)
+		}
+	}
+
+	if poetry != nil {
+		// Poetry uses the standard [project] dependencies over its own if they are both present
+		if len(pyproject.Project.Dependencies) == 0 {
+			for _, req := range poetryRequirements(poetry.Dependencies) {
+				addRequirement(req, "")
+			}
+		}
+		for _, req := range poetryRequirements(poetry.DevDependencies) {
+			addRequirement(req, "dev")
+		}
+		for _, group := range slices.Sorted(maps.Keys(poetry.Group)) {
+			for _, req := range poetryRequirements(poetry.Group[group].Dependencies) {
+				addRequirement(req, group)
+			}
+		}
+	}
+
+	// Requirements that are dependencies of the project itself are never only in a group
+	for key := range main {
+		delete(manif.Groups, key)
+	}
+
+	resolve.SortDependencies(manif.Requirements)
+
+	return manif, nil
+}
+
+// poetryRequirements returns the requirements of a table of Poetry dependencies, skipping the dependencies
+// that cannot be resolved from the package index e.g. path, git, and optional dependencies
+func poetryRequirements(deps map[string]any) []resolve.RequirementVersion {
+	var reqs []resolve.RequirementVersion
+	for name, value := range deps {
+		if name == "python" {
+			// The version of Python that the project supports
+			continue
+		}
+
+		var constraint, extras, markers string
+		switch v := value.(type) {
+		case string:
+			constraint = v
+		case map[string]any:
+			version, ok := v["version"].(string)
+			if !ok {
+				// not from the package index
+				continue
+			}
+			if optional, _ := v["optional"].(bool); optional {
+				// only installed with the extras of the project
+				continue
+			}
+			constraint = version
+			if es, ok := v["extras"].([]any); ok {
+				strs := make([]string, 0, len(es))
+				for _, e := range es {
+					if s, ok := e.(string); ok {
+						strs = append(strs, s)
+					}
+				}
+				extras = strings.Join(strs, ",")
+			}
+			markers, _ = v["markers"].(string)
+		default:
+			// TODO: multiple constraints dependencies, which are lists of tables
+			continue
+		}
+
+		pep440, ok := poetryToPEP440(constraint)
+		if !ok {
+			continue
+		}
+		reqs = append(reqs, pypiutil.NewRequirement(name, pep440, extras, markers))
+	}
+
+	return reqs
+}
+
+// poetryToPEP440 translates a Poetry version constraint to a PEP 440 version specifier,
+// returning false if it cannot be translated.
+// https://python-poetry.org/docs/dependency-specification/#version-constraints
+func poetryToPEP440(constraint string) (string, bool) {
+	constraint = strings.TrimSpace(constraint)
+	if constraint == "" || constraint == "*" {
+		return "", true
+	}
+	if strings.Contains(constraint, "||") {
+		// PEP 440 has no union of specifiers
+		return "", false
+	}
+
+	var specifiers []string
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "" || part == "*":
+			continue
+		case strings.HasPrefix(part, "^"):
+			lower := strings.TrimSpace(part[1:])
+			upper, ok := poetryUpperBound(lower, true)
+			if !ok {
+				return "", false
+			}
+			specifiers = append(specifiers, ">="+lower, "<"+upper)
+		case strings.HasPrefix(part, "~") && !strings.HasPrefix(part, "~="):
+			lower := strings.TrimSpace(part[1:])
+			upper, ok := poetryUpperBound(lower, false)
+			if !ok {
+				return "", false
+			}
+			specifiers = append(specifiers, ">="+lower, "<"+upper)
+		case part[0] >= '0' && part[0] <= '9':
+			specifiers = append(specifiers, "=="+part)
+		case part[0] == '=' && !strings.HasPrefix(part, "=="):
+			specifiers = append(specifiers, "=="+strings.TrimSpace(part[1:]))
+		default:
+			// a PEP 440 operator, with any space between it and the version removed
+			op := cachedregexp.MustCompile(`^(===|==|!=|~=|>=|<=|>|<)\s*`).FindString(part)
+			if op == "" {
+				return "", false
+			}
+			specifiers = append(specifiers, strings.TrimSpace(op)+part[len(op):])
+		}
+	}
+
+	return strings.Join(specifiers, ","), true
+}
+
+// poetryUpperBound returns the exclusive upper bound of a caret (^) or tilde (~) requirement on version.
+// Caret requirements allow updates that do not modify the left-most non-zero component,
+// while tilde requirements allow patch updates, or minor updates if only the major version is specified.
+func poetryUpperBound(version string, caret bool) (string, bool) {
+	release := cachedregexp.MustCompile(`^\d+(?:\.\d+)*`).FindString(version)
+	if release == "" {
+		return "", false
+	}
+
+	parts := strings.Split(release, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return "", false
+		}
+		nums[i] = n
+	}
+
+	bump := 0
+	if caret {
+		// the left-most non-zero component, or the last if they are all zero
+		bump = len(nums) - 1
+		for i, n := range nums {
+			if n != 0 {
+				bump = i
+				break
+			}
+		}
+	} else if len(nums) > 1 {
+		bump = 1
+	}
+
+	upper := make([]string, len(nums))
+	for i := range nums {
+		switch {
+		case i < bump:
+			upper[i] = strconv.Itoa(nums[i])
+		case i == bump:
+			upper[i] = strconv.Itoa(nums[i] + 1)
+		default:
+			upper[i] = "0"
+		}
+	}
+
+	return strings.Join(upper, "."), true
+}
+
+func (PythonReadWriter) Write(r depfile.DepFile, w io.Writer, patch Patch) error {
+	var buf strings.Builder
+	if _, err := io.Copy(&buf, r); err != nil {
+		return err
+	}
+
+	patches := make(map[string]DependencyPatch)
+	for _, p := range patch.Deps {
+		patches[pypi.CanonPackageName(p.Pkg.Name)] = p
+	}
+
+	// pyproject.toml is edited line by line rather than re-encoded, to keep its formatting and comments
+	lines := strings.SplitAfter(buf.String(), "\n")
+	table := ""
+	inArray := false
+	for i, line := range lines {
+		if inArray {
+			lines[i], inArray = patchPEP508Array(line, patches)
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			table = tomlTableName(trimmed)
+			continue
+		}
+
+		if isPEP508Table(table) {
+			if loc := cachedregexp.MustCompile(`^\s*["']?([A-Za-z0-9_.-]+)["']?\s*=\s*\[`).FindStringSubmatchIndex(line); loc != nil {
+				key := line[loc[2]:loc[3]]
+				if table != "project" || key == "dependencies" {
+					var rest string
+					rest, inArray = patchPEP508Array(line[loc[1]:], patches)
+					lines[i] = line[:loc[1]] + rest
+				}
+			}
+
+			continue
+		}
+
+		if isPoetryDependencyTable(table) {
+			lines[i] = patchPoetryDependency(line, patches)
+		}
+	}
+
+	_, err := io.WriteString(w, strings.Join(lines, ""))
+
+	return err
+}
+
+// tomlTableName returns the name of the table from its header line e.g. [tool.poetry."group".dev.dependencies]
+func tomlTableName(header string) string {
+	if end := strings.LastIndex(header, "]"); end >= 0 {
+		header = header[:end]
+	}
+	header = strings.Trim(header, "[] \t")
+
+	parts := strings.Split(header, ".")
+	for i, p := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(p), `"'`)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// isPEP508Table returns if the table contains arrays of PEP 508 requirement strings
+func isPEP508Table(table string) bool {
+	return table == "project" || table == "project.optional-dependencies" || table == "dependency-groups"
+}
+
+// isPoetryDependencyTable returns if the table maps package names to Poetry dependency specifications
+func isPoetryDependencyTable(table string) bool {
+	if table == "tool.poetry.dependencies" || table == "tool.poetry.dev-dependencies" {
+		return true
+	}
+	group, ok := strings.CutPrefix(table, "tool.poetry.group.")
+
+	return ok && strings.HasSuffix(group, ".dependencies") && strings.Count(group, ".") == 1
+}
+
+// patchPEP508Array applies the patches to the requirement strings of an array that starts on or before the line,
+// returning the patched line and if the array continues onto the next line
+func patchPEP508Array(line string, patches map[string]DependencyPatch) (string, bool) {
+	var sb strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; c {
+		case '"', '\'':
+			end := tomlStringEnd(line, i)
+			sb.WriteByte(c)
+			sb.WriteString(patchPEP508String(line[i+1:end], patches))
+			if end < len(line) {
+				sb.WriteByte(c)
+			}
+			i = end
+		case '#':
+			sb.WriteString(line[i:])
+			return sb.String(), true
+		case ']':
+			sb.WriteString(line[i:])
+			return sb.String(), false
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), true
+}
+
+// tomlStringEnd returns the index of the closing quote of the basic or literal string that starts at start
+func tomlStringEnd(line string, start int) int {
+	quote := line[start]
+	for i := start + 1; i < len(line); i++ {
+		if quote == '"' && line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == quote {
+			return i
+		}
+	}
+
+	return len(line)
+}
+
+func patchPEP508String(s string, patches map[string]DependencyPatch) string {
+	d, err := pypi.ParseDependency(s)
+	if err != nil {
+		return s
+	}
+	p, ok := patches[d.Name]
+	if !ok || d.Constraint == "" || d.Constraint != p.OrigRequire {
+		return s
+	}
+
+	return strings.Replace(s, d.Constraint, p.NewRequire, 1)
+}
+
+// patchPoetryDependency applies the patches to a line of a table of Poetry dependencies, which are either in the form of
+//
+//	name = "constraint"
+//	name = { version = "constraint", ... }
+func patchPoetryDependency(line string, patches map[string]DependencyPatch) string {
+	m := cachedregexp.MustCompile(`^\s*["']?([A-Za-z0-9_.-]+)["']?\s*=\s*`).FindStringSubmatchIndex(line)
+	if m == nil {
+		return line
+	}
+	p, ok := patches[pypi.CanonPackageName(line[m[2]:m[3]])]
+	if !ok {
+		return line
+	}
+
+	start := m[1]
+	if strings.HasPrefix(line[start:], "{") {
+		v := cachedregexp.MustCompile(`\bversion\s*=\s*`).FindStringIndex(line[start:])
+		if v == nil {
+			return line
+		}
+		start += v[1]
+	}
+
+	if start >= len(line) || (line[start] != '"' && line[start] != '\'') {
+		return line
+	}
+	end := tomlStringEnd(line, start)
+	if end >= len(line) {
+		return line
+	}
+	if pep440, ok := poetryToPEP440(line[start+1 : end]); !ok || pep440 != p.OrigRequire {
+		return line
+	}
+
+	return line[:start+1] + p.NewRequire + line[end:]
+}
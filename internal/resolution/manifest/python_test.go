@@ -0,0 +1,198 @@
+package manifest_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"deps.dev/util/resolve"
+	"deps.dev/util/resolve/dep"
+	"github.com/google/osv-scanner/v2/internal/resolution/depfile"
+	"github.com/google/osv-scanner/v2/internal/resolution/manifest"
+	"github.com/google/osv-scanner/v2/internal/testutility"
+)
+
+func pypiReq(t *testing.T, name, constraint, extras, environment string) resolve.RequirementVersion {
+	t.Helper()
+	typ := dep.NewType()
+	if environment != "" {
+		typ.AddAttr(dep.Environment, environment)
+	}
+	if extras != "" {
+		typ.AddAttr(dep.EnabledDependencies, extras)
+	}
+
+	return resolve.RequirementVersion{
+		Type: typ,
+		VersionKey: resolve.VersionKey{
+			PackageKey: resolve.PackageKey{
+				System: resolve.PyPI,
+				Name:   name,
+			},
+			Version:     constraint,
+			VersionType: resolve.Requirement,
+		},
+	}
+}
+
+func pypiReqKey(t *testing.T, name string) manifest.RequirementKey {
+	t.Helper()
+	return manifest.MakeRequirementKey(pypiReq(t, name, "", "", ""))
+}
+
+func pypiRoot(t *testing.T, name, version string) resolve.Version {
+	t.Helper()
+	return resolve.Version{
+		VersionKey: resolve.VersionKey{
+			PackageKey: resolve.PackageKey{
+				System: resolve.PyPI,
+				Name:   name,
+			},
+			Version:     version,
+			VersionType: resolve.Concrete,
+		},
+	}
+}
+
+func TestPythonRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		file string
+		want manifest.Manifest
+	}{
+		{
+			name: "uv",
+			file: "./fixtures/python-uv/pyproject.toml",
+			want: manifest.Manifest{
+				Root: pypiRoot(t, "uv-project", "0.1.0"),
+				Requirements: []resolve.RequirementVersion{
+					pypiReq(t, "requests", ">=2.25.0,<3", "", ""),
+					pypiReq(t, "flask", "==2.2.2", "async", ""),
+					pypiReq(t, "urllib3", ">=1.26.0", "", `python_version >= "3.10"`),
+					pypiReq(t, "click", "", "", ""),
+					pypiReq(t, "pytest", "~=7.4.0", "", ""),
+					pypiReq(t, "ruff", "==0.1.0", "", ""),
+				},
+				Groups: map[manifest.RequirementKey][]string{
+					pypiReqKey(t, "pytest"): {"dev"},
+					pypiReqKey(t, "ruff"):   {"lint"},
+				},
+				EcosystemSpecific: manifest.PythonManifestSpecific{Poetry: false},
+			},
+		},
+		{
+			name: "poetry",
+			file: "./fixtures/python-poetry/pyproject.toml",
+			want: manifest.Manifest{
+				Root: pypiRoot(t, "poetry-project", "1.2.3"),
+				Requirements: []resolve.RequirementVersion{
+					pypiReq(t, "requests", ">=2.25.1,<3.0.0", "", ""),
+					pypiReq(t, "django", ">=4.1,<4.2", "", ""),
+					pypiReq(t, "jinja2", "==2.11.2", "i18n", ""),
+					pypiReq(t, "numpy", ">=1.21,<2", "", "platform_machine == 'x86_64'"),
+					pypiReq(t, "ruamel-yaml", "", "", ""),
+					pypiReq(t, "black", "==22.3.0", "", ""),
+					pypiReq(t, "pytest", ">=7.0,<8.0", "", ""),
+				},
+				Groups: map[manifest.RequirementKey][]string{
+					pypiReqKey(t, "black"):  {"dev"},
+					pypiReqKey(t, "pytest"): {"test"},
+				},
+				EcosystemSpecific: manifest.PythonManifestSpecific{Poetry: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			df, err := depfile.OpenLocalDepFile(tt.file)
+			if err != nil {
+				t.Fatalf("failed to open file: %v", err)
+			}
+			defer df.Close()
+
+			pythonRW := manifest.PythonReadWriter{}
+			got, err := pythonRW.Read(df)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+			if !strings.HasSuffix(got.FilePath, "pyproject.toml") {
+				t.Errorf("manifest file path %v does not have pyproject.toml", got.FilePath)
+			}
+			got.FilePath = ""
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("python manifest mismatch:\ngot %v\nwant %v\n", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPythonWrite(t *testing.T) {
+	t.Parallel()
+
+	pypiPatch := func(name, orig, updated string) manifest.DependencyPatch {
+		return manifest.DependencyPatch{
+			Pkg: resolve.PackageKey{
+				System: resolve.PyPI,
+				Name:   name,
+			},
+			OrigRequire: orig,
+			NewRequire:  updated,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		patches []manifest.DependencyPatch
+	}{
+		{
+			name: "uv",
+			file: "./fixtures/python-uv/pyproject.toml",
+			patches: []manifest.DependencyPatch{
+				pypiPatch("requests", ">=2.25.0,<3", ">=2.32.3,<3"),
+				pypiPatch("flask", "==2.2.2", ">=2.2.5,<2.3"),
+				pypiPatch("urllib3", ">=1.26.0", ">=1.26.19,<2"),
+				pypiPatch("pytest", "~=7.4.0", ">=8.3.3,<9"),
+				// does not match the requirement in the file
+				pypiPatch("ruff", "==0.2.0", ">=0.3.0,<1"),
+			},
+		},
+		{
+			name: "poetry",
+			file: "./fixtures/python-poetry/pyproject.toml",
+			patches: []manifest.DependencyPatch{
+				pypiPatch("requests", ">=2.25.1,<3.0.0", ">=2.32.3,<3"),
+				pypiPatch("django", ">=4.1,<4.2", ">=4.2.16,<5"),
+				pypiPatch("jinja2", "==2.11.2", ">=2.11.3,<2.12"),
+				pypiPatch("numpy", ">=1.21,<2", ">=1.22.0,<2"),
+				pypiPatch("black", "==22.3.0", ">=24.3.0,<25"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			df, err := depfile.OpenLocalDepFile(tt.file)
+			if err != nil {
+				t.Fatalf("failed to open file: %v", err)
+			}
+			defer df.Close()
+
+			buf := new(bytes.Buffer)
+			pythonRW := manifest.PythonReadWriter{}
+			if err := pythonRW.Write(df, buf, manifest.Patch{Deps: tt.patches}); err != nil {
+				t.Fatalf("unable to update pyproject.toml: %v", err)
+			}
+			testutility.NewSnapshot().WithCRLFReplacement().MatchText(t, buf.String())
+		})
+	}
+}
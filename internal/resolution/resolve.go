@@ -11,6 +11,7 @@ import (
 	"deps.dev/util/resolve/dep"
 	"deps.dev/util/resolve/maven"
 	"deps.dev/util/resolve/npm"
+	"deps.dev/util/resolve/pypi"
 	"github.com/google/osv-scanner/v2/internal/resolution/client"
 	"github.com/google/osv-scanner/v2/internal/resolution/manifest"
 	mavenutil "github.com/google/osv-scanner/v2/internal/utility/maven"
@@ -68,6 +69,8 @@ func getResolver(sys resolve.System, cl resolve.Client) (resolve.Resolver, error
 		return npm.NewResolver(cl), nil
 	case resolve.Maven:
 		return maven.NewResolver(cl), nil
+	case resolve.PyPI:
+		return pypi.NewResolver(cl), nil
 	default:
 		return nil, fmt.Errorf("no resolver for ecosystem %v", sys)
 	}
@@ -78,12 +81,40 @@ type ResolveOpts struct {
 }
 
 func Resolve(ctx context.Context, cl client.ResolutionClient, m manifest.Manifest, opts ResolveOpts) (*Result, error) {
+	graph, err := ResolveGraph(ctx, cl, m, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Manifest: m.Clone(),
+		Graph:    graph,
+	}
+
+	if err := result.computeVulns(ctx, cl); err != nil {
+		return nil, err
+	}
+
+	// Make a copy of the found vulns, as `Vulns` may be filtered according to specified criteria.
+	result.UnfilteredVulns = slices.Clone(result.Vulns)
+
+	return result, nil
+}
+
+// ResolveGraph resolves the dependency graph of the manifest, without computing its vulnerabilities,
+// so the client does not need a VulnerabilityMatcher.
+func ResolveGraph(ctx context.Context, cl client.ResolutionClient, m manifest.Manifest, opts ResolveOpts) (*resolve.Graph, error) {
 	c := client.NewOverrideClient(cl.DependencyClient)
 	c.AddVersion(m.Root, m.Requirements)
 	for _, loc := range m.LocalManifests {
 		c.AddVersion(loc.Root, loc.Requirements)
 		// TODO: may need to do this recursively
 	}
+	if specific, ok := m.EcosystemSpecific.(manifest.NpmManifestSpecific); ok {
+		for name, requirement := range specific.Overrides {
+			c.AddOverride(resolve.PackageKey{System: resolve.NPM, Name: name}, requirement)
+		}
+	}
 	cl.DependencyClient = c
 	r, err := getResolver(m.System(), cl.DependencyClient)
 	if err != nil {
@@ -103,19 +134,7 @@ func Resolve(ctx context.Context, cl client.ResolutionClient, m manifest.Manifes
 		return nil, errors.New(graph.Error)
 	}
 
-	result := &Result{
-		Manifest: m.Clone(),
-		Graph:    graph,
-	}
-
-	if err := result.computeVulns(ctx, cl); err != nil {
-		return nil, err
-	}
-
-	// Make a copy of the found vulns, as `Vulns` may be filtered according to specified criteria.
-	result.UnfilteredVulns = slices.Clone(result.Vulns)
-
-	return result, nil
+	return graph, nil
 }
 
 func resolvePostProcess(ctx context.Context, cl client.ResolutionClient, m manifest.Manifest, opts ResolveOpts, graph *resolve.Graph) (*resolve.Graph, error) {
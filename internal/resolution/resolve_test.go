@@ -195,6 +195,23 @@ func TestResolve(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "pypi", // PyPI dependencies, with a vulnerable dependency only in a group
+			version:  "0.1.0",
+			system:   resolve.PyPI,
+			universe: "./fixtures/pypi-universe.yaml",
+			requirements: []requirement{
+				{
+					name:    "dependency",
+					version: "<2",
+				},
+				{
+					name:    "tool",
+					version: "==1.0.0",
+					groups:  []string{"dev"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -12,11 +12,13 @@ import (
 var OSVEcosystem = map[resolve.System]osvschema.Ecosystem{
 	resolve.NPM:   osvschema.EcosystemNPM,
 	resolve.Maven: osvschema.EcosystemMaven,
+	resolve.PyPI:  osvschema.EcosystemPyPI,
 }
 
 var PURLType = map[resolve.System]string{
 	resolve.NPM:   purl.TypeNPM,
 	resolve.Maven: purl.TypeMaven,
+	resolve.PyPI:  purl.TypePyPi,
 }
 
 func VKToPackageInfo(vk resolve.VersionKey) imodels.PackageInfo {
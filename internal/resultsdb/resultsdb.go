@@ -0,0 +1,233 @@
+// Package resultsdb persists scan results into a SQLite database, so that the
+// results of many scans can be queried over time.
+package resultsdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+
+	// Registers the pure-go "sqlite" driver
+	_ "modernc.org/sqlite"
+)
+
+// SchemaVersion is the version of the database schema, which is stored in the
+// user_version pragma. It must be bumped whenever the schema changes.
+//
+// Version 2 added the scan_labels table, which databases of version 1 are
+// migrated to by creating it, as no existing tables were changed.
+const SchemaVersion = 2
+
+const schema = `
+CREATE TABLE IF NOT EXISTS scans (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	scanned_at TEXT NOT NULL,
+	scanner_version TEXT NOT NULL,
+	targets TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS packages (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	scan_id INTEGER NOT NULL REFERENCES scans(id),
+	source_path TEXT NOT NULL,
+	source_type TEXT NOT NULL,
+	ecosystem TEXT NOT NULL,
+	name TEXT NOT NULL,
+	version TEXT NOT NULL,
+	"commit" TEXT NOT NULL,
+	licenses TEXT NOT NULL,
+	license_violations TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS vulnerabilities (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	scan_id INTEGER NOT NULL REFERENCES scans(id),
+	package_id INTEGER NOT NULL REFERENCES packages(id),
+	vuln_id TEXT NOT NULL,
+	aliases TEXT NOT NULL,
+	summary TEXT NOT NULL,
+	max_severity TEXT NOT NULL,
+	called INTEGER NOT NULL,
+	published TEXT NOT NULL,
+	modified TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS scan_labels (
+	scan_id INTEGER NOT NULL REFERENCES scans(id),
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (scan_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS packages_scan_id ON packages(scan_id);
+CREATE INDEX IF NOT EXISTS vulnerabilities_scan_id ON vulnerabilities(scan_id);
+CREATE INDEX IF NOT EXISTS vulnerabilities_vuln_id ON vulnerabilities(vuln_id);
+`
+
+// ScanMetadata describes the scan which produced a set of results
+type ScanMetadata struct {
+	ScannedAt      time.Time
+	ScannerVersion string
+	// Targets are the paths or images that were scanned
+	Targets []string
+}
+
+// Append opens (creating if needed) the SQLite database at dbPath and appends
+// the given results to it as a new scan.
+func Append(ctx context.Context, dbPath string, meta ScanMetadata, vulnResult *models.VulnerabilityResults) error {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open results database: %w", err)
+	}
+	defer db.Close()
+
+	if err := migrate(ctx, db); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // this is a no-op once committed
+
+	if err := insertScan(ctx, tx, meta, vulnResult); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// migrate creates the schema if the database is new or was created with an older
+// version of the schema, and errors if it was created with a newer version
+func migrate(ctx context.Context, db *sql.DB) error {
+	var version int
+	if err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read results database version: %w", err)
+	}
+
+	// every version so far has only added tables, which the schema creates if they do not exist
+	if version > SchemaVersion {
+		return fmt.Errorf("results database has unsupported schema version %d (expected %d)", version, SchemaVersion)
+	}
+
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return fmt.Errorf("failed to create results database schema: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion)); err != nil {
+		return fmt.Errorf("failed to set results database version: %w", err)
+	}
+
+	return nil
+}
+
+func insertScan(ctx context.Context, tx *sql.Tx, meta ScanMetadata, vulnResult *models.VulnerabilityResults) error {
+	res, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO scans (scanned_at, scanner_version, targets) VALUES (?, ?, ?)",
+		meta.ScannedAt.UTC().Format(time.RFC3339),
+		meta.ScannerVersion,
+		strings.Join(meta.Targets, "\n"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert scan: %w", err)
+	}
+
+	scanID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range vulnResult.Labels {
+		_, err := tx.ExecContext(ctx, "INSERT INTO scan_labels (scan_id, key, value) VALUES (?, ?, ?)", scanID, key, value)
+		if err != nil {
+			return fmt.Errorf("failed to insert scan label: %w", err)
+		}
+	}
+
+	for _, source := range vulnResult.Results {
+		for _, pkg := range source.Packages {
+			if err := insertPackage(ctx, tx, scanID, source.Source, pkg); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func insertPackage(ctx context.Context, tx *sql.Tx, scanID int64, source models.SourceInfo, pkg models.PackageVulns) error {
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO packages (scan_id, source_path, source_type, ecosystem, name, version, "commit", licenses, license_violations)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		scanID,
+		source.Path,
+		string(source.Type),
+		pkg.Package.Ecosystem,
+		pkg.Package.Name,
+		pkg.Package.Version,
+		pkg.Package.Commit,
+		joinLicenses(pkg.Licenses),
+		joinLicenses(pkg.LicenseViolations),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert package: %w", err)
+	}
+
+	packageID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, group := range pkg.Groups {
+		for _, vuln := range pkg.Vulnerabilities {
+			if !slices.Contains(group.IDs, vuln.ID) {
+				continue
+			}
+
+			_, err := tx.ExecContext(
+				ctx,
+				`INSERT INTO vulnerabilities (scan_id, package_id, vuln_id, aliases, summary, max_severity, called, published, modified)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				scanID,
+				packageID,
+				vuln.ID,
+				strings.Join(group.Aliases, ","),
+				vuln.Summary,
+				group.MaxSeverity,
+				group.IsCalled(),
+				formatTime(vuln.Published),
+				formatTime(vuln.Modified),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert vulnerability: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func joinLicenses(licenses []models.License) string {
+	ls := make([]string, len(licenses))
+	for i, l := range licenses {
+		ls[i] = string(l)
+	}
+
+	return strings.Join(ls, ",")
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
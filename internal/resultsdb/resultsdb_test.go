@@ -0,0 +1,163 @@
+package resultsdb_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/resultsdb"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+		t.Fatalf("failed to count rows in %s: %v", table, err)
+	}
+
+	return count
+}
+
+func TestAppend(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "results.sqlite")
+
+	vulnResult := &models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+						Vulnerabilities: []osvschema.Vulnerability{
+							{ID: "GHSA-35jh-r3h4-6jhm", Summary: "Command Injection in lodash"},
+							{ID: "GHSA-29mw-wpgm-hmr9", Summary: "ReDoS in lodash"},
+						},
+						Groups: []models.GroupInfo{
+							{IDs: []string{"GHSA-35jh-r3h4-6jhm"}, Aliases: []string{"CVE-2021-23337", "GHSA-35jh-r3h4-6jhm"}, MaxSeverity: "7.2"},
+							{IDs: []string{"GHSA-29mw-wpgm-hmr9"}, Aliases: []string{"CVE-2020-28500", "GHSA-29mw-wpgm-hmr9"}, MaxSeverity: "5.3"},
+						},
+						Licenses: []models.License{"MIT"},
+					},
+					{
+						Package:  models.PackageInfo{Name: "ms", Version: "2.1.3", Ecosystem: "npm"},
+						Licenses: []models.License{"MIT"},
+					},
+				},
+			},
+		},
+		Labels: map[string]string{"env": "prod", "service": "payments"},
+	}
+
+	meta := resultsdb.ScanMetadata{
+		ScannedAt:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		ScannerVersion: "2.0.0",
+		Targets:        []string{"/path/to"},
+	}
+
+	// appending twice should result in two scans within the same database
+	for range 2 {
+		if err := resultsdb.Append(t.Context(), dbPath, meta, vulnResult); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	if got := countRows(t, db, "scans"); got != 2 {
+		t.Errorf("expected 2 scans, got %d", got)
+	}
+	if got := countRows(t, db, "packages"); got != 4 {
+		t.Errorf("expected 4 packages, got %d", got)
+	}
+	if got := countRows(t, db, "vulnerabilities"); got != 4 {
+		t.Errorf("expected 4 vulnerabilities, got %d", got)
+	}
+	if got := countRows(t, db, "scan_labels"); got != 4 {
+		t.Errorf("expected 4 scan labels, got %d", got)
+	}
+
+	var aliases, severity string
+	err = db.QueryRow(
+		"SELECT aliases, max_severity FROM vulnerabilities WHERE vuln_id = ? AND scan_id = 2",
+		"GHSA-35jh-r3h4-6jhm",
+	).Scan(&aliases, &severity)
+	if err != nil {
+		t.Fatalf("failed to query vulnerability: %v", err)
+	}
+
+	if aliases != "CVE-2021-23337,GHSA-35jh-r3h4-6jhm" || severity != "7.2" {
+		t.Errorf("unexpected vulnerability row: aliases = %q, max_severity = %q", aliases, severity)
+	}
+}
+
+func TestAppend_UnsupportedSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "results.sqlite")
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	if _, err := db.Exec("PRAGMA user_version = 999"); err != nil {
+		t.Fatalf("failed to set version: %v", err)
+	}
+	db.Close()
+
+	err = resultsdb.Append(t.Context(), dbPath, resultsdb.ScanMetadata{}, &models.VulnerabilityResults{})
+	if err == nil {
+		t.Errorf("expected an error when appending to a database with an unsupported schema")
+	}
+}
+
+func TestAppend_MigratesOlderSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "results.sqlite")
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	// the schema of version 1, which did not have the scan_labels table
+	_, err = db.Exec(`
+		CREATE TABLE scans (id INTEGER PRIMARY KEY AUTOINCREMENT, scanned_at TEXT NOT NULL, scanner_version TEXT NOT NULL, targets TEXT NOT NULL);
+		PRAGMA user_version = 1;
+	`)
+	if err != nil {
+		t.Fatalf("failed to create version 1 schema: %v", err)
+	}
+
+	vulnResult := &models.VulnerabilityResults{Labels: map[string]string{"env": "prod"}}
+	if err := resultsdb.Append(t.Context(), dbPath, resultsdb.ScanMetadata{}, vulnResult); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatalf("failed to read version: %v", err)
+	}
+	if version != resultsdb.SchemaVersion {
+		t.Errorf("expected version %d, got %d", resultsdb.SchemaVersion, version)
+	}
+
+	var value string
+	if err := db.QueryRow("SELECT value FROM scan_labels WHERE key = 'env'").Scan(&value); err != nil {
+		t.Fatalf("failed to query scan label: %v", err)
+	}
+	if value != "prod" {
+		t.Errorf("expected label value %q, got %q", "prod", value)
+	}
+}
@@ -0,0 +1,282 @@
+// Package sbomvalidate checks SBOMs for problems that prevent their components
+// from being scanned, such as components without a purl or a version.
+package sbomvalidate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/detect"
+	"github.com/google/osv-scanner/v2/internal/utility/purl"
+	spdxjson "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/rdf"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/tagvalue"
+	spdxyaml "github.com/spdx/tools-golang/yaml"
+)
+
+// ErrIssuesFound is returned by the validate command when the SBOM has issues.
+var ErrIssuesFound = errors.New("SBOM has components that cannot be scanned")
+
+// ErrUnsupportedFormat is returned when the file is neither named like a supported SBOM
+// nor has content that can be recognized as one.
+var ErrUnsupportedFormat = errors.New("unsupported SBOM format")
+
+// IssueKind is the type of problem found with a component.
+type IssueKind string
+
+const (
+	// IssueMissingPURL is for components without a package URL, which are skipped during scanning.
+	IssueMissingPURL IssueKind = "missing-purl"
+	// IssueInvalidPURL is for components with a package URL that cannot be parsed.
+	IssueInvalidPURL IssueKind = "invalid-purl"
+	// IssueUnknownEcosystem is for components with a package URL type that does not map to an OSV ecosystem.
+	IssueUnknownEcosystem IssueKind = "unknown-ecosystem"
+	// IssueUnversioned is for components with a package URL that does not include a version.
+	IssueUnversioned IssueKind = "unversioned"
+	// IssueDuplicateRef is for bom-refs (or SPDX identifiers) used by more than one component.
+	IssueDuplicateRef IssueKind = "duplicate-ref"
+)
+
+// Issue is a problem with a single component, or a reference shared by several components.
+type Issue struct {
+	Kind      IssueKind `json:"kind"`
+	Ref       string    `json:"ref,omitempty"`
+	Component string    `json:"component,omitempty"`
+	Message   string    `json:"message"`
+}
+
+// Report is the result of validating an SBOM.
+type Report struct {
+	Path       string  `json:"path"`
+	Format     string  `json:"format"`
+	Components int     `json:"components"`
+	Scannable  int     `json:"scannable"`
+	Issues     []Issue `json:"issues"`
+}
+
+// component is the subset of an SBOM component that is needed for validation,
+// common to both CycloneDX and SPDX
+type component struct {
+	Ref     string
+	Name    string
+	Version string
+	PURL    string
+}
+
+func (c component) String() string {
+	if c.Name == "" {
+		return c.Ref
+	}
+	if c.Version == "" {
+		return c.Name
+	}
+
+	return c.Name + "@" + c.Version
+}
+
+// Validate parses the SBOM at path and reports any components that will not be
+// scanned as expected. The format of the SBOM is determined from its file name,
+// using the same conventions as scanning, or from its content if it is not named
+// as the specification of its format recommends.
+func Validate(path string) (Report, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Report{}, err
+	}
+
+	format, parse, ok := findParser(path)
+	if !ok {
+		if serialization, detected := detect.Detect(content); detected {
+			format, parse, ok = findParser("sbom" + serialization.Extension)
+		}
+	}
+	if !ok {
+		return Report{}, fmt.Errorf("%w: %s", ErrUnsupportedFormat, path)
+	}
+
+	components, err := parse(bytes.NewReader(content))
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	report := Report{
+		Path:       path,
+		Format:     format,
+		Components: len(components),
+		Issues:     []Issue{},
+	}
+
+	for _, c := range components {
+		issue, ok := checkComponent(c)
+		if !ok {
+			report.Issues = append(report.Issues, issue)
+			continue
+		}
+		report.Scannable++
+	}
+
+	report.Issues = append(report.Issues, checkDuplicateRefs(components)...)
+
+	return report, nil
+}
+
+// checkComponent returns the issue that will stop a component from being
+// scanned, or false if it can be scanned
+func checkComponent(c component) (Issue, bool) {
+	issue := Issue{Ref: c.Ref, Component: c.String()}
+
+	if c.PURL == "" {
+		issue.Kind = IssueMissingPURL
+		issue.Message = "component has no purl, so it will not be scanned"
+
+		return issue, false
+	}
+
+	pkg, err := purl.ToPackage(c.PURL)
+	if err != nil {
+		issue.Kind = IssueInvalidPURL
+		issue.Message = fmt.Sprintf("purl %q is invalid: %v", c.PURL, err)
+
+		return issue, false
+	}
+
+	if pkg.Ecosystem == "" {
+		issue.Kind = IssueUnknownEcosystem
+		issue.Message = fmt.Sprintf("purl %q is not for a known ecosystem", c.PURL)
+
+		return issue, false
+	}
+
+	if pkg.Version == "" {
+		issue.Kind = IssueUnversioned
+		issue.Message = fmt.Sprintf("purl %q has no version", c.PURL)
+		if c.Version != "" {
+			issue.Message += fmt.Sprintf(", the component version %q is not used for scanning", c.Version)
+		}
+
+		return issue, false
+	}
+
+	return Issue{}, true
+}
+
+func checkDuplicateRefs(components []component) []Issue {
+	counts := make(map[string]int)
+	for _, c := range components {
+		if c.Ref != "" {
+			counts[c.Ref]++
+		}
+	}
+
+	issues := []Issue{}
+	for ref, count := range counts {
+		if count > 1 {
+			issues = append(issues, Issue{
+				Kind:    IssueDuplicateRef,
+				Ref:     ref,
+				Message: fmt.Sprintf("%q is used by %d components", ref, count),
+			})
+		}
+	}
+
+	slices.SortFunc(issues, func(a, b Issue) int {
+		return strings.Compare(a.Ref, b.Ref)
+	})
+
+	return issues
+}
+
+type parseFunc = func(io.Reader) ([]component, error)
+
+// findParser matches the file names supported by the sbom/cdx, sbom/spdx, and sbom/detect extractors
+func findParser(path string) (string, parseFunc, bool) {
+	path = strings.ToLower(filepath.ToSlash(path))
+	base := filepath.Base(path)
+
+	switch {
+	case strings.HasSuffix(path, ".cdx.json") || base == "bom.json":
+		return "CycloneDX", parseCycloneDX(cyclonedx.BOMFileFormatJSON), true
+	case strings.HasSuffix(path, ".cdx.xml") || base == "bom.xml":
+		return "CycloneDX", parseCycloneDX(cyclonedx.BOMFileFormatXML), true
+	case strings.HasSuffix(path, ".spdx.json"):
+		return "SPDX", parseSPDX(spdxjson.Read), true
+	case strings.HasSuffix(path, ".spdx"):
+		return "SPDX", parseSPDX(tagvalue.Read), true
+	case strings.HasSuffix(path, ".spdx.yml") || strings.HasSuffix(path, ".spdx.yaml"):
+		return "SPDX", parseSPDX(spdxyaml.Read), true
+	case strings.HasSuffix(path, ".spdx.rdf") || strings.HasSuffix(path, ".spdx.rdf.xml"):
+		return "SPDX", parseSPDX(rdf.Read), true
+	}
+
+	return "", nil, false
+}
+
+func parseCycloneDX(format cyclonedx.BOMFileFormat) parseFunc {
+	return func(r io.Reader) ([]component, error) {
+		var bom cyclonedx.BOM
+		if err := cyclonedx.NewBOMDecoder(r, format).Decode(&bom); err != nil {
+			return nil, err
+		}
+
+		components := []component{}
+		if bom.Components != nil {
+			components = enumerateCycloneDX(*bom.Components, components)
+		}
+
+		return components, nil
+	}
+}
+
+func enumerateCycloneDX(cdxComponents []cyclonedx.Component, components []component) []component {
+	for _, c := range cdxComponents {
+		components = append(components, component{
+			Ref:     c.BOMRef,
+			Name:    c.Name,
+			Version: c.Version,
+			PURL:    c.PackageURL,
+		})
+
+		if c.Components != nil {
+			components = enumerateCycloneDX(*c.Components, components)
+		}
+	}
+
+	return components
+}
+
+func parseSPDX(read func(io.Reader) (*spdx.Document, error)) parseFunc {
+	return func(r io.Reader) ([]component, error) {
+		doc, err := read(r)
+		if err != nil {
+			return nil, err
+		}
+
+		components := make([]component, 0, len(doc.Packages))
+		for _, pkg := range doc.Packages {
+			c := component{
+				Ref:     string(pkg.PackageSPDXIdentifier),
+				Name:    pkg.PackageName,
+				Version: pkg.PackageVersion,
+			}
+
+			for _, ref := range pkg.PackageExternalReferences {
+				if ref.RefType == "purl" || ref.RefType == "http://spdx.org/rdf/references/purl" {
+					c.PURL = ref.Locator
+					break
+				}
+			}
+
+			components = append(components, c)
+		}
+
+		return components, nil
+	}
+}
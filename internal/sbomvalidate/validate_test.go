@@ -0,0 +1,143 @@
+package sbomvalidate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/sbomvalidate"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want sbomvalidate.Report
+	}{
+		{
+			name: "valid_cyclonedx",
+			path: "fixtures/valid.cdx.json",
+			want: sbomvalidate.Report{
+				Path:       "fixtures/valid.cdx.json",
+				Format:     "CycloneDX",
+				Components: 2,
+				Scannable:  2,
+				Issues:     []sbomvalidate.Issue{},
+			},
+		},
+		{
+			name: "cyclonedx_with_issues",
+			path: "fixtures/issues.cdx.json",
+			want: sbomvalidate.Report{
+				Path:       "fixtures/issues.cdx.json",
+				Format:     "CycloneDX",
+				Components: 7,
+				Scannable:  3,
+				Issues: []sbomvalidate.Issue{
+					{
+						Kind:      sbomvalidate.IssueMissingPURL,
+						Ref:       "internal-lib",
+						Component: "internal-lib@1.0.0",
+						Message:   "component has no purl, so it will not be scanned",
+					},
+					{
+						Kind:      sbomvalidate.IssueUnversioned,
+						Ref:       "left-pad",
+						Component: "left-pad@1.3.0",
+						Message:   `purl "pkg:npm/left-pad" has no version, the component version "1.3.0" is not used for scanning`,
+					},
+					{
+						Kind:      sbomvalidate.IssueUnknownEcosystem,
+						Ref:       "alamofire",
+						Component: "Alamofire@5.8.1",
+						Message:   `purl "pkg:swift/github.com/Alamofire/Alamofire@5.8.1" is not for a known ecosystem`,
+					},
+					{
+						Kind:      sbomvalidate.IssueInvalidPURL,
+						Ref:       "broken",
+						Component: "broken@0.1.0",
+						Message:   `purl "npm/broken@0.1.0" is invalid: purl scheme is not "pkg": ""`,
+					},
+					{
+						Kind:    sbomvalidate.IssueDuplicateRef,
+						Ref:     "lodash",
+						Message: `"lodash" is used by 2 components`,
+					},
+				},
+			},
+		},
+		{
+			name: "spdx_with_issues",
+			path: "fixtures/issues.spdx.json",
+			want: sbomvalidate.Report{
+				Path:       "fixtures/issues.spdx.json",
+				Format:     "SPDX",
+				Components: 2,
+				Scannable:  1,
+				Issues: []sbomvalidate.Issue{
+					{
+						Kind:      sbomvalidate.IssueMissingPURL,
+						Ref:       "Package-openssl",
+						Component: "openssl@3.0.2",
+						Message:   "component has no purl, so it will not be scanned",
+					},
+				},
+			},
+		},
+		{
+			name: "spdx_yaml_with_issues",
+			path: "fixtures/issues.spdx.yaml",
+			want: sbomvalidate.Report{
+				Path:       "fixtures/issues.spdx.yaml",
+				Format:     "SPDX",
+				Components: 2,
+				Scannable:  1,
+				Issues: []sbomvalidate.Issue{
+					{
+						Kind:      sbomvalidate.IssueMissingPURL,
+						Ref:       "Package-openssl",
+						Component: "openssl@3.0.2",
+						Message:   "component has no purl, so it will not be scanned",
+					},
+				},
+			},
+		},
+		{
+			name: "cyclonedx_detected_from_content",
+			path: "fixtures/legacy-export.xml",
+			want: sbomvalidate.Report{
+				Path:       "fixtures/legacy-export.xml",
+				Format:     "CycloneDX",
+				Components: 2,
+				Scannable:  2,
+				Issues:     []sbomvalidate.Issue{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := sbomvalidate.Validate(tt.path)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := sbomvalidate.Validate("fixtures/sbom.txt")
+	if !errors.Is(err, sbomvalidate.ErrUnsupportedFormat) {
+		t.Errorf("Validate() error = %v, want %v", err, sbomvalidate.ErrUnsupportedFormat)
+	}
+}
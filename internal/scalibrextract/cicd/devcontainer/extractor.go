@@ -0,0 +1,140 @@
+// Package devcontainer extracts the toolchains installed by development container features.
+package devcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/toolchain"
+	"github.com/tidwall/jsonc"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cicd/devcontainer"
+)
+
+// featuresPrefix is the registry path of the official features, which are
+// the only ones known to install the toolchain that they are named after
+const featuresPrefix = "ghcr.io/devcontainers/features/"
+
+type devcontainerFile struct {
+	// the options of a feature can either be an object or a string,
+	// which is shorthand for setting the "version" option
+	Features map[string]json.RawMessage `json:"features"`
+}
+
+// Extractor extracts the toolchain versions pinned by the features of devcontainer.json files.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for .devcontainer.json files, and devcontainer.json files
+// within a .devcontainer directory or one of its immediate subdirectories
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	path := filepath.ToSlash(fapi.Path())
+	base := filepath.Base(path)
+
+	if base == ".devcontainer.json" {
+		return true
+	}
+
+	if base != "devcontainer.json" {
+		return false
+	}
+
+	dir := filepath.ToSlash(filepath.Dir(path))
+	if filepath.Base(dir) != ".devcontainer" {
+		dir = filepath.ToSlash(filepath.Dir(dir))
+	}
+
+	return filepath.Base(dir) == ".devcontainer"
+}
+
+// Extract extracts the toolchains pinned by a devcontainer.json file passed through the scan input.
+//
+// Features which are not pinned to a specific version (such as "lts" or "latest")
+// are skipped, as it is not possible to know what they will resolve to.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	b, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	var parsed devcontainerFile
+	// devcontainer.json files can have comments and trailing commas
+	if err := json.Unmarshal(jsonc.ToJSON(b), &parsed); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+	for id, options := range parsed.Features {
+		tool, ok := featureTool(id)
+		if !ok {
+			continue
+		}
+
+		version := featureVersion(options)
+		pkg, ok := toolchain.NewPackage(tool, version)
+		if !ok {
+			cmdlogger.Warnf("%s uses feature %s with version %q, which is not a specific version", input.Path, id, version)
+			continue
+		}
+
+		pkg.Locations = []string{input.Path}
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// featureTool returns the toolchain installed by the feature with the given id,
+// e.g. "ghcr.io/devcontainers/features/node:1" installs "node"
+func featureTool(id string) (string, bool) {
+	name, ok := strings.CutPrefix(id, featuresPrefix)
+	if !ok {
+		return "", false
+	}
+
+	name, _, _ = strings.Cut(name, ":")
+	name, _, _ = strings.Cut(name, "@")
+
+	return name, toolchain.IsSupported(name)
+}
+
+// featureVersion returns the "version" option of a feature, which defaults to "latest"
+func featureVersion(options json.RawMessage) string {
+	var version string
+	if err := json.Unmarshal(options, &version); err == nil {
+		return version
+	}
+
+	var opts struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(options, &opts); err != nil || opts.Version == "" {
+		return "latest"
+	}
+
+	return opts.Version
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,117 @@
+package devcontainer_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/devcontainer"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/toolchain"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".devcontainer.json", want: true},
+		{path: ".devcontainer/devcontainer.json", want: true},
+		{path: ".devcontainer/python/devcontainer.json", want: true},
+		{path: "path/to/project/.devcontainer/devcontainer.json", want: true},
+		{path: "devcontainer.json", want: false},
+		{path: ".devcontainer/python/nested/devcontainer.json", want: false},
+		{path: ".devcontainer/Dockerfile", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := devcontainer.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "no features",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no-features.json",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "unpinned features",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/unpinned.json",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "features",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/.devcontainer/devcontainer.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "go",
+					Version:   "1.21",
+					Locations: []string{"testdata/.devcontainer/devcontainer.json"},
+					Metadata:  &toolchain.Metadata{Tool: "go", Pin: "1.21"},
+				},
+				{
+					Name:      "node",
+					Version:   "20.11.1",
+					Locations: []string{"testdata/.devcontainer/devcontainer.json"},
+					Metadata:  &toolchain.Metadata{Tool: "node", Pin: "20.11.1"},
+				},
+				{
+					Name:      "python",
+					Version:   "3.12.2",
+					Locations: []string{"testdata/.devcontainer/devcontainer.json"},
+					Metadata:  &toolchain.Metadata{Tool: "python", Pin: "3.12.2"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := devcontainer.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// Package dockerfile extracts the base images that Dockerfiles and Containerfiles build from.
+package dockerfile
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cicd/dockerfile"
+)
+
+// Extractor extracts the images that are built from by the FROM instructions of Dockerfiles.
+//
+// The images are references to images rather than packages, so are not scanned
+// themselves; instead the packages of the images can be scanned separately.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for Dockerfiles and Containerfiles, including those
+// named for a particular purpose such as "Dockerfile.dev" or "release.Dockerfile"
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	base := strings.ToLower(filepath.Base(fapi.Path()))
+
+	for _, name := range []string{"dockerfile", "containerfile"} {
+		if base == name || strings.HasPrefix(base, name+".") || strings.HasSuffix(base, "."+name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Extract extracts the images built from by a Dockerfile passed through the scan input.
+//
+// Images built from by a FROM instruction are skipped if they are "scratch", if they
+// are an earlier build stage, or if they use a build argument that has no default
+// value, as it is not possible to know what image they will resolve to.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	instructions, err := parseInstructions(input)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	// build arguments declared before the first FROM instruction can be used by FROM instructions
+	args := map[string]string{}
+	stages := map[string]struct{}{}
+	seenFrom := false
+
+	packages := []*extractor.Package{}
+	for _, inst := range instructions {
+		switch inst.command {
+		case "arg":
+			if seenFrom {
+				continue
+			}
+
+			for _, arg := range inst.args {
+				name, value, _ := strings.Cut(arg, "=")
+				args[name] = unquote(value)
+			}
+		case "from":
+			seenFrom = true
+
+			from, ok := parseFrom(inst.args)
+			if !ok {
+				cmdlogger.Warnf("%s has a FROM instruction that could not be parsed", input.Path)
+				continue
+			}
+
+			image, ok := expand(from.image, args)
+			if !ok {
+				cmdlogger.Warnf("%s builds from %s, which uses a build argument that has no default value", input.Path, from.image)
+				continue
+			}
+
+			_, isStage := stages[strings.ToLower(image)]
+			if from.stage != "" {
+				stages[strings.ToLower(from.stage)] = struct{}{}
+			}
+
+			if isStage || strings.EqualFold(image, "scratch") {
+				continue
+			}
+
+			// platforms are commonly given by the automatic platform arguments of BuildKit,
+			// which are only known when building, so the default platform is used instead
+			platform, ok := expand(from.platform, args)
+			if !ok {
+				platform = ""
+			}
+
+			packages = append(packages, newPackage(image, from.stage, platform, input.Path))
+		}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// newPackage returns the package for the image reference, named after the repository
+// of the image with its tag as its version, e.g. "node:20" is version "20" of "node"
+func newPackage(image string, stage string, platform string, path string) *extractor.Package {
+	repository, digest, _ := strings.Cut(image, "@")
+
+	var tag string
+	// tags come after the last colon, provided it is not the port of the registry
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+
+	return &extractor.Package{
+		Name:      repository,
+		Version:   tag,
+		Locations: []string{path},
+		Metadata: &Metadata{
+			Image:    image,
+			Digest:   digest,
+			Stage:    stage,
+			Platform: platform,
+		},
+	}
+}
+
+// instruction is a single instruction of a Dockerfile, such as "FROM node:20 AS build"
+type instruction struct {
+	// command is the lowercased command of the instruction, e.g. "from"
+	command string
+	args    []string
+}
+
+// parseInstructions parses the instructions of a Dockerfile, joining
+// the lines of instructions that are continued with a backslash
+func parseInstructions(input *filesystem.ScanInput) ([]instruction, error) {
+	var instructions []instruction
+	var current strings.Builder
+
+	scanner := bufio.NewScanner(input.Reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// comments are removed even within instructions that continue over multiple lines
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if rest, ok := strings.CutSuffix(line, `\`); ok {
+			current.WriteString(rest)
+			current.WriteString(" ")
+
+			continue
+		}
+
+		current.WriteString(line)
+
+		if fields := strings.Fields(current.String()); len(fields) > 0 {
+			instructions = append(instructions, instruction{
+				command: strings.ToLower(fields[0]),
+				args:    fields[1:],
+			})
+		}
+		current.Reset()
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return instructions, nil
+}
+
+type from struct {
+	image    string
+	stage    string
+	platform string
+}
+
+// parseFrom parses the arguments of a FROM instruction, which are in the
+// form of "[--platform=<platform>] <image> [AS <name>]"
+func parseFrom(args []string) (from, bool) {
+	var f from
+
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		if platform, ok := strings.CutPrefix(args[0], "--platform="); ok {
+			f.platform = platform
+		}
+		args = args[1:]
+	}
+
+	switch {
+	case len(args) == 1:
+	case len(args) == 3 && strings.EqualFold(args[1], "as"):
+		f.stage = args[2]
+	default:
+		return from{}, false
+	}
+
+	f.image = args[0]
+
+	return f, true
+}
+
+var argRe = cachedregexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)(?::([-+])([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// expand replaces the build arguments used by s with their values, supporting the
+// "${name:-default}" and "${name:+alternative}" forms, returning false if any of the
+// arguments have no value
+func expand(s string, args map[string]string) (string, bool) {
+	ok := true
+
+	expanded := argRe.ReplaceAllStringFunc(s, func(match string) string {
+		groups := argRe.FindStringSubmatch(match)
+
+		name := groups[1] + groups[4]
+		value, isSet := args[name]
+
+		switch groups[2] {
+		case "-":
+			if value == "" {
+				return groups[3]
+			}
+		case "+":
+			if value != "" {
+				return groups[3]
+			}
+
+			return ""
+		}
+
+		if !isSet || value == "" {
+			ok = false
+		}
+
+		return value
+	})
+
+	return expanded, ok
+}
+
+// unquote removes the quotes around the value of a build argument
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,147 @@
+package dockerfile_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/dockerfile"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "Dockerfile", want: true},
+		{path: "path/to/project/Dockerfile", want: true},
+		{path: "dockerfile", want: true},
+		{path: "Containerfile", want: true},
+		{path: "Dockerfile.dev", want: true},
+		{path: "release.Dockerfile", want: true},
+		{path: "build.containerfile", want: true},
+		{path: "Dockerfile/README.md", want: false},
+		{path: "Dockerfiles", want: false},
+		{path: "docker-compose.yml", want: false},
+		{path: ".dockerignore", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := dockerfile.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.Dockerfile",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "invalid from instructions",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.Dockerfile",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "multistage",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/Dockerfile",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "node",
+					Version:   "20-alpine3.19",
+					Locations: []string{"testdata/Dockerfile"},
+					Metadata: &dockerfile.Metadata{
+						Image: "node:20-alpine3.19",
+						Stage: "build",
+					},
+				},
+				{
+					Name:      "gcr.io/distroless/nodejs20-debian12",
+					Locations: []string{"testdata/Dockerfile"},
+					Metadata: &dockerfile.Metadata{
+						Image:    "gcr.io/distroless/nodejs20-debian12@sha256:9d3f5ac9e2f3b3a2f1b67ad2e8b0b3a3a0b0c0d0e0f0a1b1c1d1e1f1a2b2c2d2",
+						Digest:   "sha256:9d3f5ac9e2f3b3a2f1b67ad2e8b0b3a3a0b0c0d0e0f0a1b1c1d1e1f1a2b2c2d2",
+						Stage:    "runtime",
+						Platform: "linux/arm64",
+					},
+				},
+				{
+					Name:      "localhost:5000/tools/linter",
+					Version:   "1.2.3",
+					Locations: []string{"testdata/Dockerfile"},
+					Metadata: &dockerfile.Metadata{
+						Image: "localhost:5000/tools/linter:1.2.3",
+					},
+				},
+			},
+		},
+		{
+			Name: "build arguments",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/multi-arch.Containerfile",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "golang",
+					Version:   "1.22",
+					Locations: []string{"testdata/multi-arch.Containerfile"},
+					Metadata: &dockerfile.Metadata{
+						Image: "golang:1.22",
+						Stage: "builder",
+					},
+				},
+				{
+					Name:      "docker.io/library/debian",
+					Version:   "bookworm",
+					Locations: []string{"testdata/multi-arch.Containerfile"},
+					Metadata: &dockerfile.Metadata{
+						Image: "docker.io/library/debian:bookworm",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := dockerfile.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package dockerfile
+
+// Metadata holds the metadata for an image that a Dockerfile builds from
+type Metadata struct {
+	// Image is the reference to the image, as it is given by the FROM instruction
+	// once its build arguments have been expanded, e.g. "node:20-alpine"
+	Image string
+	// Digest is the digest that the image is pinned to, if it is pinned
+	Digest string
+	// Stage is the name of the build stage that builds from the image, if it is named
+	Stage string
+	// Platform is the platform of the image that is built from, if it is given
+	Platform string
+}
@@ -0,0 +1,275 @@
+// Package githubactions extracts the actions used by GitHub Actions workflows.
+package githubactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/toolchain"
+	"github.com/google/osv-scanner/v2/internal/utility/gitref"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cicd/githubactions"
+)
+
+// Extractor extracts the actions and reusable workflows used by GitHub Actions workflow files,
+// along with the toolchain versions pinned by "setup-*" steps.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for .yml and .yaml files within .github/workflows
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	path := filepath.ToSlash(fapi.Path())
+	ext := filepath.Ext(path)
+
+	if ext != ".yml" && ext != ".yaml" {
+		return false
+	}
+
+	dir := filepath.ToSlash(filepath.Dir(path))
+
+	return dir == ".github/workflows" || strings.HasSuffix(dir, "/.github/workflows")
+}
+
+// Extract extracts the actions used by a workflow file passed through the scan input.
+//
+// Actions pinned to a full version tag are returned with that version, and actions
+// pinned to a commit are returned with that commit, using the version in a trailing
+// comment if there is one (e.g. "actions/checkout@<sha> # v4.1.1").
+// Actions used at a branch or partial version tag are still returned but without a
+// version, as it is not possible to know what they will resolve to.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(input.Reader).Decode(&doc); err != nil {
+		// An empty file is still a valid (if useless) workflow
+		if errors.Is(err, io.EOF) {
+			return inventory.Inventory{}, nil
+		}
+
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+	for _, uses := range findUses(&doc) {
+		pkg, ok := parseUses(uses.Value, uses.LineComment)
+		if !ok {
+			continue
+		}
+
+		if pkg.Version == "" && pkg.SourceCode == nil {
+			cmdlogger.Warnf(
+				"%s:%d uses %s@%s, which is not pinned to a version or commit",
+				input.Path,
+				uses.Line,
+				pkg.Name,
+				pkg.Metadata.(*Metadata).Ref,
+			)
+		}
+
+		pkg.Locations = []string{input.Path}
+		packages = append(packages, pkg)
+	}
+
+	for _, pin := range findToolchainPins(&doc) {
+		pkg, ok := toolchain.NewPackage(pin.tool, pin.version.Value)
+		if !ok {
+			// versions set from an expression (e.g. a matrix) cannot be known ahead of time
+			if !strings.Contains(pin.version.Value, "${{") {
+				cmdlogger.Warnf(
+					"%s:%d pins %s to %s, which is not a specific version",
+					input.Path,
+					pin.version.Line,
+					pin.tool,
+					pin.version.Value,
+				)
+			}
+
+			continue
+		}
+
+		pkg.Locations = []string{input.Path}
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// setupActions are the actions that install a toolchain, along with the
+// input used to pin the version and the name of the toolchain
+var setupActions = map[string]struct {
+	input string
+	tool  string
+}{
+	"actions/setup-go":     {input: "go-version", tool: "go"},
+	"actions/setup-node":   {input: "node-version", tool: "node"},
+	"actions/setup-python": {input: "python-version", tool: "python"},
+}
+
+type toolchainPin struct {
+	tool    string
+	version *yaml.Node
+}
+
+// findToolchainPins returns the toolchain versions pinned by "setup-*" steps within the document
+func findToolchainPins(node *yaml.Node) []toolchainPin {
+	var found []toolchainPin
+
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			found = append(found, findToolchainPins(child)...)
+		}
+	case yaml.MappingNode:
+		var uses, with *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch {
+			case key.Value == "uses" && value.Kind == yaml.ScalarNode:
+				uses = value
+			case key.Value == "with" && value.Kind == yaml.MappingNode:
+				with = value
+			default:
+				found = append(found, findToolchainPins(value)...)
+			}
+		}
+
+		if uses == nil || with == nil {
+			break
+		}
+
+		action, _, _ := strings.Cut(strings.TrimSpace(uses.Value), "@")
+		setup, ok := setupActions[action]
+		if !ok {
+			break
+		}
+
+		for i := 0; i+1 < len(with.Content); i += 2 {
+			key, value := with.Content[i], with.Content[i+1]
+			if key.Value != setup.input || value.Kind != yaml.ScalarNode {
+				continue
+			}
+
+			// block scalars start on the line after their indicator
+			firstLine := value.Line
+			if value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+				firstLine++
+			}
+
+			// multiple versions can be given on separate lines
+			for j, line := range strings.Split(strings.TrimSpace(value.Value), "\n") {
+				version := *value
+				version.Value = strings.TrimSpace(line)
+				version.Line = firstLine + j
+				found = append(found, toolchainPin{tool: setup.tool, version: &version})
+			}
+		}
+	case yaml.ScalarNode, yaml.AliasNode:
+	}
+
+	return found
+}
+
+// findUses returns the values of all "uses" keys within the document,
+// which covers both the steps of a job and jobs calling reusable workflows
+func findUses(node *yaml.Node) []*yaml.Node {
+	var found []*yaml.Node
+
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			found = append(found, findUses(child)...)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "uses" && value.Kind == yaml.ScalarNode {
+				found = append(found, value)
+				continue
+			}
+			found = append(found, findUses(value)...)
+		}
+	case yaml.ScalarNode, yaml.AliasNode:
+	}
+
+	return found
+}
+
+// parseUses parses the value of a "uses" key, returning false for local
+// actions and docker images as they are not GitHub Actions packages
+func parseUses(uses string, comment string) (*extractor.Package, bool) {
+	uses = strings.TrimSpace(uses)
+
+	if strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+		return nil, false
+	}
+
+	action, ref, ok := strings.Cut(uses, "@")
+	if !ok || ref == "" {
+		return nil, false
+	}
+
+	// Actions can be in a subdirectory of a repository (e.g. "github/codeql-action/init"),
+	// but advisories are for the repository as a whole
+	parts := strings.Split(action, "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, false
+	}
+
+	pkg := &extractor.Package{
+		Name:     parts[0] + "/" + parts[1],
+		Metadata: &Metadata{Ref: ref},
+	}
+
+	if gitref.IsCommit(ref) {
+		pkg.SourceCode = &extractor.SourceCodeIdentifier{Commit: ref}
+		if version, ok := parseVersion(strings.TrimSpace(strings.TrimPrefix(comment, "#"))); ok {
+			pkg.Version = version
+		}
+
+		return pkg, true
+	}
+
+	if version, ok := parseVersion(ref); ok {
+		pkg.Version = version
+	}
+
+	return pkg, true
+}
+
+// parseVersion returns the version from a full version tag such as "v1.2.3",
+// since partial tags such as "v1" are moved as new versions are released
+func parseVersion(ref string) (string, bool) {
+	// the comment may have other text after the version, e.g. "v4.1.1 (latest)"
+	ref, _, _ = strings.Cut(ref, " ")
+
+	if !cachedregexp.MustCompile(`^v?\d+\.\d+\.\d+([-+][0-9A-Za-z.-]+)?$`).MatchString(ref) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(ref, "v"), true
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,187 @@
+package githubactions_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/githubactions"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/toolchain"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".github/workflows/ci.yml", want: true},
+		{path: ".github/workflows/release.yaml", want: true},
+		{path: "path/to/project/.github/workflows/ci.yml", want: true},
+		{path: ".github/workflows/README.md", want: false},
+		{path: ".github/dependabot.yml", want: false},
+		{path: ".github/workflows/nested/ci.yml", want: false},
+		{path: "workflows/ci.yml", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := githubactions.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid yaml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/.github/workflows/invalid.yml",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/.github/workflows/empty.yaml",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "workflow",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/.github/workflows/ci.yml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "actions/checkout",
+					Version:   "4.1.1",
+					Locations: []string{"testdata/.github/workflows/ci.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "v4.1.1"},
+				},
+				{
+					Name:    "actions/setup-go",
+					Version: "5.0.0",
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "0c52d547c9bc32b1aa3301fd7a9cb496313a4491",
+					},
+					Locations: []string{"testdata/.github/workflows/ci.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "0c52d547c9bc32b1aa3301fd7a9cb496313a4491"},
+				},
+				{
+					Name:      "github/codeql-action",
+					Locations: []string{"testdata/.github/workflows/ci.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "v3"},
+				},
+				{
+					Name: "tj-actions/changed-files",
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "2d756ea4c53f7f6b397767d8723b3a10a9f35bf2",
+					},
+					Locations: []string{"testdata/.github/workflows/ci.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "2d756ea4c53f7f6b397767d8723b3a10a9f35bf2"},
+				},
+				{
+					Name:      "octo-org/example-repo",
+					Locations: []string{"testdata/.github/workflows/ci.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "main"},
+				},
+			},
+		},
+		{
+			Name: "toolchain pins",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/.github/workflows/setup.yml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "actions/setup-go",
+					Version:   "5.0.0",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "v5.0.0"},
+				},
+				{
+					Name:      "actions/setup-node",
+					Version:   "4.0.2",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "v4.0.2"},
+				},
+				{
+					Name:      "actions/setup-node",
+					Version:   "4.0.2",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "v4.0.2"},
+				},
+				{
+					Name:      "actions/setup-node",
+					Version:   "4.0.2",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "v4.0.2"},
+				},
+				{
+					Name:      "actions/setup-python",
+					Version:   "5.0.0",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "v5.0.0"},
+				},
+				{
+					Name:      "actions/setup-java",
+					Version:   "4.2.1",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &githubactions.Metadata{Ref: "v4.2.1"},
+				},
+				{
+					Name:      "go",
+					Version:   "1.21",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &toolchain.Metadata{Tool: "go", Pin: "1.21"},
+				},
+				{
+					Name:      "node",
+					Version:   "20.11.1",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &toolchain.Metadata{Tool: "node", Pin: "v20.11.1"},
+				},
+				{
+					Name:      "python",
+					Version:   "3.11.8",
+					Locations: []string{"testdata/.github/workflows/setup.yml"},
+					Metadata:  &toolchain.Metadata{Tool: "python", Pin: "3.11.8"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := githubactions.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
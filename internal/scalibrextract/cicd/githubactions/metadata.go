@@ -0,0 +1,7 @@
+package githubactions
+
+// Metadata holds the metadata for an action used in a GitHub Actions workflow
+type Metadata struct {
+	// Ref is the git ref that the action is used at, e.g. "v4.1.1", "main", or a commit hash
+	Ref string
+}
@@ -0,0 +1,359 @@
+// Package kubernetes extracts the container images run by Kubernetes manifests and Helm charts.
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cicd/kubernetes"
+)
+
+// Extractor extracts the images run by the workloads of Kubernetes manifests,
+// and the images set by the values of Helm charts.
+//
+// The images are references to images rather than packages, so are not scanned
+// themselves; instead the packages of the images can be scanned separately.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for .yml and .yaml files, as Kubernetes manifests can be named anything
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	ext := strings.ToLower(filepath.Ext(fapi.Path()))
+
+	return ext == ".yml" || ext == ".yaml"
+}
+
+// Extract extracts the images run by the workloads of a Kubernetes manifest passed
+// through the scan input, or the images set by the values of a Helm chart if the
+// file is the values.yaml of a chart.
+//
+// Documents that are not Kubernetes objects are skipped, as are templates of
+// Helm charts, as it is not possible to know what they will render to.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	data, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	if chart, ok := readChart(input); ok {
+		return extractValues(data, chart, input.Path)
+	}
+
+	var packages []*extractor.Package
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			// templates use actions that are often not valid YAML until they are rendered
+			if bytes.Contains(data, []byte("{{")) {
+				return inventory.Inventory{}, nil
+			}
+
+			return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+		}
+
+		if !isObject(&doc) {
+			continue
+		}
+
+		var obj object
+		if err := doc.Decode(&obj); err != nil {
+			return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+		}
+
+		packages = append(packages, obj.packages(input.Path)...)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// isObject returns if the document is a Kubernetes object, which always has a kind and apiVersion
+func isObject(doc *yaml.Node) bool {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return false
+	}
+
+	var hasKind, hasAPIVersion bool
+
+	fields := doc.Content[0].Content
+	for i := 0; i+1 < len(fields); i += 2 {
+		switch fields[i].Value {
+		case "kind":
+			hasKind = fields[i+1].Kind == yaml.ScalarNode
+		case "apiVersion":
+			hasAPIVersion = fields[i+1].Kind == yaml.ScalarNode
+		}
+	}
+
+	return hasKind && hasAPIVersion
+}
+
+type container struct {
+	Name  string `yaml:"name"`
+	Image string `yaml:"image"`
+}
+
+type podSpec struct {
+	InitContainers      []container `yaml:"initContainers"`
+	Containers          []container `yaml:"containers"`
+	EphemeralContainers []container `yaml:"ephemeralContainers"`
+}
+
+type podTemplate struct {
+	Spec podSpec `yaml:"spec"`
+}
+
+type object struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+	Spec struct {
+		podSpec `yaml:",inline"`
+
+		Template    podTemplate `yaml:"template"`
+		JobTemplate struct {
+			Spec struct {
+				Template podTemplate `yaml:"template"`
+			} `yaml:"spec"`
+		} `yaml:"jobTemplate"`
+	} `yaml:"spec"`
+	// Items are the objects of a List
+	Items []object `yaml:"items"`
+}
+
+// podSpec returns the spec of the pods that the object runs, if it is a workload
+func (obj object) podSpec() (podSpec, bool) {
+	switch obj.Kind {
+	case "Pod":
+		return obj.Spec.podSpec, true
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "ReplicationController", "Job":
+		return obj.Spec.Template.Spec, true
+	case "CronJob":
+		return obj.Spec.JobTemplate.Spec.Template.Spec, true
+	}
+
+	return podSpec{}, false
+}
+
+// packages returns the images run by the containers of the object, or of the objects of a List
+func (obj object) packages(path string) []*extractor.Package {
+	if obj.Kind == "List" {
+		var packages []*extractor.Package
+		for _, item := range obj.Items {
+			packages = append(packages, item.packages(path)...)
+		}
+
+		return packages
+	}
+
+	spec, ok := obj.podSpec()
+	if !ok {
+		return nil
+	}
+
+	workload := obj.Kind + "/" + obj.Metadata.Name
+
+	var packages []*extractor.Package
+	for _, containers := range [][]container{spec.InitContainers, spec.Containers, spec.EphemeralContainers} {
+		for _, c := range containers {
+			if c.Image == "" {
+				continue
+			}
+
+			if strings.Contains(c.Image, "{{") || strings.Contains(c.Image, "$(") {
+				cmdlogger.Warnf("%s runs %s in %s, which is not a specific image", path, c.Image, workload)
+				continue
+			}
+
+			packages = append(packages, newPackage(c.Image, path, Metadata{
+				Workload:  workload,
+				Namespace: obj.Metadata.Namespace,
+				Container: c.Name,
+			}))
+		}
+	}
+
+	return packages
+}
+
+type chart struct {
+	Name       string `yaml:"name"`
+	AppVersion string `yaml:"appVersion"`
+}
+
+// readChart returns the chart that the file is the values of, if it is the values.yaml of a Helm chart
+func readChart(input *filesystem.ScanInput) (chart, bool) {
+	if input.FS == nil || filepath.Base(input.Path) != "values.yaml" {
+		return chart{}, false
+	}
+
+	data, err := fs.ReadFile(input.FS, filepath.ToSlash(filepath.Join(filepath.Dir(input.Path), "Chart.yaml")))
+	if err != nil {
+		return chart{}, false
+	}
+
+	var c chart
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		cmdlogger.Warnf("Failed to parse the Chart.yaml of %s: %s", input.Path, err)
+		return chart{}, false
+	}
+
+	return c, true
+}
+
+// extractValues extracts the images set by the values of a Helm chart, which are either
+// a reference to an image, or an object with the repository of the image and its tag or
+// digest, using the app version of the chart as the tag if it is not set
+func extractValues(data []byte, c chart, path string) (inventory.Inventory, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", path, err)
+	}
+
+	var packages []*extractor.Package
+	for _, v := range findImageValues(&doc, "", c.AppVersion) {
+		packages = append(packages, newPackage(v.ref, path, Metadata{
+			Workload:  "Chart/" + c.Name,
+			Container: v.key,
+		}))
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// imageValue is the image set by the values of a Helm chart, as an object
+type imageValue struct {
+	Registry   string `yaml:"registry"`
+	Repository string `yaml:"repository"`
+	Tag        string `yaml:"tag"`
+	Digest     string `yaml:"digest"`
+}
+
+// ref returns the reference to the image, using the app version of the chart
+// as its tag if it is not pinned to a tag or digest
+func (v imageValue) ref(appVersion string) string {
+	ref := v.Repository
+	if v.Registry != "" {
+		ref = strings.TrimSuffix(v.Registry, "/") + "/" + ref
+	}
+
+	switch {
+	case v.Digest != "":
+		ref += "@" + v.Digest
+	case v.Tag != "":
+		ref += ":" + v.Tag
+	case appVersion != "":
+		ref += ":" + appVersion
+	}
+
+	return ref
+}
+
+type foundImage struct {
+	// key is the path of the value that sets the image, e.g. "backend.image"
+	key string
+	ref string
+}
+
+// findImageValues returns the images set by the "image" values within the node
+func findImageValues(node *yaml.Node, prefix string, appVersion string) []foundImage {
+	var found []foundImage
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			found = append(found, findImageValues(child, prefix, appVersion)...)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			found = append(found, findImageValues(child, prefix+"["+strconv.Itoa(i)+"]", appVersion)...)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+
+			if key == "image" {
+				switch value.Kind {
+				case yaml.ScalarNode:
+					if value.Value != "" && !strings.Contains(value.Value, "{{") {
+						found = append(found, foundImage{key: path, ref: value.Value})
+					}
+
+					continue
+				case yaml.MappingNode:
+					var image imageValue
+					if err := value.Decode(&image); err == nil && image.Repository != "" {
+						found = append(found, foundImage{key: path, ref: image.ref(appVersion)})
+
+						continue
+					}
+				}
+			}
+
+			found = append(found, findImageValues(value, path, appVersion)...)
+		}
+	}
+
+	return found
+}
+
+// newPackage returns the package for the image reference, named after the repository
+// of the image with its tag as its version, e.g. "nginx:1.25" is version "1.25" of "nginx"
+func newPackage(image string, path string, md Metadata) *extractor.Package {
+	repository, digest, _ := strings.Cut(image, "@")
+
+	var tag string
+	// tags come after the last colon, provided it is not the port of the registry
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+
+	md.Image = image
+	md.Digest = digest
+
+	return &extractor.Package{
+		Name:      repository,
+		Version:   tag,
+		Locations: []string{path},
+		Metadata:  &md,
+	}
+}
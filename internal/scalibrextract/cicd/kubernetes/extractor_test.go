@@ -0,0 +1,204 @@
+package kubernetes_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/kubernetes"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "deployment.yaml", want: true},
+		{path: "k8s/prod/web.yml", want: true},
+		{path: "charts/web/values.yaml", want: true},
+		{path: "manifests/Service.YAML", want: true},
+		{path: "deployment.json", want: false},
+		{path: "Chart.lock", want: false},
+		{path: "yaml", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := kubernetes.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.yaml",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "invalid",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.yaml",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "not a kubernetes manifest",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-kubernetes.yaml",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "helm template",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/chart/templates/deployment.yaml",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "multiple documents",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/deployment.yaml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "ghcr.io/example/migrate",
+					Version:   "2.1.0",
+					Locations: []string{"testdata/deployment.yaml"},
+					Metadata: &kubernetes.Metadata{
+						Image:     "ghcr.io/example/migrate:2.1.0",
+						Workload:  "Deployment/web",
+						Namespace: "prod",
+						Container: "migrate",
+					},
+				},
+				{
+					Name:      "ghcr.io/example/web",
+					Locations: []string{"testdata/deployment.yaml"},
+					Metadata: &kubernetes.Metadata{
+						Image:     "ghcr.io/example/web@sha256:4f2c1e7e9b3a0d5c6f8e1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d",
+						Digest:    "sha256:4f2c1e7e9b3a0d5c6f8e1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d",
+						Workload:  "Deployment/web",
+						Namespace: "prod",
+						Container: "app",
+					},
+				},
+				{
+					Name:      "nginx",
+					Version:   "1.25-alpine",
+					Locations: []string{"testdata/deployment.yaml"},
+					Metadata: &kubernetes.Metadata{
+						Image:     "nginx:1.25-alpine",
+						Workload:  "Deployment/web",
+						Namespace: "prod",
+						Container: "proxy",
+					},
+				},
+				{
+					Name:      "localhost:5000/tools/cleanup",
+					Version:   "0.3.0",
+					Locations: []string{"testdata/deployment.yaml"},
+					Metadata: &kubernetes.Metadata{
+						Image:     "localhost:5000/tools/cleanup:0.3.0",
+						Workload:  "CronJob/cleanup",
+						Container: "cleanup",
+					},
+				},
+			},
+		},
+		{
+			Name: "list",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/list.yml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "busybox",
+					Version:   "1.36",
+					Locations: []string{"testdata/list.yml"},
+					Metadata: &kubernetes.Metadata{
+						Image:     "busybox:1.36",
+						Workload:  "Pod/debug",
+						Container: "shell",
+					},
+				},
+			},
+		},
+		{
+			Name: "helm values",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/chart/values.yaml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "example/shop",
+					Version:   "1.8.2",
+					Locations: []string{"testdata/chart/values.yaml"},
+					Metadata: &kubernetes.Metadata{
+						Image:     "example/shop:1.8.2",
+						Workload:  "Chart/shop",
+						Container: "image",
+					},
+				},
+				{
+					Name:      "quay.io/example/shop-worker",
+					Version:   "1.8.0",
+					Locations: []string{"testdata/chart/values.yaml"},
+					Metadata: &kubernetes.Metadata{
+						Image:     "quay.io/example/shop-worker:1.8.0",
+						Workload:  "Chart/shop",
+						Container: "worker.image",
+					},
+				},
+				{
+					Name:      "prom/statsd-exporter",
+					Version:   "v0.26.0",
+					Locations: []string{"testdata/chart/values.yaml"},
+					Metadata: &kubernetes.Metadata{
+						Image:     "prom/statsd-exporter:v0.26.0",
+						Workload:  "Chart/shop",
+						Container: "sidecars[0].image",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := kubernetes.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
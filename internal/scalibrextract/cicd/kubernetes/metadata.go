@@ -0,0 +1,18 @@
+package kubernetes
+
+// Metadata holds the metadata for an image that is run by a Kubernetes workload
+type Metadata struct {
+	// Image is the reference to the image, as it is given by the manifest or the
+	// values of the Helm chart, e.g. "nginx:1.25-alpine"
+	Image string
+	// Digest is the digest that the image is pinned to, if it is pinned
+	Digest string
+	// Workload is the kind and name of the workload that runs the image, e.g.
+	// "Deployment/web", or for the values of a Helm chart, the chart, e.g. "Chart/web"
+	Workload string
+	// Namespace is the namespace of the workload, if it is given
+	Namespace string
+	// Container is the name of the container that runs the image, or for the values
+	// of a Helm chart, the key of the values that set the image, e.g. "backend.image"
+	Container string
+}
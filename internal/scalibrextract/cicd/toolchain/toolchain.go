@@ -0,0 +1,80 @@
+// Package toolchain describes the language toolchains and build tools that can be pinned
+// to a version by CI and development environment configs, such as GitHub Actions "setup-*"
+// steps, and by build tool wrappers.
+package toolchain
+
+import (
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// Metadata holds the metadata for a toolchain pinned by a config file
+type Metadata struct {
+	// Tool is the toolchain that was pinned, e.g. "go", "node", "python", or "gradle"
+	Tool string
+	// Pin is the version the tool was pinned to as written in the config file, e.g. "v20.11.1"
+	Pin string
+}
+
+type tool struct {
+	ecosystem osvschema.Ecosystem
+	name      string
+	// partialVersions is whether a version without a patch component can be matched
+	partialVersions bool
+}
+
+// tools are the toolchains that have advisories in an OSV ecosystem
+var tools = map[string]tool{
+	// "go" is patched to "stdlib" when the package is converted for matching,
+	// which also assumes the latest patch of a "major.minor" version
+	"go": {ecosystem: osvschema.EcosystemGo, name: "go", partialVersions: true},
+	// the Node.js and CPython runtimes are only covered by the Bitnami advisories
+	"node":   {ecosystem: osvschema.EcosystemBitnami, name: "node"},
+	"python": {ecosystem: osvschema.EcosystemBitnami, name: "python"},
+	// build tools are covered by the advisories for the artifacts that they publish to Maven Central,
+	// with Gradle versions such as "8.5" being complete versions rather than missing a patch
+	"maven":         {ecosystem: osvschema.EcosystemMaven, name: "org.apache.maven:maven-core"},
+	"maven-wrapper": {ecosystem: osvschema.EcosystemMaven, name: "org.apache.maven.wrapper:maven-wrapper"},
+	"gradle":        {ecosystem: osvschema.EcosystemMaven, name: "org.gradle:gradle-tooling-api", partialVersions: true},
+}
+
+// IsSupported returns if the given tool has advisories that can be matched against
+func IsSupported(name string) bool {
+	_, ok := tools[name]
+
+	return ok
+}
+
+// Ecosystem returns the ecosystem that has the advisories for the given tool
+func Ecosystem(name string) osvschema.Ecosystem {
+	return tools[name].ecosystem
+}
+
+// NewPackage returns the package for the given tool pinned to a version, returning
+// false if the tool is not supported or the version is not specific enough to be
+// matched against advisories (e.g. "20.x", "lts/*", or "latest").
+func NewPackage(name string, pin string) (*extractor.Package, bool) {
+	t, ok := tools[name]
+	if !ok {
+		return nil, false
+	}
+
+	version := strings.TrimPrefix(strings.TrimSpace(pin), "v")
+	re := `^\d+\.\d+\.\d+$`
+	if t.partialVersions {
+		re = `^\d+\.\d+(\.\d+)?$`
+	}
+
+	if !cachedregexp.MustCompile(re).MatchString(version) {
+		return nil, false
+	}
+
+	return &extractor.Package{
+		Name:     t.name,
+		Version:  version,
+		Metadata: &Metadata{Tool: name, Pin: pin},
+	}, true
+}
@@ -0,0 +1,74 @@
+package toolchain_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/toolchain"
+)
+
+func TestNewPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tool   string
+		pin    string
+		want   *extractor.Package
+		wantOk bool
+	}{
+		{
+			tool:   "go",
+			pin:    "1.21.3",
+			want:   &extractor.Package{Name: "go", Version: "1.21.3", Metadata: &toolchain.Metadata{Tool: "go", Pin: "1.21.3"}},
+			wantOk: true,
+		},
+		{
+			tool:   "go",
+			pin:    "1.21",
+			want:   &extractor.Package{Name: "go", Version: "1.21", Metadata: &toolchain.Metadata{Tool: "go", Pin: "1.21"}},
+			wantOk: true,
+		},
+		{
+			tool:   "node",
+			pin:    "v20.11.1",
+			want:   &extractor.Package{Name: "node", Version: "20.11.1", Metadata: &toolchain.Metadata{Tool: "node", Pin: "v20.11.1"}},
+			wantOk: true,
+		},
+		{
+			tool:   "maven",
+			pin:    "3.9.6",
+			want:   &extractor.Package{Name: "org.apache.maven:maven-core", Version: "3.9.6", Metadata: &toolchain.Metadata{Tool: "maven", Pin: "3.9.6"}},
+			wantOk: true,
+		},
+		{
+			tool:   "gradle",
+			pin:    "8.5",
+			want:   &extractor.Package{Name: "org.gradle:gradle-tooling-api", Version: "8.5", Metadata: &toolchain.Metadata{Tool: "gradle", Pin: "8.5"}},
+			wantOk: true,
+		},
+		{tool: "node", pin: "20", wantOk: false},
+		{tool: "node", pin: "20.x", wantOk: false},
+		{tool: "node", pin: "lts/*", wantOk: false},
+		{tool: "python", pin: "3.12", wantOk: false},
+		{tool: "python", pin: "latest", wantOk: false},
+		{tool: "java", pin: "21.0.2", wantOk: false},
+		{tool: "maven", pin: "3.9", wantOk: false},
+		{tool: "gradle", pin: "8.5-rc-1", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool+"@"+tt.pin, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := toolchain.NewPackage(tt.tool, tt.pin)
+			if ok != tt.wantOk {
+				t.Errorf("NewPackage(%q, %q) ok = %v, want %v", tt.tool, tt.pin, ok, tt.wantOk)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewPackage(%q, %q) mismatch (-want +got):\n%s", tt.tool, tt.pin, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Package external extracts packages by running user-provided commands, allowing
+// custom manifest formats to be scanned without changes to the scanner.
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// NamePrefix is the prefix of the names of all external extractors,
+	// which are followed by the file name pattern that they match
+	NamePrefix = "external/"
+)
+
+// Metadata holds the details of packages that were returned by an external command
+type Metadata struct {
+	Ecosystem    string
+	DepGroupVals []string
+}
+
+// DepGroups returns the dependency groups the package is in
+func (m *Metadata) DepGroups() []string {
+	return m.DepGroupVals
+}
+
+// output is what the command is expected to write to stdout
+type output struct {
+	Packages []outputPackage `json:"packages"`
+}
+
+type outputPackage struct {
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	Ecosystem string   `json:"ecosystem"`
+	Commit    string   `json:"commit"`
+	DepGroups []string `json:"dependency_groups"`
+}
+
+// Extractor extracts packages from files with a base name matching a pattern,
+// by running a command with the contents of each file on stdin and the path of
+// the file as the last argument, which must write the packages to stdout as json:
+//
+//	{"packages": [{"name": "lodash", "version": "4.17.21", "ecosystem": "npm"}]}
+//
+// Packages can also have a "commit" and "dependency_groups".
+type Extractor struct {
+	pattern string
+	command []string
+}
+
+// New creates an extractor that runs the given command for files with a base name
+// matching the given pattern, which uses the syntax of filepath.Match
+func New(pattern string, command []string) (*Extractor, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	if len(command) == 0 {
+		return nil, fmt.Errorf("missing command for pattern %q", pattern)
+	}
+
+	return &Extractor{pattern: pattern, command: command}, nil
+}
+
+// Parse creates an extractor from a definition in the form of "<pattern>=<command>",
+// where the command is split on whitespace into the program and its arguments
+func Parse(definition string) (*Extractor, error) {
+	pattern, command, ok := strings.Cut(definition, "=")
+	if !ok || pattern == "" {
+		return nil, fmt.Errorf("invalid external extractor %q - must be in the form of <pattern>=<command>", definition)
+	}
+
+	return New(pattern, strings.Fields(command))
+}
+
+// Name of the extractor
+func (e *Extractor) Name() string { return NamePrefix + e.pattern }
+
+// Version of the extractor
+func (e *Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e *Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for files with a base name matching the pattern
+func (e *Extractor) FileRequired(api filesystem.FileAPI) bool {
+	matched, _ := filepath.Match(e.pattern, filepath.Base(api.Path()))
+
+	return matched
+}
+
+// Extract runs the command for the file passed through the scan input, and
+// returns the packages that it writes to stdout
+func (e *Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	// the path of the input is relative to the root of the scan, rather than to
+	// the directory that the command is run in
+	path := filepath.Join(input.Root, input.Path)
+
+	//nolint:gosec // running the command given by the user is the point of this extractor
+	cmd := exec.CommandContext(ctx, e.command[0], append(e.command[1:], path)...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = input.Reader
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %s failed: %w", input.Path, e.command[0], err)
+	}
+
+	var out output
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: invalid output from %s: %w", input.Path, e.command[0], err)
+	}
+
+	packages := make([]*extractor.Package, 0, len(out.Packages))
+	for _, p := range out.Packages {
+		pkg := &extractor.Package{
+			Name:      p.Name,
+			Version:   p.Version,
+			Locations: []string{input.Path},
+			Metadata: &Metadata{
+				Ecosystem:    p.Ecosystem,
+				DepGroupVals: p.DepGroups,
+			},
+		}
+
+		if p.Commit != "" {
+			pkg.SourceCode = &extractor.SourceCodeIdentifier{Commit: p.Commit}
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = &Extractor{}
@@ -0,0 +1,256 @@
+package external_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/external"
+)
+
+// helperCommand returns a command that re-runs the test binary as an external
+// extractor, behaving as described by TestHelperProcess
+func helperCommand(mode string) []string {
+	return []string{os.Args[0], "-test.run=^TestHelperProcess$", "--", mode}
+}
+
+// TestHelperProcess is not a real test, but is run by the extractor as the external
+// command, converting lines of "<name> <version> <ecosystem> [group]" to packages
+func TestHelperProcess(t *testing.T) {
+	t.Parallel()
+
+	i := slices.Index(os.Args, "--")
+	if i == -1 {
+		return
+	}
+
+	switch os.Args[i+1] {
+	case "fail":
+		fmt.Fprintln(os.Stderr, "unsupported manifest version")
+		os.Exit(2)
+	case "invalid":
+		fmt.Println("not json")
+		os.Exit(0)
+	}
+
+	input := io.Reader(os.Stdin)
+
+	// read the file from the path that is passed as the last argument instead of stdin
+	if os.Args[i+1] == "path" {
+		f, err := os.Open(os.Args[len(os.Args)-1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+
+		// the file is closed when the process exits
+		input = f
+	}
+
+	type pkg struct {
+		Name      string   `json:"name"`
+		Version   string   `json:"version"`
+		Ecosystem string   `json:"ecosystem"`
+		DepGroups []string `json:"dependency_groups,omitempty"`
+	}
+
+	packages := []pkg{}
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		p := pkg{Name: fields[0], Version: fields[1], Ecosystem: fields[2]}
+		if len(fields) > 3 {
+			p.DepGroups = fields[3:]
+		}
+		packages = append(packages, p)
+	}
+
+	_ = json.NewEncoder(os.Stdout).Encode(map[string]any{"packages": packages})
+	os.Exit(0)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		definition string
+		wantName   string
+		wantErr    bool
+	}{
+		{definition: "*.ipkg=ipkg-export --json", wantName: "external/*.ipkg"},
+		{definition: "packages.lock=export", wantName: "external/packages.lock"},
+		{definition: "*.ipkg", wantErr: true},
+		{definition: "=ipkg-export", wantErr: true},
+		{definition: "*.ipkg=", wantErr: true},
+		{definition: "[.ipkg=ipkg-export", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.definition, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := external.Parse(tt.definition)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Name() != tt.wantName {
+				t.Errorf("Parse().Name() = %q, want %q", got.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "packages.ipkg", want: true},
+		{path: "path/to/my/packages.ipkg", want: true},
+		{path: "packages.ipkg.bak", want: false},
+		{path: "packages.ipkg/file", want: false},
+	}
+
+	e, err := external.New("*.ipkg", []string{"ipkg-export"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		extracttest.TestTableEntry
+
+		mode string
+	}{
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name: "packages are returned",
+				InputConfig: extracttest.ScanInputMockConfig{
+					Path: "testdata/packages.ipkg",
+				},
+				WantPackages: []*extractor.Package{
+					{
+						Name:      "lodash",
+						Version:   "4.17.21",
+						Locations: []string{"testdata/packages.ipkg"},
+						Metadata:  &external.Metadata{Ecosystem: "npm"},
+					},
+					{
+						Name:      "jest",
+						Version:   "29.7.0",
+						Locations: []string{"testdata/packages.ipkg"},
+						Metadata:  &external.Metadata{Ecosystem: "npm", DepGroupVals: []string{"dev"}},
+					},
+					{
+						Name:      "golang.org/x/net",
+						Version:   "0.17.0",
+						Locations: []string{"testdata/packages.ipkg"},
+						Metadata:  &external.Metadata{Ecosystem: "Go"},
+					},
+				},
+			},
+			mode: "extract",
+		},
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name: "path is relative to the scan root",
+				InputConfig: extracttest.ScanInputMockConfig{
+					Path:         "packages.ipkg",
+					FakeScanRoot: "testdata",
+				},
+				WantPackages: []*extractor.Package{
+					{
+						Name:      "lodash",
+						Version:   "4.17.21",
+						Locations: []string{"packages.ipkg"},
+						Metadata:  &external.Metadata{Ecosystem: "npm"},
+					},
+					{
+						Name:      "jest",
+						Version:   "29.7.0",
+						Locations: []string{"packages.ipkg"},
+						Metadata:  &external.Metadata{Ecosystem: "npm", DepGroupVals: []string{"dev"}},
+					},
+					{
+						Name:      "golang.org/x/net",
+						Version:   "0.17.0",
+						Locations: []string{"packages.ipkg"},
+						Metadata:  &external.Metadata{Ecosystem: "Go"},
+					},
+				},
+			},
+			mode: "path",
+		},
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name: "command fails",
+				InputConfig: extracttest.ScanInputMockConfig{
+					Path: "testdata/packages.ipkg",
+				},
+				WantErr: extracttest.ContainsErrStr{Str: "unsupported manifest version"},
+			},
+			mode: "fail",
+		},
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name: "invalid output",
+				InputConfig: extracttest.ScanInputMockConfig{
+					Path: "testdata/packages.ipkg",
+				},
+				WantErr: extracttest.ContainsErrStr{Str: "invalid output"},
+			},
+			mode: "invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr, err := external.New("*.ipkg", helperCommand(tt.mode))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -35,7 +35,19 @@ func ExtractWithExtractor(ctx context.Context, localPath string, ext filesystem.
 		return nil, err
 	}
 
-	return extractWithExtractor(ctx, localPath, info, ext)
+	return extractWithExtractor(ctx, getRootDir(localPath), localPath, info, ext)
+}
+
+// ExtractWithExtractorFromRoot is like ExtractWithExtractor, except the extractor is given
+// access to the filesystem from rootDir rather than the system root, for files that belong
+// to another filesystem such as the root filesystem of an extracted container image
+func ExtractWithExtractorFromRoot(ctx context.Context, rootDir string, localPath string, ext filesystem.Extractor) ([]*extractor.Package, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractWithExtractor(ctx, rootDir, localPath, info, ext)
 }
 
 // ExtractWithExtractors attempts to extract the file at the given path
@@ -68,7 +80,7 @@ func ExtractWithExtractors(ctx context.Context, localPath string, extractors []f
 		}
 		extractorFound = true
 
-		invs, err := extractWithExtractor(ctx, localPath, info, ext)
+		invs, err := extractWithExtractor(ctx, getRootDir(localPath), localPath, info, ext)
 		if err != nil {
 			return nil, err
 		}
@@ -83,10 +95,9 @@ func ExtractWithExtractors(ctx context.Context, localPath string, extractors []f
 	return result, nil
 }
 
-func extractWithExtractor(ctx context.Context, localPath string, info fs.FileInfo, ext filesystem.Extractor) ([]*extractor.Package, error) {
-	// Create a scan input centered at the system root directory,
+func extractWithExtractor(ctx context.Context, rootDir string, localPath string, info fs.FileInfo, ext filesystem.Extractor) ([]*extractor.Package, error) {
+	// Create a scan input centered at the root directory,
 	// to give access to the full filesystem for each extractor.
-	rootDir := getRootDir(localPath)
 	si, err := createScanInput(localPath, rootDir, info)
 	if err != nil {
 		return nil, err
@@ -0,0 +1 @@
+int bar(void) { return 2; }
@@ -0,0 +1 @@
+int baz(void) { return 3; }
@@ -0,0 +1,107 @@
+package vendored
+
+import (
+	"bufio"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+// maxHintFileSize is the largest metadata file that will be read for upstream hints,
+// to avoid reading large READMEs in full
+const maxHintFileSize = 64 * 1024
+
+// upstream identifies the project and commit that a vendored library was copied from
+type upstream struct {
+	Repo   string
+	Commit string
+}
+
+// upstreamFromGitDir identifies the upstream of a vendored library that still
+// has its .git directory, using the commit checked out at HEAD.
+func upstreamFromGitDir(root string, dir string) (upstream, bool) {
+	// Assume this is fully on a real filesystem, like the gitrepo extractor
+	// TODO: Make this support virtual filesystems
+	repo, err := git.PlainOpen(path.Join(root, dir))
+	if err != nil {
+		return upstream{}, false
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return upstream{}, false
+	}
+
+	u := upstream{Commit: head.Hash().String()}
+
+	if remote, err := repo.Remote("origin"); err == nil && len(remote.Config().URLs) > 0 {
+		u.Repo = remote.Config().URLs[0]
+	}
+
+	return u, true
+}
+
+// upstreamFromMetadata identifies the upstream of a vendored library from the
+// metadata files that vendoring tools commonly leave behind, such as
+// Chromium's README.chromium or git-subrepo's .gitrepo.
+func upstreamFromMetadata(fsys scalibrfs.FS, dir string) (upstream, bool) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return upstream{}, false
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isHintFile(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || info.Size() > maxHintFileSize {
+			continue
+		}
+
+		if u, ok := parseHintFile(fsys, filepath.Join(dir, entry.Name())); ok {
+			return u, true
+		}
+	}
+
+	return upstream{}, false
+}
+
+func isHintFile(name string) bool {
+	return name == ".gitrepo" || strings.HasPrefix(strings.ToUpper(name), "README")
+}
+
+// parseHintFile looks for "<key>: <value>" or "<key> = <value>" lines recording
+// the upstream commit and url, returning false if no full commit hash is found
+func parseHintFile(fsys scalibrfs.FS, p string) (upstream, bool) {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return upstream{}, false
+	}
+	defer f.Close()
+
+	commitRe := cachedregexp.MustCompile(`(?i)^\s*(?:revision|commit)\s*[:=]\s*([0-9a-f]{40})\b`)
+	repoRe := cachedregexp.MustCompile(`(?i)^\s*(?:url|remote)\s*[:=]\s*(\S+)`)
+
+	var u upstream
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if m := commitRe.FindStringSubmatch(line); m != nil && u.Commit == "" {
+			u.Commit = strings.ToLower(m[1])
+		}
+		if m := repoRe.FindStringSubmatch(line); m != nil && u.Repo == "" {
+			u.Repo = m[1]
+		}
+	}
+
+	return u, u.Commit != ""
+}
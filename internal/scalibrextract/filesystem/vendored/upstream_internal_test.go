@@ -0,0 +1,116 @@
+package vendored
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+)
+
+func Test_upstreamFromMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dir    string
+		want   upstream
+		wantOk bool
+	}{
+		{
+			dir: "testdata/third_party/libfoo",
+			want: upstream{
+				Repo:   "https://github.com/example/libfoo",
+				Commit: "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+			},
+			wantOk: true,
+		},
+		{
+			dir: "testdata/third_party/libbar",
+			want: upstream{
+				Repo:   "https://github.com/example/libbar.git",
+				Commit: "9a6bd55c9d0722cb101fe85a3b22d89e4ff4fe52",
+			},
+			wantOk: true,
+		},
+		{
+			// the README mentions a commit, but not a full hash
+			dir:    "testdata/third_party/libbaz",
+			want:   upstream{},
+			wantOk: false,
+		},
+		{
+			dir:    "testdata/thirdparty/zlib",
+			want:   upstream{},
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := upstreamFromMetadata(scalibrfs.DirFS("."), tt.dir)
+			if ok != tt.wantOk {
+				t.Fatalf("upstreamFromMetadata(%q) ok = %v, want %v", tt.dir, ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("upstreamFromMetadata(%q) = %+v, want %+v", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_upstreamFromGitDir(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join("vendor", "libgit")
+
+	repo, err := git.PlainInit(filepath.Join(root, dir), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{"https://github.com/example/libgit.git"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, dir, "lib.c"), []byte("int lib(void) { return 0; }\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wt.Add("lib.c"); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := wt.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Unix(0, 0)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := upstreamFromGitDir(root, filepath.ToSlash(dir))
+	if !ok {
+		t.Fatalf("upstreamFromGitDir() did not identify the upstream")
+	}
+
+	want := upstream{Repo: "https://github.com/example/libgit.git", Commit: hash.String()}
+	if got != want {
+		t.Errorf("upstreamFromGitDir() = %+v, want %+v", got, want)
+	}
+
+	if _, ok := upstreamFromGitDir(root, "vendor"); ok {
+		t.Errorf("upstreamFromGitDir() identified an upstream for a directory without a .git directory")
+	}
+}
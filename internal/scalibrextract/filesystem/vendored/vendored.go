@@ -111,14 +111,30 @@ func (e *Extractor) FileRequired(fapi filesystem.FileAPI) bool {
 	return stat.IsDir()
 }
 
-// Extract determines the most likely package version from the directory and returns them as
-// commit hash inventory entries
+// Extract identifies the upstream project and commit of the vendored library and
+// returns it as a commit hash inventory entry.
+//
+// The upstream is identified from the library's own .git directory if present,
+// then from metadata files left by vendoring tools, and finally by estimating
+// the most likely version from the hashes of the library's files.
 func (e *Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
 	// todo: maybe we should return an error instead? need to double check we're always using FileRequired correctly first
 	if e.Disabled {
 		return inventory.Inventory{}, nil
 	}
 
+	// Libraries with a .git directory are otherwise only scanned by hashing,
+	// as git commit scanning does not include them
+	if e.ScanGitDir {
+		if u, ok := upstreamFromGitDir(input.Root, input.Path); ok {
+			return upstreamInventory(u, input.Path), nil
+		}
+	}
+
+	if u, ok := upstreamFromMetadata(input.FS, input.Path); ok {
+		return upstreamInventory(u, input.Path), nil
+	}
+
 	var packages []*extractor.Package
 
 	results, err := e.queryDetermineVersions(ctx, input.Path, input.FS, e.ScanGitDir)
@@ -142,6 +158,18 @@ func (e *Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (i
 	}, nil
 }
 
+func upstreamInventory(u upstream, path string) inventory.Inventory {
+	return inventory.Inventory{
+		Packages: []*extractor.Package{{
+			SourceCode: &extractor.SourceCodeIdentifier{
+				Repo:   u.Repo,
+				Commit: u.Commit,
+			},
+			Locations: []string{path},
+		}},
+	}
+}
+
 // ToPURL converts an inventory created by this extractor into a PURL.
 func (e *Extractor) ToPURL(_ *extractor.Package) *purl.PackageURL {
 	return nil
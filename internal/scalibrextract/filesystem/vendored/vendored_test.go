@@ -122,6 +122,38 @@ func TestExtractor_Extract(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "README.chromium metadata",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path:         "testdata/third_party/libfoo",
+				FakeScanRoot: cwd,
+			},
+			WantPackages: []*extractor.Package{
+				{
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/example/libfoo",
+						Commit: "4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+					},
+					Locations: []string{"testdata/third_party/libfoo"},
+				},
+			},
+		},
+		{
+			Name: "git-subrepo metadata",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path:         "testdata/third_party/libbar",
+				FakeScanRoot: cwd,
+			},
+			WantPackages: []*extractor.Package{
+				{
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/example/libbar.git",
+						Commit: "9a6bd55c9d0722cb101fe85a3b22d89e4ff4fe52",
+					},
+					Locations: []string{"testdata/third_party/libbar"},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
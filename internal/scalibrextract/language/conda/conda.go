@@ -0,0 +1,94 @@
+// Package conda maps the packages of conda environments to the packages that they
+// were built from, as conda packages are repackaged releases of packages from other
+// ecosystems (mostly PyPI) which is where their advisories are published.
+package conda
+
+import "strings"
+
+// pypiNames are the PyPI names of conda packages that are named differently on conda-forge
+var pypiNames = map[string]string{
+	"matplotlib-base":    "matplotlib",
+	"msgpack-python":     "msgpack",
+	"py-opencv":          "opencv-python",
+	"py-xgboost":         "xgboost",
+	"pytables":           "tables",
+	"python-flatbuffers": "flatbuffers",
+	"python-graphviz":    "graphviz",
+	"python-kaleido":     "kaleido",
+	"python-lmdb":        "lmdb",
+	"python-xxhash":      "xxhash",
+	"pytorch":            "torch",
+	"pytorch-cpu":        "torch",
+	"pytorch-gpu":        "torch",
+	"tensorflow-base":    "tensorflow",
+	"tensorflow-cpu":     "tensorflow",
+	"tensorflow-gpu":     "tensorflow",
+}
+
+// nonPyPINames are conda packages that are commonly installed into environments
+// but are not Python packages, such as interpreters, system libraries, and toolchains
+var nonPyPINames = map[string]bool{
+	"blas":            true,
+	"boost":           true,
+	"bzip2":           true,
+	"ca-certificates": true,
+	"cmake":           true,
+	"cudatoolkit":     true,
+	"cudnn":           true,
+	"curl":            true,
+	"ffmpeg":          true,
+	"gcc":             true,
+	"git":             true,
+	"go":              true,
+	"graphviz":        true,
+	"gxx":             true,
+	"hdf5":            true,
+	"make":            true,
+	"mkl":             true,
+	"nccl":            true,
+	"ncurses":         true,
+	"nodejs":          true,
+	"openblas":        true,
+	"openjdk":         true,
+	"openssl":         true,
+	"python":          true,
+	"python_abi":      true,
+	"readline":        true,
+	"rust":            true,
+	"sqlite":          true,
+	"tk":              true,
+	"tzdata":          true,
+	"xz":              true,
+	"zlib":            true,
+	"zstd":            true,
+}
+
+// nonPyPIPrefixes are prefixes of the names of conda packages that are not Python packages
+var nonPyPIPrefixes = []string{
+	"_",     // conda internal packages, such as _libgcc_mutex
+	"cuda-", // components of the CUDA toolkit
+	"lib",   // system libraries, such as libgcc-ng
+	"r-",    // R packages, which are published on CRAN
+}
+
+// PyPIName returns the name of the PyPI package that a conda package was built from,
+// or false if the conda package is not known to be a Python package.
+func PyPIName(name string) (string, bool) {
+	name = strings.ToLower(name)
+
+	if pypi, ok := pypiNames[name]; ok {
+		return pypi, true
+	}
+
+	if nonPyPINames[name] {
+		return "", false
+	}
+
+	for _, prefix := range nonPyPIPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return "", false
+		}
+	}
+
+	return name, true
+}
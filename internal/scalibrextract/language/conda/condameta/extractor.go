@@ -0,0 +1,116 @@
+// Package condameta extracts the packages installed into conda environments from
+// the metadata that conda records in conda-meta/*.json files.
+package condameta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "conda/condameta"
+)
+
+type packageMetadata struct {
+	Name    string   `json:"name"`
+	Version string   `json:"version"`
+	Depends []string `json:"depends"`
+	Files   []string `json:"files"`
+}
+
+// Extractor extracts the packages installed into conda environments.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for the json files in conda-meta directories
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	path := filepath.ToSlash(fapi.Path())
+
+	return filepath.Ext(path) == ".json" && filepath.Base(filepath.Dir(path)) == "conda-meta"
+}
+
+// installedPackages returns the packages of other ecosystems that were installed by
+// a conda package, based on the metadata that their installers write
+func installedPackages(files []string) []*extractor.Package {
+	packages := []*extractor.Package{}
+
+	for _, file := range files {
+		if m := cachedregexp.MustCompile(`(?:^|/)site-packages/([^/]+)-([^/-]+)\.(?:dist|egg)-info(?:/METADATA|/PKG-INFO)?$`).FindStringSubmatch(file); m != nil {
+			packages = append(packages, &extractor.Package{Name: m[1], Version: m[2], PURLType: purl.TypePyPi})
+			continue
+		}
+
+		// the version of R packages is only recorded in the DESCRIPTION file,
+		// so the version of the conda package is used for them
+		if m := cachedregexp.MustCompile(`(?i)^lib/R/library/([^/]+)/DESCRIPTION$`).FindStringSubmatch(file); m != nil {
+			packages = append(packages, &extractor.Package{Name: m[1], PURLType: purl.TypeCran})
+		}
+	}
+
+	return packages
+}
+
+// dependsOnPython returns true if the conda package requires a Python interpreter
+func dependsOnPython(depends []string) bool {
+	for _, dep := range depends {
+		name, _, _ := strings.Cut(dep, " ")
+		if name == "python" || name == "python_abi" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Extract extracts the packages from conda-meta/*.json files passed through the scan input.
+//
+// Conda packages are reported as the packages that they were built from: Python and R
+// packages are identified by the metadata that they install, and other conda packages
+// that depend on Python are assumed to be the PyPI package of the same name.
+// Conda packages of other software, such as system libraries, are skipped.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var meta packageMetadata
+	if err := json.NewDecoder(input.Reader).Decode(&meta); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := installedPackages(meta.Files)
+	if len(packages) == 0 && dependsOnPython(meta.Depends) {
+		if name, ok := conda.PyPIName(meta.Name); ok {
+			packages = append(packages, &extractor.Package{Name: name, PURLType: purl.TypePyPi})
+		}
+	}
+
+	for _, pkg := range packages {
+		if pkg.Version == "" {
+			pkg.Version = meta.Version
+		}
+		pkg.Locations = []string{input.Path}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,154 @@
+package condameta_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda/condameta"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "conda-meta/numpy-1.26.4-py311h64a7726_0.json", want: true},
+		{path: "opt/conda/envs/ml/conda-meta/numpy-1.26.4-py311h64a7726_0.json", want: true},
+		{path: "opt/conda/conda-meta/history", want: false},
+		{path: "opt/conda/conda-meta/state/numpy.json", want: false},
+		{path: "opt/conda/pkgs/numpy-1.26.4-py311h64a7726_0/info/index.json", want: false},
+		{path: "package.json", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := condameta.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/conda-meta/invalid.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "python package with dist-info",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/conda-meta/numpy-1.26.4-py311h64a7726_0.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "numpy",
+					Version:   "1.26.4",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/conda-meta/numpy-1.26.4-py311h64a7726_0.json"},
+				},
+			},
+		},
+		{
+			Name: "python package named differently on conda",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/conda-meta/pytorch-2.2.1-py3.11_cpu_0.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "torch",
+					Version:   "2.2.1",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/conda-meta/pytorch-2.2.1-py3.11_cpu_0.json"},
+				},
+			},
+		},
+		{
+			Name: "python package without dist-info",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/conda-meta/msgpack-python-1.0.7-py311h9547e67_0.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "msgpack",
+					Version:   "1.0.7",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/conda-meta/msgpack-python-1.0.7-py311h9547e67_0.json"},
+				},
+			},
+		},
+		{
+			Name: "r package",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/conda-meta/r-ggplot2-3.4.4-r43hc72bb7e_0.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "ggplot2",
+					Version:   "3.4.4",
+					PURLType:  purl.TypeCran,
+					Locations: []string{"testdata/conda-meta/r-ggplot2-3.4.4-r43hc72bb7e_0.json"},
+				},
+			},
+		},
+		{
+			Name: "system library",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/conda-meta/openssl-3.2.1-hd590300_0.json",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "python interpreter",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/conda-meta/python-3.11.8-hab00c5b_0.json",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "python abi",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/conda-meta/python_abi-3.11-4_cp311.json",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr := condameta.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Package environmentyml extracts the pinned packages of conda environment.yml files.
+package environmentyml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "conda/environmentyml"
+)
+
+type environmentFile struct {
+	Dependencies []yaml.Node `yaml:"dependencies"`
+}
+
+// pipSection is the section of the dependencies that are installed with pip
+type pipSection struct {
+	Pip []string `yaml:"pip"`
+}
+
+// Extractor extracts the pinned packages of conda environment.yml files.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for environment.yml and environment.yaml files
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	base := filepath.Base(fapi.Path())
+
+	return base == "environment.yml" || base == "environment.yaml"
+}
+
+// parseMatchSpec returns the name and exact version of a conda match spec, such as
+// "conda-forge::numpy=1.26.4=py311h64a7726_0" or "numpy==1.26.4".
+// The version is empty if the spec does not pin the package to one.
+func parseMatchSpec(spec string) (string, string) {
+	if i := strings.Index(spec, "::"); i >= 0 {
+		spec = spec[i+2:]
+	}
+	spec = strings.TrimSpace(spec)
+
+	end := strings.IndexAny(spec, " =<>!~[")
+	if end < 0 {
+		return spec, ""
+	}
+	name, rest := spec[:end], strings.TrimSpace(spec[end:])
+
+	var version string
+	switch {
+	case strings.HasPrefix(rest, "=="):
+		version = rest[2:]
+	case strings.HasPrefix(rest, "="):
+		// the build string can follow the version, e.g. "numpy=1.26.4=py311h64a7726_0"
+		version, _, _ = strings.Cut(rest[1:], "=")
+	case rest != "" && !strings.ContainsAny(rest[:1], "<>!~["):
+		// the space separated form, e.g. "numpy 1.26.4 py311h64a7726_0"
+		version, _, _ = strings.Cut(rest, " ")
+	}
+
+	// ranges, alternatives, and wildcards do not pin the package
+	if strings.ContainsAny(version, "<>!~,|*") {
+		return name, ""
+	}
+
+	return name, strings.TrimSpace(version)
+}
+
+// parsePipRequirement returns the name and exact version of a requirement in the
+// pip section of an environment, which are in the format of requirements.txt files
+func parsePipRequirement(req string) (string, string) {
+	req, _, _ = strings.Cut(req, ";")
+	req, _, _ = strings.Cut(req, "#")
+
+	name, version, found := strings.Cut(req, "==")
+	if !found || strings.ContainsAny(version, "<>!~,*") {
+		return "", ""
+	}
+	name, _, _ = strings.Cut(name, "[")
+
+	return strings.TrimSpace(name), strings.TrimPrefix(strings.TrimSpace(version), "=")
+}
+
+// Extract extracts the packages of environment.yml files passed through the scan input.
+//
+// Only packages that are pinned to an exact version are extracted. Conda packages are
+// reported as the PyPI packages that they were built from, and other conda packages
+// such as system libraries are skipped.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var env environmentFile
+	if err := yaml.NewDecoder(input.Reader).Decode(&env); err != nil && !errors.Is(err, io.EOF) {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+	add := func(name, version string) {
+		if name == "" || version == "" {
+			return
+		}
+		packages = append(packages, &extractor.Package{
+			Name:      name,
+			Version:   version,
+			PURLType:  purl.TypePyPi,
+			Locations: []string{input.Path},
+		})
+	}
+
+	for _, dep := range env.Dependencies {
+		switch dep.Kind {
+		case yaml.ScalarNode:
+			name, version := parseMatchSpec(dep.Value)
+			if pypi, ok := conda.PyPIName(name); ok {
+				add(pypi, version)
+			}
+		case yaml.MappingNode:
+			var section pipSection
+			if err := dep.Decode(&section); err != nil {
+				return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+			}
+			for _, req := range section.Pip {
+				add(parsePipRequirement(req))
+			}
+		}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
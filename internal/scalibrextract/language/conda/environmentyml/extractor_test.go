@@ -0,0 +1,108 @@
+package environmentyml_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda/environmentyml"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "environment.yml", want: true},
+		{path: "environment.yaml", want: true},
+		{path: "path/to/my/environment.yml", want: true},
+		{path: "environment.yml/file", want: false},
+		{path: "my-environment.yml", want: false},
+		{path: "env.yml", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := environmentyml.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func pypi(name, version string) *extractor.Package {
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypePyPi,
+		Locations: []string{"testdata/environment.yml"},
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid yaml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-yaml.yml",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.yml",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "pinned conda and pip packages",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/environment.yml",
+			},
+			WantPackages: []*extractor.Package{
+				pypi("numpy", "1.26.4"),
+				pypi("pandas", "2.2.1"),
+				pypi("scipy", "1.12.0"),
+				pypi("torch", "2.2.1"),
+				pypi("matplotlib", "3.8.3"),
+				pypi("transformers", "4.38.2"),
+				pypi("accelerate", "0.27.2"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr := environmentyml.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
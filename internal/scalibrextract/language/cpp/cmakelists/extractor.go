@@ -0,0 +1,322 @@
+// Package cmakelists extracts C/C++ dependencies declared with FetchContent and
+// ExternalProject in CMakeLists.txt and .cmake files.
+package cmakelists
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/utility/gitref"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cpp/cmakelists"
+)
+
+// archiveExtensions are the extensions of the source archives that dependencies are downloaded as
+var archiveExtensions = []string{".tar.gz", ".tar.xz", ".tar.bz2", ".tgz", ".zip"}
+
+// Extractor extracts the dependencies declared with FetchContent_Declare and
+// ExternalProject_Add in CMakeLists.txt and .cmake files.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for CMakeLists.txt and .cmake files, other than
+// those that CMake generates within its build directories
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	path := filepath.ToSlash(fapi.Path())
+
+	if strings.Contains("/"+path, "/CMakeFiles/") {
+		return false
+	}
+
+	return filepath.Base(path) == "CMakeLists.txt" || filepath.Ext(path) == ".cmake"
+}
+
+// Extract extracts dependencies from CMake files passed through the scan input.
+//
+// Dependencies are declared with the name of the dependency followed by where to
+// download it from, which is either a git repository and tag or the url of an archive:
+//
+//	FetchContent_Declare(
+//	  googletest
+//	  GIT_REPOSITORY https://github.com/google/googletest.git
+//	  GIT_TAG        v1.14.0
+//	)
+//
+// Dependencies pinned to a commit are returned with that commit, either from their
+// GIT_TAG or from the url of an archive of a commit on GitHub, and dependencies pinned
+// to a version tag or an archive of a release are returned with that version, as part of
+// the ConanCenter ecosystem which packages upstream C/C++ libraries under the same names.
+//
+// Variables are only expanded if they are set to a single value by the same file.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	commands, err := parseCommands(stripComments(string(content)))
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	variables := make(map[string]string)
+	packages := []*extractor.Package{}
+
+	for _, cmd := range commands {
+		switch strings.ToLower(cmd.name) {
+		case "set":
+			// cached variables are followed by their type and docstring
+			if len(cmd.args) == 2 || (len(cmd.args) > 2 && cmd.args[2] == "CACHE") {
+				variables[cmd.args[0]] = expandVariables(cmd.args[1], variables)
+			}
+		case "fetchcontent_declare", "externalproject_add":
+			pkg, ok := declarationToPackage(cmd.args, variables)
+			if !ok {
+				continue
+			}
+
+			// references to variables that are not known cannot be resolved ahead of time
+			ref := cmp.Or(pkg.Metadata.(*Metadata).GitTag, pkg.Metadata.(*Metadata).URL)
+			if pkg.Version == "" && pkg.SourceCode == nil && !strings.Contains(ref, "${") {
+				cmdlogger.Warnf(
+					"%s declares %s from %s, which is not pinned to a version or commit",
+					input.Path,
+					pkg.Name,
+					ref,
+				)
+			}
+
+			pkg.Locations = []string{input.Path}
+			packages = append(packages, pkg)
+		}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// command is an invocation of a CMake command along with its arguments
+type command struct {
+	name string
+	args []string
+}
+
+// stripComments removes line and bracket comments, leaving the contents of quoted arguments as-is
+func stripComments(content string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '"':
+			end := i + 1
+			for end < len(content) && content[end] != '"' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end, len(content)-1)
+			sb.WriteString(content[i : end+1])
+			i = end
+		case '#':
+			if matches := cachedregexp.MustCompile(`^#\[(=*)\[`).FindStringSubmatch(content[i:]); matches != nil {
+				closing := "]" + matches[1] + "]"
+				end := strings.Index(content[i:], closing)
+				if end < 0 {
+					return sb.String()
+				}
+				i += end + len(closing) - 1
+
+				continue
+			}
+
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				return sb.String()
+			}
+			i += end - 1
+		default:
+			sb.WriteByte(content[i])
+		}
+	}
+
+	return sb.String()
+}
+
+// parseCommands parses the invocations of commands, returning an error if the
+// arguments of a command are not closed
+func parseCommands(content string) ([]command, error) {
+	var commands []command
+
+	re := cachedregexp.MustCompile(`(?m)^[ \t]*([A-Za-z_][A-Za-z0-9_]*)[ \t]*\(`)
+	for _, loc := range re.FindAllStringSubmatchIndex(content, -1) {
+		name := content[loc[2]:loc[3]]
+
+		args, ok := parseArguments(content[loc[1]:])
+		if !ok {
+			return nil, fmt.Errorf("unterminated arguments of %s", name)
+		}
+
+		commands = append(commands, command{name: name, args: args})
+	}
+
+	return commands, nil
+}
+
+// parseArguments parses the arguments of a command up until its closing parenthesis,
+// returning false if the arguments are not closed
+func parseArguments(content string) ([]string, bool) {
+	var args []string
+	depth := 0
+
+	re := cachedregexp.MustCompile(`"((?:[^"\\]|\\.)*)"|\(|\)|[^\s()"]+`)
+	for _, matches := range re.FindAllStringSubmatch(content, -1) {
+		switch token := matches[0]; {
+		case token == "(":
+			depth++
+		case token == ")":
+			if depth == 0 {
+				return args, true
+			}
+			depth--
+		case strings.HasPrefix(token, `"`):
+			args = append(args, matches[1])
+		default:
+			args = append(args, token)
+		}
+	}
+
+	return nil, false
+}
+
+// expandVariables replaces references to variables that are known with their
+// values, leaving references to unknown variables as-is
+func expandVariables(value string, variables map[string]string) string {
+	return cachedregexp.MustCompile(`\$\{([A-Za-z0-9_.+-]+)\}`).ReplaceAllStringFunc(value, func(ref string) string {
+		if v, ok := variables[ref[2:len(ref)-1]]; ok {
+			return v
+		}
+
+		return ref
+	})
+}
+
+// declarationToPackage returns the dependency declared by the arguments of a FetchContent_Declare
+// or ExternalProject_Add command, returning false if it is not downloaded from a git repository
+// or url (such as when it is from a local directory)
+func declarationToPackage(args []string, variables map[string]string) (*extractor.Package, bool) {
+	if len(args) == 0 {
+		return nil, false
+	}
+
+	metadata := &Metadata{}
+	for i := 1; i+1 < len(args); i++ {
+		value := expandVariables(args[i+1], variables)
+
+		switch args[i] {
+		case "GIT_REPOSITORY":
+			metadata.GitRepository = value
+		case "GIT_TAG":
+			metadata.GitTag = value
+		case "URL":
+			metadata.URL = value
+		default:
+			continue
+		}
+		i++
+	}
+
+	pkg := &extractor.Package{
+		Name:     strings.ToLower(args[0]),
+		Metadata: metadata,
+	}
+
+	switch {
+	case metadata.GitRepository != "":
+		if gitref.IsCommit(metadata.GitTag) {
+			pkg.SourceCode = &extractor.SourceCodeIdentifier{Repo: metadata.GitRepository, Commit: metadata.GitTag}
+		} else if version, ok := parseVersion(metadata.GitTag); ok {
+			pkg.Version = version
+			pkg.PURLType = purl.TypeConan
+		}
+	case metadata.URL != "":
+		if repo, commit, ok := parseCommitArchiveURL(metadata.URL); ok {
+			pkg.SourceCode = &extractor.SourceCodeIdentifier{Repo: repo, Commit: commit}
+		} else if version, ok := parseReleaseArchiveURL(metadata.URL); ok {
+			pkg.Version = version
+			pkg.PURLType = purl.TypeConan
+		}
+	default:
+		return nil, false
+	}
+
+	return pkg, true
+}
+
+// parseCommitArchiveURL returns the repository and commit of the url of an
+// archive of a commit on GitHub, such as "https://github.com/owner/repo/archive/<sha>.zip"
+func parseCommitArchiveURL(url string) (string, string, bool) {
+	matches := cachedregexp.MustCompile(`^(https://github\.com/[^/]+/[^/]+)/archive/([0-9a-f]{40})\.(?:tar\.gz|zip)$`).FindStringSubmatch(url)
+	if matches == nil {
+		return "", "", false
+	}
+
+	return matches[1], matches[2], true
+}
+
+// parseReleaseArchiveURL returns the version from the url of an archive of a release,
+// which is either in the name of the archive (e.g. ".../zlib-1.3.1.tar.gz") or in
+// the tag of the release that the archive belongs to (e.g. ".../download/v3.11.3/json.tar.xz")
+func parseReleaseArchiveURL(url string) (string, bool) {
+	segments := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	if len(segments) < 2 {
+		return "", false
+	}
+
+	file := segments[len(segments)-1]
+	for _, ext := range archiveExtensions {
+		file = strings.TrimSuffix(file, ext)
+	}
+
+	if version, ok := parseVersion(file); ok {
+		return version, true
+	}
+
+	return parseVersion(segments[len(segments)-2])
+}
+
+// parseVersion returns the version from a tag or the name of an archive that
+// ends with a full version, such as "v1.2.3", "release-1.12.1", and "curl-8_4_0"
+func parseVersion(ref string) (string, bool) {
+	matches := cachedregexp.MustCompile(`^[A-Za-z_-]*?v?(\d+(?:[._]\d+)+)$`).FindStringSubmatch(ref)
+	if matches == nil {
+		return "", false
+	}
+
+	return strings.ReplaceAll(matches[1], "_", "."), true
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,214 @@
+package cmakelists_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/cmakelists"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "CMakeLists.txt", want: true},
+		{path: "path/to/project/CMakeLists.txt", want: true},
+		{path: "cmake/dependencies.cmake", want: true},
+		{path: "build/CMakeFiles/3.28.1/CMakeSystem.cmake", want: false},
+		{path: "CMakeFiles/Makefile.cmake", want: false},
+		{path: "CMakeCache.txt", want: false},
+		{path: "cmakelists.txt.bak", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := cmakelists.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "unterminated",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/unterminated/CMakeLists.txt",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty/CMakeLists.txt",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "basic",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/basic/CMakeLists.txt",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "googletest",
+					Version:   "1.14.0",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/basic/CMakeLists.txt"},
+					Metadata: &cmakelists.Metadata{
+						GitRepository: "https://github.com/google/googletest.git",
+						GitTag:        "v1.14.0",
+					},
+				},
+				{
+					Name: "fmt",
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/fmtlib/fmt.git",
+						Commit: "e69e5f977d458f2650bb346dadf2ad30c5320281",
+					},
+					Locations: []string{"testdata/basic/CMakeLists.txt"},
+					Metadata: &cmakelists.Metadata{
+						GitRepository: "https://github.com/fmtlib/fmt.git",
+						GitTag:        "e69e5f977d458f2650bb346dadf2ad30c5320281",
+					},
+				},
+				{
+					Name:      "catch2",
+					Locations: []string{"testdata/basic/CMakeLists.txt"},
+					Metadata: &cmakelists.Metadata{
+						GitRepository: "https://github.com/catchorg/Catch2.git",
+						GitTag:        "devel",
+					},
+				},
+				{
+					Name:      "curl",
+					Version:   "8.4.0",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/basic/CMakeLists.txt"},
+					Metadata: &cmakelists.Metadata{
+						GitRepository: "https://github.com/curl/curl.git",
+						GitTag:        "curl-8_4_0",
+					},
+				},
+			},
+		},
+		{
+			Name: "archives",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/archives/deps.cmake",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "json",
+					Version:   "3.11.3",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/archives/deps.cmake"},
+					Metadata: &cmakelists.Metadata{
+						URL: "https://github.com/nlohmann/json/releases/download/v3.11.3/json.tar.xz",
+					},
+				},
+				{
+					Name:      "zlib",
+					Version:   "1.3.1",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/archives/deps.cmake"},
+					Metadata: &cmakelists.Metadata{
+						URL: "https://zlib.net/fossils/zlib-1.3.1.tar.gz",
+					},
+				},
+				{
+					Name: "abseil",
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/abseil/abseil-cpp",
+						Commit: "4447c7562e3bc702ade25105912dce503f0c4010",
+					},
+					Locations: []string{"testdata/archives/deps.cmake"},
+					Metadata: &cmakelists.Metadata{
+						URL: "https://github.com/abseil/abseil-cpp/archive/4447c7562e3bc702ade25105912dce503f0c4010.zip",
+					},
+				},
+				{
+					Name:      "nightly",
+					Locations: []string{"testdata/archives/deps.cmake"},
+					Metadata: &cmakelists.Metadata{
+						URL: "https://example.com/downloads/nightly.tar.gz",
+					},
+				},
+			},
+		},
+		{
+			Name: "variables",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/variables/CMakeLists.txt",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "googletest",
+					Version:   "1.14.0",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/variables/CMakeLists.txt"},
+					Metadata: &cmakelists.Metadata{
+						GitRepository: "https://github.com/google/googletest.git",
+						GitTag:        "v1.14.0",
+					},
+				},
+				{
+					Name: "fmt",
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/fmtlib/fmt.git",
+						Commit: "e69e5f977d458f2650bb346dadf2ad30c5320281",
+					},
+					Locations: []string{"testdata/variables/CMakeLists.txt"},
+					Metadata: &cmakelists.Metadata{
+						GitRepository: "https://github.com/fmtlib/fmt.git",
+						GitTag:        "e69e5f977d458f2650bb346dadf2ad30c5320281",
+					},
+				},
+				{
+					Name:      "spdlog",
+					Locations: []string{"testdata/variables/CMakeLists.txt"},
+					Metadata: &cmakelists.Metadata{
+						GitRepository: "https://github.com/gabime/spdlog.git",
+						GitTag:        "${SPDLOG_TAG}",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := cmakelists.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package cmakelists
+
+// Metadata holds the metadata for a dependency declared in a CMake file
+type Metadata struct {
+	// GitRepository is the url of the repository the dependency is cloned from, if any
+	GitRepository string
+	// GitTag is the branch, tag, or commit of the repository that is checked out, if any
+	GitTag string
+	// URL is where an archive of the dependency is downloaded from, if it is not cloned
+	URL string
+}
@@ -0,0 +1,22 @@
+include(FetchContent)
+
+FetchContent_Declare(
+  json
+  URL https://github.com/nlohmann/json/releases/download/v3.11.3/json.tar.xz
+  URL_HASH SHA256=d6c65aca6b1ed68e7a182f4757257b107ae403032760ed6ef121c9d55e81757d
+)
+
+FetchContent_Declare(
+  zlib
+  URL https://zlib.net/fossils/zlib-1.3.1.tar.gz
+)
+
+FetchContent_Declare(
+  abseil
+  URL https://github.com/abseil/abseil-cpp/archive/4447c7562e3bc702ade25105912dce503f0c4010.zip
+)
+
+FetchContent_Declare(
+  nightly
+  URL https://example.com/downloads/nightly.tar.gz
+)
@@ -0,0 +1,159 @@
+// Package unrealproject extracts the engine and plugins used by Unreal Engine
+// projects from .uproject and .uplugin files.
+package unrealproject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cpp/unrealproject"
+
+	// EngineName is the name that the engine that a project is associated with is returned as
+	EngineName = "UnrealEngine"
+)
+
+// pluginReference is an entry in the "Plugins" of a descriptor
+type pluginReference struct {
+	Name           string `json:"Name"`
+	Enabled        bool   `json:"Enabled"`
+	MarketplaceURL string `json:"MarketplaceURL"`
+}
+
+// projectDescriptor is the contents of a .uproject file
+type projectDescriptor struct {
+	EngineAssociation string            `json:"EngineAssociation"`
+	Plugins           []pluginReference `json:"Plugins"`
+}
+
+// pluginDescriptor is the contents of a .uplugin file
+type pluginDescriptor struct {
+	VersionName    string            `json:"VersionName"`
+	EngineVersion  string            `json:"EngineVersion"`
+	MarketplaceURL string            `json:"MarketplaceURL"`
+	Plugins        []pluginReference `json:"Plugins"`
+}
+
+// Extractor extracts the engine and third-party plugins used by Unreal Engine projects.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for .uproject files, and for .uplugin files
+// that are not part of the engine itself
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	p := filepath.ToSlash(fapi.Path())
+
+	switch path.Ext(p) {
+	case ".uproject":
+		return true
+	case ".uplugin":
+		// the engine bundles hundreds of plugins that are versioned with it
+		return !slices.Contains(strings.Split(p, "/"), "Engine")
+	}
+
+	return false
+}
+
+// Extract extracts the engine and plugins from .uproject and .uplugin files passed
+// through the scan input.
+//
+// Projects are returned with the version of the engine that they are associated with,
+// unless they are associated with a custom build of the engine (which is identified by a
+// GUID), along with the enabled plugins that are from Fab. Plugins are returned with
+// their own version, along with the version of the engine that they were built for
+// and any plugins from Fab that they depend on.
+//
+// Plugins from Fab are referenced without a version, and the engine and plugins do not
+// have advisories in any OSV ecosystem, so they are returned without an ecosystem so
+// that they are reported as not being scanned rather than being silently missed.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	packages := []*extractor.Package{}
+
+	if path.Ext(filepath.ToSlash(input.Path)) == ".uproject" {
+		var project projectDescriptor
+		if err := json.NewDecoder(input.Reader).Decode(&project); err != nil {
+			return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+		}
+
+		if isEngineVersion(project.EngineAssociation) {
+			packages = append(packages, newPackage(EngineName, project.EngineAssociation, ""))
+		}
+
+		packages = append(packages, marketplacePlugins(project.Plugins)...)
+	} else {
+		var descriptor pluginDescriptor
+		if err := json.NewDecoder(input.Reader).Decode(&descriptor); err != nil {
+			return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+		}
+
+		name := strings.TrimSuffix(path.Base(filepath.ToSlash(input.Path)), ".uplugin")
+		packages = append(packages, newPackage(name, descriptor.VersionName, descriptor.MarketplaceURL))
+
+		if isEngineVersion(descriptor.EngineVersion) {
+			packages = append(packages, newPackage(EngineName, descriptor.EngineVersion, ""))
+		}
+
+		packages = append(packages, marketplacePlugins(descriptor.Plugins)...)
+	}
+
+	for _, pkg := range packages {
+		pkg.Locations = []string{input.Path}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// marketplacePlugins returns the enabled plugins that are from Fab, as other plugins
+// are either part of the engine or the project itself
+func marketplacePlugins(refs []pluginReference) []*extractor.Package {
+	var packages []*extractor.Package
+
+	for _, ref := range refs {
+		if !ref.Enabled || ref.MarketplaceURL == "" || ref.Name == "" {
+			continue
+		}
+
+		packages = append(packages, newPackage(ref.Name, "", ref.MarketplaceURL))
+	}
+
+	return packages
+}
+
+func newPackage(name, version, marketplaceURL string) *extractor.Package {
+	return &extractor.Package{
+		Name:     name,
+		Version:  version,
+		Metadata: &Metadata{MarketplaceURL: marketplaceURL},
+	}
+}
+
+// isEngineVersion returns whether the engine association is a version such as "5.3"
+// rather than the GUID of a custom build or the path to a source checkout
+func isEngineVersion(association string) bool {
+	return cachedregexp.MustCompile(`^\d+\.\d+(\.\d+)?$`).MatchString(association)
+}
+
+var _ filesystem.Extractor = Extractor{}
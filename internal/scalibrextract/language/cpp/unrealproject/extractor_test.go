@@ -0,0 +1,129 @@
+package unrealproject_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/unrealproject"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "Shooter.uproject", want: true},
+		{path: "path/to/Shooter/Shooter.uproject", want: true},
+		{path: "Plugins/VaRest/VaRest.uplugin", want: true},
+		{path: "path/to/Shooter/Plugins/Marketplace/VaRest/VaRest.uplugin", want: true},
+		{path: "UnrealEngine/Engine/Plugins/Runtime/Json/Json.uplugin", want: false},
+		{path: "Shooter.uproject.bak", want: false},
+		{path: "Config/DefaultEngine.ini", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := unrealproject.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid/Shooter.uproject",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty/Shooter.uproject",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "project",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/project/Shooter.uproject",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      unrealproject.EngineName,
+					Version:   "5.3",
+					Locations: []string{"testdata/project/Shooter.uproject"},
+					Metadata:  &unrealproject.Metadata{},
+				},
+				{
+					Name:      "VaRest",
+					Locations: []string{"testdata/project/Shooter.uproject"},
+					Metadata:  &unrealproject.Metadata{MarketplaceURL: "com.epicgames.launcher://ue/marketplace/content/e47be161e7a24e928560290abd5dcc4f"},
+				},
+			},
+		},
+		{
+			Name: "custom_engine",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/custom-engine/Shooter.uproject",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "plugin",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/plugin/Plugins/VaRest/VaRest.uplugin",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "VaRest",
+					Version:   "1.1-r38",
+					Locations: []string{"testdata/plugin/Plugins/VaRest/VaRest.uplugin"},
+					Metadata:  &unrealproject.Metadata{MarketplaceURL: "com.epicgames.launcher://ue/marketplace/content/e47be161e7a24e928560290abd5dcc4f"},
+				},
+				{
+					Name:      unrealproject.EngineName,
+					Version:   "5.3.0",
+					Locations: []string{"testdata/plugin/Plugins/VaRest/VaRest.uplugin"},
+					Metadata:  &unrealproject.Metadata{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := unrealproject.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
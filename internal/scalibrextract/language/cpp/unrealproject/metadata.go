@@ -0,0 +1,7 @@
+package unrealproject
+
+// Metadata holds the metadata for the engine or a plugin used by an Unreal Engine project
+type Metadata struct {
+	// MarketplaceURL is the Fab (formerly Marketplace) listing of the plugin, if it is from there
+	MarketplaceURL string
+}
@@ -0,0 +1,149 @@
+// Package vcpkg extracts C/C++ dependencies from vcpkg.json manifests.
+package vcpkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "cpp/vcpkg"
+)
+
+// dependency is an entry in the "dependencies" of a manifest, which can either
+// be the name of a port or an object with the name and any constraints of the port
+type dependency struct {
+	Name           string `json:"name"`
+	MinimumVersion string `json:"version>="`
+}
+
+func (d *dependency) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &d.Name); err == nil {
+		return nil
+	}
+
+	type plain dependency
+
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+// override is an entry in the "overrides" of a manifest, which pins a port to an exact version
+// using whichever of the version fields matches the versioning scheme of the port
+type override struct {
+	Name          string `json:"name"`
+	Version       string `json:"version"`
+	VersionSemver string `json:"version-semver"`
+	VersionDate   string `json:"version-date"`
+	VersionString string `json:"version-string"`
+}
+
+func (o override) version() string {
+	for _, v := range []string{o.Version, o.VersionSemver, o.VersionDate, o.VersionString} {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+type manifest struct {
+	Dependencies []dependency `json:"dependencies"`
+	Overrides    []override   `json:"overrides"`
+}
+
+// Extractor extracts the ports that a project depends on from its vcpkg.json manifest.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for vcpkg.json files
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	return filepath.Base(fapi.Path()) == "vcpkg.json"
+}
+
+// Extract extracts ports from vcpkg.json files passed through the scan input.
+//
+// vcpkg selects the version of each port from the baseline of its registry, which is
+// only known by checking out that registry, unless the port is pinned by an override.
+// Ports are therefore only returned with a version if they have an override, with any
+// minimum version from a "version>=" constraint kept in the metadata, and ports that
+// are pinned by an override without being a direct dependency are included as they are
+// usually transitive dependencies.
+//
+// vcpkg ports package upstream C/C++ libraries under the same names and versions as
+// ConanCenter, which is the ecosystem that they are returned as.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var m manifest
+	if err := json.NewDecoder(input.Reader).Decode(&m); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+	byName := make(map[string]*extractor.Package)
+
+	for _, dep := range m.Dependencies {
+		if dep.Name == "" {
+			continue
+		}
+
+		if pkg, ok := byName[dep.Name]; ok {
+			if dep.MinimumVersion != "" {
+				pkg.Metadata.(*Metadata).MinimumVersion = dep.MinimumVersion
+			}
+
+			continue
+		}
+
+		pkg := &extractor.Package{
+			Name:      dep.Name,
+			PURLType:  purl.TypeConan,
+			Locations: []string{input.Path},
+			Metadata:  &Metadata{MinimumVersion: dep.MinimumVersion},
+		}
+		byName[dep.Name] = pkg
+		packages = append(packages, pkg)
+	}
+
+	for _, o := range m.Overrides {
+		if o.Name == "" || o.version() == "" {
+			continue
+		}
+
+		pkg, ok := byName[o.Name]
+		if !ok {
+			pkg = &extractor.Package{
+				Name:      o.Name,
+				PURLType:  purl.TypeConan,
+				Locations: []string{input.Path},
+				Metadata:  &Metadata{},
+			}
+			byName[o.Name] = pkg
+			packages = append(packages, pkg)
+		}
+
+		pkg.Version = o.version()
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
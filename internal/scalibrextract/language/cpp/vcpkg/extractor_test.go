@@ -0,0 +1,143 @@
+package vcpkg_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/vcpkg"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "vcpkg.json", want: true},
+		{path: "path/to/project/vcpkg.json", want: true},
+		{path: "vcpkg-configuration.json", want: false},
+		{path: "vcpkg-lock.json", want: false},
+		{path: "vcpkg.json.bak", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := vcpkg.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid/vcpkg.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty/vcpkg.json",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "basic",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/basic/vcpkg.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "fmt",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/basic/vcpkg.json"},
+					Metadata:  &vcpkg.Metadata{},
+				},
+				{
+					Name:      "openssl",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/basic/vcpkg.json"},
+					Metadata:  &vcpkg.Metadata{MinimumVersion: "3.0.7"},
+				},
+				{
+					Name:      "curl",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/basic/vcpkg.json"},
+					Metadata:  &vcpkg.Metadata{},
+				},
+			},
+		},
+		{
+			Name: "overrides",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/overrides/vcpkg.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "fmt",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/overrides/vcpkg.json"},
+					Metadata:  &vcpkg.Metadata{},
+				},
+				{
+					Name:      "openssl",
+					Version:   "3.0.8",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/overrides/vcpkg.json"},
+					Metadata:  &vcpkg.Metadata{MinimumVersion: "3.0.7"},
+				},
+				{
+					Name:      "zlib",
+					Version:   "1.2.13",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/overrides/vcpkg.json"},
+					Metadata:  &vcpkg.Metadata{},
+				},
+				{
+					Name:      "tzdata",
+					Version:   "2023-03-22",
+					PURLType:  purl.TypeConan,
+					Locations: []string{"testdata/overrides/vcpkg.json"},
+					Metadata:  &vcpkg.Metadata{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := vcpkg.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
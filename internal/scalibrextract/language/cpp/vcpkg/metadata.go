@@ -0,0 +1,7 @@
+package vcpkg
+
+// Metadata holds the metadata for a port in a vcpkg.json manifest
+type Metadata struct {
+	// MinimumVersion is the version that the port is constrained to be at least by "version>=", if any
+	MinimumVersion string
+}
@@ -0,0 +1,242 @@
+// Package unitypackages extracts the packages used by Unity projects from
+// Packages/packages-lock.json and Packages/manifest.json files.
+package unitypackages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/utility/gitref"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "csharp/unitypackages"
+
+	manifestFileName = "manifest.json"
+	lockFileName     = "packages-lock.json"
+
+	// unityRegistry is the registry that packages are resolved from unless
+	// their name is within the scope of a scoped registry
+	unityRegistry = "https://packages.unity.com"
+)
+
+// npmRegistries are the registries whose packages are published to npm,
+// meaning that they can be checked against the npm ecosystem
+var npmRegistries = map[string]struct{}{
+	"registry.npmjs.org": {},
+	"registry.npmjs.com": {},
+}
+
+type lockfileEntry struct {
+	Version string `json:"version"`
+	Source  string `json:"source"`
+	URL     string `json:"url"`
+	Hash    string `json:"hash"`
+}
+
+type lockfile struct {
+	Dependencies map[string]lockfileEntry `json:"dependencies"`
+}
+
+type scopedRegistry struct {
+	URL    string   `json:"url"`
+	Scopes []string `json:"scopes"`
+}
+
+type manifest struct {
+	Dependencies     map[string]string `json:"dependencies"`
+	ScopedRegistries []scopedRegistry  `json:"scopedRegistries"`
+}
+
+// registryFor returns the registry that the package with the given name is resolved from,
+// which is the scoped registry with the longest scope that matches the name
+func (m manifest) registryFor(name string) string {
+	registry, longest := unityRegistry, 0
+
+	for _, sr := range m.ScopedRegistries {
+		for _, scope := range sr.Scopes {
+			if len(scope) > longest && (name == scope || strings.HasPrefix(name, scope+".")) {
+				registry, longest = sr.URL, len(scope)
+			}
+		}
+	}
+
+	return registry
+}
+
+// Extractor extracts the registry and git packages used by a Unity project.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for the manifest.json and packages-lock.json files
+// within the Packages directory of a Unity project
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	p := filepath.ToSlash(fapi.Path())
+	base := path.Base(p)
+
+	if base != manifestFileName && base != lockFileName {
+		return false
+	}
+
+	return path.Base(path.Dir(p)) == "Packages"
+}
+
+// Extract extracts packages from Unity manifest.json and packages-lock.json files
+// passed through the scan input.
+//
+// The lockfile records the exact versions and commits that packages were resolved to,
+// so manifests are only extracted if there is no lockfile next to them.
+//
+// Packages from registries that publish to npm are returned as npm packages, while
+// packages from other registries (including Unity's own) are returned without an
+// ecosystem as there are no advisories for them. Packages from git are returned with
+// the commit that they are pinned to, if it is known, so that they can be checked by commit.
+// Built-in, embedded, and local packages are skipped as they are part of either the
+// editor or the project itself.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	if path.Base(filepath.ToSlash(input.Path)) == lockFileName {
+		return extractLockfile(input)
+	}
+
+	if input.FS != nil {
+		lockPath := path.Join(path.Dir(filepath.ToSlash(input.Path)), lockFileName)
+		if _, err := fs.Stat(input.FS, lockPath); err == nil {
+			return inventory.Inventory{}, nil
+		}
+	}
+
+	return extractManifest(input)
+}
+
+func extractLockfile(input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var lf lockfile
+	if err := json.NewDecoder(input.Reader).Decode(&lf); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+
+	for _, name := range slices.Sorted(maps.Keys(lf.Dependencies)) {
+		entry := lf.Dependencies[name]
+
+		var pkg *extractor.Package
+		switch entry.Source {
+		case "registry":
+			pkg = newRegistryPackage(name, entry.Version, entry.URL)
+		case "git":
+			pkg = newGitPackage(name, entry.Version)
+			if gitref.IsCommit(entry.Hash) {
+				pkg.SourceCode.Commit = entry.Hash
+			}
+		default:
+			continue
+		}
+
+		pkg.Locations = []string{input.Path}
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+func extractManifest(input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var m manifest
+	if err := json.NewDecoder(input.Reader).Decode(&m); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+
+	for _, name := range slices.Sorted(maps.Keys(m.Dependencies)) {
+		version := m.Dependencies[name]
+
+		var pkg *extractor.Package
+		switch {
+		case strings.HasPrefix(version, "file:"):
+			continue
+		case isGitURL(version):
+			pkg = newGitPackage(name, version)
+		default:
+			pkg = newRegistryPackage(name, version, m.registryFor(name))
+		}
+
+		pkg.Locations = []string{input.Path}
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+func newRegistryPackage(name, version, registry string) *extractor.Package {
+	pkg := &extractor.Package{
+		Name:     name,
+		Version:  version,
+		Metadata: &Metadata{Source: "registry", Registry: registry},
+	}
+
+	if u, err := url.Parse(registry); err == nil {
+		if _, ok := npmRegistries[u.Hostname()]; ok {
+			pkg.PURLType = purl.TypeNPM
+		}
+	}
+
+	return pkg
+}
+
+// newGitPackage returns a package from the given git url, which is pinned to a
+// commit if the revision after the "#" of the url is one
+func newGitPackage(name, gitURL string) *extractor.Package {
+	repo, revision, _ := strings.Cut(gitURL, "#")
+	repo, _, _ = strings.Cut(repo, "?")
+
+	pkg := &extractor.Package{
+		Name:       name,
+		SourceCode: &extractor.SourceCodeIdentifier{Repo: strings.TrimPrefix(repo, "git+")},
+		Metadata:   &Metadata{Source: "git"},
+	}
+
+	if gitref.IsCommit(revision) {
+		pkg.SourceCode.Commit = revision
+	}
+
+	return pkg
+}
+
+// isGitURL returns whether the version of a dependency in a manifest is a git url,
+// which Unity recognizes by its protocol or by the path ending in ".git"
+func isGitURL(version string) bool {
+	if strings.HasPrefix(version, "git+") || strings.HasPrefix(version, "git@") || strings.HasPrefix(version, "ssh://") {
+		return true
+	}
+
+	repo, _, _ := strings.Cut(version, "#")
+	repo, _, _ = strings.Cut(repo, "?")
+
+	return strings.HasSuffix(repo, ".git")
+}
+
+var _ filesystem.Extractor = Extractor{}
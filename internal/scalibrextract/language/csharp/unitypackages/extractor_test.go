@@ -0,0 +1,197 @@
+package unitypackages_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/csharp/unitypackages"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "Packages/manifest.json", want: true},
+		{path: "Packages/packages-lock.json", want: true},
+		{path: "path/to/game/Packages/manifest.json", want: true},
+		{path: "path/to/game/Packages/packages-lock.json", want: true},
+		{path: "manifest.json", want: false},
+		{path: "path/to/extension/manifest.json", want: false},
+		{path: "path/to/project/packages-lock.json", want: false},
+		{path: "Packages/packages.lock.json", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := unitypackages.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid/Packages/manifest.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty/Packages/manifest.json",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "lockfile",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/lock/Packages/packages-lock.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:       "com.cysharp.unitask",
+					SourceCode: &extractor.SourceCodeIdentifier{Repo: "https://github.com/Cysharp/UniTask.git", Commit: "f9fd769be7c634610f2a61aa914a1a55f34740e1"},
+					Locations:  []string{"testdata/lock/Packages/packages-lock.json"},
+					Metadata:   &unitypackages.Metadata{Source: "git"},
+				},
+				{
+					Name:      "com.neuecc.messagepipe",
+					Version:   "1.7.4",
+					Locations: []string{"testdata/lock/Packages/packages-lock.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://package.openupm.com"},
+				},
+				{
+					Name:      "com.unity.textmeshpro",
+					Version:   "3.0.6",
+					Locations: []string{"testdata/lock/Packages/packages-lock.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://packages.unity.com"},
+				},
+				{
+					Name:      "lodash",
+					Version:   "4.17.20",
+					PURLType:  purl.TypeNPM,
+					Locations: []string{"testdata/lock/Packages/packages-lock.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://registry.npmjs.org"},
+				},
+			},
+		},
+		{
+			Name: "manifest",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/manifest/Packages/manifest.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:       "com.cysharp.unitask",
+					SourceCode: &extractor.SourceCodeIdentifier{Repo: "https://github.com/Cysharp/UniTask.git", Commit: "f9fd769be7c634610f2a61aa914a1a55f34740e1"},
+					Locations:  []string{"testdata/manifest/Packages/manifest.json"},
+					Metadata:   &unitypackages.Metadata{Source: "git"},
+				},
+				{
+					Name:       "com.example.branch",
+					SourceCode: &extractor.SourceCodeIdentifier{Repo: "git@github.com:example/unity-package.git"},
+					Locations:  []string{"testdata/manifest/Packages/manifest.json"},
+					Metadata:   &unitypackages.Metadata{Source: "git"},
+				},
+				{
+					Name:      "com.neuecc.messagepipe",
+					Version:   "1.7.4",
+					Locations: []string{"testdata/manifest/Packages/manifest.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://package.openupm.com"},
+				},
+				{
+					Name:      "com.unity.textmeshpro",
+					Version:   "3.0.6",
+					Locations: []string{"testdata/manifest/Packages/manifest.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://packages.unity.com"},
+				},
+				{
+					Name:      "lodash",
+					Version:   "4.17.20",
+					PURLType:  purl.TypeNPM,
+					Locations: []string{"testdata/manifest/Packages/manifest.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://registry.npmjs.org"},
+				},
+			},
+		},
+		{
+			Name: "manifest_with_lockfile",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/with-lock/Packages/manifest.json",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "lockfile_with_manifest",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/with-lock/Packages/packages-lock.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:       "com.cysharp.unitask",
+					SourceCode: &extractor.SourceCodeIdentifier{Repo: "https://github.com/Cysharp/UniTask.git", Commit: "f9fd769be7c634610f2a61aa914a1a55f34740e1"},
+					Locations:  []string{"testdata/with-lock/Packages/packages-lock.json"},
+					Metadata:   &unitypackages.Metadata{Source: "git"},
+				},
+				{
+					Name:      "com.neuecc.messagepipe",
+					Version:   "1.7.4",
+					Locations: []string{"testdata/with-lock/Packages/packages-lock.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://package.openupm.com"},
+				},
+				{
+					Name:      "com.unity.textmeshpro",
+					Version:   "3.0.6",
+					Locations: []string{"testdata/with-lock/Packages/packages-lock.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://packages.unity.com"},
+				},
+				{
+					Name:      "lodash",
+					Version:   "4.17.20",
+					PURLType:  purl.TypeNPM,
+					Locations: []string{"testdata/with-lock/Packages/packages-lock.json"},
+					Metadata:  &unitypackages.Metadata{Source: "registry", Registry: "https://registry.npmjs.org"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := unitypackages.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
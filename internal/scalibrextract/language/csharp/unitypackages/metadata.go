@@ -0,0 +1,9 @@
+package unitypackages
+
+// Metadata holds the metadata for a package used by a Unity project
+type Metadata struct {
+	// Source is where the package comes from, which is either "registry" or "git"
+	Source string
+	// Registry is the url of the registry that the package comes from, if it is from a registry
+	Registry string
+}
@@ -0,0 +1,130 @@
+// Package modulestxt extracts vendored Go modules from vendor/modules.txt files.
+package modulestxt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "go/modulestxt"
+)
+
+// Extractor extracts Go modules from the vendor/modules.txt file written by `go mod vendor`.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for modules.txt files directly within a vendor directory
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	return filepath.Base(filepath.Dir(fapi.Path())) == "vendor" && filepath.Base(fapi.Path()) == "modules.txt"
+}
+
+// Extract extracts packages from vendor/modules.txt files passed through the scan input.
+//
+// The format is not formally specified, but is written by the go command as a
+// "# <module> <version> [=> <replacement> [<version>]]" line for each module,
+// followed by "## " lines of markers (e.g. "## explicit; go 1.21") and the
+// packages that are vendored from that module.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	packages := []*extractor.Package{}
+
+	var current *extractor.Package
+
+	scanner := bufio.NewScanner(input.Reader)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "## "):
+			if current == nil {
+				continue
+			}
+			for _, marker := range strings.Split(strings.TrimPrefix(line, "## "), ";") {
+				if strings.TrimSpace(marker) == "explicit" {
+					current.Metadata = &Metadata{Explicit: true}
+				}
+			}
+		case strings.HasPrefix(line, "# "):
+			name, version, err := parseModuleLine(strings.TrimPrefix(line, "# "))
+			if err != nil {
+				return inventory.Inventory{}, fmt.Errorf("could not extract from %s: line %d: %w", input.Path, lineNum, err)
+			}
+
+			current = nil
+			if version == "" {
+				continue
+			}
+
+			current = &extractor.Package{
+				Name:      name,
+				Version:   strings.TrimPrefix(version, "v"),
+				PURLType:  purl.TypeGolang,
+				Metadata:  &Metadata{Explicit: false},
+				Locations: []string{input.Path},
+			}
+			packages = append(packages, current)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// parseModuleLine parses a module line (without the leading "# ") into the
+// name and version of the module that is actually vendored.
+//
+// The version is empty if the module is not part of the build list or has been
+// replaced by a local directory, since there is nothing to check in either case
+func parseModuleLine(line string) (string, string, error) {
+	mod, replacement, replaced := strings.Cut(line, "=>")
+
+	fields := strings.Fields(mod)
+	if len(fields) == 0 || len(fields) > 2 {
+		return "", "", fmt.Errorf("invalid module line %q", line)
+	}
+
+	// Modules without a version are only listed to record a replace directive
+	if len(fields) == 1 {
+		return fields[0], "", nil
+	}
+
+	if !replaced {
+		return fields[0], fields[1], nil
+	}
+
+	fields = strings.Fields(replacement)
+	switch len(fields) {
+	case 1:
+		// local directory replacements have no version
+		return fields[0], "", nil
+	case 2:
+		return fields[0], fields[1], nil
+	default:
+		return "", "", fmt.Errorf("invalid module replacement %q", line)
+	}
+}
+
+var _ filesystem.Extractor = Extractor{}
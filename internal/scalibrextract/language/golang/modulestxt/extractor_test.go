@@ -0,0 +1,133 @@
+package modulestxt_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/golang/modulestxt"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "vendor/modules.txt", want: true},
+		{path: "path/to/vendor/modules.txt", want: true},
+		{path: "modules.txt", want: false},
+		{path: "vendor/other/modules.txt", want: false},
+		{path: "vendor/modules.txt.bak", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := modulestxt.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid/vendor/modules.txt",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty/vendor/modules.txt",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "basic",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/basic/vendor/modules.txt",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "github.com/BurntSushi/toml",
+					Version:   "1.4.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/basic/vendor/modules.txt"},
+					Metadata:  &modulestxt.Metadata{Explicit: true},
+				},
+				{
+					Name:      "golang.org/x/sys",
+					Version:   "0.20.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/basic/vendor/modules.txt"},
+					Metadata:  &modulestxt.Metadata{Explicit: false},
+				},
+				{
+					Name:      "golang.org/x/text",
+					Version:   "0.3.7",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/basic/vendor/modules.txt"},
+					Metadata:  &modulestxt.Metadata{Explicit: true},
+				},
+			},
+		},
+		{
+			Name: "replaced",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/replaced/vendor/modules.txt",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "github.com/gogo/protobuf",
+					Version:   "1.3.2",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/replaced/vendor/modules.txt"},
+					Metadata:  &modulestxt.Metadata{Explicit: true},
+				},
+				{
+					Name:      "golang.org/x/crypto",
+					Version:   "0.1.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/replaced/vendor/modules.txt"},
+					Metadata:  &modulestxt.Metadata{Explicit: false},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := modulestxt.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package modulestxt
+
+// Metadata holds the metadata for a module in vendor/modules.txt
+type Metadata struct {
+	// Explicit is true if the module is required directly by the main module's go.mod,
+	// as indicated by the "## explicit" marker
+	Explicit bool
+}
@@ -0,0 +1,264 @@
+package pomxmlenhanceable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"deps.dev/util/maven"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/javalockfile"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/datasource"
+	mavenutil "github.com/google/osv-scanner/v2/internal/utility/maven"
+)
+
+// offlineExtractor extracts Maven packages from pom.xml files without any network access,
+// by computing their effective POM from the default profiles, the parents, and the
+// imported BOMs that can be found either next to the pom.xml or in the local repository.
+//
+// Parents and BOMs that cannot be found are skipped, which leaves out dependencies with
+// versions that use their properties, and leaves the dependencies that they manage
+// without a version.
+type offlineExtractor struct {
+	// localRepository is the path to the local Maven repository, which is not
+	// read from if it is empty
+	localRepository string
+}
+
+func newOfflineExtractor() *offlineExtractor {
+	return &offlineExtractor{localRepository: datasource.DefaultMavenSettings().LocalRepositoryPath()}
+}
+
+func (e *offlineExtractor) Name() string { return "java/pomxml" }
+
+func (e *offlineExtractor) Version() int { return 0 }
+
+func (e *offlineExtractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{Network: plugin.NetworkOffline}
+}
+
+func (e *offlineExtractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == "pom.xml" || filepath.Ext(api.Path()) == ".pom"
+}
+
+func (e *offlineExtractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var project maven.Project
+	if err := datasource.NewMavenDecoder(input.Reader).Decode(&project); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	if err := e.computeEffectivePOM(&project, input.FS, input.Path); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("failed to compute effective pom of %s: %w", input.Path, err)
+	}
+
+	details := map[string]*extractor.Package{}
+
+	for _, dep := range project.Dependencies {
+		g, a, found := strings.Cut(dep.Name(), ":")
+		if !found {
+			return inventory.Inventory{}, fmt.Errorf("invalid package name: %s", dep.Name())
+		}
+
+		depType := ""
+		if dep.Type != "jar" {
+			depType = string(dep.Type)
+		}
+
+		metadata := javalockfile.Metadata{
+			ArtifactID:   a,
+			GroupID:      g,
+			Type:         depType,
+			Classifier:   string(dep.Classifier),
+			DepGroupVals: []string{},
+		}
+		// only non-default scopes are dependency groups, with compile being the default
+		if scope := strings.TrimSpace(string(dep.Scope)); scope != "" && scope != "compile" {
+			metadata.DepGroupVals = []string{scope}
+		}
+
+		details[dep.Name()] = &extractor.Package{
+			Name:      dep.Name(),
+			Version:   parseResolvedVersion(dep.Version),
+			PURLType:  purl.TypeMaven,
+			Locations: []string{input.Path},
+			Metadata:  &metadata,
+		}
+	}
+
+	return inventory.Inventory{Packages: slices.Collect(maps.Values(details))}, nil
+}
+
+// computeEffectivePOM merges the default profiles and parents of the project into it,
+// resolves its properties, and fills in the versions of its dependencies from the
+// dependency management of the project and of the BOMs that it imports
+func (e *offlineExtractor) computeEffectivePOM(project *maven.Project, fsys fs.FS, projectPath string) error {
+	if err := e.mergeInherited(project, fsys, projectPath); err != nil {
+		return err
+	}
+
+	project.ProcessDependencies(func(groupID, artifactID, version maven.String) (maven.DependencyManagement, error) {
+		bom, err := e.loadFromLocalRepository(maven.ProjectKey{GroupID: groupID, ArtifactID: artifactID, Version: version})
+		if err != nil {
+			return maven.DependencyManagement{}, err
+		}
+
+		// the BOMs imported by this BOM are imported by ProcessDependencies itself
+		if err := e.mergeInherited(&bom, nil, ""); err != nil {
+			return maven.DependencyManagement{}, err
+		}
+
+		return bom.DependencyManagement, nil
+	})
+
+	return nil
+}
+
+// mergeInherited merges the default profiles and parents of the project into it and
+// resolves its properties, with parents being looked for in the filesystem that the
+// project is in if it has a path, and otherwise only in the local repository
+func (e *offlineExtractor) mergeInherited(project *maven.Project, fsys fs.FS, projectPath string) error {
+	// empty JDK and ActivationOS indicates merging the default profiles
+	if err := project.MergeProfiles("", maven.ActivationOS{}); err != nil {
+		return fmt.Errorf("failed to merge profiles: %w", err)
+	}
+
+	if err := e.mergeParents(project, fsys, projectPath); err != nil {
+		return err
+	}
+
+	if err := project.Interpolate(); err != nil {
+		return fmt.Errorf("failed to interpolate: %w", err)
+	}
+
+	return nil
+}
+
+// mergeParents merges the parents of the project into it in the same manner as Maven,
+// looking for each parent first at the relative path given by its child, and then
+// in the local repository, stopping at the first parent that cannot be found
+func (e *offlineExtractor) mergeParents(project *maven.Project, fsys fs.FS, projectPath string) error {
+	current := project.Parent
+	currentPath := projectPath
+	visited := make(map[maven.ProjectKey]bool, mavenutil.MaxParent)
+
+	for range mavenutil.MaxParent {
+		if current.GroupID == "" || current.ArtifactID == "" || current.Version == "" {
+			break
+		}
+		if visited[current.ProjectKey] {
+			return errors.New("a cycle of parents is detected")
+		}
+		visited[current.ProjectKey] = true
+
+		parent, parentPath, found := loadParentLocal(fsys, current, currentPath)
+
+		if !found {
+			// once a parent is not next to its child, none of its parents will be either
+			fsys = nil
+
+			var err error
+			parent, err = e.loadFromLocalRepository(current.ProjectKey)
+			if err != nil || mavenutil.ProjectKey(parent) != current.ProjectKey || parent.Packaging != "pom" {
+				break
+			}
+		}
+		currentPath = parentPath
+
+		if err := parent.MergeProfiles("", maven.ActivationOS{}); err != nil {
+			return fmt.Errorf("failed to merge profiles of parent %s: %w", current.Name(), err)
+		}
+
+		project.MergeParent(parent)
+		current = parent.Parent
+	}
+
+	return nil
+}
+
+// loadParentLocal loads the parent from the filesystem that its child is in, returning
+// whether it was found at the relative path given by the child along with its path
+func loadParentLocal(fsys fs.FS, parent maven.Parent, childPath string) (maven.Project, string, bool) {
+	if fsys == nil || childPath == "" {
+		return maven.Project{}, "", false
+	}
+
+	relativePath := string(parent.RelativePath)
+	if relativePath == "" {
+		relativePath = "../pom.xml"
+	}
+
+	parentPath := path.Join(path.Dir(filepath.ToSlash(childPath)), filepath.ToSlash(relativePath))
+	if info, err := fs.Stat(fsys, parentPath); err == nil && info.IsDir() {
+		parentPath = path.Join(parentPath, "pom.xml")
+	}
+
+	proj, err := decodeProject(fsys, parentPath)
+	if err != nil {
+		return maven.Project{}, "", false
+	}
+
+	// only projects with the expected identifiers and packaging are the parent
+	if mavenutil.ProjectKey(proj) != parent.ProjectKey || proj.Packaging != "pom" {
+		return maven.Project{}, "", false
+	}
+
+	return proj, parentPath, true
+}
+
+// loadFromLocalRepository loads the project with the given key from the local repository
+func (e *offlineExtractor) loadFromLocalRepository(key maven.ProjectKey) (maven.Project, error) {
+	if e.localRepository == "" {
+		return maven.Project{}, errors.New("no local repository")
+	}
+
+	pomPath := path.Join(
+		strings.ReplaceAll(string(key.GroupID), ".", "/"),
+		string(key.ArtifactID),
+		string(key.Version),
+		fmt.Sprintf("%s-%s.pom", key.ArtifactID, key.Version),
+	)
+
+	return decodeProject(os.DirFS(e.localRepository), pomPath)
+}
+
+func decodeProject(fsys fs.FS, name string) (maven.Project, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return maven.Project{}, err
+	}
+	defer f.Close()
+
+	var proj maven.Project
+	if err := datasource.NewMavenDecoder(f).Decode(&proj); err != nil {
+		return maven.Project{}, fmt.Errorf("failed to unmarshal %s: %w", name, err)
+	}
+
+	return proj, nil
+}
+
+// parseResolvedVersion returns the version of the version requirement, which is
+// the lower bound of the requirement if it is a range
+func parseResolvedVersion(version maven.String) string {
+	results := cachedregexp.MustCompile(`[[(]?(.*?)(?:,|[)\]]|$)`).FindStringSubmatch(string(version))
+
+	// the first capture group will always exist, but might be empty
+	if results == nil || results[1] == "" {
+		return ""
+	}
+
+	return results[1]
+}
+
+var _ filesystem.Extractor = &offlineExtractor{}
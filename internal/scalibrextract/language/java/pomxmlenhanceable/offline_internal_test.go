@@ -0,0 +1,157 @@
+package pomxmlenhanceable
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/javalockfile"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+)
+
+func TestOfflineExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "properties and dependencies from default profiles",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/profiles/pom.xml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "org.springframework:spring-core",
+					Version:   "5.3.18",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/profiles/pom.xml"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:   "spring-core",
+						GroupID:      "org.springframework",
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:      "junit:junit",
+					Version:   "4.12",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/profiles/pom.xml"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:   "junit",
+						GroupID:      "junit",
+						DepGroupVals: []string{"test"},
+					},
+				},
+			},
+		},
+		{
+			Name: "properties and dependency management from a local parent",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/parent/app/pom.xml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "org.springframework:spring-core",
+					Version:   "5.3.18",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/parent/app/pom.xml"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:   "spring-core",
+						GroupID:      "org.springframework",
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:      "com.google.guava:guava",
+					Version:   "31.0-jre",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/parent/app/pom.xml"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:   "guava",
+						GroupID:      "com.google.guava",
+						DepGroupVals: []string{},
+					},
+				},
+			},
+		},
+		{
+			Name: "properties from a parent in the local repository",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/repository-parent/pom.xml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "org.apache.logging.log4j:log4j-core",
+					Version:   "2.14.1",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/repository-parent/pom.xml"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:   "log4j-core",
+						GroupID:      "org.apache.logging.log4j",
+						DepGroupVals: []string{},
+					},
+				},
+			},
+		},
+		{
+			Name: "dependency management from boms in the local repository",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/bom/pom.xml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "com.fasterxml.jackson.core:jackson-databind",
+					Version:   "2.12.1",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/bom/pom.xml"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:   "jackson-databind",
+						GroupID:      "com.fasterxml.jackson.core",
+						DepGroupVals: []string{},
+					},
+				},
+				{
+					Name:      "org.apache.logging.log4j:log4j-api",
+					Version:   "2.14.1",
+					PURLType:  purl.TypeMaven,
+					Locations: []string{"testdata/bom/pom.xml"},
+					Metadata: &javalockfile.Metadata{
+						ArtifactID:   "log4j-api",
+						GroupID:      "org.apache.logging.log4j",
+						DepGroupVals: []string{},
+					},
+				},
+			},
+		},
+		{
+			// dependencies with properties that cannot be resolved are skipped when interpolating
+			Name: "parent that cannot be found",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/missing-parent/pom.xml",
+			},
+			WantPackages: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := offlineExtractor{localRepository: "testdata/repository"}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/google/osv-scalibr/extractor/filesystem"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxml"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/java/pomxmlnet"
 	"github.com/google/osv-scalibr/inventory"
 	"github.com/google/osv-scalibr/plugin"
@@ -20,8 +19,9 @@ type Extractor struct {
 	actual filesystem.Extractor
 }
 
-// New returns a new instance of the extractor.
-func New() filesystem.Extractor { return &Extractor{actual: pomxml.New()} }
+// New returns a new instance of the extractor, which computes the effective POM
+// of pom.xml files offline until it is enhanced.
+func New() filesystem.Extractor { return &Extractor{actual: newOfflineExtractor()} }
 
 // Name of the extractor
 func (e *Extractor) Name() string { return Name }
@@ -0,0 +1,169 @@
+// Package wrapperproperties extracts the versions of Maven and Gradle pinned by the
+// maven-wrapper.properties and gradle-wrapper.properties files of their wrappers.
+package wrapperproperties
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/toolchain"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "java/wrapperproperties"
+
+	mavenWrapperFileName  = "maven-wrapper.properties"
+	gradleWrapperFileName = "gradle-wrapper.properties"
+)
+
+// Extractor extracts the build tools pinned by Maven and Gradle wrapper properties files.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for maven-wrapper.properties files within .mvn/wrapper
+// and gradle-wrapper.properties files within gradle/wrapper
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	p := filepath.ToSlash(fapi.Path())
+
+	switch path.Base(p) {
+	case mavenWrapperFileName:
+		return strings.HasSuffix(path.Dir(p), ".mvn/wrapper")
+	case gradleWrapperFileName:
+		return strings.HasSuffix(path.Dir(p), "gradle/wrapper")
+	}
+
+	return false
+}
+
+// Extract extracts the build tools pinned by a wrapper properties file passed through the scan input.
+//
+// The version of Maven or Gradle is taken from the name of the distribution that the wrapper
+// downloads, and the version of the Maven wrapper itself from either its "wrapperVersion"
+// property or the name of the jar that it downloads. A warning is printed if a distribution
+// is used whose version cannot be determined, such as one from a custom mirror.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	props, err := parseProperties(input)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	type pin struct {
+		tool    string
+		version string
+		from    string
+	}
+
+	var pins []pin
+
+	if path.Base(filepath.ToSlash(input.Path)) == mavenWrapperFileName {
+		if url := props["distributionUrl"]; url != "" {
+			pins = append(pins, pin{"maven", matchVersion(`/apache-maven-([^/]+)-bin\.(?:zip|tar\.gz)$`, url), url})
+		}
+
+		switch {
+		case props["wrapperVersion"] != "":
+			pins = append(pins, pin{"maven-wrapper", props["wrapperVersion"], props["wrapperVersion"]})
+		case props["wrapperUrl"] != "":
+			url := props["wrapperUrl"]
+			pins = append(pins, pin{"maven-wrapper", matchVersion(`/maven-wrapper-([^/]+)\.jar$`, url), url})
+		}
+	} else if url := props["distributionUrl"]; url != "" {
+		pins = append(pins, pin{"gradle", matchVersion(`/gradle-([^/]+)-(?:bin|all)\.zip$`, url), url})
+	}
+
+	packages := []*extractor.Package{}
+
+	for _, p := range pins {
+		pkg, ok := toolchain.NewPackage(p.tool, p.version)
+		if !ok {
+			cmdlogger.Warnf("%s pins %s to %s, which is not a specific version", input.Path, p.tool, p.from)
+
+			continue
+		}
+
+		pkg.Locations = []string{input.Path}
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// matchVersion returns the version captured by the pattern from the url, if it matches
+func matchVersion(pattern, url string) string {
+	matches := cachedregexp.MustCompile(pattern).FindStringSubmatch(url)
+	if matches == nil {
+		return ""
+	}
+
+	return matches[1]
+}
+
+// parseProperties parses the key-value pairs of a Java properties file, unescaping
+// the escaped characters that are commonly written by the wrappers (e.g. "https\://")
+func parseProperties(input *filesystem.ScanInput) (map[string]string, error) {
+	props := make(map[string]string)
+
+	scanner := bufio.NewScanner(input.Reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		sep := strings.IndexFunc(line, func(r rune) bool {
+			return r == '=' || r == ':'
+		})
+		if sep < 0 {
+			continue
+		}
+
+		key := strings.TrimSpace(line[:sep])
+		value := strings.TrimSpace(line[sep+1:])
+
+		props[key] = unescape(value)
+	}
+
+	return props, scanner.Err()
+}
+
+func unescape(value string) string {
+	var sb strings.Builder
+
+	escaped := false
+	for _, r := range value {
+		if r == '\\' && !escaped {
+			escaped = true
+
+			continue
+		}
+
+		escaped = false
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,140 @@
+package wrapperproperties_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/toolchain"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/java/wrapperproperties"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".mvn/wrapper/maven-wrapper.properties", want: true},
+		{path: "path/to/project/.mvn/wrapper/maven-wrapper.properties", want: true},
+		{path: "gradle/wrapper/gradle-wrapper.properties", want: true},
+		{path: "path/to/project/gradle/wrapper/gradle-wrapper.properties", want: true},
+		{path: "maven-wrapper.properties", want: false},
+		{path: "gradle-wrapper.properties", want: false},
+		{path: ".mvn/wrapper/maven-wrapper.jar", want: false},
+		{path: "gradle/wrapper/gradle-wrapper.jar", want: false},
+		{path: "gradle.properties", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := wrapperproperties.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty/gradle/wrapper/gradle-wrapper.properties",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "maven",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/maven/.mvn/wrapper/maven-wrapper.properties",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "org.apache.maven:maven-core",
+					Version:   "3.9.6",
+					Locations: []string{"testdata/maven/.mvn/wrapper/maven-wrapper.properties"},
+					Metadata:  &toolchain.Metadata{Tool: "maven", Pin: "3.9.6"},
+				},
+				{
+					Name:      "org.apache.maven.wrapper:maven-wrapper",
+					Version:   "3.3.2",
+					Locations: []string{"testdata/maven/.mvn/wrapper/maven-wrapper.properties"},
+					Metadata:  &toolchain.Metadata{Tool: "maven-wrapper", Pin: "3.3.2"},
+				},
+			},
+		},
+		{
+			Name: "maven_wrapper_jar",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/maven-legacy/.mvn/wrapper/maven-wrapper.properties",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "org.apache.maven:maven-core",
+					Version:   "3.8.1",
+					Locations: []string{"testdata/maven-legacy/.mvn/wrapper/maven-wrapper.properties"},
+					Metadata:  &toolchain.Metadata{Tool: "maven", Pin: "3.8.1"},
+				},
+				{
+					Name:      "org.apache.maven.wrapper:maven-wrapper",
+					Version:   "3.1.0",
+					Locations: []string{"testdata/maven-legacy/.mvn/wrapper/maven-wrapper.properties"},
+					Metadata:  &toolchain.Metadata{Tool: "maven-wrapper", Pin: "3.1.0"},
+				},
+			},
+		},
+		{
+			Name: "gradle",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/gradle/gradle/wrapper/gradle-wrapper.properties",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "org.gradle:gradle-tooling-api",
+					Version:   "8.5",
+					Locations: []string{"testdata/gradle/gradle/wrapper/gradle-wrapper.properties"},
+					Metadata:  &toolchain.Metadata{Tool: "gradle", Pin: "8.5"},
+				},
+			},
+		},
+		{
+			Name: "custom_distribution",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/custom/gradle/wrapper/gradle-wrapper.properties",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := wrapperproperties.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+// Package jsbundle extracts the packages embedded in JavaScript bundles, such as the
+// output of webpack and rollup, for when the node_modules and lockfiles that the
+// bundles were built from are not available.
+package jsbundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "javascript/jsbundle"
+
+	// DetectedFromSourceMap is for packages detected from the paths of the sources in the source map of the bundle
+	DetectedFromSourceMap = "source-map"
+	// DetectedFromLicenseBanner is for packages detected from a license banner comment
+	DetectedFromLicenseBanner = "license-banner"
+	// DetectedFromFingerprint is for packages detected from a known pattern in their code
+	DetectedFromFingerprint = "fingerprint"
+
+	// maxFileSize is the size of the largest bundle or source map that is read,
+	// as larger files are unlikely to be bundles and are expensive to search
+	maxFileSize = 32 << 20
+)
+
+// semverPattern matches a version of an npm package
+const semverPattern = `\d+\.\d+\.\d+(?:-[0-9A-Za-z.]+)?`
+
+// bannerNames maps the names that libraries use in their license banners
+// to their npm packages, where the two are not the same when lowercased
+var bannerNames = map[string]string{
+	"vue.js":    "vue",
+	"moment.js": "moment",
+	"popper.js": "@popperjs/core",
+	"three.js":  "three",
+}
+
+// notBannerNames are words that license banners start with that are not the names of packages
+var notBannerNames = map[string]struct{}{
+	"copyright": {},
+	"license":   {},
+	"version":   {},
+	"v":         {},
+}
+
+// fingerprint is a pattern in the code of a package that includes its version,
+// which survives minification so it can be found in bundles without banners
+type fingerprint struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+var fingerprints = []fingerprint{
+	{
+		// var VERSION = '4.17.21'; var LARGE_ARRAY_SIZE = 200; var CORE_ERROR_TEXT = 'Unsupported core-js use...'
+		name:    "lodash",
+		pattern: cachedregexp.MustCompile(`['"](` + semverPattern + `)['"]\s*[,;]\s*(?:var\s+)?[\w$]+\s*=\s*200\s*[,;]\s*(?:var\s+)?[\w$]+\s*=\s*['"]Unsupported core-js use`),
+	},
+	{
+		// var version = "3.7.1", jQuery = function( selector, context ) { return new jQuery.fn.init( selector, context ); }
+		name:    "jquery",
+		pattern: cachedregexp.MustCompile(`['"](` + semverPattern + `)['"]\s*,\s*[\w$]+\s*=\s*function\s*\(\s*[\w$]+\s*,\s*[\w$]+\s*\)\s*\{\s*return\s+new\s+[\w$]+\.fn\.init\(`),
+	},
+	{
+		// (store.versions || (store.versions = [])).push({ version: '3.37.1', mode: IS_PURE ? 'pure' : 'global', ...
+		name:    "core-js",
+		pattern: cachedregexp.MustCompile(`\bversion\s*:\s*['"](` + semverPattern + `)['"]\s*,\s*mode\s*:\s*[^,]*['"](?:pure|global)['"]`),
+	},
+	{
+		// injectIntoDevTools({ ..., version: "18.3.1", rendererPackageName: "react-dom" })
+		name:    "react-dom",
+		pattern: cachedregexp.MustCompile(`\bversion\s*:\s*['"](` + semverPattern + `)['"]\s*,\s*rendererPackageName\s*:\s*['"]react-dom['"]`),
+	},
+}
+
+// Extractor extracts the packages embedded in JavaScript bundles.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for JavaScript files that are not within node_modules,
+// as the packages in node_modules are extracted from their own metadata
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	p := filepath.ToSlash(fapi.Path())
+
+	switch path.Ext(p) {
+	case ".js", ".mjs", ".cjs":
+	default:
+		return false
+	}
+
+	if strings.HasPrefix(p, "node_modules/") || strings.Contains(p, "/node_modules/") {
+		return false
+	}
+
+	// Stat costs performance, so perform it after the name checks
+	stat, err := fapi.Stat()
+	if err != nil {
+		return false
+	}
+
+	return stat.Size() <= maxFileSize
+}
+
+// found collects the packages detected in a bundle, keeping the first way that each was detected
+type found struct {
+	location string
+	packages []*extractor.Package
+	seen     map[string]struct{}
+}
+
+func (f *found) add(name, version, detectedFrom string) {
+	key := name + "@" + version
+	if _, ok := f.seen[key]; ok {
+		return
+	}
+	f.seen[key] = struct{}{}
+
+	f.packages = append(f.packages, &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypeNPM,
+		Locations: []string{f.location},
+		Metadata:  &Metadata{DetectedFrom: detectedFrom},
+	})
+}
+
+// Extract extracts the packages embedded in the JavaScript bundle passed through the scan input.
+//
+// Packages are detected on a best-effort basis from:
+//   - the paths of the sources in the source map of the bundle, for package managers
+//     that include the version of each package in the path that it is installed to
+//   - the license banners of the bundle, of the file that webpack extracts them to,
+//     and of the original sources in the source map
+//   - known patterns in the code of popular packages that include their version
+//
+// Only packages with a version are returned, as they cannot be checked for vulnerabilities otherwise.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	content, err := io.ReadAll(io.LimitReader(input.Reader, maxFileSize))
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	f := &found{location: input.Path, seen: map[string]struct{}{}}
+	dir := path.Dir(filepath.ToSlash(input.Path))
+
+	if sm := readSourceMap(input.FS, filepath.ToSlash(input.Path), content); sm != nil {
+		for _, source := range sm.Sources {
+			if name, version, ok := packageFromSourcePath(source); ok {
+				f.add(name, version, DetectedFromSourceMap)
+			}
+		}
+
+		for _, source := range sm.SourcesContent {
+			findBanners(f, []byte(source))
+			findFingerprints(f, []byte(source))
+		}
+	}
+
+	findBanners(f, content)
+
+	if m := cachedregexp.MustCompile(`For license information please see ([^\s*]+)`).FindSubmatch(content); m != nil {
+		if licenses, ok := readFile(input.FS, path.Join(dir, string(m[1]))); ok {
+			findBanners(f, licenses)
+		}
+	}
+
+	findFingerprints(f, content)
+
+	return inventory.Inventory{Packages: f.packages}, nil
+}
+
+type sourceMap struct {
+	Sources        []string `json:"sources"`
+	SourcesContent []string `json:"sourcesContent"`
+}
+
+// readSourceMap reads the source map of the bundle, which is either inlined into the
+// bundle as a data URL, in the file referenced by its sourceMappingURL comment, or in
+// a file with the same name as the bundle and a ".map" extension
+func readSourceMap(fsys fs.FS, bundlePath string, content []byte) *sourceMap {
+	mapPath := bundlePath + ".map"
+
+	if m := cachedregexp.MustCompile(`//[#@]\s*sourceMappingURL=(\S+)\s*$`).FindSubmatch(bytes.TrimSpace(content)); m != nil {
+		url := string(m[1])
+
+		if data, ok := strings.CutPrefix(url, "data:application/json;"); ok {
+			encoded, ok := strings.CutPrefix(strings.TrimPrefix(data, "charset=utf-8;"), "base64,")
+			if !ok {
+				return nil
+			}
+
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil
+			}
+
+			return parseSourceMap(decoded)
+		}
+
+		// source maps that are served from elsewhere cannot be read
+		if strings.Contains(url, ":") {
+			return nil
+		}
+
+		mapPath = path.Join(path.Dir(bundlePath), url)
+	}
+
+	data, ok := readFile(fsys, mapPath)
+	if !ok {
+		return nil
+	}
+
+	return parseSourceMap(data)
+}
+
+func parseSourceMap(data []byte) *sourceMap {
+	var sm sourceMap
+	if err := json.Unmarshal(data, &sm); err != nil {
+		return nil
+	}
+
+	return &sm
+}
+
+// readFile reads the file if it exists and is not too large to be searched
+func readFile(fsys fs.FS, p string) ([]byte, bool) {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxFileSize))
+	if err != nil {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// packageFromSourcePath returns the package that a source in a source map is from, for
+// the package managers that install packages to paths that include their version:
+//
+//	node_modules/.pnpm/@babel+runtime@7.24.0/node_modules/@babel/runtime/helpers/extends.js
+//	.yarn/cache/lodash-npm-4.17.21-6382451519-eb835a2e51.zip/node_modules/lodash/lodash.js
+func packageFromSourcePath(source string) (string, string, bool) {
+	if m := cachedregexp.MustCompile(`node_modules/\.pnpm/((?:@[^/@+]+\+)?[^/@+]+)@(` + semverPattern + `)(?:[_(/]|$)`).FindStringSubmatch(source); m != nil {
+		return strings.Replace(m[1], "+", "/", 1), m[2], true
+	}
+
+	if m := cachedregexp.MustCompile(`-npm-(` + semverPattern + `)-[0-9a-f]+-[0-9a-f]+\.zip/node_modules/((?:@[^/]+/)?[^/]+)/`).FindStringSubmatch(source); m != nil {
+		return m[2], m[1], true
+	}
+
+	return "", "", false
+}
+
+// findBanners finds the packages named in license banner comments, which are kept in
+// bundles by minifiers and are usually in one of the forms of:
+//
+//	/*! jQuery v3.7.1 | (c) OpenJS Foundation and other contributors | jquery.org/license */
+//	/*! @license DOMPurify 3.0.8 | (c) Cure53 and other contributors */
+//	/*!
+//	 * Bootstrap v5.3.3 (https://getbootstrap.com/)
+//	 */
+//	/** @license React v16.14.0
+//	 * react-dom.production.min.js
+//	 */
+func findBanners(f *found, content []byte) {
+	banners := cachedregexp.MustCompile(`/\*[*!]\s*(?:\*\s*)?(?:@license\s+)?(@?[A-Za-z][\w.\-]*(?:/[\w.\-]+)?)\s+v?(`+semverPattern+`)\b`).FindAllSubmatchIndex(content, -1)
+
+	for _, m := range banners {
+		name := strings.ToLower(string(content[m[2]:m[3]]))
+		if _, ok := notBannerNames[name]; ok {
+			continue
+		}
+
+		if mapped, ok := bannerNames[name]; ok {
+			name = mapped
+		}
+
+		// the banners of React are shared by all of its packages, which are named
+		// by the file of the package that follows the banner
+		if name == "react" {
+			if file := cachedregexp.MustCompile(`^\s*\*\s*([a-z-]+)\.(?:production|development|profiling)(?:\.min)?\.js`).FindSubmatch(content[m[1]:]); file != nil {
+				name = string(file[1])
+			}
+		}
+
+		if !cachedregexp.MustCompile(`^(?:@[a-z0-9~][a-z0-9._~-]*/)?[a-z0-9~][a-z0-9._~-]*$`).MatchString(name) {
+			continue
+		}
+
+		f.add(name, string(content[m[4]:m[5]]), DetectedFromLicenseBanner)
+	}
+}
+
+// findFingerprints finds the packages that have known patterns in their code
+func findFingerprints(f *found, content []byte) {
+	for _, fp := range fingerprints {
+		for _, m := range fp.pattern.FindAllSubmatch(content, -1) {
+			f.add(fp.name, string(m[1]), DetectedFromFingerprint)
+		}
+	}
+}
+
+var _ filesystem.Extractor = Extractor{}
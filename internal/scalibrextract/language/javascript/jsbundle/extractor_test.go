@@ -0,0 +1,139 @@
+package jsbundle_test
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scalibr/testing/fakefs"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/jsbundle"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		size int64
+		want bool
+	}{
+		{path: "dist/main.3f2a1b.js", want: true},
+		{path: "dist/huge.js", size: 64 << 20, want: false},
+		{path: "usr/share/nginx/html/static/js/vendor.min.js", want: true},
+		{path: "build/bundle.mjs", want: true},
+		{path: "build/bundle.cjs", want: true},
+		{path: "node_modules/lodash/lodash.js", want: false},
+		{path: "app/node_modules/lodash/lodash.js", want: false},
+		{path: "dist/main.3f2a1b.js.map", want: false},
+		{path: "dist/main.3f2a1b.js.LICENSE.txt", want: false},
+		{path: "src/index.ts", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			size := tt.size
+			if size == 0 {
+				size = 1000
+			}
+
+			e := jsbundle.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, fakefs.FakeFileInfo{
+				FileName: filepath.Base(tt.path),
+				FileMode: fs.ModePerm,
+				FileSize: size,
+			}))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	pkg := func(name, version, location, detectedFrom string) *extractor.Package {
+		return &extractor.Package{
+			Name:      name,
+			Version:   version,
+			PURLType:  purl.TypeNPM,
+			Locations: []string{location},
+			Metadata:  &jsbundle.Metadata{DetectedFrom: detectedFrom},
+		}
+	}
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "webpack_bundle_with_source_map_and_extracted_licenses",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/webpack/main.3f2a1b.js",
+			},
+			WantPackages: []*extractor.Package{
+				pkg("@babel/runtime", "7.12.5", "testdata/webpack/main.3f2a1b.js", jsbundle.DetectedFromSourceMap),
+				pkg("react-redux", "7.2.2", "testdata/webpack/main.3f2a1b.js", jsbundle.DetectedFromSourceMap),
+				pkg("axios", "0.21.0", "testdata/webpack/main.3f2a1b.js", jsbundle.DetectedFromSourceMap),
+				pkg("bootstrap", "5.1.3", "testdata/webpack/main.3f2a1b.js", jsbundle.DetectedFromLicenseBanner),
+				pkg("jquery", "3.4.1", "testdata/webpack/main.3f2a1b.js", jsbundle.DetectedFromLicenseBanner),
+				pkg("react-dom", "16.14.0", "testdata/webpack/main.3f2a1b.js", jsbundle.DetectedFromLicenseBanner),
+				pkg("lodash", "4.17.20", "testdata/webpack/main.3f2a1b.js", jsbundle.DetectedFromFingerprint),
+			},
+		},
+		{
+			Name: "rollup_bundle_with_inline_source_map",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/rollup/bundle.js",
+			},
+			WantPackages: []*extractor.Package{
+				pkg("dompurify", "2.0.12", "testdata/rollup/bundle.js", jsbundle.DetectedFromLicenseBanner),
+				pkg("core-js", "3.6.5", "testdata/rollup/bundle.js", jsbundle.DetectedFromFingerprint),
+			},
+		},
+		{
+			Name: "bundle_with_banners",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/banners/vendor.min.js",
+			},
+			WantPackages: []*extractor.Package{
+				pkg("vue", "2.6.11", "testdata/banners/vendor.min.js", jsbundle.DetectedFromLicenseBanner),
+				pkg("jquery", "1.12.4", "testdata/banners/vendor.min.js", jsbundle.DetectedFromLicenseBanner),
+			},
+		},
+		{
+			Name: "application_code",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/plain/app.js",
+			},
+			WantPackages: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr := jsbundle.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
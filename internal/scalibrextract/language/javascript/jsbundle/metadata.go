@@ -0,0 +1,8 @@
+package jsbundle
+
+// Metadata holds the metadata for a package that was found embedded in a JavaScript bundle
+type Metadata struct {
+	// DetectedFrom is how the package was detected, either from the source map of the
+	// bundle, from a license banner, or from a fingerprint of the code of the package
+	DetectedFrom string
+}
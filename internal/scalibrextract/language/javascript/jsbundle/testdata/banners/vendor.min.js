@@ -0,0 +1,10 @@
+/*! Vue.js v2.6.11 (c) 2014-2019 Evan You Released under the MIT License. */
+!function(e,t){"object"==typeof exports?module.exports=t():e.Vue=t()}(this,function(){});
+/*! Copyright 2020 Example Inc. */
+/*! version 1.0.0 */
+/*!
+ * jQuery JavaScript Library v1.12.4
+ * http://jquery.com/
+ */
+/*! jQuery v1.12.4 | (c) jQuery Foundation | jquery.org/license */
+!function(a,b){}("undefined"!=typeof window?window:this,function(a,b){var c=[],d="1.12.4",n=function(a,b){return new n.fn.init(a,b)};});
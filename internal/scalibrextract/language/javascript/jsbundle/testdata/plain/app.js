@@ -0,0 +1,6 @@
+// Copyright 2024 Example Inc.
+/*! app v1 */
+const version = "1.2.3";
+export function main() {
+  return { version: version, mode: "development" };
+}
@@ -0,0 +1,3 @@
+/*! For license information please see main.3f2a1b.js.LICENSE.txt */
+(()=>{var e={486:function(e,t,n){var r;e=n.nmd(e),function(){var u,i="4.17.20",o=200,a="Unsupported core-js use. Try https://npms.io/search?q=ponyfill.",c="Expected a function";}.call(this)}},t={};function n(r){var u=t[r];if(void 0!==u)return u.exports;var i=t[r]={id:r,loaded:!1,exports:{}};return e[r].call(i.exports,i,i.exports,n),i.loaded=!0,i.exports}n(486)})();
+//# sourceMappingURL=main.3f2a1b.js.map
@@ -0,0 +1,223 @@
+// Package yarnlock extracts npm packages from yarn.lock files, adding support
+// for the protocols used by Yarn Berry (v2 and later) lockfiles.
+package yarnlock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/yarnlock"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = yarnlock.Name
+)
+
+// localProtocols are the protocols of packages that are part of the project
+// itself, which are linked rather than installed from a registry
+var localProtocols = []string{"workspace:", "portal:", "link:"}
+
+// Extractor extracts npm packages from yarn.lock files, using the underlying yarn.lock
+// extractor for Yarn v1 lockfiles and parsing Yarn Berry lockfiles itself.
+type Extractor struct {
+	actual filesystem.Extractor
+}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor {
+	return &Extractor{actual: yarnlock.New()}
+}
+
+// Name of the extractor
+func (e *Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e *Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e *Extractor) Requirements() *plugin.Capabilities {
+	return e.actual.Requirements()
+}
+
+// FileRequired returns true if the specified file matches using the underlying yarn.lock extractor
+func (e *Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return e.actual.FileRequired(api)
+}
+
+// Extract extracts packages from yarn.lock files passed through the scan input.
+//
+// Yarn Berry lockfiles are YAML documents with a "__metadata" entry, which are
+// parsed based on the resolution of each entry so that:
+//   - packages from the workspace: portal: and link: protocols are skipped as they are local
+//   - packages from the patch: protocol are resolved to the package that they patch
+func (e *Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	if !isBerryLockfile(content) {
+		actualInput := *input
+		actualInput.Reader = bytes.NewReader(content)
+
+		return e.actual.Extract(ctx, &actualInput)
+	}
+
+	packages, err := parseBerryLockfile(content)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	for _, pkg := range packages {
+		pkg.Locations = []string{input.Path}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// isBerryLockfile checks if the lockfile was written by Yarn Berry, which always
+// includes a top-level "__metadata" entry with the version of the lockfile
+func isBerryLockfile(content []byte) bool {
+	return bytes.HasPrefix(content, []byte("__metadata:")) || bytes.Contains(content, []byte("\n__metadata:"))
+}
+
+type berryEntry struct {
+	Version    string `yaml:"version"`
+	Resolution string `yaml:"resolution"`
+	LinkType   string `yaml:"linkType"`
+}
+
+func parseBerryLockfile(content []byte) ([]*extractor.Package, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("lockfile is not a mapping of packages")
+	}
+
+	packages := []*extractor.Package{}
+	seen := make(map[string]bool)
+
+	// the entries are decoded in order from the node so that packages are
+	// returned in the same order as they are in the lockfile
+	entries := doc.Content[0].Content
+	for i := 0; i+1 < len(entries); i += 2 {
+		descriptors := entries[i].Value
+		if descriptors == "__metadata" {
+			continue
+		}
+
+		var entry berryEntry
+		if err := entries[i+1].Decode(&entry); err != nil {
+			return nil, fmt.Errorf("invalid entry for %s: %w", descriptors, err)
+		}
+
+		pkg, ok := berryEntryToPackage(descriptors, entry)
+		if !ok {
+			continue
+		}
+
+		// patched packages are usually listed alongside the package that they patch
+		key := pkg.Name + "@" + pkg.Version + "#" + pkg.SourceCode.Commit
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		packages = append(packages, pkg)
+	}
+
+	return packages, nil
+}
+
+// berryEntryToPackage returns the package resolved by an entry, returning false
+// if the entry is for a local package that should not be scanned
+func berryEntryToPackage(descriptors string, entry berryEntry) (*extractor.Package, bool) {
+	resolution := entry.Resolution
+	if resolution == "" {
+		resolution, _, _ = strings.Cut(descriptors, ",")
+	}
+
+	name, reference := splitLocator(strings.TrimSpace(resolution))
+
+	// patched packages are resolved to the underlying package that is being patched,
+	// which is url-encoded after the protocol, e.g. "resolve@patch:resolve@npm%3A1.22.1#~builtin<compat/resolve>"
+	for strings.HasPrefix(reference, "patch:") {
+		source, _, _ := strings.Cut(strings.TrimPrefix(reference, "patch:"), "#")
+		if unescaped, err := url.PathUnescape(source); err == nil {
+			source = unescaped
+		}
+		name, reference = splitLocator(source)
+	}
+
+	if entry.LinkType == "soft" {
+		return nil, false
+	}
+	for _, protocol := range localProtocols {
+		if strings.HasPrefix(reference, protocol) {
+			return nil, false
+		}
+	}
+
+	// aliased packages reference the actual package, e.g. "string-width-cjs@npm:string-width@4.2.3"
+	if aliased, ok := strings.CutPrefix(reference, "npm:"); ok && strings.Contains(strings.TrimPrefix(aliased, "@"), "@") {
+		name, _ = splitLocator(aliased)
+	}
+
+	if name == "" || entry.Version == "" {
+		return nil, false
+	}
+
+	return &extractor.Package{
+		Name:     name,
+		Version:  entry.Version,
+		PURLType: purl.TypeNPM,
+		SourceCode: &extractor.SourceCodeIdentifier{
+			Commit: extractCommit(reference),
+		},
+	}, true
+}
+
+// splitLocator splits a locator or descriptor into the name of the package and its
+// reference, such as "@types/node@npm:20.0.0" into "@types/node" and "npm:20.0.0"
+func splitLocator(locator string) (string, string) {
+	locator = strings.Trim(locator, `"`)
+
+	i := strings.Index(strings.TrimPrefix(locator, "@"), "@")
+	if i == -1 {
+		return locator, ""
+	}
+	if strings.HasPrefix(locator, "@") {
+		i++
+	}
+
+	return locator[:i], locator[i+1:]
+}
+
+// extractCommit returns the commit of packages resolved from a git repository,
+// which Yarn Berry records as a "commit" parameter of the url
+func extractCommit(reference string) string {
+	matches := cachedregexp.MustCompile(`[#&]commit[=:]([0-9a-f]{40})\b`).FindStringSubmatch(reference)
+	if matches == nil {
+		return ""
+	}
+
+	return matches[1]
+}
+
+var _ filesystem.Extractor = &Extractor{}
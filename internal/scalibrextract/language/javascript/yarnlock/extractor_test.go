@@ -0,0 +1,128 @@
+package yarnlock_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/yarnlock"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "yarn.lock", want: true},
+		{path: "path/to/my/yarn.lock", want: true},
+		{path: "path/to/node_modules/dep/yarn.lock", want: false},
+		{path: "yarn.lock/file", want: false},
+		{path: "package-lock.json", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := yarnlock.New()
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func npmPackage(name, version, commit, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:       name,
+		Version:    version,
+		PURLType:   purl.TypeNPM,
+		Locations:  []string{location},
+		SourceCode: &extractor.SourceCodeIdentifier{Commit: commit},
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "yarn v1 lockfile",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/one-package.v1.lock",
+			},
+			WantPackages: []*extractor.Package{
+				npmPackage("balanced-match", "1.0.2", "", "testdata/one-package.v1.lock"),
+			},
+		},
+		{
+			Name: "invalid berry lockfile",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.v4.lock",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "patched packages are resolved to the packages they patch",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/patches.v4.lock",
+			},
+			WantPackages: []*extractor.Package{
+				npmPackage("@types/node", "20.11.0", "", "testdata/patches.v4.lock"),
+				npmPackage("fsevents", "2.3.3", "", "testdata/patches.v4.lock"),
+				npmPackage("resolve", "1.22.8", "", "testdata/patches.v4.lock"),
+			},
+		},
+		{
+			Name: "workspace, portal, and link packages are skipped",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/local-packages.v4.lock",
+			},
+			WantPackages: []*extractor.Package{
+				npmPackage("lodash", "4.17.21", "", "testdata/local-packages.v4.lock"),
+				npmPackage("minimist", "1.2.8", "", "testdata/local-packages.v4.lock"),
+			},
+		},
+		{
+			Name: "commits and aliases",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/commits-and-aliases.v4.lock",
+			},
+			WantPackages: []*extractor.Package{
+				npmPackage("ansi-regex", "5.0.1", "", "testdata/commits-and-aliases.v4.lock"),
+				npmPackage("@babel/helper-validator-identifier", "7.22.20", "", "testdata/commits-and-aliases.v4.lock"),
+				npmPackage("my-package", "0.2.2", "59e2127b9f9d4fda5f928c4204213b3502cd5bb0", "testdata/commits-and-aliases.v4.lock"),
+				npmPackage("my-tarball", "1.0.0", "", "testdata/commits-and-aliases.v4.lock"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr := yarnlock.New()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Package flakelock extracts the inputs of Nix flakes from flake.lock files.
+package flakelock
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "nix/flakelock"
+)
+
+type lockedRef struct {
+	Type  string `json:"type"`
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Host  string `json:"host"`
+	URL   string `json:"url"`
+	Rev   string `json:"rev"`
+}
+
+type lockNode struct {
+	Locked *lockedRef `json:"locked"`
+}
+
+type lockFile struct {
+	Nodes map[string]lockNode `json:"nodes"`
+	Root  string              `json:"root"`
+}
+
+// Extractor extracts the inputs of Nix flakes from flake.lock files.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for flake.lock files
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	return filepath.Base(fapi.Path()) == "flake.lock"
+}
+
+// repoURL returns the url of the git repository of a locked input, if it is from one
+func repoURL(ref lockedRef) (string, bool) {
+	switch ref.Type {
+	case "github":
+		return fmt.Sprintf("https://%s/%s/%s", cmp.Or(ref.Host, "github.com"), ref.Owner, ref.Repo), true
+	case "gitlab":
+		return fmt.Sprintf("https://%s/%s/%s", cmp.Or(ref.Host, "gitlab.com"), ref.Owner, ref.Repo), true
+	case "sourcehut":
+		return fmt.Sprintf("https://%s/%s/%s", cmp.Or(ref.Host, "git.sr.ht"), ref.Owner, ref.Repo), true
+	case "git":
+		return strings.TrimPrefix(ref.URL, "git+"), true
+	}
+
+	return "", false
+}
+
+// Extract extracts the inputs of flakes from flake.lock files passed through the scan input.
+//
+// Inputs are locked to the commit of the repository that they were fetched from,
+// which is what they are scanned as. Inputs from paths, tarballs, and files are skipped,
+// as they are not locked to a commit.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var lock lockFile
+	if err := json.NewDecoder(input.Reader).Decode(&lock); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+
+	for _, name := range slices.Sorted(maps.Keys(lock.Nodes)) {
+		node := lock.Nodes[name]
+		if name == lock.Root || node.Locked == nil || node.Locked.Rev == "" {
+			continue
+		}
+
+		repo, ok := repoURL(*node.Locked)
+		if !ok {
+			continue
+		}
+
+		packages = append(packages, &extractor.Package{
+			Name:       name,
+			Locations:  []string{input.Path},
+			SourceCode: &extractor.SourceCodeIdentifier{Repo: repo, Commit: node.Locked.Rev},
+		})
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
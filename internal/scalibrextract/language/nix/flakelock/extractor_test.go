@@ -0,0 +1,96 @@
+package flakelock_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/nix/flakelock"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "flake.lock", want: true},
+		{path: "path/to/my/flake.lock", want: true},
+		{path: "flake.nix", want: false},
+		{path: "flake.lock/file", want: false},
+		{path: "my-flake.lock", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := flakelock.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func input(name, repo, commit string) *extractor.Package {
+	return &extractor.Package{
+		Name:       name,
+		Locations:  []string{"testdata/flake.lock"},
+		SourceCode: &extractor.SourceCodeIdentifier{Repo: repo, Commit: commit},
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid-flake.lock",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "inputs locked to commits",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/flake.lock",
+			},
+			WantPackages: []*extractor.Package{
+				input("flake-utils", "https://github.com/numtide/flake-utils", "b1d9ab70662946ef0850d488da1c9019f3a9752a"),
+				input("nixpkgs", "https://github.com/NixOS/nixpkgs", "1042fd8b148a9105f3c0aca3a6177fd1d9360ba5"),
+				input("systems", "https://github.com/nix-systems/default", "da67096a3b9bf56a91d16901293e51ba5b49a27e"),
+				input("tools", "https://git.example.com/tools.git", "9f3c2a1e0b7d4c6a8e5f2d1b0c9a8e7f6d5c4b3a"),
+				input("wlroots", "https://gitlab.freedesktop.org/wlroots/wlroots", "0c3b4f5d6e7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr := flakelock.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
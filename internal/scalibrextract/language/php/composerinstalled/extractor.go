@@ -0,0 +1,123 @@
+// Package composerinstalled extracts PHP packages installed by Composer from vendor/composer/installed.json files.
+package composerinstalled
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/osv"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "php/composerinstalled"
+)
+
+type installedPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Dist    struct {
+		Reference string `json:"reference"`
+	} `json:"dist"`
+	Source struct {
+		Reference string `json:"reference"`
+	} `json:"source"`
+}
+
+// installedJSON is the format written by Composer 2, which also records
+// which of the installed packages are only required for development
+type installedJSON struct {
+	Packages        []installedPackage `json:"packages"`
+	DevPackageNames []string           `json:"dev-package-names"`
+}
+
+// Extractor extracts PHP packages from the vendor/composer/installed.json file that
+// Composer writes when installing packages, which is often deployed without the
+// composer.lock file that the packages were installed from.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for installed.json files within a vendor/composer directory
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	path := fapi.Path()
+
+	return filepath.Base(path) == "installed.json" &&
+		filepath.Base(filepath.Dir(path)) == "composer" &&
+		filepath.Base(filepath.Dir(filepath.Dir(path))) == "vendor"
+}
+
+// Extract extracts packages from vendor/composer/installed.json files passed through the scan input.
+//
+// Composer 1 writes the installed packages as a top-level array, while Composer 2
+// writes an object with the packages under "packages" and the names of the
+// packages that were installed for development under "dev-package-names".
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	var installed installedJSON
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &installed.Packages)
+	} else {
+		err = json.Unmarshal(content, &installed)
+	}
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := make([]*extractor.Package, 0, len(installed.Packages))
+	for _, installedPackage := range installed.Packages {
+		if installedPackage.Name == "" || installedPackage.Version == "" {
+			continue
+		}
+
+		depGroups := []string{}
+		if slices.Contains(installed.DevPackageNames, installedPackage.Name) {
+			depGroups = []string{"dev"}
+		}
+
+		commit := installedPackage.Dist.Reference
+		if commit == "" {
+			commit = installedPackage.Source.Reference
+		}
+
+		packages = append(packages, &extractor.Package{
+			Name:      installedPackage.Name,
+			Version:   installedPackage.Version,
+			PURLType:  purl.TypeComposer,
+			Locations: []string{input.Path},
+			SourceCode: &extractor.SourceCodeIdentifier{
+				Commit: commit,
+			},
+			Metadata: osv.DepGroupMetadata{
+				DepGroupVals: depGroups,
+			},
+		})
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,151 @@
+package composerinstalled_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/osv"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/php/composerinstalled"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "vendor/composer/installed.json", want: true},
+		{path: "path/to/vendor/composer/installed.json", want: true},
+		{path: "installed.json", want: false},
+		{path: "composer/installed.json", want: false},
+		{path: "vendor/installed.json", want: false},
+		{path: "vendor/composer/installed.php", want: false},
+		{path: "vendor/composer/installed.json.bak", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := composerinstalled.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid/vendor/composer/installed.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty/vendor/composer/installed.json",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "composer 1",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/v1/vendor/composer/installed.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "guzzlehttp/psr7",
+					Version:   "1.8.1",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/v1/vendor/composer/installed.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "35ea11d335fd638b5882ff1725228b3d35496ab1",
+					},
+					Metadata: osv.DepGroupMetadata{DepGroupVals: []string{}},
+				},
+				{
+					Name:      "psr/http-message",
+					Version:   "1.0.1",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/v1/vendor/composer/installed.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "f6561bf28d520154e4b0ec72be95418abe6d9363",
+					},
+					Metadata: osv.DepGroupMetadata{DepGroupVals: []string{}},
+				},
+			},
+		},
+		{
+			Name: "composer 2",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/v2/vendor/composer/installed.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "monolog/monolog",
+					Version:   "2.3.5",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/v2/vendor/composer/installed.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "fd4380d6fc37626e2f799f29d91195040137eba9",
+					},
+					Metadata: osv.DepGroupMetadata{DepGroupVals: []string{}},
+				},
+				{
+					Name:      "psr/log",
+					Version:   "1.1.4",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/v2/vendor/composer/installed.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "d49695b909c3b7628b6289db5479a1c204601f11",
+					},
+					Metadata: osv.DepGroupMetadata{DepGroupVals: []string{}},
+				},
+				{
+					Name:      "phpunit/phpunit",
+					Version:   "9.5.10",
+					PURLType:  purl.TypeComposer,
+					Locations: []string{"testdata/v2/vendor/composer/installed.json"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Commit: "c814a05837f2edb0d1471d6e3f4ab3501ca3899a",
+					},
+					Metadata: osv.DepGroupMetadata{DepGroupVals: []string{"dev"}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := composerinstalled.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+// Package ipynb extracts the PyPI packages installed by the pip magics and
+// shell commands in the code cells of Jupyter notebooks.
+package ipynb
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/requirements"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "python/ipynb"
+)
+
+// CellLocation returns the location of the cell with the given index in the notebook at path,
+// which is the location that packages installed by that cell are attributed to
+func CellLocation(path string, index int) string {
+	return path + "#cell-" + strconv.Itoa(index)
+}
+
+// notebookSource is the source of a cell, which is either a string or a list of lines
+type notebookSource []string
+
+func (s *notebookSource) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = notebookSource{str}
+
+		return nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return err
+	}
+	*s = lines
+
+	return nil
+}
+
+type notebookCell struct {
+	CellType string         `json:"cell_type"`
+	Source   notebookSource `json:"source"`
+}
+
+type notebook struct {
+	Cells []notebookCell `json:"cells"`
+}
+
+// Extractor extracts the PyPI packages installed by the code cells of Jupyter notebooks.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file is a Jupyter notebook.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	path := filepath.ToSlash(api.Path())
+
+	// notebooks are saved into a checkpoint directory as they are edited
+	if slices.Contains(strings.Split(path, "/"), ".ipynb_checkpoints") {
+		return false
+	}
+
+	return filepath.Ext(path) == ".ipynb"
+}
+
+// Extract extracts the packages installed by the pip magics (e.g. "%pip install")
+// and shell commands (e.g. "!pip install") in the code cells of a Jupyter notebook,
+// attributing each package to the location of the cell that installs it.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var nb notebook
+	if err := json.NewDecoder(input.Reader).Decode(&nb); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := make([]*extractor.Package, 0)
+	for i, cell := range nb.Cells {
+		if cell.CellType != "code" {
+			continue
+		}
+
+		location := CellLocation(input.Path, i+1)
+		seen := make(map[string]bool)
+
+		for _, args := range findPipInstalls(strings.Join(cell.Source, "")) {
+			for _, requirement := range parseInstallArgs(args) {
+				pkg, ok := parseRequirement(requirement)
+				if !ok || seen[pkg.Name+"@"+pkg.Version] {
+					continue
+				}
+				seen[pkg.Name+"@"+pkg.Version] = true
+
+				pkg.Locations = []string{location}
+				packages = append(packages, pkg)
+			}
+		}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// pipCommand matches the start of commands that run "pip install", either through the
+// %pip magic or in a shell command, with the arguments to install being in the last group
+var pipCommand = cachedregexp.MustCompile(
+	`^(?:%pip3?|!\s*(?:uv\s+pip|pip3?|python3?\s+-m\s+pip|\{sys\.executable\}\s+-m\s+pip|\S*python\S*\s+-m\s+pip))\s+install\b(.*)$`,
+)
+
+// findPipInstalls returns the arguments of each pip install command in the source of a cell
+func findPipInstalls(source string) []string {
+	var installs []string
+
+	var line strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(source))
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		// join lines that are continued
+		if strings.HasSuffix(text, `\`) {
+			line.WriteString(strings.TrimSuffix(text, `\`) + " ")
+			continue
+		}
+		line.WriteString(text)
+
+		if m := pipCommand.FindStringSubmatch(strings.TrimSpace(line.String())); m != nil {
+			installs = append(installs, m[1])
+		}
+
+		line.Reset()
+	}
+
+	return installs
+}
+
+// optionsWithValues are the options of pip install that are followed by a value
+var optionsWithValues = []string{
+	"-r", "--requirement", "-c", "--constraint", "-e", "--editable",
+	"-i", "--index-url", "--extra-index-url", "-f", "--find-links",
+	"-t", "--target", "--prefix", "--root", "--src", "--platform",
+	"--python-version", "--implementation", "--abi", "--upgrade-strategy",
+	"--progress-bar", "--trusted-host", "--proxy", "--cache-dir", "--log",
+	"--report", "--config-settings", "-C", "--global-option", "--no-binary",
+	"--only-binary", "--python",
+}
+
+// parseInstallArgs returns the requirements in the arguments of a pip install command,
+// skipping options and requirements that are not from a package index (e.g. paths and URLs)
+func parseInstallArgs(args string) []string {
+	var requirements []string
+
+	fields := splitShellWords(args)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		if strings.HasPrefix(field, "-") {
+			// the value of options given as --option=value is part of the same field
+			if slices.Contains(optionsWithValues, field) {
+				i++
+			}
+
+			continue
+		}
+
+		// variables in the notebook or the shell cannot be known
+		if strings.ContainsAny(field, "{}$") {
+			continue
+		}
+
+		if strings.Contains(field, "/") || strings.Contains(field, `\`) || strings.HasPrefix(field, ".") {
+			continue
+		}
+
+		requirements = append(requirements, field)
+	}
+
+	return requirements
+}
+
+// splitShellWords splits the arguments of a command into words as a shell would,
+// stopping at the end of the command, such as at a redirection or another command.
+//
+// Notably, this means that unquoted requirements such as pandas>=2 are only for
+// the package, as the shell treats the rest of the requirement as a redirection.
+func splitShellWords(s string) []string {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case strings.ContainsRune(";&|<>", r), r == '#' && !inWord:
+			if inWord {
+				words = append(words, word.String())
+			}
+
+			return words
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words
+}
+
+var (
+	reValidPkg = cachedregexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`)
+	reExtras   = cachedregexp.MustCompile(`\[[^\]]*\]`)
+)
+
+// comparators are the comparators of a requirement that the version they compare
+// against is used as the version of the package, as in requirements.txt files
+var comparators = []string{"===", "==", ">=", "<=", "~="}
+
+// parseRequirement parses a requirement in the same way as a line of a requirements.txt
+// file, using the version that it is pinned to or the lowest version that it allows
+func parseRequirement(requirement string) (*extractor.Package, bool) {
+	spec, _, _ := strings.Cut(requirement, ";")
+	spec = reExtras.ReplaceAllString(strings.ReplaceAll(spec, " ", ""), "")
+
+	name, version, comparator := spec, "", ""
+	if i := strings.IndexAny(spec, "=<>~!"); i != -1 {
+		name = spec[:i]
+		constraint := spec[i:]
+
+		// the version of a range with multiple bounds cannot be known without resolving it
+		if !strings.Contains(constraint, ",") {
+			for _, c := range comparators {
+				if v, ok := strings.CutPrefix(constraint, c); ok {
+					version, comparator = v, c
+					break
+				}
+			}
+		}
+	}
+
+	if !reValidPkg.MatchString(name) {
+		return nil, false
+	}
+
+	return &extractor.Package{
+		Name:     name,
+		Version:  version,
+		PURLType: purl.TypePyPi,
+		Metadata: &requirements.Metadata{
+			VersionComparator: comparator,
+			Requirement:       strings.TrimSpace(requirement),
+		},
+	}, true
+}
+
+var _ filesystem.Extractor = Extractor{}
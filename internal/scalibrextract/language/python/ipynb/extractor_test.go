@@ -0,0 +1,120 @@
+package ipynb_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/requirements"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/ipynb"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "analysis.ipynb", want: true},
+		{path: "path/to/my/analysis.ipynb", want: true},
+		{path: "path/to/.ipynb_checkpoints/analysis-checkpoint.ipynb", want: false},
+		{path: "analysis.ipynb.bak", want: false},
+		{path: "analysis.py", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := ipynb.New()
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func pypiPackage(name, version, comparator, requirement, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypePyPi,
+		Locations: []string{location},
+		Metadata: &requirements.Metadata{
+			VersionComparator: comparator,
+			Requirement:       requirement,
+		},
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-json.ipynb",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "no cells",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.ipynb",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "no installs",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no-installs.ipynb",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "installs",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/installs.ipynb",
+			},
+			WantPackages: []*extractor.Package{
+				pypiPackage("pandas", "2.0.3", "==", "pandas==2.0.3", ipynb.CellLocation("testdata/installs.ipynb", 2)),
+				pypiPackage("numpy", "", "", "numpy", ipynb.CellLocation("testdata/installs.ipynb", 2)),
+				pypiPackage("requests", "2.31.0", ">=", "requests>=2.31.0", ipynb.CellLocation("testdata/installs.ipynb", 3)),
+				pypiPackage("scikit-learn", "1.3.0", "==", "scikit-learn[alldeps]==1.3.0", ipynb.CellLocation("testdata/installs.ipynb", 3)),
+				pypiPackage("torch", "2.1.0", "==", "torch==2.1.0", ipynb.CellLocation("testdata/installs.ipynb", 4)),
+				pypiPackage("matplotlib", "", "", "matplotlib", ipynb.CellLocation("testdata/installs.ipynb", 5)),
+				pypiPackage("pandas", "2.0.3", "==", "pandas==2.0.3", ipynb.CellLocation("testdata/installs.ipynb", 6)),
+				pypiPackage("polars", "0.19.0", "==", "polars==0.19.0; python_version >= '3.8'", ipynb.CellLocation("testdata/installs.ipynb", 7)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr := ipynb.New()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
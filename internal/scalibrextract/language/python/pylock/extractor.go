@@ -0,0 +1,157 @@
+// Package pylock extracts PyPI packages from pylock.toml files, as specified by PEP 751.
+package pylock
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "python/pylock"
+)
+
+type pylockFile struct {
+	LockVersion string          `toml:"lock-version"`
+	Packages    []pylockPackage `toml:"packages"`
+}
+
+type pylockPackage struct {
+	Name      string               `toml:"name"`
+	Version   string               `toml:"version"`
+	Marker    string               `toml:"marker"`
+	Index     string               `toml:"index"`
+	VCS       *pylockVCS           `toml:"vcs"`
+	Directory *pylockDirectory     `toml:"directory"`
+	Archive   *pylockDistribution  `toml:"archive"`
+	Sdist     *pylockDistribution  `toml:"sdist"`
+	Wheels    []pylockDistribution `toml:"wheels"`
+}
+
+type pylockVCS struct {
+	Type     string `toml:"type"`
+	URL      string `toml:"url"`
+	CommitID string `toml:"commit-id"`
+}
+
+type pylockDirectory struct {
+	Path string `toml:"path"`
+}
+
+type pylockDistribution struct {
+	Hashes map[string]string `toml:"hashes"`
+}
+
+// Extractor extracts PyPI packages from pylock.toml files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// pylockNameRe matches the names that PEP 751 allows lockfiles to have, which
+// are "pylock.toml" or "pylock.<name>.toml" where the name does not have a dot
+var pylockNameRe = cachedregexp.MustCompile(`^pylock\.([^.]+\.)?toml$`)
+
+// FileRequired returns true if the specified file matches pylock.toml file patterns.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return pylockNameRe.MatchString(filepath.Base(api.Path()))
+}
+
+// Extract extracts packages from pylock.toml files passed through the scan input.
+//
+// Packages that are installed from a local directory are skipped, as they are most
+// likely the project itself, as are packages without a version unless they are
+// installed from a version control system, as they are identified by their commit.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var parsedLockfile *pylockFile
+
+	_, err := toml.NewDecoder(input.Reader).Decode(&parsedLockfile)
+
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	if parsedLockfile == nil {
+		return inventory.Inventory{Packages: []*extractor.Package{}}, nil
+	}
+
+	packages := make([]*extractor.Package, 0, len(parsedLockfile.Packages))
+
+	for _, lockPackage := range parsedLockfile.Packages {
+		if lockPackage.Directory != nil {
+			continue
+		}
+
+		if lockPackage.Version == "" && (lockPackage.VCS == nil || lockPackage.VCS.CommitID == "") {
+			continue
+		}
+
+		pkg := &extractor.Package{
+			Name:      lockPackage.Name,
+			Version:   lockPackage.Version,
+			PURLType:  purl.TypePyPi,
+			Locations: []string{input.Path},
+			Metadata: &Metadata{
+				Marker: lockPackage.Marker,
+				Index:  lockPackage.Index,
+				Hashes: lockPackage.hashes(),
+			},
+		}
+
+		if lockPackage.VCS != nil && lockPackage.VCS.CommitID != "" {
+			pkg.SourceCode = &extractor.SourceCodeIdentifier{
+				Repo:   lockPackage.VCS.URL,
+				Commit: lockPackage.VCS.CommitID,
+			}
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// hashes returns the hashes of all the distributions of the package, sorted and without duplicates
+func (p pylockPackage) hashes() []string {
+	distributions := slices.Clone(p.Wheels)
+	if p.Sdist != nil {
+		distributions = append(distributions, *p.Sdist)
+	}
+	if p.Archive != nil {
+		distributions = append(distributions, *p.Archive)
+	}
+
+	var hashes []string
+	for _, dist := range distributions {
+		for algorithm, digest := range dist.Hashes {
+			hashes = append(hashes, algorithm+":"+digest)
+		}
+	}
+
+	slices.Sort(hashes)
+
+	return slices.Compact(hashes)
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,174 @@
+package pylock_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/pylock"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "pylock.toml", want: true},
+		{path: "path/to/my/pylock.toml", want: true},
+		{path: "pylock.dev.toml", want: true},
+		{path: "pylock.toml/file", want: false},
+		{path: "pylock.dev.linux.toml", want: false},
+		{path: "pylock..toml", want: false},
+		{path: "my.pylock.toml", want: false},
+		{path: "pyproject.toml", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := pylock.New()
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid toml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-toml.txt",
+			},
+			WantPackages: nil,
+			WantErr:      extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.toml",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "packages from indexes and archives",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/pylock.toml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "certifi",
+					Version:   "2024.8.30",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/pylock.toml"},
+					Metadata: &pylock.Metadata{
+						Index: "https://pypi.org/simple",
+						Hashes: []string{
+							"sha256:922820b53db7a7257ffbda3f597266d435245903d80737e34f06ecba96dad9e0",
+							"sha256:bec941d2aa8195e248a60b31ff9f0558284cf01a52591ceda73ea9afffd69fd9",
+						},
+					},
+				},
+				{
+					Name:      "colorama",
+					Version:   "0.4.6",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/pylock.toml"},
+					Metadata: &pylock.Metadata{
+						Marker: "sys_platform == 'win32'",
+						Index:  "https://pypi.org/simple",
+						Hashes: []string{
+							"md5:7c8dc10c5bb1f4e6e1b5e8d6d1a6c0b0",
+							"sha256:4f1d9991f5acc0ca119f9d443620b77f9d6b33703e51011c16baf57afb285fc6",
+						},
+					},
+				},
+				{
+					Name:      "pytest",
+					Version:   "8.3.3",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/pylock.toml"},
+					Metadata: &pylock.Metadata{
+						Marker: "'dev' in dependency_groups",
+						Index:  "https://pypi.org/simple",
+						Hashes: []string{
+							"sha256:a6853c7375b2663155079443d2e45de913a911a11d669df02a50814944db57b2",
+						},
+					},
+				},
+				{
+					Name:      "internal-tools",
+					Version:   "1.2.0",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/pylock.toml"},
+					Metadata: &pylock.Metadata{
+						Hashes: []string{
+							"sha256:f1b8a3c5d0e2b4a6c8e0f2a4b6c8d0e2f4a6b8c0d2e4f6a8b0c2d4e6f8a0b2c4",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "packages from version control",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/vcs.toml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "attrs",
+					Version:   "25.1.0",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/vcs.toml"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/python-attrs/attrs",
+						Commit: "a4c21f37c4be2f3bc1d41b2eb54a6a2e6a35d5f6",
+					},
+					Metadata: &pylock.Metadata{},
+				},
+				{
+					Name:      "unreleased",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/vcs.toml"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/example/unreleased",
+						Commit: "0b3c5e7f9a1c3e5f7a9b1d3f5a7c9e1b3d5f7a9c",
+					},
+					Metadata: &pylock.Metadata{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := pylock.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
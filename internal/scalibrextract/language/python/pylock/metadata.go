@@ -0,0 +1,13 @@
+package pylock
+
+// Metadata holds the metadata for a package in a pylock.toml file
+type Metadata struct {
+	// Marker is the environment marker that determines whether the package is installed,
+	// e.g. "sys_platform == 'win32'", if it is only installed in some environments
+	Marker string
+	// Index is the url of the index that the package is from, if it is from one
+	Index string
+	// Hashes are the hashes of the wheels, source distribution, and archive of the package,
+	// each in the form of "<algorithm>:<digest>"
+	Hashes []string
+}
@@ -0,0 +1,216 @@
+// Package uvlock extracts PyPI packages from uv.lock files, including those of uv workspaces.
+package uvlock
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/python/uvlock"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = uvlock.Name
+)
+
+type uvLockPackageSource struct {
+	Registry  string `toml:"registry"`
+	Git       string `toml:"git"`
+	URL       string `toml:"url"`
+	Path      string `toml:"path"`
+	Directory string `toml:"directory"`
+	Editable  string `toml:"editable"`
+	Virtual   string `toml:"virtual"`
+}
+
+// isLocal returns whether the package is from the local filesystem, which is
+// the case for the members of the workspace along with any other local packages
+func (s uvLockPackageSource) isLocal() bool {
+	return s.Path != "" || s.Directory != "" || s.Editable != "" || s.Virtual != ""
+}
+
+type uvLockDependency struct {
+	Name string `toml:"name"`
+}
+
+type uvLockDistribution struct {
+	URL      string `toml:"url"`
+	Path     string `toml:"path"`
+	Filename string `toml:"filename"`
+}
+
+// fileName returns the name of the file of the distribution
+func (d uvLockDistribution) fileName() string {
+	if d.Filename != "" {
+		return d.Filename
+	}
+
+	if d.Path != "" {
+		return path.Base(filepath.ToSlash(d.Path))
+	}
+
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return ""
+	}
+
+	return path.Base(u.Path)
+}
+
+type uvLockPackage struct {
+	Name    string               `toml:"name"`
+	Version string               `toml:"version"`
+	Source  uvLockPackageSource  `toml:"source"`
+	Sdist   *uvLockDistribution  `toml:"sdist"`
+	Wheels  []uvLockDistribution `toml:"wheels"`
+
+	OptionalDependencies map[string][]uvLockDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvLockDependency `toml:"dev-dependencies"`
+}
+
+type uvLockFile struct {
+	Version  int             `toml:"version"`
+	Packages []uvLockPackage `toml:"package"`
+}
+
+// Extractor extracts PyPI packages from uv.lock files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file matches uv lockfile patterns.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == "uv.lock"
+}
+
+// Extract extracts packages from uv.lock files passed through the scan input.
+//
+// The members of the workspace, along with any other packages from the local filesystem,
+// are skipped. Packages are in the extras and dependency groups that members depend on
+// them in, and packages from git are identified by the commit that they are locked to.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var parsedLockfile *uvLockFile
+
+	_, err := toml.NewDecoder(input.Reader).Decode(&parsedLockfile)
+
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	if parsedLockfile == nil {
+		return inventory.Inventory{Packages: []*extractor.Package{}}, nil
+	}
+
+	groups := dependencyGroups(parsedLockfile.Packages)
+
+	packages := make([]*extractor.Package, 0, len(parsedLockfile.Packages))
+
+	for _, lockPackage := range parsedLockfile.Packages {
+		if lockPackage.Source.isLocal() {
+			continue
+		}
+
+		metadata := &Metadata{
+			DepGroupVals: groups[lockPackage.Name],
+			Registry:     lockPackage.Source.Registry,
+		}
+
+		if metadata.DepGroupVals == nil {
+			metadata.DepGroupVals = []string{}
+		}
+
+		if lockPackage.Sdist != nil {
+			metadata.Sdist = lockPackage.Sdist.fileName()
+		}
+
+		for _, wheel := range lockPackage.Wheels {
+			metadata.Wheels = append(metadata.Wheels, wheel.fileName())
+		}
+
+		pkg := &extractor.Package{
+			Name:      lockPackage.Name,
+			Version:   lockPackage.Version,
+			PURLType:  purl.TypePyPi,
+			Locations: []string{input.Path},
+			Metadata:  metadata,
+		}
+
+		if repo, commit, ok := parseGitSource(lockPackage.Source.Git); ok {
+			pkg.SourceCode = &extractor.SourceCodeIdentifier{
+				Repo:   repo,
+				Commit: commit,
+			}
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// dependencyGroups returns the extras and dependency groups that each package is in,
+// based on the direct dependencies of the members of the workspace
+func dependencyGroups(packages []uvLockPackage) map[string][]string {
+	groups := make(map[string][]string)
+
+	for _, lockPackage := range packages {
+		if !lockPackage.Source.isLocal() {
+			continue
+		}
+
+		for _, deps := range []map[string][]uvLockDependency{lockPackage.OptionalDependencies, lockPackage.DevDependencies} {
+			for group, groupDeps := range deps {
+				for _, dep := range groupDeps {
+					if !slices.Contains(groups[dep.Name], group) {
+						groups[dep.Name] = append(groups[dep.Name], group)
+					}
+				}
+			}
+		}
+	}
+
+	for _, g := range groups {
+		slices.Sort(g)
+	}
+
+	return groups
+}
+
+// parseGitSource parses the source of a package from git, which is the url of the
+// repository with the commit that the package is locked to as its fragment, e.g.
+// "https://github.com/astral-sh/ruff?rev=0.8.1#84748be16341b76e073d117329f7f5f4ee2941ad"
+func parseGitSource(source string) (string, string, bool) {
+	repo, commit, ok := strings.Cut(source, "#")
+	if !ok || commit == "" {
+		return "", "", false
+	}
+
+	repo, _, _ = strings.Cut(repo, "?")
+
+	return repo, commit, true
+}
+
+var _ filesystem.Extractor = Extractor{}
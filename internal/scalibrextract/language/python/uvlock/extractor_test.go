@@ -0,0 +1,183 @@
+package uvlock_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/uvlock"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "uv.lock", want: true},
+		{path: "path/to/my/uv.lock", want: true},
+		{path: "uv.lock/file", want: false},
+		{path: "uv.lock.bak", want: false},
+		{path: "pyproject.toml", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := uvlock.New()
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid toml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-toml.txt",
+			},
+			WantPackages: nil,
+			WantErr:      extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.lock",
+			},
+			WantPackages: nil,
+		},
+		{
+			Name: "workspace",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/workspace.lock",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "pysocks",
+					Version:   "1.7.1",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/workspace.lock"},
+					Metadata: &uvlock.Metadata{
+						DepGroupVals: []string{"socks"},
+						Registry:     "https://pypi.org/simple",
+						Sdist:        "PySocks-1.7.1.tar.gz",
+						Wheels:       []string{"PySocks-1.7.1-py3-none-any.whl"},
+					},
+				},
+				{
+					Name:      "pytest",
+					Version:   "8.3.4",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/workspace.lock"},
+					Metadata: &uvlock.Metadata{
+						DepGroupVals: []string{"dev"},
+						Registry:     "https://pypi.org/simple",
+						Sdist:        "pytest-8.3.4.tar.gz",
+						Wheels:       []string{"pytest-8.3.4-py3-none-any.whl"},
+					},
+				},
+				{
+					Name:      "requests",
+					Version:   "2.32.3",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/workspace.lock"},
+					Metadata: &uvlock.Metadata{
+						DepGroupVals: []string{},
+						Registry:     "https://pypi.org/simple",
+						Sdist:        "requests-2.32.3.tar.gz",
+						Wheels:       []string{"requests-2.32.3-py3-none-any.whl"},
+					},
+				},
+				{
+					Name:      "ruff",
+					Version:   "0.8.1",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/workspace.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/astral-sh/ruff",
+						Commit: "84748be16341b76e073d117329f7f5f4ee2941ad",
+					},
+					Metadata: &uvlock.Metadata{
+						DepGroupVals: []string{"lint"},
+					},
+				},
+				{
+					Name:      "pyyaml",
+					Version:   "6.0.2",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/workspace.lock"},
+					Metadata: &uvlock.Metadata{
+						DepGroupVals: []string{},
+						Registry:     "https://internal.example.com/simple",
+						Sdist:        "PyYAML-6.0.2.tar.gz",
+					},
+				},
+			},
+		},
+		{
+			Name: "sources",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/sources.lock",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "httpx",
+					Version:   "0.28.1",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/sources.lock"},
+					Metadata: &uvlock.Metadata{
+						DepGroupVals: []string{},
+						Sdist:        "httpx-0.28.1.tar.gz",
+					},
+				},
+				{
+					Name:      "wheel-only",
+					Version:   "3.1.0",
+					PURLType:  purl.TypePyPi,
+					Locations: []string{"testdata/sources.lock"},
+					Metadata: &uvlock.Metadata{
+						DepGroupVals: []string{},
+						Registry:     "https://pypi.org/simple",
+						Wheels: []string{
+							"wheel_only-3.1.0-cp312-cp312-manylinux_2_17_x86_64.whl",
+							"wheel_only-3.1.0-cp312-cp312-win_amd64.whl",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := uvlock.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
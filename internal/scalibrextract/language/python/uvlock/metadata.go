@@ -0,0 +1,20 @@
+package uvlock
+
+// Metadata holds the metadata for a package in a uv.lock file
+type Metadata struct {
+	// DepGroupVals are the extras and dependency groups of the workspace members
+	// that the package is a direct dependency of
+	DepGroupVals []string
+	// Registry is the url of the index that the package is resolved from, if it is from one
+	Registry string
+	// Sdist is the file name of the source distribution of the package, if it has one
+	Sdist string
+	// Wheels are the file names of the wheels of the package, which are installed in preference
+	// to the source distribution on platforms that one of them is compatible with
+	Wheels []string
+}
+
+// DepGroups returns the dependency groups that the package is in
+func (m *Metadata) DepGroups() []string {
+	return m.DepGroupVals
+}
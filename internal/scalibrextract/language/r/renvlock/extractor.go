@@ -0,0 +1,160 @@
+// Package renvlock extracts CRAN and Bioconductor packages from renv.lock files.
+package renvlock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/r/renvlock"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = renvlock.Name
+)
+
+const (
+	sourceRepository   = "Repository"
+	sourceBioconductor = "Bioconductor"
+	// sourceCRAN is used by lockfiles written by versions of renv before 0.10
+	sourceCRAN = "CRAN"
+	// sourceUnknown is used by lockfiles that do not record the source of a
+	// package, which are assumed to be from the repository that is recorded
+	sourceUnknown = ""
+)
+
+// cranRepositories are the names that renv gives to repositories of CRAN
+// and its mirrors, such as Posit Package Manager
+var cranRepositories = []string{"CRAN", "RSPM", "PPM", "P3M"}
+
+// cranHosts are the hosts of repositories that mirror CRAN
+var cranHosts = []string{"r-project.org", "packagemanager.posit.co", "packagemanager.rstudio.com", "p3m.dev"}
+
+type renvPackage struct {
+	Package    string `json:"Package"`
+	Version    string `json:"Version"`
+	Source     string `json:"Source"`
+	Repository string `json:"Repository"`
+}
+
+type renvRepository struct {
+	Name string `json:"Name"`
+	URL  string `json:"URL"`
+}
+
+type renvLockfile struct {
+	R struct {
+		Repositories []renvRepository `json:"Repositories"`
+	} `json:"R"`
+	Packages map[string]renvPackage `json:"Packages"`
+}
+
+// Extractor extracts CRAN and Bioconductor packages from renv.lock files.
+type Extractor struct{}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor { return &Extractor{} }
+
+// Name of the extractor
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true if the specified file matches renv lockfile patterns.
+func (e Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return filepath.Base(api.Path()) == "renv.lock"
+}
+
+// Extract extracts packages from renv.lock files passed through the scan input.
+//
+// Packages are from CRAN if they are from a repository that mirrors CRAN, which is
+// determined by the name of the repository or by its url if it is listed in the lockfile,
+// and are from Bioconductor if renv installed them from Bioconductor. Packages from other
+// sources (such as GitHub or local directories) are skipped.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	var parsedLockfile *renvLockfile
+
+	err := json.NewDecoder(input.Reader).Decode(&parsedLockfile)
+
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	if parsedLockfile == nil {
+		return inventory.Inventory{Packages: []*extractor.Package{}}, nil
+	}
+
+	repositories := make(map[string]string, len(parsedLockfile.R.Repositories))
+	for _, repo := range parsedLockfile.R.Repositories {
+		repositories[repo.Name] = repo.URL
+	}
+
+	packages := make([]*extractor.Package, 0, len(parsedLockfile.Packages))
+
+	for _, pkg := range parsedLockfile.Packages {
+		metadata := &Metadata{Source: pkg.Source, Repository: pkg.Repository}
+
+		isCRAN := pkg.Source == sourceCRAN ||
+			(pkg.Source == sourceRepository || pkg.Source == sourceUnknown) && isCRANRepository(pkg.Repository, repositories)
+		if !isCRAN && !metadata.IsBioconductor() {
+			continue
+		}
+
+		p := &extractor.Package{
+			Name:      pkg.Package,
+			Version:   pkg.Version,
+			Locations: []string{input.Path},
+			Metadata:  metadata,
+		}
+
+		// there is no purl type for Bioconductor, so its ecosystem is determined from the metadata
+		if isCRAN {
+			p.PURLType = purl.TypeCran
+		}
+
+		packages = append(packages, p)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// isCRANRepository returns whether the repository with the given name is CRAN
+// or a mirror of it, based on its name or on its url if it is known
+func isCRANRepository(name string, repositories map[string]string) bool {
+	if slices.Contains(cranRepositories, name) {
+		return true
+	}
+
+	u, err := url.Parse(repositories[name])
+	if err != nil || u.Hostname() == "" {
+		return false
+	}
+
+	return slices.ContainsFunc(cranHosts, func(host string) bool {
+		return u.Hostname() == host || strings.HasSuffix(u.Hostname(), "."+host)
+	})
+}
+
+// isBioconductorRepository returns whether the repository is one of those of Bioconductor,
+// which renv names after the repositories that are configured by BiocManager
+func isBioconductorRepository(name string) bool {
+	return strings.HasPrefix(name, "BioC")
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,178 @@
+package renvlock_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/r/renvlock"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "renv.lock", want: true},
+		{path: "path/to/my/renv.lock", want: true},
+		{path: "renv.lock/file", want: false},
+		{path: "renv.lock.bak", want: false},
+		{path: "DESCRIPTION", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := renvlock.New()
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func cranPackage(name, version, source, repository, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypeCran,
+		Locations: []string{location},
+		Metadata:  &renvlock.Metadata{Source: source, Repository: repository},
+	}
+}
+
+func bioconductorPackage(name, version, source, repository, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		Locations: []string{location},
+		Metadata:  &renvlock.Metadata{Source: source, Repository: repository},
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-json.txt",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "no packages",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.lock",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "package without a source",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/one-package.lock",
+			},
+			WantPackages: []*extractor.Package{
+				cranPackage("morning", "0.1.0", "", "CRAN", "testdata/one-package.lock"),
+			},
+		},
+		{
+			Name: "two packages",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/two-packages.lock",
+			},
+			WantPackages: []*extractor.Package{
+				cranPackage("markdown", "1.0", "Repository", "CRAN", "testdata/two-packages.lock"),
+				cranPackage("mime", "0.7", "Repository", "CRAN", "testdata/two-packages.lock"),
+			},
+		},
+		{
+			Name: "package without a repository is skipped",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/without-repository.lock",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "packages from github are skipped",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/with-mixed-sources.lock",
+			},
+			WantPackages: []*extractor.Package{
+				cranPackage("markdown", "1.0", "Repository", "CRAN", "testdata/with-mixed-sources.lock"),
+			},
+		},
+		{
+			Name: "cran mirrors",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/mirrors.lock",
+			},
+			WantPackages: []*extractor.Package{
+				cranPackage("cli", "3.6.3", "Repository", "RSPM", "testdata/mirrors.lock"),
+				cranPackage("glue", "1.7.0", "Repository", "mirror", "testdata/mirrors.lock"),
+				cranPackage("rlang", "1.1.4", "Repository", "P3M", "testdata/mirrors.lock"),
+			},
+		},
+		{
+			Name: "lockfile written by an old version of renv",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/legacy-cran.lock",
+			},
+			WantPackages: []*extractor.Package{
+				cranPackage("jsonlite", "1.6.1", "CRAN", "", "testdata/legacy-cran.lock"),
+			},
+		},
+		{
+			Name: "bioconductor packages",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/with-bioconductor.lock",
+			},
+			WantPackages: []*extractor.Package{
+				cranPackage("BH", "1.75.0-0", "Repository", "CRAN", "testdata/with-bioconductor.lock"),
+				bioconductorPackage("BSgenome", "1.60.0", "Bioconductor", "", "testdata/with-bioconductor.lock"),
+			},
+		},
+		{
+			Name: "bioconductor repositories",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/bioconductor-repositories.lock",
+			},
+			WantPackages: []*extractor.Package{
+				bioconductorPackage("BiocGenerics", "0.50.0", "Repository", "BioCsoft", "testdata/bioconductor-repositories.lock"),
+				bioconductorPackage("S4Vectors", "0.42.1", "Bioconductor", "", "testdata/bioconductor-repositories.lock"),
+				cranPackage("digest", "0.6.37", "Repository", "CRAN", "testdata/bioconductor-repositories.lock"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr := renvlock.New()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package renvlock
+
+// Metadata holds the metadata for a package in a renv.lock file
+type Metadata struct {
+	// Source is where renv installed the package from, such as "Repository" or "Bioconductor"
+	Source string
+	// Repository is the name of the repository that the package is from, if it is from one
+	Repository string
+}
+
+// IsBioconductor returns whether the package is from Bioconductor rather than CRAN
+func (m *Metadata) IsBioconductor() bool {
+	return m.Source == sourceBioconductor || isBioconductorRepository(m.Repository)
+}
@@ -0,0 +1,192 @@
+// Package gemfilelock extracts gems from Gemfile.lock files, adding support for
+// gems sourced from git repositories and local paths, and for platform-specific gems.
+package gemfilelock
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/ruby/gemfilelock"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = gemfilelock.Name
+)
+
+// Metadata holds the platforms that a gem was locked for, if it has platform-specific variants
+type Metadata struct {
+	// Platforms are the platforms of the variants of the gem, such as "x86_64-linux",
+	// which is "ruby" for the variant that is not specific to a platform
+	Platforms []string
+}
+
+// Extractor extracts gems from Gemfile.lock files.
+type Extractor struct {
+	actual filesystem.Extractor
+}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor {
+	return &Extractor{actual: gemfilelock.New()}
+}
+
+// Name of the extractor
+func (e *Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e *Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e *Extractor) Requirements() *plugin.Capabilities {
+	return e.actual.Requirements()
+}
+
+// FileRequired returns true if the specified file matches using the underlying Gemfile.lock extractor
+func (e *Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return e.actual.FileRequired(api)
+}
+
+// section is a source of gems in the lockfile, such as a GEM or GIT section
+type section struct {
+	kind     string
+	remote   string
+	revision string
+	specs    []string
+}
+
+// parseSections parses the source sections of the lockfile, which are made up of options
+// indented by two spaces and specs indented by four spaces, with the dependencies of
+// each spec indented further
+func parseSections(input *filesystem.ScanInput) ([]*section, error) {
+	var sections []*section
+	var current *section
+
+	scanner := bufio.NewScanner(input.Reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		switch {
+		case indent == 0:
+			current = &section{kind: line}
+			sections = append(sections, current)
+		case current == nil:
+			return nil, fmt.Errorf("%q is not in a section", line)
+		case indent == 2:
+			key, value, _ := strings.Cut(strings.TrimSpace(line), ": ")
+			switch key {
+			case "remote":
+				// GEM sections can have several remotes, of which the first is used
+				if current.remote == "" {
+					current.remote = value
+				}
+			case "revision":
+				current.revision = value
+			}
+		case indent == 4:
+			current.specs = append(current.specs, strings.TrimSpace(line))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+// parseSpec parses a spec in the form of "name (version)" or "name (version-platform)"
+func parseSpec(spec string) (string, string, string, bool) {
+	m := cachedregexp.MustCompile(`^(\S+) \(([^-\s)]+)(?:-([^)]+))?\)!?$`).FindStringSubmatch(spec)
+	if m == nil {
+		return "", "", "", false
+	}
+
+	return m[1], m[2], m[3], true
+}
+
+// Extract extracts gems from Gemfile.lock files passed through the scan input.
+//
+// Gems are extracted from the source sections of the lockfile, so that:
+//   - gems from GIT sections are identified by the repository and commit they were locked to
+//   - gems from PATH sections are skipped as they are local
+//   - gems with variants for several platforms are only reported once
+func (e *Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	sections, err := parseSections(input)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+	seen := make(map[string]*extractor.Package)
+	platforms := make(map[*extractor.Package][]string)
+
+	for _, sec := range sections {
+		if !slices.Contains([]string{"GEM", "GIT", "PLUGIN SOURCE"}, sec.kind) {
+			// PATH sections are local gems, which are part of the project itself
+			continue
+		}
+
+		for _, spec := range sec.specs {
+			name, version, platform, ok := parseSpec(spec)
+			if !ok {
+				continue
+			}
+
+			if platform == "" {
+				platform = "ruby"
+			}
+
+			key := sec.kind + ":" + sec.remote + ":" + name + "@" + version
+			if pkg, ok := seen[key]; ok {
+				if !slices.Contains(platforms[pkg], platform) {
+					platforms[pkg] = append(platforms[pkg], platform)
+				}
+
+				continue
+			}
+
+			pkg := &extractor.Package{
+				Name:      name,
+				Version:   version,
+				Locations: []string{input.Path},
+			}
+
+			if sec.kind == "GIT" {
+				// gems from git repositories may not match any version that has been published,
+				// so they are matched by the commit that they were locked to instead
+				pkg.SourceCode = &extractor.SourceCodeIdentifier{Repo: sec.remote, Commit: sec.revision}
+			} else {
+				pkg.PURLType = purl.TypeGem
+			}
+
+			platforms[pkg] = []string{platform}
+			seen[key] = pkg
+			packages = append(packages, pkg)
+		}
+	}
+
+	for pkg, p := range platforms {
+		if len(p) > 1 || p[0] != "ruby" {
+			pkg.Metadata = &Metadata{Platforms: p}
+		}
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = &Extractor{}
@@ -0,0 +1,146 @@
+package gemfilelock_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/ruby/gemfilelock"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "Gemfile.lock", want: true},
+		{path: "path/to/my/Gemfile.lock", want: true},
+		{path: "gems.locked", want: true},
+		{path: "Gemfile.lock/file", want: false},
+		{path: "Gemfile", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := gemfilelock.New()
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid lockfile",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.lock",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "git gems are identified by commit and path gems are skipped",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/git-and-path.lock",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "actionpack",
+					Version:   "7.2.0.alpha",
+					Locations: []string{"testdata/git-and-path.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/rails/rails.git",
+						Commit: "8f2a7a5f8a1e6c4c2e4b1d7a3f9e0c5b6d2a1f3e",
+					},
+				},
+				{
+					Name:      "activesupport",
+					Version:   "7.2.0.alpha",
+					Locations: []string{"testdata/git-and-path.lock"},
+					SourceCode: &extractor.SourceCodeIdentifier{
+						Repo:   "https://github.com/rails/rails.git",
+						Commit: "8f2a7a5f8a1e6c4c2e4b1d7a3f9e0c5b6d2a1f3e",
+					},
+				},
+				{
+					Name:      "rack",
+					Version:   "3.0.8",
+					PURLType:  purl.TypeGem,
+					Locations: []string{"testdata/git-and-path.lock"},
+				},
+			},
+		},
+		{
+			Name: "platform-specific gems are only reported once",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/platforms.lock",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "mini_portile2",
+					Version:   "2.8.5",
+					PURLType:  purl.TypeGem,
+					Locations: []string{"testdata/platforms.lock"},
+				},
+				{
+					Name:      "nokogiri",
+					Version:   "1.16.0",
+					PURLType:  purl.TypeGem,
+					Locations: []string{"testdata/platforms.lock"},
+					Metadata: &gemfilelock.Metadata{
+						Platforms: []string{"ruby", "arm64-darwin", "x86_64-linux"},
+					},
+				},
+				{
+					Name:      "racc",
+					Version:   "1.7.3",
+					PURLType:  purl.TypeGem,
+					Locations: []string{"testdata/platforms.lock"},
+				},
+				{
+					Name:      "sqlite3",
+					Version:   "1.7.0",
+					PURLType:  purl.TypeGem,
+					Locations: []string{"testdata/platforms.lock"},
+					Metadata: &gemfilelock.Metadata{
+						Platforms: []string{"x86_64-linux"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			extr := gemfilelock.New()
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package terraformlock extracts Terraform providers from .terraform.lock.hcl files.
+package terraformlock
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "terraform/terraformlock"
+)
+
+// publicRegistries are the hosts of the public provider registries, which require
+// providers to be published from a GitHub repository named "terraform-provider-<type>"
+// that is owned by the namespace of the provider
+var publicRegistries = []string{
+	"registry.terraform.io",
+	"registry.opentofu.org",
+}
+
+// Extractor extracts Terraform providers from the .terraform.lock.hcl file written by `terraform init`.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for .terraform.lock.hcl files
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	return filepath.Base(fapi.Path()) == ".terraform.lock.hcl"
+}
+
+// Extract extracts providers from .terraform.lock.hcl files passed through the scan input.
+//
+// Providers are written in their own "provider" block with their exact version,
+// which are always formatted by Terraform with one attribute per line:
+//
+//	provider "registry.terraform.io/hashicorp/aws" {
+//	  version     = "5.31.0"
+//	  constraints = "~> 5.0"
+//	  hashes = [...]
+//	}
+//
+// Providers are written in Go and so have their advisories published against the
+// Go module of their source repository, which is what they are returned as.
+// Providers from private registries are skipped, as their repository is not known.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	packages := []*extractor.Package{}
+
+	var current *Metadata
+	var version string
+
+	scanner := bufio.NewScanner(input.Reader)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if matches := cachedregexp.MustCompile(`^provider\s+"([^"]+)"\s*\{$`).FindStringSubmatch(line); matches != nil {
+			current = &Metadata{Source: matches[1]}
+			version = ""
+
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if line == "}" {
+			if pkg, ok := providerToPackage(*current, version); ok {
+				pkg.Locations = []string{input.Path}
+				packages = append(packages, pkg)
+			}
+			current = nil
+
+			continue
+		}
+
+		matches := cachedregexp.MustCompile(`^(version|constraints)\s*=\s*(".*")$`).FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		value, err := strconv.Unquote(matches[2])
+		if err != nil {
+			return inventory.Inventory{}, fmt.Errorf("could not extract from %s: line %d: invalid %s: %w", input.Path, lineNum, matches[1], err)
+		}
+
+		if matches[1] == "version" {
+			version = value
+		} else {
+			current.Constraints = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	if current != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: unterminated provider block for %s", input.Path, current.Source)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// providerToPackage returns the Go module of the provider with the given source
+// address, returning false if the provider is not from a public registry
+func providerToPackage(metadata Metadata, version string) (*extractor.Package, bool) {
+	parts := strings.Split(metadata.Source, "/")
+	if len(parts) != 3 || version == "" {
+		return nil, false
+	}
+
+	host, namespace, providerType := parts[0], parts[1], parts[2]
+	if !slices.Contains(publicRegistries, strings.ToLower(host)) {
+		return nil, false
+	}
+
+	return &extractor.Package{
+		Name:     "github.com/" + namespace + "/terraform-provider-" + providerType,
+		Version:  version,
+		PURLType: purl.TypeGolang,
+		Metadata: &metadata,
+	}, true
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,136 @@
+package terraformlock_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/terraform/terraformlock"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".terraform.lock.hcl", want: true},
+		{path: "path/to/infra/.terraform.lock.hcl", want: true},
+		{path: "terraform.lock.hcl", want: false},
+		{path: "main.tf", want: false},
+		{path: ".terraform.lock.hcl.bak", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := terraformlock.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "invalid",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid/.terraform.lock.hcl",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "could not extract from"},
+		},
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty/.terraform.lock.hcl",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "basic",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/basic/.terraform.lock.hcl",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "github.com/hashicorp/terraform-provider-aws",
+					Version:   "5.31.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/basic/.terraform.lock.hcl"},
+					Metadata: &terraformlock.Metadata{
+						Source:      "registry.terraform.io/hashicorp/aws",
+						Constraints: "~> 5.0",
+					},
+				},
+				{
+					Name:      "github.com/integrations/terraform-provider-github",
+					Version:   "6.0.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/basic/.terraform.lock.hcl"},
+					Metadata: &terraformlock.Metadata{
+						Source: "registry.terraform.io/integrations/github",
+					},
+				},
+				{
+					Name:      "github.com/hashicorp/terraform-provider-random",
+					Version:   "3.6.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/basic/.terraform.lock.hcl"},
+					Metadata: &terraformlock.Metadata{
+						Source:      "registry.opentofu.org/hashicorp/random",
+						Constraints: ">= 3.0.0",
+					},
+				},
+			},
+		},
+		{
+			Name: "private registry",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/private/.terraform.lock.hcl",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "github.com/hashicorp/terraform-provider-aws",
+					Version:   "5.31.0",
+					PURLType:  purl.TypeGolang,
+					Locations: []string{"testdata/private/.terraform.lock.hcl"},
+					Metadata: &terraformlock.Metadata{
+						Source: "registry.terraform.io/hashicorp/aws",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := terraformlock.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
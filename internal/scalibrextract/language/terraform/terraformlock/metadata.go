@@ -0,0 +1,9 @@
+package terraformlock
+
+// Metadata holds the metadata for a provider in a .terraform.lock.hcl file
+type Metadata struct {
+	// Source is the source address of the provider, e.g. "registry.terraform.io/hashicorp/aws"
+	Source string
+	// Constraints are the version constraints the provider was selected with, if any
+	Constraints string
+}
@@ -0,0 +1,2 @@
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "5.31.0"
@@ -0,0 +1,7 @@
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "5.31.0"
+}
+
+provider "terraform.example.com/example/internal" {
+  version = "1.2.3"
+}
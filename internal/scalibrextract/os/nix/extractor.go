@@ -0,0 +1,95 @@
+// Package nix extracts packages from the Nix store that were built from packages
+// of an ecosystem that OSV has advisories for, such as Python and Ruby packages.
+package nix
+
+import (
+	"context"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/nix"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = nix.Name
+)
+
+// Extractor extracts packages from the directories of the Nix store.
+type Extractor struct {
+	actual filesystem.Extractor
+}
+
+// New returns a new instance of the extractor.
+func New() filesystem.Extractor {
+	return &Extractor{actual: nix.New()}
+}
+
+// Name of the extractor
+func (e *Extractor) Name() string { return Name }
+
+// Version of the extractor
+func (e *Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e *Extractor) Requirements() *plugin.Capabilities {
+	return e.actual.Requirements()
+}
+
+// FileRequired returns true for the first file of each package in the Nix store
+// using the underlying Nix extractor
+func (e *Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return e.actual.FileRequired(api)
+}
+
+// upstream returns the name and purl type of the package that a package in the
+// Nix store was built from, if it is from an ecosystem that OSV has advisories for
+func upstream(name string) (string, string, bool) {
+	// nixpkgs prefixes the names of packages built for an interpreter with the
+	// interpreter, e.g. "python3.11-requests" and "ruby3.1-nokogiri"
+	m := cachedregexp.MustCompile(`^(python|ruby)\d+(?:\.\d+)*-(.+)$`).FindStringSubmatch(name)
+	if m == nil {
+		return "", "", false
+	}
+
+	switch m[1] {
+	case "python":
+		return m[2], purl.TypePyPi, true
+	case "ruby":
+		return m[2], purl.TypeGem, true
+	}
+
+	return "", "", false
+}
+
+// Extract extracts packages from the Nix store using the underlying Nix extractor,
+// which identifies them by the names of their store paths.
+//
+// OSV does not have advisories for Nix packages themselves, so only packages that were
+// built from a package of another ecosystem are returned, as that package.
+func (e *Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	inv, err := e.actual.Extract(ctx, input)
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+
+	packages := make([]*extractor.Package, 0, len(inv.Packages))
+	for _, pkg := range inv.Packages {
+		name, purlType, ok := upstream(pkg.Name)
+		if !ok {
+			continue
+		}
+
+		pkg.Name = name
+		pkg.PURLType = purlType
+		packages = append(packages, pkg)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+var _ filesystem.Extractor = &Extractor{}
@@ -0,0 +1,110 @@
+package nix_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	nixmeta "github.com/google/osv-scalibr/extractor/filesystem/os/nix/metadata"
+	scalibrfs "github.com/google/osv-scalibr/fs"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/os/nix"
+)
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "etc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "etc/os-release"), []byte("ID=nixos\nVERSION_CODENAME=vicuna\nVERSION_ID=\"24.11\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []*extractor.Package
+	}{
+		{
+			name: "python package",
+			path: "nix/store/0gmg2gz7rbpxrb0jpqqrikzfvb1dzfkz-python3.11-requests-2.31.0/lib/python3.11/site-packages/requests/__init__.py",
+			want: []*extractor.Package{
+				{
+					Name:     "requests",
+					Version:  "2.31.0",
+					PURLType: purl.TypePyPi,
+					Metadata: &nixmeta.Metadata{
+						PackageName:       "python3.11-requests",
+						PackageVersion:    "2.31.0",
+						PackageHash:       "0gmg2gz7rbpxrb0jpqqrikzfvb1dzfkz",
+						OSID:              "nixos",
+						OSVersionCodename: "vicuna",
+						OSVersionID:       "24.11",
+					},
+					Locations: []string{"nix/store/0gmg2gz7rbpxrb0jpqqrikzfvb1dzfkz-python3.11-requests-2.31.0/lib/python3.11/site-packages/requests/__init__.py"},
+				},
+			},
+		},
+		{
+			name: "ruby gem",
+			path: "nix/store/4yb4rbpxz0n1xlz2vjbh2h5zj7c5qmzk-ruby3.1-nokogiri-1.15.4/lib/foo",
+			want: []*extractor.Package{
+				{
+					Name:     "nokogiri",
+					Version:  "1.15.4",
+					PURLType: purl.TypeGem,
+					Metadata: &nixmeta.Metadata{
+						PackageName:       "ruby3.1-nokogiri",
+						PackageVersion:    "1.15.4",
+						PackageHash:       "4yb4rbpxz0n1xlz2vjbh2h5zj7c5qmzk",
+						OSID:              "nixos",
+						OSVersionCodename: "vicuna",
+						OSVersionID:       "24.11",
+					},
+					Locations: []string{"nix/store/4yb4rbpxz0n1xlz2vjbh2h5zj7c5qmzk-ruby3.1-nokogiri-1.15.4/lib/foo"},
+				},
+			},
+		},
+		{
+			name: "interpreter",
+			path: "nix/store/1ddf3x30m0z6kknmrmapsc7liz8npi1w-python3-3.11.8/bin/python3",
+			want: []*extractor.Package{},
+		},
+		{
+			name: "package without an upstream ecosystem",
+			path: "nix/store/xakcaxsqdzjszym0vji2r8n0wdy2inqc-perl5.38.2-FCGI-ProcManager-0.28/foo",
+			want: []*extractor.Package{},
+		},
+		{
+			name: "not a package",
+			path: "nix/store/xzlmnp0lblcbscy36nlgif3js4mc68gm-base-system/etc/group",
+			want: []*extractor.Package{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := nix.New()
+
+			got, err := e.Extract(t.Context(), &filesystem.ScanInput{
+				FS:   scalibrfs.DirFS(root),
+				Path: tt.path,
+				Root: root,
+			})
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got.Packages); diff != "" {
+				t.Errorf("Extract(%q) diff (-want +got):\n%s", tt.path, diff)
+			}
+		})
+	}
+}
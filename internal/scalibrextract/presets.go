@@ -17,36 +17,72 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/bunlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/pnpmlock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/yarnlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/php/composerlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pdmlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/poetrylock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/python/uvlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/wheelegg"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/r/renvlock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/ruby/gemfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargoauditable"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargolock"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/apk"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx"
 	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/devcontainer"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/dockerfile"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/githubactions"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/filesystem/vendored"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda/condameta"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda/environmentyml"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/cmakelists"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/unrealproject"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/vcpkg"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/csharp/unitypackages"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/golang/modulestxt"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/java/pomxmlenhanceable"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/java/wrapperproperties"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/jsbundle"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/nodemodules"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/yarnlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/nix/flakelock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/php/composerinstalled"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/ipynb"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/pylock"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/requirementsenhancable"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/uvlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/r/renvlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/ruby/gemfilelock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/terraform/terraformlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/os/nix"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/runtime/electron"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/detect"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/vcs/gitrepo"
 )
 
 var ExtractorsSBOMs = []string{
 	spdx.Name,
 	cdx.Name,
+	detect.Name,
 }
 
 var ExtractorsLockfiles = []string{
 	// C
 	conanlock.Name,
+	cmakelists.Name,
+	vcpkg.Name,
+
+	// Conda
+	environmentyml.Name,
+
+	// Dev containers
+	devcontainer.Name,
+
+	// Game engines
+	unitypackages.Name,
+	unrealproject.Name,
+
+	// Dockerfiles
+	dockerfile.Name,
 
 	// Erlang
 	mixlock.Name,
@@ -54,13 +90,18 @@ var ExtractorsLockfiles = []string{
 	// Flutter
 	pubspec.Name,
 
+	// GitHub Actions
+	githubactions.Name,
+
 	// Go
 	gomod.Name,
+	modulestxt.Name,
 
 	// Java
 	gradlelockfile.Name,
 	gradleverificationmetadataxml.Name,
 	pomxmlenhanceable.Name,
+	wrapperproperties.Name,
 
 	// Javascript
 	packagelockjson.Name,
@@ -68,6 +109,9 @@ var ExtractorsLockfiles = []string{
 	yarnlock.Name,
 	bunlock.Name,
 
+	// Nix
+	flakelock.Name,
+
 	// PHP
 	composerlock.Name,
 
@@ -77,6 +121,8 @@ var ExtractorsLockfiles = []string{
 	poetrylock.Name,
 	requirementsenhancable.Name,
 	uvlock.Name,
+	pylock.Name,
+	ipynb.Name,
 
 	// R
 	renvlock.Name,
@@ -87,6 +133,9 @@ var ExtractorsLockfiles = []string{
 	// Rust
 	cargolock.Name,
 
+	// Terraform
+	terraformlock.Name,
+
 	// NuGet
 	depsjson.Name,
 	packagesconfig.Name,
@@ -106,18 +155,27 @@ var ExtractorsArtifacts = []string{
 	// --- Project artifacts ---
 	// Python
 	wheelegg.Name,
+	// Conda
+	condameta.Name,
 	// Java
 	archive.Name,
 	// Go
 	gobinary.Name,
 	// Javascript
 	nodemodules.Name,
+	jsbundle.Name,
+	// PHP
+	composerinstalled.Name,
 	// Rust
 	cargoauditable.Name,
+	// Desktop applications
+	electron.Name,
 
 	// --- OS packages ---
 	// Alpine
 	apk.Name,
 	// Debian
 	dpkg.Name,
+	// NixOS
+	nix.Name,
 }
@@ -0,0 +1,223 @@
+// Package electron detects the Electron and Chromium runtimes bundled with desktop applications.
+package electron
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "runtime/electron"
+
+	// licensesFileName is the file with the licenses of Chromium, which is distributed
+	// next to the binary of every Electron application
+	licensesFileName = "LICENSES.chromium.html"
+	// versionFileName is the file with the version of Electron in its own distributions
+	versionFileName = "version"
+
+	// RuntimeElectron is the runtime of Electron applications
+	RuntimeElectron = "electron"
+	// RuntimeChromium is the runtime that Electron and other embedded browsers are built on
+	RuntimeChromium = "chromium"
+
+	// DetectedFromVersionFile is for runtimes detected from the "version" file of a distribution
+	DetectedFromVersionFile = "version-file"
+	// DetectedFromBinary is for runtimes detected from a signature within a binary
+	DetectedFromBinary = "binary"
+
+	// chunkSize is how much of a binary is searched for signatures at a time, with
+	// the chunks overlapping by signatureOverlap so signatures are not split between them
+	chunkSize        = 1 << 20
+	signatureOverlap = 64
+)
+
+// Extractor detects the Electron and Chromium runtimes bundled with desktop applications.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for the LICENSES.chromium.html file that is distributed
+// next to the binary of Electron applications
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	return path.Base(filepath.ToSlash(fapi.Path())) == licensesFileName
+}
+
+// Extract detects the runtimes of the application in the directory of the
+// LICENSES.chromium.html file passed through the scan input.
+//
+// The version of Electron is read from its "version" file if the application is an
+// Electron distribution, and otherwise from the "Electron/<version>" signature of its
+// user agent within the executables of the directory, which also has the version of
+// Chromium that it is built on as "Chrome/<version>".
+//
+// Electron is returned as the "electron" npm package, which its advisories are published
+// under, while Chromium is returned without an ecosystem as OSV does not have advisories
+// for Chromium itself, so that it is reported as an unscanned package to be reviewed.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	dir := path.Dir(filepath.ToSlash(input.Path))
+
+	entries, err := fs.ReadDir(input.FS, dir)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages := []*extractor.Package{}
+
+	electronVersion, fromVersionFile := readVersionFile(input.FS, path.Join(dir, versionFileName))
+	if fromVersionFile {
+		packages = append(packages, newPackage(RuntimeElectron, electronVersion, DetectedFromVersionFile, path.Join(dir, versionFileName)))
+	}
+
+	for _, entry := range entries {
+		if !isExecutable(entry) {
+			continue
+		}
+
+		binary := path.Join(dir, entry.Name())
+
+		found, err := findSignatures(input.FS, binary)
+		if err != nil {
+			return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", binary, err)
+		}
+
+		if found.electron == "" && found.chromium == "" {
+			continue
+		}
+
+		if found.electron != "" && !fromVersionFile {
+			packages = append(packages, newPackage(RuntimeElectron, found.electron, DetectedFromBinary, binary))
+		}
+
+		if found.chromium != "" {
+			packages = append(packages, newPackage(RuntimeChromium, found.chromium, DetectedFromBinary, binary))
+		}
+
+		break
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+func newPackage(runtime, version, detectedFrom, location string) *extractor.Package {
+	pkg := &extractor.Package{
+		Name:      runtime,
+		Version:   version,
+		Locations: []string{location},
+		Metadata:  &Metadata{Runtime: runtime, DetectedFrom: detectedFrom},
+	}
+
+	if runtime == RuntimeElectron {
+		pkg.PURLType = purl.TypeNPM
+	}
+
+	return pkg
+}
+
+// readVersionFile reads the version of Electron from the "version" file of a distribution
+func readVersionFile(fsys fs.FS, p string) (string, bool) {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(io.LimitReader(f, 64)).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", false
+	}
+
+	version := strings.TrimPrefix(strings.TrimSpace(line), "v")
+	if !cachedregexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`).MatchString(version) {
+		return "", false
+	}
+
+	return version, true
+}
+
+// isExecutable returns whether the entry could be the executable of the application,
+// which has no extension on Linux and an ".exe" extension on Windows
+func isExecutable(entry fs.DirEntry) bool {
+	if !entry.Type().IsRegular() || entry.Name() == versionFileName {
+		return false
+	}
+
+	ext := path.Ext(entry.Name())
+
+	return ext == "" || strings.EqualFold(ext, ".exe")
+}
+
+type signatures struct {
+	electron string
+	chromium string
+}
+
+// findSignatures searches the binary for the versions in the signatures of its user agent
+func findSignatures(fsys fs.FS, p string) (signatures, error) {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return signatures{}, err
+	}
+	defer f.Close()
+
+	var found signatures
+
+	buf := make([]byte, 0, chunkSize+signatureOverlap)
+	chunk := make([]byte, chunkSize)
+
+	for {
+		n, err := io.ReadFull(f, chunk)
+		buf = append(buf, chunk[:n]...)
+
+		if found.electron == "" {
+			if m := cachedregexp.MustCompile(`Electron/(\d+\.\d+\.\d+)`).FindSubmatch(buf); m != nil {
+				found.electron = string(m[1])
+			}
+		}
+
+		if found.chromium == "" {
+			if m := cachedregexp.MustCompile(`Chrome/(\d+\.\d+\.\d+\.\d+)`).FindSubmatch(buf); m != nil {
+				found.chromium = string(m[1])
+			}
+		}
+
+		if found.electron != "" && found.chromium != "" {
+			return found, nil
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return found, nil
+		}
+		if err != nil {
+			return signatures{}, err
+		}
+
+		// keep the end of the chunk in case a signature was split across chunks
+		buf = append(buf[:0], buf[max(0, len(buf)-signatureOverlap):]...)
+	}
+}
+
+var _ filesystem.Extractor = Extractor{}
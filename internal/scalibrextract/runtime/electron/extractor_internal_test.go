@@ -0,0 +1,33 @@
+package electron
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_findSignatures_AcrossChunks(t *testing.T) {
+	t.Parallel()
+
+	// place each signature so that it is split between the first and second chunks
+	chromium := []byte("Chrome/120.0.6099.56")
+	electron := []byte("Electron/28.1.0")
+
+	data := bytes.Repeat([]byte{0}, chunkSize-len(chromium)/2)
+	data = append(data, chromium...)
+	data = append(data, bytes.Repeat([]byte{0}, chunkSize-len(data)%chunkSize-len(electron)/2)...)
+	data = append(data, electron...)
+	data = append(data, bytes.Repeat([]byte{0}, 128)...)
+
+	fsys := fstest.MapFS{"app/my-app": {Data: data}}
+
+	got, err := findSignatures(fsys, "app/my-app")
+	if err != nil {
+		t.Fatalf("findSignatures() error = %v", err)
+	}
+
+	want := signatures{electron: "28.1.0", chromium: "120.0.6099.56"}
+	if got != want {
+		t.Errorf("findSignatures() = %+v, want %+v", got, want)
+	}
+}
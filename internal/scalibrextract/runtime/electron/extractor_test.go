@@ -0,0 +1,132 @@
+package electron_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/runtime/electron"
+)
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "LICENSES.chromium.html", want: true},
+		{path: "opt/my-app/LICENSES.chromium.html", want: true},
+		{path: "Program Files/My App/LICENSES.chromium.html", want: true},
+		{path: "opt/my-app/LICENSE.electron.txt", want: false},
+		{path: "opt/my-app/my-app", want: false},
+		{path: "opt/my-app/version", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := electron.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "electron_distribution",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/dist/LICENSES.chromium.html",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "electron",
+					Version:   "28.1.0",
+					PURLType:  purl.TypeNPM,
+					Locations: []string{"testdata/dist/version"},
+					Metadata:  &electron.Metadata{Runtime: electron.RuntimeElectron, DetectedFrom: electron.DetectedFromVersionFile},
+				},
+				{
+					Name:      "chromium",
+					Version:   "120.0.6099.56",
+					Locations: []string{"testdata/dist/electron"},
+					Metadata:  &electron.Metadata{Runtime: electron.RuntimeChromium, DetectedFrom: electron.DetectedFromBinary},
+				},
+			},
+		},
+		{
+			Name: "packaged_application",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/packaged/LICENSES.chromium.html",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "electron",
+					Version:   "27.1.3",
+					PURLType:  purl.TypeNPM,
+					Locations: []string{"testdata/packaged/my-app"},
+					Metadata:  &electron.Metadata{Runtime: electron.RuntimeElectron, DetectedFrom: electron.DetectedFromBinary},
+				},
+				{
+					Name:      "chromium",
+					Version:   "118.0.5993.159",
+					Locations: []string{"testdata/packaged/my-app"},
+					Metadata:  &electron.Metadata{Runtime: electron.RuntimeChromium, DetectedFrom: electron.DetectedFromBinary},
+				},
+			},
+		},
+		{
+			Name: "chromium_only",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/chromium-only/LICENSES.chromium.html",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "chromium",
+					Version:   "119.0.6045.199",
+					Locations: []string{"testdata/chromium-only/cefclient.exe"},
+					Metadata:  &electron.Metadata{Runtime: electron.RuntimeChromium, DetectedFrom: electron.DetectedFromBinary},
+				},
+			},
+		},
+		{
+			Name: "unknown",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/unknown/LICENSES.chromium.html",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := electron.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package electron
+
+// Metadata holds the metadata for a runtime bundled with a desktop application
+type Metadata struct {
+	// Runtime is the runtime that was detected, either "electron" or "chromium"
+	Runtime string
+	// DetectedFrom is how the version was detected, either from the "version" file
+	// of an Electron distribution or from a signature within the binary
+	DetectedFrom string
+}
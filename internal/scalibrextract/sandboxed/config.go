@@ -0,0 +1,87 @@
+// Package sandboxed extracts packages from manifests that can only be accurately
+// resolved by the native tool of their ecosystem, by running the tool in a container
+// to generate a lockfile for the manifest which is then extracted, so that the build
+// scripts of the project are never run on the host.
+package sandboxed
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Tool is the native tool of an ecosystem that manifests are resolved with
+type Tool string
+
+const (
+	ToolBundler Tool = "bundler"
+	ToolGradle  Tool = "gradle"
+	ToolMaven   Tool = "maven"
+)
+
+// Tools returns the names of every tool that can be run in a sandbox
+func Tools() []string {
+	return slices.Sorted(maps.Keys(tools))
+}
+
+// ParseTool returns the tool with the given name
+func ParseTool(name string) (Tool, error) {
+	if _, ok := tools[name]; !ok {
+		return "", fmt.Errorf("unsupported tool %q - must be one of: %s", name, strings.Join(Tools(), ", "))
+	}
+
+	return Tool(name), nil
+}
+
+// NetworkMode determines the network access that tools have when they are run
+type NetworkMode string
+
+const (
+	// NetworkNone runs tools without any network access, so every dependency
+	// must already be available to the tool from within the project
+	NetworkNone NetworkMode = "none"
+	// NetworkRegistry runs tools with network access, configuring them to
+	// only resolve dependencies from the registry of their ecosystem
+	NetworkRegistry NetworkMode = "registry"
+)
+
+// NetworkModes returns the names of every network mode
+func NetworkModes() []string {
+	return []string{string(NetworkNone), string(NetworkRegistry)}
+}
+
+// Config configures how tools are run in a sandbox
+type Config struct {
+	// Runtime is the command of the container runtime that runs the sandbox,
+	// such as "docker" or "podman", followed by any arguments to always pass it
+	Runtime []string
+	Network NetworkMode
+	// Registries overrides the registry that each tool resolves dependencies
+	// from when the network mode is NetworkRegistry
+	Registries map[Tool]string
+	// Images overrides the container image that each tool is run in
+	Images map[Tool]string
+}
+
+// ParseToolValues parses definitions in the form of "<tool>=<value>" into a map
+// of the value for each tool, such as for the registry or image of each tool
+func ParseToolValues(definitions []string) (map[Tool]string, error) {
+	values := make(map[Tool]string, len(definitions))
+
+	for _, definition := range definitions {
+		name, value, ok := strings.Cut(definition, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid value %q - must be in the form of <tool>=<value>", definition)
+		}
+
+		tool, err := ParseTool(name)
+		if err != nil {
+			return nil, err
+		}
+
+		values[tool] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,283 @@
+package sandboxed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// NamePrefix is the prefix of the names of all sandboxed extractors,
+	// which are followed by the name of the tool that they run
+	NamePrefix = "sandboxed/"
+)
+
+// skippedDirs are not copied into the sandbox, as they are not needed to resolve the manifest
+var skippedDirs = []string{".git", "node_modules"}
+
+// Extractor extracts packages from the manifests of an ecosystem by running its
+// native tool in a container to generate a lockfile, which is then extracted.
+//
+// The tool is run on a copy of the directory of the manifest, so that the build
+// scripts of the project cannot change the files on the host.
+type Extractor struct {
+	tool   Tool
+	config Config
+}
+
+// New creates an extractor that resolves manifests by running the tool in a sandbox
+func New(t Tool, config Config) (*Extractor, error) {
+	if _, err := ParseTool(string(t)); err != nil {
+		return nil, err
+	}
+
+	if len(config.Runtime) == 0 {
+		return nil, errors.New("missing container runtime")
+	}
+
+	if !slices.Contains(NetworkModes(), string(config.Network)) {
+		return nil, fmt.Errorf("unsupported network mode %q - must be one of: %s", config.Network, strings.Join(NetworkModes(), ", "))
+	}
+
+	return &Extractor{tool: t, config: config}, nil
+}
+
+// Supersedes returns the names of the extractors that extract the same
+// manifests as this extractor, but less accurately
+func (e *Extractor) Supersedes() []string {
+	return tools[string(e.tool)].supersedes
+}
+
+// Name of the extractor
+func (e *Extractor) Name() string { return NamePrefix + string(e.tool) }
+
+// Version of the extractor
+func (e *Extractor) Version() int { return 0 }
+
+// Requirements of the extractor
+func (e *Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for the manifests of the tool
+func (e *Extractor) FileRequired(api filesystem.FileAPI) bool {
+	return slices.Contains(tools[string(e.tool)].manifests, filepath.Base(api.Path()))
+}
+
+// Extract runs the tool in a sandbox to generate a lockfile for the manifest
+// passed through the scan input, and returns the packages in the lockfile
+func (e *Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	t := tools[string(e.tool)]
+	dir := path.Dir(filepath.ToSlash(input.Path))
+
+	skip, err := e.isResolved(input.FS, dir)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+	if skip {
+		return inventory.Inventory{}, nil
+	}
+
+	tmp, err := os.MkdirTemp("", "osv-scanner-sandbox-*")
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+	defer os.RemoveAll(tmp)
+
+	workspace := filepath.Join(tmp, "workspace")
+	osv := filepath.Join(tmp, "osv-scanner")
+
+	if err := copyDir(input.FS, dir, workspace); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: failed to copy project: %w", input.Path, err)
+	}
+
+	if err := os.Mkdir(osv, 0o755); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	registry := t.registry
+	if r, ok := e.config.Registries[e.tool]; ok {
+		registry = r
+	}
+
+	if t.files != nil {
+		for name, content := range t.files(e.config.Network, registry) {
+			if err := os.WriteFile(filepath.Join(osv, name), []byte(content), 0o644); err != nil {
+				return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+			}
+		}
+	}
+
+	var env []string
+	if t.env != nil {
+		env = t.env(e.config.Network, registry, path.Base(filepath.ToSlash(input.Path)))
+	}
+
+	if err := e.run(ctx, workspace, osv, env, t.command(e.config.Network)); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	packages, err := t.extract(ctx, osv, workspace, input.Path)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: failed to read the lockfile generated by %s: %w", input.Path, e.tool, err)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// isResolved returns whether the manifests in dir do not need to be resolved, either
+// because they already have a lockfile or because they are a module of the parent
+// directory, which is resolved along with all of its modules
+func (e *Extractor) isResolved(fsys fs.FS, dir string) (bool, error) {
+	t := tools[string(e.tool)]
+
+	for _, name := range t.lockfiles {
+		if exists, err := fileExists(fsys, path.Join(dir, name)); exists || err != nil {
+			return exists, err
+		}
+	}
+
+	if !t.modules || dir == "." {
+		return false, nil
+	}
+
+	for _, name := range t.manifests {
+		if exists, err := fileExists(fsys, path.Join(path.Dir(dir), name)); exists || err != nil {
+			return exists, err
+		}
+	}
+
+	return false, nil
+}
+
+// run runs the command in a container of the image of the tool, with the workspace
+// and osv-scanner directories mounted and the network access of the network mode
+func (e *Extractor) run(ctx context.Context, workspace string, osv string, env []string, command []string) error {
+	args := e.containerArgs(workspace, osv, env, command)
+
+	//nolint:gosec // the runtime is given by the user, and the container is what sandboxes the command
+	cmd := exec.CommandContext(ctx, e.config.Runtime[0], args...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return fmt.Errorf("%s failed in sandbox: %w", e.tool, err)
+	}
+
+	return nil
+}
+
+// containerArgs returns the arguments to the container runtime to run the command
+func (e *Extractor) containerArgs(workspace string, osv string, env []string, command []string) []string {
+	image := tools[string(e.tool)].image
+	if i, ok := e.config.Images[e.tool]; ok {
+		image = i
+	}
+
+	args := slices.Clone(e.config.Runtime[1:])
+	args = append(args,
+		"run", "--rm",
+		"--cap-drop=ALL",
+		"--security-opt=no-new-privileges",
+		"--tmpfs=/tmp",
+		"--env=HOME=/tmp",
+	)
+
+	if e.config.Network == NetworkNone {
+		args = append(args, "--network=none")
+	}
+
+	// run as the current user so that the files written by the tool can be read and removed
+	if filepath.Base(e.config.Runtime[0]) == "podman" {
+		args = append(args, "--userns=keep-id")
+	} else if uid := os.Getuid(); uid != -1 {
+		args = append(args, "--user="+strconv.Itoa(uid)+":"+strconv.Itoa(os.Getgid()))
+	}
+
+	for _, v := range env {
+		args = append(args, "--env="+v)
+	}
+
+	args = append(args,
+		"--volume="+workspace+":"+workspaceDir,
+		"--volume="+osv+":"+osvDir,
+		"--workdir="+workspaceDir,
+		image,
+	)
+
+	return append(args, command...)
+}
+
+// copyDir copies the files in dir of fsys to the directory at dst,
+// skipping symlinks and the directories that are not needed
+func copyDir(fsys fs.FS, dir string, dst string) error {
+	return fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, filepath.FromSlash(rel))
+
+		switch {
+		case d.IsDir() && p != dir && slices.Contains(skippedDirs, d.Name()):
+			return fs.SkipDir
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case !d.Type().IsRegular():
+			return nil
+		}
+
+		src, err := fsys.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(out, src); err != nil {
+			out.Close()
+			return err
+		}
+
+		return out.Close()
+	})
+}
+
+func fileExists(fsys fs.FS, p string) (bool, error) {
+	_, err := fs.Stat(fsys, p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+var _ filesystem.Extractor = &Extractor{}
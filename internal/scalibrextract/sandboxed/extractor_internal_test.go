@@ -0,0 +1,86 @@
+package sandboxed
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractor_containerArgs(t *testing.T) {
+	t.Parallel()
+
+	if os.Getuid() == -1 {
+		t.Skip("the current user is only passed to the container runtime on unix")
+	}
+
+	user := "--user=" + strconv.Itoa(os.Getuid()) + ":" + strconv.Itoa(os.Getgid())
+
+	tests := []struct {
+		name   string
+		tool   Tool
+		config Config
+		env    []string
+		want   []string
+	}{
+		{
+			name:   "no network",
+			tool:   ToolMaven,
+			config: Config{Runtime: []string{"docker"}, Network: NetworkNone},
+			want: []string{
+				"run", "--rm", "--cap-drop=ALL", "--security-opt=no-new-privileges", "--tmpfs=/tmp", "--env=HOME=/tmp",
+				"--network=none",
+				user,
+				"--volume=/tmp/w:/workspace", "--volume=/tmp/o:/osv-scanner", "--workdir=/workspace",
+				"maven:3-eclipse-temurin-21",
+				"mvn", "dependency:list",
+			},
+		},
+		{
+			name: "registry network with an image and environment",
+			tool: ToolBundler,
+			config: Config{
+				Runtime: []string{"docker", "--context=remote"},
+				Network: NetworkRegistry,
+				Images:  map[Tool]string{ToolBundler: "ruby:3.1"},
+			},
+			env: []string{"BUNDLE_MIRROR__ALL=https://gems.example.com"},
+			want: []string{
+				"--context=remote",
+				"run", "--rm", "--cap-drop=ALL", "--security-opt=no-new-privileges", "--tmpfs=/tmp", "--env=HOME=/tmp",
+				user,
+				"--env=BUNDLE_MIRROR__ALL=https://gems.example.com",
+				"--volume=/tmp/w:/workspace", "--volume=/tmp/o:/osv-scanner", "--workdir=/workspace",
+				"ruby:3.1",
+				"mvn", "dependency:list",
+			},
+		},
+		{
+			name:   "podman",
+			tool:   ToolGradle,
+			config: Config{Runtime: []string{"/usr/bin/podman"}, Network: NetworkNone},
+			want: []string{
+				"run", "--rm", "--cap-drop=ALL", "--security-opt=no-new-privileges", "--tmpfs=/tmp", "--env=HOME=/tmp",
+				"--network=none",
+				"--userns=keep-id",
+				"--volume=/tmp/w:/workspace", "--volume=/tmp/o:/osv-scanner", "--workdir=/workspace",
+				"gradle:8-jdk21",
+				"mvn", "dependency:list",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := &Extractor{tool: tt.tool, config: tt.config}
+			got := e.containerArgs("/tmp/w", "/tmp/o", tt.env, []string{"mvn", "dependency:list"})
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("containerArgs() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package sandboxed_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/javalockfile"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sandboxed"
+)
+
+// helperRuntime returns a container runtime that re-runs the test binary,
+// behaving as described by TestHelperProcess
+func helperRuntime() []string {
+	return []string{os.Args[0], "-test.run=^TestHelperProcess$", "--"}
+}
+
+// TestHelperProcess is not a real test, but is run by the extractor as the container
+// runtime, writing the lockfile that the tool being run would have generated
+func TestHelperProcess(t *testing.T) {
+	t.Parallel()
+
+	i := slices.Index(os.Args, "--")
+	if i == -1 {
+		return
+	}
+
+	args := os.Args[i+1:]
+	mounts := make(map[string]string)
+	image := ""
+	for j, arg := range args {
+		if volume, ok := strings.CutPrefix(arg, "--volume="); ok {
+			host, container, _ := strings.Cut(volume, ":")
+			mounts[container] = host
+		}
+
+		if j > 0 && strings.HasPrefix(args[j-1], "--workdir=") {
+			image = arg
+			args = args[j+1:]
+
+			break
+		}
+	}
+
+	if image == "fail" {
+		fmt.Fprintln(os.Stderr, "Could not find gem 'does-not-exist' in locally installed gems.")
+		os.Exit(7)
+	}
+
+	copyFile := func(name string, dst string) {
+		b, err := os.ReadFile(filepath.Join("testdata", "generated", name))
+		if err == nil {
+			err = os.WriteFile(dst, b, 0o600)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	switch args[0] {
+	case "bundle":
+		copyFile("Gemfile.lock", filepath.Join(mounts["/osv-scanner"], "Gemfile.lock"))
+	case "mvn":
+		copyFile("dependencies.txt", filepath.Join(mounts["/osv-scanner"], "dependencies.txt"))
+	case "gradle":
+		copyFile("gradle.lockfile", filepath.Join(mounts["/workspace"], "gradle.lockfile"))
+		copyFile("gradle.lockfile", filepath.Join(mounts["/workspace"], "app", "gradle.lockfile"))
+	}
+
+	os.Exit(0)
+}
+
+func TestParseToolValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		definitions []string
+		want        map[sandboxed.Tool]string
+		wantErr     bool
+	}{
+		{
+			definitions: []string{"maven=https://repo.example.com/maven2/", "bundler=https://gems.example.com"},
+			want: map[sandboxed.Tool]string{
+				sandboxed.ToolMaven:   "https://repo.example.com/maven2/",
+				sandboxed.ToolBundler: "https://gems.example.com",
+			},
+		},
+		{definitions: []string{"gradle=gradle:8.5-jdk17"}, want: map[sandboxed.Tool]string{sandboxed.ToolGradle: "gradle:8.5-jdk17"}},
+		{definitions: []string{}, want: map[sandboxed.Tool]string{}},
+		{definitions: []string{"maven"}, wantErr: true},
+		{definitions: []string{"maven="}, wantErr: true},
+		{definitions: []string{"npm=https://registry.npmjs.org"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.definitions, ","), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := sandboxed.ParseToolValues(tt.definitions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseToolValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseToolValues() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tool sandboxed.Tool
+		path string
+		want bool
+	}{
+		{tool: sandboxed.ToolBundler, path: "Gemfile", want: true},
+		{tool: sandboxed.ToolBundler, path: "path/to/gems.rb", want: true},
+		{tool: sandboxed.ToolBundler, path: "Gemfile.lock", want: false},
+		{tool: sandboxed.ToolGradle, path: "settings.gradle", want: true},
+		{tool: sandboxed.ToolGradle, path: "path/to/settings.gradle.kts", want: true},
+		{tool: sandboxed.ToolGradle, path: "build.gradle", want: false},
+		{tool: sandboxed.ToolMaven, path: "pom.xml", want: true},
+		{tool: sandboxed.ToolMaven, path: "path/to/pom.xml", want: true},
+		{tool: sandboxed.ToolMaven, path: "Gemfile", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.tool)+"/"+tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e, err := sandboxed.New(tt.tool, sandboxed.Config{Runtime: []string{"docker"}, Network: sandboxed.NetworkNone})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func gemPackage(name, version, location string) *extractor.Package {
+	return &extractor.Package{
+		Name:      name,
+		Version:   version,
+		PURLType:  purl.TypeGem,
+		Locations: []string{location},
+	}
+}
+
+func mavenPackage(group, artifact, version, location string, metadata javalockfile.Metadata) *extractor.Package {
+	metadata.GroupID = group
+	metadata.ArtifactID = artifact
+
+	return &extractor.Package{
+		Name:      group + ":" + artifact,
+		Version:   version,
+		PURLType:  purl.TypeMaven,
+		Locations: []string{location},
+		Metadata:  &metadata,
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		extracttest.TestTableEntry
+
+		tool  sandboxed.Tool
+		image string
+	}{
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name:        "bundler",
+				InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/bundler/Gemfile"},
+				WantPackages: []*extractor.Package{
+					gemPackage("rack", "3.0.8", "testdata/bundler/Gemfile"),
+					gemPackage("rack-session", "2.0.0", "testdata/bundler/Gemfile"),
+				},
+			},
+			tool: sandboxed.ToolBundler,
+		},
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name:        "manifests with a lockfile are not resolved",
+				InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/bundler-locked/Gemfile"},
+			},
+			tool: sandboxed.ToolBundler,
+		},
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name:        "tool fails",
+				InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/failing/Gemfile"},
+				WantErr:     extracttest.ContainsErrStr{Str: "Could not find gem 'does-not-exist'"},
+			},
+			tool:  sandboxed.ToolBundler,
+			image: "fail",
+		},
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name:        "gradle",
+				InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/gradle/settings.gradle"},
+				WantPackages: []*extractor.Package{
+					mavenPackage("com.google.guava", "failureaccess", "1.0.1", "testdata/gradle/settings.gradle", javalockfile.Metadata{}),
+					mavenPackage("com.google.guava", "guava", "32.1.3-jre", "testdata/gradle/settings.gradle", javalockfile.Metadata{}),
+				},
+			},
+			tool: sandboxed.ToolGradle,
+		},
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name:        "maven",
+				InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/maven/pom.xml"},
+				WantPackages: []*extractor.Package{
+					mavenPackage("org.apache.logging.log4j", "log4j-core", "2.14.1", "testdata/maven/pom.xml", javalockfile.Metadata{Type: "jar", DepGroupVals: []string{}}),
+					mavenPackage("org.apache.logging.log4j", "log4j-api", "2.14.1", "testdata/maven/pom.xml", javalockfile.Metadata{Type: "jar", DepGroupVals: []string{}}),
+					mavenPackage("junit", "junit", "4.13.2", "testdata/maven/pom.xml", javalockfile.Metadata{Type: "jar", DepGroupVals: []string{"test"}}),
+					mavenPackage("io.netty", "netty-transport-native-epoll", "4.1.100.Final", "testdata/maven/pom.xml", javalockfile.Metadata{Type: "jar", Classifier: "linux-x86_64", DepGroupVals: []string{"runtime"}}),
+				},
+			},
+			tool: sandboxed.ToolMaven,
+		},
+		{
+			TestTableEntry: extracttest.TestTableEntry{
+				Name:        "maven modules are resolved with their parent",
+				InputConfig: extracttest.ScanInputMockConfig{Path: "testdata/maven/module/pom.xml"},
+			},
+			tool: sandboxed.ToolMaven,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			config := sandboxed.Config{Runtime: helperRuntime(), Network: sandboxed.NetworkNone}
+			if tt.image != "" {
+				config.Images = map[sandboxed.Tool]string{tt.tool: tt.image}
+			}
+
+			extr, err := sandboxed.New(tt.tool, config)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package sandboxed
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/javalockfile"
+	"github.com/google/osv-scalibr/purl"
+)
+
+// parseMavenDependencyList parses the file written by the list goal of the
+// maven-dependency-plugin, which has a line for each resolved dependency of
+// each module in the form of "<group>:<artifact>:<type>[:<classifier>]:<version>:<scope>",
+// optionally followed by details of the Java module of the dependency
+func parseMavenDependencyList(r io.Reader, location string) ([]*extractor.Package, error) {
+	var packages []*extractor.Package
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " -- ")
+
+		parts := strings.Split(line, ":")
+		if len(parts) < 5 || len(parts) > 6 {
+			continue
+		}
+
+		group, artifact, typ := parts[0], parts[1], parts[2]
+		classifier := ""
+		if len(parts) == 6 {
+			classifier = parts[3]
+		}
+		version, scope := parts[len(parts)-2], parts[len(parts)-1]
+
+		// modules can have the same dependencies
+		key := strings.Join([]string{group, artifact, typ, classifier, version, scope}, ":")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		packages = append(packages, &extractor.Package{
+			Name:      group + ":" + artifact,
+			Version:   version,
+			PURLType:  purl.TypeMaven,
+			Locations: []string{location},
+			Metadata: &javalockfile.Metadata{
+				ArtifactID:   artifact,
+				GroupID:      group,
+				Type:         typ,
+				Classifier:   classifier,
+				DepGroupVals: mavenDepGroups(scope),
+			},
+		})
+	}
+
+	return packages, scanner.Err()
+}
+
+// mavenDepGroups returns the dependency groups of a dependency with the scope,
+// which are the same as those of dependencies in a pom.xml
+func mavenDepGroups(scope string) []string {
+	if scope == "" || scope == "compile" {
+		return []string{}
+	}
+
+	return []string{scope}
+}
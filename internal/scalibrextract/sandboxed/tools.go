@@ -0,0 +1,231 @@
+package sandboxed
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/java/gradlelockfile"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/java/pomxmlenhanceable"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/ruby/gemfilelock"
+)
+
+// The directories that the project and the files of osv-scanner are mounted to in the container
+const (
+	workspaceDir = "/workspace"
+	osvDir       = "/osv-scanner"
+)
+
+// tool describes how to resolve the manifests of an ecosystem with its native tool
+type tool struct {
+	// manifests are the names of the files that the tool is run for
+	manifests []string
+	// lockfiles are the names of lockfiles that, if they are next to a manifest, mean
+	// the manifest does not need to be resolved as the lockfile is already scanned
+	lockfiles []string
+	// supersedes are the extractors that extract the same manifests less accurately
+	supersedes []string
+	// modules is whether the manifests in the subdirectories of a manifest are
+	// resolved along with it, so they do not need to be resolved separately
+	modules bool
+
+	image    string
+	registry string
+
+	// files returns the config files to write to the osv-scanner directory
+	files func(network NetworkMode, registry string) map[string]string
+	// env returns the environment variables to run the tool with for the manifest
+	env func(network NetworkMode, registry string, manifest string) []string
+	// command returns the command that generates the lockfile of the manifest
+	command func(network NetworkMode) []string
+	// extract extracts the packages from the lockfile that the command generated,
+	// which is in either the osv-scanner directory or the workspace
+	extract func(ctx context.Context, osv string, workspace string, location string) ([]*extractor.Package, error)
+}
+
+var tools = map[string]tool{
+	string(ToolBundler): {
+		manifests: []string{"Gemfile", "gems.rb"},
+		lockfiles: []string{"Gemfile.lock", "gems.locked"},
+		image:     "ruby:3.3",
+		registry:  "https://rubygems.org",
+		env: func(network NetworkMode, registry string, manifest string) []string {
+			env := []string{"BUNDLE_GEMFILE=" + workspaceDir + "/" + manifest}
+			if network == NetworkRegistry {
+				env = append(env, "BUNDLE_MIRROR__ALL="+registry)
+			}
+
+			return env
+		},
+		command: func(network NetworkMode) []string {
+			command := []string{"bundle", "lock", "--lockfile=" + osvDir + "/Gemfile.lock"}
+			if network == NetworkNone {
+				command = append(command, "--local")
+			}
+
+			return command
+		},
+		extract: func(ctx context.Context, osv string, _ string, location string) ([]*extractor.Package, error) {
+			return extractLockfile(ctx, gemfilelock.New(), filepath.Join(osv, "Gemfile.lock"), location)
+		},
+	},
+	string(ToolGradle): {
+		manifests: []string{"settings.gradle", "settings.gradle.kts"},
+		lockfiles: []string{"gradle.lockfile"},
+		image:     "gradle:8-jdk21",
+		registry:  "https://repo.maven.apache.org/maven2/",
+		files: func(network NetworkMode, registry string) map[string]string {
+			script := gradleInitScript
+			if network == NetworkRegistry {
+				script += fmt.Sprintf(gradleRegistryScript, registry)
+			}
+
+			return map[string]string{"init.gradle": script}
+		},
+		command: func(network NetworkMode) []string {
+			command := []string{"gradle", "--no-daemon", "--quiet", "--init-script=" + osvDir + "/init.gradle", "--write-locks", "osvResolveAndLockAll"}
+			if network == NetworkNone {
+				command = append(command, "--offline")
+			}
+
+			return command
+		},
+		extract: func(ctx context.Context, _ string, workspace string, location string) ([]*extractor.Package, error) {
+			// every project in the build writes its own lockfile, which can have the same dependencies
+			var packages []*extractor.Package
+			seen := make(map[string]bool)
+			err := filepath.WalkDir(workspace, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() || !gradlelockfile.New().FileRequired(simplefileapi.New(path, nil)) {
+					return err
+				}
+
+				pkgs, err := extractLockfile(ctx, gradlelockfile.New(), path, location)
+				for _, pkg := range pkgs {
+					if !seen[pkg.Name+"@"+pkg.Version] {
+						seen[pkg.Name+"@"+pkg.Version] = true
+						packages = append(packages, pkg)
+					}
+				}
+
+				return err
+			})
+
+			return packages, err
+		},
+	},
+	string(ToolMaven): {
+		manifests:  []string{"pom.xml"},
+		supersedes: []string{pomxmlenhanceable.Name},
+		modules:    true,
+		image:      "maven:3-eclipse-temurin-21",
+		registry:   "https://repo.maven.apache.org/maven2/",
+		files: func(network NetworkMode, registry string) map[string]string {
+			mirror := ""
+			if network == NetworkRegistry {
+				mirror = fmt.Sprintf(mavenMirror, registry)
+			}
+
+			return map[string]string{"settings.xml": fmt.Sprintf(mavenSettings, mirror)}
+		},
+		command: func(network NetworkMode) []string {
+			command := []string{
+				"mvn", "--batch-mode", "--quiet",
+				"--settings=" + osvDir + "/settings.xml",
+				"-Dmaven.repo.local=/tmp/.m2/repository",
+				"dependency:list",
+				"-DoutputFile=" + osvDir + "/dependencies.txt",
+				"-DappendOutput=true",
+			}
+			if network == NetworkNone {
+				command = append(command, "--offline")
+			}
+
+			return command
+		},
+		extract: func(_ context.Context, osv string, _ string, location string) ([]*extractor.Package, error) {
+			f, err := os.Open(filepath.Join(osv, "dependencies.txt"))
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+
+			return parseMavenDependencyList(f, location)
+		},
+	},
+}
+
+// gradleInitScript enables dependency locking for every configuration of every
+// project, and adds a task to resolve them so that their lockfiles are written
+const gradleInitScript = `allprojects {
+    dependencyLocking {
+        lockAllConfigurations()
+    }
+
+    tasks.register('osvResolveAndLockAll') {
+        notCompatibleWithConfigurationCache('resolves configurations at execution time')
+
+        doLast {
+            configurations.findAll { it.canBeResolved }.each { it.resolve() }
+        }
+    }
+}
+`
+
+// gradleRegistryScript replaces every repository that dependencies and plugins
+// are resolved from with the registry
+const gradleRegistryScript = `
+def osvRegistry = '%s'
+def osvRestrict = { RepositoryHandler repositories ->
+    repositories.all { ArtifactRepository repo ->
+        if (!(repo instanceof MavenArtifactRepository) || repo.url.toString() != osvRegistry) {
+            repositories.remove(repo)
+        }
+    }
+    repositories.maven { url = osvRegistry }
+}
+
+settingsEvaluated { settings ->
+    osvRestrict(settings.pluginManagement.repositories)
+    osvRestrict(settings.dependencyResolutionManagement.repositories)
+}
+
+allprojects {
+    osvRestrict(buildscript.repositories)
+    osvRestrict(repositories)
+}
+`
+
+const mavenSettings = `<settings>
+  <mirrors>%s</mirrors>
+</settings>
+`
+
+const mavenMirror = `
+    <mirror>
+      <id>osv-scanner-registry</id>
+      <mirrorOf>*</mirrorOf>
+      <url>%s</url>
+    </mirror>
+  `
+
+// extractLockfile extracts the packages from the lockfile at path with the
+// extractor, as if they were from the file at location
+func extractLockfile(ctx context.Context, extr filesystem.Extractor, path string, location string) ([]*extractor.Package, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	inv, err := extr.Extract(ctx, &filesystem.ScanInput{Path: location, Reader: f})
+	if err != nil {
+		return nil, err
+	}
+
+	return inv.Packages, nil
+}
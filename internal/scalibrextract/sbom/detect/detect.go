@@ -0,0 +1,64 @@
+package detect
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Serialization is a way that an SBOM can be written, identified by the file
+// extension that the specification of its format recommends for it
+type Serialization struct {
+	// Format is the format of the SBOM, either "SPDX" or "CycloneDX"
+	Format string
+	// Extension is the recommended file extension of the serialization, e.g. ".spdx.json"
+	Extension string
+}
+
+var (
+	spdxJSON     = Serialization{Format: "SPDX", Extension: ".spdx.json"}
+	spdxTagValue = Serialization{Format: "SPDX", Extension: ".spdx"}
+	spdxYAML     = Serialization{Format: "SPDX", Extension: ".spdx.yml"}
+	spdxRDF      = Serialization{Format: "SPDX", Extension: ".spdx.rdf"}
+	cdxJSON      = Serialization{Format: "CycloneDX", Extension: ".cdx.json"}
+	cdxXML       = Serialization{Format: "CycloneDX", Extension: ".cdx.xml"}
+)
+
+// Detect determines the format and serialization of an SBOM from its content,
+// returning false if the content is not an SBOM that can be parsed
+func Detect(content []byte) (Serialization, bool) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	trimmed := bytes.TrimSpace(content)
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		switch {
+		case bytes.Contains(trimmed, []byte(`"bomFormat"`)):
+			return cdxJSON, true
+		case bytes.Contains(trimmed, []byte(`"spdxVersion"`)):
+			return spdxJSON, true
+		}
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		switch {
+		case bytes.Contains(trimmed, []byte("cyclonedx.org/schema/bom")):
+			return cdxXML, true
+		case bytes.Contains(trimmed, []byte("spdx.org/rdf/terms")):
+			return spdxRDF, true
+		}
+	default:
+		for line := range strings.Lines(string(trimmed)) {
+			key, _, ok := strings.Cut(strings.TrimSpace(line), ":")
+			if !ok {
+				continue
+			}
+
+			switch strings.Trim(key, `"'`) {
+			case "SPDXVersion":
+				return spdxTagValue, true
+			case "spdxVersion":
+				return spdxYAML, true
+			}
+		}
+	}
+
+	return Serialization{}, false
+}
@@ -0,0 +1,91 @@
+// Package detect extracts packages from SBOMs that are not named as the specification
+// of their format recommends, by detecting their format from their content.
+package detect
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx"
+	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "sbom/detect"
+)
+
+// Extractor extracts packages from SPDX and CycloneDX SBOMs in any of their
+// serializations, regardless of what they are named.
+//
+// The SBOMs are parsed by the sbom/spdx and sbom/cdx extractors once their
+// serialization has been detected, so the packages have the same metadata.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired returns true for SPDX YAML documents with the ".spdx.yaml" extension,
+// as other SBOMs with standard names are extracted by the sbom/spdx and sbom/cdx extractors,
+// and files without standard names are too likely to be something other than an SBOM
+func (e Extractor) FileRequired(fapi filesystem.FileAPI) bool {
+	return strings.HasSuffix(strings.ToLower(filepath.ToSlash(fapi.Path())), ".spdx.yaml")
+}
+
+// Extract extracts packages from the SBOM passed through the scan input, detecting
+// its format and serialization from its content.
+func (e Extractor) Extract(ctx context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	content, err := io.ReadAll(input.Reader)
+	if err != nil {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: %w", input.Path, err)
+	}
+
+	serialization, ok := Detect(content)
+	if !ok {
+		return inventory.Inventory{}, fmt.Errorf("could not extract from %s: not a SPDX or CycloneDX SBOM", input.Path)
+	}
+
+	var ext filesystem.Extractor = spdx.Extractor{}
+	if serialization.Format == "CycloneDX" {
+		ext = cdx.Extractor{}
+	}
+
+	// the extractors determine the serialization from the extension of the path,
+	// so the SBOM is given the extension that it would have if it was named as recommended
+	inv, err := ext.Extract(ctx, &filesystem.ScanInput{
+		FS:     input.FS,
+		Path:   input.Path + serialization.Extension,
+		Root:   input.Root,
+		Info:   input.Info,
+		Reader: bytes.NewReader(content),
+	})
+	if err != nil {
+		return inventory.Inventory{}, err
+	}
+
+	// the extractors locate packages by the path of the SBOM first, followed by any locations within it
+	for _, pkg := range inv.Packages {
+		if len(pkg.Locations) > 0 {
+			pkg.Locations[0] = input.Path
+		}
+	}
+
+	return inv, nil
+}
+
+var _ filesystem.Extractor = Extractor{}
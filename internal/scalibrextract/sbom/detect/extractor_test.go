@@ -0,0 +1,241 @@
+package detect_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	cdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx/metadata"
+	spdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx/metadata"
+	"github.com/google/osv-scalibr/extractor/filesystem/simplefileapi"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/detect"
+)
+
+func mustParsePURL(t *testing.T, s string) *purl.PackageURL {
+	t.Helper()
+
+	p, err := purl.FromString(s)
+	if err != nil {
+		t.Fatalf("purl.FromString(%q) error = %v", s, err)
+	}
+
+	return &p
+}
+
+func TestExtractor_FileRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "sbom.spdx.yaml", want: true},
+		{path: "path/to/project/SBOM.SPDX.YAML", want: true},
+		{path: "sbom.spdx.yml", want: false},
+		{path: "sbom.spdx.json", want: false},
+		{path: "bom.xml", want: false},
+		{path: "sbom.yaml", want: false},
+		{path: "sbom.json", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			e := detect.Extractor{}
+			got := e.FileRequired(simplefileapi.New(tt.path, nil))
+			if got != tt.want {
+				t.Errorf("FileRequired(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "spdx yaml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/sbom.spdx.yaml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "lodash",
+					PURLType:  "npm",
+					Locations: []string{"testdata/sbom.spdx.yaml"},
+					Metadata: &spdxmeta.Metadata{
+						PURL: mustParsePURL(t, "pkg:npm/lodash@4.17.20"),
+					},
+				},
+				{
+					Name:      "cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*",
+					Locations: []string{"testdata/sbom.spdx.yaml"},
+					Metadata: &spdxmeta.Metadata{
+						CPEs: []string{"cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*"},
+					},
+				},
+			},
+		},
+		{
+			Name: "spdx tag-value",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/legacy-sbom.txt",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "requests",
+					PURLType:  "pypi",
+					Locations: []string{"testdata/legacy-sbom.txt"},
+					Metadata: &spdxmeta.Metadata{
+						PURL: mustParsePURL(t, "pkg:pypi/requests@2.25.1"),
+					},
+				},
+			},
+		},
+		{
+			Name: "cyclonedx xml",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/sbom.xml",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "log4j-core",
+					Version:   "2.14.1",
+					PURLType:  "maven",
+					Locations: []string{"testdata/sbom.xml"},
+					Metadata: &cdxmeta.Metadata{
+						PURL: mustParsePURL(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"),
+					},
+				},
+			},
+		},
+		{
+			Name: "cyclonedx json",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/inventory.json",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "github.com/gin-gonic/gin",
+					Version:   "v1.6.0",
+					PURLType:  "golang",
+					Locations: []string{"testdata/inventory.json"},
+					Metadata: &cdxmeta.Metadata{
+						PURL: mustParsePURL(t, "pkg:golang/github.com/gin-gonic/gin@v1.6.0"),
+					},
+				},
+			},
+		},
+		{
+			Name: "not an sbom",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/not-an-sbom.json",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "not a SPDX or CycloneDX SBOM"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := detect.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    detect.Serialization
+		wantOk  bool
+	}{
+		{
+			name:    "spdx json",
+			content: `{"spdxVersion": "SPDX-2.3", "SPDXID": "SPDXRef-DOCUMENT"}`,
+			want:    detect.Serialization{Format: "SPDX", Extension: ".spdx.json"},
+			wantOk:  true,
+		},
+		{
+			name:    "spdx tag-value",
+			content: "# generated by a legacy tool\nSPDXVersion: SPDX-2.2\nDataLicense: CC0-1.0\n",
+			want:    detect.Serialization{Format: "SPDX", Extension: ".spdx"},
+			wantOk:  true,
+		},
+		{
+			name:    "spdx yaml",
+			content: "---\nSPDXID: SPDXRef-DOCUMENT\n'spdxVersion': SPDX-2.3\n",
+			want:    detect.Serialization{Format: "SPDX", Extension: ".spdx.yml"},
+			wantOk:  true,
+		},
+		{
+			name:    "spdx rdf",
+			content: `<rdf:RDF xmlns:spdx="http://spdx.org/rdf/terms#"></rdf:RDF>`,
+			want:    detect.Serialization{Format: "SPDX", Extension: ".spdx.rdf"},
+			wantOk:  true,
+		},
+		{
+			name:    "cyclonedx json with byte order mark",
+			content: "\xef\xbb\xbf{\"bomFormat\": \"CycloneDX\"}",
+			want:    detect.Serialization{Format: "CycloneDX", Extension: ".cdx.json"},
+			wantOk:  true,
+		},
+		{
+			name:    "cyclonedx xml",
+			content: `<?xml version="1.0"?><bom xmlns="http://cyclonedx.org/schema/bom/1.6"></bom>`,
+			want:    detect.Serialization{Format: "CycloneDX", Extension: ".cdx.xml"},
+			wantOk:  true,
+		},
+		{
+			name:    "other json",
+			content: `{"name": "left-pad"}`,
+			wantOk:  false,
+		},
+		{
+			name:    "other xml",
+			content: `<project><modelVersion>4.0.0</modelVersion></project>`,
+			wantOk:  false,
+		},
+		{
+			name:    "empty",
+			content: "",
+			wantOk:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := detect.Detect([]byte(tt.content))
+			if ok != tt.wantOk {
+				t.Fatalf("Detect() ok = %v, want %v", ok, tt.wantOk)
+			}
+
+			if got != tt.want {
+				t.Errorf("Detect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Package purllist extracts packages from a newline-delimited list of package urls.
+package purllist
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/plugin"
+	"github.com/google/osv-scalibr/purl"
+	purlutil "github.com/google/osv-scanner/v2/internal/utility/purl"
+	"github.com/package-url/packageurl-go"
+)
+
+const (
+	// Name is the unique name of this extractor.
+	Name = "sbom/purllist"
+)
+
+// Extractor extracts packages from a list of package urls, with one package url per line.
+type Extractor struct{}
+
+// Name of the extractor.
+func (e Extractor) Name() string { return Name }
+
+// Version of the extractor.
+func (e Extractor) Version() int { return 0 }
+
+// Requirements of the extractor.
+func (e Extractor) Requirements() *plugin.Capabilities {
+	return &plugin.Capabilities{}
+}
+
+// FileRequired never returns true, as purl lists do not have a standard file name.
+func (e Extractor) FileRequired(_ filesystem.FileAPI) bool {
+	return false
+}
+
+// Extract extracts packages from purl lists passed through the scan input.
+//
+// Blank lines and lines starting with "#" are ignored, allowing lists to be commented.
+func (e Extractor) Extract(_ context.Context, input *filesystem.ScanInput) (inventory.Inventory, error) {
+	packages := []*extractor.Package{}
+
+	scanner := bufio.NewScanner(input.Reader)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parsed, err := packageurl.FromString(line)
+		if err != nil {
+			return inventory.Inventory{}, fmt.Errorf("invalid package url on line %d of %s: %w", lineNum, input.Path, err)
+		}
+
+		if parsed.Version == "" {
+			return inventory.Inventory{}, fmt.Errorf("package url on line %d of %s does not have a version: %s", lineNum, input.Path, line)
+		}
+
+		pkg, err := purlutil.ToPackage(line)
+		if err != nil {
+			return inventory.Inventory{}, fmt.Errorf("invalid package url on line %d of %s: %w", lineNum, input.Path, err)
+		}
+
+		packages = append(packages, &extractor.Package{
+			Name:     pkg.Name,
+			Version:  pkg.Version,
+			PURLType: parsed.Type,
+			Metadata: &Metadata{
+				PURL:      line,
+				Ecosystem: pkg.Ecosystem,
+			},
+			Locations: []string{input.Path},
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return inventory.Inventory{}, fmt.Errorf("error while scanning %s: %w", input.Path, err)
+	}
+
+	return inventory.Inventory{Packages: packages}, nil
+}
+
+// ToPURL converts a package created by this extractor into a PURL.
+func (e Extractor) ToPURL(p *extractor.Package) *purl.PackageURL {
+	parsed, err := purl.FromString(p.Metadata.(*Metadata).PURL)
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
+
+// ToCPEs is not applicable as this extractor does not infer CPEs from the Package.
+func (e Extractor) ToCPEs(_ *extractor.Package) []string { return []string{} }
+
+// Ecosystem returns the OSV ecosystem of the package, based on its package url.
+func (e Extractor) Ecosystem(p *extractor.Package) string {
+	return p.Metadata.(*Metadata).Ecosystem
+}
+
+var _ filesystem.Extractor = Extractor{}
@@ -0,0 +1,174 @@
+package purllist_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/testing/extracttest"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/purllist"
+)
+
+func TestExtractor_Extract(t *testing.T) {
+	t.Parallel()
+
+	tests := []extracttest.TestTableEntry{
+		{
+			Name: "empty",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/empty.txt",
+			},
+			WantPackages: []*extractor.Package{},
+		},
+		{
+			Name: "invalid purl",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/invalid.txt",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "invalid package url on line 2"},
+		},
+		{
+			Name: "purl without version",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/no-version.txt",
+			},
+			WantErr: extracttest.ContainsErrStr{Str: "does not have a version"},
+		},
+		{
+			Name: "basic",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/basic.txt",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "lodash",
+					Version:   "4.17.20",
+					PURLType:  "npm",
+					Locations: []string{"testdata/basic.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:npm/lodash@4.17.20",
+						Ecosystem: "npm",
+					},
+				},
+				{
+					Name:      "@babel/core",
+					Version:   "7.12.0",
+					PURLType:  "npm",
+					Locations: []string{"testdata/basic.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:npm/%40babel/core@7.12.0",
+						Ecosystem: "npm",
+					},
+				},
+				{
+					Name:      "org.apache.logging.log4j:log4j-core",
+					Version:   "2.14.1",
+					PURLType:  "maven",
+					Locations: []string{"testdata/basic.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+						Ecosystem: "Maven",
+					},
+				},
+				{
+					Name:      "github.com/gin-gonic/gin",
+					Version:   "v1.6.0",
+					PURLType:  "golang",
+					Locations: []string{"testdata/basic.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:golang/github.com/gin-gonic/gin@v1.6.0",
+						Ecosystem: "Go",
+					},
+				},
+				{
+					Name:      "django",
+					Version:   "3.0.0",
+					PURLType:  "pypi",
+					Locations: []string{"testdata/basic.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:pypi/django@3.0.0",
+						Ecosystem: "PyPI",
+					},
+				},
+				{
+					Name:      "openssl",
+					Version:   "1.1.1n-0+deb11u3",
+					PURLType:  "deb",
+					Locations: []string{"testdata/basic.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:deb/debian/openssl@1.1.1n-0+deb11u3?arch=amd64&distro=bullseye",
+						Ecosystem: "Debian",
+					},
+				},
+			},
+		},
+		{
+			Name: "comments and blank lines",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/comments.txt",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "regex",
+					Version:   "1.5.1",
+					PURLType:  "cargo",
+					Locations: []string{"testdata/comments.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:cargo/regex@1.5.1",
+						Ecosystem: "crates.io",
+					},
+				},
+				{
+					Name:      "rails",
+					Version:   "6.0.0",
+					PURLType:  "gem",
+					Locations: []string{"testdata/comments.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:gem/rails@6.0.0",
+						Ecosystem: "RubyGems",
+					},
+				},
+			},
+		},
+		{
+			Name: "unsupported purl type",
+			InputConfig: extracttest.ScanInputMockConfig{
+				Path: "testdata/unsupported.txt",
+			},
+			WantPackages: []*extractor.Package{
+				{
+					Name:      "actions/checkout",
+					Version:   "v4",
+					PURLType:  "github",
+					Locations: []string{"testdata/unsupported.txt"},
+					Metadata: &purllist.Metadata{
+						PURL:      "pkg:github/actions/checkout@v4",
+						Ecosystem: "",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			extr := purllist.Extractor{}
+
+			scanInput := extracttest.GenerateScanInputMock(t, tt.InputConfig)
+			defer extracttest.CloseTestScanInput(t, scanInput)
+
+			got, err := extr.Extract(t.Context(), &scanInput)
+
+			if diff := cmp.Diff(tt.WantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s.Extract(%q) error diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+				return
+			}
+
+			if diff := cmp.Diff(tt.WantPackages, got.Packages, cmpopts.SortSlices(extracttest.PackageCmpLess)); diff != "" {
+				t.Errorf("%s.Extract(%q) diff (-want +got):\n%s", extr.Name(), tt.InputConfig.Path, diff)
+			}
+		})
+	}
+}
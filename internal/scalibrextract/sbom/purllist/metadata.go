@@ -0,0 +1,10 @@
+package purllist
+
+// Metadata holds the metadata for packages listed in a purl list
+type Metadata struct {
+	// PURL is the package url as it was listed
+	PURL string
+	// Ecosystem is the OSV ecosystem of the package, which is empty if the
+	// type of the package url is not supported
+	Ecosystem string
+}
@@ -0,0 +1,167 @@
+// Package selfsbom describes the Go modules that the running osv-scanner binary was built from,
+// so that the scanner itself can be reviewed and checked for vulnerabilities like any other software.
+package selfsbom
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/clients/clientinterfaces"
+	"github.com/google/osv-scanner/v2/internal/version"
+)
+
+// ErrNoBuildInfo is returned when the binary was built without module support,
+// meaning that the modules that it was built from cannot be known
+var ErrNoBuildInfo = errors.New("binary has no embedded module information")
+
+// stdlib is the name of the Go standard library in the Go ecosystem of OSV
+const stdlib = "stdlib"
+
+// Module is a Go module that the binary was built from
+type Module struct {
+	Path    string
+	Version string
+}
+
+// PURL returns the package url of the module
+func (m Module) PURL() string {
+	namespace, name := "", m.Path
+	if i := strings.LastIndex(m.Path, "/"); i >= 0 {
+		namespace, name = m.Path[:i], m.Path[i+1:]
+	}
+
+	return (&purl.PackageURL{Type: purl.TypeGolang, Namespace: namespace, Name: name, Version: m.Version}).String()
+}
+
+// Read returns the build information embedded in the running binary
+func Read() (*debug.BuildInfo, error) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil, ErrNoBuildInfo
+	}
+
+	return info, nil
+}
+
+// Modules returns the modules that the binary was built from, using the module that
+// each module was replaced with if it was replaced, along with the standard library
+// of the version of Go that the binary was built with.
+//
+// Modules that were replaced with a local directory are skipped, as they have no
+// version and are not published anywhere that they could be checked.
+func Modules(info *debug.BuildInfo) []Module {
+	modules := make([]Module, 0, len(info.Deps)+1)
+
+	if v, ok := strings.CutPrefix(info.GoVersion, "go"); ok {
+		// toolchains built from source have suffixes such as "1.24.3 X:nocoverageredesign"
+		v, _, _ = strings.Cut(v, " ")
+		modules = append(modules, Module{Path: stdlib, Version: v})
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+
+		if dep.Version == "" {
+			continue
+		}
+
+		modules = append(modules, Module{Path: dep.Path, Version: dep.Version})
+	}
+
+	slices.SortFunc(modules, func(a, b Module) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return modules
+}
+
+// BOM returns a CycloneDX SBOM of the binary, describing osv-scanner as
+// the application that is made up of the modules that it was built from
+func BOM(info *debug.BuildInfo) *cyclonedx.BOM {
+	modules := Modules(info)
+
+	main := Module{Path: info.Main.Path, Version: "v" + version.OSVVersion}
+
+	components := make([]cyclonedx.Component, 0, len(modules))
+	refs := make([]string, 0, len(modules))
+
+	for _, module := range modules {
+		ref := module.PURL()
+
+		components = append(components, cyclonedx.Component{
+			BOMRef:     ref,
+			Type:       cyclonedx.ComponentTypeLibrary,
+			Name:       module.Path,
+			Version:    module.Version,
+			PackageURL: ref,
+		})
+		refs = append(refs, ref)
+	}
+
+	bom := cyclonedx.NewBOM()
+	bom.Metadata = &cyclonedx.Metadata{
+		Component: &cyclonedx.Component{
+			BOMRef:     main.PURL(),
+			Type:       cyclonedx.ComponentTypeApplication,
+			Name:       "osv-scanner",
+			Version:    version.OSVVersion,
+			PackageURL: main.PURL(),
+		},
+	}
+	bom.Components = &components
+	bom.Dependencies = &[]cyclonedx.Dependency{{Ref: main.PURL(), Dependencies: &refs}}
+
+	return bom
+}
+
+// Vulnerable is a module that the binary was built from which has known vulnerabilities
+type Vulnerable struct {
+	Module Module
+	IDs    []string
+}
+
+// Check returns the modules that the binary was built from that are known to be vulnerable
+// according to the matcher
+func Check(ctx context.Context, matcher clientinterfaces.VulnerabilityMatcher, info *debug.BuildInfo) ([]Vulnerable, error) {
+	modules := Modules(info)
+
+	pkgs := make([]*extractor.Package, 0, len(modules))
+	for _, module := range modules {
+		pkgs = append(pkgs, &extractor.Package{
+			Name:     module.Path,
+			Version:  strings.TrimPrefix(module.Version, "v"),
+			PURLType: purl.TypeGolang,
+		})
+	}
+
+	results, err := matcher.MatchVulnerabilities(ctx, pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var vulnerable []Vulnerable
+
+	for i, vulns := range results {
+		if len(vulns) == 0 {
+			continue
+		}
+
+		ids := make([]string, 0, len(vulns))
+		for _, vuln := range vulns {
+			ids = append(ids, vuln.ID)
+		}
+		slices.Sort(ids)
+
+		vulnerable = append(vulnerable, Vulnerable{Module: modules[i], IDs: ids})
+	}
+
+	return vulnerable, nil
+}
@@ -0,0 +1,136 @@
+package selfsbom_test
+
+import (
+	"context"
+	"runtime/debug"
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/selfsbom"
+	"github.com/google/osv-scanner/v2/internal/version"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+var buildInfo = &debug.BuildInfo{
+	GoVersion: "go1.24.3",
+	Main:      debug.Module{Path: "github.com/google/osv-scanner/v2", Version: "(devel)"},
+	Deps: []*debug.Module{
+		{Path: "golang.org/x/net", Version: "v0.40.0"},
+		{Path: "github.com/BurntSushi/toml", Version: "v1.5.0"},
+		{
+			Path:    "github.com/example/forked",
+			Version: "v1.0.0",
+			Replace: &debug.Module{Path: "github.com/example/fork", Version: "v1.0.1"},
+		},
+		{
+			Path:    "github.com/example/local",
+			Version: "v1.0.0",
+			Replace: &debug.Module{Path: "../local"},
+		},
+	},
+}
+
+func TestModules(t *testing.T) {
+	t.Parallel()
+
+	want := []selfsbom.Module{
+		{Path: "github.com/BurntSushi/toml", Version: "v1.5.0"},
+		{Path: "github.com/example/fork", Version: "v1.0.1"},
+		{Path: "golang.org/x/net", Version: "v0.40.0"},
+		{Path: "stdlib", Version: "1.24.3"},
+	}
+
+	if diff := cmp.Diff(want, selfsbom.Modules(buildInfo)); diff != "" {
+		t.Errorf("Modules() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBOM(t *testing.T) {
+	t.Parallel()
+
+	bom := selfsbom.BOM(buildInfo)
+
+	mainRef := "pkg:golang/github.com/google/osv-scanner/v2@v" + version.OSVVersion
+
+	wantMain := &cyclonedx.Component{
+		BOMRef:     mainRef,
+		Type:       cyclonedx.ComponentTypeApplication,
+		Name:       "osv-scanner",
+		Version:    version.OSVVersion,
+		PackageURL: mainRef,
+	}
+
+	if diff := cmp.Diff(wantMain, bom.Metadata.Component); diff != "" {
+		t.Errorf("BOM() main component mismatch (-want +got):\n%s", diff)
+	}
+
+	refs := []string{
+		"pkg:golang/github.com/BurntSushi/toml@v1.5.0",
+		"pkg:golang/github.com/example/fork@v1.0.1",
+		"pkg:golang/golang.org/x/net@v0.40.0",
+		"pkg:golang/stdlib@1.24.3",
+	}
+
+	gotRefs := make([]string, 0, len(*bom.Components))
+	for _, component := range *bom.Components {
+		if component.Type != cyclonedx.ComponentTypeLibrary {
+			t.Errorf("BOM() component %s has type %q, want %q", component.Name, component.Type, cyclonedx.ComponentTypeLibrary)
+		}
+		gotRefs = append(gotRefs, component.PackageURL)
+	}
+
+	if diff := cmp.Diff(refs, gotRefs); diff != "" {
+		t.Errorf("BOM() components mismatch (-want +got):\n%s", diff)
+	}
+
+	wantDependencies := []cyclonedx.Dependency{{Ref: mainRef, Dependencies: &refs}}
+
+	if diff := cmp.Diff(wantDependencies, *bom.Dependencies); diff != "" {
+		t.Errorf("BOM() dependencies mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type fakeMatcher map[string][]string
+
+func (m fakeMatcher) MatchVulnerabilities(_ context.Context, pkgs []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	results := make([][]*osvschema.Vulnerability, len(pkgs))
+
+	for i, pkg := range pkgs {
+		for _, id := range m[pkg.Name+"@"+pkg.Version] {
+			results[i] = append(results[i], &osvschema.Vulnerability{ID: id})
+		}
+	}
+
+	return results, nil
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	matcher := fakeMatcher{
+		"golang.org/x/net@0.40.0": {"GO-2025-0002", "GO-2025-0001"},
+		"stdlib@1.24.3":           {"GO-2025-0003"},
+	}
+
+	got, err := selfsbom.Check(context.Background(), matcher, buildInfo)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	want := []selfsbom.Vulnerable{
+		{
+			Module: selfsbom.Module{Path: "golang.org/x/net", Version: "v0.40.0"},
+			IDs:    []string{"GO-2025-0001", "GO-2025-0002"},
+		},
+		{
+			Module: selfsbom.Module{Path: "stdlib", Version: "1.24.3"},
+			IDs:    []string{"GO-2025-0003"},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Check() mismatch (-want +got):\n%s", diff)
+	}
+}
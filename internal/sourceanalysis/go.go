@@ -9,6 +9,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 	"github.com/google/osv-scanner/v2/internal/sourceanalysis/govulncheck"
@@ -60,12 +62,18 @@ func goAnalysis(pkgs []models.PackageVulns, source models.SourceInfo) {
 
 func matchAnalysisWithPackageVulns(pkgs []models.PackageVulns, idToFindings map[string][]*govulncheck.Finding, vulnsByID map[string]osvschema.Vulnerability) {
 	idToModuleToCalled := map[string]map[string]bool{}
+	idToModuleToSymbols := map[string]map[string][]models.AffectedSymbol{}
 	for id, findings := range idToFindings {
 		idToModuleToCalled[id] = map[string]bool{}
+		idToModuleToSymbols[id] = map[string][]models.AffectedSymbol{}
 		for _, f := range findings {
 			modulePath := f.Trace[0].Module
 			called := f.Trace[0].Function != ""
 			idToModuleToCalled[f.OSV][modulePath] = called
+
+			if called {
+				idToModuleToSymbols[f.OSV][modulePath] = appendAffectedSymbol(idToModuleToSymbols[f.OSV][modulePath], f)
+			}
 		}
 	}
 
@@ -90,14 +98,66 @@ func matchAnalysisWithPackageVulns(pkgs []models.PackageVulns, idToFindings map[
 					continue
 				}
 
-				(*analysis)[vulnID] = models.AnalysisInfo{
+				info := models.AnalysisInfo{
 					Called: moduleToCalled[pv.Package.Name],
 				}
+				if info.Called {
+					info.AffectedSymbols = idToModuleToSymbols[vulnID][pv.Package.Name]
+				}
+				(*analysis)[vulnID] = info
 			}
 		}
 	}
 }
 
+// appendAffectedSymbol adds the vulnerable symbol called in the finding to symbols,
+// unless a trace to the same symbol has already been added
+func appendAffectedSymbol(symbols []models.AffectedSymbol, f *govulncheck.Finding) []models.AffectedSymbol {
+	symbol := frameSymbol(f.Trace[0])
+	for _, s := range symbols {
+		if s.Symbol == symbol {
+			return symbols
+		}
+	}
+
+	// govulncheck orders the trace from the vulnerable symbol to the entry point,
+	// whereas it is easier to follow from the entry point in the scanned code
+	trace := make([]models.CallFrame, 0, len(f.Trace))
+	for i := len(f.Trace) - 1; i >= 0; i-- {
+		frame := models.CallFrame{Symbol: frameSymbol(f.Trace[i])}
+		if pos := f.Trace[i].Position; pos != nil {
+			frame.Filename = pos.Filename
+			frame.Line = pos.Line
+			frame.Column = pos.Column
+		}
+		trace = append(trace, frame)
+	}
+
+	symbols = append(symbols, models.AffectedSymbol{Symbol: symbol, Trace: trace})
+	slices.SortFunc(symbols, func(a, b models.AffectedSymbol) int {
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+
+	return symbols
+}
+
+// frameSymbol returns the fully qualified name of the function called in the frame,
+// in the same form as govulncheck uses (e.g. "net/http.Client.Do")
+func frameSymbol(frame *govulncheck.Frame) string {
+	var sb strings.Builder
+	sb.WriteString(frame.Package)
+	sb.WriteString(".")
+	if frame.Receiver != "" {
+		sb.WriteString(strings.TrimPrefix(frame.Receiver, "*"))
+		sb.WriteString(".")
+	}
+	// closures are named after the function they are in, followed by a $ and their index
+	function, _, _ := strings.Cut(frame.Function, "$")
+	sb.WriteString(function)
+
+	return sb.String()
+}
+
 func vulnHasImportsField(vuln osvschema.Vulnerability, pkg *models.PackageInfo) bool {
 	for _, affected := range vuln.Affected {
 		if pkg != nil {
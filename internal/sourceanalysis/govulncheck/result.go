@@ -4,13 +4,49 @@
 
 package govulncheck
 
+import "github.com/ossf/osv-schema/bindings/go/osvschema"
+
+// ProtocolVersion is the version of the govulncheck JSON protocol.
+const ProtocolVersion = "v1.0.0"
+
 // Message is an entry in the output stream. It will always have exactly one
 // field filled in.
 type Message struct {
-	Finding *Finding `json:"finding,omitempty"`
+	Config  *Config                  `json:"config,omitempty"`
+	OSV     *osvschema.Vulnerability `json:"osv,omitempty"`
+	Finding *Finding                 `json:"finding,omitempty"`
+
+	// The Progress field from the JSON output is removed, since it is not used.
+}
+
+// Config must occur as the first message of a stream and informs the client
+// about the information used to generate the findings.
+type Config struct {
+	// ProtocolVersion specifies the version of the JSON protocol.
+	ProtocolVersion string `json:"protocol_version"`
+
+	// ScannerName is the name of the tool, for example, govulncheck.
+	ScannerName string `json:"scanner_name,omitempty"`
+
+	// ScannerVersion is the version of the tool.
+	ScannerVersion string `json:"scanner_version,omitempty"`
+
+	// DB is the database used by the tool, for example,
+	// vuln.go.dev.
+	DB string `json:"db,omitempty"`
+
+	// GoVersion is the version of Go used for analyzing standard library
+	// vulnerabilities.
+	GoVersion string `json:"go_version,omitempty"`
+
+	// ScanLevel instructs govulncheck to analyze at a specific level of detail.
+	// Valid values include module, package and symbol.
+	ScanLevel string `json:"scan_level,omitempty"`
 
-	// The Config, Progress, and OSV fields from the JSON output are removed, since they
-	// are not used.
+	// ScanMode instructs govulncheck how to interpret the input and
+	// what to do with it. Valid values are source, binary, query,
+	// and extract.
+	ScanMode string `json:"scan_mode,omitempty"`
 }
 
 // Finding represents a single finding.
@@ -0,0 +1,163 @@
+package spdx
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// simpleExpression is a single license from a license expression, such as
+// "GPL-2.0+" or "GPL-2.0-only WITH Classpath-exception-2.0"
+type simpleExpression struct {
+	// id is the lowercased license id, without any "+", "-only", or "-or-later" suffix
+	id string
+	// orLater is whether later versions of the license can also be used
+	orLater bool
+	// exception is the lowercased id of the exception given with WITH, if any
+	exception string
+}
+
+func parseSimpleExpression(license string) simpleExpression {
+	license = strings.ToLower(strings.TrimSpace(license))
+
+	var expr simpleExpression
+	if id, exception, ok := strings.Cut(license, " with "); ok {
+		license = strings.TrimSpace(id)
+		expr.exception = strings.TrimSpace(exception)
+	}
+
+	switch {
+	case strings.HasSuffix(license, "+"):
+		expr.orLater = true
+		license = strings.TrimSuffix(license, "+")
+	case strings.HasSuffix(license, "-or-later"):
+		expr.orLater = true
+		license = strings.TrimSuffix(license, "-or-later")
+	case strings.HasSuffix(license, "-only"):
+		license = strings.TrimSuffix(license, "-only")
+	}
+
+	expr.id = license
+
+	return expr
+}
+
+// family splits the license id into the name of the license and its version,
+// such as "gpl" and "2.0" for "gpl-2.0"
+func (e simpleExpression) family() (string, []int, bool) {
+	matches := cachedregexp.MustCompile(`^(.+)-(\d+(?:\.\d+)*)$`).FindStringSubmatch(e.id)
+	if matches == nil {
+		return "", nil, false
+	}
+
+	parts := strings.Split(matches[2], ".")
+	version := make([]int, len(parts))
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return "", nil, false
+		}
+		version[i] = v
+	}
+
+	return matches[1], version, true
+}
+
+// compareVersions compares two license versions, treating missing components as 0
+func compareVersions(a, b []int) int {
+	for i := range max(len(a), len(b)) {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+
+		if x != y {
+			if x < y {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// allows checks if the license is permitted by this allowed license.
+//
+// An exception only grants additional permissions, so a license used with an
+// exception is allowed if the license itself is allowed, but a license that is
+// only allowed with a particular exception does not allow the license on its own.
+//
+// A license that can be used at a later version (i.e. "+" or "-or-later") is
+// allowed if any of the versions it can be used at are allowed.
+func (e simpleExpression) allows(license simpleExpression) bool {
+	if e.exception != "" && e.exception != license.exception {
+		return false
+	}
+
+	if e.id == license.id {
+		return true
+	}
+
+	if !e.orLater && !license.orLater {
+		return false
+	}
+
+	allowedFamily, allowedVersion, ok := e.family()
+	if !ok {
+		return false
+	}
+
+	family, version, ok := license.family()
+	if !ok || family != allowedFamily {
+		return false
+	}
+
+	switch {
+	case e.orLater && license.orLater:
+		return true
+	case e.orLater:
+		return compareVersions(version, allowedVersion) >= 0
+	default:
+		return compareVersions(allowedVersion, version) >= 0
+	}
+}
+
+// SatisfiesExpression checks if the given license expression is satisfied by the
+// allowed licenses, evaluating "WITH" exceptions and "+" operators rather than
+// requiring them to be present in the allowlist exactly as they are in the expression.
+//
+// The allowlist can contain licenses with "+" and "WITH" too, such as
+// "GPL-2.0-only WITH Classpath-exception-2.0" to allow the GPL only when used
+// with the Classpath exception.
+func SatisfiesExpression(license models.License, allowlist []string) (bool, error) {
+	tokens := tokenise(license)
+	nod, err := parse(&tokens)
+
+	if err != nil {
+		return false, err
+	}
+
+	allowed := make([]simpleExpression, 0, len(allowlist))
+	for _, a := range allowlist {
+		allowed = append(allowed, parseSimpleExpression(a))
+	}
+
+	return nod.satisfiedBy(func(l string) bool {
+		expr := parseSimpleExpression(l)
+
+		for _, a := range allowed {
+			if a.allows(expr) {
+				return true
+			}
+		}
+
+		return false
+	}), nil
+}
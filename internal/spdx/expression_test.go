@@ -0,0 +1,132 @@
+package spdx_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/spdx"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func TestSatisfiesExpression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		license models.License
+		pass    [][]string
+		fail    [][]string
+	}{
+		// simple
+		{
+			license: "MIT",
+			pass:    [][]string{{"MIT"}, {"mit"}, {"MIT", "Apache-2.0"}},
+			fail:    [][]string{{"Apache-2.0"}, {"MIT WITH LLVM-exception"}},
+		},
+		// compound expressions
+		{
+			license: "MIT OR Apache-2.0",
+			pass:    [][]string{{"MIT"}, {"Apache-2.0"}},
+			fail:    [][]string{{"BSD-3-Clause"}},
+		},
+		{
+			license: "(MIT OR Apache-2.0) AND BSD-3-Clause",
+			pass:    [][]string{{"MIT", "BSD-3-Clause"}, {"Apache-2.0", "BSD-3-Clause"}},
+			fail:    [][]string{{"MIT"}, {"BSD-3-Clause"}},
+		},
+		// WITH expressions
+		{
+			license: "GPL-2.0-only WITH Classpath-exception-2.0",
+			pass: [][]string{
+				{"GPL-2.0-only WITH Classpath-exception-2.0"},
+				{"gpl-2.0-only with classpath-exception-2.0"},
+				{"GPL-2.0-only"},
+				{"GPL-2.0"},
+				{"GPL-2.0-or-later"},
+			},
+			fail: [][]string{
+				{"Classpath-exception-2.0"},
+				{"GPL-2.0-only WITH LLVM-exception"},
+				{"GPL-3.0-only"},
+				{"LGPL-2.0-only"},
+			},
+		},
+		{
+			license: "Apache-2.0 WITH LLVM-exception OR MIT",
+			pass:    [][]string{{"Apache-2.0"}, {"MIT"}, {"Apache-2.0 WITH LLVM-exception"}},
+			fail:    [][]string{{"LLVM-exception"}, {"BSD-3-Clause"}},
+		},
+		// + operators
+		{
+			license: "GPL-2.0+",
+			pass: [][]string{
+				{"GPL-2.0+"},
+				{"GPL-2.0-only"},
+				{"GPL-2.0-or-later"},
+				{"GPL-3.0-only"},
+				{"GPL-3.0"},
+			},
+			fail: [][]string{
+				{"GPL-1.0-only"},
+				{"LGPL-2.0-only"},
+				{"LGPL-3.0+"},
+			},
+		},
+		{
+			license: "LGPL-2.1-or-later",
+			pass:    [][]string{{"LGPL-2.1"}, {"LGPL-3.0-only"}, {"LGPL-2.0+"}},
+			fail:    [][]string{{"LGPL-2.0-only"}, {"GPL-3.0-only"}},
+		},
+		{
+			license: "GPL-2.0-only",
+			pass:    [][]string{{"GPL-2.0"}, {"GPL-1.0+"}, {"GPL-2.0-or-later"}},
+			fail:    [][]string{{"GPL-3.0-or-later"}, {"GPL-3.0-only"}},
+		},
+		{
+			license: "GPL-2.0+ WITH Bison-exception-2.2",
+			pass:    [][]string{{"GPL-3.0-only"}, {"GPL-2.0-or-later WITH Bison-exception-2.2"}},
+			fail:    [][]string{{"GPL-3.0-only WITH Classpath-exception-2.0"}, {"Bison-exception-2.2"}},
+		},
+	}
+	for _, tt := range tests {
+		for _, variant := range tt.pass {
+			t.Run(namer(t, tt.license, variant, true), func(t *testing.T) {
+				t.Parallel()
+
+				got, err := spdx.SatisfiesExpression(tt.license, variant)
+
+				if err != nil {
+					t.Errorf("SatisfiesExpression(\"%s\") = %v, want %v", tt.license, err, nil)
+				}
+
+				if !got {
+					t.Errorf("SatisfiesExpression(\"%s\") = %v, want %v", tt.license, got, true)
+				}
+			})
+		}
+
+		for _, variant := range tt.fail {
+			t.Run(namer(t, tt.license, variant, false), func(t *testing.T) {
+				t.Parallel()
+
+				got, err := spdx.SatisfiesExpression(tt.license, variant)
+
+				if err != nil {
+					t.Errorf("SatisfiesExpression(\"%s\") = %v, want %v", tt.license, err, nil)
+				}
+
+				if got {
+					t.Errorf("SatisfiesExpression(\"%s\") = %v, want %v", tt.license, got, false)
+				}
+			})
+		}
+	}
+}
+
+func TestSatisfiesExpression_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := spdx.SatisfiesExpression("MIT OR", []string{"MIT"})
+
+	if err == nil || err.Error() != "unexpected END after OR" {
+		t.Errorf("SatisfiesExpression() error = %v, want %v", err, "unexpected END after OR")
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"github.com/google/osv-scanner/v2/pkg/models"
 )
 
+// allowedFunc checks if a single license (including any "+" or "WITH" exception) is allowed
+type allowedFunc = func(license string) bool
+
 type node interface {
-	// satisfiedBy checks if the given licenses satisfy the license expression represented by this node
-	satisfiedBy(licenses []string) bool
+	// satisfiedBy checks if the license expression represented by this node is satisfied
+	// by the licenses that are allowed
+	satisfiedBy(allowed allowedFunc) bool
 }
 
 // nodeBranch represents a node in the tree that has two children, which should be
@@ -21,12 +25,12 @@ type nodeBranch struct {
 	right    node
 }
 
-func (n nodeBranch) satisfiedBy(licenses []string) bool {
+func (n nodeBranch) satisfiedBy(allowed allowedFunc) bool {
 	switch n.operator {
 	case "AND":
-		return n.left.satisfiedBy(licenses) && n.right.satisfiedBy(licenses)
+		return n.left.satisfiedBy(allowed) && n.right.satisfiedBy(allowed)
 	case "OR":
-		return n.left.satisfiedBy(licenses) || n.right.satisfiedBy(licenses)
+		return n.left.satisfiedBy(allowed) || n.right.satisfiedBy(allowed)
 	}
 
 	return false
@@ -39,16 +43,8 @@ type nodeLeaf struct {
 	value string
 }
 
-func (n nodeLeaf) satisfiedBy(licenses []string) bool {
-	v := strings.ToLower(n.value)
-
-	for _, l := range licenses {
-		if v == strings.ToLower(l) {
-			return true
-		}
-	}
-
-	return false
+func (n nodeLeaf) satisfiedBy(allowed allowedFunc) bool {
+	return allowed(n.value)
 }
 
 var _ node = nodeLeaf{}
@@ -236,7 +232,8 @@ func parseExpression(tokens *tokens) (node, error) {
 		return expr, nil
 	}
 
-	// currently WITH expressions are just treated as part of the license
+	// WITH expressions are kept as part of the license, and
+	// handled when checking if the license is allowed
 	if tokens.peek() == "WITH" {
 		nex2, err := tokens.nextAndIsNextNextValid()
 		if err != nil {
@@ -258,5 +255,15 @@ func Satisfies(license models.License, allowlist []string) (bool, error) {
 		return false, err
 	}
 
-	return nod.satisfiedBy(allowlist), nil
+	return nod.satisfiedBy(func(l string) bool {
+		l = strings.ToLower(l)
+
+		for _, a := range allowlist {
+			if l == strings.ToLower(a) {
+				return true
+			}
+		}
+
+		return false
+	}), nil
 }
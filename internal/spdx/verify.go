@@ -14,3 +14,21 @@ func Unrecognized(licenses []string) (unrecognized []string) {
 
 	return unrecognized
 }
+
+// UnrecognizedExpressions is like Unrecognized, but also allows licenses to be
+// used with the "+" operator and a "WITH" exception. Exceptions themselves are
+// not checked, as they are not part of the license list.
+func UnrecognizedExpressions(licenses []string) (unrecognized []string) {
+	for _, license := range licenses {
+		l := strings.ToLower(strings.TrimSpace(license))
+		if id, _, ok := strings.Cut(l, " with "); ok {
+			l = strings.TrimSpace(id)
+		}
+
+		if !IDs[l] && !IDs[strings.TrimSuffix(l, "+")] && l != "unknown" {
+			unrecognized = append(unrecognized, license)
+		}
+	}
+
+	return unrecognized
+}
@@ -37,3 +37,34 @@ func TestUnrecognized(t *testing.T) {
 		})
 	}
 }
+
+func TestUnrecognizedExpressions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		licenses []string
+		want     []string
+	}{
+		{
+			name:     "all recognized licenses",
+			licenses: []string{"agpl-1.0", "MIT", "apache-1.0", "UNKNOWN"},
+			want:     nil,
+		}, {
+			name:     "licenses with operators and exceptions",
+			licenses: []string{"GPL-2.0+", "LGPL-2.1+", "GPL-2.0-only WITH Classpath-exception-2.0"},
+			want:     nil,
+		}, {
+			name:     "some recognized, some unrecognized licenses",
+			licenses: []string{"agpl-1.0", "unrecognized license", "apache1.0+", "unknown WITH exception"},
+			want:     []string{"unrecognized license", "apache1.0+"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := spdx.UnrecognizedExpressions(tt.licenses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnrecognizedExpressions() = %v,\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The instruments are created from the global meter, which forwards them to
+// the provider that Setup sets even though they are created before it is set
+var (
+	phaseDuration = newHistogram("osv_scanner.phase.duration", "s", "How long each phase of the scan took")
+
+	filesExtracted = newCounter("osv_scanner.files.extracted", "{file}", "Files that an extractor was run on")
+	packagesFound  = newCounter("osv_scanner.packages.found", "{package}", "Packages found by extractors")
+
+	queryBatches    = newCounter("osv_scanner.query.batches", "{batch}", "Batches of packages queried for vulnerabilities")
+	queriedPackages = newCounter("osv_scanner.query.packages", "{package}", "Packages queried for vulnerabilities")
+
+	vulnerabilitiesFound = newCounter("osv_scanner.vulnerabilities.found", "{vulnerability}", "Vulnerabilities found in scanned packages")
+)
+
+func newCounter(name, unit, description string) metric.Int64Counter {
+	c, err := Meter().Int64Counter(name, metric.WithUnit(unit), metric.WithDescription(description))
+	if err != nil {
+		c, _ = noop.Meter{}.Int64Counter(name)
+	}
+
+	return c
+}
+
+func newHistogram(name, unit, description string) metric.Float64Histogram {
+	h, err := Meter().Float64Histogram(name, metric.WithUnit(unit), metric.WithDescription(description))
+	if err != nil {
+		h, _ = noop.Meter{}.Float64Histogram(name)
+	}
+
+	return h
+}
+
+// RecordExtraction records a span for an extractor having been run on a file, which
+// is reported by scalibr only once the extractor has finished.
+func RecordExtraction(ctx context.Context, extractor, path string, runtime time.Duration, packages int, err error) {
+	end := time.Now()
+	_, span := Tracer().Start(ctx, "extract",
+		trace.WithTimestamp(end.Add(-runtime)),
+		trace.WithAttributes(
+			attribute.String("osv_scanner.extractor", extractor),
+			attribute.String("osv_scanner.file", path),
+			attribute.Int("osv_scanner.packages", packages),
+		),
+	)
+	status := recordError(span, err)
+	span.End(trace.WithTimestamp(end))
+
+	filesExtracted.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("osv_scanner.extractor", extractor),
+		attribute.String("status", status),
+	))
+	packagesFound.Add(ctx, int64(packages), metric.WithAttributes(attribute.String("osv_scanner.extractor", extractor)))
+}
+
+// StartQueryBatch starts a span for querying a batch of packages of an ecosystem for
+// vulnerabilities. The returned function ends the span, marking it as failed if err is not nil.
+func StartQueryBatch(ctx context.Context, ecosystem string, packages int) (context.Context, func(err error)) {
+	attrs := []attribute.KeyValue{
+		attribute.String("osv_scanner.ecosystem", ecosystem),
+		attribute.Int("osv_scanner.packages", packages),
+	}
+	ctx, span := Tracer().Start(ctx, "query batch", trace.WithAttributes(attrs...))
+
+	return ctx, func(err error) {
+		status := recordError(span, err)
+		span.End()
+
+		queryBatches.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("osv_scanner.ecosystem", ecosystem),
+			attribute.String("status", status),
+		))
+		queriedPackages.Add(ctx, int64(packages), metric.WithAttributes(attribute.String("osv_scanner.ecosystem", ecosystem)))
+	}
+}
+
+// RecordVulnerabilities records the number of vulnerabilities found by the scan
+func RecordVulnerabilities(ctx context.Context, count int) {
+	vulnerabilitiesFound.Add(ctx, int64(count))
+}
@@ -0,0 +1,186 @@
+// Package telemetry instruments scans with OpenTelemetry traces and metrics,
+// which are exported over OTLP when an endpoint is configured through the
+// standard OTEL_EXPORTER_OTLP_* environment variables.
+//
+// The instrumentation always uses the global providers, so that programs using
+// osv-scanner as a library can export the telemetry with their own providers.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/google/osv-scanner/v2"
+
+// ErrPhaseIncomplete is recorded for phases that were returned from before they completed,
+// such as when a step of the phase failed
+var ErrPhaseIncomplete = errors.New("phase did not complete")
+
+// Tracer returns the tracer that scans are instrumented with
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName, trace.WithInstrumentationVersion(version.OSVVersion))
+}
+
+// Meter returns the meter that scans are instrumented with
+func Meter() metric.Meter {
+	return otel.Meter(instrumentationName, metric.WithInstrumentationVersion(version.OSVVersion))
+}
+
+// configured returns true if an OTLP endpoint for the signal is set in the environment
+func configured(signal string) bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return false
+	}
+
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_"+signal+"_ENDPOINT") != ""
+}
+
+// protocol returns the OTLP protocol configured for the signal, which defaults to http/protobuf
+func protocol(signal string) string {
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_PROTOCOL"); p != "" {
+		return p
+	}
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); p != "" {
+		return p
+	}
+
+	return "http/protobuf"
+}
+
+// Setup sets the global providers to export traces and metrics over OTLP, for each of
+// the signals that an endpoint is configured for in the environment.
+//
+// The returned function flushes any telemetry that has not been exported yet and
+// shuts the providers down, and must be called before the program exits.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	var shutdowns []func(context.Context) error
+	shutdown := func(ctx context.Context) error {
+		var errs []error
+		for _, fn := range shutdowns {
+			errs = append(errs, fn(ctx))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	tracesConfigured, metricsConfigured := configured("TRACES"), configured("METRICS")
+	if !tracesConfigured && !metricsConfigured {
+		return shutdown, nil
+	}
+
+	// attributes from OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME take precedence
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", "osv-scanner"),
+			attribute.String("service.version", version.OSVVersion),
+		),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return shutdown, fmt.Errorf("failed to create telemetry resource: %w", err)
+	}
+
+	if tracesConfigured {
+		exporter, err := newTraceExporter(ctx)
+		if err != nil {
+			return shutdown, fmt.Errorf("failed to create trace exporter: %w", err)
+		}
+		tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+		shutdowns = append(shutdowns, tp.Shutdown)
+		otel.SetTracerProvider(tp)
+	}
+
+	if metricsConfigured {
+		exporter, err := newMetricExporter(ctx)
+		if err != nil {
+			return shutdown, fmt.Errorf("failed to create metric exporter: %w", err)
+		}
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)), sdkmetric.WithResource(res))
+		shutdowns = append(shutdowns, mp.Shutdown)
+		otel.SetMeterProvider(mp)
+	}
+
+	return shutdown, nil
+}
+
+func newTraceExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	switch p := protocol("TRACES"); p {
+	case "grpc":
+		return otlptracegrpc.New(ctx)
+	case "http/protobuf":
+		return otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", p)
+	}
+}
+
+func newMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	switch p := protocol("METRICS"); p {
+	case "grpc":
+		return otlpmetricgrpc.New(ctx)
+	case "http/protobuf":
+		return otlpmetrichttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", p)
+	}
+}
+
+// StartPhase starts a span for a phase of the scan, such as walking the filesystem or
+// querying for vulnerabilities. The returned function ends the span, marking it as
+// failed if err is not nil, and records how long the phase took.
+//
+// Only the first call of the returned function has an effect, so that it can be
+// deferred with ErrPhaseIncomplete to end phases that are returned from early.
+func StartPhase(ctx context.Context, phase string) (context.Context, func(err error)) {
+	start := time.Now()
+	ctx, span := Tracer().Start(ctx, phase, trace.WithAttributes(attribute.String("osv_scanner.phase", phase)))
+
+	var once sync.Once
+
+	return ctx, func(err error) {
+		once.Do(func() {
+			status := recordError(span, err)
+			span.End()
+
+			phaseDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+				attribute.String("osv_scanner.phase", phase),
+				attribute.String("status", status),
+			))
+		})
+	}
+}
+
+// recordError marks the span as failed if err is not nil, returning the status
+// to record in metrics
+func recordError(span trace.Span, err error) string {
+	if err == nil {
+		return "ok"
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	return "error"
+}
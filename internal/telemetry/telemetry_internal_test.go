@@ -0,0 +1,151 @@
+package telemetry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setupTestProviders sets the global providers to ones that record telemetry in memory
+func setupTestProviders(t *testing.T) (*tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	return recorder, reader
+}
+
+// counterTotals returns the total of each counter that was recorded
+func counterTotals(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(t.Context(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	totals := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				totals[m.Name] += dp.Value
+			}
+		}
+	}
+
+	return totals
+}
+
+// The global providers are replaced, so these tests cannot be run in parallel
+func TestInstrumentation(t *testing.T) {
+	recorder, reader := setupTestProviders(t)
+
+	ctx, endScan := StartPhase(t.Context(), "scan")
+
+	walkCtx, endWalk := StartPhase(ctx, "walk")
+	RecordExtraction(walkCtx, "javascript/packagelockjson", "/project/package-lock.json", 10*time.Millisecond, 3, nil)
+	RecordExtraction(walkCtx, "go/gomod", "/project/go.mod", time.Millisecond, 0, errors.New("invalid go.mod"))
+	endWalk(nil)
+
+	queryCtx, endQuery := StartPhase(ctx, "query")
+	_, endBatch := StartQueryBatch(queryCtx, "npm", 3)
+	endBatch(nil)
+	endQuery(nil)
+
+	_, endEnrich := StartPhase(ctx, "enrich")
+	endEnrich(ErrPhaseIncomplete)
+	// only the first call ends the phase
+	endEnrich(nil)
+
+	RecordVulnerabilities(ctx, 2)
+	endScan(nil)
+
+	type span struct {
+		Name   string
+		Parent string
+		Status codes.Code
+	}
+	spans := recorder.Ended()
+	names := make(map[string]string)
+	for _, s := range spans {
+		names[s.SpanContext().SpanID().String()] = s.Name()
+	}
+
+	got := make([]span, 0, len(spans))
+	for _, s := range spans {
+		got = append(got, span{
+			Name:   s.Name(),
+			Parent: names[s.Parent().SpanID().String()],
+			Status: s.Status().Code,
+		})
+	}
+
+	want := []span{
+		{Name: "extract", Parent: "walk", Status: codes.Unset},
+		{Name: "extract", Parent: "walk", Status: codes.Error},
+		{Name: "walk", Parent: "scan", Status: codes.Unset},
+		{Name: "query batch", Parent: "query", Status: codes.Unset},
+		{Name: "query", Parent: "scan", Status: codes.Unset},
+		{Name: "enrich", Parent: "scan", Status: codes.Error},
+		{Name: "scan", Parent: "", Status: codes.Unset},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("spans (-want +got):\n%s", diff)
+	}
+
+	wantTotals := map[string]int64{
+		"osv_scanner.files.extracted":       2,
+		"osv_scanner.packages.found":        3,
+		"osv_scanner.query.batches":         1,
+		"osv_scanner.query.packages":        3,
+		"osv_scanner.vulnerabilities.found": 2,
+	}
+	if diff := cmp.Diff(wantTotals, counterTotals(t, reader)); diff != "" {
+		t.Errorf("counters (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetup_NotConfigured(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "")
+
+	tp := otel.GetTracerProvider()
+
+	shutdown, err := Setup(t.Context())
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if err := shutdown(t.Context()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+
+	if otel.GetTracerProvider() != tp {
+		t.Errorf("Setup() replaced the global tracer provider without an endpoint configured")
+	}
+}
+
+func TestSetup_UnsupportedProtocol(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/json")
+
+	if _, err := Setup(t.Context()); err == nil {
+		t.Errorf("Setup() did not return an error for an unsupported protocol")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"runtime"
 	"runtime/debug"
 	"strings"
 	"sync"
@@ -94,8 +95,9 @@ func (tl *Handler) Handle(ctx context.Context, record slog.Record) error {
 
 	l := tl.getLogger()
 	if l == voidLogger {
-		// This is to be safe as we currently do not have any non muffled goroutine logs
-		// When we do, this makes sure that we are aware and can add exceptions to them.
+		// This is to be safe as goroutines that log non-muffled messages should be
+		// created by a test, so that their messages are logged by that test's logger.
+		// If they are not, this makes sure that we are aware and can add exceptions to them.
 		panic("noop logger found when logging non-muffled messages")
 	}
 
@@ -141,18 +143,69 @@ func New() *Handler {
 // This uses debug.Stack(), which will create a buffer big enough to fit the entire stack trace.
 // If there is deep recursion, this will have a significant performance cost.
 //
-// Caveat: This cannot get the stack trace if called from a goroutine, and will return ""
+// If called from a goroutine, the stacks of the goroutines that created it are searched instead,
+// returning "" if the test runner call cannot be found in any of them (e.g. if they have exited)
 func getCallerInstance() string {
-	stack := debug.Stack()
+	key, parent := findCallerInstance(debug.Stack())
+	if parent == "" {
+		return key
+	}
+
+	// only goroutines need the stacks of every other goroutine, as they are expensive to get
+	stacks := allStacks()
+	for parent != "" {
+		stack, ok := stacks[parent]
+		if !ok {
+			return ""
+		}
+
+		key, parent = findCallerInstance(stack)
+	}
+
+	return key
+}
+
+// findCallerInstance finds the initial test runner call in the given stack, returning
+// the id of the goroutine that created the stack instead if it is of a goroutine
+func findCallerInstance(stack []byte) (string, string) {
 	sc := bufio.NewScanner(bytes.NewReader(stack))
 	for sc.Scan() {
 		if strings.HasPrefix(sc.Text(), "testing.tRunner(") {
-			return sc.Text()
+			return sc.Text(), ""
 		}
-		if strings.HasPrefix(sc.Text(), "created by ") && strings.Contains(sc.Text(), " in goroutine ") {
-			return ""
+		if strings.HasPrefix(sc.Text(), "created by ") {
+			if _, parent, ok := strings.Cut(sc.Text(), " in goroutine "); ok {
+				return "", parent
+			}
 		}
 	}
 
 	panic("no caller found in stack, and not in goroutine, recursed too deep?")
 }
+
+// allStacks returns the stacks of every goroutine, keyed by the id of the goroutine
+func allStacks() map[string][]byte {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := make(map[string][]byte)
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		// each stack starts with a header like "goroutine 12 [running]:"
+		header, _, _ := bytes.Cut(stack, []byte("\n"))
+		id, ok := bytes.CutPrefix(header, []byte("goroutine "))
+		if !ok {
+			continue
+		}
+		id, _, _ = bytes.Cut(id, []byte(" "))
+		stacks[string(id)] = stack
+	}
+
+	return stacks
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+
+	udiff "github.com/aymanbagabas/go-udiff"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2")) // Green
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(ColorPrimary)
+	diffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("6")) // Cyan
+)
+
+// A ViewModel showing a scrollable unified diff of the changes that will be made to a file
+type diffView struct {
+	lines    []string
+	viewport viewport.Model
+}
+
+// NewDiffView creates a ViewModel showing the diff between the original and patched contents of filename
+//
+//revive:disable-next-line:unexported-return
+func NewDiffView(filename string, original, patched []byte) *diffView {
+	v := diffView{viewport: viewport.New(ViewMinWidth, ViewMinHeight)}
+	v.viewport.KeyMap = viewport.KeyMap{
+		Up:       Keys.Up,
+		Down:     Keys.Down,
+		PageUp:   Keys.Left,
+		PageDown: Keys.Right,
+	}
+
+	diff := udiff.Unified("a/"+filename, "b/"+filename, string(original), string(patched))
+	if diff == "" {
+		v.lines = []string{DisabledTextStyle.Render("No changes to " + filename)}
+	} else {
+		v.lines = strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	}
+	v.setContent()
+
+	return &v
+}
+
+func (v *diffView) setContent() {
+	rendered := make([]string, len(v.lines))
+	for i, line := range v.lines {
+		// truncate long lines rather than wrapping them, so the diff markers stay aligned
+		line = lipgloss.NewStyle().MaxWidth(v.viewport.Width).Render(line)
+		switch {
+		case i < 2 && (strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---")): // file names
+			rendered[i] = lipgloss.NewStyle().Bold(true).Render(line)
+		case strings.HasPrefix(line, "+"):
+			rendered[i] = diffAddedStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			rendered[i] = diffRemovedStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			rendered[i] = diffHunkStyle.Render(line)
+		default:
+			rendered[i] = line
+		}
+	}
+	v.viewport.SetContent(strings.Join(rendered, "\n"))
+}
+
+func (v *diffView) Resize(w, h int) {
+	v.viewport.Width = w
+	v.viewport.Height = h
+	v.setContent()
+}
+
+func (v *diffView) Update(msg tea.Msg) (ViewModel, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, Keys.Quit) {
+		return v, CloseViewModel
+	}
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+
+	return v, cmd
+}
+
+func (v *diffView) View() string {
+	return v.viewport.View()
+}
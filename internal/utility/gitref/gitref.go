@@ -0,0 +1,9 @@
+// Package gitref provides helpers for working with git references.
+package gitref
+
+import "github.com/google/osv-scanner/v2/internal/cachedregexp"
+
+// IsCommit reports whether ref is a full commit hash rather than the name of a branch or tag
+func IsCommit(ref string) bool {
+	return cachedregexp.MustCompile(`^[0-9a-f]{40}$`).MatchString(ref)
+}
@@ -0,0 +1,52 @@
+package gitref_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/utility/gitref"
+)
+
+func TestIsCommit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ref  string
+		want bool
+	}{
+		{
+			name: "full commit hash",
+			ref:  "11bd71901bbe5b1630ceea73d27597364c9af683",
+			want: true,
+		},
+		{
+			name: "short commit hash",
+			ref:  "11bd719",
+			want: false,
+		},
+		{
+			name: "uppercase commit hash",
+			ref:  "11BD71901BBE5B1630CEEA73D27597364C9AF683",
+			want: false,
+		},
+		{
+			name: "tag",
+			ref:  "v4.1.1",
+			want: false,
+		},
+		{
+			name: "branch",
+			ref:  "main",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := gitref.IsCommit(tt.ref); got != tt.want {
+				t.Errorf("IsCommit(%q) = %v, want %v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package purl
 
 import (
+	"maps"
 	"slices"
 
 	"github.com/google/osv-scanner/v2/pkg/models"
@@ -41,6 +42,7 @@ func Group(packageSources []models.PackageSource) (map[string]models.PackageVuln
 					Groups:            slices.Clone(pkg.Groups),
 					Licenses:          slices.Clone(pkg.Licenses),
 					LicenseViolations: slices.Clone(pkg.LicenseViolations),
+					Enrichments:       maps.Clone(pkg.Enrichments),
 				}
 
 				uniquePackages[packageURL.ToString()] = newPackageVuln
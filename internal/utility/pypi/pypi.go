@@ -0,0 +1,49 @@
+// Package pypi provides utilities for resolving Python dependencies.
+package pypi
+
+import (
+	"strings"
+
+	"deps.dev/util/pypi"
+	"deps.dev/util/resolve"
+	"deps.dev/util/resolve/dep"
+)
+
+// MakeRequirement parses a PEP 508 requirement string into a RequirementVersion,
+// with the environment markers and extras as the attributes the PyPI resolver expects.
+func MakeRequirement(requirement string) (resolve.RequirementVersion, error) {
+	d, err := pypi.ParseDependency(requirement)
+	if err != nil {
+		return resolve.RequirementVersion{}, err
+	}
+
+	return NewRequirement(d.Name, d.Constraint, d.Extras, d.Environment), nil
+}
+
+// NewRequirement makes a RequirementVersion on the package, where extras is a comma-separated
+// list of the extras of the package to enable, and environment is a PEP 508 environment marker.
+func NewRequirement(name, constraint, extras, environment string) resolve.RequirementVersion {
+	typ := dep.NewType()
+	if environment != "" {
+		typ.AddAttr(dep.Environment, environment)
+	}
+	if extras != "" {
+		es := strings.Split(extras, ",")
+		for i, e := range es {
+			es[i] = strings.TrimSpace(e)
+		}
+		typ.AddAttr(dep.EnabledDependencies, strings.Join(es, ","))
+	}
+
+	return resolve.RequirementVersion{
+		Type: typ,
+		VersionKey: resolve.VersionKey{
+			PackageKey: resolve.PackageKey{
+				System: resolve.PyPI,
+				Name:   pypi.CanonPackageName(name),
+			},
+			Version:     constraint,
+			VersionType: resolve.Requirement,
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package vulns
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// commitURLPattern matches links to a single commit on the common git hosts,
+// such as "https://github.com/owner/repo/commit/<sha>" and
+// "https://gitlab.com/group/repo/-/commit/<sha>.patch", capturing the repository and commit
+const commitURLPattern = `^(https?://[^?#]+?)(?:/-)?/commits?/([0-9a-fA-F]{7,40})(?:\.patch|\.diff)?/?(?:[?#].*)?$`
+
+// FixReferences extracts references to the upstream fixes of the vulnerabilities from
+// the fixed commits of their git ranges, their FIX and ADVISORY references, and who is
+// credited with developing the fixes, returning nil if there are no references at all
+func FixReferences(vulns []osvschema.Vulnerability) *models.FixReferences {
+	var refs models.FixReferences
+
+	for _, vuln := range vulns {
+		for _, affected := range vuln.Affected {
+			for _, r := range affected.Ranges {
+				if r.Type != osvschema.RangeGit || r.Repo == "" {
+					continue
+				}
+
+				for _, event := range r.Events {
+					if event.Fixed != "" {
+						refs.FixCommits = addFixCommit(refs.FixCommits, models.FixCommit{Repo: normalizeRepo(r.Repo), Commit: event.Fixed})
+					}
+				}
+			}
+		}
+
+		for _, ref := range vuln.References {
+			switch ref.Type { //nolint:exhaustive // only fixes and advisories are relevant
+			case osvschema.ReferenceFix:
+				if commit, ok := parseCommitURL(ref.URL); ok {
+					refs.FixCommits = addFixCommit(refs.FixCommits, commit)
+				} else {
+					refs.PatchURLs = append(refs.PatchURLs, ref.URL)
+				}
+			case osvschema.ReferenceAdvisory:
+				refs.AdvisoryURLs = append(refs.AdvisoryURLs, ref.URL)
+			}
+		}
+
+		for _, credit := range vuln.Credits {
+			if credit.Type == osvschema.CreditRemediationDeveloper && credit.Name != "" {
+				refs.RemediationDevelopers = append(refs.RemediationDevelopers, credit.Name)
+			}
+		}
+	}
+
+	if len(refs.FixCommits) == 0 && len(refs.PatchURLs) == 0 && len(refs.AdvisoryURLs) == 0 && len(refs.RemediationDevelopers) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(refs.FixCommits, func(a, b models.FixCommit) int {
+		return cmp.Or(cmp.Compare(a.Repo, b.Repo), cmp.Compare(a.Commit, b.Commit))
+	})
+	refs.PatchURLs = sortedUnique(refs.PatchURLs)
+	refs.AdvisoryURLs = sortedUnique(refs.AdvisoryURLs)
+	refs.RemediationDevelopers = sortedUnique(refs.RemediationDevelopers)
+
+	return &refs
+}
+
+// parseCommitURL returns the commit that the url links to, if it links to a single commit
+func parseCommitURL(url string) (models.FixCommit, bool) {
+	matches := cachedregexp.MustCompile(commitURLPattern).FindStringSubmatch(url)
+	if matches == nil {
+		return models.FixCommit{}, false
+	}
+
+	return models.FixCommit{
+		Repo:   normalizeRepo(matches[1]),
+		Commit: strings.ToLower(matches[2]),
+		URL:    url,
+	}, true
+}
+
+// addFixCommit adds the commit if it is not already present, treating commits in the
+// same repository as the same if one is an abbreviation of the other, and keeping the
+// full commit hash and the url of whichever commit has them
+func addFixCommit(commits []models.FixCommit, commit models.FixCommit) []models.FixCommit {
+	for i, existing := range commits {
+		if existing.Repo != commit.Repo {
+			continue
+		}
+
+		if !strings.HasPrefix(existing.Commit, commit.Commit) && !strings.HasPrefix(commit.Commit, existing.Commit) {
+			continue
+		}
+
+		if len(commit.Commit) > len(existing.Commit) {
+			commits[i].Commit = commit.Commit
+		}
+		if existing.URL == "" {
+			commits[i].URL = commit.URL
+		}
+
+		return commits
+	}
+
+	return append(commits, commit)
+}
+
+// normalizeRepo normalizes the url of a repository so that the different ways
+// of referring to the same repository can be compared
+func normalizeRepo(repo string) string {
+	repo = strings.TrimSuffix(repo, "/")
+	repo = strings.TrimSuffix(repo, ".git")
+
+	return repo
+}
+
+func sortedUnique(values []string) []string {
+	slices.Sort(values)
+
+	return slices.Compact(values)
+}
@@ -0,0 +1,125 @@
+package vulns_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/utility/vulns"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func TestFixReferences(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		vulns []osvschema.Vulnerability
+		want  *models.FixReferences
+	}{
+		{
+			name:  "no vulnerabilities",
+			vulns: nil,
+			want:  nil,
+		},
+		{
+			name: "no references to fixes",
+			vulns: []osvschema.Vulnerability{{
+				ID:         "GHSA-1",
+				References: []osvschema.Reference{{Type: osvschema.ReferenceWeb, URL: "https://example.com"}},
+			}},
+			want: nil,
+		},
+		{
+			name: "fixed commits of git ranges",
+			vulns: []osvschema.Vulnerability{{
+				ID: "OSV-1",
+				Affected: []osvschema.Affected{{
+					Ranges: []osvschema.Range{
+						{
+							Type: osvschema.RangeGit,
+							Repo: "https://github.com/owner/repo.git",
+							Events: []osvschema.Event{
+								{Introduced: "0"},
+								{Fixed: "8a9b2c3d4e5f60718293a4b5c6d7e8f901234567"},
+							},
+						},
+						{
+							Type:   osvschema.RangeSemVer,
+							Events: []osvschema.Event{{Introduced: "0"}, {Fixed: "1.2.3"}},
+						},
+					},
+				}},
+			}},
+			want: &models.FixReferences{
+				FixCommits: []models.FixCommit{
+					{Repo: "https://github.com/owner/repo", Commit: "8a9b2c3d4e5f60718293a4b5c6d7e8f901234567"},
+				},
+			},
+		},
+		{
+			name: "references to fixes and advisories",
+			vulns: []osvschema.Vulnerability{
+				{
+					ID: "GHSA-1",
+					References: []osvschema.Reference{
+						{Type: osvschema.ReferenceAdvisory, URL: "https://nvd.nist.gov/vuln/detail/CVE-2024-1"},
+						{Type: osvschema.ReferenceFix, URL: "https://github.com/owner/repo/commit/8a9b2c3d"},
+						{Type: osvschema.ReferenceFix, URL: "https://github.com/owner/repo/pull/42"},
+						{Type: osvschema.ReferenceFix, URL: "https://gitlab.com/group/sub/repo/-/commit/0123456789abcdef0123456789abcdef01234567.patch"},
+						{Type: osvschema.ReferenceWeb, URL: "https://example.com/blog"},
+					},
+					Credits: []osvschema.Credit{
+						{Name: "Jane Doe", Type: osvschema.CreditRemediationDeveloper},
+						{Name: "John Doe", Type: osvschema.CreditFinder},
+					},
+				},
+				{
+					ID: "CVE-2024-1",
+					Affected: []osvschema.Affected{{
+						Ranges: []osvschema.Range{{
+							Type:   osvschema.RangeGit,
+							Repo:   "https://github.com/owner/repo",
+							Events: []osvschema.Event{{Introduced: "0"}, {Fixed: "8a9b2c3d4e5f60718293a4b5c6d7e8f901234567"}},
+						}},
+					}},
+					References: []osvschema.Reference{
+						{Type: osvschema.ReferenceAdvisory, URL: "https://nvd.nist.gov/vuln/detail/CVE-2024-1"},
+						{Type: osvschema.ReferenceAdvisory, URL: "https://github.com/advisories/GHSA-1"},
+					},
+				},
+			},
+			want: &models.FixReferences{
+				FixCommits: []models.FixCommit{
+					{
+						Repo:   "https://github.com/owner/repo",
+						Commit: "8a9b2c3d4e5f60718293a4b5c6d7e8f901234567",
+						URL:    "https://github.com/owner/repo/commit/8a9b2c3d",
+					},
+					{
+						Repo:   "https://gitlab.com/group/sub/repo",
+						Commit: "0123456789abcdef0123456789abcdef01234567",
+						URL:    "https://gitlab.com/group/sub/repo/-/commit/0123456789abcdef0123456789abcdef01234567.patch",
+					},
+				},
+				PatchURLs: []string{"https://github.com/owner/repo/pull/42"},
+				AdvisoryURLs: []string{
+					"https://github.com/advisories/GHSA-1",
+					"https://nvd.nist.gov/vuln/detail/CVE-2024-1",
+				},
+				RemediationDevelopers: []string{"Jane Doe"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := vulns.FixReferences(tt.vulns)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FixReferences() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
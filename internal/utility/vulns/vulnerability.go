@@ -41,7 +41,12 @@ func rangeContainsVersion(ar osvschema.Range, pkg imodels.PackageInfo) bool {
 		return false
 	}
 
-	vp := semantic.MustParse(pkg.Version(), string(pkg.Ecosystem().Ecosystem))
+	// Versions of ecosystems that cannot be compared locally (e.g. GitHub Actions)
+	// are only matched by the OSV API
+	vp, err := semantic.Parse(pkg.Version(), string(pkg.Ecosystem().Ecosystem))
+	if err != nil {
+		return false
+	}
 
 	sort.Slice(ar.Events, func(i, j int) bool {
 		a := ar.Events[i]
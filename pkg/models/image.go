@@ -4,6 +4,9 @@ import "github.com/opencontainers/go-digest"
 
 type ImageOriginDetails struct {
 	Index int `json:"index"`
+	// InBaseImage is true if the layer the package was introduced in comes from a
+	// base image, rather than being added by the image itself
+	InBaseImage bool `json:"in_base_image,omitempty"`
 }
 
 type ImageMetadata struct {
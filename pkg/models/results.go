@@ -1,8 +1,10 @@
 package models
 
 import (
+	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/ossf/osv-schema/bindings/go/osvschema"
@@ -13,7 +15,179 @@ type VulnerabilityResults struct {
 	Results                    []PackageSource            `json:"results"`
 	ExperimentalAnalysisConfig ExperimentalAnalysisConfig `json:"experimental_config"`
 	ImageMetadata              *ImageMetadata             `json:"image_metadata,omitempty"`
+	RepositoryMetadata         *RepositoryMetadata        `json:"repository_metadata,omitempty"`
 	LicenseSummary             []LicenseCount             `json:"license_summary,omitempty"`
+	ExperimentalScanStats      *ScanStats                 `json:"experimental_scan_stats,omitempty"`
+	UnscannedPackages          []UnscannedPackage         `json:"unscanned_packages,omitempty"`
+	IntegrityMismatches        []IntegrityMismatch        `json:"integrity_mismatches,omitempty"`
+	RiskScore                  *RiskScore                 `json:"risk_score,omitempty"`
+	Policy                     *PolicyResult              `json:"policy,omitempty"`
+	Summary                    *ResultsSummary            `json:"summary,omitempty"`
+	// Diagnostics are the problems that were encountered during the scan without
+	// stopping it, which may mean that the results are incomplete
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+	// Labels are metadata describing what was scanned, such as the service or
+	// environment that it is deployed to, as given to the scan
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ResultsSummary is the counts of the vulnerabilities found by a scan, with a vulnerability
+// being counted once for each package and source that it was found in
+type ResultsSummary struct {
+	// Vulnerabilities is the number of vulnerabilities that are reported, which does
+	// not include those that are hidden for being uncalled or unimportant
+	Vulnerabilities int            `json:"vulnerabilities"`
+	Severity        SeverityCounts `json:"severity"`
+	// Ecosystems is the number of vulnerabilities in the packages of each ecosystem
+	Ecosystems map[string]int `json:"ecosystems"`
+	Fixable    int            `json:"fixable"`
+	Unfixable  int            `json:"unfixable"`
+	// Called is the number of vulnerabilities that are reported, which are either
+	// reachable or were not checked by call analysis
+	Called int `json:"called"`
+	// Uncalled is the number of vulnerabilities that call analysis found to be unreachable
+	Uncalled int `json:"uncalled"`
+	// SeverityByEcosystem is the number of vulnerabilities of each severity in the packages
+	// of each ecosystem, which add up to Ecosystems and Severity
+	SeverityByEcosystem map[string]SeverityCounts `json:"severityByEcosystem"`
+	// FixableBySeverity and UnfixableBySeverity are the number of vulnerabilities of
+	// each severity that can and cannot be fixed, which add up to Fixable and Unfixable
+	FixableBySeverity   SeverityCounts `json:"fixableBySeverity"`
+	UnfixableBySeverity SeverityCounts `json:"unfixableBySeverity"`
+	// TopPackages are the packages with the most vulnerabilities, up to ten of them
+	TopPackages []PackageSummary `json:"topPackages"`
+}
+
+// PackageSummary is the counts of the vulnerabilities of a package, in every source
+// that the package was found in
+type PackageSummary struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	Ecosystem       string `json:"ecosystem"`
+	Vulnerabilities int    `json:"vulnerabilities"`
+	Fixable         int    `json:"fixable"`
+}
+
+// SeverityCounts is the number of vulnerabilities with each severity rating
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Unknown  int `json:"unknown"`
+}
+
+// Total is the number of vulnerabilities of any severity
+func (c SeverityCounts) Total() int {
+	return c.Critical + c.High + c.Medium + c.Low + c.Unknown
+}
+
+// PolicyResult is the outcome of evaluating the results of a scan against a policy,
+// which decides whether the scan passes in place of the vulnerabilities that were found
+type PolicyResult struct {
+	// Path is the file that the policy was loaded from
+	Path   string `json:"path"`
+	Passed bool   `json:"passed"`
+	// Messages are the reasons the policy gave for its decision
+	Messages []string `json:"messages,omitempty"`
+}
+
+// RepositoryMetadata describes the remote Git repository that was scanned,
+// which the paths of the sources in the results are relative to
+type RepositoryMetadata struct {
+	URL string `json:"url"`
+	// Ref is the branch, tag, or commit that was requested, if one was
+	Ref    string `json:"ref,omitempty"`
+	Commit string `json:"commit"`
+}
+
+// RiskScore is the aggregate risk of all the vulnerabilities found by a scan, combining
+// how many there are with how severe they are and how likely they are to be exploited
+type RiskScore struct {
+	Score float64 `json:"score"`
+	// MaxScore is the highest score the scan could have without failing, if it was gated on the score
+	MaxScore float64 `json:"max_score,omitempty"`
+}
+
+// UnscannedReason is why a package could not be checked for vulnerabilities
+type UnscannedReason string
+
+const (
+	UnscannedReasonUnknownEcosystem UnscannedReason = "unknown ecosystem"
+	UnscannedReasonInvalidPURL      UnscannedReason = "unsupported or unparsable package url"
+	UnscannedReasonUnmappedCPE      UnscannedReason = "no mapping for cpe"
+	UnscannedReasonMissingName      UnscannedReason = "missing name"
+	UnscannedReasonMissingVersion   UnscannedReason = "missing version"
+	UnscannedReasonQueryFailed      UnscannedReason = "vulnerability query failed"
+)
+
+// UnscannedPackage is a package that was found, but which could not be
+// checked for vulnerabilities as it does not have enough information,
+// or because querying for its vulnerabilities failed
+type UnscannedPackage struct {
+	Package PackageInfo     `json:"package"`
+	Source  SourceInfo      `json:"source"`
+	Reason  UnscannedReason `json:"reason"`
+	// Error is the error that caused the package to not be scanned, if there was one
+	Error string `json:"error,omitempty"`
+}
+
+// IntegrityMismatch is a package with a hash recorded by a lockfile that does not match
+// the hash published by the registry of the package, which may indicate that either the
+// lockfile or the package has been tampered with
+type IntegrityMismatch struct {
+	Package PackageInfo `json:"package"`
+	Source  SourceInfo  `json:"source"`
+	// File is the file of the package that the hash is of, which is empty for
+	// packages that are distributed as a single file
+	File         string `json:"file,omitempty"`
+	LockfileHash string `json:"lockfile_hash"`
+	// RegistryHashes are the hashes of the file that are published by the registry
+	// using the same algorithm as the hash recorded by the lockfile
+	RegistryHashes []string `json:"registry_hashes"`
+}
+
+// ScanStats contains diagnostics about the extraction phase of a scan, so that
+// an empty result can be told apart from extractors failing to parse files.
+type ScanStats struct {
+	// FilesConsidered is the number of files which were offered to the extractors
+	FilesConsidered int              `json:"files_considered"`
+	Extractors      []ExtractorStats `json:"extractors"`
+}
+
+// ExtractorStats contains the counters for a single extractor.
+type ExtractorStats struct {
+	Name          string `json:"name"`
+	FilesMatched  int    `json:"files_matched"`
+	FilesParsed   int    `json:"files_parsed"`
+	FilesErrored  int    `json:"files_errored"`
+	PackagesFound int    `json:"packages_found"`
+	DurationMs    int64  `json:"duration_ms"`
+}
+
+// DiagnosticKind is the kind of problem that a Diagnostic records
+type DiagnosticKind string
+
+const (
+	// DiagnosticExtractionError is for a file that an extractor failed to parse
+	DiagnosticExtractionError DiagnosticKind = "extraction_error"
+	// DiagnosticSkippedFile is for a file or directory that was not scanned
+	DiagnosticSkippedFile DiagnosticKind = "skipped_file"
+	// DiagnosticAPIFailure is for a request to a service that failed
+	DiagnosticAPIFailure DiagnosticKind = "api_failure"
+	// DiagnosticPartialResult is for part of the scan that could only be partially completed
+	DiagnosticPartialResult DiagnosticKind = "partial_result"
+)
+
+// Diagnostic is a problem that was encountered during a scan, so that a clean scan
+// can be told apart from one where parts of what was being scanned were missed
+type Diagnostic struct {
+	Kind DiagnosticKind `json:"kind"`
+	// Path is the file, directory, or image that the problem is about, if any
+	Path string `json:"path,omitempty"`
+	// Source is the extractor or service that the problem came from, if any
+	Source  string `json:"source,omitempty"`
+	Message string `json:"message"`
 }
 
 type LicenseCount struct {
@@ -127,6 +301,33 @@ type PackageVulns struct {
 	Groups            []GroupInfo               `json:"groups,omitempty"`
 	Licenses          []License                 `json:"licenses,omitempty"`
 	LicenseViolations []License                 `json:"license_violations,omitempty"`
+	// Enrichments is the information added to the package by the custom enrichers
+	// that the scan was run with, keyed by the name of each enricher
+	Enrichments map[string]any `json:"enrichments,omitempty"`
+	// GoWorkspace is set if the package is a Go module required by a member of a Go workspace
+	GoWorkspace *GoWorkspace `json:"go_workspace,omitempty"`
+	// IntroducedVia is the shortest chain of dependencies from a direct dependency of the
+	// project to the package, ending with the package itself, if the source records its
+	// dependency graph; it only has the package itself if it is a direct dependency
+	IntroducedVia []DependencyLink `json:"introduced_via,omitempty"`
+}
+
+// DependencyLink is a package in a chain of dependencies
+type DependencyLink struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// GoWorkspace is the Go workspace that a module required by one of its members is built in
+type GoWorkspace struct {
+	// Path is the path to the go.work file of the workspace
+	Path string `json:"path"`
+	// Selected is true if the version of the module required by the member is the
+	// version that minimal version selection picks across the whole workspace
+	Selected bool `json:"selected"`
+	// SelectedVersion is the version of the module that the workspace builds, which is
+	// empty if the module is replaced by a local directory or is a member of the workspace
+	SelectedVersion string `json:"selected_version,omitempty"`
 }
 
 type GroupInfo struct {
@@ -137,6 +338,83 @@ type GroupInfo struct {
 	// Map of Vulnerability IDs to AnalysisInfo
 	ExperimentalAnalysis map[string]AnalysisInfo `json:"experimental_analysis,omitempty"`
 	MaxSeverity          string                  `json:"max_severity"`
+	// SeverityLabel is the custom label of the max severity of the group, if the config
+	// of the source of the package maps severities to custom labels
+	SeverityLabel string `json:"severity_label,omitempty"`
+	// EPSS is the highest EPSS score of the CVEs in the aliases of the group,
+	// if the results were enriched with EPSS scores and any CVE had a score
+	EPSS *EPSSScore `json:"epss,omitempty"`
+	// KEV is the entry in the Known Exploited Vulnerabilities catalog of the CVEs in
+	// the aliases of the group, if the results were checked against the catalog
+	KEV *KEVEntry `json:"kev,omitempty"`
+	// FixReferences are the references to the upstream fixes of the vulnerabilities in the group,
+	// if they were extracted from the OSV records
+	FixReferences *FixReferences `json:"fix_references,omitempty"`
+	// Suppression is set if the group is soft-suppressed, meaning that it is
+	// still reported but does not cause the scan to fail
+	Suppression *Suppression `json:"suppression,omitempty"`
+	// Baseline is set if the results were compared against the results of a previous
+	// scan, recording whether the group is new since then and when it was first seen
+	Baseline *BaselineStatus `json:"baseline,omitempty"`
+}
+
+// Suppression records why a vulnerability group was soft-suppressed
+type Suppression struct {
+	Reason string `json:"reason"`
+}
+
+// BaselineStatus records how a vulnerability group compares to the results of a previous scan
+type BaselineStatus struct {
+	// New is true if the group was not found by the previous scan
+	New bool `json:"new"`
+	// FirstSeen is when the group was first found, which is carried over from the previous
+	// scan for groups that it found, and is unknown if the previous scan did not record it
+	FirstSeen time.Time `json:"first_seen,omitzero"`
+}
+
+// EPSSScore is the Exploit Prediction Scoring System (EPSS) score of a CVE
+type EPSSScore struct {
+	CVE string `json:"cve"`
+	// Score is the probability of the CVE being exploited in the next 30 days
+	Score float64 `json:"score"`
+	// Percentile is the proportion of all scored CVEs with the same or a lower score
+	Percentile float64 `json:"percentile"`
+	// Date is the day that the score was calculated for, in the form of YYYY-MM-DD
+	Date string `json:"date,omitempty"`
+}
+
+// FixReferences are references to the upstream fixes of vulnerabilities, extracted
+// from their OSV records so that tooling can find and backport the patches
+type FixReferences struct {
+	// FixCommits are sorted by repository and then by commit
+	FixCommits []FixCommit `json:"fix_commits,omitempty"`
+	// PatchURLs are links to fixes that are not a single commit, such as pull requests and patch files
+	PatchURLs    []string `json:"patch_urls,omitempty"`
+	AdvisoryURLs []string `json:"advisory_urls,omitempty"`
+	// RemediationDevelopers are who is credited with developing the fixes
+	RemediationDevelopers []string `json:"remediation_developers,omitempty"`
+}
+
+// FixCommit is a commit in an upstream repository that fixes a vulnerability
+type FixCommit struct {
+	Repo   string `json:"repo"`
+	Commit string `json:"commit"`
+	// URL is a link to the commit, if the OSV record references one
+	URL string `json:"url,omitempty"`
+}
+
+// KEVEntry is an entry in the Known Exploited Vulnerabilities (KEV) catalog of CISA,
+// which lists CVEs that are known to have been exploited in the wild
+type KEVEntry struct {
+	CVE string `json:"cve"`
+	// DateAdded is the day the CVE was added to the catalog, in the form of YYYY-MM-DD
+	DateAdded string `json:"date_added"`
+	// DueDate is the day that US federal agencies must have remediated the CVE by,
+	// in the form of YYYY-MM-DD
+	DueDate        string `json:"due_date,omitempty"`
+	RequiredAction string `json:"required_action,omitempty"`
+	// KnownRansomwareCampaignUse is true if the CVE is known to have been used in ransomware campaigns
+	KnownRansomwareCampaignUse bool `json:"known_ransomware_campaign_use,omitempty"`
 }
 
 // IsCalled returns true if any analysis performed determines that the vulnerability is being called
@@ -187,6 +465,42 @@ func (groupInfo *GroupInfo) IndexString() string {
 type AnalysisInfo struct {
 	Called      bool `json:"called"`
 	Unimportant bool `json:"unimportant"`
+	// AffectedSymbols are the vulnerable symbols that were found to be reachable
+	// by call analysis, if the analysis reports which symbols are called
+	AffectedSymbols []AffectedSymbol `json:"affected_symbols,omitempty"`
+}
+
+// AffectedSymbol is a vulnerable symbol that is reachable from the scanned code
+type AffectedSymbol struct {
+	// Symbol is the fully qualified name of the vulnerable symbol,
+	// such as "golang.org/x/text/language.Parse"
+	Symbol string `json:"symbol"`
+	// Trace is the chain of calls that reaches the symbol, starting from the
+	// entry point in the scanned code and ending with the symbol itself
+	Trace []CallFrame `json:"trace,omitempty"`
+}
+
+// CallFrame is a single call in the trace of an AffectedSymbol
+type CallFrame struct {
+	Symbol string `json:"symbol"`
+	// Filename, Line, and Column are the position of the call, if known
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// Position returns the position of the call in the form of "filename:line:column",
+// or an empty string if the position is not known
+func (f CallFrame) Position() string {
+	if f.Filename == "" || f.Line <= 0 {
+		return ""
+	}
+
+	if f.Column <= 0 {
+		return fmt.Sprintf("%s:%d", f.Filename, f.Line)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", f.Filename, f.Line, f.Column)
 }
 
 type PackageInfo struct {
@@ -0,0 +1,194 @@
+package osvscanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	scalibr "github.com/google/osv-scalibr"
+	"github.com/google/osv-scalibr/artifact/image/layerscanning/image"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/builders"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/dockerfile"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// takeBaseImages removes the images that Dockerfiles build from out of the scan results,
+// as they are references to images rather than packages that can be checked for vulnerabilities
+func takeBaseImages(scanResults *results.ScanResults) []imodels.PackageInfo {
+	return takeImageReferences[dockerfile.Metadata](scanResults)
+}
+
+// takeImageReferences removes the packages with metadata of type M out of the scan results,
+// which are references to images rather than packages
+func takeImageReferences[M any](scanResults *results.ScanResults) []imodels.PackageInfo {
+	var images []imodels.PackageInfo
+
+	packages := make([]imodels.PackageScanResult, 0, len(scanResults.PackageScanResults))
+	for _, psr := range scanResults.PackageScanResults {
+		if _, ok := psr.PackageInfo.Metadata.(*M); ok {
+			images = append(images, psr.PackageInfo)
+
+			continue
+		}
+
+		packages = append(packages, psr)
+	}
+
+	scanResults.PackageScanResults = packages
+
+	return images
+}
+
+// scanBaseImages scans the packages of the images that Dockerfiles build from, which are
+// located by the Dockerfile and the image as it is written in the Dockerfile so that
+// vulnerabilities of the base images are reported under the Dockerfile that uses them
+//
+// Images that cannot be pulled are skipped and recorded as diagnostics, so that an unavailable
+// registry does not prevent the rest of the project from being scanned.
+func scanBaseImages(ctx context.Context, baseImages []imodels.PackageInfo, accessors ExternalAccessors, actions ScannerActions) ([]imodels.PackageScanResult, []models.Diagnostic) {
+	var packages []imodels.PackageScanResult
+	var diagnostics []models.Diagnostic
+
+	// images are often built from by many Dockerfiles in the same project, so they are only scanned once
+	scanned := map[dockerfile.Metadata][]*extractor.Package{}
+
+	for _, baseImage := range baseImages {
+		// images that are not scanned once the scan has been stopped would only fail
+		if ctx.Err() != nil {
+			break
+		}
+
+		md, ok := baseImage.Metadata.(*dockerfile.Metadata)
+		if !ok {
+			continue
+		}
+
+		key := dockerfile.Metadata{Image: md.Image, Platform: md.Platform}
+
+		invs, ok := scanned[key]
+		if !ok {
+			var err error
+			invs, err = scanRemoteImage(ctx, md.Image, md.Digest, md.Platform, accessors, actions)
+			if err != nil {
+				cmdlogger.Errorf("Failed to scan base image %q of %s: %s", md.Image, baseImage.Location(), err)
+				diagnostics = append(diagnostics, models.Diagnostic{
+					Kind:    models.DiagnosticPartialResult,
+					Path:    baseImage.Location(),
+					Source:  md.Image,
+					Message: "failed to scan base image: " + err.Error(),
+				})
+			}
+
+			scanned[key] = invs
+		}
+
+		location := baseImage.Location() + "#" + md.Image
+
+		for _, inv := range invs {
+			pkg := *inv
+			pkg.Locations = append([]string{location}, inv.Locations...)
+
+			packages = append(packages, imodels.PackageScanResult{
+				PackageInfo: imodels.FromInventory(&pkg),
+			})
+		}
+	}
+
+	return packages, diagnostics
+}
+
+// scanRemoteImage pulls an image from its registry and extracts its packages,
+// resolving the image to the digest that it is pinned to if it is pinned, or otherwise
+// to the digest that its tag currently points to in its registry
+func scanRemoteImage(ctx context.Context, ref string, digest string, platform string, accessors ExternalAccessors, actions ScannerActions) ([]*extractor.Package, error) {
+	opts := []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx)}
+
+	if platform != "" {
+		p, err := v1.ParsePlatform(platform)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform %q: %w", platform, err)
+		}
+
+		opts = append(opts, remote.WithPlatform(*p))
+	}
+
+	resolved, err := resolveRemoteImage(ref, digest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	cmdlogger.Infof("Scanning image %q (%s)", ref, resolved.DigestStr())
+
+	img, err := image.FromRemoteName(resolved.String(), image.DefaultConfig(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := img.CleanUp(); err != nil {
+			cmdlogger.Errorf("Failed to clean up image: %s", err)
+		}
+	}()
+
+	extractors := builders.BuildExtractors(scalibrextract.ExtractorsArtifacts)
+	configureExtractors(extractors, accessors, actions)
+
+	extractors, err = cacheLayerExtractions(extractors, img, actions)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := scalibr.New().ScanContainer(ctx, img, &scalibr.ScanConfig{
+		FilesystemExtractors: extractors,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan container image: %w", err)
+	}
+
+	invs := make([]*extractor.Package, 0, len(sr.Inventory.Packages))
+	for _, inv := range sr.Inventory.Packages {
+		// kernel packages do not apply within a container, as containers use the host's kernel
+		if inv.Name == "linux" {
+			continue
+		}
+
+		invs = append(invs, inv)
+	}
+
+	cmdlogger.Infof(
+		"Found %d %s in image %q",
+		len(invs),
+		output.Form(len(invs), "package", "packages"),
+		ref,
+	)
+
+	return invs, nil
+}
+
+// resolveRemoteImage returns the digest of the image that ref refers to
+func resolveRemoteImage(ref string, digest string, opts []remote.Option) (name.Digest, error) {
+	if digest != "" {
+		return name.NewDigest(ref)
+	}
+
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	desc, err := remote.Head(parsed, opts...)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("failed to resolve %q: %w", ref, err)
+	}
+
+	return parsed.Context().Digest(desc.Digest.String()), nil
+}
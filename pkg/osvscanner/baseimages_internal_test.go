@@ -0,0 +1,110 @@
+package osvscanner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/dockerfile"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func Test_takeBaseImages(t *testing.T) {
+	t.Parallel()
+
+	packages := []*extractor.Package{
+		{
+			Name:      "node",
+			Version:   "20-alpine",
+			Locations: []string{"/path/to/Dockerfile"},
+			Plugins:   []string{dockerfile.Name},
+			Metadata:  &dockerfile.Metadata{Image: "node:20-alpine", Stage: "build"},
+		},
+		{
+			Name:      "lodash",
+			Version:   "4.17.21",
+			PURLType:  "npm",
+			Locations: []string{"/path/to/package-lock.json"},
+			Plugins:   []string{"javascript/packagelockjson"},
+		},
+		{
+			Name:      "gcr.io/distroless/nodejs20-debian12",
+			Locations: []string{"/path/to/Dockerfile"},
+			Plugins:   []string{dockerfile.Name},
+			Metadata: &dockerfile.Metadata{
+				Image:  "gcr.io/distroless/nodejs20-debian12@sha256:9d3f5ac9e2f3b3a2f1b67ad2e8b0b3a3a0b0c0d0e0f0a1b1c1d1e1f1a2b2c2d2",
+				Digest: "sha256:9d3f5ac9e2f3b3a2f1b67ad2e8b0b3a3a0b0c0d0e0f0a1b1c1d1e1f1a2b2c2d2",
+			},
+		},
+	}
+
+	var scanResults results.ScanResults
+	for _, pkg := range packages {
+		scanResults.PackageScanResults = append(scanResults.PackageScanResults, imodels.PackageScanResult{
+			PackageInfo: imodels.FromInventory(pkg),
+		})
+	}
+
+	baseImages := takeBaseImages(&scanResults)
+
+	gotImages := make([]string, 0, len(baseImages))
+	for _, baseImage := range baseImages {
+		gotImages = append(gotImages, baseImage.Metadata.(*dockerfile.Metadata).Image)
+	}
+
+	wantImages := []string{
+		"node:20-alpine",
+		"gcr.io/distroless/nodejs20-debian12@sha256:9d3f5ac9e2f3b3a2f1b67ad2e8b0b3a3a0b0c0d0e0f0a1b1c1d1e1f1a2b2c2d2",
+	}
+
+	if diff := cmp.Diff(wantImages, gotImages); diff != "" {
+		t.Errorf("takeBaseImages() base images mismatch (-want +got):\n%s", diff)
+	}
+
+	gotPackages := make([]string, 0, len(scanResults.PackageScanResults))
+	for _, psr := range scanResults.PackageScanResults {
+		gotPackages = append(gotPackages, psr.PackageInfo.Name())
+	}
+
+	if diff := cmp.Diff([]string{"lodash"}, gotPackages); diff != "" {
+		t.Errorf("takeBaseImages() remaining packages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_scanBaseImages_Unresolvable(t *testing.T) {
+	t.Parallel()
+
+	baseImages := []imodels.PackageInfo{
+		imodels.FromInventory(&extractor.Package{
+			Name:      "node",
+			Version:   "20",
+			Locations: []string{"/path/to/Dockerfile"},
+			Plugins:   []string{dockerfile.Name},
+			Metadata:  &dockerfile.Metadata{Image: "node:20", Platform: "not/a/valid/platform/at/all"},
+		}),
+		imodels.FromInventory(&extractor.Package{
+			Name:      "Not A Valid Image",
+			Locations: []string{"/path/to/Dockerfile"},
+			Plugins:   []string{dockerfile.Name},
+			Metadata:  &dockerfile.Metadata{Image: "Not A Valid Image"},
+		}),
+	}
+
+	got, diagnostics := scanBaseImages(context.Background(), baseImages, ExternalAccessors{}, ScannerActions{})
+	if len(got) != 0 {
+		t.Errorf("scanBaseImages() = %v, want no packages", got)
+	}
+
+	// each image that could not be pulled is recorded as a diagnostic
+	if len(diagnostics) != 2 {
+		t.Errorf("scanBaseImages() diagnostics = %v, want 2", diagnostics)
+	}
+	for _, d := range diagnostics {
+		if d.Kind != models.DiagnosticPartialResult || d.Path != "/path/to/Dockerfile" {
+			t.Errorf("scanBaseImages() diagnostic = %+v, want a partial result of /path/to/Dockerfile", d)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package osvscanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// loadBaseline reads the results of a previous scan, as written by the json output format
+func loadBaseline(path string) (*models.VulnerabilityResults, error) {
+	if path == "" {
+		return nil, nil //nolint:nilnil // there being no baseline is not an error
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var baseline models.VulnerabilityResults
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+
+	return &baseline, nil
+}
+
+// baselinePackageKey identifies a package across scans, which does not include its version
+// so that vulnerabilities that remain after upgrading the package are not reported as new
+type baselinePackageKey struct {
+	ecosystem string
+	name      string
+}
+
+// applyBaseline records whether each vulnerability group is new since the baseline and when
+// it was first seen, soft-suppressing the groups that are not new if onlyNew is true, and
+// returning the number of groups that were suppressed.
+//
+// Groups are matched by the package and any of their aliases, regardless of the source that
+// the package was found in, so that baselines remain valid when the paths of sources change,
+// such as when the project is checked out to a different directory.
+func applyBaseline(vulnResults *models.VulnerabilityResults, baseline *models.VulnerabilityResults, onlyNew bool, now time.Time) int {
+	if baseline == nil {
+		return 0
+	}
+
+	baselineGroups := map[baselinePackageKey][]*models.GroupInfo{}
+	for i := range baseline.Results {
+		for j := range baseline.Results[i].Packages {
+			pkg := &baseline.Results[i].Packages[j]
+			key := baselinePackageKey{ecosystem: pkg.Package.Ecosystem, name: pkg.Package.Name}
+
+			for k := range pkg.Groups {
+				baselineGroups[key] = append(baselineGroups[key], &pkg.Groups[k])
+			}
+		}
+	}
+
+	seen := map[*models.GroupInfo]bool{}
+	newGroups, suppressed := 0, 0
+
+	for i := range vulnResults.Results {
+		for j := range vulnResults.Results[i].Packages {
+			pkg := &vulnResults.Results[i].Packages[j]
+			key := baselinePackageKey{ecosystem: pkg.Package.Ecosystem, name: pkg.Package.Name}
+
+			for k := range pkg.Groups {
+				group := &pkg.Groups[k]
+
+				previous := findBaselineGroup(baselineGroups[key], group)
+				if previous == nil {
+					group.Baseline = &models.BaselineStatus{New: true, FirstSeen: now}
+					newGroups++
+
+					continue
+				}
+
+				seen[previous] = true
+				group.Baseline = &models.BaselineStatus{New: false}
+				if previous.Baseline != nil {
+					group.Baseline.FirstSeen = previous.Baseline.FirstSeen
+				}
+
+				if !onlyNew || group.Suppression != nil {
+					continue
+				}
+
+				reason := "it was already found by the baseline scan"
+				if !group.Baseline.FirstSeen.IsZero() {
+					reason += ", where it was first seen on " + group.Baseline.FirstSeen.Format(time.DateOnly)
+				}
+				group.Suppression = &models.Suppression{Reason: reason}
+				suppressed++
+			}
+		}
+	}
+
+	fixed := 0
+	for _, groups := range baselineGroups {
+		for _, group := range groups {
+			if !seen[group] {
+				fixed++
+			}
+		}
+	}
+
+	cmdlogger.Infof(
+		"Found %d new %s since the baseline, which had %d %s that are no longer present",
+		newGroups,
+		output.Form(newGroups, "vulnerability", "vulnerabilities"),
+		fixed,
+		output.Form(fixed, "vulnerability", "vulnerabilities"),
+	)
+
+	return suppressed
+}
+
+// findBaselineGroup returns the group of the baseline that shares any aliases with the group
+func findBaselineGroup(baselineGroups []*models.GroupInfo, group *models.GroupInfo) *models.GroupInfo {
+	for _, previous := range baselineGroups {
+		for _, alias := range append(slices.Clone(group.IDs), group.Aliases...) {
+			if slices.Contains(previous.IDs, alias) || slices.Contains(previous.Aliases, alias) {
+				return previous
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package osvscanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func Test_applyBaseline(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	firstSeen := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	baseline := func() *models.VulnerabilityResults {
+		return &models.VulnerabilityResults{
+			Results: []models.PackageSource{{
+				Source: models.SourceInfo{Path: "/old/checkout/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "lodash", Version: "4.17.19", Ecosystem: "npm"},
+						Groups: []models.GroupInfo{
+							{
+								IDs:      []string{"GHSA-1"},
+								Aliases:  []string{"CVE-2020-1", "GHSA-1"},
+								Baseline: &models.BaselineStatus{New: true, FirstSeen: firstSeen},
+							},
+							// recorded before the scan compared against baselines
+							{IDs: []string{"GHSA-2"}, Aliases: []string{"GHSA-2"}},
+							// fixed since the baseline
+							{IDs: []string{"GHSA-3"}, Aliases: []string{"GHSA-3"}},
+						},
+					},
+					{
+						Package: models.PackageInfo{Name: "minimist", Version: "1.2.5", Ecosystem: "npm"},
+						Groups:  []models.GroupInfo{{IDs: []string{"GHSA-4"}, Aliases: []string{"GHSA-4"}}},
+					},
+				},
+			}},
+		}
+	}
+
+	results := func() models.VulnerabilityResults {
+		return models.VulnerabilityResults{
+			Results: []models.PackageSource{{
+				Source: models.SourceInfo{Path: "/new/checkout/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					{
+						Package: models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+						Groups: []models.GroupInfo{
+							// matched by an alias, after the package was upgraded
+							{IDs: []string{"GHSA-1b"}, Aliases: []string{"CVE-2020-1", "GHSA-1b"}},
+							{IDs: []string{"GHSA-2"}, Aliases: []string{"GHSA-2"}},
+							{IDs: []string{"GHSA-5"}, Aliases: []string{"GHSA-5"}},
+						},
+					},
+					{
+						// the same vulnerability in a different package is new
+						Package: models.PackageInfo{Name: "lodash-es", Version: "4.17.20", Ecosystem: "npm"},
+						Groups:  []models.GroupInfo{{IDs: []string{"GHSA-2"}, Aliases: []string{"GHSA-2"}}},
+					},
+				},
+			}},
+		}
+	}
+
+	wantBaselines := []*models.BaselineStatus{
+		{New: false, FirstSeen: firstSeen},
+		{New: false},
+		{New: true, FirstSeen: now},
+		{New: true, FirstSeen: now},
+	}
+
+	tests := []struct {
+		name           string
+		baseline       *models.VulnerabilityResults
+		onlyNew        bool
+		wantBaselines  []*models.BaselineStatus
+		wantSuppressed []string
+	}{
+		{
+			name:           "no_baseline",
+			baseline:       nil,
+			wantBaselines:  []*models.BaselineStatus{nil, nil, nil, nil},
+			wantSuppressed: []string{"", "", "", ""},
+		},
+		{
+			name:           "annotates_groups",
+			baseline:       baseline(),
+			wantBaselines:  wantBaselines,
+			wantSuppressed: []string{"", "", "", ""},
+		},
+		{
+			name:          "only_new_suppresses_existing_groups",
+			baseline:      baseline(),
+			onlyNew:       true,
+			wantBaselines: wantBaselines,
+			wantSuppressed: []string{
+				"it was already found by the baseline scan, where it was first seen on 2025-01-15",
+				"it was already found by the baseline scan",
+				"",
+				"",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			vulnResults := results()
+			suppressed := applyBaseline(&vulnResults, tt.baseline, tt.onlyNew, now)
+
+			var gotBaselines []*models.BaselineStatus
+			var gotSuppressed []string
+			gotSuppressedCount := 0
+			for _, pkg := range vulnResults.Results[0].Packages {
+				for _, group := range pkg.Groups {
+					gotBaselines = append(gotBaselines, group.Baseline)
+
+					reason := ""
+					if group.Suppression != nil {
+						reason = group.Suppression.Reason
+						gotSuppressedCount++
+					}
+					gotSuppressed = append(gotSuppressed, reason)
+				}
+			}
+
+			if diff := cmp.Diff(tt.wantBaselines, gotBaselines); diff != "" {
+				t.Errorf("applyBaseline() baselines mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantSuppressed, gotSuppressed); diff != "" {
+				t.Errorf("applyBaseline() suppressions mismatch (-want +got):\n%s", diff)
+			}
+
+			if suppressed != gotSuppressedCount {
+				t.Errorf("applyBaseline() = %d, but %d groups were suppressed", suppressed, gotSuppressedCount)
+			}
+		})
+	}
+}
+
+func Test_loadBaseline(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`{"results": [{"source": {"path": "/a/package-lock.json", "type": "lockfile"}, "packages": []}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`<results/>`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadBaseline("")
+	if got != nil || err != nil {
+		t.Errorf("loadBaseline(\"\") = %v, %v, want nil, nil", got, err)
+	}
+
+	got, err = loadBaseline(valid)
+	if err != nil {
+		t.Fatalf("loadBaseline() error = %v", err)
+	}
+	if len(got.Results) != 1 || got.Results[0].Source.Path != "/a/package-lock.json" {
+		t.Errorf("loadBaseline() = %+v, want one source", got)
+	}
+
+	if _, err := loadBaseline(invalid); err == nil {
+		t.Error("loadBaseline() of invalid json did not error")
+	}
+
+	if _, err := loadBaseline(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("loadBaseline() of missing file did not error")
+	}
+}
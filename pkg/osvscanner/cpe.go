@@ -0,0 +1,58 @@
+package osvscanner
+
+import (
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/cpe"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// mapCPEPackages maps the components of SBOMs that are only identified by CPEs, such as
+// firmware and appliances, to the packages in OSV ecosystems that they are built from,
+// using the mappings from the config of the SBOM along with those bundled with the scanner
+func mapCPEPackages(scanResults *results.ScanResults) error {
+	var bundled []cpe.Mapping
+
+	for i, psr := range scanResults.PackageScanResults {
+		pkg := psr.PackageInfo
+		if pkg.SourceType() != models.SourceTypeSBOM {
+			continue
+		}
+
+		cpes := cpe.FromSBOM(pkg.Metadata)
+		if len(cpes) == 0 {
+			continue
+		}
+
+		if bundled == nil {
+			var err error
+			if bundled, err = cpe.Bundled(); err != nil {
+				return err
+			}
+		}
+
+		mappings := scanResults.ConfigManager.Get(pkg.Location()).CPEMappings
+
+		mapped, ok := cpe.Map(cpes, mappings, bundled)
+		if !ok {
+			continue
+		}
+
+		cmdlogger.Infof("Mapped %s from %s to %s/%s", mapped.Metadata.CPE, pkg.Location(), mapped.Metadata.Ecosystem, mapped.Name)
+
+		inventory := pkg.Package
+		inventory.Name = mapped.Name
+		inventory.Metadata = mapped.Metadata
+
+		// CPEs that match any version are still scannable if the component has a version,
+		// which CycloneDX SBOMs give separately from the CPE
+		if mapped.Version != "" {
+			inventory.Version = mapped.Version
+		}
+
+		scanResults.PackageScanResults[i].PackageInfo = imodels.FromInventory(inventory)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package osvscanner
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx"
+	cdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/cdx/metadata"
+	"github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx"
+	spdxmeta "github.com/google/osv-scalibr/extractor/filesystem/sbom/spdx/metadata"
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/cpe"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func Test_mapCPEPackages(t *testing.T) {
+	t.Parallel()
+
+	sbomPackage := func(plugin string, name string, version string, metadata any) *extractor.Package {
+		return &extractor.Package{
+			Name:      name,
+			Version:   version,
+			Locations: []string{"/path/to/firmware.spdx.json"},
+			Plugins:   []string{plugin},
+			Metadata:  metadata,
+		}
+	}
+
+	packages := []*extractor.Package{
+		// mapped by the bundled mappings
+		sbomPackage(spdx.Name, "cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*", "", &spdxmeta.Metadata{
+			CPEs: []string{"cpe:2.3:a:openssl:openssl:3.0.7:*:*:*:*:*:*:*"},
+		}),
+		// mapped by the config, with the version of the component
+		sbomPackage(cdx.Name, "Router Firmware", "2.4.1", &cdxmeta.Metadata{
+			CPEs: []string{"cpe:2.3:o:acme:router_firmware:*:*:*:*:*:*:*:*"},
+		}),
+		// not mapped
+		sbomPackage(spdx.Name, "cpe:2.3:h:acme:router:-:*:*:*:*:*:*:*", "", &spdxmeta.Metadata{
+			CPEs: []string{"cpe:2.3:h:acme:router:-:*:*:*:*:*:*:*"},
+		}),
+		// not from an SBOM
+		{
+			Name:      "lodash",
+			Version:   "4.17.21",
+			PURLType:  "npm",
+			Locations: []string{"/path/to/package-lock.json"},
+			Plugins:   []string{"javascript/packagelockjson"},
+		},
+	}
+
+	scanResults := results.ScanResults{
+		ConfigManager: config.Manager{
+			OverrideConfig: &config.Config{
+				CPEMappings: []cpe.Mapping{
+					{Vendor: "acme", Product: "router_firmware", Ecosystem: "Linux", Name: "Kernel"},
+				},
+			},
+			ConfigMap: make(map[string]config.Config),
+		},
+	}
+	for _, pkg := range packages {
+		scanResults.PackageScanResults = append(scanResults.PackageScanResults, imodels.PackageScanResult{
+			PackageInfo: imodels.FromInventory(pkg),
+		})
+	}
+
+	if err := mapCPEPackages(&scanResults); err != nil {
+		t.Fatalf("mapCPEPackages() error = %v", err)
+	}
+
+	filterUnscannablePackages(&scanResults)
+
+	got := make([]models.PackageInfo, 0, len(scanResults.PackageScanResults))
+	for _, psr := range scanResults.PackageScanResults {
+		got = append(got, models.PackageInfo{
+			Name:      psr.PackageInfo.Name(),
+			Version:   psr.PackageInfo.Version(),
+			Ecosystem: psr.PackageInfo.Ecosystem().String(),
+		})
+	}
+
+	want := []models.PackageInfo{
+		{Name: "openssl", Version: "3.0.7", Ecosystem: "ConanCenter"},
+		{Name: "Kernel", Version: "2.4.1", Ecosystem: "Linux"},
+		{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mapCPEPackages() scanned packages mismatch (-want +got):\n%s", diff)
+	}
+
+	wantUnscanned := []models.UnscannedPackage{
+		{
+			Package: models.PackageInfo{Name: "cpe:2.3:h:acme:router:-:*:*:*:*:*:*:*"},
+			Source:  models.SourceInfo{Path: "/path/to/firmware.spdx.json", Type: models.SourceTypeSBOM},
+			Reason:  models.UnscannedReasonUnmappedCPE,
+		},
+	}
+
+	if diff := cmp.Diff(wantUnscanned, scanResults.UnscannedPackages); diff != "" {
+		t.Errorf("mapCPEPackages() unscanned packages mismatch (-want +got):\n%s", diff)
+	}
+}
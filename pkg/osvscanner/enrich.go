@@ -0,0 +1,57 @@
+package osvscanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// Enricher adds custom information to the results of each package of a scan, such as
+// the team that owns it or whether it is deployed somewhere exposed to the internet.
+//
+// Enrichers are run in the order that they are given, once the vulnerabilities and
+// licenses of each package have been found and the built-in enrichment (such as EPSS
+// scores and call analysis) has been done, but before any vulnerabilities are filtered
+// by config. They are run for every package that is included in the results.
+type Enricher interface {
+	// Name of the enricher, which the information it adds to each package is keyed by
+	Name() string
+	// Enrich returns the information to add to the results of the package, which was found in
+	// the source, or nil if there is nothing to add. An error stops the scan.
+	Enrich(ctx context.Context, source models.SourceInfo, pkg models.PackageVulns) (any, error)
+}
+
+// runEnrichers sets the information added by each of the enrichers to the results of each package
+func runEnrichers(ctx context.Context, enrichers []Enricher, vulnResults *models.VulnerabilityResults) error {
+	if len(enrichers) == 0 {
+		return nil
+	}
+
+	for i := range vulnResults.Results {
+		source := vulnResults.Results[i].Source
+
+		for j := range vulnResults.Results[i].Packages {
+			pkg := &vulnResults.Results[i].Packages[j]
+
+			for _, enricher := range enrichers {
+				enrichment, err := enricher.Enrich(ctx, source, *pkg)
+				if err != nil {
+					return fmt.Errorf("failed to enrich %s/%s with %s: %w", pkg.Package.Ecosystem, pkg.Package.Name, enricher.Name(), err)
+				}
+
+				if enrichment == nil {
+					continue
+				}
+
+				if pkg.Enrichments == nil {
+					pkg.Enrichments = make(map[string]any)
+				}
+
+				pkg.Enrichments[enricher.Name()] = enrichment
+			}
+		}
+	}
+
+	return nil
+}
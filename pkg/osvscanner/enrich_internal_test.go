@@ -0,0 +1,98 @@
+package osvscanner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// ownerEnricher looks up the owner of each package from the directory it was found in
+type ownerEnricher struct {
+	owners map[string]string
+}
+
+func (e ownerEnricher) Name() string { return "owner" }
+
+func (e ownerEnricher) Enrich(_ context.Context, source models.SourceInfo, _ models.PackageVulns) (any, error) {
+	owner, ok := e.owners[source.Path]
+	if !ok {
+		return nil, nil
+	}
+
+	return owner, nil
+}
+
+// failingEnricher fails to enrich every package
+type failingEnricher struct{}
+
+func (failingEnricher) Name() string { return "failing" }
+
+func (failingEnricher) Enrich(context.Context, models.SourceInfo, models.PackageVulns) (any, error) {
+	return nil, errors.New("service unavailable")
+}
+
+func Test_runEnrichers(t *testing.T) {
+	t.Parallel()
+
+	newResults := func() models.VulnerabilityResults {
+		return models.VulnerabilityResults{
+			Results: []models.PackageSource{
+				{
+					Source: models.SourceInfo{Path: "/path/to/frontend/package-lock.json", Type: models.SourceTypeProjectPackage},
+					Packages: []models.PackageVulns{
+						{Package: models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"}},
+					},
+				},
+				{
+					Source: models.SourceInfo{Path: "/path/to/unowned/go.mod", Type: models.SourceTypeProjectPackage},
+					Packages: []models.PackageVulns{
+						{Package: models.PackageInfo{Name: "golang.org/x/net", Version: "0.1.0", Ecosystem: "Go"}},
+					},
+				},
+			},
+		}
+	}
+
+	enricher := ownerEnricher{owners: map[string]string{"/path/to/frontend/package-lock.json": "web-team"}}
+
+	t.Run("no_enrichers", func(t *testing.T) {
+		t.Parallel()
+
+		got := newResults()
+		if err := runEnrichers(context.Background(), nil, &got); err != nil {
+			t.Fatalf("runEnrichers() error = %v", err)
+		}
+
+		if diff := cmp.Diff(newResults(), got); diff != "" {
+			t.Errorf("runEnrichers() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("enrichments", func(t *testing.T) {
+		t.Parallel()
+
+		got := newResults()
+		if err := runEnrichers(context.Background(), []Enricher{enricher}, &got); err != nil {
+			t.Fatalf("runEnrichers() error = %v", err)
+		}
+
+		want := newResults()
+		want.Results[0].Packages[0].Enrichments = map[string]any{"owner": "web-team"}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("runEnrichers() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		got := newResults()
+		if err := runEnrichers(context.Background(), []Enricher{enricher, failingEnricher{}}, &got); err == nil {
+			t.Errorf("runEnrichers() did not return an error")
+		}
+	})
+}
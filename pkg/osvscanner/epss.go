@@ -0,0 +1,90 @@
+package osvscanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/clients/clientinterfaces"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// enrichEPSS sets the EPSS score of each vulnerability group to the highest score
+// of the CVEs in its aliases, so that findings can be prioritized by how likely
+// they are to be exploited
+func enrichEPSS(ctx context.Context, matcher clientinterfaces.EPSSMatcher, vulnResults *models.VulnerabilityResults) error {
+	var cves []string
+	for _, source := range vulnResults.Results {
+		for _, pkg := range source.Packages {
+			for _, group := range pkg.Groups {
+				cves = append(cves, groupCVEs(group)...)
+			}
+		}
+	}
+
+	if len(cves) == 0 {
+		return nil
+	}
+
+	scores, err := matcher.MatchEPSS(ctx, cves)
+	if err != nil {
+		return err
+	}
+
+	for i := range vulnResults.Results {
+		for j := range vulnResults.Results[i].Packages {
+			groups := vulnResults.Results[i].Packages[j].Groups
+			for k := range groups {
+				for _, cve := range groupCVEs(groups[k]) {
+					score, ok := scores[cve]
+					if !ok {
+						continue
+					}
+
+					if groups[k].EPSS == nil || score.Score > groups[k].EPSS.Score {
+						groups[k].EPSS = &score
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// groupCVEs returns the ids and aliases of the group that are CVEs
+func groupCVEs(group models.GroupInfo) []string {
+	var cves []string
+	for _, id := range slices.Concat(group.IDs, group.Aliases) {
+		if strings.HasPrefix(id, "CVE-") {
+			cves = append(cves, id)
+		}
+	}
+
+	return cves
+}
+
+// meetsEPSSThreshold checks if the vulnerability is likely enough to be exploited to fail
+// the scan, with vulnerabilities that do not have an EPSS score never meeting a threshold
+func meetsEPSSThreshold(vf models.VulnerabilityFlattened, threshold float64) bool {
+	if threshold <= 0 {
+		return true
+	}
+
+	return vf.GroupInfo.EPSS != nil && vf.GroupInfo.EPSS.Score >= threshold
+}
+
+// checkEPSSActions checks that the EPSS scores can be looked up if they are needed
+func checkEPSSActions(actions ScannerActions) error {
+	if actions.FailOnEPSS < 0 || actions.FailOnEPSS > 1 {
+		return fmt.Errorf("EPSS threshold must be between 0 and 1, got %v", actions.FailOnEPSS)
+	}
+
+	if actions.CompareOffline && actions.FailOnEPSS > 0 {
+		return errors.New("EPSS scores cannot be looked up when running in offline mode")
+	}
+
+	return nil
+}
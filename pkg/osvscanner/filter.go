@@ -2,9 +2,12 @@ package osvscanner
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/cpe"
 	"github.com/google/osv-scanner/v2/internal/imodels"
 	"github.com/google/osv-scanner/v2/internal/imodels/results"
 	"github.com/google/osv-scanner/v2/pkg/models"
@@ -12,7 +15,7 @@ import (
 )
 
 // filterUnscannablePackages removes packages that don't have enough information to be scanned
-// e,g, local packages that specified by path
+// e,g, local packages that specified by path, recording them along with the reason they were removed
 func filterUnscannablePackages(scanResults *results.ScanResults) {
 	packageResults := make([]imodels.PackageScanResult, 0, len(scanResults.PackageScanResults))
 	for _, psr := range scanResults.PackageScanResults {
@@ -24,6 +27,8 @@ func filterUnscannablePackages(scanResults *results.ScanResults) {
 		case p.Commit() != "":
 		case p.Ecosystem().Ecosystem == osvschema.EcosystemMaven && p.Name() == "unknown":
 		default:
+			scanResults.UnscannedPackages = append(scanResults.UnscannedPackages, newUnscannedPackage(p, unscannableReason(p)))
+
 			continue
 		}
 
@@ -37,6 +42,37 @@ func filterUnscannablePackages(scanResults *results.ScanResults) {
 	scanResults.PackageScanResults = packageResults
 }
 
+func newUnscannedPackage(p imodels.PackageInfo, reason models.UnscannedReason) models.UnscannedPackage {
+	return models.UnscannedPackage{
+		Package: models.PackageInfo{
+			Name:      p.Name(),
+			Version:   p.Version(),
+			Ecosystem: p.Ecosystem().String(),
+		},
+		Source: models.SourceInfo{
+			Path: filepath.ToSlash(p.Location()),
+			Type: p.SourceType(),
+		},
+		Reason: reason,
+	}
+}
+
+// unscannableReason returns why the package does not have enough information to be scanned
+func unscannableReason(p imodels.PackageInfo) models.UnscannedReason {
+	switch {
+	case p.Ecosystem().IsEmpty() && p.SourceType() == models.SourceTypeSBOM && len(cpe.FromSBOM(p.Metadata)) > 0:
+		return models.UnscannedReasonUnmappedCPE
+	case p.Ecosystem().IsEmpty() && p.SourceType() == models.SourceTypeSBOM:
+		return models.UnscannedReasonInvalidPURL
+	case p.Ecosystem().IsEmpty():
+		return models.UnscannedReasonUnknownEcosystem
+	case p.Name() == "":
+		return models.UnscannedReasonMissingName
+	default:
+		return models.UnscannedReasonMissingVersion
+	}
+}
+
 // filterNonContainerRelevantPackages removes packages that are not relevant when doing container scanning
 func filterNonContainerRelevantPackages(scanResults *results.ScanResults) {
 	packageResults := make([]imodels.PackageScanResult, 0, len(scanResults.PackageScanResults))
@@ -95,6 +131,7 @@ func filterResults(results *models.VulnerabilityResults, configManager *config.M
 	newResults := []models.PackageSource{} // Want 0 vulnerabilities to show in JSON as an empty list, not null.
 	for _, pkgSrc := range results.Results {
 		configToUse := configManager.Get(pkgSrc.Source.Path)
+		logEffectiveConfig(pkgSrc, configToUse)
 		var newPackages []models.PackageVulns
 		for _, pkgVulns := range pkgSrc.Packages {
 			newVulns := filterPackageVulns(pkgVulns, configToUse)
@@ -168,3 +205,30 @@ func filterPackageVulns(pkgVulns models.PackageVulns, configToUse config.Config)
 
 	return pkgVulns
 }
+
+// logEffectiveConfig logs the configs that the findings of the package source are
+// filtered with, so that it is clear which of the inherited configs applied to them
+func logEffectiveConfig(pkgSrc models.PackageSource, configToUse config.Config) {
+	findings := 0
+	for _, pkgVulns := range pkgSrc.Packages {
+		findings += len(pkgVulns.Groups)
+	}
+
+	if findings == 0 {
+		return
+	}
+
+	switch {
+	case configToUse.LoadPath == "":
+		cmdlogger.Debugf("No config applies to the findings in %s", pkgSrc.Source.Path)
+	case len(configToUse.InheritedFrom) == 0:
+		cmdlogger.Debugf("Findings in %s use the config from %s", pkgSrc.Source.Path, configToUse.LoadPath)
+	default:
+		cmdlogger.Debugf(
+			"Findings in %s use the config from %s, inheriting from %s",
+			pkgSrc.Source.Path,
+			configToUse.LoadPath,
+			strings.Join(configToUse.InheritedFrom, ", "),
+		)
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
 	"github.com/google/osv-scanner/v2/internal/testutility"
 	"github.com/google/osv-scanner/v2/pkg/models"
 )
@@ -54,3 +58,84 @@ func Test_filterResults(t *testing.T) {
 		})
 	}
 }
+
+func Test_filterUnscannablePackages(t *testing.T) {
+	t.Parallel()
+
+	packages := []*extractor.Package{
+		{
+			Name:      "lodash",
+			Version:   "4.17.21",
+			PURLType:  "npm",
+			Locations: []string{"/path/to/package-lock.json"},
+			Plugins:   []string{"javascript/packagelockjson"},
+		},
+		{
+			Name:      "local-package",
+			PURLType:  "npm",
+			Locations: []string{"/path/to/package-lock.json"},
+			Plugins:   []string{"javascript/packagelockjson"},
+		},
+		{
+			Version:   "1.0.0",
+			PURLType:  "npm",
+			Locations: []string{"/path/to/package-lock.json"},
+			Plugins:   []string{"javascript/packagelockjson"},
+		},
+		{
+			Name:      "something",
+			Version:   "1.0.0",
+			PURLType:  "generic",
+			Locations: []string{"/path/to/other.lock"},
+			Plugins:   []string{"some/extractor"},
+		},
+		{
+			Name:      "zlib",
+			Locations: []string{"/path/to/third_party/zlib"},
+			Plugins:   []string{"filesystem/vendored"},
+			SourceCode: &extractor.SourceCodeIdentifier{
+				Commit: "04f42ceca40f73e2978b50e93806c2a18c1281fc",
+			},
+		},
+	}
+
+	scanResults := results.ScanResults{}
+	for _, pkg := range packages {
+		scanResults.PackageScanResults = append(scanResults.PackageScanResults, imodels.PackageScanResult{
+			PackageInfo: imodels.FromInventory(pkg),
+		})
+	}
+
+	filterUnscannablePackages(&scanResults)
+
+	gotNames := make([]string, 0, len(scanResults.PackageScanResults))
+	for _, psr := range scanResults.PackageScanResults {
+		gotNames = append(gotNames, psr.PackageInfo.Name())
+	}
+
+	if diff := cmp.Diff([]string{"lodash", "zlib"}, gotNames); diff != "" {
+		t.Errorf("filterUnscannablePackages() scanned packages mismatch (-want +got):\n%s", diff)
+	}
+
+	wantUnscanned := []models.UnscannedPackage{
+		{
+			Package: models.PackageInfo{Name: "local-package", Ecosystem: "npm"},
+			Source:  models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Reason:  models.UnscannedReasonMissingVersion,
+		},
+		{
+			Package: models.PackageInfo{Version: "1.0.0", Ecosystem: "npm"},
+			Source:  models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Reason:  models.UnscannedReasonMissingName,
+		},
+		{
+			Package: models.PackageInfo{Name: "something", Version: "1.0.0"},
+			Source:  models.SourceInfo{Path: "/path/to/other.lock", Type: models.SourceTypeProjectPackage},
+			Reason:  models.UnscannedReasonUnknownEcosystem,
+		},
+	}
+
+	if diff := cmp.Diff(wantUnscanned, scanResults.UnscannedPackages); diff != "" {
+		t.Errorf("filterUnscannablePackages() unscanned packages mismatch (-want +got):\n%s", diff)
+	}
+}
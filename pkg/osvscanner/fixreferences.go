@@ -0,0 +1,30 @@
+package osvscanner
+
+import (
+	"slices"
+
+	"github.com/google/osv-scanner/v2/internal/utility/vulns"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// addFixReferences sets the references to the upstream fixes of each vulnerability group
+// from the OSV records of the vulnerabilities in the group
+func addFixReferences(vulnResults *models.VulnerabilityResults) {
+	for i := range vulnResults.Results {
+		for j := range vulnResults.Results[i].Packages {
+			pkg := &vulnResults.Results[i].Packages[j]
+
+			for k := range pkg.Groups {
+				var groupVulns []osvschema.Vulnerability
+				for _, vuln := range pkg.Vulnerabilities {
+					if slices.Contains(pkg.Groups[k].IDs, vuln.ID) {
+						groupVulns = append(groupVulns, vuln)
+					}
+				}
+
+				pkg.Groups[k].FixReferences = vulns.FixReferences(groupVulns)
+			}
+		}
+	}
+}
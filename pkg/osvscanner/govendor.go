@@ -0,0 +1,63 @@
+package osvscanner
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gomod"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/golang/modulestxt"
+)
+
+// reconcileGoVendor removes the modules extracted from a go.mod file if
+// the module also has a vendor/modules.txt file, as the vendored modules are
+// what is actually built and may have diverged from the go.mod file.
+//
+// The Go stdlib is kept from the go.mod file, since modules.txt does not record
+// which version of Go is used.
+func reconcileGoVendor(pkgs []*extractor.Package) []*extractor.Package {
+	// vendored maps the directory of each module to its vendored module versions
+	vendored := make(map[string]map[string]string)
+	for _, pkg := range pkgs {
+		if !slices.Contains(pkg.Plugins, modulestxt.Name) || len(pkg.Locations) == 0 {
+			continue
+		}
+
+		moduleDir := filepath.Dir(filepath.Dir(pkg.Locations[0]))
+		if vendored[moduleDir] == nil {
+			vendored[moduleDir] = make(map[string]string)
+		}
+		vendored[moduleDir][pkg.Name] = pkg.Version
+	}
+
+	if len(vendored) == 0 {
+		return pkgs
+	}
+
+	return slices.DeleteFunc(pkgs, func(pkg *extractor.Package) bool {
+		if !slices.Contains(pkg.Plugins, gomod.Name) || len(pkg.Locations) == 0 || pkg.Name == "stdlib" {
+			return false
+		}
+
+		versions, ok := vendored[filepath.Dir(pkg.Locations[0])]
+		if !ok {
+			return false
+		}
+
+		// Modules that are required but not vendored do not provide any packages
+		// to the build, so they are dropped without warning
+		if v, ok := versions[pkg.Name]; ok && v != pkg.Version {
+			cmdlogger.Warnf(
+				"%s requires %s@%s but %s@%s is vendored, using the vendored version",
+				pkg.Locations[0],
+				pkg.Name,
+				pkg.Version,
+				pkg.Name,
+				v,
+			)
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,61 @@
+package osvscanner
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gomod"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/golang/modulestxt"
+)
+
+func Test_reconcileGoVendor(t *testing.T) {
+	t.Parallel()
+
+	goModPkg := func(dir, name, version string) *extractor.Package {
+		return &extractor.Package{
+			Name:      name,
+			Version:   version,
+			Locations: []string{dir + "/go.mod"},
+			Plugins:   []string{gomod.Name},
+		}
+	}
+	vendoredPkg := func(dir, name, version string) *extractor.Package {
+		return &extractor.Package{
+			Name:      name,
+			Version:   version,
+			Locations: []string{dir + "/vendor/modules.txt"},
+			Plugins:   []string{modulestxt.Name},
+		}
+	}
+
+	npmPkg := &extractor.Package{
+		Name:      "lodash",
+		Version:   "4.17.20",
+		Locations: []string{"/project/package-lock.json"},
+		Plugins:   []string{packagelockjson.Name},
+	}
+
+	pkgs := []*extractor.Package{
+		goModPkg("/project", "stdlib", "1.22.0"),
+		goModPkg("/project", "golang.org/x/net", "0.20.0"),
+		goModPkg("/project", "golang.org/x/text", "0.14.0"),
+		vendoredPkg("/project", "golang.org/x/net", "0.23.0"),
+		goModPkg("/other", "golang.org/x/net", "0.20.0"),
+		npmPkg,
+	}
+
+	want := []*extractor.Package{
+		goModPkg("/project", "stdlib", "1.22.0"),
+		vendoredPkg("/project", "golang.org/x/net", "0.23.0"),
+		goModPkg("/other", "golang.org/x/net", "0.20.0"),
+		npmPkg,
+	}
+
+	got := reconcileGoVendor(pkgs)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("reconcileGoVendor() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,252 @@
+package osvscanner
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gomod"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// goWorkspace is a parsed go.work file
+type goWorkspace struct {
+	path string
+	file *modfile.WorkFile
+	// members are the directories of the modules that are used by the workspace
+	members []string
+	// modules are the paths of the modules that are used by the workspace
+	modules []string
+	// selected is the version of each required module that minimal version
+	// selection picks across the members, which is empty if the module is
+	// only ever replaced by a local directory
+	selected map[string]string
+}
+
+// goWorkspaceMemberPaths returns the go.mod files of the members of the Go workspace in dir,
+// if it has one, which would not otherwise be scanned.
+//
+// Members are not scanned by default if they are outside dir, or are in a subdirectory of it
+// when the scan is not recursive.
+func goWorkspaceMemberPaths(dir string, recursive bool) []string {
+	work, err := parseGoWorkspace(filepath.Join(dir, "go.work"))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			cmdlogger.Warnf("Failed to parse %s: %s", filepath.Join(dir, "go.work"), err)
+		}
+
+		return nil
+	}
+
+	var paths []string
+	for _, member := range work.members {
+		if member == dir {
+			continue
+		}
+
+		if rel, err := filepath.Rel(dir, member); recursive && err == nil && !strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		path := filepath.Join(member, "go.mod")
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		cmdlogger.Infof("Scanning Go workspace member %s", member)
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// resolveGoWorkspaces returns the Go workspace of each module extracted from the go.mod
+// file of a module that is a member of a workspace, noting whether the version of the
+// module that its go.mod file requires is the one that the workspace actually builds.
+//
+// The workspace of a module is found in the same way as the go command, by looking for
+// a go.work file in the directory of the module and then each of its parents.
+func resolveGoWorkspaces(pkgs []*extractor.Package) map[*extractor.Package]*models.GoWorkspace {
+	// workspaces caches the workspace of each directory, which is nil if it has none
+	workspaces := make(map[string]*goWorkspace)
+	resolved := make(map[*extractor.Package]*models.GoWorkspace)
+
+	for _, pkg := range pkgs {
+		if !slices.Contains(pkg.Plugins, gomod.Name) || len(pkg.Locations) == 0 || filepath.Base(pkg.Locations[0]) != "go.mod" {
+			continue
+		}
+
+		moduleDir := filepath.Dir(pkg.Locations[0])
+		work := findGoWorkspace(moduleDir, workspaces)
+		if work == nil || !slices.Contains(work.members, moduleDir) {
+			continue
+		}
+
+		resolved[pkg] = work.resolve(pkg.Name, pkg.Version)
+	}
+
+	return resolved
+}
+
+// findGoWorkspace returns the workspace that the module in dir is built in, if any
+func findGoWorkspace(dir string, workspaces map[string]*goWorkspace) *goWorkspace {
+	if work, ok := workspaces[dir]; ok {
+		return work
+	}
+
+	path := filepath.Join(dir, "go.work")
+	work, err := parseGoWorkspace(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			cmdlogger.Warnf("Failed to parse %s: %s", path, err)
+		}
+
+		work = nil
+		if parent := filepath.Dir(dir); parent != dir {
+			work = findGoWorkspace(parent, workspaces)
+		}
+	}
+
+	workspaces[dir] = work
+
+	return work
+}
+
+// parseGoWorkspace parses the go.work file at path, and the go.mod file of each of its members
+func parseGoWorkspace(path string) (*goWorkspace, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := modfile.ParseWork(path, b, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	work := &goWorkspace{
+		path:     path,
+		file:     file,
+		selected: make(map[string]string),
+	}
+
+	if file.Go != nil {
+		work.selectVersion("stdlib", file.Go.Version)
+	}
+
+	for _, use := range file.Use {
+		member := use.Path
+		if !filepath.IsAbs(member) {
+			member = filepath.Join(filepath.Dir(path), member)
+		}
+		member = filepath.Clean(member)
+		work.members = append(work.members, member)
+
+		modulePath, requirements, err := parseGoModRequirements(filepath.Join(member, "go.mod"))
+		if err != nil {
+			cmdlogger.Warnf("Failed to parse go.mod of Go workspace member %s: %s", member, err)
+			continue
+		}
+
+		work.modules = append(work.modules, modulePath)
+		for name, version := range requirements {
+			work.selectVersion(name, version)
+		}
+	}
+
+	return work, nil
+}
+
+// selectVersion records that a member requires the given version of a module, keeping the
+// highest version required by any member as that is what minimal version selection picks
+func (work *goWorkspace) selectVersion(name, version string) {
+	current, ok := work.selected[name]
+	if !ok || current == "" || (version != "" && semver.Compare("v"+version, "v"+current) > 0) {
+		work.selected[name] = version
+	}
+}
+
+// resolve returns what the workspace builds for the given version of a module that
+// is required by one of its members
+func (work *goWorkspace) resolve(name, version string) *models.GoWorkspace {
+	result := &models.GoWorkspace{Path: work.path}
+
+	// the go command always builds the members of a workspace from their directories
+	if slices.Contains(work.modules, name) {
+		return result
+	}
+
+	selected := work.selected[name]
+
+	// replacements in the go.work file override those of the members
+	for _, replace := range work.file.Replace {
+		if replace.Old.Path != name || (replace.Old.Version != "" && strings.TrimPrefix(replace.Old.Version, "v") != selected) {
+			continue
+		}
+
+		if replace.New.Path != name || replace.New.Version == "" {
+			return result
+		}
+
+		selected = strings.TrimPrefix(replace.New.Version, "v")
+	}
+
+	result.SelectedVersion = selected
+	result.Selected = selected != "" && selected == version
+
+	return result
+}
+
+// parseGoModRequirements returns the path of the module of the go.mod file at path, along
+// with the version of each module that it requires after applying its replacements,
+// in the same way as the go.mod extractor.
+//
+// The version of the Go standard library is also returned, as the module "stdlib".
+func parseGoModRequirements(path string) (string, map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	file, err := modfile.Parse(path, b, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	requirements := make(map[string]string)
+	for _, require := range file.Require {
+		name, version := require.Mod.Path, strings.TrimPrefix(require.Mod.Version, "v")
+
+		for _, replace := range file.Replace {
+			if replace.Old.Path == name && (replace.Old.Version == "" || strings.TrimPrefix(replace.Old.Version, "v") == version) {
+				name, version = replace.New.Path, strings.TrimPrefix(replace.New.Version, "v")
+
+				break
+			}
+		}
+
+		requirements[name] = version
+	}
+
+	switch {
+	case file.Toolchain != nil && file.Toolchain.Name != "":
+		v, _, _ := strings.Cut(file.Toolchain.Name, "-")
+		requirements["stdlib"] = strings.TrimPrefix(v, "go")
+	case file.Go != nil && file.Go.Version != "":
+		requirements["stdlib"] = file.Go.Version
+	}
+
+	modulePath := ""
+	if file.Module != nil {
+		modulePath = file.Module.Mod.Path
+	}
+
+	return modulePath, requirements, nil
+}
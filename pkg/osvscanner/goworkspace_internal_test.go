@@ -0,0 +1,126 @@
+package osvscanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/language/golang/gomod"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func writeGoWorkspace(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func Test_resolveGoWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	dir := writeGoWorkspace(t, map[string]string{
+		"go.work": "go 1.22.0\n\nuse (\n\t./api\n\t./worker\n)\n\nreplace golang.org/x/crypto => golang.org/x/crypto v0.31.0\n",
+		"api/go.mod": "module example.com/api\n\ngo 1.21\n\nrequire (\n" +
+			"\tgolang.org/x/net v0.20.0\n" +
+			"\tgolang.org/x/crypto v0.17.0\n" +
+			"\texample.com/worker v1.0.0\n)\n",
+		"worker/go.mod": "module example.com/worker\n\ngo 1.22.0\n\nrequire golang.org/x/net v0.23.0\n",
+		"other/go.mod":  "module example.com/other\n\ngo 1.22.0\n\nrequire golang.org/x/net v0.20.0\n",
+	})
+
+	goModPkg := func(module, name, version string) *extractor.Package {
+		return &extractor.Package{
+			Name:      name,
+			Version:   version,
+			Locations: []string{filepath.Join(dir, module, "go.mod")},
+			Plugins:   []string{gomod.Name},
+		}
+	}
+
+	apiNet := goModPkg("api", "golang.org/x/net", "0.20.0")
+	apiCrypto := goModPkg("api", "golang.org/x/crypto", "0.17.0")
+	apiWorker := goModPkg("api", "example.com/worker", "1.0.0")
+	apiStdlib := goModPkg("api", "stdlib", "1.21")
+	workerNet := goModPkg("worker", "golang.org/x/net", "0.23.0")
+	otherNet := goModPkg("other", "golang.org/x/net", "0.20.0")
+
+	got := resolveGoWorkspaces([]*extractor.Package{apiNet, apiCrypto, apiWorker, apiStdlib, workerNet, otherNet})
+
+	workPath := filepath.Join(dir, "go.work")
+	want := map[*extractor.Package]*models.GoWorkspace{
+		apiNet:    {Path: workPath, SelectedVersion: "0.23.0"},
+		apiCrypto: {Path: workPath, SelectedVersion: "0.31.0"},
+		apiWorker: {Path: workPath},
+		apiStdlib: {Path: workPath, SelectedVersion: "1.22.0"},
+		workerNet: {Path: workPath, Selected: true, SelectedVersion: "0.23.0"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resolveGoWorkspaces() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_goWorkspaceMemberPaths(t *testing.T) {
+	t.Parallel()
+
+	dir := writeGoWorkspace(t, map[string]string{
+		"workspace/go.work":     "go 1.22.0\n\nuse (\n\t.\n\t./api\n\t../shared\n\t./missing\n)\n",
+		"workspace/go.mod":      "module example.com/root\n",
+		"workspace/api/go.mod":  "module example.com/api\n",
+		"shared/go.mod":         "module example.com/shared\n",
+		"standalone/go.mod":     "module example.com/standalone\n",
+		"standalone/sub/go.mod": "module example.com/sub\n",
+	})
+
+	tests := []struct {
+		name      string
+		dir       string
+		recursive bool
+		want      []string
+	}{
+		{
+			name: "not_recursive",
+			dir:  filepath.Join(dir, "workspace"),
+			want: []string{
+				filepath.Join(dir, "workspace", "api", "go.mod"),
+				filepath.Join(dir, "shared", "go.mod"),
+			},
+		},
+		{
+			name:      "recursive",
+			dir:       filepath.Join(dir, "workspace"),
+			recursive: true,
+			want:      []string{filepath.Join(dir, "shared", "go.mod")},
+		},
+		{
+			name: "no_workspace",
+			dir:  filepath.Join(dir, "standalone"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := goWorkspaceMemberPaths(tt.dir, tt.recursive)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("goWorkspaceMemberPaths() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
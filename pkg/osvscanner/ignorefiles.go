@@ -0,0 +1,95 @@
+package osvscanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/customgitignore"
+)
+
+// readScannerIgnoreFiles reads the .osv-scanner-ignore files of the given directories,
+// unless ignore files are not being respected. Paths that are files rather than
+// directories are skipped, as they have been explicitly given to be scanned.
+func readScannerIgnoreFiles(paths []string, actions ScannerActions) ([]customgitignore.IgnoreFiles, error) {
+	if actions.NoIgnore {
+		return nil, nil
+	}
+
+	var ignores []customgitignore.IgnoreFiles
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			continue
+		}
+
+		ignore, err := customgitignore.ParseIgnoreFiles(path, customgitignore.ScannerIgnoreFile, actions.Recursive)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(ignore.Patterns) == 0 {
+			continue
+		}
+
+		for _, dir := range ignore.IgnoredDirs {
+			cmdlogger.Infof("Skipping %s as it is ignored by %s", dir, customgitignore.ScannerIgnoreFile)
+		}
+
+		ignores = append(ignores, ignore)
+	}
+
+	return ignores, nil
+}
+
+// ignoredDirs returns the directories that are ignored by the ignore files,
+// for the walk to skip entirely
+func ignoredDirs(ignores []customgitignore.IgnoreFiles) []string {
+	var dirs []string
+	for _, ignore := range ignores {
+		dirs = append(dirs, ignore.IgnoredDirs...)
+	}
+
+	return dirs
+}
+
+// skipIgnoredFiles wraps the extractors so that files ignored by the ignore files
+// are not extracted, with root being the root of the scan that paths are relative to
+func skipIgnoredFiles(extractors []filesystem.Extractor, root string, ignores []customgitignore.IgnoreFiles) []filesystem.Extractor {
+	if len(ignores) == 0 {
+		return extractors
+	}
+
+	wrapped := make([]filesystem.Extractor, 0, len(extractors))
+	for _, ext := range extractors {
+		wrapped = append(wrapped, ignoringExtractor{Extractor: ext, root: root, ignores: ignores})
+	}
+
+	return wrapped
+}
+
+// ignoringExtractor is an extractor that does not require files that are ignored
+type ignoringExtractor struct {
+	filesystem.Extractor
+
+	root    string
+	ignores []customgitignore.IgnoreFiles
+}
+
+func (e ignoringExtractor) FileRequired(api filesystem.FileAPI) bool {
+	// ignored directories are skipped by the walk, so only files need to be matched
+	path := filepath.Join(e.root, filepath.FromSlash(api.Path()))
+	for _, ignore := range e.ignores {
+		if ignore.Match(path, false) {
+			return false
+		}
+	}
+
+	return e.Extractor.FileRequired(api)
+}
@@ -0,0 +1,97 @@
+package osvscanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/customgitignore"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func Test_scan_ScannerIgnoreFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		customgitignore.ScannerIgnoreFile: "build/\nlegacy/go.mod\n",
+		"go.mod":                          "module example.com/app\n\ngo 1.21\n",
+		"build/go.mod":                    "module example.com/build\n\ngo 1.21\n",
+		"legacy/go.mod":                   "module example.com/legacy\n\ngo 1.21\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		noIgnore    bool
+		want        []string
+		wantSkipped []string
+	}{
+		{
+			name:        "respecting_ignore_files",
+			want:        []string{filepath.Join(dir, "go.mod")},
+			wantSkipped: []string{filepath.Join(dir, "build")},
+		},
+		{
+			name:     "no_ignore",
+			noIgnore: true,
+			want: []string{
+				filepath.Join(dir, "build", "go.mod"),
+				filepath.Join(dir, "go.mod"),
+				filepath.Join(dir, "legacy", "go.mod"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statsCollector := newExtractorStatsCollector(context.Background(), false, nil)
+			pkgs, err := scan(context.Background(), ExternalAccessors{}, ScannerActions{
+				DirectoryPaths: []string{dir},
+				Recursive:      true,
+				NoIgnore:       tt.noIgnore,
+			}, statsCollector)
+			if err != nil {
+				t.Fatalf("scan() error = %v", err)
+			}
+
+			var got []string
+			for _, pkg := range pkgs {
+				if !slices.Contains(got, pkg.PackageInfo.Location()) {
+					got = append(got, pkg.PackageInfo.Location())
+				}
+			}
+			slices.Sort(got)
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("scan() locations mismatch (-want +got):\n%s", diff)
+			}
+
+			var gotSkipped []string
+			for _, d := range statsCollector.Diagnostics() {
+				if d.Kind == models.DiagnosticSkippedFile {
+					gotSkipped = append(gotSkipped, d.Path)
+				}
+			}
+
+			if diff := cmp.Diff(tt.wantSkipped, gotSkipped); diff != "" {
+				t.Errorf("scan() skipped files mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package osvscanner
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/google/osv-scanner/v2/internal/clients/clientinterfaces"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/internal/integrity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxConcurrentIntegrityRequests is how many packages have their hashes looked up at once
+const maxConcurrentIntegrityRequests = 8
+
+// recordedHashes are the hashes recorded by a lockfile for a package
+type recordedHashes struct {
+	pkg    models.PackageInfo
+	source models.SourceInfo
+	hashes []integrity.Hash
+}
+
+// verifyIntegrity compares the hashes recorded by the lockfiles of the scanned packages
+// against the hashes published by the registries of the packages, returning the recorded
+// hashes that do not match; packages from lockfiles that do not record hashes, and that
+// are not known to their registry, are not verified
+func verifyIntegrity(ctx context.Context, matcher clientinterfaces.IntegrityMatcher, scanResults *results.ScanResults) ([]models.IntegrityMismatch, error) {
+	recorded := collectRecordedHashes(scanResults)
+
+	var mu sync.Mutex
+	var mismatches []models.IntegrityMismatch
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentIntegrityRequests)
+
+	for _, r := range recorded {
+		g.Go(func() error {
+			published, err := matcher.MatchIntegrity(ctx, r.pkg)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, hash := range integrity.Mismatches(r.hashes, published) {
+				mismatches = append(mismatches, newIntegrityMismatch(r, hash, published))
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(mismatches, func(a, b models.IntegrityMismatch) int {
+		return cmp.Or(
+			cmp.Compare(a.Source.Path, b.Source.Path),
+			cmp.Compare(a.Package.Name, b.Package.Name),
+			cmp.Compare(a.Package.Version, b.Package.Version),
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.LockfileHash, b.LockfileHash),
+		)
+	})
+
+	return mismatches, nil
+}
+
+// collectRecordedHashes reads the hashes that the lockfiles of the scanned packages record
+// for each of them, reading each lockfile only once
+func collectRecordedHashes(scanResults *results.ScanResults) []recordedHashes {
+	lockfiles := make(map[string]*integrity.Lockfile)
+	seen := make(map[recordedKey]bool)
+	var recorded []recordedHashes
+
+	for _, psr := range scanResults.PackageScanResults {
+		p := psr.PackageInfo
+
+		hashesPath, ok := integrity.HashesPath(p.Location())
+		if !ok {
+			continue
+		}
+
+		lockfile, read := lockfiles[hashesPath]
+		if !read {
+			l, err := integrity.Read(hashesPath)
+			switch {
+			case err == nil:
+				lockfile = &l
+			case errors.Is(err, fs.ErrNotExist):
+				// such as go.mod files without a go.sum, which have no hashes to verify
+			default:
+				cmdlogger.Warnf("Failed to read the hashes of packages from %s: %s", hashesPath, err)
+			}
+			lockfiles[hashesPath] = lockfile
+		}
+
+		if lockfile == nil || lockfile.Ecosystem != p.Ecosystem().Ecosystem {
+			continue
+		}
+
+		key := recordedKey{path: hashesPath, name: p.Name(), version: p.Version()}
+		hashes := lockfile.Hashes(p.Name(), p.Version())
+		if len(hashes) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		recorded = append(recorded, recordedHashes{
+			pkg: models.PackageInfo{
+				Name:      p.Name(),
+				Version:   p.Version(),
+				Ecosystem: p.Ecosystem().String(),
+			},
+			source: models.SourceInfo{
+				Path: filepath.ToSlash(hashesPath),
+				Type: p.SourceType(),
+			},
+			hashes: hashes,
+		})
+	}
+
+	return recorded
+}
+
+type recordedKey struct {
+	path    string
+	name    string
+	version string
+}
+
+func newIntegrityMismatch(r recordedHashes, hash integrity.Hash, published []integrity.Hash) models.IntegrityMismatch {
+	mismatch := models.IntegrityMismatch{
+		Package:        r.pkg,
+		Source:         r.source,
+		File:           hash.File,
+		LockfileHash:   hash.Value,
+		RegistryHashes: []string{},
+	}
+
+	for _, p := range published {
+		if p.File == hash.File && p.Algorithm() == hash.Algorithm() {
+			mismatch.RegistryHashes = append(mismatch.RegistryHashes, p.Value)
+		}
+	}
+
+	return mismatch
+}
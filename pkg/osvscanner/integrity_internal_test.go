@@ -0,0 +1,87 @@
+package osvscanner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/internal/integrity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+type fakeIntegrityMatcher map[string][]integrity.Hash
+
+func (m fakeIntegrityMatcher) MatchIntegrity(_ context.Context, pkg models.PackageInfo) ([]integrity.Hash, error) {
+	return m[pkg.Name], nil
+}
+
+func Test_verifyIntegrity(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	lockfilePath := filepath.Join(dir, "package-lock.json")
+	lockfile := `{
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "my-app"},
+			"node_modules/lodash": {"version": "4.17.21", "integrity": "sha512-tampered=="},
+			"node_modules/wrappy": {"version": "1.0.2", "integrity": "sha512-wrappy=="},
+			"node_modules/private": {"version": "1.0.0", "integrity": "sha512-private=="}
+		}
+	}`
+	if err := os.WriteFile(lockfilePath, []byte(lockfile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// go.mod files without a go.sum have no hashes to verify
+	goModPath := filepath.Join(dir, "go.mod")
+
+	newPackage := func(name, version, purlType, location string) imodels.PackageScanResult {
+		return imodels.PackageScanResult{
+			PackageInfo: imodels.FromInventory(&extractor.Package{
+				Name:      name,
+				Version:   version,
+				PURLType:  purlType,
+				Locations: []string{location},
+				Plugins:   []string{"javascript/packagelockjson"},
+			}),
+		}
+	}
+
+	scanResults := results.ScanResults{
+		PackageScanResults: []imodels.PackageScanResult{
+			newPackage("lodash", "4.17.21", "npm", lockfilePath),
+			newPackage("wrappy", "1.0.2", "npm", lockfilePath),
+			newPackage("private", "1.0.0", "npm", lockfilePath),
+			newPackage("golang.org/x/text", "0.14.0", "golang", goModPath),
+		},
+	}
+
+	matcher := fakeIntegrityMatcher{
+		"lodash": {{Value: "sha512-lodash=="}, {Value: "sha1-lodash="}},
+		"wrappy": {{Value: "sha512-wrappy=="}},
+	}
+
+	got, err := verifyIntegrity(t.Context(), matcher, &scanResults)
+	if err != nil {
+		t.Fatalf("verifyIntegrity() error = %v", err)
+	}
+
+	want := []models.IntegrityMismatch{
+		{
+			Package:        models.PackageInfo{Name: "lodash", Version: "4.17.21", Ecosystem: "npm"},
+			Source:         models.SourceInfo{Path: filepath.ToSlash(lockfilePath), Type: models.SourceTypeProjectPackage},
+			LockfileHash:   "sha512-tampered==",
+			RegistryHashes: []string{"sha512-lodash=="},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("verifyIntegrity() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,184 @@
+package imagehelpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/google/osv-scalibr/artifact/image/layerscanning/image"
+	"github.com/google/osv-scanner/v2/internal/cachedregexp"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// UnknownBaseImageName is used as the name of base images that are identified
+// from the history of the image, rather than by matching a known image
+const UnknownBaseImageName = "unknown (from image history)"
+
+// BaseImageHint is an image that the user has said the scanned image was built from
+type BaseImageHint struct {
+	Name   string
+	Layers []models.LayerMetadata
+}
+
+// LoadBaseImageHint reads the layers of the given base image, which can either be
+// the path to a local image archive or the name of an image to export with docker or containerd.
+func LoadBaseImageHint(ctx context.Context, ref string) (*BaseImageHint, error) {
+	path := ref
+	if _, err := os.Stat(ref); err != nil {
+		exportPath, err := ExportImage(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(exportPath)
+
+		path = exportPath
+	}
+
+	img, err := image.FromTarball(path, image.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base image %q: %w", ref, err)
+	}
+	defer func() {
+		if err := img.CleanUp(); err != nil {
+			cmdlogger.Errorf("Failed to clean up base image: %s", err)
+		}
+	}()
+
+	chainLayers, err := img.ChainLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	hint := &BaseImageHint{Name: ref}
+	for _, cl := range chainLayers {
+		hint.Layers = append(hint.Layers, models.LayerMetadata{
+			DiffID:  cl.Layer().DiffID(),
+			Command: cl.Layer().Command(),
+			IsEmpty: cl.Layer().IsEmpty(),
+		})
+	}
+
+	return hint, nil
+}
+
+// errNotBuiltFromBaseImage is returned when the layers of the hinted base image
+// are not the first layers of the scanned image
+var errNotBuiltFromBaseImage = errors.New("image does not start with the layers of the base image")
+
+// applyBaseImageHint attributes the layers that the image shares with the hinted
+// base image to that base image, as the closest base image of the scanned image.
+//
+// Base images matched within those layers are kept as the base images of the hinted
+// base image, while any matched in the remaining layers are removed, as those layers
+// must have been added on top of the hinted base image.
+func applyBaseImageHint(layerMetadata []models.LayerMetadata, baseImages [][]models.BaseImageDetails, hint BaseImageHint) ([][]models.BaseImageDetails, error) {
+	if len(hint.Layers) > len(layerMetadata) {
+		return baseImages, errNotBuiltFromBaseImage
+	}
+
+	for i, l := range hint.Layers {
+		if l.IsEmpty != layerMetadata[i].IsEmpty || (!l.IsEmpty && l.DiffID != layerMetadata[i].DiffID) {
+			return baseImages, errNotBuiltFromBaseImage
+		}
+	}
+
+	for i := range layerMetadata {
+		switch {
+		case i >= len(hint.Layers):
+			layerMetadata[i].BaseImageIndex = 0
+		case layerMetadata[i].BaseImageIndex == 0:
+			layerMetadata[i].BaseImageIndex = 1
+		default:
+			layerMetadata[i].BaseImageIndex++
+		}
+	}
+
+	baseImages = slices.Insert(slices.Clone(baseImages), 1, []models.BaseImageDetails{{Name: hint.Name}})
+
+	return removeUnusedBaseImages(layerMetadata, baseImages), nil
+}
+
+// applyHistoryHeuristic attributes layers to an unknown base image when no base
+// image could otherwise be identified, by assuming that the base image ends with
+// the last CMD or ENTRYPOINT instruction that is followed by a layer with content,
+// as base images typically set a default command that derived images override.
+func applyHistoryHeuristic(layerMetadata []models.LayerMetadata, baseImages [][]models.BaseImageDetails) [][]models.BaseImageDetails {
+	boundary := -1
+	hasContentAfter := false
+
+	for i, l := range slices.Backward(layerMetadata) {
+		if hasContentAfter && isDefaultCommandInstruction(l.Command) {
+			boundary = i
+			break
+		}
+
+		if !l.IsEmpty {
+			hasContentAfter = true
+		}
+	}
+
+	if boundary == -1 {
+		return baseImages
+	}
+
+	for i := range layerMetadata[:boundary+1] {
+		layerMetadata[i].BaseImageIndex = 1
+	}
+
+	return append(baseImages, []models.BaseImageDetails{{Name: UnknownBaseImageName}})
+}
+
+func isDefaultCommandInstruction(command string) bool {
+	// commands are either recorded as-is by BuildKit, or as a "#(nop)" shell command by the legacy builder
+	return cachedregexp.MustCompile(`^(?:/bin/sh -c #\(nop\)\s+)?(?:CMD|ENTRYPOINT)\b`).MatchString(command)
+}
+
+// removeUnusedBaseImages removes base images that no layers belong to,
+// updating the base image index of each layer to match
+func removeUnusedBaseImages(layerMetadata []models.LayerMetadata, baseImages [][]models.BaseImageDetails) [][]models.BaseImageDetails {
+	used := make([]bool, len(baseImages))
+	// index 0 is always kept, as it represents the scanned image itself
+	used[0] = true
+	for _, l := range layerMetadata {
+		used[l.BaseImageIndex] = true
+	}
+
+	newIndex := make([]int, len(baseImages))
+	kept := make([][]models.BaseImageDetails, 0, len(baseImages))
+	for i, baseImage := range baseImages {
+		if !used[i] {
+			continue
+		}
+		newIndex[i] = len(kept)
+		kept = append(kept, baseImage)
+	}
+
+	for i := range layerMetadata {
+		layerMetadata[i].BaseImageIndex = newIndex[layerMetadata[i].BaseImageIndex]
+	}
+
+	return kept
+}
+
+// attributeBaseImages identifies the layers of the image that come from a base image,
+// preferring the hinted base image if there is one, then any base images matched from
+// known images, and finally falling back to heuristics based on the image history.
+func attributeBaseImages(layerMetadata []models.LayerMetadata, baseImages [][]models.BaseImageDetails, hint *BaseImageHint) [][]models.BaseImageDetails {
+	if hint != nil {
+		attributed, err := applyBaseImageHint(layerMetadata, baseImages, *hint)
+		if err == nil {
+			return attributed
+		}
+
+		cmdlogger.Warnf("Ignoring base image %q: %s", hint.Name, err)
+	}
+
+	if len(baseImages) > 1 {
+		return baseImages
+	}
+
+	return applyHistoryHeuristic(layerMetadata, baseImages)
+}
@@ -0,0 +1,133 @@
+package imagehelpers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/opencontainers/go-digest"
+)
+
+func layers(specs ...string) []models.LayerMetadata {
+	metadata := make([]models.LayerMetadata, 0, len(specs))
+	for _, spec := range specs {
+		if spec[0] == '#' {
+			metadata = append(metadata, models.LayerMetadata{Command: spec[1:], IsEmpty: true})
+			continue
+		}
+		metadata = append(metadata, models.LayerMetadata{DiffID: digest.Digest("sha256:" + spec), Command: "RUN " + spec})
+	}
+
+	return metadata
+}
+
+func baseImageIndexes(layerMetadata []models.LayerMetadata) []int {
+	indexes := make([]int, 0, len(layerMetadata))
+	for _, l := range layerMetadata {
+		indexes = append(indexes, l.BaseImageIndex)
+	}
+
+	return indexes
+}
+
+func TestAttributeBaseImages(t *testing.T) {
+	t.Parallel()
+
+	noBaseImages := [][]models.BaseImageDetails{{}}
+
+	tests := []struct {
+		name           string
+		layers         []models.LayerMetadata
+		matched        []int
+		baseImages     [][]models.BaseImageDetails
+		hint           *BaseImageHint
+		wantIndexes    []int
+		wantBaseImages [][]models.BaseImageDetails
+	}{
+		{
+			name:           "matched_base_images_are_kept",
+			layers:         layers("a", "#CMD [\"sh\"]", "b"),
+			matched:        []int{1, 1, 0},
+			baseImages:     [][]models.BaseImageDetails{{}, {{Name: "alpine"}}},
+			wantIndexes:    []int{1, 1, 0},
+			wantBaseImages: [][]models.BaseImageDetails{{}, {{Name: "alpine"}}},
+		},
+		{
+			name:           "history_with_buildkit_commands",
+			layers:         layers("a", "#CMD [\"sh\"]", "#WORKDIR /app", "b"),
+			baseImages:     noBaseImages,
+			wantIndexes:    []int{1, 1, 0, 0},
+			wantBaseImages: [][]models.BaseImageDetails{{}, {{Name: UnknownBaseImageName}}},
+		},
+		{
+			name:           "history_with_legacy_builder_commands",
+			layers:         layers("a", "#/bin/sh -c #(nop)  ENTRYPOINT [\"/init\"]", "b", "#/bin/sh -c #(nop)  CMD [\"app\"]"),
+			baseImages:     noBaseImages,
+			wantIndexes:    []int{1, 1, 0, 0},
+			wantBaseImages: [][]models.BaseImageDetails{{}, {{Name: UnknownBaseImageName}}},
+		},
+		{
+			name:           "history_without_default_command",
+			layers:         layers("a", "b", "#CMD [\"app\"]"),
+			baseImages:     noBaseImages,
+			wantIndexes:    []int{0, 0, 0},
+			wantBaseImages: noBaseImages,
+		},
+		{
+			name:       "hint_is_closest_base_image",
+			layers:     layers("a", "b", "#CMD [\"sh\"]", "c"),
+			matched:    []int{1, 0, 0, 0},
+			baseImages: [][]models.BaseImageDetails{{}, {{Name: "debian"}}},
+			hint: &BaseImageHint{
+				Name:   "example/base:1",
+				Layers: layers("a", "b", "#CMD [\"sh\"]"),
+			},
+			wantIndexes:    []int{2, 1, 1, 0},
+			wantBaseImages: [][]models.BaseImageDetails{{}, {{Name: "example/base:1"}}, {{Name: "debian"}}},
+		},
+		{
+			name:       "hint_removes_base_images_matched_after_it",
+			layers:     layers("a", "b", "c"),
+			matched:    []int{2, 1, 0},
+			baseImages: [][]models.BaseImageDetails{{}, {{Name: "example/app"}}, {{Name: "debian"}}},
+			hint: &BaseImageHint{
+				Name:   "debian:12",
+				Layers: layers("a"),
+			},
+			// the hint has no layers of its own, so only the matched base image is kept
+			wantIndexes:    []int{1, 0, 0},
+			wantBaseImages: [][]models.BaseImageDetails{{}, {{Name: "debian"}}},
+		},
+		{
+			name:       "hint_that_does_not_match_is_ignored",
+			layers:     layers("a", "#CMD [\"sh\"]", "b"),
+			baseImages: noBaseImages,
+			hint: &BaseImageHint{
+				Name:   "example/other:1",
+				Layers: layers("z"),
+			},
+			wantIndexes:    []int{1, 1, 0},
+			wantBaseImages: [][]models.BaseImageDetails{{}, {{Name: UnknownBaseImageName}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			for i, index := range tt.matched {
+				tt.layers[i].BaseImageIndex = index
+			}
+
+			got := attributeBaseImages(tt.layers, tt.baseImages, tt.hint)
+
+			if diff := cmp.Diff(tt.wantBaseImages, got); diff != "" {
+				t.Errorf("attributeBaseImages() base images mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantIndexes, baseImageIndexes(tt.layers)); diff != "" {
+				t.Errorf("attributeBaseImages() layer indexes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
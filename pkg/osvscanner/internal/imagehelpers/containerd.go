@@ -0,0 +1,152 @@
+package imagehelpers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/images/archive"
+	"github.com/containerd/containerd/v2/defaults"
+	"github.com/containerd/containerd/v2/pkg/identifiers"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+)
+
+// ContainerdScheme is the prefix of image names that are read from the content store
+// of containerd, in the form of "containerd://<namespace>/<image name>"
+const ContainerdScheme = "containerd://"
+
+// containerdAddressEnvVar is the environment variable that overrides the address of
+// the containerd socket, matching the variable used by the ctr and nerdctl clients
+const containerdAddressEnvVar = "CONTAINERD_ADDRESS"
+
+// IsContainerdImage returns true if the image name refers to an image in containerd
+func IsContainerdImage(imageName string) bool {
+	return strings.HasPrefix(imageName, ContainerdScheme)
+}
+
+// ExportImage exports an image to a temporary file, either from containerd if the name
+// starts with ContainerdScheme, from Podman if it starts with PodmanScheme, or otherwise
+// using the docker binary.
+//
+// If ExportImage does not error, the temporary file needs to be cleaned up by the caller.
+func ExportImage(ctx context.Context, imageName string) (string, error) {
+	if IsContainerdImage(imageName) {
+		return ExportContainerdImage(ctx, imageName)
+	}
+
+	if IsPodmanImage(imageName) {
+		return ExportPodmanImage(ctx, imageName)
+	}
+
+	return ExportDockerImage(ctx, imageName)
+}
+
+// parseContainerdImage splits a "containerd://<namespace>/<image name>" reference
+// into the namespace and name of the image
+func parseContainerdImage(imageName string) (string, string, error) {
+	rest, ok := strings.CutPrefix(imageName, ContainerdScheme)
+	if !ok {
+		return "", "", fmt.Errorf("%q is not a containerd image reference", imageName)
+	}
+
+	namespace, name, ok := strings.Cut(rest, "/")
+	if !ok || namespace == "" || name == "" {
+		return "", "", fmt.Errorf("%q must be in the form of %s<namespace>/<image name>", imageName, ContainerdScheme)
+	}
+
+	if err := identifiers.Validate(namespace); err != nil {
+		return "", "", fmt.Errorf("%q has an invalid namespace: %w", imageName, err)
+	}
+
+	return namespace, name, nil
+}
+
+// ExportContainerdImage exports an image from the content store of containerd to a
+// temporary file, using the content of the platform that the scanner is running on.
+//
+// Unlike ExportDockerImage, images are never pulled, as the image is expected to
+// already be present on the node, such as when it is used by a running container.
+//
+// If ExportContainerdImage does not error, the temporary file needs to be cleaned up by the caller, otherwise,
+// it will be cleaned automatically by this function.
+func ExportContainerdImage(ctx context.Context, imageName string) (string, error) {
+	namespace, name, err := parseContainerdImage(imageName)
+	if err != nil {
+		return "", err
+	}
+
+	address := os.Getenv(containerdAddressEnvVar)
+	if address == "" {
+		address = defaults.DefaultAddress
+	}
+
+	client, err := containerd.New(address, containerd.WithDefaultNamespace(namespace))
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to containerd at %s: %w", address, err)
+	}
+	defer client.Close()
+
+	ctx = namespaces.WithNamespace(ctx, namespace)
+
+	img, err := findContainerdImage(ctx, client.ImageService(), name)
+	if err != nil {
+		return "", err
+	}
+
+	tempImageFile, err := os.CreateTemp("", "containerd-image-*.tar")
+	if err != nil {
+		cmdlogger.Errorf("Failed to create temporary file: %s", err)
+		return "", err
+	}
+
+	cmdlogger.Infof("Saving containerd image (%q) from namespace %q to temporary file...", img.Name, namespace)
+	err = client.Export(
+		ctx,
+		tempImageFile,
+		archive.WithImage(client.ImageService(), img.Name),
+		archive.WithPlatform(platforms.DefaultStrict()),
+	)
+	if closeErr := tempImageFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(tempImageFile.Name())
+
+		return "", fmt.Errorf("failed to export image %q from containerd: %w", img.Name, err)
+	}
+
+	return tempImageFile.Name(), nil
+}
+
+// findContainerdImage looks up the image by its name as given, and then by its
+// normalized name, as containerd stores images by their fully qualified names
+// (e.g. "docker.io/library/alpine:latest" rather than "alpine:latest")
+func findContainerdImage(ctx context.Context, store images.Store, name string) (images.Image, error) {
+	img, err := store.Get(ctx, name)
+
+	if errdefs.IsNotFound(err) {
+		if named, parseErr := reference.ParseDockerRef(name); parseErr == nil && named.String() != name {
+			img, err = store.Get(ctx, named.String())
+		}
+	}
+
+	if errdefs.IsNotFound(err) {
+		namespace, _ := namespaces.Namespace(ctx)
+
+		return images.Image{}, fmt.Errorf("image %q was not found in containerd namespace %q (images are not pulled, so it must already be present): %w", name, namespace, err)
+	}
+
+	if err != nil {
+		return images.Image{}, fmt.Errorf("failed to look up image %q in containerd: %w", name, err)
+	}
+
+	return img, nil
+}
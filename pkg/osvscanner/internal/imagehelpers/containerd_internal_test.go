@@ -0,0 +1,112 @@
+package imagehelpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/errdefs"
+)
+
+func TestParseContainerdImage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		imageName     string
+		wantNamespace string
+		wantName      string
+		wantErr       bool
+	}{
+		{
+			imageName:     "containerd://default/myimage:tag",
+			wantNamespace: "default",
+			wantName:      "myimage:tag",
+		},
+		{
+			imageName:     "containerd://k8s.io/docker.io/library/alpine:3.20",
+			wantNamespace: "k8s.io",
+			wantName:      "docker.io/library/alpine:3.20",
+		},
+		{
+			imageName:     "containerd://moby/ghcr.io/google/osv-scanner@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			wantNamespace: "moby",
+			wantName:      "ghcr.io/google/osv-scanner@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{imageName: "containerd://myimage:tag", wantErr: true},
+		{imageName: "containerd:///myimage:tag", wantErr: true},
+		{imageName: "containerd://default/", wantErr: true},
+		{imageName: "containerd://Not_Valid!/myimage:tag", wantErr: true},
+		{imageName: "myimage:tag", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.imageName, func(t *testing.T) {
+			t.Parallel()
+
+			namespace, name, err := parseContainerdImage(tt.imageName)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseContainerdImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if namespace != tt.wantNamespace || name != tt.wantName {
+				t.Errorf("parseContainerdImage() = (%q, %q), want (%q, %q)", namespace, name, tt.wantNamespace, tt.wantName)
+			}
+		})
+	}
+}
+
+// fakeImageStore is an images.Store holding images by their name
+type fakeImageStore struct {
+	images.Store
+
+	images map[string]images.Image
+}
+
+func (s fakeImageStore) Get(_ context.Context, name string) (images.Image, error) {
+	img, ok := s.images[name]
+	if !ok {
+		return images.Image{}, errdefs.ErrNotFound
+	}
+
+	return img, nil
+}
+
+func TestFindContainerdImage(t *testing.T) {
+	t.Parallel()
+
+	store := fakeImageStore{images: map[string]images.Image{
+		"docker.io/library/alpine:3.20": {Name: "docker.io/library/alpine:3.20"},
+		"myimage:tag":                   {Name: "myimage:tag"},
+	}}
+
+	tests := []struct {
+		name     string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "docker.io/library/alpine:3.20", wantName: "docker.io/library/alpine:3.20"},
+		{name: "alpine:3.20", wantName: "docker.io/library/alpine:3.20"},
+		{name: "myimage:tag", wantName: "myimage:tag"},
+		{name: "alpine:3.19", wantErr: true},
+	}
+
+	ctx := namespaces.WithNamespace(context.Background(), "default")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			img, err := findContainerdImage(ctx, store, tt.name)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findContainerdImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if img.Name != tt.wantName {
+				t.Errorf("findContainerdImage() = %q, want %q", img.Name, tt.wantName)
+			}
+		})
+	}
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/google/osv-scanner/v2/pkg/models"
 )
 
-func BuildImageMetadata(img *image.Image, baseImageMatcher clientinterfaces.BaseImageMatcher) (*models.ImageMetadata, error) {
+// BuildImageMetadata builds the metadata of the image, including which of its layers
+// come from base images, using the given hint as the closest base image if it is not nil.
+func BuildImageMetadata(ctx context.Context, img *image.Image, baseImageMatcher clientinterfaces.BaseImageMatcher, baseImageHint *BaseImageHint) (*models.ImageMetadata, error) {
 	chainLayers, err := img.ChainLayers()
 	if err != nil {
 		// This is very unlikely, as if this would error we would have failed the initial scan
@@ -39,7 +41,7 @@ func BuildImageMetadata(img *image.Image, baseImageMatcher clientinterfaces.Base
 	var baseImages [][]models.BaseImageDetails
 
 	if baseImageMatcher != nil {
-		baseImages, err = baseImageMatcher.MatchBaseImages(context.Background(), layerMetadata)
+		baseImages, err = baseImageMatcher.MatchBaseImages(ctx, layerMetadata)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query for container base images: %w", err)
 		}
@@ -51,6 +53,8 @@ func BuildImageMetadata(img *image.Image, baseImageMatcher clientinterfaces.Base
 		}
 	}
 
+	baseImages = attributeBaseImages(layerMetadata, baseImages, baseImageHint)
+
 	imgMetadata := models.ImageMetadata{
 		OS:            OS,
 		LayerMetadata: layerMetadata,
@@ -66,7 +70,7 @@ func BuildImageMetadata(img *image.Image, baseImageMatcher clientinterfaces.Base
 // cleaned automatically by this function.
 //
 // ExportDockerImage will first try to locate the image locally, and if not found, attempt to pull the image from the docker registry.
-func ExportDockerImage(dockerImageName string) (string, error) {
+func ExportDockerImage(ctx context.Context, dockerImageName string) (string, error) {
 	tempImageFile, err := os.CreateTemp("", "docker-image-*.tar")
 	if err != nil {
 		cmdlogger.Errorf("Failed to create temporary file: %s", err)
@@ -82,11 +86,11 @@ func ExportDockerImage(dockerImageName string) (string, error) {
 
 	// Check if image exists locally, if not, pull from the cloud.
 	cmdlogger.Infof("Checking if docker image (%q) exists locally...", dockerImageName)
-	cmd := exec.Command("docker", "images", "-q", dockerImageName)
+	cmd := exec.CommandContext(ctx, "docker", "images", "-q", dockerImageName)
 	output, err := cmd.Output()
 	if err != nil || string(output) == "" {
 		cmdlogger.Infof("Image not found locally, pulling docker image (%q)...", dockerImageName)
-		err = runCommandLogError("docker", "pull", "-q", dockerImageName)
+		err = runCommandLogError(ctx, "docker", "pull", "-q", dockerImageName)
 		if err != nil {
 			_ = os.RemoveAll(tempImageFile.Name())
 
@@ -95,7 +99,7 @@ func ExportDockerImage(dockerImageName string) (string, error) {
 	}
 
 	cmdlogger.Infof("Saving docker image (%q) to temporary file...", dockerImageName)
-	err = runCommandLogError("docker", "save", "-o", tempImageFile.Name(), dockerImageName)
+	err = runCommandLogError(ctx, "docker", "save", "-o", tempImageFile.Name(), dockerImageName)
 	if err != nil {
 		_ = os.RemoveAll(tempImageFile.Name())
 
@@ -105,8 +109,8 @@ func ExportDockerImage(dockerImageName string) (string, error) {
 	return tempImageFile.Name(), nil
 }
 
-func runCommandLogError(name string, args ...string) error {
-	cmd := exec.Command(name, args...)
+func runCommandLogError(ctx context.Context, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
 
 	// Get stderr for debugging when docker fails
 	stderr, err := cmd.StderrPipe()
@@ -0,0 +1,156 @@
+package imagehelpers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+)
+
+// PodmanScheme is the prefix of image names that are read from the local storage
+// of Podman, in the form of "podman:<image name>"
+const PodmanScheme = "podman:"
+
+// podmanHostEnvVar is the environment variable that overrides the address of the
+// Podman socket, matching the variable used by the podman remote client
+const podmanHostEnvVar = "CONTAINER_HOST"
+
+// IsPodmanImage returns true if the image name refers to an image in Podman
+func IsPodmanImage(imageName string) bool {
+	return strings.HasPrefix(imageName, PodmanScheme)
+}
+
+// podmanSocketPath returns the path of the socket of the Podman API service, which is
+// either given by CONTAINER_HOST, or is the socket of the rootless service of the
+// current user or of the rootful service, whichever exists first
+func podmanSocketPath(getenv func(string) string, exists func(string) bool) (string, error) {
+	if host := getenv(podmanHostEnvVar); host != "" {
+		path, ok := strings.CutPrefix(host, "unix://")
+		if !ok {
+			return "", fmt.Errorf("%s must be a unix socket, not %q", podmanHostEnvVar, host)
+		}
+
+		return path, nil
+	}
+
+	var candidates []string
+	if runtimeDir := getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		candidates = append(candidates, filepath.Join(runtimeDir, "podman", "podman.sock"))
+	}
+	candidates = append(candidates, "/run/podman/podman.sock")
+
+	for _, candidate := range candidates {
+		if exists(candidate) {
+			return candidate, nil
+		}
+	}
+
+	return "", nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ExportPodmanImage exports an image from the local storage of Podman to a temporary file.
+//
+// The image is read through the socket of the Podman API service if it is running,
+// and otherwise with the podman binary. Like ExportContainerdImage, images are never
+// pulled, as the image is expected to already be present in the local storage.
+//
+// If ExportPodmanImage does not error, the temporary file needs to be cleaned up by the caller, otherwise,
+// it will be cleaned automatically by this function.
+func ExportPodmanImage(ctx context.Context, imageName string) (string, error) {
+	name := strings.TrimPrefix(imageName, PodmanScheme)
+	if name == "" {
+		return "", fmt.Errorf("%q must be in the form of %s<image name>", imageName, PodmanScheme)
+	}
+
+	socket, err := podmanSocketPath(os.Getenv, fileExists)
+	if err != nil {
+		return "", err
+	}
+
+	tempImageFile, err := os.CreateTemp("", "podman-image-*.tar")
+	if err != nil {
+		cmdlogger.Errorf("Failed to create temporary file: %s", err)
+		return "", err
+	}
+
+	if socket != "" {
+		cmdlogger.Infof("Saving podman image (%q) from %s to temporary file...", name, socket)
+		err = exportPodmanImageFromSocket(ctx, socket, name, tempImageFile)
+	} else {
+		cmdlogger.Infof("Saving podman image (%q) to temporary file...", name)
+		err = runCommandLogError(ctx, "podman", "save", "--format", "docker-archive", "-o", tempImageFile.Name(), name)
+	}
+
+	if closeErr := tempImageFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(tempImageFile.Name())
+
+		return "", fmt.Errorf("failed to export image %q from podman: %w", name, err)
+	}
+
+	return tempImageFile.Name(), nil
+}
+
+// exportPodmanImageFromSocket writes the image as a docker archive to w, using the
+// API of the Podman service listening on the given unix socket
+func exportPodmanImageFromSocket(ctx context.Context, socket string, name string, w io.Writer) error {
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+
+	u := url.URL{
+		Scheme:   "http",
+		Host:     "d",
+		Path:     "/v4.0.0/libpod/images/" + name + "/get",
+		RawQuery: url.Values{"format": {"docker-archive"}}.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to podman at %s: %w", socket, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// errors from the API are json objects with the reason in the "message" field
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Message != "" {
+			return errors.New(apiErr.Message)
+		}
+
+		return fmt.Errorf("podman responded with %s", resp.Status)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+
+	return err
+}
@@ -0,0 +1,128 @@
+package imagehelpers
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPodmanSocketPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		existing []string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "container_host",
+			env:      map[string]string{"CONTAINER_HOST": "unix:///tmp/podman.sock", "XDG_RUNTIME_DIR": "/run/user/1000"},
+			existing: []string{"/run/user/1000/podman/podman.sock"},
+			want:     "/tmp/podman.sock",
+		},
+		{
+			name:    "container_host_not_unix",
+			env:     map[string]string{"CONTAINER_HOST": "ssh://user@host/run/podman/podman.sock"},
+			wantErr: true,
+		},
+		{
+			name:     "rootless",
+			env:      map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"},
+			existing: []string{"/run/user/1000/podman/podman.sock", "/run/podman/podman.sock"},
+			want:     "/run/user/1000/podman/podman.sock",
+		},
+		{
+			name:     "rootful",
+			env:      map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"},
+			existing: []string{"/run/podman/podman.sock"},
+			want:     "/run/podman/podman.sock",
+		},
+		{
+			name: "not_running",
+			env:  map[string]string{"XDG_RUNTIME_DIR": "/run/user/1000"},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := podmanSocketPath(
+				func(key string) string { return tt.env[key] },
+				func(path string) bool { return slices.Contains(tt.existing, path) },
+			)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("podmanSocketPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("podmanSocketPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// servePodman serves the handler on a unix socket, returning the path of the socket
+func servePodman(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	// the path of unix sockets is limited in length, which t.TempDir can exceed
+	dir, err := os.MkdirTemp("", "podman")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "podman.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &http.Server{Handler: handler}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	return socket
+}
+
+func TestExportPodmanImageFromSocket(t *testing.T) {
+	t.Parallel()
+
+	socket := servePodman(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4.0.0/libpod/images/localhost/myimage:tag/get" || r.URL.Query().Get("format") != "docker-archive" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"cause":"image not known","message":"failed to find image ` + r.URL.Path + `: image not known","response":404}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte("archive"))
+	}))
+
+	var buf bytes.Buffer
+	if err := exportPodmanImageFromSocket(context.Background(), socket, "localhost/myimage:tag", &buf); err != nil {
+		t.Fatalf("exportPodmanImageFromSocket() error = %v", err)
+	}
+
+	if got := buf.String(); got != "archive" {
+		t.Errorf("exportPodmanImageFromSocket() wrote %q, want %q", got, "archive")
+	}
+
+	err := exportPodmanImageFromSocket(context.Background(), socket, "localhost/missing:tag", &bytes.Buffer{})
+	want := "failed to find image /v4.0.0/libpod/images/localhost/missing:tag/get: image not known"
+
+	if err == nil || err.Error() != want {
+		t.Errorf("exportPodmanImageFromSocket() error = %v, want %q", err, want)
+	}
+}
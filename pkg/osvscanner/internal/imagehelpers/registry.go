@@ -0,0 +1,99 @@
+package imagehelpers
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/osv-scalibr/artifact/image/layerscanning/image"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+)
+
+// RegistryCredentials are the credentials given by the user for the registry of the scanned image
+type RegistryCredentials struct {
+	Username string
+	Password string
+	// Token is an identity token (such as an OAuth2 access token) used instead of a username and password
+	Token string
+}
+
+// IsEmpty returns true if no credentials were given
+func (c RegistryCredentials) IsEmpty() bool {
+	return c.Username == "" && c.Password == "" && c.Token == ""
+}
+
+// registryKeychain resolves the given credentials for the images of a single registry,
+// so that they are never sent to the registries of other images, such as base images
+type registryKeychain struct {
+	registry string
+	auth     authn.Authenticator
+}
+
+func (k registryKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	if target.RegistryStr() != k.registry {
+		return authn.Anonymous, nil
+	}
+
+	return k.auth, nil
+}
+
+// Keychain returns the keychain used to authenticate to the registries of images,
+// which uses the given credentials for the registry of the named image, and otherwise
+// the credentials in the docker config (~/.docker/config.json), including any
+// credential helpers that it configures
+func Keychain(imageName string, creds RegistryCredentials) (authn.Keychain, error) {
+	if creds.IsEmpty() {
+		return authn.DefaultKeychain, nil
+	}
+
+	if creds.Token == "" && (creds.Username == "" || creds.Password == "") {
+		return nil, fmt.Errorf("both a username and password are required to authenticate to the registry of %q", imageName)
+	}
+
+	ref, err := name.ParseReference(imageName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image name %q: %w", imageName, err)
+	}
+
+	cfg := authn.AuthConfig{Username: creds.Username, Password: creds.Password}
+	if creds.Token != "" {
+		cfg = authn.AuthConfig{RegistryToken: creds.Token}
+	}
+
+	return authn.NewMultiKeychain(
+		registryKeychain{registry: ref.Context().RegistryStr(), auth: authn.FromConfig(cfg)},
+		authn.DefaultKeychain,
+	), nil
+}
+
+// ShouldPullFromRegistry returns true if the named image should be pulled directly from
+// its registry rather than exported from a local container runtime, which is the case
+// when credentials for the registry have been given, or when docker is not installed
+func ShouldPullFromRegistry(imageName string, creds RegistryCredentials) bool {
+	if IsContainerdImage(imageName) || IsPodmanImage(imageName) {
+		return false
+	}
+
+	if !creds.IsEmpty() {
+		return true
+	}
+
+	_, err := exec.LookPath("docker")
+
+	return err != nil
+}
+
+// PullImage pulls the named image directly from its registry, authenticating with the given keychain
+func PullImage(ctx context.Context, imageName string, keychain authn.Keychain) (*image.Image, error) {
+	cmdlogger.Infof("Pulling image (%q) from its registry...", imageName)
+
+	img, err := image.FromRemoteName(imageName, image.DefaultConfig(), remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull container image: %w", err)
+	}
+
+	return img, nil
+}
@@ -0,0 +1,102 @@
+package imagehelpers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+func TestKeychain(t *testing.T) {
+	// the docker config is read from the home directory, which would make the
+	// credentials of registries other than that of the image depend on the machine
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+	tests := []struct {
+		name     string
+		image    string
+		creds    RegistryCredentials
+		registry string
+		want     *authn.AuthConfig
+		wantErr  bool
+	}{
+		{
+			name:     "basic_auth_for_registry_of_image",
+			image:    "us-docker.pkg.dev/my-project/my-repo/my-image:1.0.0",
+			creds:    RegistryCredentials{Username: "oauth2accesstoken", Password: "secret"},
+			registry: "us-docker.pkg.dev",
+			want:     &authn.AuthConfig{Username: "oauth2accesstoken", Password: "secret"},
+		},
+		{
+			name:     "token_for_registry_of_image",
+			image:    "myregistry.azurecr.io/my-image:1.0.0",
+			creds:    RegistryCredentials{Token: "secret"},
+			registry: "myregistry.azurecr.io",
+			want:     &authn.AuthConfig{RegistryToken: "secret"},
+		},
+		{
+			name:     "anonymous_for_other_registries",
+			image:    "myregistry.azurecr.io/my-image:1.0.0",
+			creds:    RegistryCredentials{Username: "user", Password: "secret"},
+			registry: "index.docker.io",
+			want:     &authn.AuthConfig{},
+		},
+		{
+			name:     "docker_hub_short_name",
+			image:    "my-org/my-image:1.0.0",
+			creds:    RegistryCredentials{Username: "user", Password: "secret"},
+			registry: "index.docker.io",
+			want:     &authn.AuthConfig{Username: "user", Password: "secret"},
+		},
+		{
+			name:     "no_credentials",
+			image:    "myregistry.azurecr.io/my-image:1.0.0",
+			registry: "myregistry.azurecr.io",
+			want:     &authn.AuthConfig{},
+		},
+		{
+			name:    "username_without_password",
+			image:   "myregistry.azurecr.io/my-image:1.0.0",
+			creds:   RegistryCredentials{Username: "user"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_image_name",
+			image:   "Not An Image",
+			creds:   RegistryCredentials{Token: "secret"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keychain, err := Keychain(tt.image, tt.creds)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Keychain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			registry, err := name.NewRegistry(tt.registry)
+			if err != nil {
+				t.Fatalf("NewRegistry() error = %v", err)
+			}
+
+			auth, err := keychain.Resolve(registry)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+
+			got, err := auth.Authorization()
+			if err != nil {
+				t.Fatalf("Authorization() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Keychain() credentials mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
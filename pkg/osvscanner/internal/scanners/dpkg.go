@@ -0,0 +1,74 @@
+package scanners
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract"
+)
+
+// dpkgDir is where dpkg stores its database, relative to the root of the filesystem
+const dpkgDir = "var/lib/dpkg"
+
+// extractDpkgStatus extracts the packages of a dpkg status file, or of a status.d
+// directory which distroless images use instead to store each package in a separate file
+func extractDpkgStatus(ctx context.Context, path string) ([]*extractor.Package, error) {
+	ext := dpkg.New(dpkg.DefaultConfig())
+	extract := func(p string) ([]*extractor.Package, error) {
+		if root, ok := dpkgRootDir(path); ok {
+			return scalibrextract.ExtractWithExtractorFromRoot(ctx, root, p, ext)
+		}
+
+		return scalibrextract.ExtractWithExtractor(ctx, p, ext)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return extract(path)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []*extractor.Package
+	for _, entry := range entries {
+		// each package can also have a file listing the checksums of its files
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), ".md5sums") {
+			continue
+		}
+
+		entryPkgs, err := extract(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		pkgs = append(pkgs, entryPkgs...)
+	}
+
+	return pkgs, nil
+}
+
+// dpkgRootDir returns the root of the filesystem that the dpkg database at path
+// belongs to, so that the details of the operating system are read from that
+// filesystem rather than the one osv-scanner is running on, returning false
+// if path is not within a dpkg database directory
+func dpkgRootDir(path string) (string, bool) {
+	slashed := filepath.ToSlash(path)
+
+	i := strings.LastIndex(slashed, "/"+dpkgDir+"/")
+	if i < 0 {
+		return "", false
+	}
+
+	return filepath.FromSlash(slashed[:i+1]), true
+}
@@ -0,0 +1,100 @@
+package scanners
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg/metadata"
+)
+
+type dpkgPackage struct {
+	Name        string
+	Version     string
+	SourceName  string
+	OSID        string
+	OSVersionID string
+}
+
+func Test_extractDpkgStatus(t *testing.T) {
+	t.Parallel()
+
+	statusD, err := filepath.Abs(filepath.FromSlash("fixtures/distroless/var/lib/dpkg/status.d"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []dpkgPackage
+	}{
+		{
+			name: "status.d directory",
+			path: statusD,
+			want: []dpkgPackage{
+				{Name: "base-files", Version: "12.4+deb12u5", OSID: "debian", OSVersionID: "12"},
+				{Name: "libssl3", Version: "3.0.11-1~deb12u2", SourceName: "openssl", OSID: "debian", OSVersionID: "12"},
+			},
+		},
+		{
+			name: "single file in status.d",
+			path: filepath.Join(statusD, "libssl3"),
+			want: []dpkgPackage{
+				{Name: "libssl3", Version: "3.0.11-1~deb12u2", SourceName: "openssl", OSID: "debian", OSVersionID: "12"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkgs, err := extractDpkgStatus(context.Background(), tt.path)
+			if err != nil {
+				t.Fatalf("extractDpkgStatus() error = %v", err)
+			}
+
+			got := make([]dpkgPackage, 0, len(pkgs))
+			for _, pkg := range pkgs {
+				m := pkg.Metadata.(*metadata.Metadata)
+				got = append(got, dpkgPackage{
+					Name:        pkg.Name,
+					Version:     pkg.Version,
+					SourceName:  m.SourceName,
+					OSID:        m.OSID,
+					OSVersionID: m.OSVersionID,
+				})
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("extractDpkgStatus() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_dpkgRootDir(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path   string
+		want   string
+		wantOK bool
+	}{
+		{path: "/var/lib/dpkg/status", want: "/", wantOK: true},
+		{path: "/tmp/rootfs/var/lib/dpkg/status.d", want: "/tmp/rootfs/", wantOK: true},
+		{path: "/tmp/rootfs/var/lib/dpkg/status.d/libc6", want: "/tmp/rootfs/", wantOK: true},
+		{path: "/path/to/status", want: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := dpkgRootDir(filepath.FromSlash(tt.path))
+			if got != filepath.FromSlash(tt.want) || ok != tt.wantOK {
+				t.Errorf("dpkgRootDir() = (%q, %v), want (%q, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
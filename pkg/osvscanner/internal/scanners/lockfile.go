@@ -23,23 +23,34 @@ import (
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/bunlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/packagelockjson"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/pnpmlock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/javascript/yarnlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/php/composerlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pdmlock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/pipfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/python/poetrylock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/python/uvlock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/r/renvlock"
-	"github.com/google/osv-scalibr/extractor/filesystem/language/ruby/gemfilelock"
 	"github.com/google/osv-scalibr/extractor/filesystem/language/rust/cargolock"
 	"github.com/google/osv-scalibr/extractor/filesystem/os/apk"
-	"github.com/google/osv-scalibr/extractor/filesystem/os/dpkg"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 	"github.com/google/osv-scanner/v2/internal/output"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/devcontainer"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/conda/environmentyml"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/cmakelists"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/cpp/vcpkg"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/csharp/unitypackages"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/golang/modulestxt"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/java/pomxmlenhanceable"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/java/wrapperproperties"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/javascript/yarnlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/nix/flakelock"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/osv/osvscannerjson"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/php/composerinstalled"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/pylock"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/requirementsenhancable"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/python/uvlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/r/renvlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/ruby/gemfilelock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/language/terraform/terraformlock"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/detect"
 )
 
 var lockfileExtractorMapping = map[string][]string{
@@ -48,6 +59,8 @@ var lockfileExtractorMapping = map[string][]string{
 	"yarn.lock":                   {yarnlock.Name},
 	"package-lock.json":           {packagelockjson.Name},
 	"pom.xml":                     {pomxmlenhanceable.Name},
+	"maven-wrapper.properties":    {wrapperproperties.Name},
+	"gradle-wrapper.properties":   {wrapperproperties.Name},
 	"buildscript-gradle.lockfile": {gradlelockfile.Name},
 	"gradle.lockfile":             {gradlelockfile.Name},
 	"verification-metadata.xml":   {gradleverificationmetadataxml.Name},
@@ -56,26 +69,38 @@ var lockfileExtractorMapping = map[string][]string{
 	"pdm.lock":                    {pdmlock.Name},
 	"requirements.txt":            {requirementsenhancable.Name},
 	"uv.lock":                     {uvlock.Name},
+	"pylock.toml":                 {pylock.Name},
 	"Cargo.lock":                  {cargolock.Name},
 	"composer.lock":               {composerlock.Name},
+	"installed.json":              {composerinstalled.Name},
 	"mix.lock":                    {mixlock.Name},
 	"renv.lock":                   {renvlock.Name},
 	"deps.json":                   {depsjson.Name},
 	"packages.config":             {packagesconfig.Name},
 	"packages.lock.json":          {packageslockjson.Name},
+	"packages-lock.json":          {unitypackages.Name},
 	"conan.lock":                  {conanlock.Name},
+	"vcpkg.json":                  {vcpkg.Name},
+	"CMakeLists.txt":              {cmakelists.Name},
 	"go.mod":                      {gomod.Name},
+	"modules.txt":                 {modulestxt.Name},
 	"bun.lock":                    {bunlock.Name},
 	"Gemfile.lock":                {gemfilelock.Name},
 	"gems.locked":                 {gemfilelock.Name},
 	"cabal.project.freeze":        {cabal.Name},
 	"stack.yaml.lock":             {stacklock.Name},
+	".terraform.lock.hcl":         {terraformlock.Name},
+	"flake.lock":                  {flakelock.Name},
+	"environment.yml":             {environmentyml.Name},
+	"environment.yaml":            {environmentyml.Name},
+	"devcontainer.json":           {devcontainer.Name},
+	".devcontainer.json":          {devcontainer.Name},
 	// "Package.resolved":            {packageresolved.Name},
 }
 
 // ScanSingleFile is similar to ScanSingleFileWithMapping, just without supporting the <lockfileformat>:/path/to/lockfile prefix identifier
-func ScanSingleFile(path string, extractorsToUse []filesystem.Extractor) ([]*extractor.Package, error) {
-	invs, err := scalibrextract.ExtractWithExtractors(context.Background(), path, extractorsToUse)
+func ScanSingleFile(ctx context.Context, path string, extractorsToUse []filesystem.Extractor) ([]*extractor.Package, error) {
+	invs, err := scalibrextract.ExtractWithExtractors(ctx, path, extractorsToUse)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +120,7 @@ func ScanSingleFile(path string, extractorsToUse []filesystem.Extractor) ([]*ext
 
 // ScanSingleFileWithMapping will load, identify, and parse the lockfile path passed in, and add the dependencies specified
 // within to `query`
-func ScanSingleFileWithMapping(scanPath string, extractorsToUse []filesystem.Extractor) ([]*extractor.Package, error) {
+func ScanSingleFileWithMapping(ctx context.Context, scanPath string, extractorsToUse []filesystem.Extractor) ([]*extractor.Package, error) {
 	var err error
 	var inventories []*extractor.Package
 
@@ -112,13 +137,16 @@ func ScanSingleFileWithMapping(scanPath string, extractorsToUse []filesystem.Ext
 	// used by lockfile.Parse to avoid false-positives when scanning projects
 	switch parseAs {
 	case "apk-installed":
-		inventories, err = scalibrextract.ExtractWithExtractor(context.Background(), path, apk.New(apk.DefaultConfig()))
+		inventories, err = scalibrextract.ExtractWithExtractor(ctx, path, apk.New(apk.DefaultConfig()))
 	case "dpkg-status":
-		inventories, err = scalibrextract.ExtractWithExtractor(context.Background(), path, dpkg.New(dpkg.DefaultConfig()))
+		inventories, err = extractDpkgStatus(ctx, path)
 	case "osv-scanner":
-		inventories, err = scalibrextract.ExtractWithExtractor(context.Background(), path, osvscannerjson.Extractor{})
+		inventories, err = scalibrextract.ExtractWithExtractor(ctx, path, osvscannerjson.Extractor{})
+	case "sbom":
+		// SBOMs that are not named as their specification recommends are parsed based on their content
+		inventories, err = scalibrextract.ExtractWithExtractor(ctx, path, detect.Extractor{})
 	case "": // No specific parseAs specified
-		inventories, err = scalibrextract.ExtractWithExtractors(context.Background(), path, extractorsToUse)
+		inventories, err = scalibrextract.ExtractWithExtractors(ctx, path, extractorsToUse)
 	default: // A specific parseAs without a special case is selected
 		// Find and extract with the extractor of parseAs
 		if names, ok := lockfileExtractorMapping[parseAs]; ok && len(names) > 0 {
@@ -128,7 +156,7 @@ func ScanSingleFileWithMapping(scanPath string, extractorsToUse []filesystem.Ext
 			if i < 0 {
 				return nil, fmt.Errorf("could not determine extractor, requested %s", parseAs)
 			}
-			inventories, err = scalibrextract.ExtractWithExtractor(context.Background(), path, extractorsToUse[i])
+			inventories, err = scalibrextract.ExtractWithExtractor(ctx, path, extractorsToUse[i])
 		} else {
 			return nil, fmt.Errorf("could not determine extractor, requested %s", parseAs)
 		}
@@ -0,0 +1,71 @@
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/sbom/purllist"
+)
+
+// stdinPURLListPath is the path that reads the purl list from stdin
+const stdinPURLListPath = "-"
+
+// ScanPURLList loads the packages listed in the purl list at the given path,
+// reading the list from stdin if the path is "-"
+func ScanPURLList(ctx context.Context, path string) ([]*extractor.Package, error) {
+	var inventories []*extractor.Package
+	var err error
+
+	if path == stdinPURLListPath {
+		path = "stdin"
+		inventories, err = scanPURLListFromStdin(ctx)
+	} else {
+		path, err = filepath.Abs(path)
+		if err != nil {
+			cmdlogger.Errorf("Failed to resolved path %q with error: %s", path, err)
+			return nil, err
+		}
+
+		inventories, err = scalibrextract.ExtractWithExtractor(ctx, path, purllist.Extractor{})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	pkgCount := len(inventories)
+
+	cmdlogger.Infof(
+		"Scanned %s purl list and found %d %s",
+		path,
+		pkgCount,
+		output.Form(pkgCount, "package", "packages"),
+	)
+
+	return inventories, nil
+}
+
+func scanPURLListFromStdin(ctx context.Context) ([]*extractor.Package, error) {
+	ext := purllist.Extractor{}
+
+	invs, err := ext.Extract(ctx, &filesystem.ScanInput{
+		Path:   "stdin",
+		Reader: os.Stdin,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("(extracting as %s) %w", ext.Name(), err)
+	}
+
+	for _, inv := range invs.Packages {
+		inv.Plugins = append(inv.Plugins, ext.Name())
+	}
+
+	return invs.Packages, nil
+}
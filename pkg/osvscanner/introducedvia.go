@@ -0,0 +1,69 @@
+package osvscanner
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/depgraph"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// addIntroducedVia sets the shortest chain of dependencies that introduces each vulnerable
+// package, for the sources whose dependency graph can be built.
+//
+// Only sources with vulnerable packages have their graph built, as doing so can need network
+// access for some files, such as those that are resolved.
+func addIntroducedVia(ctx context.Context, actions ScannerActions, vulnResults *models.VulnerabilityResults) {
+	opts := depgraph.Options{
+		Offline:          actions.CompareOffline,
+		ResolveMaven:     !actions.TransitiveScanningActions.Disabled,
+		MavenRegistry:    actions.MavenRegistry,
+		NativeDataSource: actions.NativeDataSource,
+	}
+
+	for i := range vulnResults.Results {
+		source := &vulnResults.Results[i]
+		if source.Source.Type != models.SourceTypeProjectPackage || !hasVulnerablePackage(*source) {
+			continue
+		}
+
+		g, err := depgraph.Read(ctx, filepath.FromSlash(source.Source.Path), opts)
+		if err != nil {
+			if !errors.Is(err, depgraph.ErrUnsupported) {
+				cmdlogger.Infof("Could not build the dependency graph of %s: %s", source.Source.Path, err)
+			}
+
+			continue
+		}
+
+		chains := depgraph.ShortestChains(g)
+		for j := range source.Packages {
+			pkg := &source.Packages[j]
+			if len(pkg.Vulnerabilities) == 0 {
+				continue
+			}
+
+			chain, ok := chains[depgraph.Key{Name: pkg.Package.Name, Version: pkg.Package.Version}]
+			if !ok {
+				continue
+			}
+
+			pkg.IntroducedVia = make([]models.DependencyLink, 0, len(chain))
+			for _, link := range chain {
+				pkg.IntroducedVia = append(pkg.IntroducedVia, models.DependencyLink{Name: link.Name, Version: link.Version})
+			}
+		}
+	}
+}
+
+func hasVulnerablePackage(source models.PackageSource) bool {
+	for _, pkg := range source.Packages {
+		if len(pkg.Vulnerabilities) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package osvscanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func Test_addIntroducedVia(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	lockfilePath := filepath.Join(dir, "package-lock.json")
+	lockfile := `{
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "my-app", "dependencies": {"express": "^4.17.1"}},
+			"node_modules/express": {"version": "4.17.1", "dependencies": {"qs": "6.7.0"}},
+			"node_modules/qs": {"version": "6.7.0"}
+		}
+	}`
+	if err := os.WriteFile(lockfilePath, []byte(lockfile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	newPackage := func(name, version string, vulnerable bool) models.PackageVulns {
+		pkg := models.PackageVulns{Package: models.PackageInfo{Name: name, Version: version, Ecosystem: "npm"}}
+		if vulnerable {
+			pkg.Vulnerabilities = []osvschema.Vulnerability{{ID: "GHSA-" + name}}
+		}
+
+		return pkg
+	}
+
+	vulnResults := models.VulnerabilityResults{
+		Results: []models.PackageSource{
+			{
+				Source: models.SourceInfo{Path: filepath.ToSlash(lockfilePath), Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{
+					newPackage("express", "4.17.1", true),
+					newPackage("qs", "6.7.0", true),
+					newPackage("unknown", "1.0.0", true),
+				},
+			},
+		},
+	}
+
+	addIntroducedVia(t.Context(), ScannerActions{}, &vulnResults)
+
+	want := [][]models.DependencyLink{
+		{{Name: "express", Version: "4.17.1"}},
+		{{Name: "express", Version: "4.17.1"}, {Name: "qs", Version: "6.7.0"}},
+		nil,
+	}
+
+	got := make([][]models.DependencyLink, 0, len(want))
+	for _, pkg := range vulnResults.Results[0].Packages {
+		got = append(got, pkg.IntroducedVia)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("addIntroducedVia() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,53 @@
+package osvscanner
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/kev"
+	"github.com/google/osv-scanner/v2/internal/version"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// loadKEVCatalog returns the latest version of the KEV catalog if it should be
+// refreshed, falling back to the bundled snapshot if it cannot be fetched
+func loadKEVCatalog(ctx context.Context, actions ScannerActions) (*kev.Catalog, error) {
+	if actions.RefreshKEV && !actions.CompareOffline {
+		client := &http.Client{Transport: &osvmatcher.RetryTransport{Config: osvmatcher.DefaultRetryConfig()}}
+
+		catalog, err := kev.Fetch(ctx, client, kev.CatalogURL, "osv-scanner_scan/"+version.OSVVersion)
+		if err == nil {
+			return catalog, nil
+		}
+
+		cmdlogger.Warnf("Failed to refresh the KEV catalog, using the bundled snapshot instead: %s", err)
+	}
+
+	return kev.Bundled()
+}
+
+// flagKEV sets the KEV entry of each vulnerability group that has a CVE in its
+// aliases which is known to have been exploited
+func flagKEV(catalog *kev.Catalog, vulnResults *models.VulnerabilityResults) {
+	for i := range vulnResults.Results {
+		for j := range vulnResults.Results[i].Packages {
+			groups := vulnResults.Results[i].Packages[j].Groups
+			for k := range groups {
+				for _, cve := range groupCVEs(groups[k]) {
+					if entry, ok := catalog.Lookup(cve); ok {
+						groups[k].KEV = &entry
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// meetsKEVRequirement checks if the vulnerability is known to have been exploited
+// if only such vulnerabilities should fail the scan
+func meetsKEVRequirement(vf models.VulnerabilityFlattened, kevOnly bool) bool {
+	return !kevOnly || vf.GroupInfo.KEV != nil
+}
@@ -0,0 +1,34 @@
+package osvscanner
+
+import (
+	"fmt"
+
+	"github.com/google/osv-scalibr/artifact/image/layerscanning/image"
+	"github.com/google/osv-scalibr/extractor/filesystem"
+	"github.com/google/osv-scanner/v2/internal/layercache"
+)
+
+// NewLayerCache returns a cache of the packages extracted from the layers of images, for
+// ScannerActions.LayerCache, which persists what is cached to dir so that it can be used
+// by later scans, or is only held in memory if dir is empty.
+//
+// The same cache should be used for each image being scanned, so that layers shared by
+// the images such as those of a common base image are only extracted once.
+func NewLayerCache(dir string) *layercache.Cache {
+	return layercache.New(dir)
+}
+
+// cacheLayerExtractions wraps the extractors so that the packages they extract from the
+// layers of the image are cached by layer, if the scan has a layer cache
+func cacheLayerExtractions(extractors []filesystem.Extractor, img *image.Image, actions ScannerActions) ([]filesystem.Extractor, error) {
+	if actions.LayerCache == nil {
+		return extractors, nil
+	}
+
+	layers, err := img.ChainLayers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layers of image: %w", err)
+	}
+
+	return layercache.Wrap(extractors, layers, actions.LayerCache), nil
+}
@@ -0,0 +1,64 @@
+package osvscanner
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/google/osv-scalibr/clients/datasource"
+	internaldatasource "github.com/google/osv-scanner/v2/internal/datasource"
+)
+
+// mavenDefaultRegistry returns the default registry to fetch Maven metadata from
+// during transitive scanning, redirected to a mirror from settings.xml if one matches.
+//
+// Settings are read from actions.MavenSettings if set, otherwise from the
+// global and user settings.xml files.
+func mavenDefaultRegistry(actions TransitiveScanningActions) (datasource.MavenRegistry, error) {
+	settings := internaldatasource.DefaultMavenSettings()
+	if actions.MavenSettings != "" {
+		var err error
+		settings, err = internaldatasource.LoadMavenSettings(actions.MavenSettings)
+		if err != nil {
+			return datasource.MavenRegistry{}, fmt.Errorf("failed to read Maven settings %s: %w", actions.MavenSettings, err)
+		}
+	}
+
+	registry := datasource.MavenRegistry{
+		URL:             actions.MavenRegistry,
+		ReleasesEnabled: true,
+	}
+
+	// Match the IDs that the Maven registry client gives to the default registry
+	repoID, repoURL := "default", actions.MavenRegistry
+	if repoURL == "" {
+		repoID, repoURL = "central", internaldatasource.MavenCentral
+	}
+
+	mirror, ok := settings.FindMirror(repoID, repoURL)
+	if !ok {
+		return registry, nil
+	}
+
+	registry.ID = mirror.ID
+	registry.URL = mirror.URL
+
+	// Credentials from the default settings files are already looked up by the
+	// registry ID, so only those in a manually specified file need to be added.
+	if actions.MavenSettings == "" {
+		return registry, nil
+	}
+
+	server, ok := settings.FindServer(mirror.ID)
+	if !ok || server.Username == "" {
+		return registry, nil
+	}
+
+	u, err := url.Parse(mirror.URL)
+	if err != nil {
+		return datasource.MavenRegistry{}, fmt.Errorf("invalid Maven mirror %s: %w", mirror.URL, err)
+	}
+	u.User = url.UserPassword(server.Username, server.Password)
+	registry.URL = u.String()
+
+	return registry, nil
+}
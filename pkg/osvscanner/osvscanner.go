@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"net/http"
 	"os"
@@ -20,6 +21,8 @@ import (
 	"github.com/google/osv-scalibr/extractor"
 	"github.com/google/osv-scalibr/extractor/filesystem"
 	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/baseimagematcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/epssmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/integritymatcher"
 	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/licensematcher"
 	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/localmatcher"
 	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
@@ -29,8 +32,13 @@ import (
 	"github.com/google/osv-scanner/v2/internal/depsdev"
 	"github.com/google/osv-scanner/v2/internal/imodels"
 	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/internal/kev"
+	"github.com/google/osv-scanner/v2/internal/layercache"
 	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/progress"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract"
+	"github.com/google/osv-scanner/v2/internal/telemetry"
+	"github.com/google/osv-scanner/v2/internal/utility/severity"
 	"github.com/google/osv-scanner/v2/internal/version"
 	"github.com/google/osv-scanner/v2/pkg/models"
 	"github.com/google/osv-scanner/v2/pkg/osvscanner/internal/imagehelpers"
@@ -41,27 +49,144 @@ import (
 type ScannerActions struct {
 	ExperimentalScannerActions
 
-	LockfilePaths      []string
-	DirectoryPaths     []string
-	GitCommits         []string
-	Recursive          bool
-	IncludeGitRoot     bool
-	NoIgnore           bool
-	Image              string
-	IsImageArchive     bool
-	ConfigOverridePath string
+	LockfilePaths       []string
+	DirectoryPaths      []string
+	GitCommits          []string
+	Recursive           bool
+	IncludeGitRoot      bool
+	NoIgnore            bool
+	Image               string
+	IsImageArchive      bool
+	BaseImage           string
+	ConfigOverridePaths []string
+	// RegistryUsername, RegistryPassword, and RegistryToken are the credentials used to
+	// pull Image directly from its registry, instead of exporting it with docker
+	RegistryUsername string
+	RegistryPassword string
+	RegistryToken    string
+	// ConfigProfile is the name of the profile to use from the config files that define it
+	ConfigProfile string
+	// InheritConfig merges the configs of the scanned directories with the configs of
+	// their subdirectories, rather than only using the config closest to each file
+	InheritConfig      bool
 	CallAnalysisStates map[string]bool
 	ShowAllPackages    bool
 	ShowAllVulns       bool
+	ShowStats          bool
+	// ShowProgress reports the progress of each phase of the scan to stderr, as a live
+	// status line if stdout is a terminal and as a stream of json events otherwise
+	ShowProgress bool
+	// ProgressWriter is where the progress of the scan is reported to, which is stderr if it is
+	// nil; it must not be the same as where machine-readable results are written to
+	ProgressWriter io.Writer
+	// HeartbeatInterval is how often a heartbeat with the phase of the scan is reported when
+	// the progress is not being shown in a terminal, which reports progress even if ShowProgress
+	// is not set, so that CI systems do not kill long scans for being idle; no heartbeats
+	// are reported if it is zero
+	HeartbeatInterval time.Duration
+	// ProgressTargets counts the targets of a scan of multiple targets that have been completed,
+	// which heartbeats report along with the phase of the target being scanned
+	ProgressTargets *progress.Targets
+	// FailOnSeverity are the minimum severities that vulnerabilities must have to fail
+	// the scan, in the form of "RATING" or "group:RATING" for a dependency group
+	FailOnSeverity []string
+	// EnrichEPSS looks up the EPSS score of the CVEs of each vulnerability
+	EnrichEPSS bool
+	// FailOnEPSS is the minimum EPSS score that vulnerabilities must have to fail the scan,
+	// which implies EnrichEPSS. Vulnerabilities can fail the scan regardless of their
+	// EPSS score if it is zero.
+	FailOnEPSS float64
+	// FlagKEV checks the CVEs of each vulnerability against the Known Exploited
+	// Vulnerabilities catalog of CISA, using the snapshot bundled with osv-scanner
+	FlagKEV bool
+	// RefreshKEV fetches the latest version of the KEV catalog instead of using the bundled snapshot
+	RefreshKEV bool
+	// FailOnKEV only fails the scan for vulnerabilities that are known to have been exploited,
+	// which implies FlagKEV
+	FailOnKEV bool
+	// ShowRiskScore includes the aggregate risk score of all the vulnerabilities in the results
+	ShowRiskScore bool
+	// MaxRiskScore is the highest aggregate risk score that the scan can have without failing,
+	// which implies ShowRiskScore. Individual vulnerabilities do not fail the scan if it is
+	// set, and the scan is not gated on the risk score if it is zero.
+	MaxRiskScore float64
+	// PolicyPath is a CEL policy that the results are evaluated against, which decides whether
+	// the scan fails in place of the vulnerabilities and license violations that were found
+	PolicyPath string
+	// SuppressUnfixedAfterDays soft-suppresses vulnerabilities that have had no fix published
+	// for more than this many days, taking precedence over the config, so that they are
+	// reported but do not fail the scan; the config is used if it is zero
+	SuppressUnfixedAfterDays int
+	// BaselinePath is the JSON output of a previous scan that the results are compared against,
+	// recording whether each vulnerability is new since that scan and when it was first seen
+	BaselinePath string
+	// OnlyNew soft-suppresses the vulnerabilities that were found by the scan of BaselinePath,
+	// so that only vulnerabilities that are new since then fail the scan
+	OnlyNew bool
+	// FailOnIgnoredVulns returns ErrIgnoredVulnerabilitiesFound if vulnerabilities were
+	// found but were all ignored by config, rather than treating the scan as a success
+	FailOnIgnoredVulns bool
+	// FailOnUncalledVulns returns ErrUncalledVulnerabilitiesFound if vulnerabilities were
+	// found but none of them are called, rather than treating the scan as a success
+	FailOnUncalledVulns bool
+	// VerifyIntegrity compares the hashes recorded by lockfiles against the hashes published
+	// by the registries of their packages, reporting those that do not match as potential
+	// tampering, which fails the scan with ErrIntegrityMismatchesFound
+	VerifyIntegrity bool
+	// IncludeFixReferences extracts the upstream fix commits, patches, and advisories
+	// of each vulnerability from its OSV record into the results
+	IncludeFixReferences bool
+	// ScanBaseImages pulls the images that the Dockerfiles of the scan build from and
+	// scans their packages along with the rest of the scan
+	ScanBaseImages bool
+	// ScanKubernetesImages pulls the images that the Kubernetes workloads of the scan run
+	// and scans their packages, reporting them under the workload that runs them
+	ScanKubernetesImages bool
+	// APIMaxAttempts is the number of times requests to the OSV API are attempted
+	// before giving up, using the default number of attempts if it is zero
+	APIMaxAttempts int
+	// Labels are metadata describing what was scanned, such as the service or environment
+	// that it is deployed to, which are attached to the results for reporting
+	Labels map[string]string
+	// ExtractionTimeout is how long extracting the packages of the scan can take, including
+	// exporting and loading the image being scanned and scanning base images, before the
+	// scan fails with ErrPhaseTimedOut; there is no timeout if it is zero
+	ExtractionTimeout time.Duration
+	// QueryTimeout is how long matching the packages against vulnerabilities can take
+	// before the scan fails with ErrPhaseTimedOut; there is no timeout if it is zero
+	QueryTimeout time.Duration
+	// EnrichmentTimeout is how long adding licenses, EPSS scores, and the results of
+	// Enrichers can take before the scan fails with ErrPhaseTimedOut; there is no
+	// timeout if it is zero
+	EnrichmentTimeout time.Duration
+	// LayerCache caches the packages extracted from the layers of the images being scanned,
+	// so that layers shared with images that have already been scanned with the same cache
+	// are not extracted again; layers are always extracted if it is nil
+	LayerCache *layercache.Cache
 
 	// local databases
 	CompareOffline    bool
 	DownloadDatabases bool
 	LocalDBPath       string
+	// LocalDBExtraPaths are directories of OSV advisories that are layered on top of
+	// the local databases, taking precedence over advisories with the same id
+	LocalDBExtraPaths []string
+	// VulnerabilitySources are the sources that packages are matched against for vulnerabilities,
+	// in order of precedence, which are either VulnSourceOSV, VulnSourceLocal, or a directory
+	// prefixed with VulnSourceFeedPrefix; vulnerabilities found by more than one source are only
+	// reported once. The local databases are used if scanning offline, and OSV.dev otherwise.
+	VulnerabilitySources []string
 
 	// license scanning
 	ScanLicensesSummary   bool
 	ScanLicensesAllowlist []string
+	// SkipVulnerabilities extracts packages and resolves their licenses
+	// without checking them for vulnerabilities
+	SkipVulnerabilities bool
+
+	// PURLListPaths are files of newline-delimited package urls to scan,
+	// where a path of "-" reads the list from stdin
+	PURLListPaths []string
 
 	// Deprecated: in favor of LockfilePaths
 	SBOMPaths []string
@@ -71,12 +196,21 @@ type ExperimentalScannerActions struct {
 	TransitiveScanningActions
 
 	Extractors []filesystem.Extractor
+
+	// Enrichers add custom information to the results of each package, such as who owns
+	// the package or where it is deployed, after the built-in enrichment has been done
+	Enrichers []Enricher
+
+	// LicenseExpressions evaluates licenses against the allowlist using
+	// SPDX expression semantics, rather than matching them as-is
+	LicenseExpressions bool
 }
 
 type TransitiveScanningActions struct {
 	Disabled         bool
 	NativeDataSource bool
 	MavenRegistry    string
+	MavenSettings    string
 }
 
 type ExternalAccessors struct {
@@ -84,11 +218,18 @@ type ExternalAccessors struct {
 	VulnMatcher      clientinterfaces.VulnerabilityMatcher
 	LicenseMatcher   clientinterfaces.LicenseMatcher
 	BaseImageMatcher clientinterfaces.BaseImageMatcher
+	EPSSMatcher      clientinterfaces.EPSSMatcher
+	IntegrityMatcher clientinterfaces.IntegrityMatcher
+
+	// KEVCatalog is nil if vulnerabilities are not being checked against the KEV catalog
+	KEVCatalog *kev.Catalog
 
 	// Required for pomxmlnet Extractor
 	MavenRegistryAPIClient *datasource.MavenRegistryAPIClient
 	// Required for vendored Extractor
 	OSVDevClient *osvdev.OSVClient
+	// OSVRetryTransport is used by the OSV.dev clients to retry failed requests
+	OSVRetryTransport *osvmatcher.RetryTransport
 
 	// DependencyClients is a map of implementations of DependencyClient
 	// for each ecosystem, the following is currently implemented:
@@ -103,23 +244,81 @@ var ErrNoPackagesFound = errors.New("no packages found in scan")
 // however, will not be raised if only uncalled vulnerabilities are found.
 var ErrVulnerabilitiesFound = errors.New("vulnerabilities found")
 
+// ErrLicenseViolationsFound is for when license violations are found; it wraps
+// ErrVulnerabilitiesFound, which it was reported as before being distinguished
+var ErrLicenseViolationsFound = fmt.Errorf("%w: license violations found", ErrVulnerabilitiesFound)
+
+// ErrPolicyViolated is for when the results do not pass the policy of ScannerActions.PolicyPath;
+// it wraps ErrVulnerabilitiesFound, as the policy decides whether the scan fails in place of it
+var ErrPolicyViolated = fmt.Errorf("%w: policy violated", ErrVulnerabilitiesFound)
+
+// ErrIgnoredVulnerabilitiesFound is for when the only vulnerabilities that were found are ignored
+// by config, which is only reported when ScannerActions.FailOnIgnoredVulns is set
+var ErrIgnoredVulnerabilitiesFound = errors.New("only ignored vulnerabilities found")
+
+// ErrUncalledVulnerabilitiesFound is for when the only vulnerabilities that were found are not
+// called (or are otherwise unimportant), which is only reported when ScannerActions.FailOnUncalledVulns
+// is set and ScannerActions.ShowAllVulns is not
+var ErrUncalledVulnerabilitiesFound = errors.New("only uncalled vulnerabilities found")
+
+// ErrUnscannedPackagesFound is for when packages were found that could not be checked for
+// vulnerabilities; it is not returned by the scanner itself, but by callers that treat
+// unscanned packages as a failure
+var ErrUnscannedPackagesFound = errors.New("unscanned packages found")
+
+// ErrIntegrityMismatchesFound is for when hashes recorded by lockfiles do not match the hashes
+// published by the registries of their packages; it wraps ErrVulnerabilitiesFound, as the
+// mismatches are reported alongside vulnerabilities as potential supply-chain tampering
+var ErrIntegrityMismatchesFound = fmt.Errorf("%w: integrity mismatches found", ErrVulnerabilitiesFound)
+
 // ErrAPIFailed describes errors related to querying API endpoints.
-// TODO(v2): Actually use this error
 var ErrAPIFailed = errors.New("API query failed")
 
-func initializeExternalAccessors(actions ScannerActions) (ExternalAccessors, error) {
+// ErrPartialAPIFailure is for when some of the packages could not be checked for vulnerabilities
+// because querying them failed even after being retried; it wraps ErrAPIFailed, but unlike it the
+// results of the packages that could be checked are still returned alongside it
+var ErrPartialAPIFailure = fmt.Errorf("%w: some packages could not be checked for vulnerabilities", ErrAPIFailed)
+
+// IsFindingErr returns whether the error reports the findings of a scan rather than it failing,
+// including when only some packages could not be checked, as the rest of the results are still valid
+func IsFindingErr(err error) bool {
+	return errors.Is(err, ErrVulnerabilitiesFound) ||
+		errors.Is(err, ErrIgnoredVulnerabilitiesFound) ||
+		errors.Is(err, ErrUncalledVulnerabilitiesFound) ||
+		errors.Is(err, ErrPartialAPIFailure)
+}
+
+func initializeExternalAccessors(ctx context.Context, actions ScannerActions) (ExternalAccessors, error) {
 	externalAccessors := ExternalAccessors{
 		DependencyClients: map[osvschema.Ecosystem]resolve.Client{},
 	}
 	var err error
 
+	// --- KEV Catalog ---
+	if actions.FlagKEV || actions.FailOnKEV {
+		externalAccessors.KEVCatalog, err = loadKEVCatalog(ctx, actions)
+		if err != nil {
+			return ExternalAccessors{}, err
+		}
+	}
+
 	// Offline Mode
 	// ------------
 	if actions.CompareOffline {
 		// --- Vulnerability Matcher ---
-		externalAccessors.VulnMatcher, err = localmatcher.NewLocalMatcher(actions.LocalDBPath, "osv-scanner_scan/"+version.OSVVersion, actions.DownloadDatabases)
-		if err != nil {
-			return ExternalAccessors{}, err
+		if !actions.SkipVulnerabilities {
+			externalAccessors.VulnMatcher, err = newVulnMatcher(actions, nil)
+			if err != nil {
+				return ExternalAccessors{}, err
+			}
+		}
+
+		// --- License Matcher ---
+		if len(actions.ScanLicensesAllowlist) > 0 || actions.ScanLicensesSummary {
+			externalAccessors.LicenseMatcher, err = localmatcher.NewLocalLicenseMatcher(actions.LocalDBPath)
+			if err != nil {
+				return ExternalAccessors{}, err
+			}
 		}
 
 		return externalAccessors, nil
@@ -127,10 +326,18 @@ func initializeExternalAccessors(actions ScannerActions) (ExternalAccessors, err
 
 	// Online Mode
 	// -----------
+	retryConfig := osvmatcher.DefaultRetryConfig()
+	if actions.APIMaxAttempts > 0 {
+		retryConfig.MaxAttempts = actions.APIMaxAttempts
+	}
+	externalAccessors.OSVRetryTransport = &osvmatcher.RetryTransport{Config: retryConfig}
+
 	// --- Vulnerability Matcher ---
-	externalAccessors.VulnMatcher = &osvmatcher.OSVMatcher{
-		Client:              *osvdev.DefaultClient(),
-		InitialQueryTimeout: 5 * time.Minute,
+	if !actions.SkipVulnerabilities {
+		externalAccessors.VulnMatcher, err = newVulnMatcher(actions, externalAccessors.OSVRetryTransport)
+		if err != nil {
+			return ExternalAccessors{}, err
+		}
 	}
 
 	// --- License Matcher ---
@@ -145,6 +352,22 @@ func initializeExternalAccessors(actions ScannerActions) (ExternalAccessors, err
 		}
 	}
 
+	// --- EPSS Matcher ---
+	if actions.EnrichEPSS || actions.FailOnEPSS > 0 {
+		externalAccessors.EPSSMatcher = &epssmatcher.FirstEPSSMatcher{
+			HTTPClient: &http.Client{Transport: &osvmatcher.RetryTransport{Config: retryConfig}},
+			UserAgent:  "osv-scanner_scan/" + version.OSVVersion,
+		}
+	}
+
+	// --- Integrity Matcher ---
+	if actions.VerifyIntegrity {
+		externalAccessors.IntegrityMatcher = &integritymatcher.RegistryIntegrityMatcher{
+			HTTPClient: &http.Client{Transport: &osvmatcher.RetryTransport{Config: retryConfig}},
+			UserAgent:  "osv-scanner_scan/" + version.OSVVersion,
+		}
+	}
+
 	// --- Base Image Matcher ---
 	if actions.Image != "" {
 		externalAccessors.BaseImageMatcher = &baseimagematcher.DepsDevBaseImageMatcher{
@@ -155,7 +378,7 @@ func initializeExternalAccessors(actions ScannerActions) (ExternalAccessors, err
 
 	// --- OSV.dev Client ---
 	// We create a separate client from VulnMatcher to keep things clean.
-	externalAccessors.OSVDevClient = osvdev.DefaultClient()
+	externalAccessors.OSVDevClient = osvmatcher.NewRetryingOSVClient(externalAccessors.OSVRetryTransport, "")
 
 	// --- No Transitive Scanning ---
 	if actions.Disabled {
@@ -163,10 +386,12 @@ func initializeExternalAccessors(actions ScannerActions) (ExternalAccessors, err
 	}
 
 	// --- Transitive Scanning Clients ---
-	externalAccessors.MavenRegistryAPIClient, err = datasource.NewMavenRegistryAPIClient(datasource.MavenRegistry{
-		URL:             actions.MavenRegistry,
-		ReleasesEnabled: true,
-	}, "")
+	mavenRegistry, err := mavenDefaultRegistry(actions.TransitiveScanningActions)
+	if err != nil {
+		return ExternalAccessors{}, err
+	}
+
+	externalAccessors.MavenRegistryAPIClient, err = datasource.NewMavenRegistryAPIClient(mavenRegistry, "")
 
 	if err != nil {
 		return ExternalAccessors{}, err
@@ -175,7 +400,11 @@ func initializeExternalAccessors(actions ScannerActions) (ExternalAccessors, err
 	if !actions.NativeDataSource {
 		externalAccessors.DependencyClients[osvschema.EcosystemMaven], err = resolution.NewDepsDevClient(depsdev.DepsdevAPI, "osv-scanner_scan/"+version.OSVVersion)
 	} else {
-		externalAccessors.DependencyClients[osvschema.EcosystemMaven], err = resolution.NewMavenRegistryClient(actions.MavenRegistry, "")
+		var mavenClient *datasource.MavenRegistryAPIClient
+		mavenClient, err = datasource.NewMavenRegistryAPIClient(mavenRegistry, "")
+		if err == nil {
+			externalAccessors.DependencyClients[osvschema.EcosystemMaven] = resolution.NewMavenRegistryClientWithAPI(mavenClient)
+		}
 	}
 
 	// We only support native registry client for PyPI.
@@ -190,28 +419,73 @@ func initializeExternalAccessors(actions ScannerActions) (ExternalAccessors, err
 
 // DoScan performs the osv scanner action, with optional reporter to output information
 func DoScan(actions ScannerActions) (models.VulnerabilityResults, error) {
+	return DoScanContext(context.Background(), actions)
+}
+
+// DoScanContext is like DoScan, except that the scan is stopped once the context is cancelled,
+// including any requests that are being made, in which case the error of the context is returned
+func DoScanContext(ctx context.Context, actions ScannerActions) (models.VulnerabilityResults, error) {
+	ctx, endScan := telemetry.StartPhase(ctx, "scan")
+	results, err := doScan(ctx, actions)
+	endScan(scanFailure(err))
+	recordVulnerabilities(ctx, results)
+
+	return results, err
+}
+
+func doScan(ctx context.Context, actions ScannerActions) (models.VulnerabilityResults, error) {
 	// --- Sanity check flags ----
 	// TODO(v2): Move the logic of the offline flag changing other flags into here from the main.go/scan.go
-	if actions.CompareOffline {
-		if actions.ScanLicensesSummary {
-			return models.VulnerabilityResults{}, errors.New("cannot retrieve licenses locally")
-		}
-	}
-
 	if !actions.CompareOffline && actions.DownloadDatabases {
 		return models.VulnerabilityResults{}, errors.New("databases can only be downloaded when running in offline mode")
 	}
 
+	if err := checkEPSSActions(actions); err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	if actions.CompareOffline && actions.VerifyIntegrity {
+		return models.VulnerabilityResults{}, errors.New("the hashes of packages cannot be verified when running in offline mode")
+	}
+
+	if actions.MaxRiskScore < 0 {
+		return models.VulnerabilityResults{}, fmt.Errorf("maximum risk score must not be negative, got %v", actions.MaxRiskScore)
+	}
+
+	if actions.SuppressUnfixedAfterDays < 0 {
+		return models.VulnerabilityResults{}, fmt.Errorf("days to suppress unfixed vulnerabilities after must not be negative, got %d", actions.SuppressUnfixedAfterDays)
+	}
+
+	pol, err := loadPolicy(actions.PolicyPath)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	baseline, err := loadBaseline(actions.BaselinePath)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	severityThresholds, err := config.ParseSeverityThresholds(actions.FailOnSeverity)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
 	scanResult := results.ScanResults{
 		ConfigManager: config.Manager{
 			DefaultConfig: config.Config{},
 			ConfigMap:     make(map[string]config.Config),
+			Profile:       actions.ConfigProfile,
 		},
 	}
 
+	if actions.InheritConfig {
+		scanResult.ConfigManager.InheritRoots = actions.DirectoryPaths
+	}
+
 	// --- Setup Config ---
-	if actions.ConfigOverridePath != "" {
-		err := scanResult.ConfigManager.UseOverride(actions.ConfigOverridePath)
+	if len(actions.ConfigOverridePaths) > 0 {
+		err := scanResult.ConfigManager.UseOverride(actions.ConfigOverridePaths...)
 		if err != nil {
 			cmdlogger.Errorf("Failed to read config file: %s", err)
 			return models.VulnerabilityResults{}, err
@@ -219,18 +493,78 @@ func DoScan(actions ScannerActions) (models.VulnerabilityResults, error) {
 	}
 
 	// --- Setup Accessors/Clients ---
-	accessors, err := initializeExternalAccessors(actions)
+	accessors, err := initializeExternalAccessors(ctx, actions)
 	if err != nil {
 		return models.VulnerabilityResults{}, fmt.Errorf("failed to initialize accessors: %w", err)
 	}
+	defer logAPIStats(accessors)
+
+	reporter := newProgressReporter(actions, accessors)
+	defer closeProgressReporter(reporter)
 
 	// ----- Perform Scanning -----
-	packages, err := scan(accessors, actions)
+	extractCtx, cancelExtract := phaseContext(ctx, "extraction", actions.ExtractionTimeout)
+	defer cancelExtract()
+
+	walkCtx, endWalk := telemetry.StartPhase(extractCtx, "walk")
+	statsCollector := newExtractorStatsCollector(walkCtx, true, reporter)
+
+	reporter.Start(progress.PhaseWalking, "files", 0)
+	reporter.Start(progress.PhaseExtracting, "files", 0)
+	packages, err := scan(walkCtx, accessors, actions, statsCollector)
+	err = phaseErr(extractCtx, err)
+	endWalk(err)
 	if err != nil {
 		return models.VulnerabilityResults{}, err
 	}
+	reporter.Finish(progress.PhaseWalking)
+	reporter.Finish(progress.PhaseExtracting)
 
 	scanResult.PackageScanResults = packages
+	scanResult.Diagnostics = statsCollector.Diagnostics()
+
+	// ----- Base Images -----
+	baseImages := takeBaseImages(&scanResult)
+	if actions.ScanBaseImages {
+		basePackages, diagnostics := scanBaseImages(extractCtx, baseImages, accessors, actions)
+		scanResult.PackageScanResults = append(scanResult.PackageScanResults, basePackages...)
+		scanResult.Diagnostics = append(scanResult.Diagnostics, diagnostics...)
+		if err := phaseErr(extractCtx, nil); err != nil {
+			return models.VulnerabilityResults{}, err
+		}
+	} else if len(baseImages) > 0 {
+		cmdlogger.Infof(
+			"Found %d base %s in Dockerfiles, which can be scanned with --scan-base-images",
+			len(baseImages),
+			output.Form(len(baseImages), "image", "images"),
+		)
+	}
+
+	// ----- Kubernetes Workloads -----
+	workloadImages := takeWorkloadImages(&scanResult)
+	if actions.ScanKubernetesImages {
+		workloadPackages, diagnostics := scanWorkloadImages(extractCtx, workloadImages, accessors, actions)
+		scanResult.PackageScanResults = append(scanResult.PackageScanResults, workloadPackages...)
+		scanResult.Diagnostics = append(scanResult.Diagnostics, diagnostics...)
+		if err := phaseErr(extractCtx, nil); err != nil {
+			return models.VulnerabilityResults{}, err
+		}
+	} else if len(workloadImages) > 0 {
+		cmdlogger.Infof(
+			"Found %d %s run by Kubernetes workloads, which can be scanned with --scan-kubernetes-images",
+			len(workloadImages),
+			output.Form(len(workloadImages), "image", "images"),
+		)
+	}
+
+	if len(scanResult.PackageScanResults) == 0 {
+		return models.VulnerabilityResults{}, ErrNoPackagesFound
+	}
+
+	// ----- Custom Mappings -----
+	if err := mapCPEPackages(&scanResult); err != nil {
+		return models.VulnerabilityResults{}, err
+	}
 
 	// ----- Filtering -----
 	filterUnscannablePackages(&scanResult)
@@ -241,26 +575,82 @@ func DoScan(actions ScannerActions) (models.VulnerabilityResults, error) {
 
 	// --- Make Vulnerability Requests ---
 	if accessors.VulnMatcher != nil {
-		err = makeVulnRequestWithMatcher(scanResult.PackageScanResults, accessors.VulnMatcher)
+		queryCtx, cancelQuery := phaseContext(ctx, "querying", actions.QueryTimeout)
+		defer cancelQuery()
+
+		spanCtx, endQuery := telemetry.StartPhase(queryCtx, "query")
+		err = makeVulnRequestWithMatcher(spanCtx, &scanResult, accessors.VulnMatcher, reporter)
+		err = phaseErr(queryCtx, err)
+		endQuery(err)
 		if err != nil {
 			return models.VulnerabilityResults{}, err
 		}
 	}
 
 	// --- Make License Requests ---
+	enrichCtx, cancelEnrich := phaseContext(ctx, "enrichment", actions.EnrichmentTimeout)
+	defer cancelEnrich()
+
+	enrichCtx, endEnrich := telemetry.StartPhase(enrichCtx, "enrich")
+	defer endEnrich(telemetry.ErrPhaseIncomplete)
+
+	reporter.Start(progress.PhaseEnriching, "", 0)
 	if accessors.LicenseMatcher != nil {
-		err = accessors.LicenseMatcher.MatchLicenses(context.Background(), scanResult.PackageScanResults)
-		if err != nil {
+		err = accessors.LicenseMatcher.MatchLicenses(enrichCtx, scanResult.PackageScanResults)
+		if err = phaseErr(enrichCtx, err); err != nil {
 			return models.VulnerabilityResults{}, err
 		}
 	}
 
 	vulnerabilityResults := buildVulnerabilityResults(actions, &scanResult)
 
+	if accessors.IntegrityMatcher != nil {
+		vulnerabilityResults.IntegrityMismatches, err = verifyIntegrity(enrichCtx, accessors.IntegrityMatcher, &scanResult)
+		if err != nil {
+			return models.VulnerabilityResults{}, fmt.Errorf("failed to verify the hashes of packages: %w", phaseErr(enrichCtx, err))
+		}
+	}
+
+	if accessors.EPSSMatcher != nil {
+		if err := enrichEPSS(enrichCtx, accessors.EPSSMatcher, &vulnerabilityResults); err != nil {
+			// the scores are required to know if the scan should fail
+			if actions.FailOnEPSS > 0 {
+				return models.VulnerabilityResults{}, fmt.Errorf("failed to look up EPSS scores: %w", phaseErr(enrichCtx, err))
+			}
+
+			cmdlogger.Warnf("Failed to look up EPSS scores: %s", err)
+			vulnerabilityResults.Diagnostics = append(vulnerabilityResults.Diagnostics, models.Diagnostic{
+				Kind:    models.DiagnosticAPIFailure,
+				Source:  "epss",
+				Message: "failed to look up EPSS scores: " + err.Error(),
+			})
+		}
+	}
+
+	if accessors.KEVCatalog != nil {
+		flagKEV(accessors.KEVCatalog, &vulnerabilityResults)
+	}
+
+	if actions.IncludeFixReferences {
+		addFixReferences(&vulnerabilityResults)
+	}
+
+	addIntroducedVia(enrichCtx, actions, &vulnerabilityResults)
+
+	if err := phaseErr(enrichCtx, runEnrichers(enrichCtx, actions.Enrichers, &vulnerabilityResults)); err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+	reporter.Finish(progress.PhaseEnriching)
+	endEnrich(nil)
+
 	if actions.ScanLicensesSummary {
 		vulnerabilityResults.LicenseSummary = buildLicenseSummary(&scanResult)
 	}
 
+	if actions.ShowStats {
+		vulnerabilityResults.ExperimentalScanStats = statsCollector.ScanStats()
+	}
+
 	filtered := filterResults(&vulnerabilityResults, &scanResult.ConfigManager, actions.ShowAllPackages)
 	if filtered > 0 {
 		cmdlogger.Infof(
@@ -270,19 +660,100 @@ func DoScan(actions ScannerActions) (models.VulnerabilityResults, error) {
 		)
 	}
 
-	return vulnerabilityResults, determineReturnErr(vulnerabilityResults, actions.ShowAllVulns, false)
+	labelSeverities(&vulnerabilityResults, &scanResult.ConfigManager)
+	suppressed := suppressUnfixed(&vulnerabilityResults, &scanResult.ConfigManager, actions.SuppressUnfixedAfterDays, time.Now())
+	suppressed += applyBaseline(&vulnerabilityResults, baseline, actions.OnlyNew, time.Now())
+
+	if actions.ShowRiskScore || actions.MaxRiskScore > 0 {
+		vulnerabilityResults.RiskScore = calculateRiskScore(vulnerabilityResults, actions.MaxRiskScore, actions.ShowAllVulns, false)
+	}
+
+	var returnErr error
+	if pol != nil {
+		returnErr = evaluatePolicy(pol, &vulnerabilityResults)
+		if returnErr != nil && !errors.Is(returnErr, ErrPolicyViolated) {
+			return models.VulnerabilityResults{}, returnErr
+		}
+	} else if actions.MaxRiskScore > 0 {
+		returnErr = determineRiskScoreReturnErr(vulnerabilityResults)
+	} else {
+		returnErr = determineReturnErr(vulnerabilityResults, &scanResult.ConfigManager, severityThresholds, actions.FailOnEPSS, actions.FailOnKEV, actions.ShowAllVulns, false)
+	}
+
+	if errors.Is(returnErr, ErrUncalledVulnerabilitiesFound) && !actions.FailOnUncalledVulns {
+		returnErr = nil
+	}
+
+	if returnErr == nil && actions.FailOnIgnoredVulns && filtered+suppressed > 0 {
+		returnErr = ErrIgnoredVulnerabilitiesFound
+	}
+
+	if len(vulnerabilityResults.IntegrityMismatches) > 0 {
+		returnErr = errors.Join(returnErr, ErrIntegrityMismatchesFound)
+	}
+
+	if hasFailedQueries(vulnerabilityResults) {
+		returnErr = errors.Join(returnErr, ErrPartialAPIFailure)
+	}
+
+	return vulnerabilityResults, returnErr
 }
 
+// DoContainerScan performs the osv scanner action on the image of the actions
 func DoContainerScan(actions ScannerActions) (models.VulnerabilityResults, error) {
+	return DoContainerScanContext(context.Background(), actions)
+}
+
+// DoContainerScanContext is like DoContainerScan, except that the scan is stopped once the context
+// is cancelled, including any images that are being exported, in which case the error of the
+// context is returned
+func DoContainerScanContext(ctx context.Context, actions ScannerActions) (models.VulnerabilityResults, error) {
+	ctx, endScan := telemetry.StartPhase(ctx, "container scan")
+	results, err := doContainerScan(ctx, actions)
+	endScan(scanFailure(err))
+	recordVulnerabilities(ctx, results)
+
+	return results, err
+}
+
+func doContainerScan(ctx context.Context, actions ScannerActions) (models.VulnerabilityResults, error) {
+	severityThresholds, err := config.ParseSeverityThresholds(actions.FailOnSeverity)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	if err := checkEPSSActions(actions); err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	if actions.MaxRiskScore < 0 {
+		return models.VulnerabilityResults{}, fmt.Errorf("maximum risk score must not be negative, got %v", actions.MaxRiskScore)
+	}
+
+	if actions.SuppressUnfixedAfterDays < 0 {
+		return models.VulnerabilityResults{}, fmt.Errorf("days to suppress unfixed vulnerabilities after must not be negative, got %d", actions.SuppressUnfixedAfterDays)
+	}
+
+	pol, err := loadPolicy(actions.PolicyPath)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
+	baseline, err := loadBaseline(actions.BaselinePath)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
 	scanResult := results.ScanResults{
 		ConfigManager: config.Manager{
 			DefaultConfig: config.Config{},
 			ConfigMap:     make(map[string]config.Config),
+			Profile:       actions.ConfigProfile,
 		},
 	}
 
-	if actions.ConfigOverridePath != "" {
-		err := scanResult.ConfigManager.UseOverride(actions.ConfigOverridePath)
+	if len(actions.ConfigOverridePaths) > 0 {
+		err := scanResult.ConfigManager.UseOverride(actions.ConfigOverridePaths...)
 		if err != nil {
 			cmdlogger.Errorf("Failed to read config file: %s", err)
 			return models.VulnerabilityResults{}, err
@@ -290,20 +761,43 @@ func DoContainerScan(actions ScannerActions) (models.VulnerabilityResults, error
 	}
 
 	// --- Setup Accessors/Clients ---
-	accessors, err := initializeExternalAccessors(actions)
+	accessors, err := initializeExternalAccessors(ctx, actions)
 	if err != nil {
 		return models.VulnerabilityResults{}, fmt.Errorf("failed to initialize accessors: %w", err)
 	}
+	defer logAPIStats(accessors)
+
+	reporter := newProgressReporter(actions, accessors)
+	defer closeProgressReporter(reporter)
+
+	extractCtx, cancelExtract := phaseContext(ctx, "extraction", actions.ExtractionTimeout)
+	defer cancelExtract()
 
 	// --- Initialize Image To Scan ---'
+	reporter.Start(progress.PhaseLoading, "", 0)
+
+	creds := imagehelpers.RegistryCredentials{
+		Username: actions.RegistryUsername,
+		Password: actions.RegistryPassword,
+		Token:    actions.RegistryToken,
+	}
 
 	var img *image.Image
 	if actions.IsImageArchive {
 		cmdlogger.Infof("Scanning local image tarball %q", actions.Image)
 		img, err = image.FromTarball(actions.Image, image.DefaultConfig())
+	} else if actions.Image != "" && imagehelpers.ShouldPullFromRegistry(actions.Image, creds) {
+		keychain, keychainErr := imagehelpers.Keychain(actions.Image, creds)
+		if keychainErr != nil {
+			return models.VulnerabilityResults{}, keychainErr
+		}
+
+		img, err = imagehelpers.PullImage(extractCtx, actions.Image, keychain)
+		err = phaseErr(extractCtx, err)
+		cmdlogger.Infof("Scanning image %q", actions.Image)
 	} else if actions.Image != "" {
-		path, exportErr := imagehelpers.ExportDockerImage(actions.Image)
-		if exportErr != nil {
+		path, exportErr := imagehelpers.ExportImage(extractCtx, actions.Image)
+		if exportErr = phaseErr(extractCtx, exportErr); exportErr != nil {
 			return models.VulnerabilityResults{}, exportErr
 		}
 		defer os.Remove(path)
@@ -321,19 +815,38 @@ func DoContainerScan(actions ScannerActions) (models.VulnerabilityResults, error
 			cmdlogger.Errorf("Failed to clean up image: %s", err)
 		}
 	}()
+	reporter.Finish(progress.PhaseLoading)
 
 	// --- Do Scalibr Scan ---
+	walkCtx, endWalk := telemetry.StartPhase(extractCtx, "walk")
+	defer endWalk(telemetry.ErrPhaseIncomplete)
+
+	statsCollector := newExtractorStatsCollector(walkCtx, false, reporter)
+	reporter.Start(progress.PhaseWalking, "files", 0)
+	reporter.Start(progress.PhaseExtracting, "files", 0)
+	extractors, err := cacheLayerExtractions(getExtractors(
+		scalibrextract.ExtractorsArtifacts,
+		accessors,
+		actions,
+	), img, actions)
+	if err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+
 	scanner := scalibr.New()
-	scalibrSR, err := scanner.ScanContainer(context.Background(), img, &scalibr.ScanConfig{
-		FilesystemExtractors: getExtractors(
-			scalibrextract.ExtractorsArtifacts,
-			accessors,
-			actions,
-		),
+	scalibrSR, err := scanner.ScanContainer(walkCtx, img, &scalibr.ScanConfig{
+		FilesystemExtractors: extractors,
+		Stats:                statsCollector,
 	})
+	if extractCtx.Err() != nil {
+		return models.VulnerabilityResults{}, phaseErr(extractCtx, err)
+	}
 	if err != nil {
 		return models.VulnerabilityResults{}, fmt.Errorf("failed to scan container image: %w", err)
 	}
+	reporter.Finish(progress.PhaseWalking)
+	reporter.Finish(progress.PhaseExtracting)
+	endWalk(nil)
 
 	if scalibrSR.Inventory.IsEmpty() {
 		return models.VulnerabilityResults{}, ErrNoPackagesFound
@@ -345,11 +858,35 @@ func DoContainerScan(actions ScannerActions) (models.VulnerabilityResults, error
 		scanResult.PackageScanResults[i].PackageInfo = imodels.FromInventory(inv)
 		scanResult.PackageScanResults[i].LayerDetails = inv.LayerDetails
 	}
+	scanResult.Diagnostics = statsCollector.Diagnostics()
 
 	// --- Fill Image Metadata ---
-	scanResult.ImageMetadata, err = imagehelpers.BuildImageMetadata(img, accessors.BaseImageMatcher)
+	var baseImageHint *imagehelpers.BaseImageHint
+	if actions.BaseImage != "" {
+		baseImageHint, err = imagehelpers.LoadBaseImageHint(extractCtx, actions.BaseImage)
+		if err != nil { // Not being able to use the hint is not fatal
+			cmdlogger.Errorf("Failed to load base image: %v", err)
+			scanResult.Diagnostics = append(scanResult.Diagnostics, models.Diagnostic{
+				Kind:    models.DiagnosticPartialResult,
+				Path:    actions.BaseImage,
+				Message: "failed to load base image: " + err.Error(),
+			})
+		}
+	}
+
+	scanResult.ImageMetadata, err = imagehelpers.BuildImageMetadata(extractCtx, img, accessors.BaseImageMatcher, baseImageHint)
 	if err != nil { // Not getting image metadata is not fatal
 		cmdlogger.Errorf("Failed to fully get image metadata: %v", err)
+		scanResult.Diagnostics = append(scanResult.Diagnostics, models.Diagnostic{
+			Kind:    models.DiagnosticPartialResult,
+			Path:    actions.Image,
+			Message: "failed to fully get image metadata: " + err.Error(),
+		})
+	}
+
+	// unless the metadata could not be got because the scan was stopped
+	if err := phaseErr(extractCtx, nil); err != nil {
+		return models.VulnerabilityResults{}, err
 	}
 
 	// ----- Filtering -----
@@ -360,16 +897,29 @@ func DoContainerScan(actions ScannerActions) (models.VulnerabilityResults, error
 
 	// --- Make Vulnerability Requests ---
 	if accessors.VulnMatcher != nil {
-		err = makeVulnRequestWithMatcher(scanResult.PackageScanResults, accessors.VulnMatcher)
+		queryCtx, cancelQuery := phaseContext(ctx, "querying", actions.QueryTimeout)
+		defer cancelQuery()
+
+		spanCtx, endQuery := telemetry.StartPhase(queryCtx, "query")
+		err = makeVulnRequestWithMatcher(spanCtx, &scanResult, accessors.VulnMatcher, reporter)
+		err = phaseErr(queryCtx, err)
+		endQuery(err)
 		if err != nil {
 			return models.VulnerabilityResults{}, err
 		}
 	}
 
 	// --- Make License Requests ---
+	enrichCtx, cancelEnrich := phaseContext(ctx, "enrichment", actions.EnrichmentTimeout)
+	defer cancelEnrich()
+
+	enrichCtx, endEnrich := telemetry.StartPhase(enrichCtx, "enrich")
+	defer endEnrich(telemetry.ErrPhaseIncomplete)
+
+	reporter.Start(progress.PhaseEnriching, "", 0)
 	if accessors.LicenseMatcher != nil {
-		err = accessors.LicenseMatcher.MatchLicenses(context.Background(), scanResult.PackageScanResults)
-		if err != nil {
+		err = accessors.LicenseMatcher.MatchLicenses(enrichCtx, scanResult.PackageScanResults)
+		if err = phaseErr(enrichCtx, err); err != nil {
 			return models.VulnerabilityResults{}, err
 		}
 	}
@@ -387,10 +937,44 @@ func DoContainerScan(actions ScannerActions) (models.VulnerabilityResults, error
 
 	vulnerabilityResults := buildVulnerabilityResults(actions, &scanResult)
 
+	if accessors.EPSSMatcher != nil {
+		if err := enrichEPSS(enrichCtx, accessors.EPSSMatcher, &vulnerabilityResults); err != nil {
+			// the scores are required to know if the scan should fail
+			if actions.FailOnEPSS > 0 {
+				return models.VulnerabilityResults{}, fmt.Errorf("failed to look up EPSS scores: %w", phaseErr(enrichCtx, err))
+			}
+
+			cmdlogger.Warnf("Failed to look up EPSS scores: %s", err)
+			vulnerabilityResults.Diagnostics = append(vulnerabilityResults.Diagnostics, models.Diagnostic{
+				Kind:    models.DiagnosticAPIFailure,
+				Source:  "epss",
+				Message: "failed to look up EPSS scores: " + err.Error(),
+			})
+		}
+	}
+
+	if accessors.KEVCatalog != nil {
+		flagKEV(accessors.KEVCatalog, &vulnerabilityResults)
+	}
+
+	if actions.IncludeFixReferences {
+		addFixReferences(&vulnerabilityResults)
+	}
+
+	if err := phaseErr(enrichCtx, runEnrichers(enrichCtx, actions.Enrichers, &vulnerabilityResults)); err != nil {
+		return models.VulnerabilityResults{}, err
+	}
+	reporter.Finish(progress.PhaseEnriching)
+	endEnrich(nil)
+
 	if actions.ScanLicensesSummary {
 		vulnerabilityResults.LicenseSummary = buildLicenseSummary(&scanResult)
 	}
 
+	if actions.ShowStats {
+		vulnerabilityResults.ExperimentalScanStats = statsCollector.ScanStats()
+	}
+
 	filtered := filterResults(&vulnerabilityResults, &scanResult.ConfigManager, actions.ShowAllPackages)
 	if filtered > 0 {
 		cmdlogger.Infof(
@@ -400,7 +984,64 @@ func DoContainerScan(actions ScannerActions) (models.VulnerabilityResults, error
 		)
 	}
 
-	return vulnerabilityResults, determineReturnErr(vulnerabilityResults, actions.ShowAllVulns, true)
+	labelSeverities(&vulnerabilityResults, &scanResult.ConfigManager)
+	suppressed := suppressUnfixed(&vulnerabilityResults, &scanResult.ConfigManager, actions.SuppressUnfixedAfterDays, time.Now())
+	suppressed += applyBaseline(&vulnerabilityResults, baseline, actions.OnlyNew, time.Now())
+
+	if actions.ShowRiskScore || actions.MaxRiskScore > 0 {
+		vulnerabilityResults.RiskScore = calculateRiskScore(vulnerabilityResults, actions.MaxRiskScore, actions.ShowAllVulns, true)
+	}
+
+	var returnErr error
+	if pol != nil {
+		returnErr = evaluatePolicy(pol, &vulnerabilityResults)
+		if returnErr != nil && !errors.Is(returnErr, ErrPolicyViolated) {
+			return models.VulnerabilityResults{}, returnErr
+		}
+	} else if actions.MaxRiskScore > 0 {
+		returnErr = determineRiskScoreReturnErr(vulnerabilityResults)
+	} else {
+		returnErr = determineReturnErr(vulnerabilityResults, &scanResult.ConfigManager, severityThresholds, actions.FailOnEPSS, actions.FailOnKEV, actions.ShowAllVulns, true)
+	}
+
+	if errors.Is(returnErr, ErrUncalledVulnerabilitiesFound) && !actions.FailOnUncalledVulns {
+		returnErr = nil
+	}
+
+	if returnErr == nil && actions.FailOnIgnoredVulns && filtered+suppressed > 0 {
+		returnErr = ErrIgnoredVulnerabilitiesFound
+	}
+
+	if hasFailedQueries(vulnerabilityResults) {
+		returnErr = errors.Join(returnErr, ErrPartialAPIFailure)
+	}
+
+	return vulnerabilityResults, returnErr
+}
+
+// hasFailedQueries returns whether any packages could not be checked for vulnerabilities
+// because querying them failed, which fails the scan rather than quietly under-reporting
+func hasFailedQueries(vulnResults models.VulnerabilityResults) bool {
+	return slices.ContainsFunc(vulnResults.UnscannedPackages, func(pkg models.UnscannedPackage) bool {
+		return pkg.Reason == models.UnscannedReasonQueryFailed
+	})
+}
+
+// logAPIStats logs how many requests were made to the OSV API, to help
+// with diagnosing scans that are failing due to rate limiting or outages
+func logAPIStats(accessors ExternalAccessors) {
+	if accessors.OSVRetryTransport == nil {
+		return
+	}
+
+	stats := accessors.OSVRetryTransport.Stats()
+	cmdlogger.Debugf(
+		"OSV API: %d %s made, %d retried, %d failed",
+		stats.Requests,
+		output.Form(int(stats.Requests), "request", "requests"),
+		stats.Retried,
+		stats.Failed,
+	)
 }
 
 func buildLicenseSummary(scanResult *results.ScanResults) []models.LicenseCount {
@@ -447,13 +1088,23 @@ func buildLicenseSummary(scanResult *results.ScanResults) []models.LicenseCount
 
 // determineReturnErr determines whether we found a "vulnerability" or not,
 // and therefore whether we should return a ErrVulnerabilityFound error.
-func determineReturnErr(results models.VulnerabilityResults, showAllVulns bool, isContainerScanning bool) error {
+// ErrUncalledVulnerabilitiesFound is returned if the only vulnerabilities
+// found are uncalled, which callers only report if asked to.
+func determineReturnErr(
+	results models.VulnerabilityResults,
+	configManager *config.Manager,
+	severityThresholds config.SeverityThresholds,
+	epssThreshold float64,
+	kevOnly bool,
+	showAllVulns bool,
+	isContainerScanning bool,
+) error {
 	if len(results.Results) > 0 {
 		var vuln bool
 		onlyUnimportantVuln := true
 		var licenseViolation bool
 		for _, vf := range results.Flatten() {
-			if vf.Vulnerability.ID != "" {
+			if vf.Vulnerability.ID != "" && vf.GroupInfo.Suppression == nil && meetsSeverityThreshold(vf, configManager, severityThresholds) && meetsEPSSThreshold(vf, epssThreshold) && meetsKEVRequirement(vf, kevOnly) {
 				vuln = true
 				// TODO(gongh): rewrite the logic once we support reachability analysis for container scanning.
 				if !isContainerScanning && vf.GroupInfo.IsCalled() {
@@ -476,8 +1127,15 @@ func determineReturnErr(results models.VulnerabilityResults, showAllVulns bool,
 		// If the user didn't enable showing all vulns and we only found unimportant ones,
 		// we should return without error.
 		if !showAllVulns && onlyUnimportantVuln {
-			// There is no error.
-			return nil
+			// There is no error, though callers can choose to report it.
+			return ErrUncalledVulnerabilitiesFound
+		}
+
+		switch {
+		case vuln && licenseViolation:
+			return errors.Join(ErrVulnerabilitiesFound, ErrLicenseViolationsFound)
+		case licenseViolation:
+			return ErrLicenseViolationsFound
 		}
 
 		return ErrVulnerabilitiesFound
@@ -486,30 +1144,132 @@ func determineReturnErr(results models.VulnerabilityResults, showAllVulns bool,
 	return nil
 }
 
-// TODO(V2): Add context
+// meetsSeverityThreshold checks if the vulnerability is severe enough to fail the scan,
+// based on the thresholds of the dependency groups of the package, with the thresholds
+// given by flags taking precedence over those in the config
+func meetsSeverityThreshold(vf models.VulnerabilityFlattened, configManager *config.Manager, flagThresholds config.SeverityThresholds) bool {
+	thresholds := configManager.Get(vf.Source.Path).FailOnSeverity.Merge(flagThresholds)
+
+	rating, _ := severity.CalculateRating(vf.GroupInfo.MaxSeverity)
+
+	return thresholds.ShouldFail(rating, vf.DepGroups)
+}
+
+// makeVulnRequestWithMatcher queries the matcher once for each unique package,
+// and then fans the results back out to every package scan result with that package,
+// as the same package is often found in many different lockfiles.
+//
+// Packages that the matcher was unable to match are moved to the unscanned packages,
+// rather than failing the whole scan, unless none of the packages could be matched.
 func makeVulnRequestWithMatcher(
-	packages []imodels.PackageScanResult,
-	matcher clientinterfaces.VulnerabilityMatcher) error {
+	ctx context.Context,
+	scanResults *results.ScanResults,
+	matcher clientinterfaces.VulnerabilityMatcher,
+	reporter *progress.Reporter) error {
+	packages := scanResults.PackageScanResults
 	invs := make([]*extractor.Package, 0, len(packages))
-	for _, pkgs := range packages {
-		invs = append(invs, pkgs.PackageInfo.Package)
+	// invIndexes maps each package scan result to the index of its unique package in invs
+	invIndexes := make([]int, len(packages))
+	seen := make(map[string]int)
+
+	for i, pkgs := range packages {
+		key := matchKey(pkgs.PackageInfo)
+
+		idx, ok := seen[key]
+		if !ok {
+			idx = len(invs)
+			seen[key] = idx
+			invs = append(invs, pkgs.PackageInfo.Package)
+		}
+
+		invIndexes[i] = idx
 	}
 
-	res, err := matcher.MatchVulnerabilities(context.Background(), invs)
-	if err != nil {
-		cmdlogger.Errorf("error when retrieving vulns: %v", err)
+	if len(invs) < len(packages) {
+		cmdlogger.Debugf("Querying %d unique packages out of %d packages found", len(invs), len(packages))
+	}
+
+	reporter.Start(progress.PhaseQuerying, "packages", len(invs))
+	res, err := matcher.MatchVulnerabilities(ctx, invs)
+	reporter.Finish(progress.PhaseQuerying)
+
+	// unmatched maps the index of each package that could not be matched to the reason why
+	unmatched := make(map[int]error)
+	var unmatchedErr *osvmatcher.UnmatchedPackagesError
+	if errors.As(err, &unmatchedErr) {
+		for _, failure := range unmatchedErr.Failures {
+			cmdlogger.Warnf(
+				"Failed to check %d %s %s for vulnerabilities: %v",
+				len(failure.Indexes),
+				osvmatcher.EcosystemName(failure.Ecosystem),
+				output.Form(len(failure.Indexes), "package", "packages"),
+				failure.Err,
+			)
+			scanResults.Diagnostics = append(scanResults.Diagnostics, models.Diagnostic{
+				Kind:   models.DiagnosticAPIFailure,
+				Source: "vulnerabilities",
+				Message: fmt.Sprintf(
+					"failed to check %d %s %s for vulnerabilities: %v",
+					len(failure.Indexes),
+					osvmatcher.EcosystemName(failure.Ecosystem),
+					output.Form(len(failure.Indexes), "package", "packages"),
+					failure.Err,
+				),
+			})
+			for _, idx := range failure.Indexes {
+				unmatched[idx] = failure.Err
+			}
+		}
+
+		// the scan has nothing to report if none of the packages could be checked
+		if len(unmatched) == len(invs) {
+			return fmt.Errorf("%w: %w", ErrAPIFailed, err)
+		}
+	} else if err != nil {
 		if res == nil {
-			return err
+			return fmt.Errorf("%w: %w", ErrAPIFailed, err)
 		}
+		cmdlogger.Errorf("error when retrieving vulns: %v", err)
+		scanResults.Diagnostics = append(scanResults.Diagnostics, models.Diagnostic{
+			Kind:    models.DiagnosticPartialResult,
+			Source:  "vulnerabilities",
+			Message: "failed to retrieve the vulnerabilities of every package: " + err.Error(),
+		})
 	}
 
-	for i, vulns := range res {
-		packages[i].Vulnerabilities = vulns
+	matched := make([]imodels.PackageScanResult, 0, len(packages))
+	for i, idx := range invIndexes {
+		if reason, isUnmatched := unmatched[idx]; isUnmatched {
+			unscanned := newUnscannedPackage(packages[i].PackageInfo, models.UnscannedReasonQueryFailed)
+			unscanned.Error = reason.Error()
+			scanResults.UnscannedPackages = append(scanResults.UnscannedPackages, unscanned)
+
+			continue
+		}
+
+		if idx < len(res) {
+			// each package gets its own slice so that results can be modified independently
+			packages[i].Vulnerabilities = slices.Clone(res[idx])
+		}
+
+		matched = append(matched, packages[i])
 	}
+	scanResults.PackageScanResults = matched
 
 	return nil
 }
 
+// matchKey returns a key which is the same for packages that will be matched
+// against the same vulnerabilities
+func matchKey(pkg imodels.PackageInfo) string {
+	return strings.Join([]string{
+		pkg.Ecosystem().String(),
+		pkg.Name(),
+		pkg.Version(),
+		pkg.Commit(),
+	}, "\x00")
+}
+
 // Overrides Go version using osv-scanner.toml
 func overrideGoVersion(scanResults *results.ScanResults) {
 	for i, psr := range scanResults.PackageScanResults {
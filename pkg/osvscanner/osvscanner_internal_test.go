@@ -0,0 +1,736 @@
+package osvscanner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/internal/kev"
+	"github.com/google/osv-scanner/v2/internal/utility/severity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// countingMatcher returns one vulnerability per package, named after the package,
+// while recording the packages it was asked to match
+type countingMatcher struct {
+	queried []*extractor.Package
+}
+
+func (m *countingMatcher) MatchVulnerabilities(_ context.Context, invs []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	m.queried = append(m.queried, invs...)
+
+	res := make([][]*osvschema.Vulnerability, len(invs))
+	for i, inv := range invs {
+		res[i] = []*osvschema.Vulnerability{{ID: "OSV-" + inv.Name + "@" + inv.Version}}
+	}
+
+	return res, nil
+}
+
+func Test_makeVulnRequestWithMatcher_Deduplicates(t *testing.T) {
+	t.Parallel()
+
+	newPSR := func(name, version, location string) imodels.PackageScanResult {
+		return imodels.PackageScanResult{
+			PackageInfo: imodels.FromInventory(&extractor.Package{
+				Name:      name,
+				Version:   version,
+				PURLType:  purl.TypeNPM,
+				Locations: []string{location},
+			}),
+		}
+	}
+
+	scanResults := results.ScanResults{
+		PackageScanResults: []imodels.PackageScanResult{
+			newPSR("lodash", "4.17.20", "a/package-lock.json"),
+			newPSR("lodash", "4.17.20", "b/package-lock.json"),
+			newPSR("lodash", "4.17.21", "b/package-lock.json"),
+			newPSR("lodash", "4.17.20", "c/package-lock.json"),
+		},
+	}
+
+	matcher := &countingMatcher{}
+	if err := makeVulnRequestWithMatcher(context.Background(), &scanResults, matcher, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(matcher.queried) != 2 {
+		t.Errorf("expected 2 unique packages to be queried, got %d", len(matcher.queried))
+	}
+
+	want := []string{
+		"OSV-lodash@4.17.20",
+		"OSV-lodash@4.17.20",
+		"OSV-lodash@4.17.21",
+		"OSV-lodash@4.17.20",
+	}
+
+	for i, psr := range scanResults.PackageScanResults {
+		if len(psr.Vulnerabilities) != 1 || psr.Vulnerabilities[0].ID != want[i] {
+			t.Errorf("package %d: expected vulnerability %s, got %v", i, want[i], psr.Vulnerabilities)
+		}
+	}
+}
+
+// partialMatcher fails to match the packages with the given names, as if the
+// chunk of the batch query they were in had failed
+type partialMatcher struct {
+	failing []string
+}
+
+func (m *partialMatcher) MatchVulnerabilities(_ context.Context, invs []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	res := make([][]*osvschema.Vulnerability, len(invs))
+	var failed []int
+	for i, inv := range invs {
+		if slices.Contains(m.failing, inv.Name) {
+			failed = append(failed, i)
+			continue
+		}
+		res[i] = []*osvschema.Vulnerability{{ID: "OSV-" + inv.Name}}
+	}
+
+	return res, &osvmatcher.UnmatchedPackagesError{
+		Failures: []osvmatcher.EcosystemFailure{
+			{Ecosystem: osvschema.EcosystemNPM, Indexes: failed, Err: errors.New("502 Bad Gateway")},
+		},
+	}
+}
+
+func Test_makeVulnRequestWithMatcher_UnmatchedPackages(t *testing.T) {
+	t.Parallel()
+
+	newPSR := func(name, location string) imodels.PackageScanResult {
+		return imodels.PackageScanResult{
+			PackageInfo: imodels.FromInventory(&extractor.Package{
+				Name:      name,
+				Version:   "1.0.0",
+				PURLType:  purl.TypeNPM,
+				Locations: []string{location},
+			}),
+		}
+	}
+
+	scanResults := results.ScanResults{
+		PackageScanResults: []imodels.PackageScanResult{
+			newPSR("lodash", "a/package-lock.json"),
+			newPSR("express", "a/package-lock.json"),
+			newPSR("express", "b/package-lock.json"),
+		},
+	}
+
+	matcher := &partialMatcher{failing: []string{"express"}}
+	if err := makeVulnRequestWithMatcher(context.Background(), &scanResults, matcher, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(scanResults.PackageScanResults) != 1 || scanResults.PackageScanResults[0].PackageInfo.Name() != "lodash" {
+		t.Errorf("expected only lodash to be matched, got %v", scanResults.PackageScanResults)
+	}
+
+	want := []models.UnscannedPackage{
+		{
+			Package: models.PackageInfo{Name: "express", Version: "1.0.0", Ecosystem: "npm"},
+			Source:  models.SourceInfo{Path: "a/package-lock.json", Type: models.SourceTypeUnknown},
+			Reason:  models.UnscannedReasonQueryFailed,
+			Error:   "502 Bad Gateway",
+		},
+		{
+			Package: models.PackageInfo{Name: "express", Version: "1.0.0", Ecosystem: "npm"},
+			Source:  models.SourceInfo{Path: "b/package-lock.json", Type: models.SourceTypeUnknown},
+			Reason:  models.UnscannedReasonQueryFailed,
+			Error:   "502 Bad Gateway",
+		},
+	}
+
+	if diff := cmp.Diff(want, scanResults.UnscannedPackages); diff != "" {
+		t.Errorf("unexpected unscanned packages (-want +got):\n%s", diff)
+	}
+
+	wantDiagnostics := []models.Diagnostic{
+		{
+			Kind:    models.DiagnosticAPIFailure,
+			Source:  "vulnerabilities",
+			Message: "failed to check 1 npm package for vulnerabilities: 502 Bad Gateway",
+		},
+	}
+
+	if diff := cmp.Diff(wantDiagnostics, scanResults.Diagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+	}
+}
+
+func Test_makeVulnRequestWithMatcher_NoPackagesMatched(t *testing.T) {
+	t.Parallel()
+
+	scanResults := results.ScanResults{
+		PackageScanResults: []imodels.PackageScanResult{
+			{
+				PackageInfo: imodels.FromInventory(&extractor.Package{
+					Name:      "express",
+					Version:   "1.0.0",
+					PURLType:  purl.TypeNPM,
+					Locations: []string{"package-lock.json"},
+				}),
+			},
+		},
+	}
+
+	matcher := &partialMatcher{failing: []string{"express"}}
+	err := makeVulnRequestWithMatcher(context.Background(), &scanResults, matcher, nil)
+
+	if !errors.Is(err, ErrAPIFailed) {
+		t.Errorf("makeVulnRequestWithMatcher() error = %v, want %v", err, ErrAPIFailed)
+	}
+}
+
+func Test_hasFailedQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		reasons []models.UnscannedReason
+		want    bool
+	}{
+		{name: "no unscanned packages", want: false},
+		{name: "unsupported packages", reasons: []models.UnscannedReason{models.UnscannedReasonMissingVersion}, want: false},
+		{
+			name:    "failed queries",
+			reasons: []models.UnscannedReason{models.UnscannedReasonMissingVersion, models.UnscannedReasonQueryFailed},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var vulnResults models.VulnerabilityResults
+			for _, reason := range tt.reasons {
+				vulnResults.UnscannedPackages = append(vulnResults.UnscannedPackages, models.UnscannedPackage{Reason: reason})
+			}
+
+			if got := hasFailedQueries(vulnResults); got != tt.want {
+				t.Errorf("hasFailedQueries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsFindingErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "no error", err: nil, want: false},
+		{name: "vulnerabilities found", err: ErrVulnerabilitiesFound, want: true},
+		{name: "license violations found", err: ErrLicenseViolationsFound, want: true},
+		{name: "only ignored vulnerabilities found", err: ErrIgnoredVulnerabilitiesFound, want: true},
+		{name: "only uncalled vulnerabilities found", err: ErrUncalledVulnerabilitiesFound, want: true},
+		{name: "some packages could not be checked", err: ErrPartialAPIFailure, want: true},
+		{name: "findings alongside failed queries", err: errors.Join(ErrVulnerabilitiesFound, ErrPartialAPIFailure), want: true},
+		{name: "api failed", err: ErrAPIFailed, want: false},
+		{name: "no packages found", err: ErrNoPackagesFound, want: false},
+		{name: "other error", err: errors.New("something went wrong"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsFindingErr(tt.err); got != tt.want {
+				t.Errorf("IsFindingErr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_determineReturnErr_SeverityThresholds(t *testing.T) {
+	t.Parallel()
+
+	vulnPackage := func(name string, maxSeverity string, depGroups ...string) models.PackageVulns {
+		return models.PackageVulns{
+			Package:         models.PackageInfo{Name: name, Version: "1.0.0", Ecosystem: "npm"},
+			DepGroups:       depGroups,
+			Vulnerabilities: []osvschema.Vulnerability{{ID: "GHSA-" + name}},
+			Groups:          []models.GroupInfo{{IDs: []string{"GHSA-" + name}, MaxSeverity: maxSeverity}},
+		}
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{
+				vulnPackage("prod", "5.3"),
+				vulnPackage("dev", "8.1", "dev"),
+			},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		config     config.Config
+		thresholds config.SeverityThresholds
+		want       error
+	}{
+		{
+			name: "no thresholds",
+			want: ErrVulnerabilitiesFound,
+		},
+		{
+			name:       "all vulnerabilities are below the thresholds",
+			thresholds: config.SeverityThresholds{Default: severity.HighRating, DepGroups: map[string]severity.Rating{"dev": severity.CriticalRating}},
+			want:       nil,
+		},
+		{
+			name:       "dev dependency meets the default threshold",
+			thresholds: config.SeverityThresholds{Default: severity.HighRating},
+			want:       ErrVulnerabilitiesFound,
+		},
+		{
+			name:   "thresholds from the config are used",
+			config: config.Config{FailOnSeverity: config.SeverityThresholds{Default: severity.HighRating, DepGroups: map[string]severity.Rating{"dev": severity.CriticalRating}}},
+			want:   nil,
+		},
+		{
+			name:       "thresholds from flags take precedence over the config",
+			config:     config.Config{FailOnSeverity: config.SeverityThresholds{Default: severity.HighRating, DepGroups: map[string]severity.Rating{"dev": severity.CriticalRating}}},
+			thresholds: config.SeverityThresholds{DepGroups: map[string]severity.Rating{"dev": severity.HighRating}},
+			want:       ErrVulnerabilitiesFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configManager := config.Manager{
+				OverrideConfig: &tt.config,
+				ConfigMap:      make(map[string]config.Config),
+			}
+
+			err := determineReturnErr(results, &configManager, tt.thresholds, 0, false, false, false)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("determineReturnErr() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_determineReturnErr_UncalledVulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	results := func(called bool) models.VulnerabilityResults {
+		return models.VulnerabilityResults{
+			Results: []models.PackageSource{{
+				Source: models.SourceInfo{Path: "/path/to/go.mod", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{{
+					Package:         models.PackageInfo{Name: "golang.org/x/net", Version: "0.1.0", Ecosystem: "Go"},
+					Vulnerabilities: []osvschema.Vulnerability{{ID: "GO-2023-1"}},
+					Groups: []models.GroupInfo{{
+						IDs:                  []string{"GO-2023-1"},
+						ExperimentalAnalysis: map[string]models.AnalysisInfo{"GO-2023-1": {Called: called}},
+					}},
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		called       bool
+		showAllVulns bool
+		want         error
+	}{
+		{
+			name:   "called vulnerabilities",
+			called: true,
+			want:   ErrVulnerabilitiesFound,
+		},
+		{
+			name:   "only uncalled vulnerabilities",
+			called: false,
+			want:   ErrUncalledVulnerabilitiesFound,
+		},
+		{
+			name:         "uncalled vulnerabilities when showing all vulnerabilities",
+			called:       false,
+			showAllVulns: true,
+			want:         ErrVulnerabilitiesFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configManager := config.Manager{ConfigMap: make(map[string]config.Config)}
+
+			err := determineReturnErr(results(tt.called), &configManager, config.SeverityThresholds{}, 0, false, tt.showAllVulns, false)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("determineReturnErr() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+type fakeEPSSMatcher map[string]models.EPSSScore
+
+func (m fakeEPSSMatcher) MatchEPSS(_ context.Context, cves []string) (map[string]models.EPSSScore, error) {
+	scores := make(map[string]models.EPSSScore)
+	for _, cve := range cves {
+		if score, ok := m[cve]; ok {
+			scores[cve] = score
+		}
+	}
+
+	return scores, nil
+}
+
+func Test_enrichEPSS(t *testing.T) {
+	t.Parallel()
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Packages: []models.PackageVulns{{
+				Groups: []models.GroupInfo{
+					{IDs: []string{"GHSA-1"}, Aliases: []string{"GHSA-1", "CVE-2024-1", "CVE-2024-2"}},
+					{IDs: []string{"CVE-2024-3"}, Aliases: []string{"CVE-2024-3"}},
+					{IDs: []string{"GHSA-2"}, Aliases: []string{"GHSA-2", "CVE-2024-4"}},
+				},
+			}},
+		}},
+	}
+
+	matcher := fakeEPSSMatcher{
+		"CVE-2024-1": {CVE: "CVE-2024-1", Score: 0.2, Percentile: 0.7},
+		"CVE-2024-2": {CVE: "CVE-2024-2", Score: 0.6, Percentile: 0.9},
+		"CVE-2024-3": {CVE: "CVE-2024-3", Score: 0.01, Percentile: 0.1},
+	}
+
+	if err := enrichEPSS(context.Background(), matcher, &results); err != nil {
+		t.Fatalf("enrichEPSS() error = %v", err)
+	}
+
+	want := []*models.EPSSScore{
+		{CVE: "CVE-2024-2", Score: 0.6, Percentile: 0.9},
+		{CVE: "CVE-2024-3", Score: 0.01, Percentile: 0.1},
+		nil,
+	}
+
+	got := make([]*models.EPSSScore, 0, len(want))
+	for _, group := range results.Results[0].Packages[0].Groups {
+		got = append(got, group.EPSS)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("enrichEPSS() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_determineReturnErr_EPSSThreshold(t *testing.T) {
+	t.Parallel()
+
+	vulnPackage := func(name string, epss *models.EPSSScore) models.PackageVulns {
+		return models.PackageVulns{
+			Package:         models.PackageInfo{Name: name, Version: "1.0.0", Ecosystem: "npm"},
+			Vulnerabilities: []osvschema.Vulnerability{{ID: "GHSA-" + name}},
+			Groups:          []models.GroupInfo{{IDs: []string{"GHSA-" + name}, EPSS: epss}},
+		}
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{
+				vulnPackage("likely", &models.EPSSScore{CVE: "CVE-2024-1", Score: 0.7}),
+				vulnPackage("unlikely", &models.EPSSScore{CVE: "CVE-2024-2", Score: 0.02}),
+				vulnPackage("unscored", nil),
+			},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		want      error
+	}{
+		{
+			name: "no threshold",
+			want: ErrVulnerabilitiesFound,
+		},
+		{
+			name:      "vulnerability meets the threshold",
+			threshold: 0.5,
+			want:      ErrVulnerabilitiesFound,
+		},
+		{
+			name:      "threshold is inclusive",
+			threshold: 0.7,
+			want:      ErrVulnerabilitiesFound,
+		},
+		{
+			name:      "all vulnerabilities are below the threshold",
+			threshold: 0.9,
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configManager := config.Manager{
+				OverrideConfig: &config.Config{},
+				ConfigMap:      make(map[string]config.Config),
+			}
+
+			err := determineReturnErr(results, &configManager, config.SeverityThresholds{}, tt.threshold, false, false, false)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("determineReturnErr() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_determineReturnErr_KEV(t *testing.T) {
+	t.Parallel()
+
+	vulnPackage := func(name string, entry *models.KEVEntry) models.PackageVulns {
+		return models.PackageVulns{
+			Package:         models.PackageInfo{Name: name, Version: "1.0.0", Ecosystem: "npm"},
+			Vulnerabilities: []osvschema.Vulnerability{{ID: "GHSA-" + name}},
+			Groups:          []models.GroupInfo{{IDs: []string{"GHSA-" + name}, KEV: entry}},
+		}
+	}
+
+	resultsWith := func(pkgs ...models.PackageVulns) models.VulnerabilityResults {
+		return models.VulnerabilityResults{
+			Results: []models.PackageSource{{
+				Source:   models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: pkgs,
+			}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		results models.VulnerabilityResults
+		kevOnly bool
+		want    error
+	}{
+		{
+			name:    "all vulnerabilities fail the scan by default",
+			results: resultsWith(vulnPackage("unexploited", nil)),
+			want:    ErrVulnerabilitiesFound,
+		},
+		{
+			name:    "exploited vulnerability fails the scan",
+			results: resultsWith(vulnPackage("unexploited", nil), vulnPackage("exploited", &models.KEVEntry{CVE: "CVE-2021-44228"})),
+			kevOnly: true,
+			want:    ErrVulnerabilitiesFound,
+		},
+		{
+			name:    "unexploited vulnerabilities do not fail the scan",
+			results: resultsWith(vulnPackage("unexploited", nil)),
+			kevOnly: true,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configManager := config.Manager{
+				OverrideConfig: &config.Config{},
+				ConfigMap:      make(map[string]config.Config),
+			}
+
+			err := determineReturnErr(tt.results, &configManager, config.SeverityThresholds{}, 0, tt.kevOnly, false, false)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("determineReturnErr() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flagKEV(t *testing.T) {
+	t.Parallel()
+
+	catalog, err := kev.Bundled()
+	if err != nil {
+		t.Fatalf("kev.Bundled() error = %v", err)
+	}
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Packages: []models.PackageVulns{{
+				Groups: []models.GroupInfo{
+					{IDs: []string{"GHSA-jfh8-c2jp-5v3q"}, Aliases: []string{"GHSA-jfh8-c2jp-5v3q", "CVE-2021-44228"}},
+					{IDs: []string{"GHSA-1"}, Aliases: []string{"GHSA-1", "CVE-2024-0000"}},
+				},
+			}},
+		}},
+	}
+
+	flagKEV(catalog, &results)
+
+	groups := results.Results[0].Packages[0].Groups
+	if groups[0].KEV == nil || groups[0].KEV.CVE != "CVE-2021-44228" {
+		t.Errorf("flagKEV() did not flag the exploited vulnerability, got %v", groups[0].KEV)
+	}
+	if groups[1].KEV != nil {
+		t.Errorf("flagKEV() flagged a vulnerability that is not in the catalog, got %v", groups[1].KEV)
+	}
+}
+
+func Test_addFixReferences(t *testing.T) {
+	t.Parallel()
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Packages: []models.PackageVulns{{
+				Vulnerabilities: []osvschema.Vulnerability{
+					{ID: "GHSA-1", References: []osvschema.Reference{{Type: osvschema.ReferenceFix, URL: "https://github.com/owner/repo/pull/1"}}},
+					{ID: "GHSA-2", References: []osvschema.Reference{{Type: osvschema.ReferenceWeb, URL: "https://example.com"}}},
+				},
+				Groups: []models.GroupInfo{
+					{IDs: []string{"GHSA-1"}},
+					{IDs: []string{"GHSA-2"}},
+				},
+			}},
+		}},
+	}
+
+	addFixReferences(&results)
+
+	want := []*models.FixReferences{
+		{PatchURLs: []string{"https://github.com/owner/repo/pull/1"}},
+		nil,
+	}
+
+	got := make([]*models.FixReferences, 0, len(want))
+	for _, group := range results.Results[0].Packages[0].Groups {
+		got = append(got, group.FixReferences)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("addFixReferences() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_calculateRiskScore(t *testing.T) {
+	t.Parallel()
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Packages: []models.PackageVulns{
+				{
+					Groups: []models.GroupInfo{
+						// critical
+						{IDs: []string{"GHSA-1"}, MaxSeverity: "9.8"},
+						// medium, with a 50% chance of being exploited
+						{IDs: []string{"GHSA-2"}, MaxSeverity: "5.0", EPSS: &models.EPSSScore{CVE: "CVE-2", Score: 0.5}},
+					},
+				},
+				{
+					Groups: []models.GroupInfo{
+						// low, and known to have been exploited
+						{IDs: []string{"GHSA-3"}, MaxSeverity: "2.0", KEV: &models.KEVEntry{CVE: "CVE-3"}},
+						// unknown severity, but not called
+						{IDs: []string{"GO-4"}, ExperimentalAnalysis: map[string]models.AnalysisInfo{"GO-4": {Called: false}}},
+						// license violations are not vulnerabilities
+						{},
+					},
+				},
+			},
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		showAllVulns bool
+		want         models.RiskScore
+	}{
+		{
+			name: "uncalled vulnerabilities are not counted",
+			want: models.RiskScore{Score: 15, MaxScore: 20},
+		},
+		{
+			name:         "uncalled vulnerabilities are counted when showing all vulnerabilities",
+			showAllVulns: true,
+			want:         models.RiskScore{Score: 16, MaxScore: 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := calculateRiskScore(results, 20, tt.showAllVulns, false)
+
+			if diff := cmp.Diff(tt.want, *got); diff != "" {
+				t.Errorf("calculateRiskScore() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_determineRiskScoreReturnErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		results models.VulnerabilityResults
+		want    error
+	}{
+		{
+			name: "score below the maximum does not fail the scan",
+			results: models.VulnerabilityResults{
+				Results: []models.PackageSource{{
+					Packages: []models.PackageVulns{{
+						Vulnerabilities: []osvschema.Vulnerability{{ID: "GHSA-1"}},
+						Groups:          []models.GroupInfo{{IDs: []string{"GHSA-1"}}},
+					}},
+				}},
+				RiskScore: &models.RiskScore{Score: 5, MaxScore: 10},
+			},
+			want: nil,
+		},
+		{
+			name: "score above the maximum fails the scan",
+			results: models.VulnerabilityResults{
+				RiskScore: &models.RiskScore{Score: 12.5, MaxScore: 10},
+			},
+			want: ErrVulnerabilitiesFound,
+		},
+		{
+			name: "license violations fail the scan regardless of the score",
+			results: models.VulnerabilityResults{
+				Results: []models.PackageSource{{
+					Packages: []models.PackageVulns{{LicenseViolations: []models.License{"MIT"}}},
+				}},
+				RiskScore: &models.RiskScore{Score: 0, MaxScore: 10},
+			},
+			want: ErrLicenseViolationsFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := determineRiskScoreReturnErr(tt.results)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("determineRiskScoreReturnErr() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
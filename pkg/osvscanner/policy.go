@@ -0,0 +1,41 @@
+package osvscanner
+
+import (
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/policy"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// loadPolicy compiles the policy at path, returning nil if there is no policy
+func loadPolicy(path string) (*policy.Policy, error) {
+	if path == "" {
+		return nil, nil //nolint:nilnil // there being no policy is not an error
+	}
+
+	return policy.Load(path)
+}
+
+// evaluatePolicy records the outcome of evaluating the results against the policy in
+// the results, returning ErrPolicyViolated if they did not pass
+func evaluatePolicy(pol *policy.Policy, results *models.VulnerabilityResults) error {
+	result, err := pol.Evaluate(*results)
+	if err != nil {
+		return err
+	}
+
+	results.Policy = &result
+
+	if result.Passed {
+		for _, msg := range result.Messages {
+			cmdlogger.Infof("Policy: %s", msg)
+		}
+
+		return nil
+	}
+
+	for _, msg := range result.Messages {
+		cmdlogger.Errorf("Policy violation: %s", msg)
+	}
+
+	return ErrPolicyViolated
+}
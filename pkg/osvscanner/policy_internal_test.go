@@ -0,0 +1,61 @@
+package osvscanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/policy"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func Test_evaluatePolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    *models.PolicyResult
+		wantErr error
+	}{
+		{
+			name: "passes",
+			expr: `results.results.size() == 1`,
+			want: &models.PolicyResult{Path: "policy.cel", Passed: true},
+		},
+		{
+			name:    "violated",
+			expr:    `["no lockfiles are allowed"]`,
+			want:    &models.PolicyResult{Path: "policy.cel", Passed: false, Messages: []string{"no lockfiles are allowed"}},
+			wantErr: ErrPolicyViolated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pol, err := policy.Compile("policy.cel", tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			results := models.VulnerabilityResults{
+				Results: []models.PackageSource{{Source: models.SourceInfo{Path: "/dir/package-lock.json"}}},
+			}
+
+			err = evaluatePolicy(pol, &results)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("evaluatePolicy() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, results.Policy); diff != "" {
+				t.Errorf("evaluatePolicy() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if !errors.Is(ErrPolicyViolated, ErrVulnerabilitiesFound) {
+		t.Errorf("expected ErrPolicyViolated to be an ErrVulnerabilitiesFound")
+	}
+}
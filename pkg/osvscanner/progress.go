@@ -0,0 +1,64 @@
+package osvscanner
+
+import (
+	"io"
+	"os"
+
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/progress"
+	"golang.org/x/term"
+)
+
+// newProgressReporter returns a reporter for the progress of the scan if it has been
+// enabled or heartbeats are to be reported, which renders a live status line if both
+// stdout and the progress writer are terminals, and otherwise emits json events for tools
+// that are running the scanner, so that control characters are never written into files
+// or piped output.
+//
+// The reporter must be closed with closeProgressReporter once the scan is done.
+func newProgressReporter(actions ScannerActions, accessors ExternalAccessors) *progress.Reporter {
+	w := actions.ProgressWriter
+	if w == nil {
+		w = os.Stderr
+	}
+
+	interactive := term.IsTerminal(int(os.Stdout.Fd())) && isTerminal(w)
+
+	// heartbeats are only needed if nobody is watching the scan in a terminal
+	heartbeat := actions.HeartbeatInterval > 0 && !interactive
+	if !actions.ShowProgress && !heartbeat {
+		return nil
+	}
+
+	reporter := progress.New(w, interactive)
+	reporter.Heartbeat(actions.HeartbeatInterval, actions.ProgressTargets)
+
+	// logs are written on their own line, which the status line is redrawn after
+	if interactive {
+		cmdlogger.ClearLineBeforeLogs(true)
+	}
+
+	if matcher, ok := accessors.VulnMatcher.(*osvmatcher.OSVMatcher); ok {
+		matcher.Progress = func(packages int) {
+			reporter.Add(progress.PhaseQuerying, packages)
+		}
+	}
+
+	return reporter
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+func closeProgressReporter(reporter *progress.Reporter) {
+	if reporter == nil {
+		return
+	}
+
+	reporter.Close()
+	cmdlogger.ClearLineBeforeLogs(false)
+}
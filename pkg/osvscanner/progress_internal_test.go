@@ -0,0 +1,68 @@
+package osvscanner
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/progress"
+)
+
+func Test_newProgressReporter_NotATerminal(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	reporter := newProgressReporter(ScannerActions{ShowProgress: true, ProgressWriter: &buf}, ExternalAccessors{})
+	reporter.Start(progress.PhaseQuerying, "packages", 2)
+	reporter.Add(progress.PhaseQuerying, 2)
+	reporter.Finish(progress.PhaseQuerying)
+	closeProgressReporter(reporter)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		t.Fatalf("newProgressReporter() did not report any progress")
+	}
+
+	// anything other than json events would corrupt the output of tools reading it
+	for _, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("newProgressReporter() wrote a line that is not a json event: %q", line)
+		}
+	}
+}
+
+func Test_newProgressReporter_Disabled(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	reporter := newProgressReporter(ScannerActions{ProgressWriter: &buf}, ExternalAccessors{})
+	reporter.Start(progress.PhaseQuerying, "packages", 2)
+	closeProgressReporter(reporter)
+
+	if buf.Len() != 0 {
+		t.Errorf("newProgressReporter() wrote %q, want nothing", buf.String())
+	}
+}
+
+func Test_newProgressReporter_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	// heartbeats report progress even if it has not been asked for
+	reporter := newProgressReporter(ScannerActions{ProgressWriter: &buf, HeartbeatInterval: time.Hour}, ExternalAccessors{})
+	if reporter == nil {
+		t.Fatalf("newProgressReporter() = nil, want a reporter for heartbeats")
+	}
+
+	reporter.Start(progress.PhaseLoading, "", 0)
+	closeProgressReporter(reporter)
+
+	if !strings.Contains(buf.String(), `"phase":"loading"`) {
+		t.Errorf("newProgressReporter() wrote %q, want the phases of the scan", buf.String())
+	}
+}
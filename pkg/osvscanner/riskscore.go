@@ -0,0 +1,96 @@
+package osvscanner
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/google/osv-scanner/v2/internal/utility/severity"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// riskWeights are how much a vulnerability of each severity rating adds to the risk score
+var riskWeights = map[severity.Rating]float64{
+	severity.CriticalRating: 10,
+	severity.HighRating:     5,
+	severity.MediumRating:   2,
+	severity.LowRating:      1,
+	severity.UnknownRating:  1,
+}
+
+// kevRiskMultiplier is how much more a vulnerability that is known to have been exploited adds to the risk score
+const kevRiskMultiplier = 2
+
+// calculateRiskScore combines the vulnerability groups of the results into a single score,
+// with each group adding the weight of its severity rating, which is increased by up to
+// double its EPSS score and doubled again if it is in the KEV catalog. Only the groups
+// that would fail the scan on their own are counted, so soft-suppressed vulnerabilities
+// never add to the score, and unimportant and uncalled vulnerabilities do not add to
+// the score unless all vulnerabilities are being shown.
+func calculateRiskScore(results models.VulnerabilityResults, maxScore float64, showAllVulns bool, isContainerScanning bool) *models.RiskScore {
+	var score float64
+
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			for _, group := range pkg.Groups {
+				if len(group.IDs) == 0 || group.Suppression != nil {
+					continue
+				}
+
+				if !showAllVulns {
+					if !isContainerScanning && !group.IsCalled() {
+						continue
+					}
+					if isContainerScanning && group.IsGroupUnimportant() {
+						continue
+					}
+				}
+
+				score += groupRisk(group)
+			}
+		}
+	}
+
+	return &models.RiskScore{
+		Score:    math.Round(score*100) / 100,
+		MaxScore: maxScore,
+	}
+}
+
+// groupRisk is how much the vulnerability group adds to the risk score
+func groupRisk(group models.GroupInfo) float64 {
+	rating, _ := severity.CalculateRating(group.MaxSeverity)
+
+	// ratings that are not weighted, such as NONE, do not add any risk
+	risk := riskWeights[rating]
+
+	if group.EPSS != nil {
+		risk *= 1 + group.EPSS.Score
+	}
+
+	if group.KEV != nil {
+		risk *= kevRiskMultiplier
+	}
+
+	return risk
+}
+
+// determineRiskScoreReturnErr determines whether the scan should fail based on its aggregate
+// risk score rather than any single vulnerability, though license violations still fail it
+func determineRiskScoreReturnErr(results models.VulnerabilityResults) error {
+	exceeded := results.RiskScore != nil && results.RiskScore.Score > results.RiskScore.MaxScore
+	licenseViolation := slices.ContainsFunc(results.Flatten(), func(vf models.VulnerabilityFlattened) bool {
+		return len(vf.LicenseViolations) > 0
+	})
+
+	switch {
+	case exceeded && licenseViolation:
+		return errors.Join(ErrVulnerabilitiesFound, ErrLicenseViolationsFound)
+	case exceeded:
+		return ErrVulnerabilitiesFound
+	case licenseViolation:
+		return ErrLicenseViolationsFound
+	}
+
+	return nil
+}
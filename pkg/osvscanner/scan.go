@@ -20,6 +20,7 @@ import (
 	"github.com/google/osv-scalibr/plugin"
 	"github.com/google/osv-scanner/v2/internal/builders"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/customgitignore"
 	"github.com/google/osv-scanner/v2/internal/imodels"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract"
 	"github.com/google/osv-scanner/v2/internal/scalibrextract/filesystem/vendored"
@@ -75,14 +76,14 @@ func getExtractors(defaultExtractorNames []string, accessors ExternalAccessors,
 }
 
 // scan essentially converts ScannerActions into PackageScanResult by performing the extractions
-func scan(accessors ExternalAccessors, actions ScannerActions) ([]imodels.PackageScanResult, error) {
+func scan(ctx context.Context, accessors ExternalAccessors, actions ScannerActions, statsCollector *extractorStatsCollector) ([]imodels.PackageScanResult, error) {
 	//nolint:prealloc // We don't know how many inventories we will retrieve
 	var scannedInventories []*extractor.Package
 
 	// --- Lockfiles ---
 	lockfileExtractors := getExtractors(scalibrextract.ExtractorsLockfiles, accessors, actions)
 	for _, lockfileElem := range actions.LockfilePaths {
-		invs, err := scanners.ScanSingleFileWithMapping(lockfileElem, lockfileExtractors)
+		invs, err := scanners.ScanSingleFileWithMapping(ctx, lockfileElem, lockfileExtractors)
 		if err != nil {
 			return nil, err
 		}
@@ -100,7 +101,7 @@ func scan(accessors ExternalAccessors, actions ScannerActions) ([]imodels.Packag
 			return nil, err
 		}
 
-		invs, err := scanners.ScanSingleFile(path, sbomExtractors)
+		invs, err := scanners.ScanSingleFile(ctx, path, sbomExtractors)
 		if err != nil {
 			cmdlogger.Infof("Failed to parse SBOM %q with error: %s", path, err)
 
@@ -114,6 +115,16 @@ func scan(accessors ExternalAccessors, actions ScannerActions) ([]imodels.Packag
 		scannedInventories = append(scannedInventories, invs...)
 	}
 
+	// --- PURL lists ---
+	for _, purlListPath := range actions.PURLListPaths {
+		invs, err := scanners.ScanPURLList(ctx, purlListPath)
+		if err != nil {
+			return nil, err
+		}
+
+		scannedInventories = append(scannedInventories, invs...)
+	}
+
 	// --- Directories ---
 
 	dirExtractors := getExtractors(
@@ -138,13 +149,24 @@ func scan(accessors ExternalAccessors, actions ScannerActions) ([]imodels.Packag
 			return nil, err
 		}
 
-		_, err = os.Stat(absPath)
+		info, err := os.Stat(absPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan dir: %w", err)
 		}
 
 		root := getRootDir(absPath)
 		rootMap[root] = append(rootMap[root], absPath)
+
+		if !info.IsDir() {
+			continue
+		}
+
+		for _, member := range goWorkspaceMemberPaths(absPath, actions.Recursive) {
+			memberRoot := getRootDir(member)
+			if !slices.Contains(rootMap[memberRoot], member) {
+				rootMap[memberRoot] = append(rootMap[memberRoot], member)
+			}
+		}
 	}
 
 	testlogger.BeginDirScanMarker()
@@ -165,25 +187,36 @@ func scan(accessors ExternalAccessors, actions ScannerActions) ([]imodels.Packag
 			capabilities.OS = plugin.OSWindows
 		}
 
-		sr := scanner.Scan(context.Background(), &scalibr.ScanConfig{
-			FilesystemExtractors:  dirExtractors,
+		ignores, err := readScannerIgnoreFiles(paths, actions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s files: %w", customgitignore.ScannerIgnoreFile, err)
+		}
+		for _, dir := range ignoredDirs(ignores) {
+			statsCollector.skipped(dir, "ignored by "+customgitignore.ScannerIgnoreFile)
+		}
+
+		sr := scanner.Scan(ctx, &scalibr.ScanConfig{
+			FilesystemExtractors:  skipIgnoredFiles(dirExtractors, root, ignores),
 			StandaloneExtractors:  nil,
 			Detectors:             nil,
 			Capabilities:          &capabilities,
 			ScanRoots:             fs.RealFSScanRoots(root),
 			PathsToExtract:        paths,
 			IgnoreSubDirs:         !actions.Recursive,
-			DirsToSkip:            nil,
+			DirsToSkip:            ignoredDirs(ignores),
 			SkipDirRegex:          nil,
 			SkipDirGlob:           nil,
 			UseGitignore:          !actions.NoIgnore,
-			Stats:                 FileOpenedPrinter{},
+			Stats:                 statsCollector,
 			ReadSymlinks:          false,
 			MaxInodes:             0,
 			StoreAbsolutePath:     true,
 			PrintDurationAnalysis: false,
 			ErrorOnFSErrors:       false,
 		})
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if sr.Status.Status != plugin.ScanStatusSucceeded {
 			return nil, errors.New(sr.Status.FailureReason)
 		}
@@ -210,6 +243,9 @@ func scan(accessors ExternalAccessors, actions ScannerActions) ([]imodels.Packag
 		return nil, ErrNoPackagesFound
 	}
 
+	scannedInventories = reconcileGoVendor(scannedInventories)
+	goWorkspaces := resolveGoWorkspaces(scannedInventories)
+
 	// Convert to imodels.PackageScanResult for use in the rest of osv-scanner
 	packages := []imodels.PackageScanResult{}
 	for _, inv := range scannedInventories {
@@ -217,6 +253,7 @@ func scan(accessors ExternalAccessors, actions ScannerActions) ([]imodels.Packag
 
 		packages = append(packages, imodels.PackageScanResult{
 			PackageInfo: pi,
+			GoWorkspace: goWorkspaces[inv],
 		})
 	}
 
@@ -0,0 +1,25 @@
+package osvscanner
+
+import (
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// labelSeverities labels the max severity of each vulnerability group with the
+// custom severity labels of the config of the source that the group was found in
+func labelSeverities(vulnResults *models.VulnerabilityResults, configManager *config.Manager) {
+	for i := range vulnResults.Results {
+		labels := configManager.Get(vulnResults.Results[i].Source.Path).SeverityLabels
+		if labels.IsEmpty() {
+			continue
+		}
+
+		for j := range vulnResults.Results[i].Packages {
+			pkg := &vulnResults.Results[i].Packages[j]
+
+			for k := range pkg.Groups {
+				pkg.Groups[k].SeverityLabel = labels.Label(pkg.Groups[k].MaxSeverity)
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package osvscanner
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func Test_labelSeverities(t *testing.T) {
+	t.Parallel()
+
+	vulnResults := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{{
+				Package: models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+				Groups: []models.GroupInfo{
+					{IDs: []string{"GHSA-1"}, MaxSeverity: "9.8"},
+					{IDs: []string{"GHSA-2"}, MaxSeverity: "7.5"},
+					{IDs: []string{"GHSA-3"}, MaxSeverity: "2.0"},
+					{IDs: []string{"GHSA-4"}, MaxSeverity: ""},
+				},
+			}},
+		}},
+	}
+
+	configManager := config.Manager{
+		OverrideConfig: &config.Config{
+			SeverityLabels: config.SeverityLabels{
+				Unknown: "Unrated",
+				Ranges: []config.SeverityLabelRange{
+					{Label: "Blocker", MinScore: 9},
+					{Label: "Major", MinScore: 7},
+					{Label: "Minor", MinScore: 4},
+				},
+			},
+		},
+		ConfigMap: make(map[string]config.Config),
+	}
+
+	labelSeverities(&vulnResults, &configManager)
+
+	got := make(map[string]string)
+	for _, group := range vulnResults.Results[0].Packages[0].Groups {
+		got[group.IDs[0]] = group.SeverityLabel
+	}
+
+	want := map[string]string{
+		"GHSA-1": "Blocker",
+		"GHSA-2": "Major",
+		"GHSA-3": "",
+		"GHSA-4": "Unrated",
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("labelSeverities() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,11 +1,19 @@
 package osvscanner
 
 import (
+	"cmp"
+	"context"
 	"path/filepath"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/google/osv-scalibr/stats"
 	"github.com/google/osv-scanner/v2/internal/cmdlogger"
 	"github.com/google/osv-scanner/v2/internal/output"
+	"github.com/google/osv-scanner/v2/internal/progress"
+	"github.com/google/osv-scanner/v2/internal/telemetry"
+	"github.com/google/osv-scanner/v2/pkg/models"
 )
 
 type FileOpenedPrinter struct {
@@ -28,3 +36,148 @@ func (c FileOpenedPrinter) AfterExtractorRun(_ string, extractorstats *stats.Aft
 		output.Form(pkgsFound, "package", "packages"),
 	)
 }
+
+// extractorStatsCollector records per-extractor counters and the files that could
+// not be extracted across one or more scalibr scans, optionally printing opened
+// files like FileOpenedPrinter and reporting the progress of walking and extracting
+type extractorStatsCollector struct {
+	stats.NoopCollector
+
+	// ctx is the context of the span that the extraction of each file is traced under
+	ctx            context.Context
+	logOpenedFiles bool
+	progress       *progress.Reporter
+
+	mu              sync.Mutex
+	filesConsidered int
+	extractors      map[string]*models.ExtractorStats
+	// durations are the total runtimes of the extractors, which are only converted
+	// to milliseconds once they have been summed to avoid truncating each run
+	durations   map[string]time.Duration
+	diagnostics []models.Diagnostic
+}
+
+var _ stats.Collector = &extractorStatsCollector{}
+
+func newExtractorStatsCollector(ctx context.Context, logOpenedFiles bool, reporter *progress.Reporter) *extractorStatsCollector {
+	return &extractorStatsCollector{
+		ctx:            ctx,
+		logOpenedFiles: logOpenedFiles,
+		progress:       reporter,
+		extractors:     make(map[string]*models.ExtractorStats),
+		durations:      make(map[string]time.Duration),
+	}
+}
+
+func (c *extractorStatsCollector) AfterInodeVisited(_ string) {
+	c.progress.Add(progress.PhaseWalking, 1)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.filesConsidered++
+}
+
+func (c *extractorStatsCollector) AfterExtractorRun(pluginName string, extractorstats *stats.AfterExtractorStats) {
+	if c.logOpenedFiles {
+		FileOpenedPrinter{}.AfterExtractorRun(pluginName, extractorstats)
+	}
+
+	c.progress.Add(progress.PhaseExtracting, 1)
+
+	pkgsFound := 0
+	if extractorstats.Inventory != nil {
+		pkgsFound = len(extractorstats.Inventory.Packages)
+	}
+	telemetry.RecordExtraction(
+		c.ctx,
+		pluginName,
+		filepath.Join(extractorstats.Root, extractorstats.Path),
+		extractorstats.Runtime,
+		pkgsFound,
+		extractorstats.Error,
+	)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	es := c.get(pluginName)
+	es.FilesMatched++
+	c.durations[pluginName] += extractorstats.Runtime
+
+	if extractorstats.Error != nil {
+		es.FilesErrored++
+		c.diagnostics = append(c.diagnostics, models.Diagnostic{
+			Kind:    models.DiagnosticExtractionError,
+			Path:    filepath.Join(extractorstats.Root, extractorstats.Path),
+			Source:  pluginName,
+			Message: extractorstats.Error.Error(),
+		})
+
+		return
+	}
+
+	es.FilesParsed++
+	es.PackagesFound += pkgsFound
+}
+
+// skipped records that the path was not scanned for the given reason
+func (c *extractorStatsCollector) skipped(path string, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.diagnostics = append(c.diagnostics, models.Diagnostic{
+		Kind:    models.DiagnosticSkippedFile,
+		Path:    path,
+		Message: reason,
+	})
+}
+
+// get returns the stats for the given extractor, creating them if needed.
+// The caller must hold c.mu
+func (c *extractorStatsCollector) get(name string) *models.ExtractorStats {
+	es, ok := c.extractors[name]
+	if !ok {
+		es = &models.ExtractorStats{Name: name}
+		c.extractors[name] = es
+	}
+
+	return es
+}
+
+// ScanStats returns a snapshot of the collected stats, with extractors sorted by name
+func (c *extractorStatsCollector) ScanStats() *models.ScanStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ss := &models.ScanStats{
+		FilesConsidered: c.filesConsidered,
+		Extractors:      make([]models.ExtractorStats, 0, len(c.extractors)),
+	}
+
+	for name, es := range c.extractors {
+		es := *es
+		es.DurationMs = c.durations[name].Milliseconds()
+		ss.Extractors = append(ss.Extractors, es)
+	}
+
+	slices.SortFunc(ss.Extractors, func(a, b models.ExtractorStats) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return ss
+}
+
+// Diagnostics returns the files that could not be extracted or were skipped,
+// sorted by path
+func (c *extractorStatsCollector) Diagnostics() []models.Diagnostic {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	diagnostics := slices.Clone(c.diagnostics)
+	slices.SortStableFunc(diagnostics, func(a, b models.Diagnostic) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return diagnostics
+}
@@ -0,0 +1,86 @@
+package osvscanner
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/inventory"
+	"github.com/google/osv-scalibr/stats"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func Test_extractorStatsCollector(t *testing.T) {
+	t.Parallel()
+
+	c := newExtractorStatsCollector(t.Context(), false, nil)
+
+	for range 5 {
+		c.AfterInodeVisited("")
+	}
+
+	c.AfterExtractorRun("javascript/packagelockjson", &stats.AfterExtractorStats{
+		Path:    "package-lock.json",
+		Runtime: 2 * time.Millisecond,
+		Inventory: &inventory.Inventory{
+			Packages: []*extractor.Package{{Name: "a"}, {Name: "b"}},
+		},
+	})
+	c.AfterExtractorRun("javascript/packagelockjson", &stats.AfterExtractorStats{
+		Path:    "nested/package-lock.json",
+		Runtime: 3 * time.Millisecond,
+		Error:   errors.New("unexpected end of JSON input"),
+	})
+	// runs that are quicker than a millisecond still add up
+	c.AfterExtractorRun("go/gomod", &stats.AfterExtractorStats{
+		Path:      "go.mod",
+		Runtime:   600 * time.Microsecond,
+		Inventory: &inventory.Inventory{},
+	})
+	c.AfterExtractorRun("go/gomod", &stats.AfterExtractorStats{
+		Path:      "tools/go.mod",
+		Runtime:   600 * time.Microsecond,
+		Inventory: &inventory.Inventory{},
+	})
+
+	want := &models.ScanStats{
+		FilesConsidered: 5,
+		Extractors: []models.ExtractorStats{
+			{
+				Name:          "go/gomod",
+				FilesMatched:  2,
+				FilesParsed:   2,
+				FilesErrored:  0,
+				PackagesFound: 0,
+				DurationMs:    1,
+			},
+			{
+				Name:          "javascript/packagelockjson",
+				FilesMatched:  2,
+				FilesParsed:   1,
+				FilesErrored:  1,
+				PackagesFound: 2,
+				DurationMs:    5,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, c.ScanStats()); diff != "" {
+		t.Errorf("ScanStats() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantDiagnostics := []models.Diagnostic{
+		{
+			Kind:    models.DiagnosticExtractionError,
+			Path:    "nested/package-lock.json",
+			Source:  "javascript/packagelockjson",
+			Message: "unexpected end of JSON input",
+		},
+	}
+
+	if diff := cmp.Diff(wantDiagnostics, c.Diagnostics()); diff != "" {
+		t.Errorf("Diagnostics() mismatch (-want +got):\n%s", diff)
+	}
+}
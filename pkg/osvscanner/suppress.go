@@ -0,0 +1,128 @@
+package osvscanner
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scalibr/semantic"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// suppressUnfixed soft-suppresses the vulnerability groups that have had no fix published
+// for more than the given number of days since they were published, so that they are still
+// reported but do not fail the scan. The days set by the config of each source are used
+// if days is zero, returning the number of groups that were suppressed.
+func suppressUnfixed(vulnResults *models.VulnerabilityResults, configManager *config.Manager, days int, now time.Time) int {
+	suppressed := 0
+
+	for i := range vulnResults.Results {
+		sourceDays := days
+		if sourceDays == 0 {
+			sourceDays = configManager.Get(vulnResults.Results[i].Source.Path).SuppressUnfixedAfterDays
+		}
+		if sourceDays <= 0 {
+			continue
+		}
+
+		for j := range vulnResults.Results[i].Packages {
+			pkg := &vulnResults.Results[i].Packages[j]
+
+			for k := range pkg.Groups {
+				group := &pkg.Groups[k]
+
+				var groupVulns []osvschema.Vulnerability
+				for _, vuln := range pkg.Vulnerabilities {
+					if slices.Contains(group.IDs, vuln.ID) {
+						groupVulns = append(groupVulns, vuln)
+					}
+				}
+
+				if len(groupVulns) == 0 || hasFix(groupVulns, pkg.Package) {
+					continue
+				}
+
+				published := earliestPublished(groupVulns)
+				if published.IsZero() {
+					continue
+				}
+
+				unfixedFor := int(now.Sub(published).Hours() / 24)
+				if unfixedFor <= sourceDays {
+					continue
+				}
+
+				group.Suppression = &models.Suppression{
+					Reason: fmt.Sprintf(
+						"no fix has been published in the %d days since it was disclosed on %s, which is more than the %d days allowed",
+						unfixedFor,
+						published.Format(time.DateOnly),
+						sourceDays,
+					),
+				}
+				suppressed++
+
+				cmdlogger.Infof("%s has been soft-suppressed because %s", group.IDs[0], group.Suppression.Reason)
+			}
+		}
+	}
+
+	return suppressed
+}
+
+// hasFix returns whether any of the vulnerabilities have been fixed in
+// a version of the package that is newer than its installed version
+func hasFix(vulns []osvschema.Vulnerability, pkg models.PackageInfo) bool {
+	ecosystem, _, _ := strings.Cut(pkg.Ecosystem, ":")
+	installed, parseErr := semantic.Parse(pkg.Version, ecosystem)
+
+	for _, vuln := range vulns {
+		for _, affected := range vuln.Affected {
+			affectedEcosystem, _, _ := strings.Cut(affected.Package.Ecosystem, ":")
+			if affected.Package.Name != pkg.Name || affectedEcosystem != ecosystem {
+				continue
+			}
+
+			for _, r := range affected.Ranges {
+				for _, event := range r.Events {
+					if event.Fixed == "" {
+						continue
+					}
+
+					// versions that cannot be compared are assumed to be fixed by any fix,
+					// as it is better to keep failing on a vulnerability than to suppress it wrongly
+					if parseErr != nil {
+						return true
+					}
+
+					if order, err := installed.CompareStr(event.Fixed); err != nil || order < 0 {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// earliestPublished returns when the first of the vulnerabilities was published,
+// or the zero time if none of them have a published time
+func earliestPublished(vulns []osvschema.Vulnerability) time.Time {
+	var earliest time.Time
+	for _, vuln := range vulns {
+		if vuln.Published.IsZero() {
+			continue
+		}
+
+		if earliest.IsZero() || vuln.Published.Before(earliest) {
+			earliest = vuln.Published
+		}
+	}
+
+	return earliest
+}
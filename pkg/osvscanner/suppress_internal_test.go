@@ -0,0 +1,160 @@
+package osvscanner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/v2/internal/config"
+	"github.com/google/osv-scanner/v2/pkg/models"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+func Test_suppressUnfixed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	affected := func(events ...osvschema.Event) []osvschema.Affected {
+		return []osvschema.Affected{{
+			Package: osvschema.Package{Name: "lodash", Ecosystem: "npm"},
+			Ranges:  []osvschema.Range{{Type: osvschema.RangeSemVer, Events: events}},
+		}}
+	}
+
+	results := func() models.VulnerabilityResults {
+		return models.VulnerabilityResults{
+			Results: []models.PackageSource{{
+				Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+				Packages: []models.PackageVulns{{
+					Package: models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+					Vulnerabilities: []osvschema.Vulnerability{
+						{
+							// no fix, published long ago
+							ID:        "GHSA-1",
+							Published: now.AddDate(0, 0, -200),
+							Affected:  affected(osvschema.Event{Introduced: "0"}),
+						},
+						{
+							// no fix, published recently
+							ID:        "GHSA-2",
+							Published: now.AddDate(0, 0, -10),
+							Affected:  affected(osvschema.Event{Introduced: "0"}),
+						},
+						{
+							// fixed in a newer version
+							ID:        "GHSA-3",
+							Published: now.AddDate(0, 0, -200),
+							Affected:  affected(osvschema.Event{Introduced: "0"}, osvschema.Event{Fixed: "4.17.21"}),
+						},
+						{
+							// only fixed in an older version, for a range that does not affect it
+							ID:        "GHSA-4",
+							Published: now.AddDate(0, 0, -200),
+							Affected:  affected(osvschema.Event{Introduced: "0"}, osvschema.Event{Fixed: "3.0.0"}),
+						},
+						{
+							// no published time
+							ID:       "GHSA-5",
+							Affected: affected(osvschema.Event{Introduced: "0"}),
+						},
+					},
+					Groups: []models.GroupInfo{
+						{IDs: []string{"GHSA-1"}},
+						{IDs: []string{"GHSA-2"}},
+						{IDs: []string{"GHSA-3"}},
+						{IDs: []string{"GHSA-4"}},
+						{IDs: []string{"GHSA-5"}},
+					},
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		days           int
+		configDays     int
+		wantSuppressed map[string]string
+	}{
+		{
+			name:           "not enabled",
+			wantSuppressed: map[string]string{},
+		},
+		{
+			name: "from flag",
+			days: 90,
+			wantSuppressed: map[string]string{
+				"GHSA-1": "no fix has been published in the 200 days since it was disclosed on 2024-11-13, which is more than the 90 days allowed",
+				"GHSA-4": "no fix has been published in the 200 days since it was disclosed on 2024-11-13, which is more than the 90 days allowed",
+			},
+		},
+		{
+			name:       "from config",
+			configDays: 5,
+			wantSuppressed: map[string]string{
+				"GHSA-1": "no fix has been published in the 200 days since it was disclosed on 2024-11-13, which is more than the 5 days allowed",
+				"GHSA-2": "no fix has been published in the 10 days since it was disclosed on 2025-05-22, which is more than the 5 days allowed",
+				"GHSA-4": "no fix has been published in the 200 days since it was disclosed on 2024-11-13, which is more than the 5 days allowed",
+			},
+		},
+		{
+			name:           "flag takes precedence over config",
+			days:           365,
+			configDays:     5,
+			wantSuppressed: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			configManager := config.Manager{
+				OverrideConfig: &config.Config{SuppressUnfixedAfterDays: tt.configDays},
+				ConfigMap:      make(map[string]config.Config),
+			}
+
+			got := results()
+			suppressed := suppressUnfixed(&got, &configManager, tt.days, now)
+
+			gotSuppressed := map[string]string{}
+			for _, group := range got.Results[0].Packages[0].Groups {
+				if group.Suppression != nil {
+					gotSuppressed[group.IDs[0]] = group.Suppression.Reason
+				}
+			}
+
+			if diff := cmp.Diff(tt.wantSuppressed, gotSuppressed); diff != "" {
+				t.Errorf("suppressUnfixed() suppressed mismatch (-want +got):\n%s", diff)
+			}
+			if suppressed != len(tt.wantSuppressed) {
+				t.Errorf("suppressUnfixed() = %d, want %d", suppressed, len(tt.wantSuppressed))
+			}
+		})
+	}
+}
+
+func Test_determineReturnErr_Suppressed(t *testing.T) {
+	t.Parallel()
+
+	results := models.VulnerabilityResults{
+		Results: []models.PackageSource{{
+			Source: models.SourceInfo{Path: "/path/to/package-lock.json", Type: models.SourceTypeProjectPackage},
+			Packages: []models.PackageVulns{{
+				Package:         models.PackageInfo{Name: "lodash", Version: "4.17.20", Ecosystem: "npm"},
+				Vulnerabilities: []osvschema.Vulnerability{{ID: "GHSA-1"}},
+				Groups: []models.GroupInfo{{
+					IDs:         []string{"GHSA-1"},
+					Suppression: &models.Suppression{Reason: "no fix"},
+				}},
+			}},
+		}},
+	}
+
+	configManager := config.Manager{ConfigMap: make(map[string]config.Config)}
+
+	if err := determineReturnErr(results, &configManager, config.SeverityThresholds{}, 0, false, false, false); err != nil {
+		t.Errorf("determineReturnErr() = %v, want nil", err)
+	}
+}
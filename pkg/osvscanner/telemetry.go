@@ -0,0 +1,39 @@
+package osvscanner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/osv-scanner/v2/internal/telemetry"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// scanFailure returns err if it is a failure of the scan, rather than
+// the scan reporting what it found
+func scanFailure(err error) error {
+	for _, found := range []error{
+		ErrVulnerabilitiesFound,
+		ErrIgnoredVulnerabilitiesFound,
+		ErrUncalledVulnerabilitiesFound,
+		ErrUnscannedPackagesFound,
+	} {
+		if errors.Is(err, found) {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// recordVulnerabilities records the number of vulnerabilities in the results,
+// counting each group of aliases once per package
+func recordVulnerabilities(ctx context.Context, results models.VulnerabilityResults) {
+	count := 0
+	for _, source := range results.Results {
+		for _, pkg := range source.Packages {
+			count += len(pkg.Groups)
+		}
+	}
+
+	telemetry.RecordVulnerabilities(ctx, count)
+}
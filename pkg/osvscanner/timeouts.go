@@ -0,0 +1,40 @@
+package osvscanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPhaseTimedOut is for when a phase of the scan takes longer than the timeout
+// given for it in ScannerActions, such as ScannerActions.QueryTimeout
+var ErrPhaseTimedOut = errors.New("scan phase timed out")
+
+// phaseContext returns the context for a phase of the scan, which is cancelled with
+// ErrPhaseTimedOut as its cause once the timeout has passed if there is one
+func phaseContext(ctx context.Context, phase string, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w: %s took longer than %s", ErrPhaseTimedOut, phase, timeout))
+}
+
+// phaseErr returns the reason that the phase was stopped if its context has been cancelled,
+// in place of the error returned by the phase, which is usually just context.DeadlineExceeded
+// or context.Canceled; cancellations of the scan itself are returned as the context's error.
+//
+// The results of a phase that was stopped are incomplete, so the reason is returned even
+// if the phase did not return an error itself, such as when failures are only logged.
+func phaseErr(ctx context.Context, err error) error {
+	if ctx.Err() == nil {
+		return err
+	}
+
+	if cause := context.Cause(ctx); errors.Is(cause, ErrPhaseTimedOut) {
+		return cause
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,156 @@
+package osvscanner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scalibr/purl"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/ossf/osv-schema/bindings/go/osvschema"
+)
+
+// hangingMatcher does not return until the context it is given is cancelled
+type hangingMatcher struct{}
+
+func (hangingMatcher) MatchVulnerabilities(ctx context.Context, _ []*extractor.Package) ([][]*osvschema.Vulnerability, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func Test_phaseErr(t *testing.T) {
+	t.Parallel()
+
+	errPhase := errors.New("phase failed")
+
+	t.Run("not_stopped", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := phaseContext(context.Background(), "querying", time.Hour)
+		defer cancel()
+
+		if err := phaseErr(ctx, nil); err != nil {
+			t.Errorf("phaseErr() = %v, want nil", err)
+		}
+
+		if err := phaseErr(ctx, errPhase); !errors.Is(err, errPhase) {
+			t.Errorf("phaseErr() = %v, want %v", err, errPhase)
+		}
+	})
+
+	t.Run("timed_out", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := phaseContext(context.Background(), "querying", time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		err := phaseErr(ctx, context.DeadlineExceeded)
+
+		if !errors.Is(err, ErrPhaseTimedOut) {
+			t.Fatalf("phaseErr() = %v, want %v", err, ErrPhaseTimedOut)
+		}
+
+		if want := "scan phase timed out: querying took longer than 1ns"; err.Error() != want {
+			t.Errorf("phaseErr() = %q, want %q", err, want)
+		}
+
+		// failures that are only logged still leave the results of the phase incomplete
+		if err := phaseErr(ctx, nil); !errors.Is(err, ErrPhaseTimedOut) {
+			t.Errorf("phaseErr() = %v, want %v", err, ErrPhaseTimedOut)
+		}
+	})
+
+	t.Run("scan_cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := phaseContext(parent, "querying", time.Hour)
+		defer cancel()
+		cancelParent()
+
+		if err := phaseErr(ctx, errPhase); !errors.Is(err, context.Canceled) {
+			t.Errorf("phaseErr() = %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func Test_makeVulnRequestWithMatcher_Timeout(t *testing.T) {
+	t.Parallel()
+
+	scanResults := results.ScanResults{
+		PackageScanResults: []imodels.PackageScanResult{
+			{
+				PackageInfo: imodels.FromInventory(&extractor.Package{
+					Name:     "lodash",
+					Version:  "4.17.20",
+					PURLType: purl.TypeNPM,
+				}),
+			},
+		},
+	}
+
+	ctx, cancel := phaseContext(context.Background(), "querying", 10*time.Millisecond)
+	defer cancel()
+
+	err := phaseErr(ctx, makeVulnRequestWithMatcher(ctx, &scanResults, hangingMatcher{}, nil))
+
+	if !errors.Is(err, ErrPhaseTimedOut) {
+		t.Errorf("makeVulnRequestWithMatcher() = %v, want %v", err, ErrPhaseTimedOut)
+	}
+}
+
+func TestDoScanContext_Stopped(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	lockfile := filepath.Join(dir, "go.mod")
+	if err := os.WriteFile(lockfile, []byte("module example.com/app\n\ngo 1.21\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context //nolint:containedctx // each test scans with a different context
+		timeout time.Duration
+		wantErr error
+	}{
+		{
+			name:    "cancelled",
+			ctx:     cancelled,
+			wantErr: context.Canceled,
+		},
+		{
+			name:    "extraction_timed_out",
+			ctx:     context.Background(),
+			timeout: time.Nanosecond,
+			wantErr: ErrPhaseTimedOut,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := DoScanContext(tt.ctx, ScannerActions{
+				LockfilePaths:       []string{lockfile},
+				CompareOffline:      true,
+				SkipVulnerabilities: true,
+				ExtractionTimeout:   tt.timeout,
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DoScanContext() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package osvscanner
 
 import (
+	"maps"
 	"path/filepath"
 	"slices"
 	"sort"
@@ -26,8 +27,10 @@ func buildVulnerabilityResults(
 	scanResults *results.ScanResults,
 ) models.VulnerabilityResults {
 	results := models.VulnerabilityResults{
-		Results:       []models.PackageSource{},
-		ImageMetadata: scanResults.ImageMetadata,
+		Results:           []models.PackageSource{},
+		ImageMetadata:     scanResults.ImageMetadata,
+		UnscannedPackages: scanResults.UnscannedPackages,
+		Diagnostics:       scanResults.Diagnostics,
 	}
 
 	type packageVulnsGroup struct {
@@ -59,8 +62,14 @@ func buildVulnerabilityResults(
 			pkg.Package.ImageOrigin = &models.ImageOriginDetails{
 				Index: psr.LayerDetails.Index,
 			}
+
+			if scanResults.ImageMetadata != nil && psr.LayerDetails.Index < len(scanResults.ImageMetadata.LayerMetadata) {
+				layer := scanResults.ImageMetadata.LayerMetadata[psr.LayerDetails.Index]
+				pkg.Package.ImageOrigin.InBaseImage = layer.BaseImageIndex != 0
+			}
 		}
 		pkg.DepGroups = p.DepGroups()
+		pkg.GoWorkspace = psr.GoWorkspace
 		configToUse := scanResults.ConfigManager.Get(p.Location())
 
 		if len(psr.Vulnerabilities) > 0 {
@@ -99,8 +108,13 @@ func buildVulnerabilityResults(
 			}
 			if len(actions.ScanLicensesAllowlist) > 0 {
 				pkg.Licenses = psr.Licenses
+				satisfiesFn := spdx.Satisfies
+				if actions.LicenseExpressions {
+					satisfiesFn = spdx.SatisfiesExpression
+				}
+
 				for _, license := range pkg.Licenses {
-					satisfies, err := spdx.Satisfies(license, actions.ScanLicensesAllowlist)
+					satisfies, err := satisfiesFn(license, actions.ScanLicensesAllowlist)
 
 					if err != nil {
 						cmdlogger.Errorf("license %s for package %s/%s/%s is invalid: %s", license, pkg.Package.Ecosystem, pkg.Package.Name, pkg.Package.Version, err)
@@ -165,6 +179,10 @@ func buildVulnerabilityResults(
 		results.ExperimentalAnalysisConfig.Licenses.Allowlist = allowlist
 	}
 
+	if len(actions.Labels) > 0 {
+		results.Labels = maps.Clone(actions.Labels)
+	}
+
 	return results
 }
 
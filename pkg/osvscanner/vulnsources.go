@@ -0,0 +1,85 @@
+package osvscanner
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/chainmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/localmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientinterfaces"
+	"github.com/google/osv-scanner/v2/internal/version"
+)
+
+const (
+	// VulnSourceOSV matches packages using the OSV.dev API
+	VulnSourceOSV = "osv"
+	// VulnSourceLocal matches packages using the local databases, along with any
+	// extra advisories that are layered on top of them
+	VulnSourceLocal = "local"
+	// VulnSourceFeedPrefix prefixes the directory of a feed of OSV advisories that packages
+	// are matched against, such as advisories that are only distributed internally or a
+	// clone of the GitHub Advisory Database, e.g. "feed:/path/to/advisory-database"
+	VulnSourceFeedPrefix = "feed:"
+)
+
+// newVulnMatcher returns the matcher for the sources of vulnerabilities of the scan, which
+// are chained together if there is more than one; the local databases are used by default
+// when scanning offline, and the OSV.dev API is used by default otherwise
+func newVulnMatcher(actions ScannerActions, transport *osvmatcher.RetryTransport) (clientinterfaces.VulnerabilityMatcher, error) {
+	names := actions.VulnerabilitySources
+	if len(names) == 0 {
+		names = []string{VulnSourceOSV}
+		if actions.CompareOffline {
+			names = []string{VulnSourceLocal}
+		}
+	}
+
+	sources := make([]chainmatcher.Source, 0, len(names))
+	for _, name := range names {
+		matcher, err := newVulnSource(name, actions, transport)
+		if err != nil {
+			return nil, err
+		}
+
+		sources = append(sources, chainmatcher.Source{Name: name, Matcher: matcher})
+	}
+
+	if len(sources) == 1 {
+		return sources[0].Matcher, nil
+	}
+
+	return &chainmatcher.ChainMatcher{Sources: sources}, nil
+}
+
+func newVulnSource(name string, actions ScannerActions, transport *osvmatcher.RetryTransport) (clientinterfaces.VulnerabilityMatcher, error) {
+	if dir, ok := strings.CutPrefix(name, VulnSourceFeedPrefix); ok {
+		if dir == "" {
+			return nil, errors.New("vulnerability source feed: must have a directory")
+		}
+
+		return localmatcher.NewDirMatcher([]string{dir})
+	}
+
+	switch name {
+	case VulnSourceOSV:
+		if actions.CompareOffline {
+			return nil, fmt.Errorf("vulnerability source %q cannot be used when scanning offline", name)
+		}
+
+		return &osvmatcher.OSVMatcher{
+			Client:              *osvmatcher.NewRetryingOSVClient(transport, ""),
+			InitialQueryTimeout: 5 * time.Minute,
+			Pipeline:            osvmatcher.DefaultPipelineConfig(),
+		}, nil
+	case VulnSourceLocal:
+		// the databases can always be kept up to date when the scan is not offline
+		download := actions.DownloadDatabases || !actions.CompareOffline
+
+		return localmatcher.NewLocalMatcher(actions.LocalDBPath, actions.LocalDBExtraPaths, "osv-scanner_scan/"+version.OSVVersion, download)
+	}
+
+	return nil, fmt.Errorf("unknown vulnerability source %q, must be one of %q, %q, or %q followed by a directory", name, VulnSourceOSV, VulnSourceLocal, VulnSourceFeedPrefix)
+}
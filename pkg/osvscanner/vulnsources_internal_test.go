@@ -0,0 +1,89 @@
+package osvscanner
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/chainmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/localmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientimpl/osvmatcher"
+	"github.com/google/osv-scanner/v2/internal/clients/clientinterfaces"
+)
+
+func Test_newVulnMatcher(t *testing.T) {
+	t.Parallel()
+
+	feedDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		actions ScannerActions
+		check   func(t *testing.T, matcher clientinterfaces.VulnerabilityMatcher)
+		wantErr bool
+	}{
+		{
+			name:    "online_by_default",
+			actions: ScannerActions{},
+			check: func(t *testing.T, matcher clientinterfaces.VulnerabilityMatcher) {
+				t.Helper()
+				if _, ok := matcher.(*osvmatcher.OSVMatcher); !ok {
+					t.Errorf("newVulnMatcher() = %T, want *osvmatcher.OSVMatcher", matcher)
+				}
+			},
+		},
+		{
+			name:    "local_databases_when_offline",
+			actions: ScannerActions{CompareOffline: true, LocalDBPath: t.TempDir()},
+			check: func(t *testing.T, matcher clientinterfaces.VulnerabilityMatcher) {
+				t.Helper()
+				if _, ok := matcher.(*localmatcher.LocalMatcher); !ok {
+					t.Errorf("newVulnMatcher() = %T, want *localmatcher.LocalMatcher", matcher)
+				}
+			},
+		},
+		{
+			name:    "chained_sources",
+			actions: ScannerActions{VulnerabilitySources: []string{"feed:" + feedDir, "osv"}},
+			check: func(t *testing.T, matcher clientinterfaces.VulnerabilityMatcher) {
+				t.Helper()
+				chain, ok := matcher.(*chainmatcher.ChainMatcher)
+				if !ok {
+					t.Fatalf("newVulnMatcher() = %T, want *chainmatcher.ChainMatcher", matcher)
+				}
+
+				if len(chain.Sources) != 2 || chain.Sources[0].Name != "feed:"+feedDir || chain.Sources[1].Name != "osv" {
+					t.Errorf("newVulnMatcher() sources = %v, want the feed followed by osv", chain.Sources)
+				}
+			},
+		},
+		{
+			name:    "osv_when_offline",
+			actions: ScannerActions{CompareOffline: true, VulnerabilitySources: []string{"osv"}},
+			wantErr: true,
+		},
+		{
+			name:    "feed_without_directory",
+			actions: ScannerActions{VulnerabilitySources: []string{"feed:"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown_source",
+			actions: ScannerActions{VulnerabilitySources: []string{"ghsa"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matcher, err := newVulnMatcher(tt.actions, &osvmatcher.RetryTransport{Config: osvmatcher.DefaultRetryConfig()})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newVulnMatcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.check != nil {
+				tt.check(t, matcher)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package osvscanner
+
+import (
+	"context"
+
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/cmdlogger"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/kubernetes"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+// takeWorkloadImages removes the images that Kubernetes workloads run out of the scan results,
+// as they are references to images rather than packages that can be checked for vulnerabilities
+func takeWorkloadImages(scanResults *results.ScanResults) []imodels.PackageInfo {
+	return takeImageReferences[kubernetes.Metadata](scanResults)
+}
+
+// scanWorkloadImages scans the packages of the images that Kubernetes workloads run, which are
+// located by the manifest and the workload so that vulnerabilities are reported under the
+// workload that runs the image, e.g. "k8s/web.yaml#Deployment/web"
+//
+// Images that cannot be pulled are skipped and recorded as diagnostics, so that an unavailable
+// registry does not prevent the rest of the workloads from being scanned.
+func scanWorkloadImages(ctx context.Context, workloadImages []imodels.PackageInfo, accessors ExternalAccessors, actions ScannerActions) ([]imodels.PackageScanResult, []models.Diagnostic) {
+	var packages []imodels.PackageScanResult
+	var diagnostics []models.Diagnostic
+
+	// the same image is often run by many workloads, so each image is only scanned once
+	scanned := map[string][]*extractor.Package{}
+	// a workload can run the same image in more than one container
+	seen := map[string]bool{}
+
+	for _, workloadImage := range workloadImages {
+		// images that are not scanned once the scan has been stopped would only fail
+		if ctx.Err() != nil {
+			break
+		}
+
+		md, ok := workloadImage.Metadata.(*kubernetes.Metadata)
+		if !ok {
+			continue
+		}
+
+		location := workloadImage.Location() + "#" + md.Workload
+		if seen[location+"@"+md.Image] {
+			continue
+		}
+		seen[location+"@"+md.Image] = true
+
+		invs, ok := scanned[md.Image]
+		if !ok {
+			var err error
+			invs, err = scanRemoteImage(ctx, md.Image, md.Digest, "", accessors, actions)
+			if err != nil {
+				cmdlogger.Errorf("Failed to scan image %q of %s: %s", md.Image, location, err)
+				diagnostics = append(diagnostics, models.Diagnostic{
+					Kind:    models.DiagnosticPartialResult,
+					Path:    location,
+					Source:  md.Image,
+					Message: "failed to scan image: " + err.Error(),
+				})
+			}
+
+			scanned[md.Image] = invs
+		}
+
+		for _, inv := range invs {
+			pkg := *inv
+			pkg.Locations = append([]string{location}, inv.Locations...)
+
+			packages = append(packages, imodels.PackageScanResult{
+				PackageInfo: imodels.FromInventory(&pkg),
+			})
+		}
+	}
+
+	return packages, diagnostics
+}
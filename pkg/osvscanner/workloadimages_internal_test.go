@@ -0,0 +1,99 @@
+package osvscanner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/osv-scalibr/extractor"
+	"github.com/google/osv-scanner/v2/internal/imodels"
+	"github.com/google/osv-scanner/v2/internal/imodels/results"
+	"github.com/google/osv-scanner/v2/internal/scalibrextract/cicd/kubernetes"
+	"github.com/google/osv-scanner/v2/pkg/models"
+)
+
+func Test_takeWorkloadImages(t *testing.T) {
+	t.Parallel()
+
+	packages := []*extractor.Package{
+		{
+			Name:      "nginx",
+			Version:   "1.25-alpine",
+			Locations: []string{"/path/to/k8s/web.yaml"},
+			Plugins:   []string{kubernetes.Name},
+			Metadata:  &kubernetes.Metadata{Image: "nginx:1.25-alpine", Workload: "Deployment/web", Container: "proxy"},
+		},
+		{
+			Name:      "lodash",
+			Version:   "4.17.21",
+			PURLType:  "npm",
+			Locations: []string{"/path/to/package-lock.json"},
+		},
+	}
+
+	scanResults := results.ScanResults{}
+	for _, pkg := range packages {
+		scanResults.PackageScanResults = append(scanResults.PackageScanResults, imodels.PackageScanResult{
+			PackageInfo: imodels.FromInventory(pkg),
+		})
+	}
+
+	workloadImages := takeWorkloadImages(&scanResults)
+
+	gotImages := make([]string, 0, len(workloadImages))
+	for _, img := range workloadImages {
+		gotImages = append(gotImages, img.Metadata.(*kubernetes.Metadata).Workload+"="+img.Metadata.(*kubernetes.Metadata).Image)
+	}
+
+	if diff := cmp.Diff([]string{"Deployment/web=nginx:1.25-alpine"}, gotImages); diff != "" {
+		t.Errorf("takeWorkloadImages() images mismatch (-want +got):\n%s", diff)
+	}
+
+	gotPackages := make([]string, 0, len(scanResults.PackageScanResults))
+	for _, psr := range scanResults.PackageScanResults {
+		gotPackages = append(gotPackages, psr.PackageInfo.Name())
+	}
+
+	if diff := cmp.Diff([]string{"lodash"}, gotPackages); diff != "" {
+		t.Errorf("takeWorkloadImages() remaining packages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_scanWorkloadImages_Unresolvable(t *testing.T) {
+	t.Parallel()
+
+	newImage := func(workload string, container string) imodels.PackageInfo {
+		return imodels.FromInventory(&extractor.Package{
+			Name:      "Not A Valid Image",
+			Locations: []string{"/path/to/k8s/web.yaml"},
+			Plugins:   []string{kubernetes.Name},
+			Metadata:  &kubernetes.Metadata{Image: "Not A Valid Image", Workload: workload, Container: container},
+		})
+	}
+
+	workloadImages := []imodels.PackageInfo{
+		newImage("Deployment/web", "app"),
+		// the same image run by another container of the workload is only reported once
+		newImage("Deployment/web", "sidecar"),
+		newImage("Job/migrate", "migrate"),
+	}
+
+	got, diagnostics := scanWorkloadImages(context.Background(), workloadImages, ExternalAccessors{}, ScannerActions{})
+	if len(got) != 0 {
+		t.Errorf("scanWorkloadImages() = %v, want no packages", got)
+	}
+
+	// the image is only attempted once, even though it is run by more than one workload
+	want := []models.Diagnostic{
+		{
+			Kind:   models.DiagnosticPartialResult,
+			Path:   "/path/to/k8s/web.yaml#Deployment/web",
+			Source: "Not A Valid Image",
+		},
+	}
+
+	if diff := cmp.Diff(want, diagnostics, cmpopts.IgnoreFields(models.Diagnostic{}, "Message")); diff != "" {
+		t.Errorf("scanWorkloadImages() diagnostics mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,8 @@
+// Package resultspb contains the protocol buffer bindings for the results of a scan,
+// as written by the "proto" output format.
+//
+// The schema is defined in results.proto, which is the source of truth for
+// consumers in other languages.
+package resultspb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative results.proto
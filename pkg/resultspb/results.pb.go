@@ -0,0 +1,1259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: results.proto
+
+package resultspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SourceType int32
+
+const (
+	SourceType_SOURCE_TYPE_UNSPECIFIED SourceType = 0
+	SourceType_SOURCE_TYPE_UNKNOWN     SourceType = 1
+	SourceType_SOURCE_TYPE_OS          SourceType = 2
+	SourceType_SOURCE_TYPE_LOCKFILE    SourceType = 3
+	SourceType_SOURCE_TYPE_ARTIFACT    SourceType = 4
+	SourceType_SOURCE_TYPE_SBOM        SourceType = 5
+	SourceType_SOURCE_TYPE_GIT         SourceType = 6
+)
+
+// Enum value maps for SourceType.
+var (
+	SourceType_name = map[int32]string{
+		0: "SOURCE_TYPE_UNSPECIFIED",
+		1: "SOURCE_TYPE_UNKNOWN",
+		2: "SOURCE_TYPE_OS",
+		3: "SOURCE_TYPE_LOCKFILE",
+		4: "SOURCE_TYPE_ARTIFACT",
+		5: "SOURCE_TYPE_SBOM",
+		6: "SOURCE_TYPE_GIT",
+	}
+	SourceType_value = map[string]int32{
+		"SOURCE_TYPE_UNSPECIFIED": 0,
+		"SOURCE_TYPE_UNKNOWN":     1,
+		"SOURCE_TYPE_OS":          2,
+		"SOURCE_TYPE_LOCKFILE":    3,
+		"SOURCE_TYPE_ARTIFACT":    4,
+		"SOURCE_TYPE_SBOM":        5,
+		"SOURCE_TYPE_GIT":         6,
+	}
+)
+
+func (x SourceType) Enum() *SourceType {
+	p := new(SourceType)
+	*p = x
+	return p
+}
+
+func (x SourceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SourceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_results_proto_enumTypes[0].Descriptor()
+}
+
+func (SourceType) Type() protoreflect.EnumType {
+	return &file_results_proto_enumTypes[0]
+}
+
+func (x SourceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SourceType.Descriptor instead.
+func (SourceType) EnumDescriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{0}
+}
+
+type Results struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Results            []*PackageSource       `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	UnscannedPackages  []*UnscannedPackage    `protobuf:"bytes,2,rep,name=unscanned_packages,json=unscannedPackages,proto3" json:"unscanned_packages,omitempty"`
+	LicenseSummary     []*LicenseCount        `protobuf:"bytes,3,rep,name=license_summary,json=licenseSummary,proto3" json:"license_summary,omitempty"`
+	RepositoryMetadata *RepositoryMetadata    `protobuf:"bytes,4,opt,name=repository_metadata,json=repositoryMetadata,proto3" json:"repository_metadata,omitempty"`
+	RiskScore          *RiskScore             `protobuf:"bytes,5,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	Labels             map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Results) Reset() {
+	*x = Results{}
+	mi := &file_results_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Results) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Results) ProtoMessage() {}
+
+func (x *Results) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Results.ProtoReflect.Descriptor instead.
+func (*Results) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Results) GetResults() []*PackageSource {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *Results) GetUnscannedPackages() []*UnscannedPackage {
+	if x != nil {
+		return x.UnscannedPackages
+	}
+	return nil
+}
+
+func (x *Results) GetLicenseSummary() []*LicenseCount {
+	if x != nil {
+		return x.LicenseSummary
+	}
+	return nil
+}
+
+func (x *Results) GetRepositoryMetadata() *RepositoryMetadata {
+	if x != nil {
+		return x.RepositoryMetadata
+	}
+	return nil
+}
+
+func (x *Results) GetRiskScore() *RiskScore {
+	if x != nil {
+		return x.RiskScore
+	}
+	return nil
+}
+
+func (x *Results) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type SourceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Type          SourceType             `protobuf:"varint,2,opt,name=type,proto3,enum=osvscanner.results.v1.SourceType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourceInfo) Reset() {
+	*x = SourceInfo{}
+	mi := &file_results_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceInfo) ProtoMessage() {}
+
+func (x *SourceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceInfo.ProtoReflect.Descriptor instead.
+func (*SourceInfo) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SourceInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SourceInfo) GetType() SourceType {
+	if x != nil {
+		return x.Type
+	}
+	return SourceType_SOURCE_TYPE_UNSPECIFIED
+}
+
+type PackageSource struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        *SourceInfo            `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Packages      []*PackageVulns        `protobuf:"bytes,2,rep,name=packages,proto3" json:"packages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageSource) Reset() {
+	*x = PackageSource{}
+	mi := &file_results_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageSource) ProtoMessage() {}
+
+func (x *PackageSource) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageSource.ProtoReflect.Descriptor instead.
+func (*PackageSource) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PackageSource) GetSource() *SourceInfo {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *PackageSource) GetPackages() []*PackageVulns {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+type PackageInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	OsPackageName string                 `protobuf:"bytes,2,opt,name=os_package_name,json=osPackageName,proto3" json:"os_package_name,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Ecosystem     string                 `protobuf:"bytes,4,opt,name=ecosystem,proto3" json:"ecosystem,omitempty"`
+	Commit        string                 `protobuf:"bytes,5,opt,name=commit,proto3" json:"commit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PackageInfo) Reset() {
+	*x = PackageInfo{}
+	mi := &file_results_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageInfo) ProtoMessage() {}
+
+func (x *PackageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageInfo.ProtoReflect.Descriptor instead.
+func (*PackageInfo) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PackageInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetOsPackageName() string {
+	if x != nil {
+		return x.OsPackageName
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetEcosystem() string {
+	if x != nil {
+		return x.Ecosystem
+	}
+	return ""
+}
+
+func (x *PackageInfo) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+type PackageVulns struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Package           *PackageInfo           `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
+	DependencyGroups  []string               `protobuf:"bytes,2,rep,name=dependency_groups,json=dependencyGroups,proto3" json:"dependency_groups,omitempty"`
+	Vulnerabilities   []*Vulnerability       `protobuf:"bytes,3,rep,name=vulnerabilities,proto3" json:"vulnerabilities,omitempty"`
+	Groups            []*Group               `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
+	Licenses          []string               `protobuf:"bytes,5,rep,name=licenses,proto3" json:"licenses,omitempty"`
+	LicenseViolations []string               `protobuf:"bytes,6,rep,name=license_violations,json=licenseViolations,proto3" json:"license_violations,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PackageVulns) Reset() {
+	*x = PackageVulns{}
+	mi := &file_results_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PackageVulns) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageVulns) ProtoMessage() {}
+
+func (x *PackageVulns) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageVulns.ProtoReflect.Descriptor instead.
+func (*PackageVulns) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PackageVulns) GetPackage() *PackageInfo {
+	if x != nil {
+		return x.Package
+	}
+	return nil
+}
+
+func (x *PackageVulns) GetDependencyGroups() []string {
+	if x != nil {
+		return x.DependencyGroups
+	}
+	return nil
+}
+
+func (x *PackageVulns) GetVulnerabilities() []*Vulnerability {
+	if x != nil {
+		return x.Vulnerabilities
+	}
+	return nil
+}
+
+func (x *PackageVulns) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *PackageVulns) GetLicenses() []string {
+	if x != nil {
+		return x.Licenses
+	}
+	return nil
+}
+
+func (x *PackageVulns) GetLicenseViolations() []string {
+	if x != nil {
+		return x.LicenseViolations
+	}
+	return nil
+}
+
+type Vulnerability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Aliases       []string               `protobuf:"bytes,2,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Related       []string               `protobuf:"bytes,3,rep,name=related,proto3" json:"related,omitempty"`
+	Summary       string                 `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"`
+	Details       string                 `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	Modified      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=modified,proto3" json:"modified,omitempty"`
+	Published     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=published,proto3" json:"published,omitempty"`
+	Severity      []*Severity            `protobuf:"bytes,8,rep,name=severity,proto3" json:"severity,omitempty"`
+	OsvJson       []byte                 `protobuf:"bytes,9,opt,name=osv_json,json=osvJson,proto3" json:"osv_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vulnerability) Reset() {
+	*x = Vulnerability{}
+	mi := &file_results_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vulnerability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vulnerability) ProtoMessage() {}
+
+func (x *Vulnerability) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vulnerability.ProtoReflect.Descriptor instead.
+func (*Vulnerability) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Vulnerability) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetRelated() []string {
+	if x != nil {
+		return x.Related
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetPublished() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Published
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetSeverity() []*Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetOsvJson() []byte {
+	if x != nil {
+		return x.OsvJson
+	}
+	return nil
+}
+
+type Severity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Score         string                 `protobuf:"bytes,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Severity) Reset() {
+	*x = Severity{}
+	mi := &file_results_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Severity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Severity) ProtoMessage() {}
+
+func (x *Severity) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Severity.ProtoReflect.Descriptor instead.
+func (*Severity) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Severity) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Severity) GetScore() string {
+	if x != nil {
+		return x.Score
+	}
+	return ""
+}
+
+type Group struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Ids               []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Aliases           []string               `protobuf:"bytes,2,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	MaxSeverity       string                 `protobuf:"bytes,3,opt,name=max_severity,json=maxSeverity,proto3" json:"max_severity,omitempty"`
+	SeverityLabel     string                 `protobuf:"bytes,4,opt,name=severity_label,json=severityLabel,proto3" json:"severity_label,omitempty"`
+	Called            bool                   `protobuf:"varint,5,opt,name=called,proto3" json:"called,omitempty"`
+	Unimportant       bool                   `protobuf:"varint,6,opt,name=unimportant,proto3" json:"unimportant,omitempty"`
+	Epss              *EPSSScore             `protobuf:"bytes,7,opt,name=epss,proto3" json:"epss,omitempty"`
+	Kev               *KEVEntry              `protobuf:"bytes,8,opt,name=kev,proto3" json:"kev,omitempty"`
+	SuppressionReason string                 `protobuf:"bytes,9,opt,name=suppression_reason,json=suppressionReason,proto3" json:"suppression_reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_results_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Group) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *Group) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Group) GetMaxSeverity() string {
+	if x != nil {
+		return x.MaxSeverity
+	}
+	return ""
+}
+
+func (x *Group) GetSeverityLabel() string {
+	if x != nil {
+		return x.SeverityLabel
+	}
+	return ""
+}
+
+func (x *Group) GetCalled() bool {
+	if x != nil {
+		return x.Called
+	}
+	return false
+}
+
+func (x *Group) GetUnimportant() bool {
+	if x != nil {
+		return x.Unimportant
+	}
+	return false
+}
+
+func (x *Group) GetEpss() *EPSSScore {
+	if x != nil {
+		return x.Epss
+	}
+	return nil
+}
+
+func (x *Group) GetKev() *KEVEntry {
+	if x != nil {
+		return x.Kev
+	}
+	return nil
+}
+
+func (x *Group) GetSuppressionReason() string {
+	if x != nil {
+		return x.SuppressionReason
+	}
+	return ""
+}
+
+type EPSSScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cve           string                 `protobuf:"bytes,1,opt,name=cve,proto3" json:"cve,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Percentile    float64                `protobuf:"fixed64,3,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	Date          string                 `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EPSSScore) Reset() {
+	*x = EPSSScore{}
+	mi := &file_results_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EPSSScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EPSSScore) ProtoMessage() {}
+
+func (x *EPSSScore) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EPSSScore.ProtoReflect.Descriptor instead.
+func (*EPSSScore) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EPSSScore) GetCve() string {
+	if x != nil {
+		return x.Cve
+	}
+	return ""
+}
+
+func (x *EPSSScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *EPSSScore) GetPercentile() float64 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+func (x *EPSSScore) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type KEVEntry struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Cve                        string                 `protobuf:"bytes,1,opt,name=cve,proto3" json:"cve,omitempty"`
+	DateAdded                  string                 `protobuf:"bytes,2,opt,name=date_added,json=dateAdded,proto3" json:"date_added,omitempty"`
+	DueDate                    string                 `protobuf:"bytes,3,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	RequiredAction             string                 `protobuf:"bytes,4,opt,name=required_action,json=requiredAction,proto3" json:"required_action,omitempty"`
+	KnownRansomwareCampaignUse bool                   `protobuf:"varint,5,opt,name=known_ransomware_campaign_use,json=knownRansomwareCampaignUse,proto3" json:"known_ransomware_campaign_use,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *KEVEntry) Reset() {
+	*x = KEVEntry{}
+	mi := &file_results_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KEVEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KEVEntry) ProtoMessage() {}
+
+func (x *KEVEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KEVEntry.ProtoReflect.Descriptor instead.
+func (*KEVEntry) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *KEVEntry) GetCve() string {
+	if x != nil {
+		return x.Cve
+	}
+	return ""
+}
+
+func (x *KEVEntry) GetDateAdded() string {
+	if x != nil {
+		return x.DateAdded
+	}
+	return ""
+}
+
+func (x *KEVEntry) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+func (x *KEVEntry) GetRequiredAction() string {
+	if x != nil {
+		return x.RequiredAction
+	}
+	return ""
+}
+
+func (x *KEVEntry) GetKnownRansomwareCampaignUse() bool {
+	if x != nil {
+		return x.KnownRansomwareCampaignUse
+	}
+	return false
+}
+
+type UnscannedPackage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Package       *PackageInfo           `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
+	Source        *SourceInfo            `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnscannedPackage) Reset() {
+	*x = UnscannedPackage{}
+	mi := &file_results_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnscannedPackage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnscannedPackage) ProtoMessage() {}
+
+func (x *UnscannedPackage) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnscannedPackage.ProtoReflect.Descriptor instead.
+func (*UnscannedPackage) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UnscannedPackage) GetPackage() *PackageInfo {
+	if x != nil {
+		return x.Package
+	}
+	return nil
+}
+
+func (x *UnscannedPackage) GetSource() *SourceInfo {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *UnscannedPackage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UnscannedPackage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type LicenseCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LicenseCount) Reset() {
+	*x = LicenseCount{}
+	mi := &file_results_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LicenseCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LicenseCount) ProtoMessage() {}
+
+func (x *LicenseCount) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LicenseCount.ProtoReflect.Descriptor instead.
+func (*LicenseCount) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LicenseCount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LicenseCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type RepositoryMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Ref           string                 `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Commit        string                 `protobuf:"bytes,3,opt,name=commit,proto3" json:"commit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepositoryMetadata) Reset() {
+	*x = RepositoryMetadata{}
+	mi := &file_results_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepositoryMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepositoryMetadata) ProtoMessage() {}
+
+func (x *RepositoryMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepositoryMetadata.ProtoReflect.Descriptor instead.
+func (*RepositoryMetadata) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RepositoryMetadata) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *RepositoryMetadata) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *RepositoryMetadata) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+type RiskScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Score         float64                `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	MaxScore      float64                `protobuf:"fixed64,2,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RiskScore) Reset() {
+	*x = RiskScore{}
+	mi := &file_results_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RiskScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RiskScore) ProtoMessage() {}
+
+func (x *RiskScore) ProtoReflect() protoreflect.Message {
+	mi := &file_results_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RiskScore.ProtoReflect.Descriptor instead.
+func (*RiskScore) Descriptor() ([]byte, []int) {
+	return file_results_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RiskScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RiskScore) GetMaxScore() float64 {
+	if x != nil {
+		return x.MaxScore
+	}
+	return 0
+}
+
+var File_results_proto protoreflect.FileDescriptor
+
+const file_results_proto_rawDesc = "" +
+	"\n" +
+	"\rresults.proto\x12\x15osvscanner.results.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8b\x04\n" +
+	"\aResults\x12>\n" +
+	"\aresults\x18\x01 \x03(\v2$.osvscanner.results.v1.PackageSourceR\aresults\x12V\n" +
+	"\x12unscanned_packages\x18\x02 \x03(\v2'.osvscanner.results.v1.UnscannedPackageR\x11unscannedPackages\x12L\n" +
+	"\x0flicense_summary\x18\x03 \x03(\v2#.osvscanner.results.v1.LicenseCountR\x0elicenseSummary\x12Z\n" +
+	"\x13repository_metadata\x18\x04 \x01(\v2).osvscanner.results.v1.RepositoryMetadataR\x12repositoryMetadata\x12?\n" +
+	"\n" +
+	"risk_score\x18\x05 \x01(\v2 .osvscanner.results.v1.RiskScoreR\triskScore\x12B\n" +
+	"\x06labels\x18\x06 \x03(\v2*.osvscanner.results.v1.Results.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"W\n" +
+	"\n" +
+	"SourceInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x125\n" +
+	"\x04type\x18\x02 \x01(\x0e2!.osvscanner.results.v1.SourceTypeR\x04type\"\x8b\x01\n" +
+	"\rPackageSource\x129\n" +
+	"\x06source\x18\x01 \x01(\v2!.osvscanner.results.v1.SourceInfoR\x06source\x12?\n" +
+	"\bpackages\x18\x02 \x03(\v2#.osvscanner.results.v1.PackageVulnsR\bpackages\"\x99\x01\n" +
+	"\vPackageInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12&\n" +
+	"\x0fos_package_name\x18\x02 \x01(\tR\rosPackageName\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1c\n" +
+	"\tecosystem\x18\x04 \x01(\tR\tecosystem\x12\x16\n" +
+	"\x06commit\x18\x05 \x01(\tR\x06commit\"\xca\x02\n" +
+	"\fPackageVulns\x12<\n" +
+	"\apackage\x18\x01 \x01(\v2\".osvscanner.results.v1.PackageInfoR\apackage\x12+\n" +
+	"\x11dependency_groups\x18\x02 \x03(\tR\x10dependencyGroups\x12N\n" +
+	"\x0fvulnerabilities\x18\x03 \x03(\v2$.osvscanner.results.v1.VulnerabilityR\x0fvulnerabilities\x124\n" +
+	"\x06groups\x18\x04 \x03(\v2\x1c.osvscanner.results.v1.GroupR\x06groups\x12\x1a\n" +
+	"\blicenses\x18\x05 \x03(\tR\blicenses\x12-\n" +
+	"\x12license_violations\x18\x06 \x03(\tR\x11licenseViolations\"\xd1\x02\n" +
+	"\rVulnerability\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aaliases\x18\x02 \x03(\tR\aaliases\x12\x18\n" +
+	"\arelated\x18\x03 \x03(\tR\arelated\x12\x18\n" +
+	"\asummary\x18\x04 \x01(\tR\asummary\x12\x18\n" +
+	"\adetails\x18\x05 \x01(\tR\adetails\x126\n" +
+	"\bmodified\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bmodified\x128\n" +
+	"\tpublished\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tpublished\x12;\n" +
+	"\bseverity\x18\b \x03(\v2\x1f.osvscanner.results.v1.SeverityR\bseverity\x12\x19\n" +
+	"\bosv_json\x18\t \x01(\fR\aosvJson\"4\n" +
+	"\bSeverity\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\tR\x05score\"\xcf\x02\n" +
+	"\x05Group\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x18\n" +
+	"\aaliases\x18\x02 \x03(\tR\aaliases\x12!\n" +
+	"\fmax_severity\x18\x03 \x01(\tR\vmaxSeverity\x12%\n" +
+	"\x0eseverity_label\x18\x04 \x01(\tR\rseverityLabel\x12\x16\n" +
+	"\x06called\x18\x05 \x01(\bR\x06called\x12 \n" +
+	"\vunimportant\x18\x06 \x01(\bR\vunimportant\x124\n" +
+	"\x04epss\x18\a \x01(\v2 .osvscanner.results.v1.EPSSScoreR\x04epss\x121\n" +
+	"\x03kev\x18\b \x01(\v2\x1f.osvscanner.results.v1.KEVEntryR\x03kev\x12-\n" +
+	"\x12suppression_reason\x18\t \x01(\tR\x11suppressionReason\"g\n" +
+	"\tEPSSScore\x12\x10\n" +
+	"\x03cve\x18\x01 \x01(\tR\x03cve\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x1e\n" +
+	"\n" +
+	"percentile\x18\x03 \x01(\x01R\n" +
+	"percentile\x12\x12\n" +
+	"\x04date\x18\x04 \x01(\tR\x04date\"\xc2\x01\n" +
+	"\bKEVEntry\x12\x10\n" +
+	"\x03cve\x18\x01 \x01(\tR\x03cve\x12\x1d\n" +
+	"\n" +
+	"date_added\x18\x02 \x01(\tR\tdateAdded\x12\x19\n" +
+	"\bdue_date\x18\x03 \x01(\tR\adueDate\x12'\n" +
+	"\x0frequired_action\x18\x04 \x01(\tR\x0erequiredAction\x12A\n" +
+	"\x1dknown_ransomware_campaign_use\x18\x05 \x01(\bR\x1aknownRansomwareCampaignUse\"\xb9\x01\n" +
+	"\x10UnscannedPackage\x12<\n" +
+	"\apackage\x18\x01 \x01(\v2\".osvscanner.results.v1.PackageInfoR\apackage\x129\n" +
+	"\x06source\x18\x02 \x01(\v2!.osvscanner.results.v1.SourceInfoR\x06source\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"8\n" +
+	"\fLicenseCount\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"P\n" +
+	"\x12RepositoryMetadata\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x10\n" +
+	"\x03ref\x18\x02 \x01(\tR\x03ref\x12\x16\n" +
+	"\x06commit\x18\x03 \x01(\tR\x06commit\">\n" +
+	"\tRiskScore\x12\x14\n" +
+	"\x05score\x18\x01 \x01(\x01R\x05score\x12\x1b\n" +
+	"\tmax_score\x18\x02 \x01(\x01R\bmaxScore*\xb5\x01\n" +
+	"\n" +
+	"SourceType\x12\x1b\n" +
+	"\x17SOURCE_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SOURCE_TYPE_UNKNOWN\x10\x01\x12\x12\n" +
+	"\x0eSOURCE_TYPE_OS\x10\x02\x12\x18\n" +
+	"\x14SOURCE_TYPE_LOCKFILE\x10\x03\x12\x18\n" +
+	"\x14SOURCE_TYPE_ARTIFACT\x10\x04\x12\x14\n" +
+	"\x10SOURCE_TYPE_SBOM\x10\x05\x12\x13\n" +
+	"\x0fSOURCE_TYPE_GIT\x10\x06B0Z.github.com/google/osv-scanner/v2/pkg/resultspbb\x06proto3"
+
+var (
+	file_results_proto_rawDescOnce sync.Once
+	file_results_proto_rawDescData []byte
+)
+
+func file_results_proto_rawDescGZIP() []byte {
+	file_results_proto_rawDescOnce.Do(func() {
+		file_results_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_results_proto_rawDesc), len(file_results_proto_rawDesc)))
+	})
+	return file_results_proto_rawDescData
+}
+
+var file_results_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_results_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_results_proto_goTypes = []any{
+	(SourceType)(0),               // 0: osvscanner.results.v1.SourceType
+	(*Results)(nil),               // 1: osvscanner.results.v1.Results
+	(*SourceInfo)(nil),            // 2: osvscanner.results.v1.SourceInfo
+	(*PackageSource)(nil),         // 3: osvscanner.results.v1.PackageSource
+	(*PackageInfo)(nil),           // 4: osvscanner.results.v1.PackageInfo
+	(*PackageVulns)(nil),          // 5: osvscanner.results.v1.PackageVulns
+	(*Vulnerability)(nil),         // 6: osvscanner.results.v1.Vulnerability
+	(*Severity)(nil),              // 7: osvscanner.results.v1.Severity
+	(*Group)(nil),                 // 8: osvscanner.results.v1.Group
+	(*EPSSScore)(nil),             // 9: osvscanner.results.v1.EPSSScore
+	(*KEVEntry)(nil),              // 10: osvscanner.results.v1.KEVEntry
+	(*UnscannedPackage)(nil),      // 11: osvscanner.results.v1.UnscannedPackage
+	(*LicenseCount)(nil),          // 12: osvscanner.results.v1.LicenseCount
+	(*RepositoryMetadata)(nil),    // 13: osvscanner.results.v1.RepositoryMetadata
+	(*RiskScore)(nil),             // 14: osvscanner.results.v1.RiskScore
+	nil,                           // 15: osvscanner.results.v1.Results.LabelsEntry
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+}
+var file_results_proto_depIdxs = []int32{
+	3,  // 0: osvscanner.results.v1.Results.results:type_name -> osvscanner.results.v1.PackageSource
+	11, // 1: osvscanner.results.v1.Results.unscanned_packages:type_name -> osvscanner.results.v1.UnscannedPackage
+	12, // 2: osvscanner.results.v1.Results.license_summary:type_name -> osvscanner.results.v1.LicenseCount
+	13, // 3: osvscanner.results.v1.Results.repository_metadata:type_name -> osvscanner.results.v1.RepositoryMetadata
+	14, // 4: osvscanner.results.v1.Results.risk_score:type_name -> osvscanner.results.v1.RiskScore
+	15, // 5: osvscanner.results.v1.Results.labels:type_name -> osvscanner.results.v1.Results.LabelsEntry
+	0,  // 6: osvscanner.results.v1.SourceInfo.type:type_name -> osvscanner.results.v1.SourceType
+	2,  // 7: osvscanner.results.v1.PackageSource.source:type_name -> osvscanner.results.v1.SourceInfo
+	5,  // 8: osvscanner.results.v1.PackageSource.packages:type_name -> osvscanner.results.v1.PackageVulns
+	4,  // 9: osvscanner.results.v1.PackageVulns.package:type_name -> osvscanner.results.v1.PackageInfo
+	6,  // 10: osvscanner.results.v1.PackageVulns.vulnerabilities:type_name -> osvscanner.results.v1.Vulnerability
+	8,  // 11: osvscanner.results.v1.PackageVulns.groups:type_name -> osvscanner.results.v1.Group
+	16, // 12: osvscanner.results.v1.Vulnerability.modified:type_name -> google.protobuf.Timestamp
+	16, // 13: osvscanner.results.v1.Vulnerability.published:type_name -> google.protobuf.Timestamp
+	7,  // 14: osvscanner.results.v1.Vulnerability.severity:type_name -> osvscanner.results.v1.Severity
+	9,  // 15: osvscanner.results.v1.Group.epss:type_name -> osvscanner.results.v1.EPSSScore
+	10, // 16: osvscanner.results.v1.Group.kev:type_name -> osvscanner.results.v1.KEVEntry
+	4,  // 17: osvscanner.results.v1.UnscannedPackage.package:type_name -> osvscanner.results.v1.PackageInfo
+	2,  // 18: osvscanner.results.v1.UnscannedPackage.source:type_name -> osvscanner.results.v1.SourceInfo
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_results_proto_init() }
+func file_results_proto_init() {
+	if File_results_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_results_proto_rawDesc), len(file_results_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_results_proto_goTypes,
+		DependencyIndexes: file_results_proto_depIdxs,
+		EnumInfos:         file_results_proto_enumTypes,
+		MessageInfos:      file_results_proto_msgTypes,
+	}.Build()
+	File_results_proto = out.File
+	file_results_proto_goTypes = nil
+	file_results_proto_depIdxs = nil
+}
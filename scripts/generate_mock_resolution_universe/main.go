@@ -358,7 +358,7 @@ func main() {
 				})
 			}
 		}
-		if io, err := lockfile.GetReadWriter(filename); err == nil {
+		if io, err := lockfile.GetReadWriter(filename, ""); err == nil {
 			if remediation.SupportsInPlace(io) {
 				group.Go(func() error {
 					err := doInPlace(cl, io, filename)
@@ -0,0 +1,13 @@
+#!/usr/bin/env bash
+
+# Updates the snapshot of the Known Exploited Vulnerabilities catalog of CISA
+# that is bundled with osv-scanner for flagging exploited vulnerabilities offline
+
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+curl -fsSL https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json \
+  | jq . > internal/kev/catalog.json
+
+echo "Updated KEV catalog to version $(jq -r .catalogVersion internal/kev/catalog.json)"